@@ -7,13 +7,23 @@ const (
 	LabelCacheEnabled     = "cache.kyverno.io/enabled"
 	LabelCertManagedBy    = "cert.kyverno.io/managed-by"
 	LabelCleanupTtl       = "cleanup.kyverno.io/ttl"
+	LabelMemberCluster    = "kyverno.io/member-cluster"
+	LabelOCISource        = "kyverno.io/oci-source"
+	LabelPolicySet        = "kyverno.io/policyset"
 	LabelWebhookManagedBy = "webhook.kyverno.io/managed-by"
 	// Well known annotations
-	AnnotationAutogenControllers = "pod-policies.kyverno.io/autogen-controllers"
-	AnnotationImageVerify        = "kyverno.io/verify-images"
-	AnnotationPolicyCategory     = "policies.kyverno.io/category"
-	AnnotationPolicyScored       = "policies.kyverno.io/scored"
-	AnnotationPolicySeverity     = "policies.kyverno.io/severity"
+	AnnotationAutogenControllers  = "pod-policies.kyverno.io/autogen-controllers"
+	AnnotationExceptionApprovedBy = "exceptions.kyverno.io/approved-by"
+	AnnotationImageVerify         = "kyverno.io/verify-images"
+	AnnotationLastApplied         = "kyverno.io/last-applied"
+	AnnotationOCISource           = "kyverno.io/oci-source"
+	AnnotationPolicyCategory      = "policies.kyverno.io/category"
+	AnnotationPolicyDescription   = "policies.kyverno.io/description"
+	AnnotationPolicyLibrary       = "policies.kyverno.io/library-version"
+	AnnotationPolicyScored        = "policies.kyverno.io/scored"
+	AnnotationPolicySeverity      = "policies.kyverno.io/severity"
+	AnnotationPolicySubject       = "policies.kyverno.io/subject"
+	AnnotationPolicyTitle         = "policies.kyverno.io/title"
 	// Well known values
 	ValueKyvernoApp        = "kyverno"
 	ValueTtlDateTimeLayout = "2006-01-02T150405Z"
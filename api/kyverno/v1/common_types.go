@@ -3,6 +3,10 @@ package v1
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
 	"github.com/sigstore/k8s-manifest-sigstore/pkg/k8smanifest"
@@ -85,8 +89,49 @@ type ContextEntry struct {
 	// details.
 	ImageRegistry *ImageRegistry `json:"imageRegistry,omitempty" yaml:"imageRegistry,omitempty"`
 
+	// CloudMetadata defines a request to the metadata service of the cloud provider
+	// Kyverno runs on, for example the region or the tags of the cluster. It can only
+	// be used in cluster policies.
+	// +optional
+	CloudMetadata *CloudMetadata `json:"cloudMetadata,omitempty" yaml:"cloudMetadata,omitempty"`
+
 	// Variable defines an arbitrary JMESPath context variable that can be defined inline.
 	Variable *Variable `json:"variable,omitempty" yaml:"variable,omitempty"`
+
+	// Timeout is the maximum duration allowed to load an APICall or ImageRegistry context entry.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// FailurePolicy defines how errors loading an APICall or ImageRegistry context entry are handled.
+	// When set to Ignore, errors (including timeouts and calls skipped because the dependency keeps failing)
+	// result in the default value for the context entry instead of a rule error.
+	// Allowed values are Ignore or Fail. Defaults to Fail.
+	// +optional
+	FailurePolicy *FailurePolicyType `json:"failurePolicy,omitempty" yaml:"failurePolicy,omitempty"`
+
+	// Required defines whether the data of a ConfigMap or APICall context entry must exist.
+	// When set to false, a missing ConfigMap or a not found response from an APICall sets the context entry
+	// to its default value instead of failing the rule. Defaults to true.
+	// +optional
+	Required *bool `json:"required,omitempty" yaml:"required,omitempty"`
+
+	// Default is an optional arbitrary JSON object used as the context entry value when its data
+	// could not be loaded and the entry is not required, or its failure policy is Ignore.
+	// +optional
+	Default *apiextv1.JSON `json:"default,omitempty" yaml:"default,omitempty"`
+}
+
+// IsRequired returns true if the context entry data must exist
+func (c *ContextEntry) IsRequired() bool {
+	return c.Required == nil || *c.Required
+}
+
+// GetFailurePolicy returns the failure policy to be applied when loading the context entry
+func (c *ContextEntry) GetFailurePolicy() FailurePolicyType {
+	if c.FailurePolicy == nil {
+		return Fail
+	}
+	return *c.FailurePolicy
 }
 
 // Variable defines an arbitrary JMESPath context variable that can be defined inline.
@@ -124,6 +169,28 @@ type ImageRegistry struct {
 	ImageRegistryCredentials *ImageRegistryCredentials `json:"imageRegistryCredentials,omitempty" yaml:"imageRegistryCredentials,omitempty"`
 }
 
+// CloudProvider is the cloud provider whose metadata is resolved by a context entry.
+// +kubebuilder:validation:Enum=AWS;GCP;Azure
+type CloudProvider string
+
+const (
+	CloudProviderAWS   CloudProvider = "AWS"
+	CloudProviderGCP   CloudProvider = "GCP"
+	CloudProviderAzure CloudProvider = "Azure"
+)
+
+// CloudMetadata defines a request to the metadata service of the cloud provider
+// Kyverno runs on.
+type CloudMetadata struct {
+	// Provider is the cloud provider, one of AWS, GCP or Azure.
+	Provider CloudProvider `json:"provider" yaml:"provider"`
+
+	// JMESPath is an optional JSON Match Expression that can be used to
+	// transform the metadata document returned by the provider.
+	// +optional
+	JMESPath string `json:"jmesPath,omitempty" yaml:"jmesPath,omitempty"`
+}
+
 // ConfigMapReference refers to a ConfigMap
 type ConfigMapReference struct {
 	// Name is the ConfigMap name.
@@ -161,6 +228,22 @@ type APICall struct {
 	// of deployments across all namespaces.
 	// +kubebuilder:validation:Optional
 	JMESPath string `json:"jmesPath,omitempty" yaml:"jmesPath,omitempty"`
+
+	// PageSize enables the chunked retrieval of lists from the Kubernetes API server.
+	// When set, the list is retrieved in pages of at most PageSize items using the
+	// `limit` and `continue` query parameters, and the items of all pages are merged.
+	// This bounds the size of each response when listing large collections, for example
+	// when a foreach iterates over all the resources of a kind in background scans.
+	// Only applies to GET requests with a URLPath.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	PageSize int64 `json:"pageSize,omitempty" yaml:"pageSize,omitempty"`
+
+	// MaxItems is the maximum number of items retrieved by a paginated call.
+	// The call fails when the list contains more items. Only applies when PageSize is set.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	MaxItems int64 `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
 }
 
 type ServiceCall struct {
@@ -172,6 +255,24 @@ type ServiceCall struct {
 	// the server certificate.
 	// +kubebuilder:validation:Optional
 	CABundle string `json:"caBundle" yaml:"caBundle"`
+
+	// Headers is a list of optional HTTP headers to be included in the request.
+	// Header values can reference secrets resolved at runtime, using
+	// `vault://{path}#{field}` for HashiCorp Vault or `k8s://{namespace}/{name}#{key}`
+	// for Kubernetes secrets, secret references can only be used in cluster policies.
+	// The Kyverno service account token is only sent to in-cluster services,
+	// and not when an Authorization header is provided.
+	// +kubebuilder:validation:Optional
+	Headers []HTTPHeader `json:"headers,omitempty" yaml:"headers,omitempty"`
+}
+
+// HTTPHeader is a HTTP header sent with a service call.
+type HTTPHeader struct {
+	// Key is the header key
+	Key string `json:"key" yaml:"key"`
+
+	// Value is the header value
+	Value string `json:"value" yaml:"value"`
 }
 
 // Method is a HTTP request type.
@@ -305,6 +406,21 @@ type Mutation struct {
 	// ForEach applies mutation rules to a list of sub-elements by creating a context for each entry in the list and looping over it to apply the specified logic.
 	// +optional
 	ForEachMutation []ForEachMutation `json:"foreach,omitempty" yaml:"foreach,omitempty"`
+
+	// DryRun computes the changes of the rule without applying them. The patches that would
+	// have been applied are reported in the rule response and surfaced as admission warnings.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty" yaml:"dryRun,omitempty"`
+
+	// Audit evaluates the rule against the existing resources during background scans. The resources
+	// that would be changed by the mutation are reported with a fail result, they are not modified.
+	// +optional
+	Audit bool `json:"audit,omitempty" yaml:"audit,omitempty"`
+
+	// ResolveDigest replaces image tags with the digests they resolve to in the registry,
+	// without requiring the images to be signed.
+	// +optional
+	ResolveDigest *ResolveDigest `json:"resolveDigest,omitempty" yaml:"resolveDigest,omitempty"`
 }
 
 func (m *Mutation) GetPatchStrategicMerge() apiextensions.JSON {
@@ -315,6 +431,43 @@ func (m *Mutation) SetPatchStrategicMerge(in apiextensions.JSON) {
 	m.RawPatchStrategicMerge = ToJSON(in)
 }
 
+// ResolveDigest pins images to the digests their tags resolve to.
+type ResolveDigest struct {
+	// Registries is the list of registries whose images are resolved, wildcards are supported.
+	// Images from all registries are resolved when empty.
+	// +optional
+	Registries []string `json:"registries,omitempty" yaml:"registries,omitempty"`
+
+	// ImageRegistryCredentials provides credentials used to access the registries.
+	// +optional
+	ImageRegistryCredentials *ImageRegistryCredentials `json:"imageRegistryCredentials,omitempty" yaml:"imageRegistryCredentials,omitempty"`
+
+	// CacheTTL is the duration a resolved digest is cached for. Defaults to 5m, 0s disables caching.
+	// +optional
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty" yaml:"cacheTTL,omitempty"`
+
+	// FailurePolicy defines how errors resolving a digest are handled. When set to Ignore, images
+	// whose digest can't be resolved are left unchanged. Allowed values are Ignore or Fail. Defaults to Fail.
+	// +optional
+	FailurePolicy *FailurePolicyType `json:"failurePolicy,omitempty" yaml:"failurePolicy,omitempty"`
+}
+
+// GetCacheTTL returns the duration a resolved digest is cached for
+func (r *ResolveDigest) GetCacheTTL() time.Duration {
+	if r.CacheTTL == nil {
+		return 5 * time.Minute
+	}
+	return r.CacheTTL.Duration
+}
+
+// GetFailurePolicy returns the failure policy applied when a digest can't be resolved
+func (r *ResolveDigest) GetFailurePolicy() FailurePolicyType {
+	if r.FailurePolicy == nil {
+		return Fail
+	}
+	return *r.FailurePolicy
+}
+
 // ForEachMutation applies mutation rules to a list of sub-elements by creating a context for each entry in the list and looping over it to apply the specified logic.
 type ForEachMutation struct {
 	// List specifies a JMESPath expression that results in one or more elements
@@ -367,6 +520,29 @@ type Validation struct {
 	// +optional
 	Message string `json:"message,omitempty" yaml:"message,omitempty"`
 
+	// MessageTemplate specifies a multi-line message replacing the message of failed rule results.
+	// Variables are substituted and line breaks are preserved in admission denials.
+	// +optional
+	MessageTemplate string `json:"messageTemplate,omitempty" yaml:"messageTemplate,omitempty"`
+
+	// RemediationURL links to documentation describing how to fix resources failing the rule.
+	// It is added to admission denials and policy report results.
+	// +optional
+	RemediationURL string `json:"remediationUrl,omitempty" yaml:"remediationUrl,omitempty"`
+
+	// FailureAction overrides the validationFailureAction of the policy, including its namespace overrides,
+	// for this rule. It allows a policy to mix rules blocking admission requests with rules that are only reported.
+	// Allowed values are Audit or Enforce.
+	// +optional
+	// +kubebuilder:validation:Enum=Audit;Enforce
+	FailureAction *ValidationFailureAction `json:"failureAction,omitempty" yaml:"failureAction,omitempty"`
+
+	// Severity overrides the severity of the rule results in policy reports, the severity is taken
+	// from the policies.kyverno.io/severity annotation of the policy when not set.
+	// +optional
+	// +kubebuilder:validation:Enum=critical;high;medium;low;info
+	Severity string `json:"severity,omitempty" yaml:"severity,omitempty"`
+
 	// Manifest specifies conditions for manifest verification
 	// +optional
 	Manifests *Manifests `json:"manifests,omitempty" yaml:"manifests,omitempty"`
@@ -380,7 +556,8 @@ type Validation struct {
 	RawPattern *apiextv1.JSON `json:"pattern,omitempty" yaml:"pattern,omitempty"`
 
 	// AnyPattern specifies list of validation patterns. At least one of the patterns
-	// must be satisfied for the validation rule to succeed.
+	// must be satisfied for the validation rule to succeed. When used with pattern,
+	// the resource must satisfy the pattern and at least one of the patterns.
 	// +optional
 	RawAnyPattern *apiextv1.JSON `json:"anyPattern,omitempty" yaml:"anyPattern,omitempty"`
 
@@ -396,6 +573,31 @@ type Validation struct {
 	// CEL allows validation checks using the Common Expression Language (https://kubernetes.io/docs/reference/using-api/cel/).
 	// +optional
 	CEL *CEL `json:"cel,omitempty" yaml:"cel,omitempty"`
+
+	// Immutable denies updates to selected fields of a resource, either always, during
+	// configured time windows or once the existing resource has matching labels.
+	// +optional
+	Immutable *Immutable `json:"immutable,omitempty" yaml:"immutable,omitempty"`
+
+	// ImageAllowList checks the container images of the resource against ImageAllowList resources.
+	// +optional
+	ImageAllowList *ImageAllowListValidation `json:"imageAllowList,omitempty" yaml:"imageAllowList,omitempty"`
+
+	// Baseline records the values of a field observed across the cluster in a ConfigMap
+	// and, once switched to enforce mode, rejects values that were not recorded.
+	// +optional
+	Baseline *Baseline `json:"baseline,omitempty" yaml:"baseline,omitempty"`
+
+	// Rego evaluates an embedded Rego module against the admission request.
+	// This is an experimental feature meant to ease migrations from OPA.
+	// +optional
+	Rego *Rego `json:"rego,omitempty" yaml:"rego,omitempty"`
+
+	// ServerMetadata controls the metadata populated by the API server (managedFields, resourceVersion, generation,
+	// uid, creationTimestamp and selfLink) in pattern evaluation. Strip removes it from the resource and the old
+	// resource before patterns are evaluated, Preserve evaluates patterns against it. Defaults to Strip.
+	// +optional
+	ServerMetadata ServerMetadataMode `json:"serverMetadata,omitempty" yaml:"serverMetadata,omitempty"`
 }
 
 // PodSecurity applies exemptions for Kubernetes Pod Security admission
@@ -485,6 +687,92 @@ func (c *CEL) GetParamRef() v1alpha1.ParamRef {
 	return *c.ParamRef
 }
 
+// Immutable denies updates to selected fields of a resource.
+// When both windows and selector are omitted the fields are always immutable, otherwise the fields
+// are immutable when the request time falls in one of the windows or the existing resource matches the selector.
+type Immutable struct {
+	// Fields are JMESPath expressions, relative to the resource, selecting the fields that cannot be updated
+	// (e.g. `spec.replicas`).
+	Fields []string `json:"fields" yaml:"fields"`
+
+	// Windows are the time windows during which the fields are immutable.
+	// +optional
+	Windows []TimeWindow `json:"windows,omitempty" yaml:"windows,omitempty"`
+
+	// Selector makes the fields immutable when the labels of the existing resource match
+	// (e.g. freeze fields while an `incident-mode` label is set).
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
+}
+
+// TimeWindow defines a time interval, start and end are RFC 3339 timestamps and support variables.
+type TimeWindow struct {
+	// Start is the beginning of the time window (inclusive).
+	Start string `json:"start" yaml:"start"`
+
+	// End is the end of the time window (exclusive).
+	End string `json:"end" yaml:"end"`
+}
+
+// ImageAllowListValidation checks the container images of a resource against ImageAllowList resources.
+type ImageAllowListValidation struct {
+	// Names are the names of the ImageAllowList resources, an image is allowed when at least one of the lists allows it.
+	Names []string `json:"names" yaml:"names"`
+}
+
+// BaselineMode is the mode of a baseline validation.
+// +kubebuilder:validation:Enum=Record;Enforce
+type BaselineMode string
+
+const (
+	// BaselineRecord passes resources, the values observed on existing resources are recorded in the baseline ConfigMap.
+	BaselineRecord BaselineMode = "Record"
+	// BaselineEnforce fails resources with values that are not recorded in the baseline ConfigMap.
+	BaselineEnforce BaselineMode = "Enforce"
+)
+
+// Baseline learns the values of a field observed across the cluster, then enforces them.
+// In Record mode the rule always passes and the background controller periodically adds the values
+// observed on existing resources to the ConfigMap. In Enforce mode the values are checked against the ConfigMap.
+type Baseline struct {
+	// Mode is either Record or Enforce.
+	Mode BaselineMode `json:"mode" yaml:"mode"`
+
+	// JMESPath selects the value, or the list of values, observed in the resource (e.g. `spec.containers[].image`).
+	JMESPath string `json:"jmesPath" yaml:"jmesPath"`
+
+	// ConfigMap is the ConfigMap holding the recorded values, stored as a JSON array under the `values` key.
+	ConfigMap ConfigMapReference `json:"configMap" yaml:"configMap"`
+}
+
+// Rego evaluates a Rego module against the `input` document, which holds the admission request under `input.request`.
+// The query produces the violation messages, either strings or objects with a `msg` field,
+// the rule fails when at least one violation is produced.
+type Rego struct {
+	// Module is the source of the Rego module.
+	Module string `json:"module" yaml:"module"`
+
+	// Query is the query producing the violations, it defaults to the `deny` rule of the module package (e.g. `data.kubernetes.deny`).
+	// +optional
+	Query string `json:"query,omitempty" yaml:"query,omitempty"`
+}
+
+// ServerMetadataMode controls the metadata populated by the API server in pattern evaluation.
+// +kubebuilder:validation:Enum=Strip;Preserve
+type ServerMetadataMode string
+
+const (
+	// ServerMetadataStrip removes the server populated metadata before patterns are evaluated.
+	ServerMetadataStrip ServerMetadataMode = "Strip"
+	// ServerMetadataPreserve evaluates patterns against the server populated metadata.
+	ServerMetadataPreserve ServerMetadataMode = "Preserve"
+)
+
+// StripServerMetadata returns true when the server populated metadata must be removed before patterns are evaluated
+func (in *Validation) StripServerMetadata() bool {
+	return in.ServerMetadata != ServerMetadataPreserve
+}
+
 // DeserializeAnyPattern deserialize apiextensions.JSON to []interface{}
 func (in *Validation) DeserializeAnyPattern() ([]interface{}, error) {
 	anyPattern := in.GetAnyPattern()
@@ -549,6 +837,12 @@ func (d *Deny) SetAnyAllConditions(in apiextensions.JSON) {
 	d.RawAnyAllConditions = ToJSON(in)
 }
 
+// GetConditions returns the deny conditions normalized as any/all blocks
+func (d *Deny) GetConditions() (AnyAllConditions, error) {
+	conditions, errs := ParseConditions(field.NewPath("conditions"), d.GetAnyAllConditions())
+	return conditions, errs.ToAggregate()
+}
+
 // ForEachValidation applies validate rules to a list of sub-elements by creating a context for each entry in the list and looping over it to apply the specified logic.
 type ForEachValidation struct {
 	// List specifies a JMESPath expression that results in one or more elements
@@ -577,7 +871,8 @@ type ForEachValidation struct {
 	RawPattern *apiextv1.JSON `json:"pattern,omitempty" yaml:"pattern,omitempty"`
 
 	// AnyPattern specifies list of validation patterns. At least one of the patterns
-	// must be satisfied for the validation rule to succeed.
+	// must be satisfied for the validation rule to succeed. When used with pattern,
+	// the resource must satisfy the pattern and at least one of the patterns.
 	// +optional
 	RawAnyPattern *apiextv1.JSON `json:"anyPattern,omitempty" yaml:"anyPattern,omitempty"`
 
@@ -611,6 +906,17 @@ type Generation struct {
 	// ResourceSpec contains information to select the resource.
 	ResourceSpec `json:",omitempty" yaml:",omitempty"`
 
+	// Namespaces is a list of target namespaces, the resource is generated in each of them.
+	// At most one of Namespace, Namespaces or NamespaceSelector can be specified.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+
+	// NamespaceSelector selects the target namespaces by label, the resource is generated
+	// in each matching namespace.
+	// At most one of Namespace, Namespaces or NamespaceSelector can be specified.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty" yaml:"namespaceSelector,omitempty"`
+
 	// Synchronize controls if generated resources should be kept in-sync with their source resource.
 	// If Synchronize is set to "true" changes to generated resources will be overwritten with resource
 	// data from Data or the resource specified in the Clone declaration.
@@ -618,12 +924,32 @@ type Generation struct {
 	// +optional
 	Synchronize bool `json:"synchronize,omitempty" yaml:"synchronize,omitempty"`
 
+	// Cascade adds a finalizer to the trigger resource so that its deletion is blocked until the
+	// generated resources are deleted. Requires synchronize to be enabled and the background controller
+	// to be allowed to update the trigger resource.
+	// Optional. Defaults to "false" if not specified.
+	// +optional
+	Cascade bool `json:"cascade,omitempty" yaml:"cascade,omitempty"`
+
+	// AllowClusterScopedTarget allows a namespaced policy to generate a cluster-scoped resource from the
+	// resources of its namespace. The resource must be generated from data with synchronize enabled and its
+	// name must contain the namespace of the trigger, e.g. using the request.namespace variable, so that
+	// policies from different namespaces can't manage the same resource.
+	// Optional. Defaults to "false" if not specified.
+	// +optional
+	AllowClusterScopedTarget bool `json:"allowClusterScopedTarget,omitempty" yaml:"allowClusterScopedTarget,omitempty"`
+
 	// Data provides the resource declaration used to populate each generated resource.
 	// At most one of Data or Clone must be specified. If neither are provided, the generated
 	// resource will be created with default data only.
 	// +optional
 	RawData *apiextv1.JSON `json:"data,omitempty" yaml:"data,omitempty"`
 
+	// DataOverride merges a JSON override read from an annotation of the target namespace into Data,
+	// giving namespace owners a limited control over the generated resources.
+	// +optional
+	DataOverride *DataOverride `json:"dataOverride,omitempty" yaml:"dataOverride,omitempty"`
+
 	// Clone specifies the source resource used to populate each generated resource.
 	// At most one of Data or Clone can be specified. If neither are provided, the generated
 	// resource will be created with default data only.
@@ -635,6 +961,42 @@ type Generation struct {
 	CloneList CloneList `json:"cloneList,omitempty" yaml:"cloneList,omitempty"`
 }
 
+// DataOverride declares where the per-namespace override of the generated data is read from
+// and what it is allowed to change.
+type DataOverride struct {
+	// Annotation is the key of the target namespace annotation holding the override.
+	// The override is a JSON object merged into Data following the JSON merge patch semantics (RFC 7386).
+	Annotation string `json:"annotation" yaml:"annotation"`
+
+	// AllowedPaths are the dot separated paths of the fields the override can set, e.g. spec.hard.
+	// Defaults to spec.
+	// +optional
+	AllowedPaths []string `json:"allowedPaths,omitempty" yaml:"allowedPaths,omitempty"`
+
+	// MaxSize is the maximum size of the override in bytes. Defaults to 4096.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxSize *int `json:"maxSize,omitempty" yaml:"maxSize,omitempty"`
+}
+
+const defaultDataOverrideMaxSize = 4096
+
+// GetAllowedPaths returns the paths of the fields the override can set
+func (o *DataOverride) GetAllowedPaths() []string {
+	if len(o.AllowedPaths) == 0 {
+		return []string{"spec"}
+	}
+	return o.AllowedPaths
+}
+
+// GetMaxSize returns the maximum size of the override in bytes
+func (o *DataOverride) GetMaxSize() int {
+	if o.MaxSize == nil {
+		return defaultDataOverrideMaxSize
+	}
+	return *o.MaxSize
+}
+
 type CloneList struct {
 	// Namespace specifies source resource namespace.
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
@@ -655,18 +1017,62 @@ func (g *Generation) Validate(path *field.Path, namespaced bool, policyNamespace
 		}
 	}
 
+	targets := 0
+	if g.GetNamespace() != "" {
+		targets++
+	}
+	if len(g.Namespaces) != 0 {
+		targets++
+	}
+	if g.NamespaceSelector != nil {
+		targets++
+	}
+	if targets > 1 {
+		errs = append(errs, field.Forbidden(path.Child("generate").Child("namespace"), "only one of namespace, namespaces or namespaceSelector can be specified"))
+	}
+	if g.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(g.NamespaceSelector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("generate").Child("namespaceSelector"), g.NamespaceSelector, err.Error()))
+		}
+	}
+
 	if g.GetKind() != "" {
 		if !clusterResources.Has(g.GetAPIVersion() + "/" + g.GetKind()) {
-			if g.GetNamespace() == "" {
+			if targets == 0 {
 				errs = append(errs, field.Forbidden(path.Child("generate").Child("namespace"), "target namespace must be set for a namespaced resource"))
 			}
 		} else {
-			if g.GetNamespace() != "" {
+			if targets != 0 {
 				errs = append(errs, field.Forbidden(path.Child("generate").Child("namespace"), "target namespace must not be set for a cluster-wide resource"))
 			}
 		}
 	}
 
+	if g.Cascade && !g.Synchronize {
+		errs = append(errs, field.Forbidden(path.Child("generate").Child("cascade"), "cascade requires synchronize to be enabled"))
+	}
+
+	if g.DataOverride != nil {
+		overridePath := path.Child("generate").Child("dataOverride")
+		if g.RawData == nil {
+			errs = append(errs, field.Forbidden(overridePath, "dataOverride requires data to be specified"))
+		}
+		if targets == 0 {
+			errs = append(errs, field.Forbidden(overridePath, "dataOverride requires the target namespace to be set"))
+		}
+		if g.DataOverride.Annotation == "" {
+			errs = append(errs, field.Required(overridePath.Child("annotation"), "annotation is required"))
+		}
+		for i, allowedPath := range g.DataOverride.AllowedPaths {
+			segments := strings.Split(allowedPath, ".")
+			if slices.Contains(segments, "") {
+				errs = append(errs, field.Invalid(overridePath.Child("allowedPaths").Index(i), allowedPath, "path must not contain empty segments"))
+			} else if segments[0] == "apiVersion" || segments[0] == "kind" || segments[0] == "metadata" {
+				errs = append(errs, field.Forbidden(overridePath.Child("allowedPaths").Index(i), "apiVersion, kind and metadata can't be overridden"))
+			}
+		}
+	}
+
 	generateType, _ := g.GetTypeAndSync()
 	if generateType == Data {
 		return errs
@@ -746,7 +1152,14 @@ func (g *Generation) SetData(in apiextensions.JSON) {
 func (g *Generation) validateNamespacedTargetsScope(clusterResources sets.Set[string], policyNamespace string) error {
 	target := g.ResourceSpec
 	if clusterResources.Has(target.GetAPIVersion() + "/" + target.GetKind()) {
-		return fmt.Errorf("the target must be a namespaced resource: %v/%v", target.GetAPIVersion(), target.GetKind())
+		if !g.AllowClusterScopedTarget {
+			return fmt.Errorf("the target must be a namespaced resource: %v/%v, set allowClusterScopedTarget to generate cluster-scoped resources", target.GetAPIVersion(), target.GetKind())
+		}
+		return g.validateClusterScopedTarget()
+	}
+
+	if g.HasMultipleNamespaces() {
+		return fmt.Errorf("a namespaced policy cannot generate resources in multiple namespaces")
 	}
 
 	if g.GetNamespace() != policyNamespace {
@@ -761,6 +1174,23 @@ func (g *Generation) validateNamespacedTargetsScope(clusterResources sets.Set[st
 	return nil
 }
 
+// regexNamespaceVariable matches the variables resolving to the namespace of the trigger
+var regexNamespaceVariable = regexp.MustCompile(`\{\{\s*request\.(namespace|object\.metadata\.namespace)\s*\}\}`)
+
+// validateClusterScopedTarget checks the safeguards of cluster-scoped resources generated by namespaced policies
+func (g *Generation) validateClusterScopedTarget() error {
+	if g.Clone.Name != "" || len(g.CloneList.Kinds) != 0 {
+		return fmt.Errorf("a namespaced policy can only generate cluster-scoped resources from data")
+	}
+	if !g.Synchronize {
+		return fmt.Errorf("a namespaced policy requires synchronize to be enabled to generate cluster-scoped resources")
+	}
+	if !regexNamespaceVariable.MatchString(g.GetName()) {
+		return fmt.Errorf("the name of a cluster-scoped resource generated by a namespaced policy must contain the namespace of the trigger, e.g. {{ request.namespace }}-%v", g.GetName())
+	}
+	return nil
+}
+
 type GenerateType string
 
 const (
@@ -768,6 +1198,11 @@ const (
 	Clone GenerateType = "Clone"
 )
 
+// HasMultipleNamespaces returns true if the target namespaces are given by a list or a selector
+func (g *Generation) HasMultipleNamespaces() bool {
+	return len(g.Namespaces) != 0 || g.NamespaceSelector != nil
+}
+
 func (g *Generation) GetTypeAndSync() (GenerateType, bool) {
 	if g.RawData != nil {
 		return Data, g.Synchronize
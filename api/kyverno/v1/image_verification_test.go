@@ -3,8 +3,10 @@ package v1
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -72,6 +74,26 @@ func Test_ImageVerification(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "negative max signature age",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				MaxSignatureAge: &metav1.Duration{Duration: -time.Hour},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(path.Child("maxSignatureAge"), "-1h0m0s", "maxSignatureAge must be a positive duration"),
+				}
+			},
+		},
+		{
+			name: "valid max signature age",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				MaxSignatureAge: &metav1.Duration{Duration: 24 * time.Hour},
+				VerifyTagDigest: true,
+			},
+		},
 		{
 			name: "no attestors",
 			subject: ImageVerification{
@@ -225,6 +247,68 @@ func Test_ImageVerification(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "attestors with image references",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{{
+					ImageReferences: []string{"ghcr.io/vendor/*"},
+					Entries: []Attestor{{
+						Keys: &StaticKeyAttestor{
+							PublicKeys: "vendor",
+						},
+					}},
+				}, {
+					ImageReferences: []string{"ghcr.io/team/*"},
+					Entries: []Attestor{{
+						Keys: &StaticKeyAttestor{
+							PublicKeys: "team",
+						},
+					}},
+				}},
+			},
+		},
+		{
+			name: "attestors with empty image reference",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{{
+					ImageReferences: []string{""},
+					Entries: []Attestor{{
+						Keys: &StaticKeyAttestor{
+							PublicKeys: "key",
+						},
+					}},
+				}},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(path.Child("attestors").Index(0).Child("imageReferences").Index(0), "", "An image reference pattern can't be empty"),
+				}
+			},
+		},
+		{
+			name: "attestations attestors with image references",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestations: []Attestation{{
+					Type: "https://example.com/predicate",
+					Attestors: []AttestorSet{{
+						ImageReferences: []string{"ghcr.io/team/*"},
+						Entries: []Attestor{{
+							Keys: &StaticKeyAttestor{
+								PublicKeys: "key",
+							},
+						}},
+					}},
+				}},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(path.Child("attestations").Index(0).Child("attestors").Index(0).Child("imageReferences"), []string{"ghcr.io/team/*"}, "imageReferences is not supported for the attestors of attestations"),
+				}
+			},
+		},
 		{
 			name: "multiple entries",
 			subject: ImageVerification{
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -99,6 +100,18 @@ type ImageVerification struct {
 	// +kubebuilder:validation:Optional
 	VerifyDigest bool `json:"verifyDigest" yaml:"verifyDigest"`
 
+	// VerifyTagDigest validates that the image tag still resolves to the signed digest, detecting
+	// tags moved after signing. Only applies to images referenced by tag.
+	// Verification responses are not cached when set.
+	// +kubebuilder:validation:Optional
+	VerifyTagDigest bool `json:"verifyTagDigest,omitempty" yaml:"verifyTagDigest,omitempty"`
+
+	// MaxSignatureAge is the maximum age of the image signatures, older signatures are rejected.
+	// The signing time is read from the transparency log entry of Cosign signatures and from the
+	// signed attributes of Notary signatures. Verification responses are not cached when set.
+	// +kubebuilder:validation:Optional
+	MaxSignatureAge *metav1.Duration `json:"maxSignatureAge,omitempty" yaml:"maxSignatureAge,omitempty"`
+
 	// Required validates that images are verified i.e. have matched passed a signature or attestation check.
 	// +kubebuilder:default=true
 	// +kubebuilder:validation:Optional
@@ -126,6 +139,13 @@ type AttestorSet struct {
 	// attributes for keyless verification, or a nested attestor declaration.
 	// +kubebuilder:validation:Optional
 	Entries []Attestor `json:"entries,omitempty" yaml:"entries,omitempty"`
+
+	// ImageReferences is a list of image reference patterns the attestor set applies to. When empty,
+	// the attestor set applies to all the images matched by the rule. This allows verifying different
+	// images of a rule (e.g. base and application images) against different attestors. Only supported
+	// for the attestors of the rule, not for the attestors of attestations.
+	// +kubebuilder:validation:Optional
+	ImageReferences []string `json:"imageReferences,omitempty" yaml:"imageReferences,omitempty"`
 }
 
 func (as AttestorSet) RequiredCount() int {
@@ -170,6 +190,9 @@ type StaticKeyAttestor struct {
 	// elsewhere in the cluster by specifying it in the format "k8s://<namespace>/<secret_name>".
 	// The named Secret must specify a key `cosign.pub` containing the public key used for
 	// verification, (see https://github.com/sigstore/cosign/blob/main/KMS.md#kubernetes-secret).
+	// Keys can also be resolved at runtime from HashiCorp Vault by specifying a reference in the
+	// format "vault://<path>#<field>", resolved keys are cached and periodically refreshed to pick
+	// up rotated keys.
 	// When multiple keys are specified each key is processed as a separate staticKey entry
 	// (.attestors[*].entries.keys) within the set of attestors and the count is applied across the keys.
 	PublicKeys string `json:"publicKeys,omitempty" yaml:"publicKeys,omitempty"`
@@ -338,16 +361,30 @@ func (iv *ImageVerification) Validate(isAuditFailureAction bool, path *field.Pat
 		errs = append(errs, field.Invalid(path, iv, "An image reference is required"))
 	}
 
+	if iv.MaxSignatureAge != nil && iv.MaxSignatureAge.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("maxSignatureAge"), iv.MaxSignatureAge.Duration.String(), "maxSignatureAge must be a positive duration"))
+	}
+
 	asPath := path.Child("attestations")
 	for i, attestation := range copy.Attestations {
 		attestationErrors := attestation.Validate(asPath.Index(i))
 		errs = append(errs, attestationErrors...)
+		for j, as := range attestation.Attestors {
+			if len(as.ImageReferences) != 0 {
+				errs = append(errs, field.Invalid(asPath.Index(i).Child("attestors").Index(j).Child("imageReferences"), as.ImageReferences, "imageReferences is not supported for the attestors of attestations"))
+			}
+		}
 	}
 
 	attestorsPath := path.Child("attestors")
 	for i, as := range copy.Attestors {
 		attestorErrors := as.Validate(attestorsPath.Index(i))
 		errs = append(errs, attestorErrors...)
+		for j, imageReference := range as.ImageReferences {
+			if imageReference == "" {
+				errs = append(errs, field.Invalid(attestorsPath.Index(i).Child("imageReferences").Index(j), imageReference, "An image reference pattern can't be empty"))
+			}
+		}
 	}
 
 	if iv.Type == Notary {
@@ -43,6 +43,19 @@ func (m *MatchResources) GetKinds() []string {
 	return kinds
 }
 
+// GetResources returns all resources
+func (m *MatchResources) GetResources() []string {
+	var resources []string
+	resources = append(resources, m.ResourceDescription.Resources...)
+	for _, value := range m.All {
+		resources = append(resources, value.ResourceDescription.Resources...)
+	}
+	for _, value := range m.Any {
+		resources = append(resources, value.ResourceDescription.Resources...)
+	}
+	return resources
+}
+
 // Validate implements programmatic validation
 func (m *MatchResources) Validate(path *field.Path, namespaced bool, clusterResources sets.Set[string]) (errs field.ErrorList) {
 	if len(m.Any) > 0 && len(m.All) > 0 {
@@ -55,7 +68,7 @@ func (m *MatchResources) Validate(path *field.Path, namespaced bool, clusterReso
 	}
 	allPath := path.Child("all")
 	for i, filter := range m.All {
-		errs = append(errs, filter.UserInfo.Validate(anyPath.Index(i))...)
+		errs = append(errs, filter.UserInfo.Validate(allPath.Index(i))...)
 		errs = append(errs, filter.ResourceDescription.Validate(allPath.Index(i), namespaced, clusterResources)...)
 	}
 	errs = append(errs, m.UserInfo.Validate(path)...)
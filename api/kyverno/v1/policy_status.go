@@ -8,6 +8,12 @@ import (
 const (
 	// PolicyConditionReady means that the policy is ready
 	PolicyConditionReady = "Ready"
+	// PolicyConditionBackgroundFailed means that the background controller gave up processing an update request of the policy
+	PolicyConditionBackgroundFailed = "BackgroundFailed"
+	// PolicyConditionRulesPending means that some rules of the policy match kinds that are not installed yet
+	PolicyConditionRulesPending = "RulesPending"
+	// PolicyConditionExecutionFailed means that some rules of the policy failed to execute against a resource
+	PolicyConditionExecutionFailed = "ExecutionFailed"
 )
 
 const (
@@ -15,6 +21,12 @@ const (
 	PolicyReasonSucceeded = "Succeeded"
 	// PolicyReasonSucceeded is the reason set when the policy is not ready
 	PolicyReasonFailed = "Failed"
+	// PolicyReasonRetriesExhausted is the reason set when an update request of the policy failed after all attempts
+	PolicyReasonRetriesExhausted = "RetriesExhausted"
+	// PolicyReasonKindsNotFound is the reason set when some rules of the policy wait for their kinds to be installed
+	PolicyReasonKindsNotFound = "KindsNotFound"
+	// PolicyReasonRuleErrors is the reason set when some rules of the policy returned an error
+	PolicyReasonRuleErrors = "RuleErrors"
 )
 
 // Deprecated. Policy metrics are now available via the "/metrics" endpoint.
@@ -68,6 +80,70 @@ func (status *PolicyStatus) IsReady() bool {
 	return condition != nil && condition.Status == metav1.ConditionTrue
 }
 
+// SetBackgroundFailed records whether the last update request of the policy that finished processing failed
+func (status *PolicyStatus) SetBackgroundFailed(failed bool, message string) {
+	condition := metav1.Condition{
+		Type:    PolicyConditionBackgroundFailed,
+		Message: message,
+	}
+	if failed {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = PolicyReasonRetriesExhausted
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = PolicyReasonSucceeded
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// IsBackgroundFailed indicates if the background controller gave up processing an update request of the policy
+func (status *PolicyStatus) IsBackgroundFailed() bool {
+	return meta.IsStatusConditionTrue(status.Conditions, PolicyConditionBackgroundFailed)
+}
+
+// SetRulesPending records whether some rules of the policy wait for the CRDs of the kinds they match to be installed
+func (status *PolicyStatus) SetRulesPending(pending bool, message string) {
+	condition := metav1.Condition{
+		Type:    PolicyConditionRulesPending,
+		Message: message,
+	}
+	if pending {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = PolicyReasonKindsNotFound
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = PolicyReasonSucceeded
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// IsRulesPending indicates if some rules of the policy wait for the CRDs of the kinds they match to be installed
+func (status *PolicyStatus) IsRulesPending() bool {
+	return meta.IsStatusConditionTrue(status.Conditions, PolicyConditionRulesPending)
+}
+
+// SetExecutionFailed records whether some rules of the policy failed to execute at the given generation
+func (status *PolicyStatus) SetExecutionFailed(failed bool, message string, generation int64) {
+	condition := metav1.Condition{
+		Type:               PolicyConditionExecutionFailed,
+		Message:            message,
+		ObservedGeneration: generation,
+	}
+	if failed {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = PolicyReasonRuleErrors
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = PolicyReasonSucceeded
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// IsExecutionFailed indicates if some rules of the policy failed to execute
+func (status *PolicyStatus) IsExecutionFailed() bool {
+	return meta.IsStatusConditionTrue(status.Conditions, PolicyConditionExecutionFailed)
+}
+
 // AutogenStatus contains autogen status information.
 type AutogenStatus struct {
 	// Rules is a list of Rule instances. It contains auto generated rules added for pod controllers
@@ -26,7 +26,7 @@ func Test_ResourceDescription(t *testing.T) {
 			Names: []string{"bar", "baz"},
 		},
 		errors: []string{
-			`dummy: Invalid value: v1.ResourceDescription{Kinds:[]string(nil), Name:"foo", Names:[]string{"bar", "baz"}, Namespaces:[]string(nil), Annotations:map[string]string(nil), Selector:(*v1.LabelSelector)(nil), NamespaceSelector:(*v1.LabelSelector)(nil), Operations:[]v1.AdmissionOperation(nil)}: Both name and names can not be specified together`,
+			`dummy: Invalid value: v1.ResourceDescription{Kinds:[]string(nil), Name:"foo", Names:[]string{"bar", "baz"}, Namespaces:[]string(nil), Annotations:map[string]string(nil), Selector:(*v1.LabelSelector)(nil), NamespaceSelector:(*v1.LabelSelector)(nil), Operations:[]v1.AdmissionOperation(nil), OwnerReferences:[]v1.OwnerReferenceFilter(nil), Resources:[]string(nil)}: Both name and names can not be specified together`,
 		},
 	}, {
 		name:       "selector",
@@ -48,6 +48,40 @@ func Test_ResourceDescription(t *testing.T) {
 		errors: []string{
 			`dummy.selector: Invalid value: v1.LabelSelector{MatchLabels:map[string]string(nil), MatchExpressions:[]v1.LabelSelectorRequirement(nil)}: The requirements are not specified in selector`,
 		},
+	}, {
+		name:       "namespace-selector",
+		namespaced: true,
+		subject: ResourceDescription{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"env": "prod",
+				},
+			},
+		},
+	}, {
+		name:       "bad-namespace-selector",
+		namespaced: true,
+		subject: ResourceDescription{
+			Kinds:             []string{"Deployment"},
+			NamespaceSelector: &metav1.LabelSelector{},
+		},
+		errors: []string{
+			`dummy.namespaceSelector: Invalid value: v1.LabelSelector{MatchLabels:map[string]string(nil), MatchExpressions:[]v1.LabelSelectorRequirement(nil)}: The requirements are not specified in namespaceSelector`,
+		},
+	}, {
+		name:       "invalid-namespace-selector",
+		namespaced: true,
+		subject: ResourceDescription{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      "env",
+					Operator: metav1.LabelSelectorOpIn,
+				}},
+			},
+		},
+		errors: []string{
+			`dummy.namespaceSelector: Invalid value: v1.LabelSelector{MatchLabels:map[string]string(nil), MatchExpressions:[]v1.LabelSelectorRequirement{v1.LabelSelectorRequirement{Key:"env", Operator:"In", Values:[]string(nil)}}}: values: Invalid value: []string(nil): for 'in', 'notin' operators, values set can't be empty`,
+		},
 	}, {
 		name:       "namespaces",
 		namespaced: true,
@@ -57,6 +91,27 @@ func Test_ResourceDescription(t *testing.T) {
 		errors: []string{
 			"dummy.namespaces: Forbidden: Filtering namespaces not allowed in namespaced policies",
 		},
+	}, {
+		name: "negated-namespaces",
+		subject: ResourceDescription{
+			Namespaces: []string{"prod-*", "!prod-sandbox"},
+		},
+	}, {
+		name: "empty-negated-namespace",
+		subject: ResourceDescription{
+			Namespaces: []string{"prod-*", "!"},
+		},
+		errors: []string{
+			`dummy.namespaces[1]: Invalid value: "!": Negated namespace can not be empty`,
+		},
+	}, {
+		name: "conflicting-namespaces",
+		subject: ResourceDescription{
+			Namespaces: []string{"prod-sandbox", "!prod-*"},
+		},
+		errors: []string{
+			`dummy.namespaces[0]: Invalid value: "prod-sandbox": Namespace is always excluded by !prod-*`,
+		},
 	}}
 
 	path := field.NewPath("dummy")
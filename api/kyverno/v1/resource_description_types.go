@@ -28,6 +28,7 @@ type ResourceDescription struct {
 
 	// Namespaces is a list of namespaces names. Each name supports wildcard characters
 	// "*" (matches zero or many characters) and "?" (at least one character).
+	// Names prefixed with "!" exclude matching namespaces and take precedence over the other names.
 	// +optional
 	Namespaces []string `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
 
@@ -55,6 +56,36 @@ type ResourceDescription struct {
 	// Operations can contain values ["CREATE, "UPDATE", "CONNECT", "DELETE"], which are used to match a specific action.
 	// +optional
 	Operations []AdmissionOperation `json:"operations,omitempty" yaml:"operations,omitempty"`
+
+	// OwnerReferences matches resources based on their owner references. A resource matches
+	// when at least one of its owner references matches one of the entries.
+	// +optional
+	OwnerReferences []OwnerReferenceFilter `json:"ownerReferences,omitempty" yaml:"ownerReferences,omitempty"`
+
+	// Resources is a list of resources in the form `resource.group` (e.g. `deployments.apps`),
+	// the group can be omitted to match resources of any group. Resources are resolved using the
+	// API discovery and match in addition to the kinds. The resource and the group support wildcard
+	// characters "*" (matches zero or many characters) and "?" (at least one character), e.g.
+	// `*.example.com` matches all the resources of the `example.com` group.
+	// +optional
+	Resources []string `json:"resources,omitempty" yaml:"resources,omitempty"`
+}
+
+// OwnerReferenceFilter contains criteria used to match the owner references of a resource.
+type OwnerReferenceFilter struct {
+	// Kind is the kind of the owner. The kind supports wildcard characters
+	// "*" (matches zero or many characters) and "?" (at least one character).
+	// +optional
+	Kind string `json:"kind,omitempty" yaml:"kind,omitempty"`
+
+	// Name is the name of the owner. The name supports wildcard characters
+	// "*" (matches zero or many characters) and "?" (at least one character).
+	// +optional
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Controller, when set, only matches owner references with the same controller flag.
+	// +optional
+	Controller *bool `json:"controller,omitempty" yaml:"controller,omitempty"`
 }
 
 func (r ResourceDescription) IsEmpty() bool {
@@ -64,7 +95,10 @@ func (r ResourceDescription) IsEmpty() bool {
 		len(r.Namespaces) == 0 &&
 		len(r.Annotations) == 0 &&
 		r.Selector == nil &&
-		r.NamespaceSelector == nil
+		r.NamespaceSelector == nil &&
+		len(r.Operations) == 0 &&
+		len(r.OwnerReferences) == 0 &&
+		len(r.Resources) == 0
 }
 
 func (r ResourceDescription) GetOperations() []string {
@@ -90,6 +124,19 @@ func (r *ResourceDescription) Validate(path *field.Path, namespaced bool, cluste
 			}
 		}
 	}
+	if r.NamespaceSelector != nil && !kubeutils.LabelSelectorContainsWildcard(r.NamespaceSelector) {
+		if selector, err := metav1.LabelSelectorAsSelector(r.NamespaceSelector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("namespaceSelector"), r.NamespaceSelector, err.Error()))
+		} else {
+			requirements, _ := selector.Requirements()
+			if len(requirements) == 0 {
+				errs = append(errs, field.Invalid(path.Child("namespaceSelector"), r.NamespaceSelector, "The requirements are not specified in namespaceSelector"))
+			}
+		}
+	}
+	errs = append(errs, ValidateKinds(path.Child("kinds"), r.Kinds)...)
+	errs = append(errs, ValidateResources(path.Child("resources"), r.Resources)...)
+	errs = append(errs, ValidateNamespaces(path.Child("namespaces"), r.Namespaces)...)
 	if namespaced {
 		if len(r.Namespaces) > 0 {
 			errs = append(errs, field.Forbidden(path.Child("namespaces"), "Filtering namespaces not allowed in namespaced policies"))
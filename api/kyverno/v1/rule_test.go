@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"gotest.tools/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -208,6 +210,17 @@ func Test_doesMatchExcludeConflict(t *testing.T) {
 			description: "simple - fail",
 			rule:        []byte(`{"name":"set-image-pull-policy-2","match":{"resources":{"kinds":["Pod","Namespace"],"name":"somxething","namespaces":["something","something1"]}},"exclude":{"resources":{"kinds":["Pod","Namespace","Job"],"name":"some*","namespaces":["something","something1","something2"]}}}`),
 		},
+		{
+			description: "Failed to exclude operations",
+			rule:        []byte(`{"name":"block-deletes","match":{"resources":{"kinds":["Pod"]}},"exclude":{"resources":{"kinds":["Pod"],"operations":["DELETE"]}},"validate":{"message":"not allowed","deny":{}}}`),
+		},
+		{
+			description: "Same match and exclude operations",
+			rule:        []byte(`{"name":"block-deletes","match":{"resources":{"kinds":["Pod"],"operations":["DELETE"]}},"exclude":{"resources":{"kinds":["Pod"],"operations":["CREATE","DELETE"]}},"validate":{"message":"not allowed","deny":{}}}`),
+			errors: func(r *Rule) (errs field.ErrorList) {
+				return append(errs, field.Invalid(path, r, "Rule is matching an empty set"))
+			},
+		},
 		{
 			description: "empty case",
 			rule:        []byte(`{"name":"check-allow-deletes","match":{"resources":{"selector":{"matchLabels":{"allow-deletes":"false"}}}},"exclude":{"clusterRoles":["random"]},"validate":{"message":"Deleting {{request.object.kind}}/{{request.object.metadata.name}} is not allowed","deny":{"conditions":{"all":[{"key":"{{request.operation}}","operator":"Equal","value":"DELETE"}]}}}}`),
@@ -1137,3 +1150,98 @@ func Test_Validate_ClusterPolicy_Generate_Variables(t *testing.T) {
 		assert.Equal(t, len(errs) != 0, testcase.shouldFail, testcase.name)
 	}
 }
+
+func Test_Validate_NamespacedPolicy_Generate_ClusterScopedTarget(t *testing.T) {
+	path := field.NewPath("dummy")
+	clusterResources := sets.New("rbac.authorization.k8s.io/v1/ClusterRole")
+	testcases := []struct {
+		name       string
+		generate   string
+		shouldFail bool
+	}{
+		{
+			name:       "not-allowed",
+			generate:   `{"apiVersion": "rbac.authorization.k8s.io/v1", "kind": "ClusterRole", "name": "{{request.namespace}}-viewer", "synchronize": true, "data": {}}`,
+			shouldFail: true,
+		},
+		{
+			name:       "allowed",
+			generate:   `{"allowClusterScopedTarget": true, "apiVersion": "rbac.authorization.k8s.io/v1", "kind": "ClusterRole", "name": "{{ request.namespace }}-viewer", "synchronize": true, "data": {}}`,
+			shouldFail: false,
+		},
+		{
+			name:       "allowed-object-namespace",
+			generate:   `{"allowClusterScopedTarget": true, "apiVersion": "rbac.authorization.k8s.io/v1", "kind": "ClusterRole", "name": "viewer-{{request.object.metadata.namespace}}", "synchronize": true, "data": {}}`,
+			shouldFail: false,
+		},
+		{
+			name:       "name-without-namespace",
+			generate:   `{"allowClusterScopedTarget": true, "apiVersion": "rbac.authorization.k8s.io/v1", "kind": "ClusterRole", "name": "{{request.object.metadata.name}}-viewer", "synchronize": true, "data": {}}`,
+			shouldFail: true,
+		},
+		{
+			name:       "without-synchronize",
+			generate:   `{"allowClusterScopedTarget": true, "apiVersion": "rbac.authorization.k8s.io/v1", "kind": "ClusterRole", "name": "{{request.namespace}}-viewer", "data": {}}`,
+			shouldFail: true,
+		},
+		{
+			name:       "clone",
+			generate:   `{"allowClusterScopedTarget": true, "apiVersion": "rbac.authorization.k8s.io/v1", "kind": "ClusterRole", "name": "{{request.namespace}}-viewer", "synchronize": true, "clone": {"name": "viewer"}}`,
+			shouldFail: true,
+		},
+		{
+			name:       "with-namespace",
+			generate:   `{"allowClusterScopedTarget": true, "apiVersion": "rbac.authorization.k8s.io/v1", "kind": "ClusterRole", "name": "{{request.namespace}}-viewer", "namespace": "amritapuri", "synchronize": true, "data": {}}`,
+			shouldFail: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		var generation Generation
+		err := json.Unmarshal([]byte(testcase.generate), &generation)
+		assert.NilError(t, err, testcase.name)
+		errs := generation.Validate(path, true, "amritapuri", clusterResources)
+		assert.Equal(t, len(errs) != 0, testcase.shouldFail, testcase.name)
+	}
+}
+
+func Test_Rule_GetConditions(t *testing.T) {
+	var rule Rule
+	assert.NilError(t, json.Unmarshal([]byte(`{"name":"test","preconditions":[{"key":"{{ request.operation }}","operator":"Equals","value":"CREATE"}]}`), &rule))
+	conditions, err := rule.GetConditions()
+	assert.NilError(t, err)
+	assert.Equal(t, len(conditions.AnyConditions), 0)
+	assert.Equal(t, len(conditions.AllConditions), 1)
+	assert.Equal(t, conditions.AllConditions[0].Operator, ConditionOperators["Equals"])
+
+	assert.NilError(t, json.Unmarshal([]byte(`{"name":"test","preconditions":{"any":[{"key":"a","operator":"Unknown","value":"a"}]}}`), &rule))
+	_, err = rule.GetConditions()
+	assert.ErrorContains(t, err, "preconditions.any[0].operator")
+}
+
+func Test_Rule_HasMutateAudit(t *testing.T) {
+	patch := &apiextv1.JSON{Raw: []byte(`{"metadata":{"labels":{"team":"payments"}}}`)}
+	tests := []struct {
+		name string
+		rule Rule
+		want bool
+	}{{
+		name: "not a mutate rule",
+		rule: Rule{},
+	}, {
+		name: "mutate rule",
+		rule: Rule{Mutation: Mutation{RawPatchStrategicMerge: patch}},
+	}, {
+		name: "audit mutate rule",
+		rule: Rule{Mutation: Mutation{RawPatchStrategicMerge: patch, Audit: true}},
+		want: true,
+	}, {
+		name: "audit mutate existing rule",
+		rule: Rule{Mutation: Mutation{Targets: []TargetResourceSpec{{}}, RawPatchStrategicMerge: patch, Audit: true}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.rule.HasMutateAudit(), tt.want)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -53,6 +54,11 @@ type Rule struct {
 	// +optional
 	Context []ContextEntry `json:"context,omitempty" yaml:"context,omitempty"`
 
+	// DependsOn lists the names of the rules of the same policy that must be applied before this rule.
+	// Mutation rules are executed after the rules they depend on, dependencies must not form a cycle.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+
 	// MatchResources defines when this policy rule should be applied. The match
 	// criteria can include resource information (e.g. kind, name, namespace, labels)
 	// and admission review request information like the user name or role.
@@ -79,7 +85,7 @@ type Rule struct {
 	RawAnyAllConditions *apiextv1.JSON `json:"preconditions,omitempty" yaml:"preconditions,omitempty"`
 
 	// CELPreconditions are used to determine if a policy rule should be applied by evaluating a
-	// set of CEL conditions. It can only be used with the validate.cel subrule
+	// set of CEL conditions. They are evaluated against the admission request
 	// +optional
 	CELPreconditions []admissionregistrationv1alpha1.MatchCondition `json:"celPreconditions,omitempty" yaml:"celPreconditions,omitempty"`
 
@@ -105,9 +111,31 @@ type Rule struct {
 	// +kubebuilder:default=true
 	// +kubebuilder:validation:Optional
 	SkipBackgroundRequests bool `json:"skipBackgroundRequests,omitempty" yaml:"skipBackgroundRequests,omitempty"`
+
+	// SkipRequestsFromSameServiceAccount bypasses admission requests that are sent by the Kyverno
+	// admission controller service account, preventing feedback loops when Kyverno modifies resources.
+	// It is disabled unless set to "true", mutate, validate and verifyImages rules are then
+	// not applied to those requests.
+	// +kubebuilder:validation:Optional
+	SkipRequestsFromSameServiceAccount *bool `json:"skipRequestsFromSameServiceAccount,omitempty" yaml:"skipRequestsFromSameServiceAccount,omitempty"`
+
+	// ReportProperties are additional properties added to the policy report results of the rule,
+	// values support variable substitution.
+	// +optional
+	ReportProperties map[string]string `json:"reportProperties,omitempty" yaml:"reportProperties,omitempty"`
+
+	// Timeout is the maximum duration allowed to process the rule, including loading its context entries.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
 }
 
 // HasMutate checks for mutate rule
+// GetSkipRequestsFromSameServiceAccount returns true when requests sent by the Kyverno admission controller
+// service account are skipped, it is false unless set
+func (r *Rule) GetSkipRequestsFromSameServiceAccount() bool {
+	return r.SkipRequestsFromSameServiceAccount != nil && *r.SkipRequestsFromSameServiceAccount
+}
+
 func (r *Rule) HasMutate() bool {
 	return !datautils.DeepEqual(r.Mutation, Mutation{})
 }
@@ -125,6 +153,11 @@ func (r *Rule) HasMutateExisting() bool {
 	return r.Mutation.Targets != nil
 }
 
+// HasMutateAudit checks if the mutate rule is evaluated against existing resources in background scans
+func (r *Rule) HasMutateAudit() bool {
+	return r.HasMutateStandard() && r.Mutation.Audit
+}
+
 // HasVerifyImages checks for verifyImages rule
 func (r *Rule) HasVerifyImages() bool {
 	for _, verifyImage := range r.VerifyImages {
@@ -160,6 +193,21 @@ func (r *Rule) HasValidateCEL() bool {
 	return r.Validation.CEL != nil && !datautils.DeepEqual(r.Validation.CEL, &CEL{})
 }
 
+// HasValidateImageAllowList checks for validate.imageAllowList rule
+func (r *Rule) HasValidateImageAllowList() bool {
+	return r.Validation.ImageAllowList != nil
+}
+
+// HasValidateBaseline checks for validate.baseline rule
+func (r *Rule) HasValidateBaseline() bool {
+	return r.Validation.Baseline != nil
+}
+
+// HasValidateRego checks for validate.rego rule
+func (r *Rule) HasValidateRego() bool {
+	return r.Validation.Rego != nil
+}
+
 // HasValidate checks for validate rule
 func (r *Rule) HasValidate() bool {
 	return !datautils.DeepEqual(r.Validation, Validation{})
@@ -189,6 +237,12 @@ func (r *Rule) SetAnyAllConditions(in apiextensions.JSON) {
 	r.RawAnyAllConditions = ToJSON(in)
 }
 
+// GetConditions returns the rule preconditions normalized as any/all blocks
+func (r *Rule) GetConditions() (AnyAllConditions, error) {
+	conditions, errs := ParseConditions(field.NewPath("preconditions"), r.GetAnyAllConditions())
+	return conditions, errs.ToAggregate()
+}
+
 // ValidateRuleType checks only one type of rule is defined per rule
 func (r *Rule) ValidateRuleType(path *field.Path) (errs field.ErrorList) {
 	ruleTypes := []bool{r.HasMutate(), r.HasValidate(), r.HasGenerate(), r.HasVerifyImages()}
@@ -309,6 +363,14 @@ func (r *Rule) ValidateMatchExcludeConflict(path *field.Path) (errs field.ErrorL
 			return errs
 		}
 	}
+	if len(r.ExcludeResources.Operations) > 0 {
+		if len(r.MatchResources.Operations) == 0 || !sets.New(r.ExcludeResources.Operations...).HasAll(r.MatchResources.Operations...) {
+			return errs
+		}
+	}
+	if len(r.ExcludeResources.OwnerReferences) > 0 {
+		return errs
+	}
 	if r.MatchResources.Selector != nil && r.ExcludeResources.Selector != nil {
 		if len(excludeSelectorMatchExpressions) > 0 {
 			if len(r.MatchResources.Selector.MatchExpressions) == 0 {
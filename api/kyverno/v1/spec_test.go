@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"gotest.tools/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -49,3 +50,33 @@ func Test_Validate_UniqueRuleName(t *testing.T) {
 	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
 	assert.Equal(t, errs[0].Detail, "Duplicate rule name: 'deny-privileged-disallowpriviligedescalation'")
 }
+
+func Test_GetReinvocationPolicy(t *testing.T) {
+	never := admissionregistrationv1.NeverReinvocationPolicy
+	tests := []struct {
+		name string
+		spec Spec
+		want admissionregistrationv1.ReinvocationPolicyType
+	}{{
+		name: "default",
+		spec: Spec{},
+		want: admissionregistrationv1.IfNeededReinvocationPolicy,
+	}, {
+		name: "never",
+		spec: Spec{ReinvocationPolicy: &never},
+		want: admissionregistrationv1.NeverReinvocationPolicy,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.spec.GetReinvocationPolicy(), tt.want)
+		})
+	}
+}
+
+func Test_GetFailFast(t *testing.T) {
+	enabled, disabled := true, false
+	assert.Equal(t, (&Spec{}).GetFailFast(false), false)
+	assert.Equal(t, (&Spec{}).GetFailFast(true), true)
+	assert.Equal(t, (&Spec{FailFast: &enabled}).GetFailFast(false), true)
+	assert.Equal(t, (&Spec{FailFast: &disabled}).GetFailFast(true), false)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/kyverno/kyverno/pkg/toggle"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -121,6 +122,20 @@ type Spec struct {
 	// Defaults to "false" if not specified.
 	// +optional
 	UseServerSideApply bool `json:"useServerSideApply,omitempty" yaml:"useServerSideApply,omitempty"`
+
+	// ReinvocationPolicy controls whether the mutating webhook requests to be reinvoked when other admission plugins
+	// modify the object after the initial webhook call, e.g. to re-apply mutations depending on sidecar injection.
+	// Mutating webhooks are shared by policies, they are reinvoked as soon as one of the policies requires it.
+	// Allowed values are IfNeeded or Never. Defaults to IfNeeded.
+	// +optional
+	// +kubebuilder:validation:Enum=Never;IfNeeded
+	ReinvocationPolicy *admissionregistrationv1.ReinvocationPolicyType `json:"reinvocationPolicy,omitempty" yaml:"reinvocationPolicy,omitempty"`
+
+	// FailFast controls whether the admission evaluation stops at the first rule of the policy failing in enforce mode,
+	// the remaining rules and policies are skipped and the request is denied without waiting for them.
+	// Policies in audit mode are always fully evaluated. Defaults to the failFast setting of the Kyverno ConfigMap.
+	// +optional
+	FailFast *bool `json:"failFast,omitempty" yaml:"failFast,omitempty"`
 }
 
 func (s *Spec) SetRules(rules []Rule) {
@@ -167,6 +182,16 @@ func (s *Spec) HasMutateExisting() bool {
 	return false
 }
 
+// HasMutateAudit checks for mutate rules evaluated against existing resources in background scans
+func (s *Spec) HasMutateAudit() bool {
+	for _, rule := range s.Rules {
+		if rule.HasMutateAudit() {
+			return true
+		}
+	}
+	return false
+}
+
 // HasValidate checks for validate rule types
 func (s *Spec) HasValidate() bool {
 	for _, rule := range s.Rules {
@@ -177,6 +202,16 @@ func (s *Spec) HasValidate() bool {
 	return false
 }
 
+// HasValidateEnforceRules checks for validate rules overriding the policy failure action with Enforce
+func (s *Spec) HasValidateEnforceRules() bool {
+	for _, rule := range s.Rules {
+		if rule.HasValidate() && rule.Validation.FailureAction != nil && rule.Validation.FailureAction.Enforce() {
+			return true
+		}
+	}
+	return false
+}
+
 // HasGenerate checks for generate rule types
 func (s *Spec) HasGenerate() bool {
 	for _, rule := range s.Rules {
@@ -239,6 +274,23 @@ func (s *Spec) GetMutateExistingOnPolicyUpdate() bool {
 	return s.MutateExistingOnPolicyUpdate
 }
 
+// GetReinvocationPolicy returns the reinvocation policy requested for the mutating webhook
+func (s *Spec) GetReinvocationPolicy() admissionregistrationv1.ReinvocationPolicyType {
+	if s.ReinvocationPolicy == nil {
+		return admissionregistrationv1.IfNeededReinvocationPolicy
+	}
+	return *s.ReinvocationPolicy
+}
+
+// GetFailFast returns whether the evaluation stops at the first enforce failure of the policy,
+// defaultValue is the global setting used when the policy does not set it
+func (s *Spec) GetFailFast(defaultValue bool) bool {
+	if s.FailFast == nil {
+		return defaultValue
+	}
+	return *s.FailFast
+}
+
 // IsGenerateExisting return GenerateExisting set value
 func (s *Spec) IsGenerateExisting() bool {
 	if s.GenerateExistingOnPolicyUpdate != nil && *s.GenerateExistingOnPolicyUpdate {
@@ -265,22 +317,31 @@ func (s *Spec) GetApplyRules() ApplyRulesType {
 	return *s.ApplyRules
 }
 
-// ValidateRuleNames checks if the rule names are unique across a policy
+// ValidateRuleNames checks if the rule names are valid and unique across a policy
 func (s *Spec) ValidateRuleNames(path *field.Path) (errs field.ErrorList) {
-	names := sets.New[string]()
+	names := make([]string, 0, len(s.Rules))
 	for i, rule := range s.Rules {
-		rulePath := path.Index(i)
-		if names.Has(rule.Name) {
-			errs = append(errs, field.Invalid(rulePath.Child("name"), rule, fmt.Sprintf(`Duplicate rule name: '%s'`, rule.Name)))
-		}
-		names.Insert(rule.Name)
+		errs = append(errs, ValidateRuleName(path.Index(i).Child("name"), rule.Name)...)
+		names = append(names, rule.Name)
 	}
-	return errs
+	return append(errs, ValidateUniqueRuleNames(path, names)...)
+}
+
+// ValidateRuleDependencies checks if the rule dependencies exist in the policy and don't form a cycle
+func (s *Spec) ValidateRuleDependencies(path *field.Path) (errs field.ErrorList) {
+	names := make([]string, 0, len(s.Rules))
+	dependsOn := make([][]string, 0, len(s.Rules))
+	for _, rule := range s.Rules {
+		names = append(names, rule.Name)
+		dependsOn = append(dependsOn, rule.DependsOn)
+	}
+	return ValidateRuleDependencies(path, names, dependsOn)
 }
 
 // ValidateRules implements programmatic validation of Rules
 func (s *Spec) ValidateRules(path *field.Path, namespaced bool, policyNamespace string, clusterResources sets.Set[string]) (errs field.ErrorList) {
 	errs = append(errs, s.ValidateRuleNames(path)...)
+	errs = append(errs, s.ValidateRuleDependencies(path)...)
 
 	for i, rule := range s.Rules {
 		errs = append(errs, rule.Validate(path.Index(i), namespaced, policyNamespace, clusterResources)...)
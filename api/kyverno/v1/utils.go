@@ -1,10 +1,19 @@
 package v1
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/kyverno/kyverno/api/kyverno"
+	"github.com/kyverno/kyverno/ext/wildcard"
 	log "github.com/kyverno/kyverno/pkg/logging"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -41,12 +50,265 @@ func ValidateAutogenAnnotation(path *field.Path, annotations map[string]string)
 // ValidatePolicyName validates policy name
 func ValidatePolicyName(path *field.Path, name string) (errs field.ErrorList) {
 	// policy name is stored in the label of the report change request
+	if len(name) > 63 {
+		errs = append(errs, field.TooLong(path, name, 63))
+	}
+	if name != "" {
+		errs = append(errs, ValidateRFC1123Subdomain(path, name)...)
+	}
+	return errs
+}
+
+// ValidateRuleName validates rule name, it is reported in policy reports and must fit in a label value
+func ValidateRuleName(path *field.Path, name string) (errs field.ErrorList) {
 	if len(name) > 63 {
 		errs = append(errs, field.TooLong(path, name, 63))
 	}
 	return errs
 }
 
+// ValidateUniqueRuleNames checks that rule names are unique, path is the path of the rules list
+func ValidateUniqueRuleNames(path *field.Path, names []string) (errs field.ErrorList) {
+	seen := sets.New[string]()
+	for i, name := range names {
+		if seen.Has(name) {
+			errs = append(errs, field.Invalid(path.Index(i).Child("name"), name, fmt.Sprintf(`Duplicate rule name: '%s'`, name)))
+		}
+		seen.Insert(name)
+	}
+	return errs
+}
+
+// ValidateRuleDependencies checks that the rules referenced in dependsOn exist and don't form a cycle,
+// names and dependsOn are indexed by rule position and path is the path of the rules list
+func ValidateRuleDependencies(path *field.Path, names []string, dependsOn [][]string) (errs field.ErrorList) {
+	known := sets.New(names...)
+	for i, dependencies := range dependsOn {
+		for j, dependency := range dependencies {
+			dependencyPath := path.Index(i).Child("dependsOn").Index(j)
+			if dependency == names[i] {
+				errs = append(errs, field.Invalid(dependencyPath, dependency, "Rule can not depend on itself"))
+			} else if !known.Has(dependency) {
+				errs = append(errs, field.NotFound(dependencyPath, dependency))
+			}
+		}
+	}
+	if len(errs) != 0 {
+		return errs
+	}
+	if _, cyclic := OrderRules(names, dependsOn); len(cyclic) != 0 {
+		var cycle []string
+		for _, i := range cyclic {
+			cycle = append(cycle, names[i])
+		}
+		errs = append(errs, field.Invalid(path, cycle, "Rule dependencies form a cycle"))
+	}
+	return errs
+}
+
+// OrderRules returns the rule indexes ordered so that every rule comes after the rules it depends on,
+// independent rules keep their declaration order and unknown dependencies are ignored.
+// Rules that can't be ordered because of a cycle are appended in declaration order and returned as cyclic.
+func OrderRules(names []string, dependsOn [][]string) (ordered []int, cyclic []int) {
+	index := make(map[string]int, len(names))
+	for i := len(names) - 1; i >= 0; i-- {
+		index[names[i]] = i
+	}
+	placed := make([]bool, len(names))
+	ready := func(i int) bool {
+		if i >= len(dependsOn) {
+			return true
+		}
+		for _, dependency := range dependsOn[i] {
+			if j, ok := index[dependency]; ok && j != i && !placed[j] {
+				return false
+			}
+		}
+		return true
+	}
+	for len(ordered) < len(names) {
+		progress := false
+		for i := range names {
+			if !placed[i] && ready(i) {
+				placed[i] = true
+				ordered = append(ordered, i)
+				progress = true
+				break
+			}
+		}
+		if !progress {
+			for i := range names {
+				if !placed[i] {
+					ordered = append(ordered, i)
+					cyclic = append(cyclic, i)
+				}
+			}
+			break
+		}
+	}
+	return ordered, cyclic
+}
+
+// ValidateRFC1123Subdomain validates a value is a lowercase RFC 1123 subdomain, as used by most resource names
+func ValidateRFC1123Subdomain(path *field.Path, value string) (errs field.ErrorList) {
+	for _, msg := range validation.IsDNS1123Subdomain(value) {
+		errs = append(errs, field.Invalid(path, value, msg))
+	}
+	return errs
+}
+
+// ValidateRFC1123Label validates a value is a lowercase RFC 1123 label, as used by namespace names
+func ValidateRFC1123Label(path *field.Path, value string) (errs field.ErrorList) {
+	for _, msg := range validation.IsDNS1123Label(value) {
+		errs = append(errs, field.Invalid(path, value, msg))
+	}
+	return errs
+}
+
+// ValidateKind validates the format of a kind selector, [[group/]version/]Kind[/subresource] or a wildcard
+func ValidateKind(path *field.Path, kind string) (errs field.ErrorList) {
+	if kind == "" {
+		return append(errs, field.Required(path, "Kind can not be empty"))
+	}
+	if strings.ContainsAny(kind, " \t\n") {
+		return append(errs, field.Invalid(path, kind, "Kind can not contain whitespaces"))
+	}
+	for _, part := range strings.Split(kind, "/") {
+		if part == "" {
+			return append(errs, field.Invalid(path, kind, "Kind can not contain empty segments"))
+		}
+	}
+	if _, _, k, _ := kubeutils.ParseKindSelector(kind); k == "" {
+		errs = append(errs, field.Invalid(path, kind, "Kind must be in the form [[group/]version/]Kind[/subresource]"))
+	}
+	return errs
+}
+
+// ValidateKinds validates the format of every kind selector in the list
+func ValidateKinds(path *field.Path, kinds []string) (errs field.ErrorList) {
+	for i, kind := range kinds {
+		errs = append(errs, ValidateKind(path.Index(i), kind)...)
+	}
+	return errs
+}
+
+// ValidateResource validates the format of a resource selector, resource[/subresource][.group]
+func ValidateResource(path *field.Path, resource string) (errs field.ErrorList) {
+	if resource == "" {
+		return append(errs, field.Required(path, "Resource can not be empty"))
+	}
+	if strings.ContainsAny(resource, " \t\n") {
+		return append(errs, field.Invalid(path, resource, "Resource can not contain whitespaces"))
+	}
+	if _, name, subresource := kubeutils.ParseResourceSelector(resource); name == "" || strings.Contains(resource, "/") && subresource == "" {
+		errs = append(errs, field.Invalid(path, resource, "Resource must be in the form resource[/subresource][.group]"))
+	}
+	return errs
+}
+
+// ValidateResources validates the format of every resource selector in the list
+func ValidateResources(path *field.Path, resources []string) (errs field.ErrorList) {
+	for i, resource := range resources {
+		errs = append(errs, ValidateResource(path.Index(i), resource)...)
+	}
+	return errs
+}
+
+// ValidateNamespaces validates namespaces patterns, negated patterns must not be empty
+// and must not exclude every namespace matched by another pattern
+func ValidateNamespaces(path *field.Path, namespaces []string) (errs field.ErrorList) {
+	var negated []string
+	for i, namespace := range namespaces {
+		if pattern, ok := strings.CutPrefix(namespace, "!"); ok {
+			if pattern == "" {
+				errs = append(errs, field.Invalid(path.Index(i), namespace, "Negated namespace can not be empty"))
+			}
+			negated = append(negated, pattern)
+		}
+	}
+	for i, namespace := range namespaces {
+		if strings.HasPrefix(namespace, "!") {
+			continue
+		}
+		for _, pattern := range negated {
+			if pattern != "" && wildcard.Match(pattern, namespace) {
+				errs = append(errs, field.Invalid(path.Index(i), namespace, "Namespace is always excluded by !"+pattern))
+				break
+			}
+		}
+	}
+	return errs
+}
+
+// ParseConditions normalizes conditions declared either as a legacy list or as any/all blocks,
+// a legacy list is equivalent to an all block. Errors carry the path of the offending condition.
+func ParseConditions(path *field.Path, in apiextensions.JSON) (conditions AnyAllConditions, errs field.ErrorList) {
+	if in == nil {
+		return conditions, nil
+	}
+	data, err := json.Marshal(in)
+	if err != nil {
+		return conditions, append(errs, field.Invalid(path, in, err.Error()))
+	}
+	var list []json.RawMessage
+	if err := json.Unmarshal(data, &list); err == nil {
+		conditions.AllConditions, errs = parseConditionList(path, list)
+		return conditions, errs
+	}
+	var blocks map[string]json.RawMessage
+	if err := json.Unmarshal(data, &blocks); err != nil {
+		return conditions, append(errs, field.Invalid(path, in, "Conditions must be a list or an object with any or all blocks"))
+	}
+	keys := make([]string, 0, len(blocks))
+	for key := range blocks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if key != "any" && key != "all" {
+			errs = append(errs, field.NotSupported(path.Child(key), key, []string{"any", "all"}))
+			continue
+		}
+		if err := json.Unmarshal(blocks[key], &list); err != nil {
+			errs = append(errs, field.Invalid(path.Child(key), string(blocks[key]), "Conditions must be a list"))
+			continue
+		}
+		parsed, parseErrs := parseConditionList(path.Child(key), list)
+		errs = append(errs, parseErrs...)
+		if key == "any" {
+			conditions.AnyConditions = parsed
+		} else {
+			conditions.AllConditions = parsed
+		}
+	}
+	return conditions, errs
+}
+
+func parseConditionList(path *field.Path, list []json.RawMessage) (conditions []Condition, errs field.ErrorList) {
+	for i, raw := range list {
+		var condition Condition
+		if err := json.Unmarshal(raw, &condition); err != nil {
+			errs = append(errs, field.Invalid(path.Index(i), string(raw), err.Error()))
+			continue
+		}
+		if _, ok := ConditionOperators[string(condition.Operator)]; !ok {
+			errs = append(errs, field.NotSupported(path.Index(i).Child("operator"), condition.Operator, conditionOperatorNames()))
+			continue
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, errs
+}
+
+func conditionOperatorNames() []string {
+	names := make([]string, 0, len(ConditionOperators))
+	for name := range ConditionOperators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func containsString(list []string, key string) bool {
 	for _, val := range list {
 		if val == key {
@@ -0,0 +1,218 @@
+package v1
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func Test_ValidatePolicyName(t *testing.T) {
+	path := field.NewPath("name")
+	assert.Equal(t, len(ValidatePolicyName(path, "")), 0)
+	assert.Equal(t, len(ValidatePolicyName(path, "require-labels")), 0)
+	assert.Equal(t, len(ValidatePolicyName(path, "require.labels")), 0)
+	assert.Assert(t, len(ValidatePolicyName(path, "Require-Labels")) != 0)
+	assert.Assert(t, len(ValidatePolicyName(path, strings.Repeat("a", 64))) != 0)
+}
+
+func Test_ValidateRuleName(t *testing.T) {
+	path := field.NewPath("name")
+	assert.Equal(t, len(ValidateRuleName(path, "check labels")), 0)
+	assert.Equal(t, len(ValidateRuleName(path, strings.Repeat("a", 63))), 0)
+	errs := ValidateRuleName(path, strings.Repeat("a", 64))
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Type, field.ErrorTypeTooLong)
+}
+
+func Test_ValidateUniqueRuleNames(t *testing.T) {
+	path := field.NewPath("rules")
+	assert.Equal(t, len(ValidateUniqueRuleNames(path, []string{"foo", "bar"})), 0)
+	errs := ValidateUniqueRuleNames(path, []string{"foo", "bar", "foo"})
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Field, "rules[2].name")
+	assert.Equal(t, errs[0].Detail, "Duplicate rule name: 'foo'")
+}
+
+func Test_ValidateRFC1123(t *testing.T) {
+	path := field.NewPath("dummy")
+	assert.Equal(t, len(ValidateRFC1123Subdomain(path, "foo.bar")), 0)
+	assert.Assert(t, len(ValidateRFC1123Subdomain(path, "Foo")) != 0)
+	assert.Equal(t, len(ValidateRFC1123Label(path, "foo-bar")), 0)
+	assert.Assert(t, len(ValidateRFC1123Label(path, "foo.bar")) != 0)
+}
+
+func Test_ValidateKinds(t *testing.T) {
+	testCases := []struct {
+		kind  string
+		valid bool
+	}{
+		{kind: "*", valid: true},
+		{kind: "Pod", valid: true},
+		{kind: "Pod/status", valid: true},
+		{kind: "Pod.status", valid: true},
+		{kind: "v1/Pod", valid: true},
+		{kind: "apps/v1/Deployment", valid: true},
+		{kind: "apps/v1/Deployment/scale", valid: true},
+		{kind: "*/*", valid: true},
+		{kind: ""},
+		{kind: "Pod "},
+		{kind: "apps//Deployment"},
+		{kind: "Pod/"},
+		{kind: "a/b/c/d/e"},
+		{kind: "apps/v1/Deployment/scale.foo"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.kind, func(t *testing.T) {
+			errs := ValidateKinds(field.NewPath("kinds"), []string{tc.kind})
+			assert.Equal(t, len(errs) == 0, tc.valid, errs.ToAggregate())
+		})
+	}
+}
+
+func Test_ValidateResources(t *testing.T) {
+	testCases := []struct {
+		resource string
+		valid    bool
+	}{
+		{resource: "pods", valid: true},
+		{resource: "deployments.apps", valid: true},
+		{resource: "deployments/scale.apps", valid: true},
+		{resource: "*.example.com", valid: true},
+		{resource: "*.*.example.com", valid: true},
+		{resource: ""},
+		{resource: "pods "},
+		{resource: ".apps"},
+		{resource: "deployments/.apps"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.resource, func(t *testing.T) {
+			errs := ValidateResources(field.NewPath("resources"), []string{tc.resource})
+			assert.Equal(t, len(errs) == 0, tc.valid, errs.ToAggregate())
+		})
+	}
+}
+
+func Test_ValidateRuleDependencies(t *testing.T) {
+	path := field.NewPath("rules")
+	names := []string{"a", "b", "c"}
+	assert.Equal(t, len(ValidateRuleDependencies(path, names, [][]string{nil, {"a"}, {"a", "b"}})), 0)
+	errs := ValidateRuleDependencies(path, names, [][]string{{"d"}, {"b"}, nil})
+	assert.Equal(t, len(errs), 2)
+	assert.Equal(t, errs[0].Type, field.ErrorTypeNotFound)
+	assert.Equal(t, errs[0].Field, "rules[0].dependsOn[0]")
+	assert.Equal(t, errs[1].Detail, "Rule can not depend on itself")
+	errs = ValidateRuleDependencies(path, names, [][]string{{"c"}, nil, {"a"}})
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Detail, "Rule dependencies form a cycle")
+	assert.DeepEqual(t, errs[0].BadValue, []string{"a", "c"})
+}
+
+func Test_OrderRules(t *testing.T) {
+	testCases := []struct {
+		name      string
+		names     []string
+		dependsOn [][]string
+		ordered   []int
+		cyclic    []int
+	}{{
+		name:    "no dependencies",
+		names:   []string{"a", "b", "c"},
+		ordered: []int{0, 1, 2},
+	}, {
+		name:      "reversed",
+		names:     []string{"a", "b", "c"},
+		dependsOn: [][]string{{"b"}, {"c"}, nil},
+		ordered:   []int{2, 1, 0},
+	}, {
+		name:      "declaration order kept",
+		names:     []string{"a", "b", "c", "d"},
+		dependsOn: [][]string{{"d"}, nil, nil, nil},
+		ordered:   []int{1, 2, 3, 0},
+	}, {
+		name:      "unknown dependency",
+		names:     []string{"a", "b"},
+		dependsOn: [][]string{{"z"}, nil},
+		ordered:   []int{0, 1},
+	}, {
+		name:      "cycle",
+		names:     []string{"a", "b", "c"},
+		dependsOn: [][]string{{"b"}, {"a"}, nil},
+		ordered:   []int{2, 0, 1},
+		cyclic:    []int{0, 1},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ordered, cyclic := OrderRules(tc.names, tc.dependsOn)
+			assert.DeepEqual(t, ordered, tc.ordered)
+			assert.DeepEqual(t, cyclic, tc.cyclic)
+		})
+	}
+}
+
+func Test_ParseConditions(t *testing.T) {
+	testCases := []struct {
+		name   string
+		raw    string
+		any    int
+		all    int
+		errors []string
+	}{{
+		name: "empty",
+		raw:  `null`,
+	}, {
+		name: "legacy list",
+		raw:  `[{"key":"{{ request.operation }}","operator":"Equals","value":"CREATE"},{"key":"foo","operator":"In","value":["foo"]}]`,
+		all:  2,
+	}, {
+		name: "any all",
+		raw:  `{"any":[{"key":"a","operator":"Equals","value":"a"}],"all":[{"key":"b","operator":"NotEquals","value":"c"},{"key":"d","operator":"AnyIn","value":["d"]}]}`,
+		any:  1,
+		all:  2,
+	}, {
+		name:   "legacy invalid operator",
+		raw:    `[{"key":"a","operator":"Equals","value":"a"},{"key":"b","operator":"Foo","value":"b"}]`,
+		all:    1,
+		errors: []string{"preconditions[1].operator"},
+	}, {
+		name:   "any all invalid operator",
+		raw:    `{"all":[{"key":"a","operator":"Equals","value":"a"}],"any":[{"key":"b","operator":"Equals","value":"b"},{"key":"c","value":"c"}]}`,
+		any:    1,
+		all:    1,
+		errors: []string{"preconditions.any[1].operator"},
+	}, {
+		name:   "unknown block",
+		raw:    `{"all":[{"key":"a","operator":"Equals","value":"a"}],"none":[]}`,
+		all:    1,
+		errors: []string{"preconditions.none"},
+	}, {
+		name:   "invalid condition",
+		raw:    `{"any":[{"key":"a","operator":"Equals","value":"a"}, "foo"]}`,
+		any:    1,
+		errors: []string{"preconditions.any[1]"},
+	}, {
+		name:   "invalid block",
+		raw:    `{"any":{"key":"a","operator":"Equals","value":"a"}}`,
+		errors: []string{"preconditions.any"},
+	}, {
+		name:   "invalid type",
+		raw:    `"foo"`,
+		errors: []string{"preconditions"},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var in interface{}
+			assert.NilError(t, json.Unmarshal([]byte(tc.raw), &in))
+			conditions, errs := ParseConditions(field.NewPath("preconditions"), in)
+			assert.Equal(t, len(conditions.AnyConditions), tc.any)
+			assert.Equal(t, len(conditions.AllConditions), tc.all)
+			var fields []string
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			assert.DeepEqual(t, fields, tc.errors)
+		})
+	}
+}
@@ -23,6 +23,7 @@ package v1
 
 import (
 	k8smanifest "github.com/sigstore/k8s-manifest-sigstore/pkg/k8smanifest"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	v1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -43,7 +44,7 @@ func (in *APICall) DeepCopyInto(out *APICall) {
 	if in.Service != nil {
 		in, out := &in.Service, &out.Service
 		*out = new(ServiceCall)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -176,6 +177,11 @@ func (in *AttestorSet) DeepCopyInto(out *AttestorSet) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ImageReferences != nil {
+		in, out := &in.ImageReferences, &out.ImageReferences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -212,6 +218,23 @@ func (in *AutogenStatus) DeepCopy() *AutogenStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Baseline) DeepCopyInto(out *Baseline) {
+	*out = *in
+	out.ConfigMap = in.ConfigMap
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Baseline.
+func (in *Baseline) DeepCopy() *Baseline {
+	if in == nil {
+		return nil
+	}
+	out := new(Baseline)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CEL) DeepCopyInto(out *CEL) {
 	*out = *in
@@ -426,6 +449,22 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudMetadata) DeepCopyInto(out *CloudMetadata) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudMetadata.
+func (in *CloudMetadata) DeepCopy() *CloudMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
 	*out = *in
@@ -460,11 +499,36 @@ func (in *ContextEntry) DeepCopyInto(out *ContextEntry) {
 		*out = new(ImageRegistry)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CloudMetadata != nil {
+		in, out := &in.CloudMetadata, &out.CloudMetadata
+		*out = new(CloudMetadata)
+		**out = **in
+	}
 	if in.Variable != nil {
 		in, out := &in.Variable, &out.Variable
 		*out = new(Variable)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(FailurePolicyType)
+		**out = **in
+	}
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -478,6 +542,32 @@ func (in *ContextEntry) DeepCopy() *ContextEntry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataOverride) DeepCopyInto(out *DataOverride) {
+	*out = *in
+	if in.AllowedPaths != nil {
+		in, out := &in.AllowedPaths, &out.AllowedPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataOverride.
+func (in *DataOverride) DeepCopy() *DataOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(DataOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Deny) DeepCopyInto(out *Deny) {
 	*out = *in
@@ -615,11 +705,26 @@ func (in *ForEachValidation) DeepCopy() *ForEachValidation {
 func (in *Generation) DeepCopyInto(out *Generation) {
 	*out = *in
 	out.ResourceSpec = in.ResourceSpec
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RawData != nil {
 		in, out := &in.RawData, &out.RawData
 		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DataOverride != nil {
+		in, out := &in.DataOverride, &out.DataOverride
+		*out = new(DataOverride)
+		(*in).DeepCopyInto(*out)
+	}
 	out.Clone = in.Clone
 	in.CloneList.DeepCopyInto(&out.CloneList)
 	return
@@ -657,6 +762,43 @@ func (in IgnoreFieldList) DeepCopy() IgnoreFieldList {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeader) DeepCopyInto(out *HTTPHeader) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeader.
+func (in *HTTPHeader) DeepCopy() *HTTPHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageAllowListValidation) DeepCopyInto(out *ImageAllowListValidation) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageAllowListValidation.
+func (in *ImageAllowListValidation) DeepCopy() *ImageAllowListValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageAllowListValidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageExtractorConfig) DeepCopyInto(out *ImageExtractorConfig) {
 	*out = *in
@@ -786,6 +928,11 @@ func (in *ImageVerification) DeepCopyInto(out *ImageVerification) {
 			(*out)[key] = val
 		}
 	}
+	if in.MaxSignatureAge != nil {
+		in, out := &in.MaxSignatureAge, &out.MaxSignatureAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.ImageRegistryCredentials != nil {
 		in, out := &in.ImageRegistryCredentials, &out.ImageRegistryCredentials
 		*out = new(ImageRegistryCredentials)
@@ -804,6 +951,37 @@ func (in *ImageVerification) DeepCopy() *ImageVerification {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Immutable) DeepCopyInto(out *Immutable) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]TimeWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Immutable.
+func (in *Immutable) DeepCopy() *Immutable {
+	if in == nil {
+		return nil
+	}
+	out := new(Immutable)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeylessAttestor) DeepCopyInto(out *KeylessAttestor) {
 	*out = *in
@@ -922,6 +1100,11 @@ func (in *Mutation) DeepCopyInto(out *Mutation) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ResolveDigest != nil {
+		in, out := &in.ResolveDigest, &out.ResolveDigest
+		*out = new(ResolveDigest)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -961,6 +1144,27 @@ func (in *ObjectFieldBinding) DeepCopy() *ObjectFieldBinding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OwnerReferenceFilter) DeepCopyInto(out *OwnerReferenceFilter) {
+	*out = *in
+	if in.Controller != nil {
+		in, out := &in.Controller, &out.Controller
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OwnerReferenceFilter.
+func (in *OwnerReferenceFilter) DeepCopy() *OwnerReferenceFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(OwnerReferenceFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSecurity) DeepCopyInto(out *PodSecurity) {
 	*out = *in
@@ -1097,6 +1301,22 @@ func (in *PolicyStatus) DeepCopy() *PolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Rego) DeepCopyInto(out *Rego) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rego.
+func (in *Rego) DeepCopy() *Rego {
+	if in == nil {
+		return nil
+	}
+	out := new(Rego)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rekor) DeepCopyInto(out *Rekor) {
 	*out = *in
@@ -1134,6 +1354,42 @@ func (in *RequestData) DeepCopy() *RequestData {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolveDigest) DeepCopyInto(out *ResolveDigest) {
+	*out = *in
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImageRegistryCredentials != nil {
+		in, out := &in.ImageRegistryCredentials, &out.ImageRegistryCredentials
+		*out = new(ImageRegistryCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CacheTTL != nil {
+		in, out := &in.CacheTTL, &out.CacheTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(FailurePolicyType)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolveDigest.
+func (in *ResolveDigest) DeepCopy() *ResolveDigest {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolveDigest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceDescription) DeepCopyInto(out *ResourceDescription) {
 	*out = *in
@@ -1174,6 +1430,18 @@ func (in *ResourceDescription) DeepCopyInto(out *ResourceDescription) {
 		*out = make([]AdmissionOperation, len(*in))
 		copy(*out, *in)
 	}
+	if in.OwnerReferences != nil {
+		in, out := &in.OwnerReferences, &out.OwnerReferences
+		*out = make([]OwnerReferenceFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1253,6 +1521,11 @@ func (in *Rule) DeepCopyInto(out *Rule) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.MatchResources.DeepCopyInto(&out.MatchResources)
 	in.ExcludeResources.DeepCopyInto(&out.ExcludeResources)
 	if in.ImageExtractors != nil {
@@ -1290,6 +1563,23 @@ func (in *Rule) DeepCopyInto(out *Rule) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SkipRequestsFromSameServiceAccount != nil {
+		in, out := &in.SkipRequestsFromSameServiceAccount, &out.SkipRequestsFromSameServiceAccount
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReportProperties != nil {
+		in, out := &in.ReportProperties, &out.ReportProperties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -1338,6 +1628,11 @@ func (in *SecretReference) DeepCopy() *SecretReference {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceCall) DeepCopyInto(out *ServiceCall) {
 	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HTTPHeader, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1403,6 +1698,16 @@ func (in *Spec) DeepCopyInto(out *Spec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ReinvocationPolicy != nil {
+		in, out := &in.ReinvocationPolicy, &out.ReinvocationPolicy
+		*out = new(admissionregistrationv1.ReinvocationPolicyType)
+		**out = **in
+	}
+	if in.FailFast != nil {
+		in, out := &in.FailFast, &out.FailFast
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -1476,6 +1781,22 @@ func (in *TargetResourceSpec) DeepCopy() *TargetResourceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeWindow) DeepCopyInto(out *TimeWindow) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeWindow.
+func (in *TimeWindow) DeepCopy() *TimeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserInfo) DeepCopyInto(out *UserInfo) {
 	*out = *in
@@ -1526,6 +1847,11 @@ func (in *ValidatingAdmissionPolicyStatus) DeepCopy() *ValidatingAdmissionPolicy
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Validation) DeepCopyInto(out *Validation) {
 	*out = *in
+	if in.FailureAction != nil {
+		in, out := &in.FailureAction, &out.FailureAction
+		*out = new(ValidationFailureAction)
+		**out = **in
+	}
 	if in.Manifests != nil {
 		in, out := &in.Manifests, &out.Manifests
 		*out = new(Manifests)
@@ -1563,6 +1889,26 @@ func (in *Validation) DeepCopyInto(out *Validation) {
 		*out = new(CEL)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Immutable != nil {
+		in, out := &in.Immutable, &out.Immutable
+		*out = new(Immutable)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImageAllowList != nil {
+		in, out := &in.ImageAllowList, &out.ImageAllowList
+		*out = new(ImageAllowListValidation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Baseline != nil {
+		in, out := &in.Baseline, &out.Baseline
+		*out = new(Baseline)
+		**out = **in
+	}
+	if in.Rego != nil {
+		in, out := &in.Rego, &out.Rego
+		*out = new(Rego)
+		**out = **in
+	}
 	return
 }
 
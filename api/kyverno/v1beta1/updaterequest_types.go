@@ -20,6 +20,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	admissionv1 "k8s.io/api/admission/v1"
 	authenticationv1 "k8s.io/api/authentication/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -39,7 +40,63 @@ type UpdateRequestStatus struct {
 	// Will be used during clean up resources.
 	GeneratedResources []kyvernov1.ResourceSpec `json:"generatedResources,omitempty" yaml:"generatedResources,omitempty"`
 
+	// Namespaces tracks the generation state in each target namespace when a generate rule
+	// targets multiple namespaces.
+	// +optional
+	Namespaces []NamespaceStatus `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+
+	// RetryCount is the number of failed attempts to process the update request.
 	RetryCount int `json:"retryCount,omitempty" yaml:"retryCount,omitempty"`
+
+	// Conditions describe the processing state of the update request, the reason of a failed
+	// condition tells why the last attempt failed.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+
+	// LastError is the error of the most recent failed attempt, it is kept after a later attempt succeeds.
+	// +optional
+	LastError string `json:"lastError,omitempty" yaml:"lastError,omitempty"`
+
+	// AttemptedResources are the downstream resources processed by the last attempt,
+	// when the attempt failed they are the resources that could not be applied.
+	// +optional
+	AttemptedResources []kyvernov1.ResourceSpec `json:"attemptedResources,omitempty" yaml:"attemptedResources,omitempty"`
+
+	// Targets tracks the generation state of each downstream resource processed by the last attempt,
+	// a downstream resource that could not be applied does not prevent the others from being applied.
+	// +optional
+	Targets []TargetStatus `json:"targets,omitempty" yaml:"targets,omitempty"`
+}
+
+// NamespaceStatus is the generation state in one of the target namespaces.
+type NamespaceStatus struct {
+	// Namespace is the target namespace.
+	Namespace string `json:"namespace" yaml:"namespace"`
+
+	// State represents the generation state in the namespace.
+	State UpdateRequestState `json:"state" yaml:"state"`
+
+	// Message is the error message when the generation failed in the namespace.
+	// +optional
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// TargetStatus is the generation state of one of the downstream resources.
+type TargetStatus struct {
+	// Resource identifies the downstream resource.
+	Resource kyvernov1.ResourceSpec `json:"resource" yaml:"resource"`
+
+	// State represents the generation state of the downstream resource.
+	State UpdateRequestState `json:"state" yaml:"state"`
+
+	// Message is the error message when the downstream resource could not be applied.
+	// +optional
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+
+	// Applied is the downstream resource applied by the last attempt, with the defaults declared by its schema set.
+	// It is only recorded for resources generated from data, generated Secrets are not recorded.
+	// +optional
+	Applied *apiextv1.JSON `json:"applied,omitempty" yaml:"applied,omitempty"`
 }
 
 // +genclient
@@ -152,6 +209,20 @@ const (
 	Skip UpdateRequestState = "Skip"
 )
 
+// URConditionProcessed is the condition type telling whether the last attempt to process the update request succeeded.
+const URConditionProcessed = "Processed"
+
+// Reasons of the Processed condition.
+const (
+	URReasonSucceeded = "Succeeded"
+	URReasonSkipped   = "Skipped"
+	URReasonFailed    = "Failed"
+	URReasonForbidden = "Forbidden"
+	URReasonNotFound  = "NotFound"
+	URReasonConflict  = "Conflict"
+	URReasonInvalid   = "Invalid"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
 
@@ -181,3 +252,34 @@ func (s *UpdateRequestSpec) GetSynchronize() bool {
 func (s *UpdateRequestSpec) GetResource() kyvernov1.ResourceSpec {
 	return s.Resource
 }
+
+// GetTarget returns the status of the given downstream resource, nil if it was not processed by the last attempt.
+func (s *UpdateRequestStatus) GetTarget(resource kyvernov1.ResourceSpec) *TargetStatus {
+	for i := range s.Targets {
+		if s.Targets[i].Resource == resource {
+			return &s.Targets[i]
+		}
+	}
+	return nil
+}
+
+// TargetsInState returns the downstream resources in the given state.
+func (s *UpdateRequestStatus) TargetsInState(state UpdateRequestState) []kyvernov1.ResourceSpec {
+	var resources []kyvernov1.ResourceSpec
+	for _, target := range s.Targets {
+		if target.State == state {
+			resources = append(resources, target.Resource)
+		}
+	}
+	return resources
+}
+
+// FailedTargets returns the downstream resources that could not be applied by the last attempt.
+func (s *UpdateRequestStatus) FailedTargets() []kyvernov1.ResourceSpec {
+	return s.TargetsInState(Failed)
+}
+
+// IsPartiallyFailed tells whether the last attempt applied some downstream resources but failed on others.
+func (s *UpdateRequestStatus) IsPartiallyFailed() bool {
+	return len(s.FailedTargets()) != 0 && len(s.TargetsInState(Completed)) != 0
+}
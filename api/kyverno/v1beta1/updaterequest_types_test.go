@@ -0,0 +1,28 @@
+package v1beta1
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+)
+
+func Test_UpdateRequestStatus_Targets(t *testing.T) {
+	settings := kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Namespace: "tenant", Name: "settings"}
+	regcred := kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "Secret", Namespace: "tenant", Name: "regcred"}
+	quota := kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ResourceQuota", Namespace: "tenant", Name: "quota"}
+	status := UpdateRequestStatus{Targets: []TargetStatus{
+		{Resource: settings, State: Completed},
+		{Resource: regcred, State: Failed, Message: "forbidden"},
+		{Resource: quota, State: Skip},
+	}}
+	assert.DeepEqual(t, status.GetTarget(regcred), &TargetStatus{Resource: regcred, State: Failed, Message: "forbidden"})
+	assert.Assert(t, status.GetTarget(kyvernov1.ResourceSpec{Kind: "Secret", Name: "other"}) == nil)
+	assert.DeepEqual(t, status.FailedTargets(), []kyvernov1.ResourceSpec{regcred})
+	assert.DeepEqual(t, status.TargetsInState(Skip), []kyvernov1.ResourceSpec{quota})
+	assert.Assert(t, status.IsPartiallyFailed())
+
+	status.Targets[0].State = Failed
+	assert.Assert(t, !status.IsPartiallyFailed())
+	assert.Assert(t, !(&UpdateRequestStatus{}).IsPartiallyFailed())
+}
@@ -16,6 +16,8 @@ limitations under the License.
 package v2
 
 import (
+	"time"
+
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	"github.com/kyverno/kyverno/ext/wildcard"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -64,6 +66,11 @@ type PolicyExceptionSpec struct {
 
 	// Exceptions is a list policy/rules to be excluded
 	Exceptions []Exception `json:"exceptions" yaml:"exceptions"`
+
+	// ExpiresAt is the time after which the exception is no longer applied.
+	// Expired exceptions are ignored by the engine and flagged in policy reports.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty"`
 }
 
 func (p *PolicyExceptionSpec) BackgroundProcessingEnabled() bool {
@@ -73,6 +80,11 @@ func (p *PolicyExceptionSpec) BackgroundProcessingEnabled() bool {
 	return *p.Background
 }
 
+// HasExpired returns true if the exception expired at the given time
+func (p *PolicyExceptionSpec) HasExpired(now time.Time) bool {
+	return p.ExpiresAt != nil && !now.Before(p.ExpiresAt.Time)
+}
+
 // Validate implements programmatic validation
 func (p *PolicyExceptionSpec) Validate(path *field.Path) (errs field.ErrorList) {
 	if p.BackgroundProcessingEnabled() {
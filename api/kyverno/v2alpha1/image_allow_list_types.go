@@ -0,0 +1,85 @@
+/*
+Copyright 2020 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=ial,categories=kyverno
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ImageAllowList declares the container images allowed in the cluster.
+// Validate rules reference allow lists by name with `validate.imageAllowList`.
+type ImageAllowList struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the allowed registries, repositories and image digests.
+	Spec ImageAllowListSpec `json:"spec"`
+}
+
+// GetSpec returns the image allow list spec
+func (l *ImageAllowList) GetSpec() *ImageAllowListSpec {
+	return &l.Spec
+}
+
+// ImageAllowListSpec declares the images allowed by an ImageAllowList.
+// An image is allowed when its registry, its repository or its digest is allowed.
+type ImageAllowListSpec struct {
+	// Registries are the registries any image can be pulled from (e.g. `ghcr.io`).
+	// Each registry supports wildcard characters "*" (matches zero or many characters) and "?" (at least one character).
+	// +optional
+	Registries []string `json:"registries,omitempty"`
+
+	// Repositories are the repositories any image can be pulled from, including the registry (e.g. `ghcr.io/kyverno/*`).
+	// Each repository supports wildcard characters "*" (matches zero or many characters) and "?" (at least one character).
+	// +optional
+	Repositories []string `json:"repositories,omitempty"`
+
+	// Images are the images allowed only when pinned to one of their digests.
+	// +optional
+	Images []AllowedImage `json:"images,omitempty"`
+
+	// RequireDigest requires every image to be referenced by digest, including the images
+	// pulled from allowed registries and repositories.
+	// +optional
+	RequireDigest bool `json:"requireDigest,omitempty"`
+}
+
+// AllowedImage is an image allowed when referenced by one of its digests.
+type AllowedImage struct {
+	// Repository is the image repository, including the registry (e.g. `ghcr.io/kyverno/kyverno`).
+	Repository string `json:"repository"`
+
+	// Digests are the allowed digests of the image (e.g. `sha256:...`).
+	Digests []string `json:"digests"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ImageAllowListList is a list of ImageAllowList instances.
+type ImageAllowListList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ImageAllowList `json:"items"`
+}
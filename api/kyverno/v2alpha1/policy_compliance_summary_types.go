@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=pcsum,categories=kyverno
+// +kubebuilder:printcolumn:name="Pass",type=integer,JSONPath=".summary.pass"
+// +kubebuilder:printcolumn:name="Fail",type=integer,JSONPath=".summary.fail"
+// +kubebuilder:printcolumn:name="Warn",type=integer,JSONPath=".summary.warn"
+// +kubebuilder:printcolumn:name="Error",type=integer,JSONPath=".summary.error"
+// +kubebuilder:printcolumn:name="Skip",type=integer,JSONPath=".summary.skip"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// PolicyComplianceSummary aggregates the results of all policy reports in the cluster.
+// It is computed periodically by the reports controller and must not be edited.
+type PolicyComplianceSummary struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// LastUpdateTime is the time the summary was last computed.
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// Summary aggregates the results of all policy reports.
+	// +optional
+	Summary ComplianceSummary `json:"summary,omitempty"`
+
+	// Policies contains the results aggregated by policy.
+	// +optional
+	Policies []ComplianceGroup `json:"policies,omitempty"`
+
+	// Categories contains the results aggregated by policy category.
+	// +optional
+	Categories []ComplianceGroup `json:"categories,omitempty"`
+
+	// Severities contains the results aggregated by severity.
+	// +optional
+	Severities []ComplianceGroup `json:"severities,omitempty"`
+
+	// Namespaces contains the results aggregated by namespace, cluster wide results are not included.
+	// +optional
+	Namespaces []ComplianceGroup `json:"namespaces,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PolicyComplianceSummaryList is a list of PolicyComplianceSummary instances.
+type PolicyComplianceSummaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []PolicyComplianceSummary `json:"items"`
+}
+
+// ComplianceGroup stores the aggregated results of a group of report results.
+type ComplianceGroup struct {
+	// Name is the name of the group (policy, category, severity or namespace).
+	Name string `json:"name"`
+
+	// Summary aggregates the results of the group.
+	Summary ComplianceSummary `json:"summary"`
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2020 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// PolicySetConditionReady is the condition type reported once all selected member clusters are synced
+	PolicySetConditionReady = "Ready"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=polset,categories=kyverno
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type == "Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// PolicySet distributes a set of ClusterPolicies from a management cluster to member clusters.
+type PolicySet struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the policies to distribute and the clusters they are placed on.
+	Spec PolicySetSpec `json:"spec"`
+
+	// Status contains the sync state and compliance summary of every member cluster.
+	// +optional
+	Status PolicySetStatus `json:"status,omitempty"`
+}
+
+// GetSpec returns the policy set spec
+func (p *PolicySet) GetSpec() *PolicySetSpec {
+	return &p.Spec
+}
+
+// GetStatus returns the policy set status
+func (p *PolicySet) GetStatus() *PolicySetStatus {
+	return &p.Status
+}
+
+// Validate implements programmatic validation
+func (p *PolicySet) Validate() (errs field.ErrorList) {
+	errs = append(errs, p.Spec.Validate(field.NewPath("spec"))...)
+	return errs
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PolicySetList is a list of PolicySet instances.
+type PolicySetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []PolicySet `json:"items"`
+}
+
+// PolicySetSpec stores the policies to distribute and their placement.
+type PolicySetSpec struct {
+	// Policies is a list of ClusterPolicy names to distribute.
+	// +optional
+	Policies []string `json:"policies,omitempty"`
+
+	// PolicySelector selects additional ClusterPolicies to distribute by label.
+	// +optional
+	PolicySelector *metav1.LabelSelector `json:"policySelector,omitempty"`
+
+	// Placement defines the member clusters the policies are distributed to.
+	Placement Placement `json:"placement"`
+}
+
+// Validate implements programmatic validation
+func (p *PolicySetSpec) Validate(path *field.Path) (errs field.ErrorList) {
+	if len(p.Policies) == 0 && p.PolicySelector == nil {
+		errs = append(errs, field.Required(path, "either policies or policySelector must be specified"))
+	}
+	if p.PolicySelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(p.PolicySelector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("policySelector"), p.PolicySelector, err.Error()))
+		}
+	}
+	errs = append(errs, p.Placement.Validate(path.Child("placement"))...)
+	return errs
+}
+
+// Placement selects member clusters.
+type Placement struct {
+	// ClusterSelector selects member clusters by the labels of their kubeconfig secret.
+	// An empty selector selects all member clusters.
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (p *Placement) Validate(path *field.Path) (errs field.ErrorList) {
+	if p.ClusterSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(p.ClusterSelector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("clusterSelector"), p.ClusterSelector, err.Error()))
+		}
+	}
+	return errs
+}
+
+// PolicySetStatus stores the status of the policy set.
+type PolicySetStatus struct {
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Clusters contains the status of every selected member cluster.
+	// +optional
+	Clusters []ClusterStatus `json:"clusters,omitempty"`
+}
+
+// ClusterStatus stores the sync state of a member cluster.
+type ClusterStatus struct {
+	// Name is the name of the member cluster.
+	Name string `json:"name"`
+
+	// Synced indicates if the policies were successfully applied to the member cluster.
+	Synced bool `json:"synced"`
+
+	// Message contains the last sync error, if any.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Summary aggregates the cluster policy report results of the distributed policies.
+	// +optional
+	Summary ComplianceSummary `json:"summary,omitempty"`
+}
+
+// ComplianceSummary provides a summary of report results.
+type ComplianceSummary struct {
+	// +optional
+	Pass int `json:"pass"`
+
+	// +optional
+	Fail int `json:"fail"`
+
+	// +optional
+	Warn int `json:"warn"`
+
+	// +optional
+	Error int `json:"error"`
+
+	// +optional
+	Skip int `json:"skip"`
+}
@@ -62,8 +62,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&CleanupPolicyList{},
 		&ClusterCleanupPolicy{},
 		&ClusterCleanupPolicyList{},
+		&ImageAllowList{},
+		&ImageAllowListList{},
+		&PolicyComplianceSummary{},
+		&PolicyComplianceSummaryList{},
 		&PolicyException{},
 		&PolicyExceptionList{},
+		&PolicySet{},
+		&PolicySetList{},
 	)
 	// AddToGroupVersion allows the serialization of client types like ListOptions.
 	v1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -12,6 +12,29 @@ type Validation struct {
 	// +optional
 	Message string `json:"message,omitempty" yaml:"message,omitempty"`
 
+	// MessageTemplate specifies a multi-line message replacing the message of failed rule results.
+	// Variables are substituted and line breaks are preserved in admission denials.
+	// +optional
+	MessageTemplate string `json:"messageTemplate,omitempty" yaml:"messageTemplate,omitempty"`
+
+	// RemediationURL links to documentation describing how to fix resources failing the rule.
+	// It is added to admission denials and policy report results.
+	// +optional
+	RemediationURL string `json:"remediationUrl,omitempty" yaml:"remediationUrl,omitempty"`
+
+	// FailureAction overrides the validationFailureAction of the policy, including its namespace overrides,
+	// for this rule. It allows a policy to mix rules blocking admission requests with rules that are only reported.
+	// Allowed values are Audit or Enforce.
+	// +optional
+	// +kubebuilder:validation:Enum=Audit;Enforce
+	FailureAction *kyvernov1.ValidationFailureAction `json:"failureAction,omitempty" yaml:"failureAction,omitempty"`
+
+	// Severity overrides the severity of the rule results in policy reports, the severity is taken
+	// from the policies.kyverno.io/severity annotation of the policy when not set.
+	// +optional
+	// +kubebuilder:validation:Enum=critical;high;medium;low;info
+	Severity string `json:"severity,omitempty" yaml:"severity,omitempty"`
+
 	// Manifest specifies conditions for manifest verification
 	// +optional
 	Manifests *kyvernov1.Manifests `json:"manifests,omitempty" yaml:"manifests,omitempty"`
@@ -25,7 +48,8 @@ type Validation struct {
 	RawPattern *apiextv1.JSON `json:"pattern,omitempty" yaml:"pattern,omitempty"`
 
 	// AnyPattern specifies list of validation patterns. At least one of the patterns
-	// must be satisfied for the validation rule to succeed.
+	// must be satisfied for the validation rule to succeed. When used with pattern,
+	// the resource must satisfy the pattern and at least one of the patterns.
 	// +optional
 	RawAnyPattern *apiextv1.JSON `json:"anyPattern,omitempty" yaml:"anyPattern,omitempty"`
 
@@ -41,6 +65,31 @@ type Validation struct {
 	// CEL allows validation checks using the Common Expression Language (https://kubernetes.io/docs/reference/using-api/cel/).
 	// +optional
 	CEL *kyvernov1.CEL `json:"cel,omitempty" yaml:"cel,omitempty"`
+
+	// Immutable denies updates to selected fields of a resource, either always, during
+	// configured time windows or once the existing resource has matching labels.
+	// +optional
+	Immutable *kyvernov1.Immutable `json:"immutable,omitempty" yaml:"immutable,omitempty"`
+
+	// ImageAllowList checks the container images of the resource against ImageAllowList resources.
+	// +optional
+	ImageAllowList *kyvernov1.ImageAllowListValidation `json:"imageAllowList,omitempty" yaml:"imageAllowList,omitempty"`
+
+	// Baseline records the values of a field observed across the cluster in a ConfigMap
+	// and, once switched to enforce mode, rejects values that were not recorded.
+	// +optional
+	Baseline *kyvernov1.Baseline `json:"baseline,omitempty" yaml:"baseline,omitempty"`
+
+	// Rego evaluates an embedded Rego module against the admission request.
+	// This is an experimental feature meant to ease migrations from OPA.
+	// +optional
+	Rego *kyvernov1.Rego `json:"rego,omitempty" yaml:"rego,omitempty"`
+
+	// ServerMetadata controls the metadata populated by the API server (managedFields, resourceVersion, generation,
+	// uid, creationTimestamp and selfLink) in pattern evaluation. Strip removes it from the resource and the old
+	// resource before patterns are evaluated, Preserve evaluates patterns against it. Defaults to Strip.
+	// +optional
+	ServerMetadata kyvernov1.ServerMetadataMode `json:"serverMetadata,omitempty" yaml:"serverMetadata,omitempty"`
 }
 
 // ConditionOperator is the operation performed on condition key and value.
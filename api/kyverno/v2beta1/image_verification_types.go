@@ -2,6 +2,7 @@ package v2beta1
 
 import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -46,6 +47,18 @@ type ImageVerification struct {
 	// +kubebuilder:validation:Optional
 	VerifyDigest bool `json:"verifyDigest" yaml:"verifyDigest"`
 
+	// VerifyTagDigest validates that the image tag still resolves to the signed digest, detecting
+	// tags moved after signing. Only applies to images referenced by tag.
+	// Verification responses are not cached when set.
+	// +kubebuilder:validation:Optional
+	VerifyTagDigest bool `json:"verifyTagDigest,omitempty" yaml:"verifyTagDigest,omitempty"`
+
+	// MaxSignatureAge is the maximum age of the image signatures, older signatures are rejected.
+	// The signing time is read from the transparency log entry of Cosign signatures and from the
+	// signed attributes of Notary signatures. Verification responses are not cached when set.
+	// +kubebuilder:validation:Optional
+	MaxSignatureAge *metav1.Duration `json:"maxSignatureAge,omitempty" yaml:"maxSignatureAge,omitempty"`
+
 	// Required validates that images are verified i.e. have matched passed a signature or attestation check.
 	// +kubebuilder:default=true
 	// +kubebuilder:validation:Optional
@@ -73,16 +86,30 @@ func (iv *ImageVerification) Validate(isAuditFailureAction bool, path *field.Pat
 		errs = append(errs, field.Invalid(path, iv, "An image reference is required"))
 	}
 
+	if iv.MaxSignatureAge != nil && iv.MaxSignatureAge.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("maxSignatureAge"), iv.MaxSignatureAge.Duration.String(), "maxSignatureAge must be a positive duration"))
+	}
+
 	asPath := path.Child("attestations")
 	for i, attestation := range copy.Attestations {
 		attestationErrors := attestation.Validate(asPath.Index(i))
 		errs = append(errs, attestationErrors...)
+		for j, as := range attestation.Attestors {
+			if len(as.ImageReferences) != 0 {
+				errs = append(errs, field.Invalid(asPath.Index(i).Child("attestors").Index(j).Child("imageReferences"), as.ImageReferences, "imageReferences is not supported for the attestors of attestations"))
+			}
+		}
 	}
 
 	attestorsPath := path.Child("attestors")
 	for i, as := range copy.Attestors {
 		attestorErrors := as.Validate(attestorsPath.Index(i))
 		errs = append(errs, attestorErrors...)
+		for j, imageReference := range as.ImageReferences {
+			if imageReference == "" {
+				errs = append(errs, field.Invalid(attestorsPath.Index(i).Child("imageReferences").Index(j), imageReference, "An image reference pattern can't be empty"))
+			}
+		}
 	}
 
 	if iv.Type == kyvernov1.Notary {
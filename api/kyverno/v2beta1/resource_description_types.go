@@ -23,6 +23,7 @@ type ResourceDescription struct {
 
 	// Namespaces is a list of namespaces names. Each name supports wildcard characters
 	// "*" (matches zero or many characters) and "?" (at least one character).
+	// Names prefixed with "!" exclude matching namespaces and take precedence over the other names.
 	// +optional
 	Namespaces []string `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
 
@@ -50,6 +51,19 @@ type ResourceDescription struct {
 	// Operations can contain values ["CREATE, "UPDATE", "CONNECT", "DELETE"], which are used to match a specific action.
 	// +optional
 	Operations []kyvernov1.AdmissionOperation `json:"operations,omitempty" yaml:"operations,omitempty"`
+
+	// OwnerReferences matches resources based on their owner references. A resource matches
+	// when at least one of its owner references matches one of the entries.
+	// +optional
+	OwnerReferences []kyvernov1.OwnerReferenceFilter `json:"ownerReferences,omitempty" yaml:"ownerReferences,omitempty"`
+
+	// Resources is a list of resources in the form `resource.group` (e.g. `deployments.apps`),
+	// the group can be omitted to match resources of any group. Resources are resolved using the
+	// API discovery and match in addition to the kinds. The resource and the group support wildcard
+	// characters "*" (matches zero or many characters) and "?" (at least one character), e.g.
+	// `*.example.com` matches all the resources of the `example.com` group.
+	// +optional
+	Resources []string `json:"resources,omitempty" yaml:"resources,omitempty"`
 }
 
 func (r ResourceDescription) GetOperations() []string {
@@ -75,6 +89,9 @@ func (r *ResourceDescription) Validate(path *field.Path, namespaced bool, cluste
 			}
 		}
 	}
+	errs = append(errs, kyvernov1.ValidateKinds(path.Child("kinds"), r.Kinds)...)
+	errs = append(errs, kyvernov1.ValidateResources(path.Child("resources"), r.Resources)...)
+	errs = append(errs, kyvernov1.ValidateNamespaces(path.Child("namespaces"), r.Namespaces)...)
 	if namespaced {
 		if len(r.Namespaces) > 0 {
 			errs = append(errs, field.Forbidden(path.Child("namespaces"), "Filtering namespaces not allowed in namespaced policies"))
@@ -6,6 +6,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -22,6 +23,11 @@ type Rule struct {
 	// +optional
 	Context []kyvernov1.ContextEntry `json:"context,omitempty" yaml:"context,omitempty"`
 
+	// DependsOn lists the names of the rules of the same policy that must be applied before this rule.
+	// Mutation rules are executed after the rules they depend on, dependencies must not form a cycle.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+
 	// MatchResources defines when this policy rule should be applied. The match
 	// criteria can include resource information (e.g. kind, name, namespace, labels)
 	// and admission review request information like the user name or role.
@@ -46,7 +52,7 @@ type Rule struct {
 	RawAnyAllConditions *AnyAllConditions `json:"preconditions,omitempty" yaml:"preconditions,omitempty"`
 
 	// CELPreconditions are used to determine if a policy rule should be applied by evaluating a
-	// set of CEL conditions. It can only be used with the validate.cel subrule
+	// set of CEL conditions. They are evaluated against the admission request
 	// +optional
 	CELPreconditions []admissionregistrationv1.MatchCondition `json:"celPreconditions,omitempty" yaml:"celPreconditions,omitempty"`
 
@@ -72,6 +78,22 @@ type Rule struct {
 	// +kubebuilder:default=true
 	// +kubebuilder:validation:Optional
 	SkipBackgroundRequests bool `json:"skipBackgroundRequests,omitempty" yaml:"skipBackgroundRequests,omitempty"`
+
+	// SkipRequestsFromSameServiceAccount bypasses admission requests that are sent by the Kyverno
+	// admission controller service account, preventing feedback loops when Kyverno modifies resources.
+	// It is disabled unless set to "true", mutate, validate and verifyImages rules are then
+	// not applied to those requests.
+	// +kubebuilder:validation:Optional
+	SkipRequestsFromSameServiceAccount *bool `json:"skipRequestsFromSameServiceAccount,omitempty" yaml:"skipRequestsFromSameServiceAccount,omitempty"`
+
+	// ReportProperties are additional properties added to the policy report results of the rule,
+	// values support variable substitution.
+	// +optional
+	ReportProperties map[string]string `json:"reportProperties,omitempty" yaml:"reportProperties,omitempty"`
+
+	// Timeout is the maximum duration allowed to process the rule, including loading its context entries.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
 }
 
 // HasMutate checks for mutate rule
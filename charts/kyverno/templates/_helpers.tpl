@@ -29,6 +29,9 @@
   {{- $flags = append $flags (print "--backgroundScan=" .enabled) -}}
   {{- $flags = append $flags (print "--backgroundScanWorkers=" .backgroundScanWorkers) -}}
   {{- $flags = append $flags (print "--backgroundScanInterval=" .backgroundScanInterval) -}}
+  {{- with .backgroundScanCache -}}
+    {{- $flags = append $flags (print "--backgroundScanCache=" .enabled) -}}
+  {{- end -}}
   {{- $flags = append $flags (print "--skipResourceFilters=" .skipResourceFilters) -}}
 {{- end -}}
 {{- with .configMapCaching -}}
@@ -64,6 +67,9 @@
 {{- with .protectManagedResources -}}
   {{- $flags = append $flags (print "--protectManagedResources=" .enabled) -}}
 {{- end -}}
+{{- with .regoRules -}}
+  {{- $flags = append $flags (print "--enableRegoRules=" .enabled) -}}
+{{- end -}}
 {{- with .reports -}}
   {{- $flags = append $flags (print "--reportsChunkSize=" .chunkSize) -}}
 {{- end -}}
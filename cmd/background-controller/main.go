@@ -9,22 +9,31 @@ import (
 	"sync"
 	"time"
 
+	"github.com/kyverno/kyverno/api/kyverno"
 	"github.com/kyverno/kyverno/cmd/internal"
 	"github.com/kyverno/kyverno/pkg/background"
+	"github.com/kyverno/kyverno/pkg/background/common"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
+	baselinecontroller "github.com/kyverno/kyverno/pkg/controllers/baseline"
 	policymetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/policy"
+	ocipolicycontroller "github.com/kyverno/kyverno/pkg/controllers/ocipolicy"
+	policysetcontroller "github.com/kyverno/kyverno/pkg/controllers/policyset"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/informers"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/policy"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"k8s.io/apimachinery/pkg/labels"
 	kubeinformers "k8s.io/client-go/informers"
+	corev1informers "k8s.io/client-go/informers/core/v1"
 	kyamlopenapi "sigs.k8s.io/kustomize/kyaml/openapi"
 )
 
@@ -36,6 +45,7 @@ func createrLeaderControllers(
 	eng engineapi.Engine,
 	genWorkers int,
 	kubeInformer kubeinformers.SharedInformerFactory,
+	memberClusterSecrets corev1informers.SecretInformer,
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
 	kyvernoClient versioned.Interface,
 	dynamicClient dclient.Interface,
@@ -44,6 +54,11 @@ func createrLeaderControllers(
 	eventGenerator event.Interface,
 	jp jmespath.Interface,
 	backgroundScanInterval time.Duration,
+	retryPolicy common.RetryPolicy,
+	enablePolicySets bool,
+	rclient registryclient.Client,
+	ociPolicySources []ocipolicycontroller.Source,
+	ociPolicySourcesInterval time.Duration,
 ) ([]internal.Controller, error) {
 	policyCtrl, err := policy.NewPolicyController(
 		kyvernoClient,
@@ -74,11 +89,45 @@ func createrLeaderControllers(
 		eventGenerator,
 		configuration,
 		jp,
+		retryPolicy,
 	)
-	return []internal.Controller{
+	baselineController := baselinecontroller.NewController(
+		dynamicClient,
+		dynamicClient.GetKubeClient().CoreV1(),
+		kyvernoInformer.Kyverno().V1().Policies(),
+		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+		kubeInformer.Core().V1().Namespaces(),
+		eng,
+		configuration,
+		jp,
+		backgroundScanInterval,
+	)
+	leaderControllers := []internal.Controller{
 		internal.NewController("policy-controller", policyCtrl, 2),
 		internal.NewController("background-controller", backgroundController, genWorkers),
-	}, err
+		internal.NewController(baselinecontroller.ControllerName, baselineController, baselinecontroller.Workers),
+	}
+	if enablePolicySets {
+		policySetController := policysetcontroller.NewController(
+			kyvernoClient,
+			kyvernoInformer.Kyverno().V2alpha1().PolicySets(),
+			kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+			memberClusterSecrets,
+			config.KyvernoNamespace(),
+			policysetcontroller.NewClientFactory(),
+		)
+		leaderControllers = append(leaderControllers, internal.NewController(policysetcontroller.ControllerName, policySetController, policysetcontroller.Workers))
+	}
+	if len(ociPolicySources) != 0 {
+		ociPolicyController := ocipolicycontroller.NewController(
+			kyvernoClient,
+			ociPolicySources,
+			ocipolicycontroller.NewPullFunc(rclient),
+			ociPolicySourcesInterval,
+		)
+		leaderControllers = append(leaderControllers, internal.NewController(ocipolicycontroller.ControllerName, ociPolicyController, ocipolicycontroller.Workers))
+	}
+	return leaderControllers, err
 }
 
 func main() {
@@ -87,12 +136,25 @@ func main() {
 		maxQueuedEvents          int
 		omitEvents               string
 		maxAPICallResponseLength int64
+		retryPolicy              = common.DefaultRetryPolicy
+		enablePolicySets         bool
+		ociPolicySources         string
+		ociPolicySourcesKey      string
+		ociPolicySourcesInterval time.Duration
 	)
 	flagset := flag.NewFlagSet("updaterequest-controller", flag.ExitOnError)
 	flagset.IntVar(&genWorkers, "genWorkers", 10, "Workers for the background controller.")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
 	flagset.StringVar(&omitEvents, "omit-events", "", "Set this flag to a comma sperated list of PolicyViolation, PolicyApplied, PolicyError, PolicySkipped to disable events, e.g. --omit-events=PolicyApplied,PolicyViolation")
 	flagset.Int64Var(&maxAPICallResponseLength, "maxAPICallResponseLength", 2*1000*1000, "Maximum allowed response size from API Calls. A value of 0 bypasses checks (not recommended).")
+	flagset.IntVar(&retryPolicy.MaxAttempts, "retryMaxAttempts", retryPolicy.MaxAttempts, "Maximum attempts to process a failed update request before giving up. A value of 0 retries forever.")
+	flagset.DurationVar(&retryPolicy.Backoff, "retryBackoff", retryPolicy.Backoff, "Delay before retrying a failed update request, doubled after every failed attempt.")
+	flagset.DurationVar(&retryPolicy.MaxBackoff, "retryMaxBackoff", retryPolicy.MaxBackoff, "Maximum delay between two attempts to process a failed update request.")
+	flagset.Float64Var(&retryPolicy.Jitter, "retryJitter", retryPolicy.Jitter, "Fraction of the retry delay randomly added to spread retries of failed update requests.")
+	flagset.BoolVar(&enablePolicySets, "enablePolicySets", false, "Enable distributing ClusterPolicies to member clusters with PolicySets.")
+	flagset.StringVar(&ociPolicySources, "ociPolicySources", "", "Comma separated list of OCI artifacts (pushed with kyverno oci push) the policies of the cluster are loaded from.")
+	flagset.StringVar(&ociPolicySourcesKey, "ociPolicySourcesKey", "", "Public key (path, KMS or k8s:// reference) used to verify the cosign signature of the OCI policy artifacts, artifacts are not verified when empty.")
+	flagset.DurationVar(&ociPolicySourcesInterval, "ociPolicySourcesInterval", ocipolicycontroller.SyncInterval, "Interval at which the OCI policy artifacts are pulled again.")
 
 	// config
 	appConfig := internal.NewConfiguration(
@@ -102,12 +164,17 @@ func main() {
 		internal.WithKubeconfig(),
 		internal.WithPolicyExceptions(),
 		internal.WithConfigMapCaching(),
+		internal.WithCloudMetadata(),
+		internal.WithSecretReferences(),
+		internal.WithServiceCalls(),
+		internal.WithImageAllowLists(),
 		internal.WithDeferredLoading(),
 		internal.WithRegistryClient(),
 		internal.WithLeaderElection(),
 		internal.WithKyvernoClient(),
 		internal.WithDynamicClient(),
 		internal.WithKyvernoDynamicClient(),
+		internal.WithApiServerClient(),
 		internal.WithEventsClient(),
 		internal.WithFlagSets(flagset),
 	)
@@ -133,6 +200,12 @@ func main() {
 	kyamlopenapi.Schema()
 	// informer factories
 	kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, resyncPeriod)
+	var ociSources []ocipolicycontroller.Source
+	for _, ref := range strings.Split(ociPolicySources, ",") {
+		if ref = strings.TrimSpace(ref); ref != "" {
+			ociSources = append(ociSources, ocipolicycontroller.Source{Reference: ref, Key: ociPolicySourcesKey})
+		}
+	}
 	emitEventsValues := strings.Split(omitEvents, ",")
 	if omitEvents == "" {
 		emitEventsValues = []string{}
@@ -162,6 +235,7 @@ func main() {
 		setup.KubeClient,
 		setup.KyvernoClient,
 		setup.RegistrySecretLister,
+		setup.ApiServerClient,
 		apicall.NewAPICallConfiguration(maxAPICallResponseLength),
 	)
 	// start informers and wait for cache sync
@@ -184,11 +258,22 @@ func main() {
 			// create leader factories
 			kubeInformer := kubeinformers.NewSharedInformerFactory(setup.KubeClient, resyncPeriod)
 			kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, resyncPeriod)
+			// only the member cluster secrets are watched
+			var memberClusterSecrets corev1informers.SecretInformer
+			if enablePolicySets {
+				memberClusterSecrets = informers.NewLabelledSecretInformer(
+					setup.KubeClient,
+					config.KyvernoNamespace(),
+					labels.SelectorFromSet(labels.Set{kyverno.LabelMemberCluster: "true"}),
+					resyncPeriod,
+				)
+			}
 			// create leader controllers
 			leaderControllers, err := createrLeaderControllers(
 				engine,
 				genWorkers,
 				kubeInformer,
+				memberClusterSecrets,
 				kyvernoInformer,
 				setup.KyvernoClient,
 				setup.KyvernoDynamicClient,
@@ -197,6 +282,11 @@ func main() {
 				eventGenerator,
 				setup.Jp,
 				bgscanInterval,
+				retryPolicy,
+				enablePolicySets,
+				setup.RegistryClient,
+				ociSources,
+				ociPolicySourcesInterval,
 			)
 			if err != nil {
 				logger.Error(err, "failed to create leader controllers")
@@ -207,6 +297,10 @@ func main() {
 				logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
 				os.Exit(1)
 			}
+			if memberClusterSecrets != nil && !informers.StartInformersAndWaitForCacheSync(signalCtx, logger, memberClusterSecrets) {
+				logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+				os.Exit(1)
+			}
 			// start leader controllers
 			var wg sync.WaitGroup
 			for _, controller := range leaderControllers {
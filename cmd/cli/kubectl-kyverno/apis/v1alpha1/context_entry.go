@@ -0,0 +1,21 @@
+package v1alpha1
+
+import (
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// ContextEntry declares a stubbed response for a context entry of a policy rule
+type ContextEntry struct {
+	// Name is the name of the context entry
+	Name string `json:"name"`
+
+	// APICall is the response returned in place of calling the API server or service,
+	// the JMESPath of the context entry is applied to it
+	// +optional
+	APICall *apiextv1.JSON `json:"apiCall,omitempty"`
+
+	// ImageRegistry is the response returned in place of querying the image registry,
+	// the JMESPath of the context entry is applied to it
+	// +optional
+	ImageRegistry *apiextv1.JSON `json:"imageRegistry,omitempty"`
+}
@@ -16,4 +16,7 @@ type Rule struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
 	ForeachValues map[string][]interface{} `json:"foreachValues,omitempty"`
+
+	// Context are stubbed responses for the apiCall and imageRegistry context entries of the policy rule
+	Context []ContextEntry `json:"context,omitempty"`
 }
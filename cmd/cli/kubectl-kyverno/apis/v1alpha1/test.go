@@ -23,6 +23,11 @@ type Test struct {
 	// Resources are the resource to be used in the test
 	Resources []string `json:"resources,omitempty"`
 
+	// ClusterResources are the resources existing in the cluster the test is run against,
+	// namespaces provide the labels matched by namespace selectors and config maps are
+	// loaded by configMap context entries
+	ClusterResources []string `json:"clusterResources,omitempty"`
+
 	// Variables is the values to be used in the test
 	Variables string `json:"variables,omitempty"`
 
@@ -6,8 +6,10 @@ import (
 	"io"
 	"net/url"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/go-git/go-billy/v5/memfs"
@@ -15,11 +17,14 @@ import (
 	"github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/deprecations"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/experimental"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/oci"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/report"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/userinfo"
@@ -29,6 +34,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/evaluation"
 	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
 	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
 	"github.com/spf13/cobra"
@@ -61,9 +67,33 @@ type ApplyCommandConfig struct {
 	AuditWarn      bool
 	ResourcePaths  []string
 	PolicyPaths    []string
+	IncludePaths   []string
+	ExcludePaths   []string
 	GitBranch      string
+	OCIVerifyKey   string
+	Diff           bool
+	DiffExitCode   bool
 	warnExitCode   int
 	warnNoPassed   bool
+	// CheckIdempotency applies mutate policies twice and warns when the second pass changes resources further
+	CheckIdempotency bool
+	// Remote is the address of an evaluation server policies are evaluated with instead of the local engine
+	Remote      string
+	RemoteCA    string
+	RemoteToken string
+	// ShowResolved prints the rules after variable substitution
+	ShowResolved bool
+	// Trace prints the decision trace of every evaluated rule
+	Trace bool
+	// KustomizePaths are kustomization directories rendered to get resources
+	KustomizePaths []string
+	// HelmCharts are chart directories rendered with HelmValues to get resources, experimental
+	HelmCharts      []string
+	HelmValues      []string
+	HelmReleaseName string
+	HelmNamespace   string
+	// Watch re-evaluates policies every time the policy or resource files change
+	Watch bool
 }
 
 func Command() *cobra.Command {
@@ -79,22 +109,36 @@ func Command() *cobra.Command {
 			out := cmd.OutOrStdout()
 			color.Init(removeColor)
 			applyCommandConfig.PolicyPaths = args
-			rc, _, skipInvalidPolicies, responses, err := applyCommandConfig.applyCommandHelper(out)
+			run := func() (*processor.ResultCounts, error) {
+				rc, _, skipInvalidPolicies, responses, err := applyCommandConfig.applyCommandHelper(out)
+				if err != nil {
+					return nil, err
+				}
+				printSkippedAndInvalidPolicies(out, skipInvalidPolicies)
+				if applyCommandConfig.PolicyReport {
+					printReport(out, responses, applyCommandConfig.AuditWarn)
+				} else if table {
+					printTable(out, detailedResults, applyCommandConfig.AuditWarn, responses...)
+				} else {
+					printViolations(out, rc)
+				}
+				return rc, nil
+			}
+			if applyCommandConfig.Watch {
+				ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+				defer stop()
+				return applyCommandConfig.watch(ctx, out, run)
+			}
+			rc, err := run()
 			if err != nil {
 				return err
 			}
-			printSkippedAndInvalidPolicies(out, skipInvalidPolicies)
-			if applyCommandConfig.PolicyReport {
-				printReport(out, responses, applyCommandConfig.AuditWarn)
-			} else if table {
-				printTable(out, detailedResults, applyCommandConfig.AuditWarn, responses...)
-			} else {
-				printViolations(out, rc)
-			}
-			return exit(rc, applyCommandConfig.warnExitCode, applyCommandConfig.warnNoPassed)
+			return exit(rc, applyCommandConfig.warnExitCode, applyCommandConfig.warnNoPassed, applyCommandConfig.DiffExitCode)
 		},
 	}
 	cmd.Flags().StringSliceVarP(&applyCommandConfig.ResourcePaths, "resource", "r", []string{}, "Path to resource files")
+	cmd.Flags().StringSliceVar(&applyCommandConfig.IncludePaths, "include", nil, "Glob patterns of resource files to include when loading resources from directories")
+	cmd.Flags().StringSliceVar(&applyCommandConfig.ExcludePaths, "exclude", nil, "Glob patterns of resource files or directories to exclude when loading resources from directories")
 	cmd.Flags().BoolVarP(&applyCommandConfig.Cluster, "cluster", "c", false, "Checks if policies should be applied to cluster in the current context")
 	cmd.Flags().StringVarP(&applyCommandConfig.MutateLogPath, "output", "o", "", "Prints the mutated resources in provided file/directory")
 	// currently `set` flag supports variable for single policy applied on single resource
@@ -108,9 +152,26 @@ func Command() *cobra.Command {
 	cmd.Flags().StringVar(&applyCommandConfig.KubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
 	cmd.Flags().StringVar(&applyCommandConfig.Context, "context", "", "The name of the kubeconfig context to use")
 	cmd.Flags().StringVarP(&applyCommandConfig.GitBranch, "git-branch", "b", "", "test git repository branch")
+	cmd.Flags().StringVar(&applyCommandConfig.OCIVerifyKey, "oci-verify-key", "", "Public key (path, KMS or k8s:// reference) used to verify the cosign signature of oci:// policy and resource artifacts")
 	cmd.Flags().BoolVar(&applyCommandConfig.AuditWarn, "audit-warn", false, "If set to true, will flag audit policies as warnings instead of failures")
 	cmd.Flags().IntVar(&applyCommandConfig.warnExitCode, "warn-exit-code", 0, "Set the exit code for warnings; if failures or errors are found, will exit 1")
 	cmd.Flags().BoolVar(&applyCommandConfig.warnNoPassed, "warn-no-pass", false, "Specify if warning exit code should be raised if no objects satisfied a policy; can be used together with --warn-exit-code flag")
+	cmd.Flags().BoolVar(&applyCommandConfig.Diff, "diff", false, "Print a unified diff between input and mutated resources for every mutate policy instead of the mutated resources")
+	cmd.Flags().BoolVar(&applyCommandConfig.DiffExitCode, "diff-exit-code", false, "Exit with an error if mutate policies changed at least one resource; can be used together with --diff flag")
+	cmd.Flags().BoolVar(&applyCommandConfig.CheckIdempotency, "check-idempotency", false, "Apply mutate policies a second time and warn when the second pass changes the mutated resources")
+	cmd.Flags().StringVar(&applyCommandConfig.Remote, "remote", "", "Address of a Kyverno evaluation server, when set policies are evaluated remotely instead of with the local engine")
+	cmd.Flags().StringVar(&applyCommandConfig.RemoteCA, "remote-ca", "", "Path to the CA certificate used to verify the evaluation server certificate")
+	cmd.Flags().StringVar(&applyCommandConfig.RemoteToken, "remote-token", "", "Bearer token sent to the evaluation server, its owner must be allowed to evaluate evaluations.kyverno.io")
+	cmd.Flags().BoolVar(&applyCommandConfig.ShowResolved, "show-resolved", false, "Print every evaluated rule after variable substitution, values that can't be resolved before evaluation (e.g. foreach element variables) are kept unchanged")
+	cmd.Flags().BoolVar(&applyCommandConfig.Trace, "trace", false, "Print the decision trace of every evaluated rule (match, preconditions, anchors, result and patch), not supported with --remote")
+	cmd.Flags().StringSliceVar(&applyCommandConfig.KustomizePaths, "kustomize", nil, "Path to kustomization directories, resources are rendered the same way kustomize build does")
+	if experimental.IsEnabled() {
+		cmd.Flags().StringSliceVar(&applyCommandConfig.HelmCharts, "helm", nil, "Path to helm chart directories, resources are rendered the same way helm template does (experimental)")
+		cmd.Flags().StringSliceVar(&applyCommandConfig.HelmValues, "helm-values", nil, "Values files used to render helm charts, merged in order on top of the chart values (experimental)")
+		cmd.Flags().StringVar(&applyCommandConfig.HelmReleaseName, "helm-release-name", "release-name", "Release name used to render helm charts (experimental)")
+		cmd.Flags().StringVar(&applyCommandConfig.HelmNamespace, "helm-namespace", "default", "Release namespace used to render helm charts (experimental)")
+	}
+	cmd.Flags().BoolVar(&applyCommandConfig.Watch, "watch", false, "Keep running and re-evaluate policies every time the local policy, resource, kustomization, values or userinfo files change")
 	cmd.Flags().BoolVar(&removeColor, "remove-color", false, "Remove any color from output")
 	cmd.Flags().BoolVar(&detailedResults, "detailed-results", false, "If set to true, display detailed results")
 	cmd.Flags().BoolVarP(&table, "table", "t", false, "Show results in table format")
@@ -165,17 +226,21 @@ func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.Resul
 		fmt.Fprintf(out, "\nApplying %d policy rule(s) to %d resource(s)...\n", policyRulesCount, len(resources))
 	}
 
-	rc, resources1, responses1, err = c.applyPolicytoResource(
-		out,
-		&store,
-		variables,
-		policies,
-		resources,
-		&skipInvalidPolicies,
-		dClient,
-		userInfo,
-		mutateLogPathIsDir,
-	)
+	if c.Remote != "" {
+		rc, resources1, responses1, err = c.applyPolicytoResourceRemote(out, variables, policies, resources, userInfo)
+	} else {
+		rc, resources1, responses1, err = c.applyPolicytoResource(
+			out,
+			&store,
+			variables,
+			policies,
+			resources,
+			&skipInvalidPolicies,
+			dClient,
+			userInfo,
+			mutateLogPathIsDir,
+		)
+	}
 	if err != nil {
 		return rc, resources1, skipInvalidPolicies, responses1, err
 	}
@@ -273,6 +338,10 @@ func (c *ApplyCommandConfig) applyPolicytoResource(
 			AuditWarn:            c.AuditWarn,
 			Subresources:         vars.Subresources(),
 			Out:                  out,
+			Diff:                 c.Diff,
+			CheckIdempotency:     c.CheckIdempotency,
+			ShowResolved:         c.ShowResolved,
+			Trace:                c.Trace,
 		}
 		ers, err := processor.ApplyPoliciesOnResource()
 		if err != nil {
@@ -283,10 +352,78 @@ func (c *ApplyCommandConfig) applyPolicytoResource(
 	return &rc, resources, responses, nil
 }
 
-func (c *ApplyCommandConfig) loadResources(out io.Writer, policies []kyvernov1.PolicyInterface, validatingAdmissionPolicies []v1alpha1.ValidatingAdmissionPolicy, dClient dclient.Interface) ([]*unstructured.Unstructured, error) {
-	resources, err := common.GetResourceAccordingToResourcePath(out, nil, c.ResourcePaths, c.Cluster, policies, validatingAdmissionPolicies, dClient, c.Namespace, c.PolicyReport, "")
+func (c *ApplyCommandConfig) applyPolicytoResourceRemote(
+	out io.Writer,
+	vars *variables.Variables,
+	policies []kyvernov1.PolicyInterface,
+	resources []*unstructured.Unstructured,
+	userInfo *v1beta1.RequestInfo,
+) (*processor.ResultCounts, []*unstructured.Unstructured, []engineapi.EngineResponse, error) {
+	conn, err := evaluation.Dial(c.Remote, c.RemoteCA, c.RemoteToken)
 	if err != nil {
-		return resources, fmt.Errorf("failed to load resources (%w)", err)
+		return nil, resources, nil, fmt.Errorf("failed to connect to evaluation server %s (%w)", c.Remote, err)
+	}
+	defer conn.Close()
+	client := evaluation.NewEvaluationClient(conn)
+	var rc processor.ResultCounts
+	var responses []engineapi.EngineResponse
+	for _, resource := range resources {
+		processor := processor.RemoteProcessor{
+			Client:               client,
+			Policies:             policies,
+			Resource:             *resource,
+			UserInfo:             userInfo,
+			NamespaceSelectorMap: vars.NamespaceSelectors(),
+			Rc:                   &rc,
+			AuditWarn:            c.AuditWarn,
+			ShowResolved:         c.ShowResolved,
+			Out:                  out,
+		}
+		ers, err := processor.ApplyPoliciesOnResource(context.TODO())
+		if err != nil {
+			return &rc, resources, responses, fmt.Errorf("failed to evaluate policies on resource %v (%w)", resource.GetName(), err)
+		}
+		responses = append(responses, ers...)
+	}
+	return &rc, resources, responses, nil
+}
+
+func (c *ApplyCommandConfig) loadResources(out io.Writer, policies []kyvernov1.PolicyInterface, validatingAdmissionPolicies []v1alpha1.ValidatingAdmissionPolicy, dClient dclient.Interface) ([]*unstructured.Unstructured, error) {
+	var resources []*unstructured.Unstructured
+	if len(c.ResourcePaths) > 0 || c.Cluster {
+		resourcePaths := c.ResourcePaths
+		if !c.Cluster && c.OCIVerifyKey != "" {
+			resourcePaths = make([]string, 0, len(c.ResourcePaths))
+			for _, path := range c.ResourcePaths {
+				if source.IsOCI(path) {
+					verified, err := oci.Verify(context.TODO(), path, c.OCIVerifyKey)
+					if err != nil {
+						return nil, fmt.Errorf("failed to verify resources (%w)", err)
+					}
+					path = verified
+				}
+				resourcePaths = append(resourcePaths, path)
+			}
+		}
+		loaded, err := common.GetResourceAccordingToResourcePath(out, nil, resourcePaths, c.Cluster, policies, validatingAdmissionPolicies, dClient, c.Namespace, c.PolicyReport, "", c.IncludePaths, c.ExcludePaths)
+		if err != nil {
+			return loaded, fmt.Errorf("failed to load resources (%w)", err)
+		}
+		resources = append(resources, loaded...)
+	}
+	for _, path := range c.KustomizePaths {
+		rendered, err := resource.Kustomize(path)
+		if err != nil {
+			return resources, fmt.Errorf("failed to load resources (%w)", err)
+		}
+		resources = append(resources, rendered...)
+	}
+	for _, path := range c.HelmCharts {
+		rendered, err := resource.Helm(path, c.HelmValues, resource.HelmRelease{Name: c.HelmReleaseName, Namespace: c.HelmNamespace})
+		if err != nil {
+			return resources, fmt.Errorf("failed to load resources (%w)", err)
+		}
+		resources = append(resources, rendered...)
 	}
 	return resources, nil
 }
@@ -315,7 +452,7 @@ func (c *ApplyCommandConfig) loadPolicies(skipInvalidPolicies SkippedInvalidPoli
 			var gitPathToYamls string
 			c.GitBranch, gitPathToYamls = common.GetGitBranchOrPolicyPaths(c.GitBranch, repoURL, path)
 			fs := memfs.New()
-			if _, err := gitutils.Clone(repoURL, fs, c.GitBranch); err != nil {
+			if _, err := gitutils.CloneWithAuth(repoURL, fs, c.GitBranch, source.GitAuth()); err != nil {
 				log.Log.V(3).Info(fmt.Sprintf("failed to clone repository  %v as it is not valid", repoURL), "error", err)
 				return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("failed to clone repository (%w)", err), nil, nil
 			}
@@ -332,6 +469,13 @@ func (c *ApplyCommandConfig) loadPolicies(skipInvalidPolicies SkippedInvalidPoli
 				validatingAdmissionPolicies = append(validatingAdmissionPolicies, admissionPoliciesFromFile...)
 			}
 		} else {
+			if source.IsOCI(path) && c.OCIVerifyKey != "" {
+				verified, err := oci.Verify(context.TODO(), path, c.OCIVerifyKey)
+				if err != nil {
+					return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("failed to verify policies (%w)", err), nil, nil
+				}
+				path = verified
+			}
 			policiesFromFile, admissionPoliciesFromFile, err := policy.Load(nil, "", path)
 			if err != nil {
 				return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("failed to load policies (%w)", err), nil, nil
@@ -398,8 +542,8 @@ func (c *ApplyCommandConfig) checkArguments() (*processor.ResultCounts, []*unstr
 	if (len(c.PolicyPaths) > 0 && c.PolicyPaths[0] == "-") && len(c.ResourcePaths) > 0 && c.ResourcePaths[0] == "-" {
 		return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("a stdin pipe can be used for either policies or resources, not both")
 	}
-	if len(c.ResourcePaths) == 0 && !c.Cluster {
-		return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("resource file(s) or cluster required")
+	if len(c.ResourcePaths) == 0 && len(c.KustomizePaths) == 0 && len(c.HelmCharts) == 0 && !c.Cluster {
+		return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("resource file(s), kustomization(s), helm chart(s) or cluster required")
 	}
 	return nil, nil, skipInvalidPolicies, nil, nil
 }
@@ -442,9 +586,11 @@ func printViolations(out io.Writer, rc *processor.ResultCounts) {
 	fmt.Fprintf(out, "\npass: %d, fail: %d, warn: %d, error: %d, skip: %d \n", rc.Pass(), rc.Fail(), rc.Warn(), rc.Error(), rc.Skip())
 }
 
-func exit(rc *processor.ResultCounts, warnExitCode int, warnNoPassed bool) error {
+func exit(rc *processor.ResultCounts, warnExitCode int, warnNoPassed bool, diffExitCode bool) error {
 	if rc.Fail() > 0 || rc.Error() > 0 {
 		return fmt.Errorf("exit as fail or error count > 0")
+	} else if rc.Changed() > 0 && diffExitCode {
+		return fmt.Errorf("exit as mutate policies changed %d resource(s)", rc.Changed())
 	} else if rc.Warn() > 0 && warnExitCode != 0 {
 		return fmt.Errorf("exit as warnExitCode is %d", warnExitCode)
 	} else if rc.Pass() == 0 && warnNoPassed {
@@ -414,3 +414,54 @@ func TestCommandHelp(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
 }
+
+func Test_ApplyDiff(t *testing.T) {
+	dir := t.TempDir()
+	policy := filepath.Join(dir, "policy.yaml")
+	resource := filepath.Join(dir, "resource.yaml")
+	assert.NoError(t, os.WriteFile(policy, []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: add-label
+spec:
+  rules:
+  - name: add-label
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    mutate:
+      patchStrategicMerge:
+        metadata:
+          labels:
+            team: platform
+`), 0o600))
+	assert.NoError(t, os.WriteFile(resource, []byte(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: app
+  namespace: default
+spec:
+  containers:
+  - name: app
+    image: nginx:1.25
+`), 0o600))
+	for _, diff := range []bool{false, true} {
+		config := ApplyCommandConfig{
+			PolicyPaths:   []string{policy},
+			ResourcePaths: []string{resource},
+			DiffExitCode:  true,
+			Diff:          diff,
+		}
+		var out bytes.Buffer
+		rc, _, _, _, err := config.applyCommandHelper(&out)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, rc.Changed())
+		// the diff is only printed with --diff
+		assert.Equal(t, diff, strings.Contains(out.String(), "+    team: platform"), out.String())
+		assert.Equal(t, diff, strings.Contains(out.String(), "mutate policy add-label (rules add-label) changed"), out.String())
+	}
+}
@@ -15,6 +15,26 @@ var examples = [][]string{
 		"# Apply on a folder of resources",
 		"kyverno apply /path/to/policy.yaml /path/to/folderOfPolicies --resource=/path/to/resources/",
 	},
+	{
+		"# Apply on a folder of resources recursively, skipping test fixtures",
+		"kyverno apply /path/to/policy.yaml --resource=/path/to/resources/ --include='*.yaml' --exclude='tests/*'",
+	},
+	{
+		"# Apply on kustomize output piped from stdin",
+		"kustomize build /path/to/overlay | kyverno apply /path/to/policy.yaml --resource -",
+	},
+	{
+		"# Apply on a kustomize overlay",
+		"kyverno apply /path/to/policy.yaml --kustomize /path/to/overlay",
+	},
+	{
+		"# Apply on the resources rendered from a helm chart (experimental)",
+		"KYVERNO_EXPERIMENTAL=true kyverno apply /path/to/policy.yaml --helm /path/to/chart --helm-values /path/to/values.yaml --helm-release-name my-release --helm-namespace my-namespace",
+	},
+	{
+		"# Re-evaluate every time the policies or resources change, for a fast feedback loop while writing policies",
+		"kyverno apply /path/to/policies/ --resource /path/to/resources/ --kustomize /path/to/overlay --watch",
+	},
 	{
 		"# Apply on a cluster",
 		"kyverno apply /path/to/policy.yaml /path/to/folderOfPolicies --cluster",
@@ -23,6 +43,22 @@ var examples = [][]string{
 		"# Apply policies from a gitSourceURL on a cluster",
 		"kyverno apply https://github.com/kyverno/policies/openshift/ --git-branch main --cluster",
 	},
+	{
+		"# Apply policies from an OCI artifact, verifying its cosign signature",
+		"kyverno apply oci://ghcr.io/org/policies:v1 --oci-verify-key cosign.pub --resource=/path/to/resources/",
+	},
+	{
+		"# Apply a policy to the resources stored in an OCI artifact",
+		"kyverno apply /path/to/policy.yaml --resource oci://ghcr.io/org/resources:v1",
+	},
+	{
+		"# Show the changes made by mutate policies as a unified diff and fail if any resource is changed",
+		"kyverno apply /path/to/mutate-policy.yaml --resource /path/to/resource.yaml --diff --diff-exit-code",
+	},
+	{
+		"# Evaluate policies with a remote Kyverno evaluation server",
+		"kyverno apply /path/to/policy.yaml --resource /path/to/resource.yaml --remote kyverno-svc.kyverno.svc:9444 --remote-ca /path/to/ca.crt --remote-token $(kubectl create token evaluator)",
+	},
 	{
 		"# Apply single policy with variable on single resource",
 		"kyverno apply /path/to/policy.yaml --resource /path/to/resource.yaml --set <variable1>=<value1>,<variable2>=<value2>",
@@ -35,7 +35,7 @@ func printTable(out io.Writer, compact, auditWarn bool, engineResponses ...engin
 			} else if ruleResponse.Status() == engineapi.RuleStatusFail {
 				if !scored {
 					row.Result = color.ResultWarn()
-				} else if auditWarn && engineResponse.GetValidationFailureAction().Audit() {
+				} else if auditWarn && engineResponse.GetRuleValidationFailureAction(ruleResponse).Audit() {
 					row.Result = color.ResultWarn()
 				} else {
 					row.Result = color.ResultFail()
@@ -46,6 +46,7 @@ func printTable(out io.Writer, compact, auditWarn bool, engineResponses ...engin
 				row.Result = color.ResultError()
 			} else if ruleResponse.Status() == engineapi.RuleStatusSkip {
 				row.Result = color.ResultSkip()
+				row.Reason = string(ruleResponse.SkipReason())
 			}
 			row.Message = ruleResponse.Message()
 			resultsTable.Add(row)
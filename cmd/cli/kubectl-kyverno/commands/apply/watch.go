@@ -0,0 +1,222 @@
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// watchDebounce is the delay used to coalesce the events produced by a single change (editors usually write, rename and chmod)
+var watchDebounce = 200 * time.Millisecond
+
+// watchPaths returns the local files and directories the results of the command depend on
+func (c *ApplyCommandConfig) watchPaths() ([]string, error) {
+	var paths []string
+	for _, path := range c.PolicyPaths {
+		if path == "-" || source.IsGit(path) || source.IsOCI(path) || source.IsHttp(path) {
+			return nil, fmt.Errorf("policy path %s can't be watched, only local files and directories are supported", path)
+		}
+		paths = append(paths, path)
+	}
+	// with --cluster, resource paths are resource names
+	if !c.Cluster {
+		for _, path := range c.ResourcePaths {
+			if path == "-" || source.IsHttp(path) || source.IsOCI(path) {
+				return nil, fmt.Errorf("resource path %s can't be watched, only local files and directories are supported", path)
+			}
+			paths = append(paths, path)
+		}
+	}
+	paths = append(paths, c.KustomizePaths...)
+	paths = append(paths, c.HelmCharts...)
+	paths = append(paths, c.HelmValues...)
+	for _, path := range []string{c.ValuesFile, c.UserInfoPath} {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// watch runs the command once, then every time one of the watched paths changes, until the context is cancelled
+func (c *ApplyCommandConfig) watch(ctx context.Context, out io.Writer, run func() (*processor.ResultCounts, error)) error {
+	paths, err := c.watchPaths()
+	if err != nil {
+		return err
+	}
+	w, err := newWatcher(paths)
+	if err != nil {
+		return err
+	}
+	defer w.close()
+	var previous *processor.ResultCounts
+	evaluate := func() {
+		rc, err := run()
+		if err != nil {
+			fmt.Fprintln(out, "Error:", err)
+		} else {
+			printChanges(out, previous, rc)
+			previous = rc
+		}
+		fmt.Fprintln(out, "\nWatching for changes, press Ctrl+C to stop...")
+	}
+	evaluate()
+	return w.run(ctx, func(changed []string) {
+		fmt.Fprintln(out, divider)
+		fmt.Fprintf(out, "%s changed:\n", time.Now().Format(time.TimeOnly))
+		for _, path := range changed {
+			fmt.Fprintln(out, "-", path)
+		}
+		evaluate()
+	})
+}
+
+// printChanges prints the difference between the result counts of two successive evaluations
+func printChanges(out io.Writer, previous *processor.ResultCounts, current *processor.ResultCounts) {
+	if previous == nil {
+		return
+	}
+	var changes []string
+	for _, count := range []struct {
+		name              string
+		previous, current int
+	}{
+		{"pass", previous.Pass(), current.Pass()},
+		{"fail", previous.Fail(), current.Fail()},
+		{"warn", previous.Warn(), current.Warn()},
+		{"error", previous.Error(), current.Error()},
+		{"skip", previous.Skip(), current.Skip()},
+	} {
+		if delta := count.current - count.previous; delta != 0 {
+			changes = append(changes, fmt.Sprintf("%s: %+d", count.name, delta))
+		}
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "no changes since the previous run")
+	} else {
+		fmt.Fprintln(out, "changes since the previous run:", strings.Join(changes, ", "))
+	}
+}
+
+// watcher notifies changes made to a set of files and directories, directories are watched recursively
+// and files are watched through their parent directory so that atomic saves (write then rename) are seen
+type watcher struct {
+	fsw   *fsnotify.Watcher
+	files sets.Set[string]
+	dirs  sets.Set[string]
+}
+
+func newWatcher(paths []string) (*watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file watcher (%w)", err)
+	}
+	w := &watcher{
+		fsw:   fsw,
+		files: sets.New[string](),
+		dirs:  sets.New[string](),
+	}
+	for _, path := range paths {
+		if err := w.add(filepath.Clean(path)); err != nil {
+			w.close()
+			return nil, fmt.Errorf("failed to watch %s (%w)", path, err)
+		}
+	}
+	return w, nil
+}
+
+func (w *watcher) add(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		w.files.Insert(path)
+		return w.fsw.Add(filepath.Dir(path))
+	}
+	return filepath.WalkDir(path, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if ignored(path) {
+			return filepath.SkipDir
+		}
+		w.dirs.Insert(path)
+		return w.fsw.Add(path)
+	})
+}
+
+// matches returns true if the event concerns a watched file or a file under a watched directory
+func (w *watcher) matches(event fsnotify.Event) bool {
+	if event.Op == fsnotify.Chmod || ignored(event.Name) {
+		return false
+	}
+	return w.files.Has(event.Name) || w.dirs.Has(filepath.Dir(event.Name))
+}
+
+func (w *watcher) run(ctx context.Context, onChange func([]string)) error {
+	changed := sets.New[string]()
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return nil
+			}
+			if !w.matches(event) {
+				continue
+			}
+			// new directories under a watched directory are watched too
+			if event.Has(fsnotify.Create) && w.dirs.Has(filepath.Dir(event.Name)) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := w.add(event.Name); err != nil {
+						log.Log.Error(err, "failed to watch directory", "path", event.Name)
+					}
+				}
+			}
+			changed.Insert(event.Name)
+			debounce = time.After(watchDebounce)
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return nil
+			}
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				changed.Insert("...")
+				debounce = time.After(watchDebounce)
+				continue
+			}
+			log.Log.Error(err, "file watcher error")
+		case <-debounce:
+			onChange(sets.List(changed))
+			changed = sets.New[string]()
+			debounce = nil
+		}
+	}
+}
+
+func (w *watcher) close() {
+	_ = w.fsw.Close()
+}
+
+// ignored returns true for hidden files and editor temporary files
+func ignored(path string) bool {
+	name := filepath.Base(path)
+	return (strings.HasPrefix(name, ".") && name != "." && name != "..") || strings.HasSuffix(name, "~") || strings.HasSuffix(name, ".swp")
+}
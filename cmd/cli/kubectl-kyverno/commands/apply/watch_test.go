@@ -0,0 +1,96 @@
+package apply
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_watchPaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ApplyCommandConfig
+		want    []string
+		wantErr bool
+	}{{
+		name: "local paths",
+		config: ApplyCommandConfig{
+			PolicyPaths:    []string{"policies/"},
+			ResourcePaths:  []string{"resource.yaml"},
+			KustomizePaths: []string{"overlay"},
+			ValuesFile:     "values.yaml",
+		},
+		want: []string{"policies/", "resource.yaml", "overlay", "values.yaml"},
+	}, {
+		name: "cluster resources",
+		config: ApplyCommandConfig{
+			PolicyPaths:   []string{"policy.yaml"},
+			ResourcePaths: []string{"nginx"},
+			Cluster:       true,
+		},
+		want: []string{"policy.yaml"},
+	}, {
+		name:    "stdin",
+		config:  ApplyCommandConfig{PolicyPaths: []string{"policy.yaml"}, ResourcePaths: []string{"-"}},
+		wantErr: true,
+	}, {
+		name:    "git",
+		config:  ApplyCommandConfig{PolicyPaths: []string{"https://github.com/kyverno/policies/best-practices/"}},
+		wantErr: true,
+	}, {
+		name:    "oci",
+		config:  ApplyCommandConfig{PolicyPaths: []string{"oci://ghcr.io/org/policies:v1"}},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.watchPaths()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_watcher(t *testing.T) {
+	dir := t.TempDir()
+	policy := filepath.Join(dir, "policy.yaml")
+	resources := filepath.Join(dir, "resources")
+	assert.NoError(t, os.WriteFile(policy, []byte("kind: ClusterPolicy"), 0o600))
+	assert.NoError(t, os.MkdirAll(resources, 0o755))
+	w, err := newWatcher([]string{policy, resources})
+	assert.NoError(t, err)
+	defer w.close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan []string)
+	go func() {
+		_ = w.run(ctx, func(changed []string) { changes <- changed })
+	}()
+	next := func() []string {
+		select {
+		case changed := <-changes:
+			return changed
+		case <-time.After(5 * time.Second):
+			return nil
+		}
+	}
+	// files next to a watched file are ignored
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "other.yaml"), []byte("kind: Pod"), 0o600))
+	assert.NoError(t, os.WriteFile(policy, []byte("kind: Policy"), 0o600))
+	assert.Equal(t, []string{policy}, next())
+	// directories are watched recursively, including new ones
+	nested := filepath.Join(resources, "nested")
+	assert.NoError(t, os.MkdirAll(nested, 0o755))
+	assert.Equal(t, []string{nested}, next())
+	assert.NoError(t, os.WriteFile(filepath.Join(nested, ".pod.yaml.swp"), []byte(""), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(nested, "pod.yaml"), []byte("kind: Pod"), 0o600))
+	assert.Equal(t, []string{filepath.Join(nested, "pod.yaml")}, next())
+}
@@ -0,0 +1,36 @@
+package apply
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "apply [bundle]",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			objects, err := load(args[0])
+			if err != nil {
+				return err
+			}
+			if options.dryRun {
+				return options.execute(cmd.Context(), cmd.OutOrStdout(), objects, nil)
+			}
+			client, err := options.client()
+			if err != nil {
+				return err
+			}
+			return options.execute(cmd.Context(), cmd.OutOrStdout(), objects, client)
+		},
+	}
+	cmd.Flags().BoolVar(&options.dryRun, "dry-run", false, "Verify the bundle and print the objects that would be applied without applying them")
+	cmd.Flags().StringVar(&options.kubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&options.context, "context", "", "The name of the kubeconfig context to use")
+	return cmd
+}
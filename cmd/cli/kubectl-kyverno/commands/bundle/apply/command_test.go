@@ -0,0 +1,176 @@
+package apply
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/bundle/internal"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+const policies = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: label team is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+---
+apiVersion: kyverno.io/v1
+kind: Policy
+metadata:
+  name: require-app
+spec:
+  rules:
+  - name: check-app
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: label app is required
+      pattern:
+        metadata:
+          labels:
+            app: "?*"
+`
+
+const exceptions = `
+apiVersion: kyverno.io/v2beta1
+kind: PolicyException
+metadata:
+  name: allow-system
+  namespace: kube-system
+spec:
+  exceptions:
+  - policyName: require-labels
+    ruleNames:
+    - check-team
+  match:
+    any:
+    - resources:
+        kinds:
+        - Pod
+`
+
+func writeBundle(t *testing.T) string {
+	b := internal.New("test")
+	assert.NoError(t, b.Add("policies.yaml", internal.FileTypePolicy, []byte(policies)))
+	assert.NoError(t, b.Add("exceptions.yaml", internal.FileTypeException, []byte(exceptions)))
+	assert.NoError(t, b.Add("values.yaml", internal.FileTypeValues, []byte("apiVersion: cli.kyverno.io/v1alpha1\nkind: Values\n")))
+	path := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	defer f.Close()
+	assert.NoError(t, internal.Write(f, b))
+	return path
+}
+
+func TestCommandWithoutArgs(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs(nil)
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandDryRun(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{writeBundle(t), "--dry-run"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, `Would apply ClusterPolicy require-labels
+Would apply Policy default/require-app
+Would apply PolicyException kube-system/allow-system
+`, b.String())
+}
+
+func TestExecute(t *testing.T) {
+	objects, err := load(writeBundle(t))
+	assert.NoError(t, err)
+	existing := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "require-labels"},
+	}
+	client := fake.NewSimpleClientset(existing)
+	b := bytes.NewBufferString("")
+	assert.NoError(t, options{}.execute(context.TODO(), b, objects, client))
+	assert.Contains(t, b.String(), "Applied ClusterPolicy require-labels")
+	assert.Contains(t, b.String(), "Applied Policy default/require-app")
+	assert.Contains(t, b.String(), "Applied PolicyException kube-system/allow-system")
+	policy, err := client.KyvernoV1().ClusterPolicies().Get(context.TODO(), "require-labels", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, policy.Spec.Rules, 1)
+	_, err = client.KyvernoV1().Policies("default").Get(context.TODO(), "require-app", metav1.GetOptions{})
+	assert.NoError(t, err)
+	_, err = client.KyvernoV2beta1().PolicyExceptions("kube-system").Get(context.TODO(), "allow-system", metav1.GetOptions{})
+	assert.NoError(t, err)
+}
+
+func TestExecuteRollback(t *testing.T) {
+	objects, err := load(writeBundle(t))
+	assert.NoError(t, err)
+	existing := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "require-labels"},
+	}
+	client := fake.NewSimpleClientset(existing)
+	client.PrependReactor("create", "policyexceptions", func(clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("denied")
+	})
+	b := bytes.NewBufferString("")
+	err = options{}.execute(context.TODO(), b, objects, client)
+	assert.EqualError(t, err, "failed to apply PolicyException kube-system/allow-system, changes were rolled back (denied)")
+	assert.Contains(t, b.String(), "Rolled back Policy default/require-app")
+	assert.Contains(t, b.String(), "Rolled back ClusterPolicy require-labels")
+	policy, err := client.KyvernoV1().ClusterPolicies().Get(context.TODO(), "require-labels", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, policy.Spec.Rules)
+	list, err := client.KyvernoV1().Policies("default").List(context.TODO(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, list.Items)
+}
+
+func TestLoadInvalidBundle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	assert.NoError(t, os.WriteFile(path, []byte("foo"), 0o600))
+	_, err := load(path)
+	assert.ErrorContains(t, err, "failed to read bundle")
+}
+
+func TestLoadMixedFile(t *testing.T) {
+	b := internal.New("test")
+	assert.NoError(t, b.Add("bundle.yaml", internal.FileTypePolicy, []byte(policies+"---"+exceptions)))
+	path := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	defer f.Close()
+	assert.NoError(t, internal.Write(f, b))
+	objects, err := load(path)
+	assert.NoError(t, err)
+	assert.Len(t, objects.policies, 2)
+	assert.Len(t, objects.exceptions, 1)
+}
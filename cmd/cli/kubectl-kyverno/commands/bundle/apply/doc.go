@@ -0,0 +1,26 @@
+package apply
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#bundle-apply`
+
+var description = []string{
+	`Applies the policies and exceptions of a bundle to the cluster.`,
+	``,
+	`The bundle manifest and checksums are verified and every policy and exception is validated before anything is applied.`,
+	`Objects are created or updated, if one of them fails the changes already made are rolled back.`,
+	`Values, tests and resources are carried by the bundle but not applied.`,
+}
+
+var examples = [][]string{
+	{
+		`# Apply a bundle to the current cluster`,
+		`kyverno bundle apply policies.tar.gz`,
+	},
+	{
+		`# Verify a bundle and print what would be applied`,
+		`kyverno bundle apply policies.tar.gz --dry-run`,
+	},
+	{
+		`# Apply a bundle to another cluster`,
+		`kyverno bundle apply policies.tar.gz --context production`,
+	},
+}
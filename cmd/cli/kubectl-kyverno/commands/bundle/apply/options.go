@@ -0,0 +1,206 @@
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/bundle/internal"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/exception"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/config"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type options struct {
+	dryRun     bool
+	kubeConfig string
+	context    string
+}
+
+// objects holds the policies and exceptions of a bundle in manifest order
+type objects struct {
+	policies   []kyvernov1.PolicyInterface
+	exceptions []*kyvernov2beta1.PolicyException
+}
+
+// change records how to revert an object applied to the cluster
+type change struct {
+	description string
+	rollback    func(context.Context) error
+}
+
+// load reads and verifies a bundle, then loads and validates its policies and exceptions
+func load(path string) (*objects, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	bundle, err := internal.Read(f)
+	if err != nil {
+		return nil, err
+	}
+	var objects objects
+	// files are classified by their first document, policies and exceptions can be declared in the same file
+	files := append(bundle.FilesOfType(internal.FileTypePolicy), bundle.FilesOfType(internal.FileTypeException)...)
+	for _, file := range files {
+		policies, _, err := yamlutils.GetPolicy(bundle.Contents[file.Path])
+		if err != nil {
+			return nil, fmt.Errorf("failed to load policies from %s (%w)", file.Path, err)
+		}
+		for _, policy := range policies {
+			if _, err := policyvalidation.Validate(policy, nil, nil, true, config.KyvernoUserName(config.KyvernoServiceAccountName())); err != nil {
+				return nil, fmt.Errorf("invalid policy %s in %s (%w)", policy.GetName(), file.Path, err)
+			}
+			objects.policies = append(objects.policies, policy)
+		}
+	}
+	for _, file := range files {
+		exceptions, err := exception.Load(bundle.Contents[file.Path])
+		if err != nil {
+			return nil, fmt.Errorf("failed to load exceptions from %s (%w)", file.Path, err)
+		}
+		objects.exceptions = append(objects.exceptions, exceptions...)
+	}
+	if len(objects.policies) == 0 && len(objects.exceptions) == 0 {
+		return nil, errors.New("bundle contains no policy or exception")
+	}
+	return &objects, nil
+}
+
+func (o options) client() (versioned.Interface, error) {
+	restConfig, err := config.CreateClientConfigWithContext(o.kubeConfig, o.context)
+	if err != nil {
+		return nil, err
+	}
+	return versioned.NewForConfig(restConfig)
+}
+
+func (o options) execute(ctx context.Context, out io.Writer, objects *objects, client versioned.Interface) error {
+	if o.dryRun {
+		for _, policy := range objects.policies {
+			fmt.Fprintf(out, "Would apply %s\n", describePolicy(policy))
+		}
+		for _, exception := range objects.exceptions {
+			fmt.Fprintf(out, "Would apply %s\n", describeException(exception))
+		}
+		return nil
+	}
+	var changes []change
+	apply := func(description string, rollback func(context.Context) error, err error) error {
+		if err != nil {
+			if rollbackErr := revert(ctx, out, changes); rollbackErr != nil {
+				return fmt.Errorf("failed to apply %s (%w), rollback failed (%w)", description, err, rollbackErr)
+			}
+			return fmt.Errorf("failed to apply %s, changes were rolled back (%w)", description, err)
+		}
+		changes = append(changes, change{description: description, rollback: rollback})
+		fmt.Fprintf(out, "Applied %s\n", description)
+		return nil
+	}
+	for _, policy := range objects.policies {
+		var rollback func(context.Context) error
+		var err error
+		switch typed := policy.(type) {
+		case *kyvernov1.ClusterPolicy:
+			rollback, err = upsert[*kyvernov1.ClusterPolicy](ctx, client.KyvernoV1().ClusterPolicies(), typed.DeepCopy())
+		case *kyvernov1.Policy:
+			policy := typed.DeepCopy()
+			if policy.GetNamespace() == "" {
+				policy.SetNamespace(metav1.NamespaceDefault)
+			}
+			rollback, err = upsert[*kyvernov1.Policy](ctx, client.KyvernoV1().Policies(policy.GetNamespace()), policy)
+		default:
+			err = fmt.Errorf("unsupported policy type %T", policy)
+		}
+		if err := apply(describePolicy(policy), rollback, err); err != nil {
+			return err
+		}
+	}
+	for _, exception := range objects.exceptions {
+		exception := exception.DeepCopy()
+		if exception.GetNamespace() == "" {
+			exception.SetNamespace(metav1.NamespaceDefault)
+		}
+		rollback, err := upsert[*kyvernov2beta1.PolicyException](ctx, client.KyvernoV2beta1().PolicyExceptions(exception.GetNamespace()), exception)
+		if err := apply(describeException(exception), rollback, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revert rolls back the changes in reverse order
+func revert(ctx context.Context, out io.Writer, changes []change) error {
+	var errs []error
+	for i := len(changes) - 1; i >= 0; i-- {
+		if err := changes[i].rollback(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", changes[i].description, err))
+			continue
+		}
+		fmt.Fprintf(out, "Rolled back %s\n", changes[i].description)
+	}
+	return errors.Join(errs...)
+}
+
+type resourceClient[T metav1.Object] interface {
+	Get(context.Context, string, metav1.GetOptions) (T, error)
+	Create(context.Context, T, metav1.CreateOptions) (T, error)
+	Update(context.Context, T, metav1.UpdateOptions) (T, error)
+	Delete(context.Context, string, metav1.DeleteOptions) error
+}
+
+// upsert creates or updates the object and returns a function reverting the change,
+// a created object is deleted and an updated object is restored to its previous state
+func upsert[T metav1.Object](ctx context.Context, client resourceClient[T], object T) (func(context.Context) error, error) {
+	name := object.GetName()
+	existing, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, err
+		}
+		if _, err := client.Create(ctx, object, metav1.CreateOptions{}); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context) error {
+			return client.Delete(ctx, name, metav1.DeleteOptions{})
+		}, nil
+	}
+	object.SetResourceVersion(existing.GetResourceVersion())
+	updated, err := client.Update(ctx, object, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context) error {
+		existing.SetResourceVersion(updated.GetResourceVersion())
+		_, err := client.Update(ctx, existing, metav1.UpdateOptions{})
+		return err
+	}, nil
+}
+
+func describePolicy(policy kyvernov1.PolicyInterface) string {
+	if policy.IsNamespaced() {
+		namespace := policy.GetNamespace()
+		if namespace == "" {
+			namespace = metav1.NamespaceDefault
+		}
+		return fmt.Sprintf("Policy %s/%s", namespace, policy.GetName())
+	}
+	return fmt.Sprintf("ClusterPolicy %s", policy.GetName())
+}
+
+func describeException(exception *kyvernov2beta1.PolicyException) string {
+	namespace := exception.GetNamespace()
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	return fmt.Sprintf("PolicyException %s/%s", namespace, exception.GetName())
+}
@@ -0,0 +1,27 @@
+package create
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "create [dir or file]...",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, paths []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.execute(cmd.OutOrStdout(), paths...)
+		},
+	}
+	cmd.Flags().StringVarP(&options.output, "output", "o", "", "Path of the bundle file to create")
+	cmd.Flags().StringVar(&options.name, "name", "", "Name of the bundle recorded in the manifest")
+	return cmd
+}
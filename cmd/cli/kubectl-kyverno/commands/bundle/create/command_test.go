@@ -0,0 +1,103 @@
+package create
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/bundle/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+const policy = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: label team is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+`
+
+const test = `
+apiVersion: cli.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: require-labels
+policies:
+- ../policies/policy.yaml
+resources:
+- resource.yaml
+`
+
+func TestCommandWithoutArgs(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"-o", "bundle.tar.gz"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithoutOutput(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"."})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, b.String(), "Error: output is required")
+}
+
+func TestExecute(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "policies"), 0o755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "tests"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "policies", "policy.yaml"), []byte(policy), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "policies", "README.md"), []byte("readme"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "tests", "kyverno-test.yaml"), []byte(test), 0o600))
+	output := filepath.Join(dir, "bundle.tar.gz")
+	b := bytes.NewBufferString("")
+	o := options{output: output, name: "production"}
+	assert.NoError(t, o.execute(b, filepath.Join(dir, "policies"), filepath.Join(dir, "tests")))
+	assert.Contains(t, b.String(), "1 policy file(s)")
+	assert.Contains(t, b.String(), "1 test file(s)")
+	f, err := os.Open(output)
+	assert.NoError(t, err)
+	defer f.Close()
+	bundle, err := internal.Read(f)
+	assert.NoError(t, err)
+	assert.Equal(t, "production", bundle.Manifest.Name)
+	assert.Equal(t, []internal.File{{
+		Path:   "policies/policy.yaml",
+		Type:   internal.FileTypePolicy,
+		Sha256: internal.Checksum([]byte(policy)),
+	}, {
+		Path:   "tests/kyverno-test.yaml",
+		Type:   internal.FileTypeTest,
+		Sha256: internal.Checksum([]byte(test)),
+	}}, bundle.Manifest.Files)
+}
+
+func TestExecuteInvalidPolicy(t *testing.T) {
+	dir := t.TempDir()
+	invalid := bytes.ReplaceAll([]byte(policy), []byte("name: check-team"), []byte("name: check-team\n    dependsOn: [foo]"))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "policy.yaml"), invalid, 0o600))
+	o := options{output: filepath.Join(dir, "bundle.tar.gz")}
+	err := o.execute(bytes.NewBufferString(""), filepath.Join(dir, "policy.yaml"))
+	assert.ErrorContains(t, err, "invalid policy require-labels in policy.yaml")
+	_, err = os.Stat(o.output)
+	assert.True(t, os.IsNotExist(err))
+}
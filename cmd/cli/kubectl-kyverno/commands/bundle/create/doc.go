@@ -0,0 +1,22 @@
+package create
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#bundle-create`
+
+var description = []string{
+	`Creates a bundle from policy, exception, values and test files.`,
+	``,
+	`Directories are walked recursively and YAML files are added with their path relative to the directory,`,
+	`prefixed with the directory name, so that tests keep referencing their policies and resources.`,
+	`Policies and exceptions are validated before the bundle is written.`,
+}
+
+var examples = [][]string{
+	{
+		`# Create a bundle from a directory`,
+		`kyverno bundle create ./policies -o policies.tar.gz`,
+	},
+	{
+		`# Create a named bundle from policies and their tests`,
+		`kyverno bundle create ./policies ./tests --name production -o production.tar.gz`,
+	},
+}
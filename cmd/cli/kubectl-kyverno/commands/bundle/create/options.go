@@ -0,0 +1,127 @@
+package create
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/bundle/internal"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/exception"
+	"github.com/kyverno/kyverno/pkg/config"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
+)
+
+type options struct {
+	output string
+	name   string
+}
+
+func (o options) validate() error {
+	if o.output == "" {
+		return errors.New("output is required")
+	}
+	return nil
+}
+
+func (o options) execute(out io.Writer, paths ...string) error {
+	bundle := internal.New(o.name)
+	for _, path := range paths {
+		if err := add(bundle, path); err != nil {
+			return err
+		}
+	}
+	if len(bundle.Manifest.Files) == 0 {
+		return errors.New("no YAML file found")
+	}
+	for _, file := range bundle.Manifest.Files {
+		if err := check(file, bundle.Contents[file.Path]); err != nil {
+			return err
+		}
+	}
+	f, err := os.Create(o.output)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle file (%w)", err)
+	}
+	defer f.Close()
+	if err := internal.Write(f, bundle); err != nil {
+		return fmt.Errorf("failed to write bundle (%w)", err)
+	}
+	counts := map[internal.FileType]int{}
+	for _, file := range bundle.Manifest.Files {
+		counts[file.Type]++
+	}
+	fmt.Fprintf(out, "Created bundle %s (%d policy file(s), %d exception file(s), %d values file(s), %d test file(s), %d resource file(s))\n",
+		o.output,
+		counts[internal.FileTypePolicy],
+		counts[internal.FileTypeException],
+		counts[internal.FileTypeValues],
+		counts[internal.FileTypeTest],
+		counts[internal.FileTypeResource],
+	)
+	return nil
+}
+
+// add adds a file or the YAML files of a directory, paths in the bundle are relative to the parent of the argument
+func add(bundle *internal.Bundle, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	root := filepath.Dir(filepath.Clean(path))
+	if !info.IsDir() {
+		return addFile(bundle, root, path)
+	}
+	return filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if ext := filepath.Ext(file); ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		return addFile(bundle, root, file)
+	})
+}
+
+func addFile(bundle *internal.Bundle, root, file string) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(root, file)
+	if err != nil {
+		return err
+	}
+	fileType, err := internal.Classify(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s (%w)", file, err)
+	}
+	return bundle.Add(filepath.ToSlash(rel), fileType, content)
+}
+
+// check validates the policies and exceptions of the bundle
+func check(file internal.File, content []byte) error {
+	// files are classified by their first document, policies and exceptions can be declared in the same file
+	switch file.Type {
+	case internal.FileTypePolicy, internal.FileTypeException:
+		policies, _, err := yamlutils.GetPolicy(content)
+		if err != nil {
+			return fmt.Errorf("failed to load policies from %s (%w)", file.Path, err)
+		}
+		for _, policy := range policies {
+			if _, err := policyvalidation.Validate(policy, nil, nil, true, config.KyvernoUserName(config.KyvernoServiceAccountName())); err != nil {
+				return fmt.Errorf("invalid policy %s in %s (%w)", policy.GetName(), file.Path, err)
+			}
+		}
+		if _, err := exception.Load(content); err != nil {
+			return fmt.Errorf("failed to load exceptions from %s (%w)", file.Path, err)
+		}
+	}
+	return nil
+}
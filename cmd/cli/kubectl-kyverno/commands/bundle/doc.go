@@ -0,0 +1,21 @@
+package bundle
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#bundle`
+
+var description = []string{
+	`Packages policies, exceptions, values and tests in a single artifact.`,
+	``,
+	`A bundle is a gzipped tarball with a manifest listing every file and its checksum,`,
+	`it is used to promote a policy set between clusters and environments.`,
+}
+
+var examples = [][]string{
+	{
+		`# Create a bundle from a directory`,
+		`kyverno bundle create ./policies -o policies.tar.gz`,
+	},
+	{
+		`# Apply a bundle to the current cluster`,
+		`kyverno bundle apply policies.tar.gz`,
+	},
+}
@@ -0,0 +1,228 @@
+package internal
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	yamlutils "github.com/kyverno/kyverno/ext/yaml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	ManifestFile       = "manifest.yaml"
+	ManifestAPIVersion = "cli.kyverno.io/v1alpha1"
+	ManifestKind       = "Bundle"
+)
+
+// FileType is the type of a file contained in a bundle
+type FileType string
+
+const (
+	FileTypePolicy    FileType = "policy"
+	FileTypeException FileType = "exception"
+	FileTypeValues    FileType = "values"
+	FileTypeTest      FileType = "test"
+	FileTypeResource  FileType = "resource"
+)
+
+// File describes a file of the bundle
+type File struct {
+	Path   string   `json:"path"`
+	Type   FileType `json:"type"`
+	Sha256 string   `json:"sha256"`
+}
+
+// Manifest lists the files of the bundle with their checksums
+type Manifest struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name,omitempty"`
+	Files      []File `json:"files"`
+}
+
+// Bundle holds the manifest and the content of the files, indexed by path
+type Bundle struct {
+	Manifest Manifest
+	Contents map[string][]byte
+}
+
+func New(name string) *Bundle {
+	return &Bundle{
+		Manifest: Manifest{
+			APIVersion: ManifestAPIVersion,
+			Kind:       ManifestKind,
+			Name:       name,
+		},
+		Contents: map[string][]byte{},
+	}
+}
+
+// Add adds a file to the bundle and records its checksum in the manifest
+func (b *Bundle) Add(filePath string, fileType FileType, content []byte) error {
+	if err := validatePath(filePath); err != nil {
+		return err
+	}
+	if _, ok := b.Contents[filePath]; ok {
+		return fmt.Errorf("duplicate file %s in bundle", filePath)
+	}
+	b.Contents[filePath] = content
+	b.Manifest.Files = append(b.Manifest.Files, File{Path: filePath, Type: fileType, Sha256: Checksum(content)})
+	return nil
+}
+
+// FilesOfType returns the files of the given type in manifest order
+func (b *Bundle) FilesOfType(fileType FileType) []File {
+	var files []File
+	for _, file := range b.Manifest.Files {
+		if file.Type == fileType {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+func Checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// Write writes the bundle as a gzipped tarball, the manifest comes first and
+// timestamps are zeroed so that the same inputs always produce the same artifact
+func Write(w io.Writer, b *Bundle) error {
+	manifest, err := yaml.Marshal(b.Manifest)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	write := func(name string, content []byte) error {
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0o644,
+			Size:    int64(len(content)),
+			ModTime: time.Unix(0, 0),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := tw.Write(content)
+		return err
+	}
+	if err := write(ManifestFile, manifest); err != nil {
+		return err
+	}
+	for _, file := range b.Manifest.Files {
+		if err := write(file.Path, b.Contents[file.Path]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// Read reads a bundle and verifies that every file is listed in the manifest with a matching checksum
+func Read(r io.Reader) (*Bundle, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle (%w)", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	contents := map[string][]byte{}
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle (%w)", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			return nil, fmt.Errorf("unexpected entry %s in bundle", header.Name)
+		}
+		if err := validatePath(header.Name); err != nil {
+			return nil, err
+		}
+		var buffer bytes.Buffer
+		if _, err := io.Copy(&buffer, tr); err != nil {
+			return nil, fmt.Errorf("failed to read %s from bundle (%w)", header.Name, err)
+		}
+		contents[header.Name] = buffer.Bytes()
+	}
+	data, ok := contents[ManifestFile]
+	if !ok {
+		return nil, fmt.Errorf("bundle has no %s", ManifestFile)
+	}
+	delete(contents, ManifestFile)
+	var manifest Manifest
+	if err := yaml.UnmarshalStrict(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle manifest (%w)", err)
+	}
+	if manifest.APIVersion != ManifestAPIVersion || manifest.Kind != ManifestKind {
+		return nil, fmt.Errorf("unsupported bundle manifest %s/%s", manifest.APIVersion, manifest.Kind)
+	}
+	listed := map[string]bool{}
+	for _, file := range manifest.Files {
+		content, ok := contents[file.Path]
+		if !ok {
+			return nil, fmt.Errorf("file %s listed in the manifest is missing from the bundle", file.Path)
+		}
+		if Checksum(content) != file.Sha256 {
+			return nil, fmt.Errorf("checksum mismatch for file %s", file.Path)
+		}
+		listed[file.Path] = true
+	}
+	for name := range contents {
+		if !listed[name] {
+			return nil, fmt.Errorf("file %s is not listed in the manifest", name)
+		}
+	}
+	return &Bundle{Manifest: manifest, Contents: contents}, nil
+}
+
+// Classify returns the type of a YAML file from the kind of its first document
+func Classify(content []byte) (FileType, error) {
+	documents, err := yamlutils.SplitDocuments(content)
+	if err != nil {
+		return "", err
+	}
+	for _, document := range documents {
+		var typeMeta metav1.TypeMeta
+		if err := yaml.Unmarshal(document, &typeMeta); err != nil {
+			return "", err
+		}
+		group := strings.Split(typeMeta.APIVersion, "/")[0]
+		switch {
+		case group == "kyverno.io" && (typeMeta.Kind == "ClusterPolicy" || typeMeta.Kind == "Policy"):
+			return FileTypePolicy, nil
+		case group == "kyverno.io" && typeMeta.Kind == "PolicyException":
+			return FileTypeException, nil
+		case group == "cli.kyverno.io" && typeMeta.Kind == "Values":
+			return FileTypeValues, nil
+		case group == "cli.kyverno.io" && typeMeta.Kind == "Test":
+			return FileTypeTest, nil
+		case typeMeta.Kind != "":
+			return FileTypeResource, nil
+		}
+	}
+	return FileTypeResource, nil
+}
+
+func validatePath(filePath string) error {
+	if filePath == "" || path.IsAbs(filePath) || path.Clean(filePath) != filePath || strings.HasPrefix(filePath, "../") || filePath == ".." {
+		return fmt.Errorf("invalid file path %s in bundle", filePath)
+	}
+	return nil
+}
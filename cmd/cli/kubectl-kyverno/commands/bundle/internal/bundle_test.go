@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteRead(t *testing.T) {
+	b := New("test")
+	assert.NoError(t, b.Add("policies/policy.yaml", FileTypePolicy, []byte("policy")))
+	assert.NoError(t, b.Add("values.yaml", FileTypeValues, []byte("values")))
+	assert.Error(t, b.Add("values.yaml", FileTypeValues, []byte("values")))
+	assert.Error(t, b.Add("../values.yaml", FileTypeValues, []byte("values")))
+	assert.Error(t, b.Add("/values.yaml", FileTypeValues, []byte("values")))
+	var first, second bytes.Buffer
+	assert.NoError(t, Write(&first, b))
+	assert.NoError(t, Write(&second, b))
+	assert.Equal(t, first.Bytes(), second.Bytes())
+	read, err := Read(&first)
+	assert.NoError(t, err)
+	assert.Equal(t, b, read)
+	assert.Equal(t, []File{{Path: "values.yaml", Type: FileTypeValues, Sha256: Checksum([]byte("values"))}}, read.FilesOfType(FileTypeValues))
+}
+
+func TestReadInvalid(t *testing.T) {
+	manifest := func(content string) []byte {
+		return []byte(`apiVersion: cli.kyverno.io/v1alpha1
+kind: Bundle
+files:
+- path: policy.yaml
+  type: policy
+  sha256: ` + Checksum([]byte(content)) + "\n")
+	}
+	testCases := []struct {
+		name    string
+		files   map[string][]byte
+		wantErr string
+	}{{
+		name:    "no manifest",
+		files:   map[string][]byte{"policy.yaml": []byte("policy")},
+		wantErr: "bundle has no manifest.yaml",
+	}, {
+		name:    "checksum mismatch",
+		files:   map[string][]byte{ManifestFile: manifest("policy"), "policy.yaml": []byte("tampered")},
+		wantErr: "checksum mismatch for file policy.yaml",
+	}, {
+		name:    "missing file",
+		files:   map[string][]byte{ManifestFile: manifest("policy")},
+		wantErr: "file policy.yaml listed in the manifest is missing from the bundle",
+	}, {
+		name:    "unlisted file",
+		files:   map[string][]byte{ManifestFile: manifest("policy"), "policy.yaml": []byte("policy"), "other.yaml": []byte("other")},
+		wantErr: "file other.yaml is not listed in the manifest",
+	}, {
+		name:    "invalid path",
+		files:   map[string][]byte{ManifestFile: manifest("policy"), "../policy.yaml": []byte("policy")},
+		wantErr: "invalid file path ../policy.yaml in bundle",
+	}, {
+		name:    "unsupported manifest",
+		files:   map[string][]byte{ManifestFile: []byte("apiVersion: v1\nkind: ConfigMap\n")},
+		wantErr: "unsupported bundle manifest v1/ConfigMap",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			gz := gzip.NewWriter(&buffer)
+			tw := tar.NewWriter(gz)
+			for name, content := range tc.files {
+				assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}))
+				_, err := tw.Write(content)
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, tw.Close())
+			assert.NoError(t, gz.Close())
+			_, err := Read(&buffer)
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	testCases := []struct {
+		content string
+		want    FileType
+	}{
+		{content: "apiVersion: kyverno.io/v1\nkind: ClusterPolicy\n", want: FileTypePolicy},
+		{content: "apiVersion: kyverno.io/v1\nkind: Policy\n", want: FileTypePolicy},
+		{content: "apiVersion: kyverno.io/v2beta1\nkind: PolicyException\n", want: FileTypeException},
+		{content: "apiVersion: cli.kyverno.io/v1alpha1\nkind: Values\n", want: FileTypeValues},
+		{content: "apiVersion: cli.kyverno.io/v1alpha1\nkind: Test\n", want: FileTypeTest},
+		{content: "apiVersion: v1\nkind: Pod\n", want: FileTypeResource},
+		{content: "---\n# comment\n---\napiVersion: kyverno.io/v1\nkind: ClusterPolicy\n", want: FileTypePolicy},
+	}
+	for _, tc := range testCases {
+		got, err := Classify([]byte(tc.content))
+		assert.NoError(t, err)
+		assert.Equal(t, tc.want, got, tc.content)
+	}
+}
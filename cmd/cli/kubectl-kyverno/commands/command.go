@@ -3,13 +3,21 @@ package commands
 import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/apply"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/bundle"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/convert"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/create"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/docs"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/fix"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/installpolicies"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/jp"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/json"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/oci"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/resources"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/test"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/ur"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/version"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/webhooks"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/whatif"
 	"github.com/spf13/cobra"
 )
 
@@ -33,8 +41,16 @@ func RootCommand(experimental bool) *cobra.Command {
 	)
 	if experimental {
 		cmd.AddCommand(
+			bundle.Command(),
+			convert.Command(),
 			fix.Command(),
+			installpolicies.Command(),
+			json.Command(),
 			oci.Command(),
+			resources.Command(),
+			ur.Command(),
+			webhooks.Command(),
+			whatif.Command(),
 		)
 	}
 	return cmd
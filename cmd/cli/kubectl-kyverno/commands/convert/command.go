@@ -0,0 +1,29 @@
+package convert
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "convert [policy]...",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.policyPaths = args
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.execute(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+	cmd.Flags().StringVarP(&options.namespace, "namespace", "n", "", "Namespace of the policies converted from cluster policies")
+	cmd.Flags().StringVarP(&options.output, "output", "o", "", "Output file path (defaults to stdout)")
+	cmd.Flags().StringSliceVar(&options.clusterResources, "cluster-resource", nil, "Additional cluster-scoped kinds not allowed in namespaced policies")
+	return cmd
+}
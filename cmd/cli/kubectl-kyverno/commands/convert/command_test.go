@@ -0,0 +1,119 @@
+package convert
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const clusterPolicy = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  validationFailureAction: Audit
+  rules:
+  - name: check-for-labels
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+          namespaces:
+          - dev
+    validate:
+      message: label app is required
+      pattern:
+        metadata:
+          labels:
+            app: ?*
+`
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithInvalidArg(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: requires at least 1 arg(s), only received 0`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidFlag(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--xxx"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown flag: --xxx`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func TestCommandConvert(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "policy.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(clusterPolicy), 0o600))
+	// a namespace is required to convert a cluster policy
+	cmd := Command()
+	cmd.SetOut(bytes.NewBufferString(""))
+	cmd.SetErr(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{path})
+	assert.Error(t, cmd.Execute())
+	// convert the cluster policy to a policy
+	output := filepath.Join(dir, "converted.yaml")
+	cmd = Command()
+	cmd.SetArgs([]string{path, "--namespace", "dev", "--output", output})
+	assert.NoError(t, cmd.Execute())
+	converted, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Contains(t, string(converted), "kind: Policy")
+	assert.Contains(t, string(converted), "namespace: dev")
+	// convert the policy back to a cluster policy
+	b := bytes.NewBufferString("")
+	cmd = Command()
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{output})
+	assert.NoError(t, cmd.Execute())
+	assert.Contains(t, b.String(), "kind: ClusterPolicy")
+	assert.Contains(t, b.String(), "- dev")
+	// the cluster policy doesn't apply to the prod namespace
+	cmd = Command()
+	cmd.SetOut(bytes.NewBufferString(""))
+	cmd.SetErr(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{path, "--namespace", "prod"})
+	assert.Error(t, cmd.Execute())
+}
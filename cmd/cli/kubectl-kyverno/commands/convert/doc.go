@@ -0,0 +1,25 @@
+package convert
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Converts cluster policies to namespaced policies and namespaced policies to cluster policies.`,
+	``,
+	`When converting a cluster policy, namespace filters of match and exclude statements are resolved against`,
+	`the target namespace and rules that can't match resources in the namespace are removed.`,
+	`The converted policy is validated against the restrictions of namespaced policies.`,
+	``,
+	`When converting a policy, match statements are restricted to the namespace of the policy.`,
+}
+
+var examples = [][]string{
+	{
+		`# Convert a cluster policy to a policy in the dev namespace`,
+		`KYVERNO_EXPERIMENTAL=true kyverno convert /path/to/cluster-policy.yaml --namespace dev`,
+	},
+	{
+		`# Convert a policy to a cluster policy and save it`,
+		`KYVERNO_EXPERIMENTAL=true kyverno convert /path/to/policy.yaml -o cluster-policy.yaml`,
+	},
+}
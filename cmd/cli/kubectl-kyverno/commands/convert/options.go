@@ -0,0 +1,70 @@
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/fix"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/pkg/policy/scope"
+)
+
+type options struct {
+	policyPaths      []string
+	namespace        string
+	output           string
+	clusterResources []string
+}
+
+func (o options) validate() error {
+	if len(o.policyPaths) == 0 {
+		return errors.New("at least one policy is required")
+	}
+	return nil
+}
+
+func (o options) execute(out io.Writer, errOut io.Writer) error {
+	policies, _, err := policy.Load(nil, "", o.policyPaths...)
+	if err != nil {
+		return err
+	}
+	if len(policies) == 0 {
+		return errors.New("no policy found")
+	}
+	clusterResources := scope.WellKnownClusterResources.Clone().Insert(o.clusterResources...)
+	var converted []kyvernov1.PolicyInterface
+	for _, pol := range policies {
+		switch typed := pol.(type) {
+		case *kyvernov1.ClusterPolicy:
+			if o.namespace == "" {
+				return fmt.Errorf("namespace is required to convert cluster policy %s", typed.GetName())
+			}
+			result, warnings, err := scope.ToPolicy(typed, o.namespace, clusterResources)
+			for _, warning := range warnings {
+				fmt.Fprintf(errOut, "WARNING: %s: %s\n", typed.GetName(), warning)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to convert cluster policy %s (%w)", typed.GetName(), err)
+			}
+			converted = append(converted, result)
+		case *kyvernov1.Policy:
+			result, err := scope.ToClusterPolicy(typed, clusterResources)
+			if err != nil {
+				return fmt.Errorf("failed to convert policy %s/%s (%w)", typed.GetNamespace(), typed.GetName(), err)
+			}
+			converted = append(converted, result)
+		}
+	}
+	yamlBytes, err := fix.MarshalPolicies(converted, nil)
+	if err != nil {
+		return err
+	}
+	if o.output == "" {
+		_, err := out.Write(yamlBytes)
+		return err
+	}
+	return os.WriteFile(o.output, yamlBytes, 0o600)
+}
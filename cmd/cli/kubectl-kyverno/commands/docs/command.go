@@ -4,6 +4,7 @@ import (
 	"log"
 
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/docs/policies"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +27,7 @@ func Command(root *cobra.Command) *cobra.Command {
 	cmd.Flags().StringVarP(&options.path, "output", "o", ".", "Output path")
 	cmd.Flags().BoolVar(&options.website, "website", false, "Website version")
 	cmd.Flags().BoolVar(&options.autogenTag, "autogenTag", true, "Determines if the generated docs should contain a timestamp")
+	cmd.AddCommand(policies.Command())
 	if err := cmd.MarkFlagDirname("output"); err != nil {
 		log.Println("WARNING", err)
 	}
@@ -0,0 +1,40 @@
+package policies
+
+import (
+	"os"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "policies [dir]...",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if options.output != "" {
+				file, err := os.Create(options.output)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				out = file
+			}
+			return options.execute(out, args...)
+		},
+	}
+	cmd.Flags().StringVarP(&options.output, "output", "o", "", "Output file (uses standard console output if not set)")
+	cmd.Flags().StringVarP(&options.format, "format", "f", formatMarkdown, "Output format (markdown or html)")
+	cmd.Flags().StringVar(&options.testFileName, "test-file-name", "kyverno-test.yaml", "Test filename used to look up examples")
+	cmd.Flags().BoolVar(&options.examples, "examples", true, "Include the resources of the tests as examples")
+	return cmd
+}
@@ -0,0 +1,187 @@
+package policies
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var policyYaml = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+  annotations:
+    policies.kyverno.io/title: Require Labels
+    policies.kyverno.io/category: Best Practices
+    policies.kyverno.io/severity: medium
+    policies.kyverno.io/subject: Pod, Label
+    policies.kyverno.io/description: >-
+      Labels are used to identify the owner of a workload.
+      This policy requires the label team on pods.
+spec:
+  validationFailureAction: Audit
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: "The label team is required."
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+`
+
+var testYaml = `
+apiVersion: cli.kyverno.io/v1alpha1
+kind: Test
+metadata:
+  name: test
+policies:
+- policy.yaml
+resources:
+- resources.yaml
+results:
+- policy: require-labels
+  rule: check-team
+  kind: Pod
+  resources:
+  - good-pod
+  result: pass
+- policy: require-labels
+  rule: check-team
+  kind: Pod
+  resources:
+  - default/bad-pod
+  result: fail
+`
+
+var resourcesYaml = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: good-pod
+  namespace: default
+  labels:
+    team: platform
+spec:
+  containers:
+  - name: nginx
+    image: nginx
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: bad-pod
+  namespace: default
+spec:
+  containers:
+  - name: nginx
+    image: nginx
+`
+
+func writePolicies(t *testing.T) string {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "policy.yaml"), []byte(policyYaml), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "kyverno-test.yaml"), []byte(testYaml), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "resources.yaml"), []byte(resourcesYaml), 0o600))
+	return dir
+}
+
+func TestCommandNoArgs(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: requires at least 1 arg(s), only received 0`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandInvalidFormat(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{writePolicies(t), "--format", "pdf"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: invalid format pdf, must be one of markdown, html`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandMarkdown(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{writePolicies(t)})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "| [Require Labels](#require-labels) | Best Practices | medium | Labels are used to identify the owner of a workload. This policy requires the label team on pods. |")
+	assert.Contains(t, string(out), "| Subject | Pod, Label |")
+	assert.Contains(t, string(out), "#### `check-team`")
+	assert.Contains(t, string(out), "- Matches: Pod")
+	assert.Contains(t, string(out), "- Message: The label team is required.")
+	assert.Contains(t, string(out), "**pass**: Pod `good-pod`")
+	assert.Contains(t, string(out), "**fail**: Pod `default/bad-pod`")
+	assert.Contains(t, string(out), "  labels:\n    team: platform\n")
+}
+
+func TestCommandWithoutExamples(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{writePolicies(t), "--examples=false"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "#### `check-team`")
+	assert.NotContains(t, string(out), "good-pod")
+}
+
+func TestCommandHtml(t *testing.T) {
+	dir := writePolicies(t)
+	output := filepath.Join(t.TempDir(), "catalog.html")
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{dir, "--format", "html", "-o", output})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `<h2 id="require-labels">Require Labels</h2>`)
+	assert.Contains(t, string(out), `<p><strong>fail</strong>: Pod <code>default/bad-pod</code></p>`)
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
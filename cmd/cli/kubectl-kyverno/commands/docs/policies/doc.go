@@ -0,0 +1,29 @@
+package policies
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Generates policies documentation.`,
+	``,
+	`The policies command generates a human readable catalog from a set of policies.`,
+	``,
+	`Title, category, severity, subject and description are read from the policies annotations.`,
+	`Rules are summarized with their type, match and exclude scope and message.`,
+	`Resources used in Kyverno CLI tests are included as examples of passing and failing resources.`,
+}
+
+var examples = [][]string{
+	{
+		`# Generate markdown documentation for the policies in a folder`,
+		`KYVERNO_EXPERIMENTAL=true kyverno docs policies ./policies`,
+	},
+	{
+		`# Generate an html catalog in a file`,
+		`KYVERNO_EXPERIMENTAL=true kyverno docs policies ./policies --format html -o catalog.html`,
+	},
+	{
+		`# Generate documentation without examples`,
+		`KYVERNO_EXPERIMENTAL=true kyverno docs policies ./policies --examples=false`,
+	},
+}
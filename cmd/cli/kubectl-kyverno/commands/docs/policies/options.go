@@ -0,0 +1,238 @@
+package policies
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	formatMarkdown = "markdown"
+	formatHtml     = "html"
+)
+
+type options struct {
+	output       string
+	format       string
+	testFileName string
+	examples     bool
+}
+
+type policyDoc struct {
+	Name                    string
+	Namespace               string
+	Kind                    string
+	Title                   string
+	Category                string
+	Severity                string
+	Subject                 string
+	Description             string
+	ValidationFailureAction string
+	Background              bool
+	Rules                   []ruleDoc
+}
+
+type ruleDoc struct {
+	Name       string
+	Type       string
+	Kinds      []string
+	Namespaces []string
+	Excluded   []string
+	Message    string
+	Examples   []example
+}
+
+type example struct {
+	Result   string
+	Kind     string
+	Name     string
+	Resource string
+}
+
+func (o options) validate() error {
+	if o.format != formatMarkdown && o.format != formatHtml {
+		return fmt.Errorf("invalid format %s, must be one of %s, %s", o.format, formatMarkdown, formatHtml)
+	}
+	return nil
+}
+
+func (o options) execute(out io.Writer, dirs ...string) error {
+	policies, _, err := policy.LoadWithLoader(policy.LegacyLoader, nil, "", dirs...)
+	if err != nil {
+		return err
+	}
+	docs := buildDocs(policies)
+	if o.examples {
+		for _, dir := range dirs {
+			if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+				continue
+			}
+			tests, err := test.LoadTests(dir, o.testFileName)
+			if err != nil {
+				return err
+			}
+			addExamples(docs, tests)
+		}
+	}
+	return render(out, o.format, docs)
+}
+
+// buildDocs returns the documentation of the policies sorted by category and name
+func buildDocs(policies []kyvernov1.PolicyInterface) []policyDoc {
+	docs := make([]policyDoc, 0, len(policies))
+	for _, p := range policies {
+		annotations := p.GetAnnotations()
+		spec := p.GetSpec()
+		doc := policyDoc{
+			Name:                    p.GetName(),
+			Namespace:               p.GetNamespace(),
+			Kind:                    p.GetKind(),
+			Title:                   annotations[kyverno.AnnotationPolicyTitle],
+			Category:                annotations[kyverno.AnnotationPolicyCategory],
+			Severity:                annotations[kyverno.AnnotationPolicySeverity],
+			Subject:                 annotations[kyverno.AnnotationPolicySubject],
+			Description:             strings.TrimSpace(annotations[kyverno.AnnotationPolicyDescription]),
+			ValidationFailureAction: string(spec.ValidationFailureAction),
+			Background:              spec.BackgroundProcessingEnabled(),
+		}
+		if doc.Title == "" {
+			doc.Title = doc.Name
+		}
+		for _, rule := range spec.Rules {
+			rule := rule
+			doc.Rules = append(doc.Rules, ruleDoc{
+				Name:       rule.Name,
+				Type:       ruleType(&rule),
+				Kinds:      rule.MatchResources.GetKinds(),
+				Namespaces: namespaces(rule.MatchResources),
+				Excluded:   rule.ExcludeResources.GetKinds(),
+				Message:    message(&rule),
+			})
+		}
+		docs = append(docs, doc)
+	}
+	sort.SliceStable(docs, func(i, j int) bool {
+		if docs[i].Category != docs[j].Category {
+			return docs[i].Category < docs[j].Category
+		}
+		return docs[i].Name < docs[j].Name
+	})
+	return docs
+}
+
+func ruleType(rule *kyvernov1.Rule) string {
+	switch {
+	case rule.HasValidate():
+		return "validate"
+	case rule.HasMutate():
+		return "mutate"
+	case rule.HasGenerate():
+		return "generate"
+	case rule.HasVerifyImages():
+		return "verifyImages"
+	}
+	return ""
+}
+
+func message(rule *kyvernov1.Rule) string {
+	if rule.HasValidate() {
+		return rule.Validation.Message
+	}
+	return ""
+}
+
+func namespaces(match kyvernov1.MatchResources) []string {
+	namespaces := match.ResourceDescription.Namespaces
+	for _, filter := range match.All {
+		namespaces = append(namespaces, filter.ResourceDescription.Namespaces...)
+	}
+	for _, filter := range match.Any {
+		namespaces = append(namespaces, filter.ResourceDescription.Namespaces...)
+	}
+	return namespaces
+}
+
+// addExamples adds the resources referenced in the results of the tests to the matching rules
+func addExamples(docs []policyDoc, tests test.TestCases) {
+	for _, tc := range tests {
+		if tc.Err != nil || tc.Test == nil {
+			continue
+		}
+		resources := loadResources(tc)
+		for _, result := range tc.Test.Results {
+			for i := range docs {
+				if docs[i].Name != result.Policy {
+					continue
+				}
+				for j := range docs[i].Rules {
+					rule := &docs[i].Rules[j]
+					if rule.Name != result.Rule {
+						continue
+					}
+					names := result.Resources
+					if result.Resource != "" {
+						names = append(names, result.Resource)
+					}
+					outcome := string(result.Result)
+					if outcome == "" {
+						outcome = string(result.Status)
+					}
+					for _, name := range names {
+						rule.Examples = append(rule.Examples, example{
+							Result:   outcome,
+							Kind:     result.Kind,
+							Name:     name,
+							Resource: findResource(resources, result.Kind, name),
+						})
+					}
+				}
+			}
+		}
+	}
+}
+
+func loadResources(tc test.TestCase) []*unstructured.Unstructured {
+	var resources []*unstructured.Unstructured
+	for _, path := range tc.Test.Resources {
+		bytes, err := os.ReadFile(filepath.Join(tc.Dir(), path)) // #nosec G304
+		if err != nil {
+			continue
+		}
+		loaded, err := resource.GetUnstructuredResources(bytes)
+		if err != nil {
+			continue
+		}
+		resources = append(resources, loaded...)
+	}
+	return resources
+}
+
+// findResource returns the yaml of a resource given its kind and its name in the form `<name>` or `<namespace>/<name>`
+func findResource(resources []*unstructured.Unstructured, kind, name string) string {
+	namespace, name, found := strings.Cut(name, "/")
+	if !found {
+		name, namespace = namespace, ""
+	}
+	for _, r := range resources {
+		if r.GetKind() != kind || r.GetName() != name || (namespace != "" && r.GetNamespace() != namespace) {
+			continue
+		}
+		bytes, err := yaml.Marshal(r.Object)
+		if err != nil {
+			return ""
+		}
+		return string(bytes)
+	}
+	return ""
+}
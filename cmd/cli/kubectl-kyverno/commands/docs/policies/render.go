@@ -0,0 +1,47 @@
+package policies
+
+import (
+	_ "embed"
+	htmltemplate "html/template"
+	"io"
+	"regexp"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates/markdown.tmpl
+var markdownTemplate string
+
+//go:embed templates/html.tmpl
+var htmlTemplate string
+
+var anchorRegex = regexp.MustCompile(`[^a-z0-9 -]`)
+
+var funcs = map[string]any{
+	// anchor computes the anchor of a heading the same way GitHub does
+	"anchor": func(s string) string {
+		return strings.ReplaceAll(anchorRegex.ReplaceAllString(strings.ToLower(s), ""), " ", "-")
+	},
+	// inline makes a text usable in a single line markdown context (tables, lists)
+	"inline": func(s string) string {
+		return strings.ReplaceAll(strings.Join(strings.Fields(s), " "), "|", `\|`)
+	},
+	"join": func(s []string) string {
+		return strings.Join(s, ", ")
+	},
+}
+
+func render(out io.Writer, format string, docs []policyDoc) error {
+	if format == formatHtml {
+		tmpl, err := htmltemplate.New("html").Funcs(funcs).Parse(htmlTemplate)
+		if err != nil {
+			return err
+		}
+		return tmpl.Execute(out, docs)
+	}
+	tmpl, err := texttemplate.New("markdown").Funcs(funcs).Parse(markdownTemplate)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(out, docs)
+}
@@ -22,5 +22,6 @@ func Command() *cobra.Command {
 		},
 	}
 	cmd.Flags().BoolVar(&options.save, "save", false, "Save fixed file")
+	cmd.Flags().BoolVar(&options.diff, "diff", false, "Show the changes as a unified diff")
 	return cmd
 }
@@ -16,4 +16,8 @@ var examples = [][]string{
 		`# Fix Kyverno policy files and save them back`,
 		`KYVERNO_EXPERIMENTAL=true kyverno fix policy . --save`,
 	},
+	{
+		`# Show the changes to Kyverno policy files as a diff`,
+		`KYVERNO_EXPERIMENTAL=true kyverno fix policy . --diff`,
+	},
 }
@@ -16,6 +16,10 @@ var examples = [][]string{
 		`# Fix Kyverno test files and save them back`,
 		`KYVERNO_EXPERIMENTAL=true kyverno fix test . --save`,
 	},
+	{
+		`# Show the changes to Kyverno test files as a diff`,
+		`KYVERNO_EXPERIMENTAL=true kyverno fix test . --diff`,
+	},
 	{
 		`# Fix Kyverno test files with a specific file name`,
 		`KYVERNO_EXPERIMENTAL=true kyverno fix test . --file-name test.yaml --save`,
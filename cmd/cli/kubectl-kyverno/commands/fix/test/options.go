@@ -20,6 +20,7 @@ import (
 type options struct {
 	fileName string
 	save     bool
+	diff     bool
 	force    bool
 	compress bool
 }
@@ -65,36 +66,8 @@ func (o options) execute(out io.Writer, dirs ...string) error {
 			continue
 		}
 		needsSave := !reflect.DeepEqual(testCase.Test, &fixed)
-		if o.save && (o.force || needsSave) {
-			fmt.Fprintf(out, "  Saving test file (%s)...", testCase.Path)
-			fmt.Fprintln(out)
-			untyped, err := kubeutils.ObjToUnstructured(fixed)
-			if err != nil {
-				fmt.Fprintf(out, "    ERROR: converting to unstructured: %s", err)
-				fmt.Fprintln(out)
-				continue
-			}
-			unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata", "creationTimestamp")
-			unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata", "generation")
-			unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata", "uid")
-			jsonBytes, err := untyped.MarshalJSON()
-			if err != nil {
-				fmt.Fprintf(out, "    ERROR: converting to json: %s", err)
-				fmt.Fprintln(out)
-				continue
-			}
-			yamlBytes, err := yaml.JSONToYAML(jsonBytes)
-			if err != nil {
-				fmt.Fprintf(out, "    ERROR: converting to yaml: %s", err)
-				fmt.Fprintln(out)
-				continue
-			}
-			if err := os.WriteFile(testCase.Path, yamlBytes, os.ModePerm); err != nil {
-				fmt.Fprintf(out, "    ERROR: saving test file (%s): %s", testCase.Path, err)
-				fmt.Fprintln(out)
-				continue
-			}
-			fmt.Fprintln(out, "    OK")
+		if o.force || needsSave {
+			o.output(out, "test file", testCase.Path, fixed, false)
 		}
 		if testCase.Test.UserInfo != "" {
 			fmt.Fprintf(out, "  Processing user info file (%s)...\n", testCase.Test.UserInfo)
@@ -113,34 +86,8 @@ func (o options) execute(out io.Writer, dirs ...string) error {
 				continue
 			}
 			needsSave := !reflect.DeepEqual(info, &fixed)
-			if o.save && (o.force || needsSave) {
-				fmt.Fprintf(out, "  Saving user info file (%s)...\n", path)
-				untyped, err := kubeutils.ObjToUnstructured(fixed)
-				if err != nil {
-					fmt.Fprintf(out, "    ERROR: converting to unstructured: %s\n", err)
-					continue
-				}
-				unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata", "creationTimestamp")
-				unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata", "generation")
-				unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata", "uid")
-				if item, _, _ := unstructured.NestedMap(untyped.UnstructuredContent(), "metadata"); len(item) == 0 {
-					unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata")
-				}
-				jsonBytes, err := untyped.MarshalJSON()
-				if err != nil {
-					fmt.Fprintf(out, "    ERROR: converting to json: %s\n", err)
-					continue
-				}
-				yamlBytes, err := yaml.JSONToYAML(jsonBytes)
-				if err != nil {
-					fmt.Fprintf(out, "    ERROR: converting to yaml: %s\n", err)
-					continue
-				}
-				if err := os.WriteFile(path, yamlBytes, os.ModePerm); err != nil {
-					fmt.Fprintf(out, "    ERROR: saving user info file (%s): %s\n", path, err)
-					continue
-				}
-				fmt.Fprintln(out, "    OK")
+			if o.force || needsSave {
+				o.output(out, "user info file", path, fixed, true)
 			}
 		}
 		if testCase.Test.Variables != "" {
@@ -160,34 +107,8 @@ func (o options) execute(out io.Writer, dirs ...string) error {
 				continue
 			}
 			needsSave := !reflect.DeepEqual(values, &fixed)
-			if o.save && (o.force || needsSave) {
-				fmt.Fprintf(out, "  Saving values file (%s)...\n", path)
-				untyped, err := kubeutils.ObjToUnstructured(fixed)
-				if err != nil {
-					fmt.Fprintf(out, "    ERROR: converting to unstructured: %s\n", err)
-					continue
-				}
-				unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata", "creationTimestamp")
-				unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata", "generation")
-				unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata", "uid")
-				if item, _, _ := unstructured.NestedMap(untyped.UnstructuredContent(), "metadata"); len(item) == 0 {
-					unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata")
-				}
-				jsonBytes, err := untyped.MarshalJSON()
-				if err != nil {
-					fmt.Fprintf(out, "    ERROR: converting to json: %s\n", err)
-					continue
-				}
-				yamlBytes, err := yaml.JSONToYAML(jsonBytes)
-				if err != nil {
-					fmt.Fprintf(out, "    ERROR: converting to yaml: %s\n", err)
-					continue
-				}
-				if err := os.WriteFile(path, yamlBytes, os.ModePerm); err != nil {
-					fmt.Fprintf(out, "    ERROR: saving values file (%s): %s\n", path, err)
-					continue
-				}
-				fmt.Fprintln(out, "    OK")
+			if o.force || needsSave {
+				o.output(out, "values file", path, fixed, true)
 			}
 		}
 		fmt.Fprintln(out)
@@ -195,3 +116,60 @@ func (o options) execute(out io.Writer, dirs ...string) error {
 	fmt.Fprintln(out, "Done.")
 	return nil
 }
+
+// output prints the diff between a file and its fixed content and saves the fixed content, as requested by the options
+func (o options) output(out io.Writer, kind string, path string, fixed interface{}, pruneEmptyMetadata bool) {
+	if !o.save && !o.diff {
+		return
+	}
+	yamlBytes, err := toYaml(fixed, pruneEmptyMetadata)
+	if err != nil {
+		fmt.Fprintf(out, "    ERROR: %s\n", err)
+		return
+	}
+	if o.diff {
+		original, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(out, "    ERROR: reading %s (%s): %s\n", kind, path, err)
+			return
+		}
+		diff, err := fix.Diff(path, original, yamlBytes)
+		if err != nil {
+			fmt.Fprintf(out, "    ERROR: computing diff: %s\n", err)
+			return
+		}
+		fmt.Fprint(out, diff)
+	}
+	if o.save {
+		fmt.Fprintf(out, "  Saving %s (%s)...\n", kind, path)
+		if err := os.WriteFile(path, yamlBytes, os.ModePerm); err != nil {
+			fmt.Fprintf(out, "    ERROR: saving %s (%s): %s\n", kind, path, err)
+			return
+		}
+		fmt.Fprintln(out, "    OK")
+	}
+}
+
+func toYaml(obj interface{}, pruneEmptyMetadata bool) ([]byte, error) {
+	untyped, err := kubeutils.ObjToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("converting to unstructured: %w", err)
+	}
+	unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata", "generation")
+	unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata", "uid")
+	if pruneEmptyMetadata {
+		if item, _, _ := unstructured.NestedMap(untyped.UnstructuredContent(), "metadata"); len(item) == 0 {
+			unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata")
+		}
+	}
+	jsonBytes, err := untyped.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("converting to json: %w", err)
+	}
+	yamlBytes, err := yaml.JSONToYAML(jsonBytes)
+	if err != nil {
+		return nil, fmt.Errorf("converting to yaml: %w", err)
+	}
+	return yamlBytes, nil
+}
@@ -0,0 +1,41 @@
+package installpolicies
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "install-policies",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			library, err := loadLibrary()
+			if err != nil {
+				return err
+			}
+			if err := options.validate(library); err != nil {
+				return err
+			}
+			if options.list || options.dryRun {
+				return options.execute(cmd.Context(), cmd.OutOrStdout(), library, nil)
+			}
+			client, err := options.clusterPolicies()
+			if err != nil {
+				return err
+			}
+			return options.execute(cmd.Context(), cmd.OutOrStdout(), library, client)
+		},
+	}
+	cmd.Flags().StringSliceVar(&options.categories, "category", nil, "Categories of policies to install, all categories are installed when not set")
+	cmd.Flags().BoolVar(&options.list, "list", false, "List the embedded policies and their categories without installing them")
+	cmd.Flags().BoolVar(&options.dryRun, "dry-run", false, "Print the policies that would be installed instead of installing them")
+	cmd.Flags().StringVar(&options.kubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&options.context, "context", "", "The name of the kubeconfig context to use")
+	return cmd
+}
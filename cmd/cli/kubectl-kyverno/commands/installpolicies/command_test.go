@@ -0,0 +1,114 @@
+package installpolicies
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/kyverno/kyverno/pkg/config"
+	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCommandWithArgs(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"foo"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown command "foo" for "install-policies"`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidCategory(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--category", "foo", "--list"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown category foo, must be one of best-practices, pod-security`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func TestCommandList(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--list", "--category", "pod-security"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	assert.Contains(t, b.String(), "pod-security-baseline")
+	assert.Contains(t, b.String(), "pod-security-restricted")
+	assert.NotContains(t, b.String(), "disallow-latest-tag")
+}
+
+func TestLibrary(t *testing.T) {
+	library, err := loadLibrary()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, library)
+	for _, p := range library {
+		_, err := policyvalidation.Validate(p.policy, nil, nil, true, config.KyvernoUserName(config.KyvernoServiceAccountName()))
+		assert.NoError(t, err, p.policy.GetName())
+	}
+}
+
+func TestExecute(t *testing.T) {
+	library, err := loadLibrary()
+	assert.NoError(t, err)
+	existing := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-security-baseline"},
+		Spec:       kyvernov1.Spec{ValidationFailureAction: kyvernov1.Enforce},
+	}
+	client := fake.NewSimpleClientset(existing).KyvernoV1().ClusterPolicies()
+	b := bytes.NewBufferString("")
+	o := options{categories: []string{"pod-security"}}
+	assert.NoError(t, o.execute(context.TODO(), b, library, client))
+	assert.Contains(t, b.String(), "Installed policy pod-security-baseline (pod-security)")
+	assert.Contains(t, b.String(), "Installed policy pod-security-restricted (pod-security)")
+	list, err := client.List(context.TODO(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, list.Items, 2)
+	for _, policy := range list.Items {
+		assert.Equal(t, kyvernov1.Audit, policy.Spec.ValidationFailureAction)
+		assert.Contains(t, policy.GetAnnotations(), kyverno.AnnotationPolicyLibrary)
+	}
+}
+
+func TestExecuteDryRun(t *testing.T) {
+	library, err := loadLibrary()
+	assert.NoError(t, err)
+	b := bytes.NewBufferString("")
+	o := options{dryRun: true, categories: []string{"best-practices"}}
+	assert.NoError(t, o.execute(context.TODO(), b, library, nil))
+	assert.Contains(t, b.String(), "name: disallow-latest-tag")
+	assert.Contains(t, b.String(), "validationFailureAction: Audit")
+	assert.NotContains(t, b.String(), "pod-security-baseline")
+}
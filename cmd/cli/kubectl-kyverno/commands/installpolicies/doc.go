@@ -0,0 +1,25 @@
+package installpolicies
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#install-policies`
+
+var description = []string{
+	`Installs the policies embedded in the CLI into the cluster.`,
+	``,
+	`The embedded library contains a curated set of baseline policies (pod security standards, best practices) versioned with the CLI.`,
+	`Policies are always installed in Audit mode and annotated with the CLI version they were installed from.`,
+}
+
+var examples = [][]string{
+	{
+		`# List the embedded policies`,
+		`kyverno install-policies --list`,
+	},
+	{
+		`# Install the pod security policies`,
+		`kyverno install-policies --category pod-security`,
+	},
+	{
+		`# Print all the policies instead of installing them`,
+		`kyverno install-policies --dry-run`,
+	},
+}
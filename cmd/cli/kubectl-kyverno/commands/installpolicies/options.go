@@ -0,0 +1,182 @@
+package installpolicies
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/data"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1client "github.com/kyverno/kyverno/pkg/client/clientset/versioned/typed/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"github.com/kyverno/kyverno/pkg/version"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+type libraryPolicy struct {
+	category string
+	policy   *kyvernov1.ClusterPolicy
+}
+
+type options struct {
+	categories []string
+	list       bool
+	dryRun     bool
+	kubeConfig string
+	context    string
+}
+
+type row struct {
+	Category string `header:"category"`
+	Name     string `header:"name"`
+	Title    string `header:"title"`
+	Severity string `header:"severity"`
+}
+
+// loadLibrary loads the embedded policies, the category of a policy is the name of its folder
+func loadLibrary() ([]libraryPolicy, error) {
+	var library []libraryPolicy
+	err := fs.WalkDir(data.Policies(), data.PoliciesFolder, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || path.Ext(file) != ".yaml" {
+			return nil
+		}
+		content, err := fs.ReadFile(data.Policies(), file)
+		if err != nil {
+			return err
+		}
+		policies, _, err := yamlutils.GetPolicy(content)
+		if err != nil {
+			return fmt.Errorf("failed to load embedded policy %s (%w)", file, err)
+		}
+		for _, policy := range policies {
+			clusterPolicy, ok := policy.(*kyvernov1.ClusterPolicy)
+			if !ok {
+				return fmt.Errorf("embedded policy %s is not a cluster policy", file)
+			}
+			library = append(library, libraryPolicy{
+				category: path.Base(path.Dir(file)),
+				policy:   clusterPolicy,
+			})
+		}
+		return nil
+	})
+	return library, err
+}
+
+func (o options) validate(library []libraryPolicy) error {
+	if o.list && o.dryRun {
+		return fmt.Errorf("--list and --dry-run can not be used together")
+	}
+	for _, category := range o.categories {
+		if !slices.ContainsFunc(library, func(p libraryPolicy) bool { return p.category == category }) {
+			return fmt.Errorf("unknown category %s, must be one of %s", category, strings.Join(categories(library), ", "))
+		}
+	}
+	return nil
+}
+
+func (o options) clusterPolicies() (kyvernov1client.ClusterPolicyInterface, error) {
+	restConfig, err := config.CreateClientConfigWithContext(o.kubeConfig, o.context)
+	if err != nil {
+		return nil, err
+	}
+	client, err := versioned.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return client.KyvernoV1().ClusterPolicies(), nil
+}
+
+func (o options) execute(ctx context.Context, out io.Writer, library []libraryPolicy, client kyvernov1client.ClusterPolicyInterface) error {
+	var selected []libraryPolicy
+	for _, p := range library {
+		if len(o.categories) == 0 || slices.Contains(o.categories, p.category) {
+			selected = append(selected, p)
+		}
+	}
+	if o.list {
+		rows := make([]row, 0, len(selected))
+		for _, p := range selected {
+			annotations := p.policy.GetAnnotations()
+			rows = append(rows, row{
+				Category: p.category,
+				Name:     p.policy.GetName(),
+				Title:    annotations[kyverno.AnnotationPolicyTitle],
+				Severity: annotations[kyverno.AnnotationPolicySeverity],
+			})
+		}
+		printer := table.NewTablePrinter(out)
+		printer.Print(rows)
+		return nil
+	}
+	for i, p := range selected {
+		policy := prepare(p.policy)
+		if o.dryRun {
+			content, err := yaml.Marshal(policy)
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				fmt.Fprintln(out, "---")
+			}
+			fmt.Fprint(out, string(content))
+			continue
+		}
+		if err := install(ctx, client, policy); err != nil {
+			return fmt.Errorf("failed to install policy %s (%w)", policy.GetName(), err)
+		}
+		fmt.Fprintf(out, "Installed policy %s (%s)\n", policy.GetName(), p.category)
+	}
+	return nil
+}
+
+// prepare forces the audit mode and records the library version in the policy
+func prepare(policy *kyvernov1.ClusterPolicy) *kyvernov1.ClusterPolicy {
+	policy = policy.DeepCopy()
+	policy.Spec.ValidationFailureAction = kyvernov1.Audit
+	policy.Spec.ValidationFailureActionOverrides = nil
+	annotations := policy.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[kyverno.AnnotationPolicyLibrary] = version.Version()
+	policy.SetAnnotations(annotations)
+	return policy
+}
+
+func install(ctx context.Context, client kyvernov1client.ClusterPolicyInterface, policy *kyvernov1.ClusterPolicy) error {
+	existing, err := client.Get(ctx, policy.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return err
+		}
+		_, err := client.Create(ctx, policy, metav1.CreateOptions{})
+		return err
+	}
+	policy.SetResourceVersion(existing.GetResourceVersion())
+	_, err = client.Update(ctx, policy, metav1.UpdateOptions{})
+	return err
+}
+
+func categories(library []libraryPolicy) []string {
+	var categories []string
+	for _, p := range library {
+		if !slices.Contains(categories, p.category) {
+			categories = append(categories, p.category)
+		}
+	}
+	return categories
+}
@@ -0,0 +1,14 @@
+package json
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#json`
+
+var description = []string{
+	`Runs validating policies against JSON payloads that are not Kubernetes resources.`,
+}
+
+var examples = [][]string{
+	{
+		`# Scan a terraform plan with a payload validating policy`,
+		`kyverno json scan --policy policy.yaml --payload plan.json`,
+	},
+}
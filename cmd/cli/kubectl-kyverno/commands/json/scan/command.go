@@ -0,0 +1,30 @@
+package scan
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "scan",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.run(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringSliceVar(&options.policyPaths, "policy", nil, "Path to the payload validating policies")
+	cmd.Flags().StringVar(&options.payloadPath, "payload", "", "Path to the JSON or YAML payload")
+	cmd.Flags().StringVar(&options.remote, "remote", "", "Address of a Kyverno evaluation server, payloads are evaluated locally when empty")
+	cmd.Flags().StringVar(&options.remoteCA, "remote-ca", "", "Path to the CA certificate used to verify the evaluation server certificate")
+	cmd.Flags().StringVar(&options.remoteToken, "remote-token", "", "Bearer token sent to the evaluation server, its owner must be allowed to evaluate evaluations.kyverno.io")
+	return cmd
+}
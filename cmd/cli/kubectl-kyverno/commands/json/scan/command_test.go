@@ -0,0 +1,94 @@
+package scan
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const policy = `
+apiVersion: json.kyverno.io/v1alpha1
+kind: ValidatingPolicy
+metadata:
+  name: require-version
+spec:
+  rules:
+  - name: check-version
+    validate:
+      message: terraform 1.x is required
+      pattern:
+        terraform_version: "1.*"
+`
+
+func TestCommandWithoutPayload(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--policy", "policy.yaml"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: payload is required`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func TestCommand(t *testing.T) {
+	dir := t.TempDir()
+	policyPath := filepath.Join(dir, "policy.yaml")
+	assert.NoError(t, os.WriteFile(policyPath, []byte(policy), 0o600))
+	tests := []struct {
+		name    string
+		payload string
+		wantErr bool
+		want    []string
+	}{{
+		name:    "json pass",
+		payload: `{"terraform_version":"1.5.7"}`,
+		want:    []string{"require-version", "check-version", "pass", "1 rule(s) evaluated, 0 failed"},
+	}, {
+		name:    "yaml fail",
+		payload: "terraform_version: 0.14.0\n",
+		wantErr: true,
+		want:    []string{"fail", "1 rule(s) evaluated, 1 failed"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payloadPath := filepath.Join(dir, "payload")
+			assert.NoError(t, os.WriteFile(payloadPath, []byte(tt.payload), 0o600))
+			cmd := Command()
+			b := bytes.NewBufferString("")
+			cmd.SetOut(b)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs([]string{"--policy", policyPath, "--payload", payloadPath})
+			err := cmd.Execute()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			for _, want := range tt.want {
+				assert.Contains(t, b.String(), want)
+			}
+		})
+	}
+}
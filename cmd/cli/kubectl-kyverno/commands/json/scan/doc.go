@@ -0,0 +1,23 @@
+package scan
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#json-scan`
+
+var description = []string{
+	`Validates a JSON payload against payload validating policies.`,
+	``,
+	`Payload validating policies (json.kyverno.io/v1alpha1 ValidatingPolicy) contain validate rules`,
+	`that are not bound to Kubernetes kinds, the payload is available as request.object.`,
+	`The command fails when at least one rule fails or errors.`,
+}
+
+var examples = [][]string{
+	{
+		`# Scan a terraform plan`,
+		`terraform show -json tfplan > plan.json`,
+		`kyverno json scan --policy policy.yaml --payload plan.json`,
+	},
+	{
+		`# Scan a payload with a Kyverno evaluation server`,
+		`kyverno json scan --policy policy.yaml --payload plan.json --remote kyverno-svc.kyverno.svc:9444 --remote-ca /path/to/ca.crt --remote-token $(kubectl create token evaluator)`,
+	},
+}
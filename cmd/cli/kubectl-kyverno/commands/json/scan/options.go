@@ -0,0 +1,153 @@
+package scan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/payload"
+	"github.com/kyverno/kyverno/pkg/evaluation"
+	"sigs.k8s.io/yaml"
+)
+
+type options struct {
+	policyPaths []string
+	payloadPath string
+	remote      string
+	remoteCA    string
+	remoteToken string
+}
+
+type row struct {
+	Policy  string `header:"policy"`
+	Rule    string `header:"rule"`
+	Status  string `header:"status"`
+	Message string `header:"message"`
+}
+
+func (o options) validate() error {
+	if len(o.policyPaths) == 0 {
+		return fmt.Errorf("at least one policy is required")
+	}
+	if o.payloadPath == "" {
+		return fmt.Errorf("payload is required")
+	}
+	return nil
+}
+
+func (o options) run(ctx context.Context, out io.Writer) error {
+	var documents []string
+	for _, path := range o.policyPaths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read policies (%w)", err)
+		}
+		documents = append(documents, string(data))
+	}
+	data, err := os.ReadFile(o.payloadPath)
+	if err != nil {
+		return fmt.Errorf("failed to read payload (%w)", err)
+	}
+	input, err := loadPayload(data)
+	if err != nil {
+		return err
+	}
+	var results []evaluation.PolicyResult
+	if o.remote != "" {
+		results, err = o.evaluateRemote(ctx, documents, input)
+	} else {
+		results, err = evaluateLocal(ctx, documents, input)
+	}
+	if err != nil {
+		return err
+	}
+	return printResults(out, results)
+}
+
+func loadPayload(data []byte) (map[string]interface{}, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse payload (%w)", err)
+	}
+	var input map[string]interface{}
+	if err := json.Unmarshal(jsonData, &input); err != nil {
+		return nil, fmt.Errorf("failed to parse payload, a JSON object is expected (%w)", err)
+	}
+	return input, nil
+}
+
+func evaluateLocal(ctx context.Context, documents []string, input map[string]interface{}) ([]evaluation.PolicyResult, error) {
+	cfg := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(cfg)
+	var results []evaluation.PolicyResult
+	for _, document := range documents {
+		policies, err := payload.Load([]byte(document))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load policies (%w)", err)
+		}
+		for _, policy := range policies {
+			response, err := payload.Evaluate(ctx, jp, cfg, policy, input)
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate policy %s (%w)", policy.Name, err)
+			}
+			result := evaluation.PolicyResult{Name: policy.Name}
+			for _, rule := range response.Rules {
+				result.Rules = append(result.Rules, evaluation.RuleResult{
+					Name:    rule.Name(),
+					Type:    rule.RuleType(),
+					Status:  rule.Status(),
+					Message: rule.Message(),
+				})
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+func (o options) evaluateRemote(ctx context.Context, documents []string, input map[string]interface{}) ([]evaluation.PolicyResult, error) {
+	conn, err := evaluation.Dial(o.remote, o.remoteCA, o.remoteToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to evaluation server %s (%w)", o.remote, err)
+	}
+	defer conn.Close()
+	response, err := evaluation.NewEvaluationClient(conn).EvaluatePayload(ctx, &evaluation.EvaluatePayloadRequest{Policies: documents, Payload: input})
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate payload (%w)", err)
+	}
+	return response.Results, nil
+}
+
+func printResults(out io.Writer, results []evaluation.PolicyResult) error {
+	var rows []row
+	failed := 0
+	for _, result := range results {
+		for _, rule := range result.Rules {
+			if rule.Status == engineapi.RuleStatusFail || rule.Status == engineapi.RuleStatusError {
+				failed++
+			}
+			rows = append(rows, row{
+				Policy:  result.Name,
+				Rule:    rule.Name,
+				Status:  string(rule.Status),
+				Message: rule.Message,
+			})
+		}
+	}
+	if len(rows) != 0 {
+		printer := table.NewTablePrinter(out)
+		printer.Print(rows)
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintf(out, "%d rule(s) evaluated, %d failed\n", len(rows), failed)
+	if failed != 0 {
+		return fmt.Errorf("payload validation failed")
+	}
+	return nil
+}
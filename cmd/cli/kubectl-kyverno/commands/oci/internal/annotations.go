@@ -2,14 +2,16 @@ package internal
 
 import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/oci"
 )
 
 const (
-	PolicyConfigMediaType = "application/vnd.cncf.kyverno.config.v1+json"
-	PolicyLayerMediaType  = "application/vnd.cncf.kyverno.policy.layer.v1+yaml"
-	AnnotationKind        = "io.kyverno.image.kind"
-	AnnotationName        = "io.kyverno.image.name"
-	AnnotationApiVersion  = "io.kyverno.image.apiVersion"
+	PolicyConfigMediaType  = oci.PolicyConfigMediaType
+	PolicyLayerMediaType   = oci.PolicyLayerMediaType
+	ResourceLayerMediaType = oci.ResourceLayerMediaType
+	AnnotationKind         = "io.kyverno.image.kind"
+	AnnotationName         = "io.kyverno.image.name"
+	AnnotationApiVersion   = "io.kyverno.image.apiVersion"
 )
 
 func Annotations(policy kyvernov1.PolicyInterface) map[string]string {
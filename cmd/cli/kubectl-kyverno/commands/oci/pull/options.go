@@ -4,15 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 
 	securejoin "github.com/cyphar/filepath-securejoin"
 	"github.com/google/go-containerregistry/pkg/authn"
-	"github.com/google/go-containerregistry/pkg/name"
-	"github.com/google/go-containerregistry/pkg/v1/remote"
-	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/oci/internal"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/oci"
 	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
 )
@@ -53,53 +50,25 @@ func (o options) execute(ctx context.Context, dir string, keychain authn.Keychai
 	if err == nil && !fi.IsDir() {
 		return fmt.Errorf("dir '%s' must be a directory", dir)
 	}
-	ref, err := name.ParseReference(o.imageRef)
+	fmt.Fprintf(os.Stderr, "Downloading policies from an image [%s]...\n", o.imageRef)
+	layers, err := oci.PullPolicyLayers(ctx, o.imageRef, keychain)
 	if err != nil {
-		return fmt.Errorf("parsing image reference: %v", err)
+		return err
 	}
-	fmt.Fprintf(os.Stderr, "Downloading policies from an image [%s]...\n", ref.Name())
-	rmt, err := remote.Get(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain))
-	if err != nil {
-		return fmt.Errorf("getting image: %v", err)
-	}
-	img, err := rmt.Image()
-	if err != nil {
-		return fmt.Errorf("getting image: %v", err)
-	}
-	l, err := img.Layers()
-	if err != nil {
-		return fmt.Errorf("getting image layers: %v", err)
-	}
-	for _, layer := range l {
-		lmt, err := layer.MediaType()
+	for _, layerBytes := range layers {
+		policies, _, err := yamlutils.GetPolicy(layerBytes)
 		if err != nil {
-			return fmt.Errorf("getting layer media type: %v", err)
+			return fmt.Errorf("unmarshaling layer blob: %v", err)
 		}
-		if lmt == internal.PolicyLayerMediaType {
-			blob, err := layer.Compressed()
-			if err != nil {
-				return fmt.Errorf("getting layer blob: %v", err)
-			}
-			defer blob.Close()
-
-			layerBytes, err := io.ReadAll(blob)
-			if err != nil {
-				return fmt.Errorf("reading layer blob: %v", err)
-			}
-			policies, _, err := yamlutils.GetPolicy(layerBytes)
+		for _, policy := range policies {
+			policyBytes, err := policyutils.ToYaml(policy)
 			if err != nil {
-				return fmt.Errorf("unmarshaling layer blob: %v", err)
+				return fmt.Errorf("converting policy to yaml: %v", err)
 			}
-			for _, policy := range policies {
-				policyBytes, err := policyutils.ToYaml(policy)
-				if err != nil {
-					return fmt.Errorf("converting policy to yaml: %v", err)
-				}
-				pp := filepath.Join(dir, policy.GetName()+".yaml")
-				fmt.Fprintf(os.Stderr, "Saving policy into disk [%s]...\n", pp)
-				if err := os.WriteFile(pp, policyBytes, 0o600); err != nil {
-					return fmt.Errorf("creating file: %v", err)
-				}
+			pp := filepath.Join(dir, policy.GetName()+".yaml")
+			fmt.Fprintf(os.Stderr, "Saving policy into disk [%s]...\n", pp)
+			if err := os.WriteFile(pp, policyBytes, 0o600); err != nil {
+				return fmt.Errorf("creating file: %v", err)
 			}
 		}
 	}
@@ -26,6 +26,7 @@ func Command(keychain authn.Keychain) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&options.imageRef, "image", "i", "", "image reference to push to or pull from")
+	cmd.Flags().StringSliceVarP(&options.resources, "resource", "r", nil, "resource files added to the image, they can be loaded with kyverno apply --resource oci://")
 	if err := cmd.MarkFlagRequired("image"); err != nil {
 		log.Println("WARNING", err)
 	}
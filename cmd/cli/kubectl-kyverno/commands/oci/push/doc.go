@@ -15,4 +15,8 @@ var examples = [][]string{
 		`# Push multiple policies to an OCI image from a given directory that includes policies`,
 		`kyverno oci push . -i <imgref>`,
 	},
+	{
+		`# Push policies and the resources they are tested against to an OCI image`,
+		`kyverno oci push ./policy.yaml -r ./resources.yaml -i <imgref>`,
+	},
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
@@ -21,7 +22,8 @@ import (
 )
 
 type options struct {
-	imageRef string
+	imageRef  string
+	resources []string
 }
 
 func (o options) validate(policy string) error {
@@ -69,6 +71,19 @@ func (o options) execute(ctx context.Context, dir string, keychain authn.Keychai
 			return fmt.Errorf("mutating image: %v", err)
 		}
 	}
+	for _, path := range o.resources {
+		fmt.Fprintf(os.Stderr, "Adding resources [%s]\n", path)
+		resourceBytes, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return fmt.Errorf("reading resource file: %v", err)
+		}
+		img, err = mutate.Append(img, mutate.Addendum{
+			Layer: static.NewLayer(resourceBytes, internal.ResourceLayerMediaType),
+		})
+		if err != nil {
+			return fmt.Errorf("mutating image: %v", err)
+		}
+	}
 	fmt.Fprintf(os.Stderr, "Uploading [%s]...\n", ref.Name())
 	if err = remote.Write(ref, img, remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain)); err != nil {
 		return fmt.Errorf("writing image: %v", err)
@@ -0,0 +1,33 @@
+package resources
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "resources [policy]...",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.policyPaths = args
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.run(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringSliceVarP(&options.resourcePaths, "resource", "r", nil, "Path to resource files")
+	cmd.Flags().BoolVarP(&options.cluster, "cluster", "c", false, "List matched resources from the cluster in the current context")
+	cmd.Flags().StringVarP(&options.namespace, "namespace", "n", "", "Only list resources in the given namespace (used with the cluster flag)")
+	cmd.Flags().StringVar(&options.rule, "rule", "", "Only list resources matched by the given rule")
+	cmd.Flags().StringVar(&options.operation, "operation", "CREATE", "Admission operation used to evaluate match and exclude blocks (CREATE, UPDATE, DELETE or CONNECT)")
+	cmd.Flags().StringVar(&options.kubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&options.context, "context", "", "The name of the kubeconfig context to use")
+	return cmd
+}
@@ -0,0 +1,173 @@
+package resources
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestCommandNoArgs(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithoutResources(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"policy.yaml"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: resource file(s) or cluster required`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidOperation(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"policy.yaml", "--cluster", "--operation", "foo"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: invalid operation foo, must be one of CREATE, UPDATE, DELETE or CONNECT`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func newResource(apiVersion, kind, namespace, name string, labels map[string]string) *unstructured.Unstructured {
+	resource := &unstructured.Unstructured{}
+	resource.SetAPIVersion(apiVersion)
+	resource.SetKind(kind)
+	resource.SetNamespace(namespace)
+	resource.SetName(name)
+	resource.SetLabels(labels)
+	return resource
+}
+
+func TestExecute(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "require-team"},
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{
+				Name: "pods",
+				MatchResources: kyvernov1.MatchResources{
+					Any: kyvernov1.ResourceFilters{{
+						ResourceDescription: kyvernov1.ResourceDescription{
+							Kinds:             []string{"Pod"},
+							NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+						},
+					}},
+				},
+				ExcludeResources: kyvernov1.MatchResources{
+					Any: kyvernov1.ResourceFilters{{
+						ResourceDescription: kyvernov1.ResourceDescription{
+							Names: []string{"excluded-*"},
+						},
+					}},
+				},
+				Validation: kyvernov1.Validation{Message: "team required"},
+			}},
+		},
+	}
+	autogenPolicy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "restrict-pods"},
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{
+				Name: "check",
+				MatchResources: kyvernov1.MatchResources{
+					Any: kyvernov1.ResourceFilters{{
+						ResourceDescription: kyvernov1.ResourceDescription{
+							Kinds: []string{"Pod"},
+						},
+					}},
+				},
+				Validation: kyvernov1.Validation{Message: "restricted", Deny: &kyvernov1.Deny{}},
+			}},
+		},
+	}
+	resources := []*unstructured.Unstructured{
+		newResource("v1", "Namespace", "", "prod", map[string]string{"env": "prod"}),
+		newResource("v1", "Namespace", "", "dev", map[string]string{"env": "dev"}),
+		newResource("v1", "Pod", "prod", "matched", nil),
+		newResource("v1", "Pod", "prod", "excluded-pod", nil),
+		newResource("v1", "Pod", "dev", "other-namespace", nil),
+		newResource("apps/v1", "Deployment", "prod", "controller", nil),
+		newResource("v1", "ConfigMap", "prod", "other-kind", nil),
+	}
+	o := options{operation: "CREATE"}
+	tests := []struct {
+		name     string
+		options  options
+		policies []kyvernov1.PolicyInterface
+		want     []string
+		notWant  []string
+	}{{
+		name:     "all",
+		options:  options{operation: "CREATE"},
+		policies: []kyvernov1.PolicyInterface{policy},
+		want:     []string{"matched", "1 matched resource(s)"},
+		notWant:  []string{"excluded-pod", "other-namespace", "other-kind", "controller"},
+	}, {
+		name:     "autogen",
+		options:  options{operation: "CREATE"},
+		policies: []kyvernov1.PolicyInterface{autogenPolicy},
+		want:     []string{"controller", "autogen-check", "4 matched resource(s)"},
+		notWant:  []string{"other-kind"},
+	}, {
+		name:     "rule",
+		options:  options{operation: "CREATE", rule: "check"},
+		policies: []kyvernov1.PolicyInterface{policy, autogenPolicy},
+		want:     []string{"matched", "excluded-pod", "other-namespace", "3 matched resource(s)"},
+		notWant:  []string{"controller"},
+	}, {
+		name:     "none",
+		options:  options{operation: "CREATE", rule: "foo"},
+		policies: []kyvernov1.PolicyInterface{policy, autogenPolicy},
+		want:     []string{"No matched resources found."},
+	}}
+	labels, err := o.namespaceLabels(context.TODO(), nil, resources)
+	assert.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := bytes.NewBufferString("")
+			err := tt.options.execute(b, tt.policies, resources, labels)
+			assert.NoError(t, err)
+			for _, want := range tt.want {
+				assert.Contains(t, b.String(), want)
+			}
+			for _, notWant := range tt.notWant {
+				assert.NotContains(t, b.String(), notWant)
+			}
+		})
+	}
+}
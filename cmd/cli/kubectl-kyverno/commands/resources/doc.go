@@ -0,0 +1,25 @@
+package resources
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#resources`
+
+var description = []string{
+	`Lists the resources matched by policy rules.`,
+	``,
+	`Resources are matched using the same match and exclude logic as the engine,`,
+	`this can be used to verify the impact of a policy before switching it to Enforce.`,
+}
+
+var examples = [][]string{
+	{
+		`# List resources in the cluster matched by a policy`,
+		`kyverno resources /path/to/policy.yaml --cluster`,
+	},
+	{
+		`# List resources in a namespace matched by a single rule`,
+		`kyverno resources /path/to/policy.yaml --rule <rule> --cluster --namespace <namespace>`,
+	},
+	{
+		`# List resources from files matched by a policy`,
+		`kyverno resources /path/to/policy.yaml --resource /path/to/resources/`,
+	},
+}
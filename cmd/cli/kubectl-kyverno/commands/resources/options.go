@@ -0,0 +1,180 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+type options struct {
+	policyPaths   []string
+	resourcePaths []string
+	cluster       bool
+	namespace     string
+	rule          string
+	operation     string
+	kubeConfig    string
+	context       string
+}
+
+type row struct {
+	Policy    string `header:"policy"`
+	Rule      string `header:"rule"`
+	Kind      string `header:"kind"`
+	Namespace string `header:"namespace"`
+	Name      string `header:"name"`
+}
+
+func (o options) validate() error {
+	if len(o.resourcePaths) == 0 && !o.cluster {
+		return fmt.Errorf("resource file(s) or cluster required")
+	}
+	switch kyvernov1.AdmissionOperation(o.operation) {
+	case kyvernov1.Create, kyvernov1.Update, kyvernov1.Delete, kyvernov1.Connect:
+		return nil
+	default:
+		return fmt.Errorf("invalid operation %s, must be one of CREATE, UPDATE, DELETE or CONNECT", o.operation)
+	}
+}
+
+func (o options) run(ctx context.Context, out io.Writer) error {
+	var policies []kyvernov1.PolicyInterface
+	for _, path := range o.policyPaths {
+		loaded, _, err := policy.Load(nil, "", path)
+		if err != nil {
+			return fmt.Errorf("failed to load policies (%w)", err)
+		}
+		policies = append(policies, loaded...)
+	}
+	var dClient dclient.Interface
+	if o.cluster {
+		client, err := o.client(ctx)
+		if err != nil {
+			return err
+		}
+		dClient = client
+	}
+	resources, err := common.GetResources(out, policies, nil, o.resourcePaths, dClient, o.cluster, o.namespace, false)
+	if err != nil {
+		return fmt.Errorf("failed to load resources (%w)", err)
+	}
+	namespaceLabels, err := o.namespaceLabels(ctx, dClient, resources)
+	if err != nil {
+		return err
+	}
+	return o.execute(out, policies, resources, namespaceLabels)
+}
+
+func (o options) client(ctx context.Context) (dclient.Interface, error) {
+	restConfig, err := config.CreateClientConfigWithContext(o.kubeConfig, o.context)
+	if err != nil {
+		return nil, err
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return dclient.NewClient(ctx, dynamicClient, kubeClient, 15*time.Minute)
+}
+
+// namespaceLabels returns the labels of every known namespace, used to evaluate namespace selectors.
+func (o options) namespaceLabels(ctx context.Context, dClient dclient.Interface, resources []*unstructured.Unstructured) (map[string]map[string]string, error) {
+	namespaceLabels := map[string]map[string]string{}
+	if dClient != nil {
+		list, err := dClient.ListResource(ctx, "v1", "Namespace", "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list namespaces (%w)", err)
+		}
+		for _, namespace := range list.Items {
+			namespaceLabels[namespace.GetName()] = namespace.GetLabels()
+		}
+	}
+	for _, resource := range resources {
+		if resource.GetKind() == "Namespace" && resource.GetAPIVersion() == "v1" {
+			namespaceLabels[resource.GetName()] = resource.GetLabels()
+		}
+	}
+	return namespaceLabels, nil
+}
+
+func (o options) execute(out io.Writer, policies []kyvernov1.PolicyInterface, resources []*unstructured.Unstructured, namespaceLabels map[string]map[string]string) error {
+	var rows []row
+	for _, policy := range policies {
+		for _, rule := range autogen.ComputeRules(policy) {
+			if o.rule != "" && rule.Name != o.rule {
+				continue
+			}
+			for _, resource := range resources {
+				err := engineutils.MatchesResourceDescription(
+					*resource,
+					rule,
+					kyvernov1beta1.RequestInfo{},
+					namespaceLabels[resource.GetNamespace()],
+					policy.GetNamespace(),
+					resource.GroupVersionKind(),
+					"",
+					kyvernov1.AdmissionOperation(o.operation),
+				)
+				if err != nil {
+					continue
+				}
+				rows = append(rows, row{
+					Policy:    policyKey(policy),
+					Rule:      rule.Name,
+					Kind:      resource.GetKind(),
+					Namespace: resource.GetNamespace(),
+					Name:      resource.GetName(),
+				})
+			}
+		}
+	}
+	if len(rows) == 0 {
+		fmt.Fprintln(out, "No matched resources found.")
+		return nil
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Policy != rows[j].Policy {
+			return rows[i].Policy < rows[j].Policy
+		}
+		if rows[i].Rule != rows[j].Rule {
+			return rows[i].Rule < rows[j].Rule
+		}
+		if rows[i].Kind != rows[j].Kind {
+			return rows[i].Kind < rows[j].Kind
+		}
+		if rows[i].Namespace != rows[j].Namespace {
+			return rows[i].Namespace < rows[j].Namespace
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	printer := table.NewTablePrinter(out)
+	printer.Print(rows)
+	fmt.Fprintf(out, "\n%d matched resource(s)\n", len(rows))
+	return nil
+}
+
+func policyKey(policy kyvernov1.PolicyInterface) string {
+	if policy.GetNamespace() != "" {
+		return policy.GetNamespace() + "/" + policy.GetName()
+	}
+	return policy.GetName()
+}
@@ -0,0 +1,58 @@
+package test
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// clusterState holds the resources existing in the cluster a test is run against
+type clusterState struct {
+	namespaceLabels map[string]map[string]string
+	configMaps      []corev1.ConfigMap
+}
+
+func loadClusterState(fs billy.Filesystem, paths ...string) (*clusterState, error) {
+	state := clusterState{
+		namespaceLabels: map[string]map[string]string{},
+	}
+	for _, path := range paths {
+		resources, err := resource.GetResourcesFromPath(fs, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load cluster resources from %s (%w)", path, err)
+		}
+		for _, object := range resources {
+			switch object.GroupVersionKind() {
+			case corev1.SchemeGroupVersion.WithKind("Namespace"):
+				state.namespaceLabels[object.GetName()] = object.GetLabels()
+			case corev1.SchemeGroupVersion.WithKind("ConfigMap"):
+				var configMap corev1.ConfigMap
+				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.UnstructuredContent(), &configMap); err != nil {
+					return nil, fmt.Errorf("failed to decode config map %s (%w)", object.GetName(), err)
+				}
+				state.configMaps = append(state.configMaps, configMap)
+			default:
+				return nil, fmt.Errorf("cluster resources of kind %s are not supported, only namespaces and config maps are", object.GetKind())
+			}
+		}
+	}
+	return &state, nil
+}
+
+// namespaceSelectors returns the labels of the namespaces, the labels declared in the values take precedence
+func (s *clusterState) namespaceSelectors(values map[string]map[string]string) map[string]map[string]string {
+	if s == nil || len(s.namespaceLabels) == 0 {
+		return values
+	}
+	out := map[string]map[string]string{}
+	for name, labels := range s.namespaceLabels {
+		out[name] = labels
+	}
+	for name, labels := range values {
+		out[name] = labels
+	}
+	return out
+}
@@ -0,0 +1,29 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_loadClusterState(t *testing.T) {
+	state, err := loadClusterState(nil, "../../../../../test/cli/test/cluster-resources/cluster.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"prod": {"env": "prod"},
+		"dev":  {"env": "dev"},
+	}, state.namespaceSelectors(nil))
+	assert.Equal(t, map[string]map[string]string{
+		"prod": {"env": "prod"},
+		"dev":  {"env": "staging"},
+	}, state.namespaceSelectors(map[string]map[string]string{"dev": {"env": "staging"}}))
+	assert.Len(t, state.configMaps, 1)
+	assert.Equal(t, "kyverno", state.configMaps[0].Namespace)
+	assert.Equal(t, map[string]string{"allowed": `["payments", "search"]`}, state.configMaps[0].Data)
+
+	_, err = loadClusterState(nil, "../../../../../test/cli/test/cluster-resources/resources.yaml")
+	assert.Error(t, err)
+
+	var empty *clusterState
+	assert.Nil(t, empty.namespaceSelectors(nil))
+}
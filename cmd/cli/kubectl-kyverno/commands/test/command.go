@@ -20,8 +20,10 @@ import (
 
 func Command() *cobra.Command {
 	var testCase string
-	var fileName, gitBranch string
-	var registryAccess, failOnly, removeColor, detailedResults bool
+	var fileName, gitBranch, gitDir string
+	var registryAccess, failOnly, removeColor, detailedResults, checkIdempotency, coverage bool
+	var coverageOutput string
+	var coverageThreshold float64
 	cmd := &cobra.Command{
 		Use:          "test [local folder or git repository]...",
 		Short:        command.FormatDescription(true, websiteUrl, false, description...),
@@ -31,16 +33,21 @@ func Command() *cobra.Command {
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, dirPath []string) (err error) {
 			color.Init(removeColor)
-			return testCommandExecute(cmd.OutOrStdout(), dirPath, fileName, gitBranch, testCase, registryAccess, failOnly, detailedResults)
+			return testCommandExecute(cmd.OutOrStdout(), dirPath, fileName, gitBranch, gitDir, testCase, registryAccess, failOnly, detailedResults, checkIdempotency, coverage, coverageOutput, coverageThreshold)
 		},
 	}
 	cmd.Flags().StringVarP(&fileName, "file-name", "f", "kyverno-test.yaml", "Test filename")
 	cmd.Flags().StringVarP(&gitBranch, "git-branch", "b", "", "Test github repository branch")
+	cmd.Flags().StringVarP(&gitDir, "git-dir", "d", "", "Directory of the git repository containing the tests")
 	cmd.Flags().StringVarP(&testCase, "test-case-selector", "t", "policy=*,rule=*,resource=*", "Filter test cases to run")
 	cmd.Flags().BoolVar(&registryAccess, "registry", false, "If set to true, access the image registry using local docker credentials to populate external data")
 	cmd.Flags().BoolVar(&failOnly, "fail-only", false, "If set to true, display all the failing test only as output for the test command")
 	cmd.Flags().BoolVar(&removeColor, "remove-color", false, "Remove any color from output")
 	cmd.Flags().BoolVar(&detailedResults, "detailed-results", false, "If set to true, display detailed results")
+	cmd.Flags().BoolVar(&checkIdempotency, "check-idempotency", false, "If set to true, apply mutate policies a second time and fail the test when the second pass changes the mutated resources")
+	cmd.Flags().BoolVar(&coverage, "coverage", false, "If set to true, report the policy rules lacking a test case for pass and fail results")
+	cmd.Flags().StringVar(&coverageOutput, "coverage-output", "", "Write the coverage report in JSON format to the given file, implies --coverage")
+	cmd.Flags().Float64Var(&coverageThreshold, "coverage-threshold", 0, "Fail when the percentage of covered rules is below the given value, implies --coverage")
 	return cmd
 }
 
@@ -55,10 +62,15 @@ func testCommandExecute(
 	dirPath []string,
 	fileName string,
 	gitBranch string,
+	gitDir string,
 	testCase string,
 	registryAccess bool,
 	failOnly bool,
 	detailedResults bool,
+	checkIdempotency bool,
+	coverage bool,
+	coverageOutput string,
+	coverageThreshold float64,
 ) (err error) {
 	// check input dir
 	if len(dirPath) == 0 {
@@ -74,7 +86,7 @@ func testCommandExecute(
 		}
 	}
 	// load tests
-	tests, err := loadTests(dirPath, fileName, gitBranch)
+	tests, err := loadTests(dirPath, fileName, gitBranch, gitDir)
 	if err != nil {
 		fmt.Fprintln(out)
 		fmt.Fprintln(out, "Error loading tests:", err)
@@ -116,7 +128,7 @@ func testCommandExecute(
 				continue
 			}
 			resourcePath := filepath.Dir(test.Path)
-			responses, err := runTest(out, test, registryAccess, false)
+			responses, err := runTest(out, test, registryAccess, false, checkIdempotency)
 			if err != nil {
 				return fmt.Errorf("failed to run test (%w)", err)
 			}
@@ -133,6 +145,19 @@ func testCommandExecute(
 	} else {
 		fmt.Fprintf(out, "\nTest Summary: %d out of %d tests failed\n", rc.Fail, rc.Pass+rc.Skip+rc.Fail)
 	}
+	var coverageErr error
+	if coverage || coverageOutput != "" || coverageThreshold > 0 {
+		report, err := computeCoverage(tests)
+		if err != nil {
+			return fmt.Errorf("failed to compute coverage (%w)", err)
+		}
+		if err := printCoverage(out, report, coverageOutput); err != nil {
+			return fmt.Errorf("failed to write coverage report (%w)", err)
+		}
+		if report.Coverage < coverageThreshold {
+			coverageErr = fmt.Errorf("coverage %.2f%% is below the %.2f%% threshold", report.Coverage, coverageThreshold)
+		}
+	}
 	fmt.Fprintln(out)
 	if rc.Fail > 0 {
 		if !failOnly {
@@ -140,7 +165,7 @@ func testCommandExecute(
 		}
 		return fmt.Errorf("%d tests failed", rc.Fail)
 	}
-	return nil
+	return coverageErr
 }
 
 func checkResult(test v1alpha1.TestResult, fs billy.Filesystem, resoucePath string, response engineapi.EngineResponse, rule engineapi.RuleResponse) (bool, string, string) {
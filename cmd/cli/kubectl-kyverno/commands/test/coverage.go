@@ -0,0 +1,134 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/path"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ruleCoverage tells if a policy rule is tested for both pass and fail outcomes
+type ruleCoverage struct {
+	Policy string `json:"policy" header:"policy"`
+	Rule   string `json:"rule" header:"rule"`
+	Pass   bool   `json:"pass" header:"pass"`
+	Fail   bool   `json:"fail" header:"fail"`
+}
+
+func (r ruleCoverage) covered() bool {
+	return r.Pass && r.Fail
+}
+
+// coverageReport is the machine readable coverage output
+type coverageReport struct {
+	Rules    []ruleCoverage `json:"rules"`
+	Total    int            `json:"total"`
+	Covered  int            `json:"covered"`
+	Coverage float64        `json:"coverage"`
+}
+
+// computeCoverage lists the rules of the policies referenced by the tests and the outcomes tested for each of them.
+func computeCoverage(tests test.TestCases) (coverageReport, error) {
+	rules := map[string]map[string]*ruleCoverage{}
+	for _, testCase := range tests {
+		if testCase.Err != nil {
+			continue
+		}
+		testDir := testCase.Dir()
+		policyFullPath := path.GetFullPaths(testCase.Test.Policies, testDir, testCase.Fs != nil)
+		policies, _, err := policy.Load(testCase.Fs, testDir, policyFullPath...)
+		if err != nil {
+			return coverageReport{}, fmt.Errorf("failed to load policies (%w)", err)
+		}
+		for _, policy := range policies {
+			key, err := cache.MetaNamespaceKeyFunc(policy)
+			if err != nil {
+				return coverageReport{}, err
+			}
+			if rules[key] == nil {
+				rules[key] = map[string]*ruleCoverage{}
+			}
+			for _, rule := range policy.GetSpec().Rules {
+				if rules[key][rule.Name] == nil {
+					rules[key][rule.Name] = &ruleCoverage{Policy: key, Rule: rule.Name}
+				}
+			}
+		}
+		for _, result := range testCase.Test.Results {
+			key := result.Policy
+			if result.Namespace != "" && !strings.Contains(key, "/") {
+				key = result.Namespace + "/" + key
+			}
+			rule := strings.TrimPrefix(strings.TrimPrefix(result.Rule, "autogen-cronjob-"), "autogen-")
+			coverage := rules[key][rule]
+			if coverage == nil {
+				continue
+			}
+			expected := result.Result
+			if expected == "" {
+				expected = result.Status
+			}
+			switch expected {
+			case policyreportv1alpha2.StatusPass:
+				coverage.Pass = true
+			case policyreportv1alpha2.StatusFail:
+				coverage.Fail = true
+			}
+		}
+	}
+	var report coverageReport
+	for _, policyRules := range rules {
+		for _, rule := range policyRules {
+			report.Rules = append(report.Rules, *rule)
+			report.Total++
+			if rule.covered() {
+				report.Covered++
+			}
+		}
+	}
+	sort.Slice(report.Rules, func(i, j int) bool {
+		if report.Rules[i].Policy != report.Rules[j].Policy {
+			return report.Rules[i].Policy < report.Rules[j].Policy
+		}
+		return report.Rules[i].Rule < report.Rules[j].Rule
+	})
+	if report.Total != 0 {
+		report.Coverage = float64(report.Covered) * 100 / float64(report.Total)
+	}
+	return report, nil
+}
+
+// printCoverage prints the rules missing a pass or fail test case and writes the coverage report to the output file if any.
+func printCoverage(out io.Writer, report coverageReport, outputFile string) error {
+	var uncovered []ruleCoverage
+	for _, rule := range report.Rules {
+		if !rule.covered() {
+			uncovered = append(uncovered, rule)
+		}
+	}
+	fmt.Fprintln(out)
+	if len(uncovered) != 0 {
+		fmt.Fprintln(out, "Rules missing a pass or fail test case:")
+		printer := table.NewTablePrinter(out)
+		printer.Print(uncovered)
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintf(out, "Coverage: %d out of %d rules covered (%.2f%%)\n", report.Covered, report.Total, report.Coverage)
+	if outputFile == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outputFile, data, 0o600)
+}
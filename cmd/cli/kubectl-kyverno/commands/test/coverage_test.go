@@ -0,0 +1,53 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_computeCoverage(t *testing.T) {
+	tests, err := loadTests([]string{"../../../../../test/cli/test/simple"}, "kyverno-test.yaml", "", "")
+	assert.NoError(t, err)
+	report, err := computeCoverage(tests)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, report.Total)
+	assert.Equal(t, 2, report.Covered)
+	assert.InDelta(t, 28.57, report.Coverage, 0.01)
+	assert.Equal(t, []ruleCoverage{
+		{Policy: "disallow-latest-tag", Rule: "require-image-tag", Pass: true, Fail: true},
+		{Policy: "disallow-latest-tag", Rule: "validate-image-tag", Pass: true, Fail: true},
+		{Policy: "duration-test", Rule: "greater-equal-than", Fail: true},
+		{Policy: "duration-test", Rule: "greater-than", Fail: true},
+		{Policy: "duration-test", Rule: "less-equal-than", Pass: true},
+		{Policy: "duration-test", Rule: "less-than", Pass: true},
+		{Policy: "restrict-pod-counts", Rule: "restrict-pod-count", Fail: true},
+	}, report.Rules)
+}
+
+func Test_printCoverage(t *testing.T) {
+	report := coverageReport{
+		Rules: []ruleCoverage{
+			{Policy: "disallow-latest-tag", Rule: "require-image-tag", Pass: true, Fail: true},
+			{Policy: "restrict-pod-counts", Rule: "restrict-pod-count", Fail: true},
+		},
+		Total:    2,
+		Covered:  1,
+		Coverage: 50,
+	}
+	output := filepath.Join(t.TempDir(), "coverage.json")
+	b := bytes.NewBufferString("")
+	assert.NoError(t, printCoverage(b, report, output))
+	assert.Contains(t, b.String(), "restrict-pod-count")
+	assert.NotContains(t, b.String(), "require-image-tag")
+	assert.Contains(t, b.String(), "Coverage: 1 out of 2 rules covered (50.00%)")
+	data, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	var written coverageReport
+	assert.NoError(t, json.Unmarshal(data, &written))
+	assert.Equal(t, report, written)
+}
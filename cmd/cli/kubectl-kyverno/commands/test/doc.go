@@ -17,6 +17,10 @@ var examples = [][]string{
 		`# Test a git repository containing Kyverno test cases`,
 		`kyverno test https://github.com/kyverno/policies/pod-security --git-branch main`,
 	},
+	{
+		`# Test a folder of a git repository, credentials are read from KYVERNO_GIT_USERNAME and KYVERNO_GIT_TOKEN`,
+		`kyverno test https://github.com/org/policies.git -b main -d policies/`,
+	},
 	{
 		`# Test a local folder containing test cases`,
 		`kyverno test .`,
@@ -25,4 +29,8 @@ var examples = [][]string{
 		`# Test some specific test cases out of many test cases in a local folder`,
 		`kyverno test . --test-case-selector "policy=disallow-latest-tag, rule=require-image-tag, resource=test-require-image-tag-pass"`,
 	},
+	{
+		`# Test a local folder and fail when less than 80% of the rules have both pass and fail test cases`,
+		`kyverno test . --coverage-output coverage.json --coverage-threshold 80`,
+	},
 }
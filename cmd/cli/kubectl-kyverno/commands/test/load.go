@@ -13,10 +13,10 @@ import (
 	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
 )
 
-func loadTests(paths []string, fileName string, gitBranch string) (test.TestCases, error) {
+func loadTests(paths []string, fileName string, gitBranch string, gitDir string) (test.TestCases, error) {
 	var tests []test.TestCase
 	for _, path := range paths {
-		t, err := loadTest(path, fileName, gitBranch)
+		t, err := loadTest(path, fileName, gitBranch, gitDir)
 		if err != nil {
 			return nil, err
 		}
@@ -25,53 +25,25 @@ func loadTests(paths []string, fileName string, gitBranch string) (test.TestCase
 	return tests, nil
 }
 
-func loadTest(path string, fileName string, gitBranch string) (test.TestCases, error) {
+func loadTest(path string, fileName string, gitBranch string, gitDir string) (test.TestCases, error) {
 	var tests []test.TestCase
 	if source.IsGit(path) {
 		fs := memfs.New()
-		gitURL, err := url.Parse(path)
+		repoURL, gitBranch, gitPathToYamls, err := parseGitSource(path, gitBranch, gitDir)
 		if err != nil {
 			return nil, err
-		} else {
-			pathElems := strings.Split(gitURL.Path[1:], "/")
-			if len(pathElems) <= 1 {
-				return nil, fmt.Errorf("invalid URL path %s - expected https://github.com/:owner/:repository/:branch (without --git-branch flag) OR https://github.com/:owner/:repository/:directory (with --git-branch flag)", gitURL.Path)
-			}
-			gitURL.Path = strings.Join([]string{pathElems[0], pathElems[1]}, "/")
-			repoURL := gitURL.String()
-			var gitPathToYamls string
-			if gitBranch == "" {
-				gitPathToYamls = "/"
-				if string(path[len(path)-1]) == "/" {
-					gitBranch = strings.ReplaceAll(path, repoURL+"/", "")
-				} else {
-					gitBranch = strings.ReplaceAll(path, repoURL, "")
-				}
-				if gitBranch == "" {
-					gitBranch = "main"
-				} else if string(gitBranch[0]) == "/" {
-					gitBranch = gitBranch[1:]
-				}
-			} else {
-				if string(path[len(path)-1]) == "/" {
-					gitPathToYamls = strings.ReplaceAll(path, repoURL+"/", "/")
-				} else {
-					gitPathToYamls = strings.ReplaceAll(path, repoURL, "/")
-				}
-			}
-			if _, err := gitutils.Clone(repoURL, fs, gitBranch); err != nil {
-				return nil, fmt.Errorf("Error: failed to clone repository \nCause: %s\n", err)
-			}
-			yamlFiles, err := gitutils.ListYamls(fs, gitPathToYamls)
-			if err != nil {
-				return nil, fmt.Errorf("failed to list YAMLs in repository (%w)", err)
-			}
-			sort.Strings(yamlFiles)
-			for _, yamlFilePath := range yamlFiles {
-				if filepath.Base(yamlFilePath) == fileName {
-					// resoucePath := strings.Trim(yamlFilePath, fileName)
-					tests = append(tests, test.LoadTest(fs, yamlFilePath))
-				}
+		}
+		if _, err := gitutils.CloneWithAuth(repoURL, fs, gitBranch, source.GitAuth()); err != nil {
+			return nil, fmt.Errorf("Error: failed to clone repository \nCause: %s\n", err)
+		}
+		yamlFiles, err := gitutils.ListYamls(fs, gitPathToYamls)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list YAMLs in repository (%w)", err)
+		}
+		sort.Strings(yamlFiles)
+		for _, yamlFilePath := range yamlFiles {
+			if filepath.Base(yamlFilePath) == fileName {
+				tests = append(tests, test.LoadTest(fs, yamlFilePath))
 			}
 		}
 		return tests, nil
@@ -80,3 +52,50 @@ func loadTest(path string, fileName string, gitBranch string) (test.TestCases, e
 		return tests, err
 	}
 }
+
+// parseGitSource returns the repository URL, the branch and the directory to load tests from.
+// Repository URLs ending with .git are used as is and the directory comes from gitDir,
+// otherwise the URL is expected to be https://<domain>/:owner/:repository/:branch (without branch)
+// or https://<domain>/:owner/:repository/:directory (with branch).
+func parseGitSource(path string, gitBranch string, gitDir string) (string, string, string, error) {
+	if source.IsGitRepository(path) {
+		if gitBranch == "" {
+			gitBranch = "main"
+		}
+		return strings.TrimSuffix(path, "/"), gitBranch, "/" + strings.TrimPrefix(gitDir, "/"), nil
+	}
+	gitURL, err := url.Parse(path)
+	if err != nil {
+		return "", "", "", err
+	}
+	pathElems := strings.Split(gitURL.Path[1:], "/")
+	if len(pathElems) <= 1 {
+		return "", "", "", fmt.Errorf("invalid URL path %s - expected https://github.com/:owner/:repository/:branch (without --git-branch flag) OR https://github.com/:owner/:repository/:directory (with --git-branch flag)", gitURL.Path)
+	}
+	gitURL.Path = strings.Join([]string{pathElems[0], pathElems[1]}, "/")
+	repoURL := gitURL.String()
+	var gitPathToYamls string
+	if gitBranch == "" {
+		gitPathToYamls = "/"
+		if string(path[len(path)-1]) == "/" {
+			gitBranch = strings.ReplaceAll(path, repoURL+"/", "")
+		} else {
+			gitBranch = strings.ReplaceAll(path, repoURL, "")
+		}
+		if gitBranch == "" {
+			gitBranch = "main"
+		} else if string(gitBranch[0]) == "/" {
+			gitBranch = gitBranch[1:]
+		}
+	} else {
+		if string(path[len(path)-1]) == "/" {
+			gitPathToYamls = strings.ReplaceAll(path, repoURL+"/", "/")
+		} else {
+			gitPathToYamls = strings.ReplaceAll(path, repoURL, "/")
+		}
+	}
+	if gitDir != "" {
+		gitPathToYamls = filepath.Join(gitPathToYamls, gitDir)
+	}
+	return repoURL, gitBranch, gitPathToYamls, nil
+}
@@ -0,0 +1,78 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseGitSource(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		gitBranch  string
+		gitDir     string
+		wantRepo   string
+		wantBranch string
+		wantDir    string
+		wantErr    bool
+	}{{
+		name:       "branch in path",
+		path:       "https://github.com/kyverno/policies/release-1.10",
+		wantRepo:   "https://github.com/kyverno/policies",
+		wantBranch: "release-1.10",
+		wantDir:    "/",
+	}, {
+		name:       "default branch",
+		path:       "https://github.com/kyverno/policies",
+		wantRepo:   "https://github.com/kyverno/policies",
+		wantBranch: "main",
+		wantDir:    "/",
+	}, {
+		name:       "directory in path",
+		path:       "https://github.com/kyverno/policies/pod-security/",
+		gitBranch:  "main",
+		wantRepo:   "https://github.com/kyverno/policies",
+		wantBranch: "main",
+		wantDir:    "/pod-security/",
+	}, {
+		name:       "directory in path and flag",
+		path:       "https://github.com/kyverno/policies/pod-security",
+		gitBranch:  "main",
+		gitDir:     "baseline",
+		wantRepo:   "https://github.com/kyverno/policies",
+		wantBranch: "main",
+		wantDir:    "/pod-security/baseline",
+	}, {
+		name:       "repository",
+		path:       "https://github.com/org/policies.git",
+		gitBranch:  "dev",
+		gitDir:     "policies/",
+		wantRepo:   "https://github.com/org/policies.git",
+		wantBranch: "dev",
+		wantDir:    "/policies/",
+	}, {
+		name:       "repository without branch",
+		path:       "https://github.com/org/policies.git",
+		wantRepo:   "https://github.com/org/policies.git",
+		wantBranch: "main",
+		wantDir:    "/",
+	}, {
+		name:    "invalid path",
+		path:    "https://github.com/kyverno",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, branch, dir, err := parseGitSource(tt.path, tt.gitBranch, tt.gitDir)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRepo, repo)
+			assert.Equal(t, tt.wantBranch, branch)
+			assert.Equal(t, tt.wantDir, dir)
+		})
+	}
+}
@@ -27,7 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
-func runTest(out io.Writer, testCase test.TestCase, registryAccess bool, auditWarn bool) ([]engineapi.EngineResponse, error) {
+func runTest(out io.Writer, testCase test.TestCase, registryAccess bool, auditWarn bool, checkIdempotency bool) ([]engineapi.EngineResponse, error) {
 	// don't process test case with errors
 	if testCase.Err != nil {
 		return nil, testCase.Err
@@ -64,10 +64,20 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool, auditWa
 	// resources
 	fmt.Fprintln(out, "  Loading resources", "...")
 	resourceFullPath := path.GetFullPaths(testCase.Test.Resources, testDir, isGit)
-	resources, err := common.GetResourceAccordingToResourcePath(out, testCase.Fs, resourceFullPath, false, policies, validatingAdmissionPolicies, dClient, "", false, testDir)
+	resources, err := common.GetResourceAccordingToResourcePath(out, testCase.Fs, resourceFullPath, false, policies, validatingAdmissionPolicies, dClient, "", false, testDir, nil, nil)
 	if err != nil {
 		return nil, fmt.Errorf("Error: failed to load resources (%s)", err)
 	}
+	// cluster resources
+	var cluster *clusterState
+	if len(testCase.Test.ClusterResources) > 0 {
+		fmt.Fprintln(out, "  Loading cluster resources", "...")
+		clusterFullPath := path.GetFullPaths(testCase.Test.ClusterResources, testDir, isGit)
+		cluster, err = loadClusterState(testCase.Fs, clusterFullPath...)
+		if err != nil {
+			return nil, fmt.Errorf("Error: failed to load cluster resources (%s)", err)
+		}
+	}
 	uniques, duplicates := resource.RemoveDuplicates(resources)
 	if len(duplicates) > 0 {
 		for dup := range duplicates {
@@ -81,6 +91,9 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool, auditWa
 	if vars != nil {
 		vars.SetInStore(&store)
 	}
+	if cluster != nil {
+		store.SetConfigMaps(cluster.configMaps...)
+	}
 	fmt.Fprintln(out, "  Applying", len(policies)+len(validatingAdmissionPolicies), pluralize.Pluralize(len(policies)+len(validatingAdmissionPolicies), "policy", "policies"), "to", len(uniques), pluralize.Pluralize(len(uniques), "resource", "resources"), "...")
 	// TODO document the code below
 	ruleToCloneSourceResource := map[string]string{}
@@ -147,12 +160,14 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool, auditWa
 			Variables:                 vars,
 			UserInfo:                  userInfo,
 			PolicyReport:              true,
-			NamespaceSelectorMap:      vars.NamespaceSelectors(),
+			NamespaceSelectorMap:      cluster.namespaceSelectors(vars.NamespaceSelectors()),
 			Rc:                        &resultCounts,
 			RuleToCloneSourceResource: ruleToCloneSourceResource,
 			Client:                    dClient,
 			Subresources:              vars.Subresources(),
 			Out:                       out,
+			CheckIdempotency:          checkIdempotency,
+			FailNonIdempotent:         checkIdempotency,
 		}
 		ers, err := processor.ApplyPoliciesOnResource()
 		if err != nil {
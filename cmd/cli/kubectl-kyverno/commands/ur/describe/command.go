@@ -0,0 +1,31 @@
+package describe
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "describe [name]",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if err := options.validate(name); err != nil {
+				return err
+			}
+			client, err := options.UpdateRequests()
+			if err != nil {
+				return err
+			}
+			return options.execute(cmd.Context(), cmd.OutOrStdout(), name, client)
+		},
+	}
+	options.AddFlags(cmd.Flags())
+	return cmd
+}
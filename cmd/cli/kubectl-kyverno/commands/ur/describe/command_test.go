@@ -0,0 +1,93 @@
+package describe
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCommandWithInvalidArg(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: accepts 1 arg(s), received 0`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func TestExecute(t *testing.T) {
+	ur := &kyvernov1beta1.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "ur-failed", Namespace: "kyverno"},
+		Spec: kyvernov1beta1.UpdateRequestSpec{
+			Type:     kyvernov1beta1.Generate,
+			Policy:   "sync-secrets",
+			Rule:     "sync",
+			Resource: kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "Namespace", Name: "tenant"},
+		},
+		Status: kyvernov1beta1.UpdateRequestStatus{
+			State:      kyvernov1beta1.Failed,
+			RetryCount: 2,
+			Message:    "forbidden",
+			LastError:  "forbidden",
+			AttemptedResources: []kyvernov1.ResourceSpec{
+				{APIVersion: "v1", Kind: "Secret", Namespace: "tenant", Name: "regcred"},
+			},
+			Conditions: []metav1.Condition{{
+				Type:    kyvernov1beta1.URConditionProcessed,
+				Status:  metav1.ConditionFalse,
+				Reason:  kyvernov1beta1.URReasonForbidden,
+				Message: "forbidden",
+			}},
+		},
+	}
+	client := fake.NewSimpleClientset(ur).KyvernoV1beta1().UpdateRequests("kyverno")
+	var options options
+	b := bytes.NewBufferString("")
+	err := options.execute(context.TODO(), b, "ur-failed", client)
+	assert.NoError(t, err)
+	expected := `
+Name:         ur-failed
+Namespace:    kyverno
+Type:         generate
+Policy:       sync-secrets
+Rule:         sync
+Trigger:      v1/Namespace//tenant
+State:        Failed
+Retries:      2
+Reason:       Forbidden
+Message:      forbidden
+Last error:   forbidden
+Attempted resources:
+  - v1/Secret/tenant/regcred
+Conditions:
+  - Processed=False (Forbidden): forbidden
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(b.String()))
+	err = options.execute(context.TODO(), b, "foo", client)
+	assert.Error(t, err)
+}
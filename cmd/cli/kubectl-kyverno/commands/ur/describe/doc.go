@@ -0,0 +1,18 @@
+package describe
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#ur`
+
+var description = []string{
+	`Describes an update request, including its conditions, last error and the downstream resources it attempted to apply.`,
+}
+
+var examples = [][]string{
+	{
+		`# Describe an update request`,
+		`kyverno ur describe <name>`,
+	},
+	{
+		`# Describe an update request stored in a custom namespace`,
+		`kyverno ur describe <name> -n <namespace>`,
+	},
+}
@@ -0,0 +1,86 @@
+package describe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/ur/internal"
+	kyvernov1beta1client "github.com/kyverno/kyverno/pkg/client/clientset/versioned/typed/kyverno/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type options struct {
+	internal.ClientOptions
+}
+
+func (o options) validate(name string) error {
+	if name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+func (o options) execute(ctx context.Context, out io.Writer, name string, client kyvernov1beta1client.UpdateRequestInterface) error {
+	ur, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	printUpdateRequest(out, *ur)
+	return nil
+}
+
+func printUpdateRequest(out io.Writer, ur kyvernov1beta1.UpdateRequest) {
+	fmt.Fprintf(out, "Name:         %s\n", ur.Name)
+	fmt.Fprintf(out, "Namespace:    %s\n", ur.Namespace)
+	fmt.Fprintf(out, "Type:         %s\n", ur.Spec.GetRequestType())
+	fmt.Fprintf(out, "Policy:       %s\n", ur.Spec.GetPolicyKey())
+	fmt.Fprintf(out, "Rule:         %s\n", ur.Spec.GetRuleName())
+	fmt.Fprintf(out, "Trigger:      %s\n", ur.Spec.GetResource().String())
+	fmt.Fprintf(out, "State:        %s\n", ur.Status.State)
+	fmt.Fprintf(out, "Retries:      %d\n", ur.Status.RetryCount)
+	if reason := internal.Reason(ur); reason != "" {
+		fmt.Fprintf(out, "Reason:       %s\n", reason)
+	}
+	if ur.Status.Message != "" {
+		fmt.Fprintf(out, "Message:      %s\n", ur.Status.Message)
+	}
+	if ur.Status.LastError != "" {
+		fmt.Fprintf(out, "Last error:   %s\n", ur.Status.LastError)
+	}
+	printResources(out, "Generated resources", ur.Status.GeneratedResources)
+	printResources(out, "Attempted resources", ur.Status.AttemptedResources)
+	if len(ur.Status.Namespaces) != 0 {
+		fmt.Fprintln(out, "Namespaces:")
+		for _, ns := range ur.Status.Namespaces {
+			if ns.Message != "" {
+				fmt.Fprintf(out, "  - %s: %s (%s)\n", ns.Namespace, ns.State, ns.Message)
+			} else {
+				fmt.Fprintf(out, "  - %s: %s\n", ns.Namespace, ns.State)
+			}
+		}
+	}
+	if len(ur.Status.Conditions) != 0 {
+		fmt.Fprintln(out, "Conditions:")
+		for _, condition := range ur.Status.Conditions {
+			fmt.Fprintf(out, "  - %s=%s (%s)", condition.Type, condition.Status, condition.Reason)
+			if condition.Message != "" {
+				fmt.Fprintf(out, ": %s", condition.Message)
+			}
+			fmt.Fprintln(out)
+		}
+	}
+}
+
+func printResources(out io.Writer, title string, resources []kyvernov1.ResourceSpec) {
+	if len(resources) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "%s:\n", title)
+	for _, resource := range resources {
+		fmt.Fprintf(out, "  - %s\n", resource.String())
+	}
+}
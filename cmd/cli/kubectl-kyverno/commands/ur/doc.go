@@ -0,0 +1,18 @@
+package ur
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#ur`
+
+var description = []string{
+	`Inspects the update requests created by generate and mutate existing policies.`,
+}
+
+var examples = [][]string{
+	{
+		`# List update requests`,
+		`kyverno ur list`,
+	},
+	{
+		`# Describe an update request`,
+		`kyverno ur describe <name>`,
+	},
+}
@@ -0,0 +1,32 @@
+package internal
+
+import (
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1beta1client "github.com/kyverno/kyverno/pkg/client/clientset/versioned/typed/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/spf13/pflag"
+)
+
+type ClientOptions struct {
+	KubeConfig string
+	Context    string
+	Namespace  string
+}
+
+func (o *ClientOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.KubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	flags.StringVar(&o.Context, "context", "", "The name of the kubeconfig context to use")
+	flags.StringVarP(&o.Namespace, "namespace", "n", config.KyvernoNamespace(), "Namespace where update requests are stored")
+}
+
+func (o ClientOptions) UpdateRequests() (kyvernov1beta1client.UpdateRequestInterface, error) {
+	restConfig, err := config.CreateClientConfigWithContext(o.KubeConfig, o.Context)
+	if err != nil {
+		return nil, err
+	}
+	client, err := versioned.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return client.KyvernoV1beta1().UpdateRequests(o.Namespace), nil
+}
@@ -0,0 +1,14 @@
+package internal
+
+import (
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// Reason returns the reason of the Processed condition of the update request
+func Reason(ur kyvernov1beta1.UpdateRequest) string {
+	if condition := meta.FindStatusCondition(ur.Status.Conditions, kyvernov1beta1.URConditionProcessed); condition != nil {
+		return condition.Reason
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"testing"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReason(t *testing.T) {
+	tests := []struct {
+		name string
+		ur   kyvernov1beta1.UpdateRequest
+		want string
+	}{{
+		name: "no conditions",
+		want: "",
+	}, {
+		name: "processed",
+		ur: kyvernov1beta1.UpdateRequest{
+			Status: kyvernov1beta1.UpdateRequestStatus{
+				Conditions: []metav1.Condition{{
+					Type:   "Ready",
+					Reason: "Other",
+				}, {
+					Type:   kyvernov1beta1.URConditionProcessed,
+					Status: metav1.ConditionFalse,
+					Reason: kyvernov1beta1.URReasonForbidden,
+				}},
+			},
+		},
+		want: kyvernov1beta1.URReasonForbidden,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Reason(tt.ur); got != tt.want {
+				t.Errorf("Reason() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package list
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			client, err := options.UpdateRequests()
+			if err != nil {
+				return err
+			}
+			return options.execute(cmd.Context(), cmd.OutOrStdout(), client)
+		},
+	}
+	options.AddFlags(cmd.Flags())
+	cmd.Flags().StringVar(&options.policy, "policy", "", "Only list update requests of the given policy (namespace/name for namespaced policies)")
+	cmd.Flags().StringVar(&options.state, "state", "", "Only list update requests in the given state (Pending, Failed, Completed or Skip)")
+	return cmd
+}
@@ -0,0 +1,125 @@
+package list
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCommandWithArgs(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"foo"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown command "foo" for "list"`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidState(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--state", "foo"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: invalid state foo, must be one of Pending, Failed, Completed or Skip`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func TestExecute(t *testing.T) {
+	failed := &kyvernov1beta1.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "ur-failed", Namespace: "kyverno"},
+		Spec: kyvernov1beta1.UpdateRequestSpec{
+			Type:     kyvernov1beta1.Generate,
+			Policy:   "sync-secrets",
+			Rule:     "sync",
+			Resource: kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "Namespace", Name: "tenant"},
+		},
+		Status: kyvernov1beta1.UpdateRequestStatus{
+			State:      kyvernov1beta1.Failed,
+			RetryCount: 2,
+			Conditions: []metav1.Condition{{
+				Type:   kyvernov1beta1.URConditionProcessed,
+				Status: metav1.ConditionFalse,
+				Reason: kyvernov1beta1.URReasonForbidden,
+			}},
+		},
+	}
+	completed := &kyvernov1beta1.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "ur-completed", Namespace: "kyverno"},
+		Spec: kyvernov1beta1.UpdateRequestSpec{
+			Type:   kyvernov1beta1.Mutate,
+			Policy: "add-labels",
+			Rule:   "add",
+		},
+		Status: kyvernov1beta1.UpdateRequestStatus{
+			State: kyvernov1beta1.Completed,
+		},
+	}
+	tests := []struct {
+		name    string
+		options options
+		want    []string
+		notWant []string
+	}{{
+		name: "all",
+		want: []string{"ur-failed", "ur-completed", "Forbidden", "v1/Namespace//tenant"},
+	}, {
+		name:    "policy",
+		options: options{policy: "sync-secrets"},
+		want:    []string{"ur-failed"},
+		notWant: []string{"ur-completed"},
+	}, {
+		name:    "state",
+		options: options{state: "Completed"},
+		want:    []string{"ur-completed"},
+		notWant: []string{"ur-failed"},
+	}, {
+		name:    "none",
+		options: options{policy: "foo"},
+		want:    []string{"No update requests found."},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(failed, completed).KyvernoV1beta1().UpdateRequests("kyverno")
+			b := bytes.NewBufferString("")
+			err := tt.options.execute(context.TODO(), b, client)
+			assert.NoError(t, err)
+			for _, want := range tt.want {
+				assert.Contains(t, b.String(), want)
+			}
+			for _, notWant := range tt.notWant {
+				assert.NotContains(t, b.String(), notWant)
+			}
+		})
+	}
+}
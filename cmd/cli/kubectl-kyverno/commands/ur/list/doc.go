@@ -0,0 +1,18 @@
+package list
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#ur`
+
+var description = []string{
+	`Lists update requests with their state, retry count and failure reason.`,
+}
+
+var examples = [][]string{
+	{
+		`# List update requests`,
+		`kyverno ur list`,
+	},
+	{
+		`# List failed update requests of a policy`,
+		`kyverno ur list --policy <policy> --state Failed`,
+	},
+}
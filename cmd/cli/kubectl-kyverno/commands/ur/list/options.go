@@ -0,0 +1,72 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/ur/internal"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	kyvernov1beta1client "github.com/kyverno/kyverno/pkg/client/clientset/versioned/typed/kyverno/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type options struct {
+	internal.ClientOptions
+	policy string
+	state  string
+}
+
+type row struct {
+	Name    string `header:"name"`
+	Type    string `header:"type"`
+	Policy  string `header:"policy"`
+	Rule    string `header:"rule"`
+	Trigger string `header:"trigger"`
+	State   string `header:"state"`
+	Retries int    `header:"retries"`
+	Reason  string `header:"reason"`
+}
+
+func (o options) validate() error {
+	switch kyvernov1beta1.UpdateRequestState(o.state) {
+	case "", kyvernov1beta1.Pending, kyvernov1beta1.Failed, kyvernov1beta1.Completed, kyvernov1beta1.Skip:
+		return nil
+	default:
+		return fmt.Errorf("invalid state %s, must be one of Pending, Failed, Completed or Skip", o.state)
+	}
+}
+
+func (o options) execute(ctx context.Context, out io.Writer, client kyvernov1beta1client.UpdateRequestInterface) error {
+	list, err := client.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	rows := make([]row, 0, len(list.Items))
+	for _, ur := range list.Items {
+		if o.policy != "" && ur.Spec.GetPolicyKey() != o.policy {
+			continue
+		}
+		if o.state != "" && string(ur.Status.State) != o.state {
+			continue
+		}
+		rows = append(rows, row{
+			Name:    ur.Name,
+			Type:    string(ur.Spec.GetRequestType()),
+			Policy:  ur.Spec.GetPolicyKey(),
+			Rule:    ur.Spec.GetRuleName(),
+			Trigger: ur.Spec.GetResource().String(),
+			State:   string(ur.Status.State),
+			Retries: ur.Status.RetryCount,
+			Reason:  internal.Reason(ur),
+		})
+	}
+	if len(rows) == 0 {
+		fmt.Fprintln(out, "No update requests found.")
+		return nil
+	}
+	printer := table.NewTablePrinter(out)
+	printer.Print(rows)
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/pkg/fips"
 	"github.com/kyverno/kyverno/pkg/version"
 	"github.com/spf13/cobra"
 )
@@ -20,6 +21,7 @@ func Command() *cobra.Command {
 			fmt.Fprintf(cmd.OutOrStdout(), "Version: %s\n", version.Version())
 			fmt.Fprintf(cmd.OutOrStdout(), "Time: %s\n", version.Time())
 			fmt.Fprintf(cmd.OutOrStdout(), "Git commit ID: %s\n", version.Hash())
+			fmt.Fprintf(cmd.OutOrStdout(), "FIPS mode: %t\n", fips.Enabled())
 			return nil
 		},
 	}
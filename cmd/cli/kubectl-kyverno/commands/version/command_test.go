@@ -23,7 +23,8 @@ func TestCommand(t *testing.T) {
 	expected := `
 Version: test
 Time: ---
-Git commit ID: ---`
+Git commit ID: ---
+FIPS mode: false`
 	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
 }
 
@@ -0,0 +1,34 @@
+package webhooks
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	webhookcontroller "github.com/kyverno/kyverno/pkg/controllers/webhook"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "webhooks [policy]...",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.policyPaths = args
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.run(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+	cmd.Flags().StringVar(&options.configPath, "config", "", "Path to the Kyverno ConfigMap providing webhook selectors, annotations, labels and match conditions")
+	cmd.Flags().Int32Var(&options.timeout, "timeout", webhookcontroller.DefaultWebhookTimeout, "Default webhook timeout in seconds, as configured with the Kyverno webhookTimeout flag")
+	cmd.Flags().Int32Var(&options.servicePort, "service-port", 443, "Port of the Kyverno Service, as configured with the Kyverno servicePort flag")
+	cmd.Flags().BoolVar(&options.admissionReports, "admission-reports", true, "Whether admission reports are enabled, this changes the side effects of validating webhooks")
+	cmd.Flags().BoolVarP(&options.cluster, "cluster", "c", false, "Resolve kinds using the discovery of the cluster in the current context instead of the built-in kinds")
+	cmd.Flags().StringVar(&options.kubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&options.context, "context", "", "The name of the kubeconfig context to use")
+	return cmd
+}
@@ -0,0 +1,142 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const policyYAML = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  failurePolicy: Ignore
+  webhookTimeoutSeconds: 15
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Service
+          - Foo
+          operations:
+          - CREATE
+    validate:
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+`
+
+const configMap = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: kyverno
+  namespace: kyverno
+data:
+  webhooks: '[{"namespaceSelector":{"matchExpressions":[{"key":"kubernetes.io/metadata.name","operator":"NotIn","values":["kube-system"]}]}}]'
+`
+
+func TestCommandWithoutArgs(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs(nil)
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithInvalidTimeout(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"policy.yaml", "--timeout", "31"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: invalid timeout 31, must be between 1 and 30 seconds`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func TestExecute(t *testing.T) {
+	policies, _, err := yamlutils.GetPolicy([]byte(policyYAML))
+	assert.NoError(t, err)
+	configPath := filepath.Join(t.TempDir(), "configmap.yaml")
+	assert.NoError(t, os.WriteFile(configPath, []byte(configMap), 0o600))
+	o := options{configPath: configPath, timeout: 10, servicePort: 443}
+	cfg, err := o.configuration()
+	assert.NoError(t, err)
+	out := bytes.NewBufferString("")
+	errOut := bytes.NewBufferString("")
+	assert.NoError(t, o.execute(context.TODO(), out, errOut, newSchemeDiscovery(), cfg, policies...))
+	assert.Equal(t, "WARNING: kind Foo could not be resolved and is not part of the webhooks\n", errOut.String())
+	documents := strings.Split(out.String(), "---\n")
+	assert.Len(t, documents, 2)
+	var mwc admissionregistrationv1.MutatingWebhookConfiguration
+	assert.NoError(t, yaml.Unmarshal([]byte(documents[0]), &mwc))
+	assert.Empty(t, mwc.Webhooks)
+	var vwc admissionregistrationv1.ValidatingWebhookConfiguration
+	assert.NoError(t, yaml.Unmarshal([]byte(documents[1]), &vwc))
+	assert.Len(t, vwc.Webhooks, 1)
+	webhook := vwc.Webhooks[0]
+	assert.Equal(t, admissionregistrationv1.Ignore, *webhook.FailurePolicy)
+	assert.Equal(t, admissionregistrationv1.SideEffectClassNone, *webhook.SideEffects)
+	assert.Equal(t, int32(15), *webhook.TimeoutSeconds)
+	assert.Equal(t, "kube-system", webhook.NamespaceSelector.MatchExpressions[0].Values[0])
+	assert.Equal(t, []admissionregistrationv1.RuleWithOperations{{
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{""},
+			APIVersions: []string{"v1"},
+			Resources:   []string{"services"},
+		},
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+	}}, webhook.Rules)
+}
+
+func TestSchemeDiscovery(t *testing.T) {
+	d := newSchemeDiscovery()
+	resources, err := d.FindResources("apps", "v1", "Deployment", "scale")
+	assert.NoError(t, err)
+	assert.Len(t, resources, 1)
+	for resource := range resources {
+		assert.Equal(t, "deployments/scale", resource.ResourceSubresource())
+	}
+	resources, err = d.FindResources("*", "*", "ClusterPolicy", "")
+	assert.NoError(t, err)
+	for resource := range resources {
+		assert.Equal(t, "kyverno.io", resource.Group)
+		assert.Equal(t, "clusterpolicies", resource.Resource)
+	}
+	_, err = d.FindResources("*", "*", "PodList", "")
+	assert.True(t, dclient.IsResourceNotFound(err))
+	_, err = d.FindResources("*", "*", "DeleteOptions", "")
+	assert.True(t, dclient.IsResourceNotFound(err))
+}
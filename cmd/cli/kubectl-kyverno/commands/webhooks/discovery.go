@@ -0,0 +1,121 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	openapiv2 "github.com/google/gnostic-models/openapiv2"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	kyvernoscheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
+	kubescheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+// schemeDiscovery resolves kinds using the types registered in the Kubernetes and Kyverno schemes,
+// resource names are guessed from the kinds the same way kubectl does without discovery
+type schemeDiscovery struct {
+	resources []dclient.TopLevelApiDescription
+}
+
+func newSchemeDiscovery(schemes ...*runtime.Scheme) *schemeDiscovery {
+	if len(schemes) == 0 {
+		schemes = []*runtime.Scheme{kubescheme.Scheme, kyvernoscheme.Scheme}
+	}
+	var d schemeDiscovery
+	for _, scheme := range schemes {
+		for gvk, t := range scheme.AllKnownTypes() {
+			if gvk.Version == runtime.APIVersionInternal || !isObject(t) {
+				continue
+			}
+			plural, _ := meta.UnsafeGuessKindToResource(gvk)
+			d.resources = append(d.resources, dclient.TopLevelApiDescription{
+				GroupVersion: gvk.GroupVersion(),
+				Kind:         gvk.Kind,
+				Resource:     plural.Resource,
+			})
+		}
+	}
+	return &d
+}
+
+// isObject returns true for top level types, options and list types are registered in the schemes too
+func isObject(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || strings.HasSuffix(t.Name(), "List") {
+		return false
+	}
+	_, ok := t.FieldByName("ObjectMeta")
+	return ok
+}
+
+func (d *schemeDiscovery) FindResources(group, version, kind, subresource string) (map[dclient.TopLevelApiDescription]metav1.APIResource, error) {
+	result := map[dclient.TopLevelApiDescription]metav1.APIResource{}
+	for _, resource := range d.resources {
+		if wildcard.Match(group, resource.Group) && wildcard.Match(version, resource.Version) && wildcard.Match(kind, resource.Kind) {
+			result[resource.WithSubResource(subresource)] = metav1.APIResource{
+				Name:    resource.ResourceSubresource(),
+				Group:   resource.Group,
+				Version: resource.Version,
+				Kind:    resource.Kind,
+			}
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("failed to find resource (%s/%s/%s/%s): %w", group, version, kind, subresource, dclient.ErrResourceNotFound)
+	}
+	return result, nil
+}
+
+func (d *schemeDiscovery) GetGVRFromGVK(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+	for _, resource := range d.resources {
+		if resource.GroupVersionKind() == gvk {
+			return resource.GroupVersionResource(), nil
+		}
+	}
+	return schema.GroupVersionResource{}, dclient.ErrResourceNotFound
+}
+
+func (d *schemeDiscovery) GetGVKFromGVR(gvr schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	for _, resource := range d.resources {
+		if resource.GroupVersionResource() == gvr {
+			return resource.GroupVersionKind(), nil
+		}
+	}
+	return schema.GroupVersionKind{}, dclient.ErrResourceNotFound
+}
+
+func (d *schemeDiscovery) OpenAPISchema() (*openapiv2.Document, error) {
+	return nil, errors.New("openapi schema is not available")
+}
+
+func (d *schemeDiscovery) CachedDiscoveryInterface() discovery.CachedDiscoveryInterface {
+	return nil
+}
+
+// recordingDiscovery records the kinds that could not be resolved, these kinds are left out of the webhooks
+type recordingDiscovery struct {
+	dclient.IDiscovery
+	missing sets.Set[string]
+}
+
+func (d *recordingDiscovery) FindResources(group, version, kind, subresource string) (map[dclient.TopLevelApiDescription]metav1.APIResource, error) {
+	resources, err := d.IDiscovery.FindResources(group, version, kind, subresource)
+	if dclient.IsResourceNotFound(err) {
+		selector := kind
+		if subresource != "" {
+			selector += "/" + subresource
+		}
+		if group != "*" || version != "*" {
+			selector = schema.GroupVersion{Group: group, Version: version}.String() + "/" + selector
+		}
+		d.missing.Insert(selector)
+	}
+	return resources, err
+}
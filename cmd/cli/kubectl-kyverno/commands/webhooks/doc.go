@@ -0,0 +1,26 @@
+package webhooks
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#webhooks`
+
+var description = []string{
+	`Prints the resource webhook configurations generated for policies.`,
+	``,
+	`The output contains the webhooks Kyverno would register with the API server for the given policies (rules, selectors,`,
+	`timeouts, failure policies), this can be used to review API server facing changes before merging a policy.`,
+	`Kinds are resolved using the built-in Kubernetes and Kyverno kinds unless the cluster flag is set.`,
+}
+
+var examples = [][]string{
+	{
+		`# Preview the webhooks generated for a policy`,
+		`kyverno webhooks /path/to/policy.yaml`,
+	},
+	{
+		`# Preview the webhooks using the webhook selectors of the Kyverno configuration`,
+		`kyverno webhooks /path/to/policies/ --config /path/to/kyverno-configmap.yaml`,
+	},
+	{
+		`# Preview the webhooks resolving custom resources with the cluster discovery`,
+		`kyverno webhooks /path/to/policy.yaml --cluster`,
+	},
+}
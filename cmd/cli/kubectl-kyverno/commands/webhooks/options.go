@@ -0,0 +1,116 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	webhookcontroller "github.com/kyverno/kyverno/pkg/controllers/webhook"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+type options struct {
+	policyPaths      []string
+	configPath       string
+	timeout          int32
+	servicePort      int32
+	admissionReports bool
+	cluster          bool
+	kubeConfig       string
+	context          string
+}
+
+func (o options) validate() error {
+	if o.timeout < 1 || o.timeout > 30 {
+		return fmt.Errorf("invalid timeout %d, must be between 1 and 30 seconds", o.timeout)
+	}
+	return nil
+}
+
+func (o options) run(ctx context.Context, out io.Writer, errOut io.Writer) error {
+	var policies []kyvernov1.PolicyInterface
+	for _, path := range o.policyPaths {
+		loaded, _, err := policy.Load(nil, "", path)
+		if err != nil {
+			return fmt.Errorf("failed to load policies (%w)", err)
+		}
+		policies = append(policies, loaded...)
+	}
+	cfg, err := o.configuration()
+	if err != nil {
+		return err
+	}
+	var discoveryClient dclient.IDiscovery = newSchemeDiscovery()
+	if o.cluster {
+		client, err := o.client(ctx)
+		if err != nil {
+			return err
+		}
+		discoveryClient = client.Discovery()
+	}
+	return o.execute(ctx, out, errOut, discoveryClient, cfg, policies...)
+}
+
+func (o options) configuration() (config.Configuration, error) {
+	cfg := config.NewDefaultConfiguration(false)
+	if o.configPath != "" {
+		content, err := os.ReadFile(o.configPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read configuration (%w)", err)
+		}
+		var cm corev1.ConfigMap
+		if err := yaml.UnmarshalStrict(content, &cm); err != nil {
+			return nil, fmt.Errorf("failed to parse configuration (%w)", err)
+		}
+		if err := cfg.Load(&cm); err != nil {
+			return nil, fmt.Errorf("invalid configuration (%w)", err)
+		}
+	}
+	return cfg, nil
+}
+
+func (o options) client(ctx context.Context) (dclient.Interface, error) {
+	restConfig, err := config.CreateClientConfigWithContext(o.kubeConfig, o.context)
+	if err != nil {
+		return nil, err
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return dclient.NewClient(ctx, dynamicClient, kubeClient, 15*time.Minute)
+}
+
+func (o options) execute(ctx context.Context, out io.Writer, errOut io.Writer, discoveryClient dclient.IDiscovery, cfg config.Configuration, policies ...kyvernov1.PolicyInterface) error {
+	recorder := &recordingDiscovery{IDiscovery: discoveryClient, missing: sets.New[string]()}
+	mwc, vwc := webhookcontroller.Preview(ctx, recorder, cfg, o.timeout, o.servicePort, o.admissionReports, policies...)
+	for _, kind := range sets.List(recorder.missing) {
+		fmt.Fprintf(errOut, "WARNING: kind %s could not be resolved and is not part of the webhooks\n", kind)
+	}
+	mutating, err := yaml.Marshal(mwc)
+	if err != nil {
+		return err
+	}
+	validating, err := yaml.Marshal(vwc)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(out, string(mutating))
+	fmt.Fprintln(out, "---")
+	fmt.Fprint(out, string(validating))
+	return nil
+}
@@ -0,0 +1,29 @@
+package whatif
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "what-if [policy]...",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.policyPaths = args
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.run(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringVar(&options.remote, "remote", "", "Address of the Kyverno evaluation server holding the recorded admission requests")
+	cmd.Flags().StringVar(&options.remoteCA, "remote-ca", "", "Path to the CA certificate used to verify the evaluation server certificate")
+	cmd.Flags().StringVar(&options.remoteToken, "remote-token", "", "Bearer token sent to the evaluation server, its owner must be allowed to replay evaluations.kyverno.io")
+	return cmd
+}
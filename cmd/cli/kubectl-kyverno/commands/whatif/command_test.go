@@ -0,0 +1,97 @@
+package whatif
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/evaluation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandNoArgs(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithoutRemote(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"policy.yaml"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: evaluation server address required`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func TestPrint(t *testing.T) {
+	tests := []struct {
+		name     string
+		response evaluation.ReplayResponse
+		want     []string
+	}{{
+		name:     "empty",
+		response: evaluation.ReplayResponse{Total: 3},
+		want:     []string{"3 request(s) replayed, 0 would be denied (0 newly denied), 0 would be mutated"},
+	}, {
+		name: "results",
+		response: evaluation.ReplayResponse{
+			Total:       3,
+			Denied:      2,
+			NewlyDenied: 1,
+			Mutated:     1,
+			Results: []evaluation.ReplayResult{{
+				Kind:      "Pod",
+				Namespace: "default",
+				Name:      "nginx",
+				Operation: kyvernov1.Create,
+				Allowed:   true,
+				Denied:    true,
+				Mutated:   true,
+				Policies:  []string{"add-labels", "require-labels"},
+			}, {
+				Kind:      "Pod",
+				Namespace: "default",
+				Name:      "busybox",
+				Operation: kyvernov1.Update,
+				Denied:    true,
+				Policies:  []string{"require-labels"},
+			}},
+		},
+		want: []string{"nginx", "newly denied, mutated", "add-labels, require-labels", "busybox", "3 request(s) replayed, 2 would be denied (1 newly denied), 1 would be mutated"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := bytes.NewBufferString("")
+			err := options{}.print(b, &tt.response)
+			assert.NoError(t, err)
+			for _, want := range tt.want {
+				assert.Contains(t, b.String(), want)
+			}
+		})
+	}
+}
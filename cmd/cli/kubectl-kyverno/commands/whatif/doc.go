@@ -0,0 +1,17 @@
+package whatif
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#what-if`
+
+var description = []string{
+	`Replays recent admission requests against policies that are not applied yet.`,
+	``,
+	`Requests are recorded by the admission controller when started with --admissionRecorderSize,`,
+	`the command reports how many of them would be denied or mutated by the policies.`,
+}
+
+var examples = [][]string{
+	{
+		`# Replay recorded admission requests against a policy`,
+		`kyverno what-if /path/to/policy.yaml --remote kyverno-svc.kyverno.svc:9444 --remote-ca /path/to/ca.crt --remote-token $(kubectl create token evaluator)`,
+	},
+}
@@ -0,0 +1,88 @@
+package whatif
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/pkg/evaluation"
+)
+
+type options struct {
+	policyPaths []string
+	remote      string
+	remoteCA    string
+	remoteToken string
+}
+
+type row struct {
+	Kind      string `header:"kind"`
+	Namespace string `header:"namespace"`
+	Name      string `header:"name"`
+	Operation string `header:"operation"`
+	Result    string `header:"result"`
+	Policies  string `header:"policies"`
+}
+
+func (o options) validate() error {
+	if o.remote == "" {
+		return fmt.Errorf("evaluation server address required")
+	}
+	return nil
+}
+
+func (o options) run(ctx context.Context, out io.Writer) error {
+	var documents []string
+	for _, path := range o.policyPaths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read policies (%w)", err)
+		}
+		documents = append(documents, string(data))
+	}
+	conn, err := evaluation.Dial(o.remote, o.remoteCA, o.remoteToken)
+	if err != nil {
+		return fmt.Errorf("failed to connect to evaluation server %s (%w)", o.remote, err)
+	}
+	defer conn.Close()
+	response, err := evaluation.NewEvaluationClient(conn).Replay(ctx, &evaluation.ReplayRequest{Policies: documents})
+	if err != nil {
+		return fmt.Errorf("failed to replay admission requests (%w)", err)
+	}
+	return o.print(out, response)
+}
+
+func (o options) print(out io.Writer, response *evaluation.ReplayResponse) error {
+	if len(response.Results) != 0 {
+		var rows []row
+		for _, result := range response.Results {
+			var outcomes []string
+			if result.Denied {
+				if result.Allowed {
+					outcomes = append(outcomes, "newly denied")
+				} else {
+					outcomes = append(outcomes, "denied")
+				}
+			}
+			if result.Mutated {
+				outcomes = append(outcomes, "mutated")
+			}
+			rows = append(rows, row{
+				Kind:      result.Kind,
+				Namespace: result.Namespace,
+				Name:      result.Name,
+				Operation: string(result.Operation),
+				Result:    strings.Join(outcomes, ", "),
+				Policies:  strings.Join(result.Policies, ", "),
+			})
+		}
+		printer := table.NewTablePrinter(out)
+		printer.Print(rows)
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintf(out, "%d request(s) replayed, %d would be denied (%d newly denied), %d would be mutated\n", response.Total, response.Denied, response.NewlyDenied, response.Mutated)
+	return nil
+}
@@ -5,11 +5,21 @@ import (
 	"io/fs"
 )
 
-const CrdsFolder = "crds"
+const (
+	CrdsFolder     = "crds"
+	PoliciesFolder = "policies"
+)
 
 //go:embed crds
 var crdsFs embed.FS
 
+//go:embed policies
+var policiesFs embed.FS
+
 func Crds() fs.FS {
 	return crdsFs
 }
+
+func Policies() fs.FS {
+	return policiesFs
+}
@@ -3,6 +3,7 @@ package exception
 import (
 	"fmt"
 
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/data"
@@ -19,6 +20,7 @@ var (
 	exceptionV2      = schema.GroupVersion(kyvernov2.GroupVersion).WithKind("PolicyException")
 )
 
+// Load loads the policy exceptions, the policies, values and tests declared in the same content are ignored
 func Load(content []byte) ([]*kyvernov2beta1.PolicyException, error) {
 	documents, err := yamlutils.SplitDocuments(content)
 	if err != nil {
@@ -26,6 +28,13 @@ func Load(content []byte) ([]*kyvernov2beta1.PolicyException, error) {
 	}
 	var exceptions []*kyvernov2beta1.PolicyException
 	for _, document := range documents {
+		typeMeta, err := yamlutils.GetTypeMeta(document)
+		if err != nil {
+			return nil, err
+		}
+		if gvk := typeMeta.GroupVersionKind(); gvk.Group == "cli.kyverno.io" || gvk.Group == kyvernov1.GroupVersion.Group && (gvk.Kind == "ClusterPolicy" || gvk.Kind == "Policy") {
+			continue
+		}
 		gvk, untyped, err := factory.Load(document)
 		if err != nil {
 			return nil, err
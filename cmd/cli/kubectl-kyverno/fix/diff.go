@@ -0,0 +1,16 @@
+package fix
+
+import (
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Diff returns a unified diff between the original and the fixed content of a file
+func Diff(path string, original, fixed []byte) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(original)),
+		B:        difflib.SplitLines(string(fixed)),
+		FromFile: path,
+		ToFile:   path + " (fixed)",
+		Context:  3,
+	})
+}
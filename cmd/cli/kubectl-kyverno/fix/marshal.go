@@ -0,0 +1,99 @@
+package fix
+
+import (
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// MarshalPolicies marshals policies and validating admission policies into a multi document yaml,
+// status and empty rule fields are pruned from the policies.
+func MarshalPolicies(fixed []kyvernov1.PolicyInterface, vaps []admissionregistrationv1alpha1.ValidatingAdmissionPolicy) ([]byte, error) {
+	var yamlBytes []byte
+	for _, policy := range fixed {
+		untyped, err := kubeutils.ObjToUnstructured(policy)
+		if err != nil {
+			return nil, fmt.Errorf("converting to unstructured: %w", err)
+		}
+		// prune some fields
+		unstructured.RemoveNestedField(untyped.UnstructuredContent(), "status")
+		unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata", "creationTimestamp")
+		unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata", "generation")
+		unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata", "uid")
+		rules, _, _ := unstructured.NestedFieldNoCopy(untyped.UnstructuredContent(), "spec", "rules")
+		rulesList, _ := rules.([]interface{})
+		for _, rule := range rulesList {
+			rule := rule.(map[string]interface{})
+			unstructured.RemoveNestedField(rule, "exclude", "resources")
+			unstructured.RemoveNestedField(rule, "match", "resources")
+			if any, ok, err := unstructured.NestedFieldNoCopy(rule, "match", "any"); ok && err == nil {
+				cleanResourceFilters(any.([]interface{}))
+			}
+			if all, ok, err := unstructured.NestedFieldNoCopy(rule, "match", "all"); ok && err == nil {
+				cleanResourceFilters(all.([]interface{}))
+			}
+			if any, ok, err := unstructured.NestedFieldNoCopy(rule, "exclude", "any"); ok && err == nil {
+				cleanResourceFilters(any.([]interface{}))
+			}
+			if all, ok, err := unstructured.NestedFieldNoCopy(rule, "exclude", "all"); ok && err == nil {
+				cleanResourceFilters(all.([]interface{}))
+			}
+			if item, _, _ := unstructured.NestedMap(rule, "generate", "clone"); len(item) == 0 {
+				unstructured.RemoveNestedField(rule, "generate", "clone")
+			}
+			if item, _, _ := unstructured.NestedMap(rule, "generate", "cloneList"); len(item) == 0 {
+				unstructured.RemoveNestedField(rule, "generate", "cloneList")
+			}
+			if item, _, _ := unstructured.NestedMap(rule, "generate"); len(item) == 0 {
+				unstructured.RemoveNestedField(rule, "generate")
+			}
+			if item, _, _ := unstructured.NestedMap(rule, "mutate"); len(item) == 0 {
+				unstructured.RemoveNestedField(rule, "mutate")
+			}
+			if item, _, _ := unstructured.NestedMap(rule, "validate", "manifests", "dryRun"); len(item) == 0 {
+				unstructured.RemoveNestedField(rule, "validate", "manifests", "dryRun")
+			}
+			if item, _, _ := unstructured.NestedMap(rule, "validate"); len(item) == 0 {
+				unstructured.RemoveNestedField(rule, "validate")
+			}
+			if item, _, _ := unstructured.NestedMap(rule, "exclude"); len(item) == 0 {
+				unstructured.RemoveNestedField(rule, "exclude")
+			}
+			if item, _, _ := unstructured.NestedMap(rule, "match"); len(item) == 0 {
+				unstructured.RemoveNestedField(rule, "match")
+			}
+		}
+		jsonBytes, err := untyped.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("converting to json: %w", err)
+		}
+		finalBytes, err := yaml.JSONToYAML(jsonBytes)
+		if err != nil {
+			return nil, fmt.Errorf("converting to yaml: %w", err)
+		}
+		yamlBytes = append(yamlBytes, []byte("---\n")...)
+		yamlBytes = append(yamlBytes, finalBytes...)
+	}
+	for _, vap := range vaps {
+		finalBytes, err := yaml.Marshal(vap)
+		if err != nil {
+			return nil, fmt.Errorf("converting to yaml: %w", err)
+		}
+		yamlBytes = append(yamlBytes, []byte("---\n")...)
+		yamlBytes = append(yamlBytes, finalBytes...)
+	}
+	return yamlBytes, nil
+}
+
+func cleanResourceFilters(rf []interface{}) {
+	for _, f := range rf {
+		a := f.(map[string]interface{})
+		if item, _, _ := unstructured.NestedMap(a, "resources"); len(item) == 0 {
+			unstructured.RemoveNestedField(a, "resources")
+		}
+	}
+}
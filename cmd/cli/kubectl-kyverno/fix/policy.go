@@ -3,6 +3,7 @@ package fix
 import (
 	"fmt"
 	"reflect"
+	"slices"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
@@ -36,6 +37,10 @@ func FixPolicy(policy kyvernov1.PolicyInterface) ([]string, error) {
 			rule.ExcludeResources.ResourceDescription = kyvernov1.ResourceDescription{}
 			rule.ExcludeResources.UserInfo = kyvernov1.UserInfo{}
 		}
+		messages = append(messages, fixResourceFilters("match", rule.MatchResources.Any)...)
+		messages = append(messages, fixResourceFilters("match", rule.MatchResources.All)...)
+		messages = append(messages, fixResourceFilters("exclude", rule.ExcludeResources.Any)...)
+		messages = append(messages, fixResourceFilters("exclude", rule.ExcludeResources.All)...)
 		preconditions := rule.GetAnyAllConditions()
 		if preconditions != nil {
 			cond, err := apiutils.ApiextensionsJsonToKyvernoConditions(preconditions)
@@ -80,3 +85,18 @@ func FixPolicy(policy kyvernov1.PolicyInterface) ([]string, error) {
 	}
 	return messages, nil
 }
+
+func fixResourceFilters(name string, filters kyvernov1.ResourceFilters) []string {
+	var messages []string
+	for i := range filters {
+		resources := &filters[i].ResourceDescription
+		if resources.Name != "" {
+			messages = append(messages, name+" uses deprecated `name` field, moving it into the `names` field")
+			if !slices.Contains(resources.Names, resources.Name) {
+				resources.Names = append(resources.Names, resources.Name)
+			}
+			resources.Name = ""
+		}
+	}
+	return messages
+}
@@ -0,0 +1,69 @@
+package fix
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
+	"github.com/stretchr/testify/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestFixPolicy(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{
+				Name: "rule",
+				MatchResources: kyvernov1.MatchResources{
+					ResourceDescription: kyvernov1.ResourceDescription{
+						Kinds: []string{"Pod"},
+						Name:  "nginx",
+					},
+				},
+				ExcludeResources: kyvernov1.MatchResources{
+					All: kyvernov1.ResourceFilters{{
+						ResourceDescription: kyvernov1.ResourceDescription{
+							Name:  "busybox",
+							Names: []string{"busybox", "alpine"},
+						},
+					}},
+				},
+				RawAnyAllConditions: &apiextv1.JSON{
+					Raw: []byte(`[{"key":"{{ request.operation }}","operator":"Equal","value":"CREATE"}]`),
+				},
+			}},
+		},
+	}
+	messages, err := FixPolicy(policy)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"match uses old syntax, moving to any",
+		"match uses deprecated `name` field, moving it into the `names` field",
+		"exclude uses deprecated `name` field, moving it into the `names` field",
+		"condition uses old operator `Equal`, updating",
+	}, messages)
+	rule := policy.Spec.Rules[0]
+	assert.Equal(t, kyvernov1.ResourceFilters{{
+		ResourceDescription: kyvernov1.ResourceDescription{
+			Kinds: []string{"Pod"},
+			Names: []string{"nginx"},
+		},
+	}}, rule.MatchResources.Any)
+	assert.Equal(t, []string{"busybox", "alpine"}, rule.ExcludeResources.All[0].Names)
+	assert.Empty(t, rule.ExcludeResources.All[0].Name)
+	cond, err := apiutils.ApiextensionsJsonToKyvernoConditions(rule.GetAnyAllConditions())
+	assert.NoError(t, err)
+	conditions, ok := cond.(kyvernov1.AnyAllConditions)
+	assert.True(t, ok)
+	assert.Len(t, conditions.AllConditions, 1)
+	assert.Equal(t, kyvernov1.ConditionOperator("Equals"), conditions.AllConditions[0].Operator)
+}
+
+func TestDiff(t *testing.T) {
+	diff, err := Diff("policy.yaml", []byte("a: 1\nb: 2\n"), []byte("a: 1\nb: 3\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "--- policy.yaml\n+++ policy.yaml (fixed)\n@@ -1,3 +1,3 @@\n a: 1\n-b: 2\n+b: 3\n \n", diff)
+	diff, err = Diff("policy.yaml", []byte("a: 1\n"), []byte("a: 1\n"))
+	assert.NoError(t, err)
+	assert.Empty(t, diff)
+}
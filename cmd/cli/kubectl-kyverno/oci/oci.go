@@ -0,0 +1,46 @@
+package oci
+
+import (
+	"context"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/authn/github"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/kyverno/kyverno/pkg/oci"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+)
+
+const (
+	PolicyConfigMediaType  = oci.PolicyConfigMediaType
+	PolicyLayerMediaType   = oci.PolicyLayerMediaType
+	ResourceLayerMediaType = oci.ResourceLayerMediaType
+)
+
+// Keychain is the keychain used to authenticate against OCI registries
+var Keychain = authn.NewMultiKeychain(
+	authn.DefaultKeychain,
+	github.Keychain,
+	registryclient.AWSKeychain,
+	registryclient.GCPKeychain,
+	registryclient.AzureKeychain,
+)
+
+// PullPolicyLayers downloads an OCI image and returns the content of its policy layers
+func PullPolicyLayers(ctx context.Context, imageRef string, keychain authn.Keychain) ([][]byte, error) {
+	return oci.PullLayers(ctx, imageRef, PolicyLayerMediaType, remote.WithAuthFromKeychain(keychain))
+}
+
+// PullResourceLayers downloads an OCI image and returns the content of its resource layers
+func PullResourceLayers(ctx context.Context, imageRef string, keychain authn.Keychain) ([][]byte, error) {
+	return oci.PullLayers(ctx, imageRef, ResourceLayerMediaType, remote.WithAuthFromKeychain(keychain))
+}
+
+// Verify checks the cosign signature of an OCI image against the given key (file path, KMS or k8s:// reference)
+// and returns the image reference pinned to the verified digest
+func Verify(ctx context.Context, imageRef string, key string) (string, error) {
+	rclient, err := registryclient.New(registryclient.WithLocalKeychain())
+	if err != nil {
+		return "", err
+	}
+	return oci.Verify(ctx, rclient, imageRef, key)
+}
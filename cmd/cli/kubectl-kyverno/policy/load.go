@@ -1,7 +1,6 @@
 package policy
 
 import (
-	"bufio"
 	"context"
 	"fmt"
 	"io"
@@ -14,6 +13,7 @@ import (
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/data"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/experimental"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/oci"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
 	"github.com/kyverno/kyverno/ext/resource/convert"
 	resourceloader "github.com/kyverno/kyverno/ext/resource/loader"
@@ -73,6 +73,13 @@ func LoadWithLoader(loader loader, fs billy.Filesystem, resourcePath string, pat
 			}
 			pols = append(pols, p...)
 			vaps = append(vaps, v...)
+		} else if source.IsOCI(path) {
+			p, v, err := ociLoad(loader, path)
+			if err != nil {
+				return nil, nil, err
+			}
+			pols = append(pols, p...)
+			vaps = append(vaps, v...)
 		} else if source.IsHttp(path) {
 			p, v, err := httpLoad(loader, path)
 			if err != nil {
@@ -100,6 +107,14 @@ func kubectlValidateLoader(content []byte) ([]kyvernov1.PolicyInterface, []v1alp
 	var policies []kyvernov1.PolicyInterface
 	var vaps []v1alpha1.ValidatingAdmissionPolicy
 	for _, document := range documents {
+		// exceptions, values and tests can be declared alongside the policies
+		typeMeta, err := extyaml.GetTypeMeta(document)
+		if err != nil {
+			return nil, nil, err
+		}
+		if gvk := typeMeta.GroupVersionKind(); gvk.Group == "cli.kyverno.io" || gvk.Group == kyvernov1.GroupVersion.Group && gvk.Kind == "PolicyException" {
+			continue
+		}
 		gvk, untyped, err := factory.Load(document)
 		if err != nil {
 			return nil, nil, err
@@ -186,6 +201,24 @@ func httpLoad(loader loader, path string) ([]kyvernov1.PolicyInterface, []v1alph
 	return loader(fileBytes)
 }
 
+func ociLoad(loader loader, path string) ([]kyvernov1.PolicyInterface, []v1alpha1.ValidatingAdmissionPolicy, error) {
+	layers, err := oci.PullPolicyLayers(context.TODO(), path, oci.Keychain)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to process %v: %v", path, err)
+	}
+	var pols []kyvernov1.PolicyInterface
+	var vaps []v1alpha1.ValidatingAdmissionPolicy
+	for _, layer := range layers {
+		p, v, err := loader(layer)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to process %v: %v", path, err)
+		}
+		pols = append(pols, p...)
+		vaps = append(vaps, v...)
+	}
+	return pols, vaps, nil
+}
+
 func gitLoad(loader loader, fs billy.Filesystem, path string) ([]kyvernov1.PolicyInterface, []v1alpha1.ValidatingAdmissionPolicy, error) {
 	file, err := fs.Open(path)
 	if err != nil {
@@ -199,10 +232,9 @@ func gitLoad(loader loader, fs billy.Filesystem, path string) ([]kyvernov1.Polic
 }
 
 func stdinLoad(loader loader) ([]kyvernov1.PolicyInterface, []v1alpha1.ValidatingAdmissionPolicy, error) {
-	policyStr := ""
-	scanner := bufio.NewScanner(os.Stdin)
-	for scanner.Scan() {
-		policyStr = policyStr + scanner.Text() + "\n"
+	policyBytes, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, nil, err
 	}
-	return loader([]byte(policyStr))
+	return loader(policyBytes)
 }
@@ -28,6 +28,12 @@ func TestLoad(t *testing.T) {
 		resourcePath: "",
 		paths:        []string{"../_testdata/policies/invalid-schema.yaml"},
 		wantErr:      true,
+	}, {
+		name:         "policy exception and policy",
+		fs:           nil,
+		resourcePath: "",
+		paths:        []string{"../_testdata/exceptions/exception-and-policy.yaml"},
+		wantErr:      false,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -84,6 +90,18 @@ func TestLoadWithKubectlValidate(t *testing.T) {
 			assert.True(t, rule.VerifyImages[0].VerifyDigest)
 			assert.True(t, rule.VerifyImages[0].UseCache)
 		},
+	}, {
+		name:         "policy, exception and values",
+		fs:           nil,
+		resourcePath: "",
+		paths:        []string{"../_testdata/exceptions/exception-and-policy.yaml", "../_testdata/values/policy-and-values.yaml"},
+		wantErr:      false,
+		checks: func(t *testing.T, policies []kyvernov1.PolicyInterface, vaps []v1alpha1.ValidatingAdmissionPolicy) {
+			assert.Len(t, policies, 2)
+			assert.Equal(t, "require-ns-purpose-label", policies[0].GetName())
+			assert.True(t, policies[0].IsNamespaced())
+			assert.False(t, policies[1].IsNamespaced())
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,51 @@
+package processor
+
+import (
+	"fmt"
+	"strings"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// diffResources returns a unified diff between the YAML representations of two resources
+func diffResources(from, to unstructured.Unstructured, fromFile, toFile string) (string, error) {
+	fromYaml, err := yaml.Marshal(from.Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal (%w)", err)
+	}
+	toYaml, err := yaml.Marshal(to.Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal (%w)", err)
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(fromYaml)),
+		B:        difflib.SplitLines(string(toYaml)),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+}
+
+// printMutateDiff prints the diff introduced by a mutate policy, computed from the resource before the policy
+// was applied and the patched resource of the mutate response
+func (p *PolicyProcessor) printMutateDiff(resource unstructured.Unstructured, response engineapi.EngineResponse, resourcePath string) error {
+	var rules []string
+	for _, rule := range response.PolicyResponse.Rules {
+		if rule.Status() == engineapi.RuleStatusPass {
+			rules = append(rules, rule.Name())
+		}
+	}
+	policy := response.Policy().GetName()
+	to := fmt.Sprintf("%s (%s)", resourcePath, policy)
+	diff, err := diffResources(resource, response.PatchedResource, resourcePath, to)
+	if err != nil {
+		return err
+	}
+	if diff != "" {
+		fmt.Fprintf(p.Out, "\nmutate policy %s (rules %s) changed %s:\n%s", policy, strings.Join(rules, ", "), resourcePath, diff)
+	}
+	return nil
+}
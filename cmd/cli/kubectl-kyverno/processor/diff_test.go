@@ -0,0 +1,53 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_diffResources(t *testing.T) {
+	from := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name": "test",
+		},
+	}}
+	to := *from.DeepCopy()
+	to.SetLabels(map[string]string{"foo": "bar"})
+	tests := []struct {
+		name string
+		from unstructured.Unstructured
+		to   unstructured.Unstructured
+		want string
+	}{{
+		name: "no change",
+		from: from,
+		to:   from,
+		want: "",
+	}, {
+		name: "label added",
+		from: from,
+		to:   to,
+		want: `--- before
++++ after
+@@ -1,5 +1,7 @@
+ apiVersion: v1
+ kind: Pod
+ metadata:
++  labels:
++    foo: bar
+   name: test
+ 
+`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := diffResources(tt.from, tt.to, "before", "after")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
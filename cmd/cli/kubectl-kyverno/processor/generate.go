@@ -96,7 +96,7 @@ func handleGeneratePolicy(out io.Writer, store *store.Store, generateResponse *e
 	var newRuleResponse []engineapi.RuleResponse
 
 	for _, rule := range generateResponse.PolicyResponse.Rules {
-		genResource, err := c.ApplyGeneratePolicy(log.Log.V(2), &policyContext, gr, []string{rule.Name()})
+		genResource, _, _, err := c.ApplyGeneratePolicy(log.Log.V(2), &policyContext, gr, []string{rule.Name()})
 		if err != nil {
 			return nil, err
 		}
@@ -133,9 +133,12 @@ func initializeMockController(out io.Writer, s *store.Store, gvrToListKind map[s
 		adapters.Client(client),
 		nil,
 		imageverifycache.DisabledImageVerifyCache(),
-		store.ContextLoaderFactory(s, nil),
+		store.ContextLoaderFactory(s, s.GetConfigMapResolver()),
 		nil,
 		"",
+		nil,
+		nil,
+		nil,
 	))
 	return c, nil
 }
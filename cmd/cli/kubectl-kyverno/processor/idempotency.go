@@ -0,0 +1,61 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// checkMutateIdempotency applies the mutate rules of a policy a second time on the already mutated resource
+// and prints a warning when the second pass produces additional changes, it returns true if the policy is not idempotent.
+// Such policies can cause patch loops when the mutating webhook is reinvoked.
+func (p *PolicyProcessor) checkMutateIdempotency(
+	eng engineapi.Engine,
+	jp jmespath.Interface,
+	cfg config.Configuration,
+	response engineapi.EngineResponse,
+	policy kyvernov1.PolicyInterface,
+	namespaceLabels map[string]string,
+	gvk schema.GroupVersionKind,
+	subresource string,
+	resourcePath string,
+) (bool, error) {
+	if response.PolicyResponse.RulesAppliedCount() == 0 {
+		return false, nil
+	}
+	policyContext, err := p.makePolicyContext(jp, cfg, response.PatchedResource, policy, namespaceLabels, gvk, subresource)
+	if err != nil {
+		return false, err
+	}
+	second := eng.Mutate(context.Background(), policyContext)
+	if second.PolicyResponse.RulesAppliedCount() == 0 {
+		return false, nil
+	}
+	diff, err := diffResources(response.PatchedResource, second.PatchedResource, resourcePath+" (first pass)", resourcePath+" (second pass)")
+	if err != nil {
+		return false, err
+	}
+	if diff == "" {
+		return false, nil
+	}
+	fmt.Fprintf(p.Out, "\nWARNING: mutate policy %s is not idempotent, a second pass changed %s:\n%s", policy.GetName(), resourcePath, diff)
+	return true, nil
+}
+
+// failNonIdempotent returns the response with the applied rules of a non idempotent policy reported as failed
+func failNonIdempotent(response engineapi.EngineResponse) engineapi.EngineResponse {
+	rules := make([]engineapi.RuleResponse, 0, len(response.PolicyResponse.Rules))
+	for _, rule := range response.PolicyResponse.Rules {
+		if rule.Status() == engineapi.RuleStatusPass {
+			rule = engineapi.RuleFail(rule.Name(), rule.RuleType(), "mutation is not idempotent, a second pass changed the resource").WithStats(rule.Stats())
+		}
+		rules = append(rules, rule)
+	}
+	response.PolicyResponse.Rules = rules
+	return response
+}
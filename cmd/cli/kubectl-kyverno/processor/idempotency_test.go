@@ -0,0 +1,110 @@
+package processor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_checkMutateIdempotency(t *testing.T) {
+	pod := []byte(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: test
+  namespace: default
+spec:
+  containers:
+  - name: nginx
+    image: nginx
+`)
+	tests := []struct {
+		name          string
+		policy        []byte
+		nonIdempotent int
+	}{{
+		name: "idempotent",
+		policy: []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: add-label
+spec:
+  rules:
+  - name: add-label
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    mutate:
+      patchStrategicMerge:
+        metadata:
+          labels:
+            foo: bar
+`),
+		nonIdempotent: 0,
+	}, {
+		name: "not idempotent",
+		policy: []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: append-container
+spec:
+  rules:
+  - name: append-container
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    mutate:
+      patchesJson6902: |-
+        - op: add
+          path: /spec/containers/-
+          value:
+            name: sidecar
+            image: busybox
+`),
+		nonIdempotent: 1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policies, _, err := yamlutils.GetPolicy(tt.policy)
+			assert.NoError(t, err)
+			resources, err := resource.GetUnstructuredResources(pod)
+			assert.NoError(t, err)
+			for _, fail := range []bool{false, true} {
+				var out bytes.Buffer
+				rc := &ResultCounts{}
+				processor := PolicyProcessor{
+					Store:             &store.Store{},
+					Policies:          policies,
+					Resource:          *resources[0],
+					Rc:                rc,
+					Out:               &out,
+					CheckIdempotency:  true,
+					FailNonIdempotent: fail,
+				}
+				responses, err := processor.ApplyPoliciesOnResource()
+				assert.NoError(t, err)
+				assert.Equal(t, tt.nonIdempotent, rc.NonIdempotent())
+				assert.Equal(t, tt.nonIdempotent != 0, bytes.Contains(out.Bytes(), []byte("is not idempotent")))
+				// non idempotent rules are only reported as failed when asked to
+				assert.Len(t, responses, 1)
+				assert.Len(t, responses[0].PolicyResponse.Rules, 1)
+				status := engineapi.RuleStatusPass
+				if fail && tt.nonIdempotent != 0 {
+					status = engineapi.RuleStatusFail
+				}
+				assert.Equal(t, status, responses[0].PolicyResponse.Rules[0].Status())
+			}
+		})
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/policycontext"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/registryclient"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
 	"gomodules.xyz/jsonpatch/v2"
 	yamlv2 "gopkg.in/yaml.v2"
@@ -52,6 +53,14 @@ type PolicyProcessor struct {
 	AuditWarn                 bool
 	Subresources              []v1alpha1.Subresource
 	Out                       io.Writer
+	Diff                      bool
+	CheckIdempotency          bool
+	// FailNonIdempotent reports the applied rules of mutate policies that are not idempotent as failed
+	FailNonIdempotent bool
+	// ShowResolved prints the rules after variable substitution
+	ShowResolved bool
+	// Trace prints the decision trace of every evaluated rule
+	Trace bool
 }
 
 func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse, error) {
@@ -75,9 +84,12 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 		client,
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
 		imageverifycache.DisabledImageVerifyCache(),
-		store.ContextLoaderFactory(p.Store, nil),
+		store.ContextLoaderFactory(p.Store, p.Store.GetConfigMapResolver()),
 		nil,
 		"",
+		nil,
+		nil,
+		nil,
 	)
 	gvk, subresource := resource.GroupVersionKind(), ""
 	// If --cluster flag is not set, then we need to find the top level resource GVK and subresource
@@ -100,7 +112,19 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 		}
 	}
 	resPath := fmt.Sprintf("%s/%s/%s", resource.GetNamespace(), resource.GetKind(), resource.GetName())
+	ctx := context.TODO()
+	if p.ShowResolved {
+		ctx = engineapi.WithResolvedRuleRecorder(ctx, func(policy kyvernov1.PolicyInterface, rule string, resolved map[string]interface{}) {
+			printResolvedRule(p.Out, policyKey(policy.GetNamespace(), policy.GetName()), rule, resPath, resolved)
+		})
+	}
+	if p.Trace {
+		ctx = engineapi.WithRuleTracer(ctx, func(trace engineapi.RuleTrace) {
+			printRuleTrace(p.Out, trace)
+		})
+	}
 	var responses []engineapi.EngineResponse
+	changed, nonIdempotent := false, false
 	// mutate
 	for _, policy := range p.Policies {
 		if !policy.GetSpec().HasMutate() {
@@ -110,14 +134,38 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 		if err != nil {
 			return responses, err
 		}
-		mutateResponse := eng.Mutate(context.Background(), policyContext)
+		mutateResponse := eng.Mutate(ctx, policyContext)
 		err = p.processMutateEngineResponse(mutateResponse, resPath)
 		if err != nil {
 			return responses, fmt.Errorf("failed to print mutated result (%w)", err)
 		}
+		if !datautils.DeepEqual(resource.Object, mutateResponse.PatchedResource.Object) {
+			changed = true
+			if p.Diff {
+				if err := p.printMutateDiff(resource, mutateResponse, resPath); err != nil {
+					return responses, fmt.Errorf("failed to print mutate diff (%w)", err)
+				}
+			}
+		}
+		if p.CheckIdempotency {
+			policyNonIdempotent, err := p.checkMutateIdempotency(eng, jp, cfg, mutateResponse, policy, namespaceLabels, gvk, subresource, resPath)
+			if err != nil {
+				return responses, fmt.Errorf("failed to check mutate idempotency (%w)", err)
+			}
+			nonIdempotent = nonIdempotent || policyNonIdempotent
+			if policyNonIdempotent && p.FailNonIdempotent {
+				mutateResponse = failNonIdempotent(mutateResponse)
+			}
+		}
 		responses = append(responses, mutateResponse)
 		resource = mutateResponse.PatchedResource
 	}
+	if changed {
+		p.Rc.changed++
+	}
+	if nonIdempotent {
+		p.Rc.nonIdempotent++
+	}
 	// verify images
 	for _, policy := range p.Policies {
 		if !policy.GetSpec().HasVerifyImages() {
@@ -127,7 +175,7 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 		if err != nil {
 			return responses, err
 		}
-		verifyImageResponse, verifiedImageData := eng.VerifyAndPatchImages(context.TODO(), policyContext)
+		verifyImageResponse, verifiedImageData := eng.VerifyAndPatchImages(ctx, policyContext)
 		// update annotation to reflect verified images
 		var patches []jsonpatch.JsonPatchOperation
 		if !verifiedImageData.IsEmpty() {
@@ -169,7 +217,7 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 		if err != nil {
 			return responses, err
 		}
-		validateResponse := eng.Validate(context.TODO(), policyContext)
+		validateResponse := eng.Validate(ctx, policyContext)
 		responses = append(responses, validateResponse)
 		resource = validateResponse.PatchedResource
 	}
@@ -180,7 +228,7 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 			if err != nil {
 				return responses, err
 			}
-			generateResponse := eng.ApplyBackgroundChecks(context.TODO(), policyContext)
+			generateResponse := eng.ApplyBackgroundChecks(ctx, policyContext)
 			if !generateResponse.IsEmpty() {
 				newRuleResponse, err := handleGeneratePolicy(p.Out, p.Store, &generateResponse, *policyContext, p.RuleToCloneSourceResource)
 				if err != nil {
@@ -336,6 +384,9 @@ func (p *PolicyProcessor) processMutateEngineResponse(response engineapi.EngineR
 		}
 
 		if p.MutateLogPath == "" {
+			if p.Diff {
+				return nil
+			}
 			mutatedResource := string(yamlEncodedResource) + string("\n---")
 			if len(strings.TrimSpace(mutatedResource)) > 0 {
 				if !p.Stdin {
@@ -0,0 +1,82 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/evaluation"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// RemoteProcessor delegates the evaluation of policies to a remote evaluation server
+type RemoteProcessor struct {
+	Client               evaluation.EvaluationClient
+	Policies             []kyvernov1.PolicyInterface
+	Resource             unstructured.Unstructured
+	UserInfo             *kyvernov1beta1.RequestInfo
+	NamespaceSelectorMap map[string]map[string]string
+	Rc                   *ResultCounts
+	AuditWarn            bool
+	// ShowResolved prints the rules after variable substitution returned by the evaluation server
+	ShowResolved bool
+	Out          io.Writer
+}
+
+func (p *RemoteProcessor) ApplyPoliciesOnResource(ctx context.Context) ([]engineapi.EngineResponse, error) {
+	request := evaluation.EvaluateRequest{
+		Resource:        p.Resource.Object,
+		NamespaceLabels: p.NamespaceSelectorMap[p.Resource.GetNamespace()],
+		UserInfo:        p.UserInfo,
+		ShowResolved:    p.ShowResolved,
+	}
+	policies := map[string]kyvernov1.PolicyInterface{}
+	for _, policy := range p.Policies {
+		document, err := json.Marshal(policy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal policy %s (%w)", policy.GetName(), err)
+		}
+		request.Policies = append(request.Policies, string(document))
+		policies[policyKey(policy.GetNamespace(), policy.GetName())] = policy
+	}
+	response, err := p.Client.Evaluate(ctx, &request)
+	if err != nil {
+		return nil, err
+	}
+	patchedResource := p.Resource
+	if response.PatchedResource != nil {
+		patchedResource = unstructured.Unstructured{Object: response.PatchedResource}
+	}
+	var responses []engineapi.EngineResponse
+	for _, result := range response.Results {
+		policy, ok := policies[policyKey(result.Namespace, result.Name)]
+		if !ok {
+			return responses, fmt.Errorf("unexpected result for policy %s", result.Name)
+		}
+		var policyResponse engineapi.PolicyResponse
+		for _, rule := range result.Rules {
+			if p.ShowResolved {
+				resourcePath := fmt.Sprintf("%s/%s/%s", p.Resource.GetNamespace(), p.Resource.GetKind(), p.Resource.GetName())
+				printResolvedRule(p.Out, policyKey(result.Namespace, result.Name), rule.Name, resourcePath, rule.Resolved)
+			}
+			policyResponse.Rules = append(policyResponse.Rules, *engineapi.NewRuleResponse(rule.Name, rule.Type, rule.Message, rule.Status))
+		}
+		engineResponse := engineapi.NewEngineResponse(p.Resource, engineapi.NewKyvernoPolicy(policy), request.NamespaceLabels).
+			WithPolicyResponse(policyResponse).
+			WithPatchedResource(patchedResource)
+		p.Rc.addEngineResponse(p.AuditWarn, engineResponse)
+		responses = append(responses, engineResponse)
+	}
+	return responses, nil
+}
+
+func policyKey(namespace, name string) string {
+	if namespace != "" {
+		return namespace + "/" + name
+	}
+	return name
+}
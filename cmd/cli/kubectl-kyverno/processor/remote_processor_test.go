@@ -0,0 +1,99 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/evaluation"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type fakeEvaluationClient struct {
+	request  *evaluation.EvaluateRequest
+	response *evaluation.EvaluateResponse
+}
+
+func (c *fakeEvaluationClient) Evaluate(_ context.Context, request *evaluation.EvaluateRequest, _ ...grpc.CallOption) (*evaluation.EvaluateResponse, error) {
+	c.request = request
+	return c.response, nil
+}
+
+func (c *fakeEvaluationClient) Replay(context.Context, *evaluation.ReplayRequest, ...grpc.CallOption) (*evaluation.ReplayResponse, error) {
+	return nil, nil
+}
+
+func (c *fakeEvaluationClient) EvaluatePayload(context.Context, *evaluation.EvaluatePayloadRequest, ...grpc.CallOption) (*evaluation.EvaluatePayloadResponse, error) {
+	return nil, nil
+}
+
+func TestRemoteProcessor_ApplyPoliciesOnResource(t *testing.T) {
+	policies, _, err := yamlutils.GetPolicy([]byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  validationFailureAction: Enforce
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: label team is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+`))
+	assert.NoError(t, err)
+	pod, err := resource.YamlToUnstructured([]byte(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: test
+  namespace: default
+spec:
+  containers:
+  - name: nginx
+    image: nginx
+`))
+	assert.NoError(t, err)
+	client := &fakeEvaluationClient{
+		response: &evaluation.EvaluateResponse{
+			Results: []evaluation.PolicyResult{{
+				Name: "require-labels",
+				Rules: []evaluation.RuleResult{{
+					Name:    "check-team",
+					Type:    engineapi.Validation,
+					Status:  engineapi.RuleStatusFail,
+					Message: "label team is required",
+				}},
+			}},
+		},
+	}
+	var rc ResultCounts
+	processor := RemoteProcessor{
+		Client:   client,
+		Policies: policies,
+		Resource: *pod,
+		NamespaceSelectorMap: map[string]map[string]string{
+			"default": {"env": "test"},
+		},
+		Rc: &rc,
+	}
+	responses, err := processor.ApplyPoliciesOnResource(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, client.request.Policies, 1)
+	assert.Equal(t, map[string]string{"env": "test"}, client.request.NamespaceLabels)
+	assert.Len(t, responses, 1)
+	assert.Equal(t, "require-labels", responses[0].Policy().GetName())
+	assert.Equal(t, []string{"check-team"}, responses[0].GetFailedRules())
+	assert.Equal(t, 1, rc.Fail())
+}
@@ -0,0 +1,21 @@
+package processor
+
+import (
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/yaml"
+)
+
+// printResolvedRule prints a rule after variable substitution
+func printResolvedRule(out io.Writer, policy, rule, resourcePath string, resolved map[string]interface{}) {
+	if out == nil || resolved == nil {
+		return
+	}
+	document, err := yaml.Marshal(resolved)
+	if err != nil {
+		fmt.Fprintf(out, "failed to marshal resolved rule %s/%s (%v)\n", policy, rule, err)
+		return
+	}
+	fmt.Fprintf(out, "\nResolved rule %s/%s for resource %s:\n%s", policy, rule, resourcePath, document)
+}
@@ -0,0 +1,66 @@
+package processor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_showResolved(t *testing.T) {
+	pod := []byte(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: test
+  namespace: default
+spec:
+  containers:
+  - name: nginx
+    image: nginx
+`)
+	policy := []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-namespace
+spec:
+  validationFailureAction: Audit
+  rules:
+  - name: check-namespace
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: "namespace {{ request.object.metadata.namespace }} is not allowed"
+      foreach:
+      - list: request.object.spec.containers
+        pattern:
+          image: "{{ element.name }}*"
+`)
+	policies, _, err := yamlutils.GetPolicy(policy)
+	assert.NoError(t, err)
+	resources, err := resource.GetUnstructuredResources(pod)
+	assert.NoError(t, err)
+	var out bytes.Buffer
+	processor := PolicyProcessor{
+		Store:        &store.Store{},
+		Policies:     policies,
+		Resource:     *resources[0],
+		Rc:           &ResultCounts{},
+		Out:          &out,
+		ShowResolved: true,
+	}
+	_, err = processor.ApplyPoliciesOnResource()
+	assert.NoError(t, err)
+	output := out.String()
+	assert.True(t, strings.Contains(output, "Resolved rule check-namespace/check-namespace for resource default/Pod/test:"), output)
+	assert.True(t, strings.Contains(output, "message: namespace default is not allowed"), output)
+	assert.True(t, strings.Contains(output, "image: '{{ element.name }}*'"), output)
+}
@@ -14,6 +14,10 @@ type ResultCounts struct {
 	warn int
 	err  int
 	skip int
+	// changed is the number of resources changed by mutate policies
+	changed int
+	// nonIdempotent is the number of resources changed by a second pass of mutate policies, only computed when checking idempotency
+	nonIdempotent int
 }
 
 func (rc ResultCounts) Pass() int  { return rc.pass }
@@ -22,6 +26,9 @@ func (rc ResultCounts) Warn() int  { return rc.warn }
 func (rc ResultCounts) Error() int { return rc.err }
 func (rc ResultCounts) Skip() int  { return rc.skip }
 
+func (rc ResultCounts) Changed() int       { return rc.changed }
+func (rc ResultCounts) NonIdempotent() int { return rc.nonIdempotent }
+
 func (rc *ResultCounts) addEngineResponses(auditWarn bool, responses ...engineapi.EngineResponse) {
 	for _, response := range responses {
 		rc.addEngineResponse(auditWarn, response)
@@ -47,7 +54,7 @@ func (rc *ResultCounts) addEngineResponse(auditWarn bool, response engineapi.Eng
 							if !scored {
 								rc.warn++
 								break
-							} else if auditWarn && response.GetValidationFailureAction().Audit() {
+							} else if auditWarn && response.GetRuleValidationFailureAction(valResponseRule).Audit() {
 								rc.warn++
 							} else {
 								rc.fail++
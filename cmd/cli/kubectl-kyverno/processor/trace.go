@@ -0,0 +1,22 @@
+package processor
+
+import (
+	"fmt"
+	"io"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"sigs.k8s.io/yaml"
+)
+
+// printRuleTrace prints the decision trace of a rule
+func printRuleTrace(out io.Writer, trace engineapi.RuleTrace) {
+	if out == nil {
+		return
+	}
+	document, err := yaml.Marshal(trace.Steps)
+	if err != nil {
+		fmt.Fprintf(out, "failed to marshal trace of rule %s/%s (%v)\n", trace.Policy, trace.Rule, err)
+		return
+	}
+	fmt.Fprintf(out, "\nTrace of %s rule %s/%s for resource %s:\n%s", trace.Type, trace.Policy, trace.Rule, trace.Resource, document)
+}
@@ -14,10 +14,13 @@ import (
 func ComputePolicyReportResult(auditWarn bool, engineResponse engineapi.EngineResponse, ruleResponse engineapi.RuleResponse) policyreportv1alpha2.PolicyReportResult {
 	policy := engineResponse.Policy()
 	policyName := cache.MetaObjectToName(policy.MetaObject()).String()
-	audit := engineResponse.GetValidationFailureAction().Audit()
+	audit := engineResponse.GetRuleValidationFailureAction(ruleResponse).Audit()
 	scored := annotations.Scored(policy.GetAnnotations())
 	category := annotations.Category(policy.GetAnnotations())
 	severity := annotations.Severity(policy.GetAnnotations())
+	if ruleResponse.Severity() != "" {
+		severity = reportutils.SeverityFromString(ruleResponse.Severity())
+	}
 	result := policyreportv1alpha2.PolicyReportResult{
 		Policy: policyName,
 		Resources: []corev1.ObjectReference{
@@ -0,0 +1,78 @@
+package resource
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
+	"github.com/kyverno/kyverno/ext/wildcard"
+)
+
+// ExpandPaths replaces directories in paths with the YAML files they contain (recursively).
+// Stdin, http and oci paths are returned unchanged.
+// When include patterns are given, only files matching at least one of them are kept,
+// files or directories matching one of the exclude patterns are skipped.
+// Patterns are matched against both the path relative to the directory and the file name.
+func ExpandPaths(paths []string, include []string, exclude []string) ([]string, error) {
+	var results []string
+	for _, path := range paths {
+		if path == "-" || source.IsHttp(path) || source.IsOCI(path) {
+			results = append(results, path)
+			continue
+		}
+		info, err := os.Stat(filepath.Clean(path))
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			results = append(results, path)
+			continue
+		}
+		root := filepath.Clean(path)
+		err = filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if file == root {
+				return nil
+			}
+			rel, err := filepath.Rel(root, file)
+			if err != nil {
+				return err
+			}
+			if matchesAny(exclude, rel, entry.Name()) {
+				if entry.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if entry.IsDir() || !isYaml(entry.Name()) {
+				return nil
+			}
+			if len(include) != 0 && !matchesAny(include, rel, entry.Name()) {
+				return nil
+			}
+			results = append(results, file)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+func isYaml(name string) bool {
+	ext := filepath.Ext(name)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+func matchesAny(patterns []string, rel string, name string) bool {
+	for _, pattern := range patterns {
+		if wildcard.Match(pattern, filepath.ToSlash(rel)) || wildcard.Match(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package resource
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandPaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{"a.yaml", "b.yml", "c.txt", "nested/d.yaml", "tests/e.yaml"} {
+		path := filepath.Join(dir, file)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte("kind: ConfigMap"), 0o600))
+	}
+	tests := []struct {
+		name    string
+		paths   []string
+		include []string
+		exclude []string
+		want    []string
+		wantErr bool
+	}{{
+		name:  "stdin, http and oci",
+		paths: []string{"-", "https://example.com/resource.yaml", "oci://ghcr.io/org/resources:v1"},
+		want:  []string{"-", "https://example.com/resource.yaml", "oci://ghcr.io/org/resources:v1"},
+	}, {
+		name:  "file",
+		paths: []string{filepath.Join(dir, "c.txt")},
+		want:  []string{filepath.Join(dir, "c.txt")},
+	}, {
+		name:  "directory",
+		paths: []string{dir},
+		want: []string{
+			filepath.Join(dir, "a.yaml"),
+			filepath.Join(dir, "b.yml"),
+			filepath.Join(dir, "nested/d.yaml"),
+			filepath.Join(dir, "tests/e.yaml"),
+		},
+	}, {
+		name:    "include",
+		paths:   []string{dir},
+		include: []string{"*.yaml"},
+		want: []string{
+			filepath.Join(dir, "a.yaml"),
+			filepath.Join(dir, "nested/d.yaml"),
+			filepath.Join(dir, "tests/e.yaml"),
+		},
+	}, {
+		name:    "exclude",
+		paths:   []string{dir},
+		exclude: []string{"tests", "b.yml"},
+		want: []string{
+			filepath.Join(dir, "a.yaml"),
+			filepath.Join(dir, "nested/d.yaml"),
+		},
+	}, {
+		name:    "not found",
+		paths:   []string{filepath.Join(dir, "missing")},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandPaths(tt.paths, tt.include, tt.exclude)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package resource
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// helmKubeVersion is the Kubernetes version reported to charts through `.Capabilities.KubeVersion`
+const helmKubeVersion = "v1.29.0"
+
+// HelmRelease describes the release a chart is rendered for
+type HelmRelease struct {
+	// Name is the release name, exposed to templates as `.Release.Name`
+	Name string
+	// Namespace is the release namespace, exposed to templates as `.Release.Namespace`
+	Namespace string
+}
+
+type helmChart struct {
+	APIVersion  string `json:"apiVersion"`
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	AppVersion  string `json:"appVersion"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+}
+
+type helmAPIVersions []string
+
+// Has returns true if the API version is available, no API version is available when rendering locally
+func (v helmAPIVersions) Has(apiVersion string) bool {
+	for _, version := range v {
+		if version == apiVersion {
+			return true
+		}
+	}
+	return false
+}
+
+// Helm renders the chart in dir with the given values files, the same way `helm template` does, and returns
+// the rendered resources. Values files are merged on top of the chart values in order. Only the templates of
+// the chart itself are rendered, dependencies in the `charts` directory are not supported.
+func Helm(dir string, valuesFiles []string, release HelmRelease) ([]*unstructured.Unstructured, error) {
+	rendered, err := renderHelmChart(dir, valuesFiles, release)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render helm chart %s (%w)", dir, err)
+	}
+	return GetUnstructuredResources(rendered)
+}
+
+func renderHelmChart(dir string, valuesFiles []string, release HelmRelease) ([]byte, error) {
+	var chart helmChart
+	if err := readHelmFile(filepath.Join(dir, "Chart.yaml"), &chart); err != nil {
+		return nil, err
+	}
+	if chart.Name == "" {
+		return nil, fmt.Errorf("chart name is required in Chart.yaml")
+	}
+	values := map[string]interface{}{}
+	if _, err := os.Stat(filepath.Join(dir, "values.yaml")); err == nil {
+		if err := readHelmFile(filepath.Join(dir, "values.yaml"), &values); err != nil {
+			return nil, err
+		}
+	}
+	for _, file := range valuesFiles {
+		override := map[string]interface{}{}
+		if err := readHelmFile(file, &override); err != nil {
+			return nil, err
+		}
+		values = mergeHelmValues(values, override)
+	}
+	templates, err := parseHelmTemplates(dir, chart.Name)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, t := range templates.Templates() {
+		base := filepath.Base(t.Name())
+		if strings.HasPrefix(base, "_") || base == "NOTES.txt" || t.Tree == nil || !strings.HasPrefix(t.Name(), chart.Name+"/templates/") {
+			continue
+		}
+		names = append(names, t.Name())
+	}
+	sort.Strings(names)
+	var out bytes.Buffer
+	for _, name := range names {
+		data := map[string]interface{}{
+			"Values": values,
+			"Chart":  chart,
+			"Release": map[string]interface{}{
+				"Name":      release.Name,
+				"Namespace": release.Namespace,
+				"Service":   "Helm",
+				"Revision":  1,
+				"IsInstall": true,
+				"IsUpgrade": false,
+			},
+			"Capabilities": map[string]interface{}{
+				"KubeVersion": map[string]interface{}{
+					"Version":    helmKubeVersion,
+					"GitVersion": helmKubeVersion,
+					"Major":      "1",
+					"Minor":      "29",
+				},
+				"APIVersions": helmAPIVersions{},
+			},
+			"Template": map[string]interface{}{
+				"Name":     name,
+				"BasePath": chart.Name + "/templates",
+			},
+		}
+		var buf bytes.Buffer
+		if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+			return nil, err
+		}
+		content := strings.ReplaceAll(buf.String(), "<no value>", "")
+		if strings.TrimSpace(content) == "" {
+			continue
+		}
+		fmt.Fprintf(&out, "---\n# Source: %s\n%s\n", name, content)
+	}
+	return out.Bytes(), nil
+}
+
+func readHelmFile(path string, out interface{}) error {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(content, out); err != nil {
+		return fmt.Errorf("failed to parse %s (%w)", path, err)
+	}
+	return nil
+}
+
+// mergeHelmValues merges override into base, nested maps are merged and other values are replaced
+func mergeHelmValues(base, override map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(base))
+	for key, value := range base {
+		out[key] = value
+	}
+	for key, value := range override {
+		if overrideMap, ok := value.(map[string]interface{}); ok {
+			if baseMap, ok := out[key].(map[string]interface{}); ok {
+				out[key] = mergeHelmValues(baseMap, overrideMap)
+				continue
+			}
+		}
+		out[key] = value
+	}
+	return out
+}
+
+func parseHelmTemplates(dir, chartName string) (*template.Template, error) {
+	templates := template.New(chartName).Option("missingkey=zero")
+	funcs := sprig.TxtFuncMap()
+	delete(funcs, "env")
+	delete(funcs, "expandenv")
+	funcs["toYaml"] = func(v interface{}) string {
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSuffix(string(data), "\n")
+	}
+	funcs["fromYaml"] = func(str string) map[string]interface{} {
+		m := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(str), &m); err != nil {
+			m["Error"] = err.Error()
+		}
+		return m
+	}
+	funcs["required"] = func(message string, v interface{}) (interface{}, error) {
+		if v == nil {
+			return v, errors.New(message)
+		}
+		if s, ok := v.(string); ok && s == "" {
+			return v, errors.New(message)
+		}
+		return v, nil
+	}
+	funcs["lookup"] = func(string, string, string, string) (map[string]interface{}, error) {
+		return map[string]interface{}{}, nil
+	}
+	funcs["include"] = func(name string, data interface{}) (string, error) {
+		var buf bytes.Buffer
+		if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	funcs["tpl"] = func(text string, data interface{}) (string, error) {
+		clone, err := templates.Clone()
+		if err != nil {
+			return "", err
+		}
+		t, err := clone.New("tpl").Parse(text)
+		if err != nil {
+			return "", err
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, data); err != nil {
+			return "", err
+		}
+		return strings.ReplaceAll(buf.String(), "<no value>", ""), nil
+	}
+	templates.Funcs(funcs)
+	root := filepath.Join(dir, "templates")
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name := chartName + "/" + filepath.ToSlash(rel)
+		if _, err := templates.New(name).Parse(string(content)); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
@@ -0,0 +1,62 @@
+package resource
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHelm(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"chart/Chart.yaml":                "apiVersion: v2\nname: app\nversion: 0.1.0\nappVersion: \"1.25\"\n",
+		"chart/values.yaml":               "replicas: 1\nimage:\n  repository: nginx\n  tag: \"\"\nservice:\n  enabled: false\n",
+		"chart/templates/_helpers.tpl":    "{{- define \"app.fullname\" -}}\n{{ .Release.Name }}-{{ .Chart.Name }}\n{{- end }}\n",
+		"chart/templates/deployment.yaml": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: {{ include \"app.fullname\" . }}\n  namespace: {{ .Release.Namespace }}\nspec:\n  replicas: {{ .Values.replicas }}\n  template:\n    spec:\n      containers:\n      - name: app\n        image: {{ .Values.image.repository }}:{{ .Values.image.tag | default .Chart.AppVersion }}\n",
+		"chart/templates/service.yaml":    "{{- if .Values.service.enabled }}\napiVersion: v1\nkind: Service\nmetadata:\n  name: {{ include \"app.fullname\" . }}\n{{- end }}\n",
+		"chart/templates/NOTES.txt":       "Installed {{ .Release.Name }}\n",
+		"values.yaml":                     "replicas: 3\nimage:\n  tag: latest\n",
+	}
+	for file, content := range files {
+		path := filepath.Join(dir, file)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	release := HelmRelease{Name: "test", Namespace: "dev"}
+	resources, err := Helm(filepath.Join(dir, "chart"), nil, release)
+	assert.NoError(t, err)
+	assert.Len(t, resources, 1)
+	assert.Equal(t, "Deployment", resources[0].GetKind())
+	assert.Equal(t, "test-app", resources[0].GetName())
+	assert.Equal(t, "dev", resources[0].GetNamespace())
+	containers := resources[0].Object["spec"].(map[string]interface{})["template"].(map[string]interface{})["spec"].(map[string]interface{})["containers"].([]interface{})
+	assert.Equal(t, "nginx:1.25", containers[0].(map[string]interface{})["image"])
+	resources, err = Helm(filepath.Join(dir, "chart"), []string{filepath.Join(dir, "values.yaml")}, release)
+	assert.NoError(t, err)
+	assert.Len(t, resources, 1)
+	assert.Equal(t, int64(3), resources[0].Object["spec"].(map[string]interface{})["replicas"])
+	containers = resources[0].Object["spec"].(map[string]interface{})["template"].(map[string]interface{})["spec"].(map[string]interface{})["containers"].([]interface{})
+	assert.Equal(t, "nginx:latest", containers[0].(map[string]interface{})["image"])
+	_, err = Helm(filepath.Join(dir, "missing"), nil, release)
+	assert.Error(t, err)
+}
+
+func Test_mergeHelmValues(t *testing.T) {
+	base := map[string]interface{}{
+		"a": 1,
+		"b": map[string]interface{}{"c": 2, "d": 3},
+		"e": map[string]interface{}{"f": 4},
+	}
+	override := map[string]interface{}{
+		"b": map[string]interface{}{"c": 5},
+		"e": "g",
+	}
+	assert.Equal(t, map[string]interface{}{
+		"a": 1,
+		"b": map[string]interface{}{"c": 5, "d": 3},
+		"e": "g",
+	}, mergeHelmValues(base, override))
+	assert.Equal(t, map[string]interface{}{"c": 2, "d": 3}, base["b"])
+}
@@ -0,0 +1,22 @@
+package resource
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// Kustomize builds the kustomization in dir, the same way `kustomize build dir` does, and returns the rendered resources
+func Kustomize(dir string) ([]*unstructured.Unstructured, error) {
+	resMap, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kustomization %s (%w)", dir, err)
+	}
+	yamlBytes, err := resMap.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render kustomization %s (%w)", dir, err)
+	}
+	return GetUnstructuredResources(yamlBytes)
+}
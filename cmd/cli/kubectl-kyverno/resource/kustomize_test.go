@@ -0,0 +1,31 @@
+package resource
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKustomize(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"base/kustomization.yaml":    "resources:\n- pod.yaml\n",
+		"base/pod.yaml":              "apiVersion: v1\nkind: Pod\nmetadata:\n  name: app\nspec:\n  containers:\n  - name: app\n    image: nginx:1.25\n",
+		"overlay/kustomization.yaml": "resources:\n- ../base\nnamespace: dev\nnamePrefix: dev-\n",
+	}
+	for file, content := range files {
+		path := filepath.Join(dir, file)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	resources, err := Kustomize(filepath.Join(dir, "overlay"))
+	assert.NoError(t, err)
+	assert.Len(t, resources, 1)
+	assert.Equal(t, "Pod", resources[0].GetKind())
+	assert.Equal(t, "dev-app", resources[0].GetName())
+	assert.Equal(t, "dev", resources[0].GetNamespace())
+	_, err = Kustomize(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
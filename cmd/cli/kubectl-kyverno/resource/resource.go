@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/go-git/go-billy/v5"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/oci"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
 	yamlutils "github.com/kyverno/kyverno/ext/yaml"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
@@ -29,7 +30,32 @@ func GetUnstructuredResources(resourceBytes []byte) ([]*unstructured.Unstructure
 		if err != nil {
 			return nil, err
 		}
-		resources = append(resources, resource)
+		if resource.IsList() {
+			items, err := listItems(resource)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, items...)
+		} else {
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+// listItems flattens a `List` kind (v1/List, PodList, ...) into its items
+func listItems(resource *unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	list, err := resource.ToList()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode resource list: %w", err)
+	}
+	resources := make([]*unstructured.Unstructured, 0, len(list.Items))
+	for i := range list.Items {
+		item := list.Items[i]
+		if item.GetNamespace() == "" {
+			item.SetNamespace("default")
+		}
+		resources = append(resources, &item)
 	}
 	return resources, nil
 }
@@ -60,6 +86,17 @@ func YamlToUnstructured(resourceYaml []byte) (*unstructured.Unstructured, error)
 }
 
 func GetResourceFromPath(fs billy.Filesystem, path string) (*unstructured.Unstructured, error) {
+	resources, err := GetResourcesFromPath(fs, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(resources) != 1 {
+		return nil, fmt.Errorf("exactly one resource expected, found %d", len(resources))
+	}
+	return resources[0], nil
+}
+
+func GetResourcesFromPath(fs billy.Filesystem, path string) ([]*unstructured.Unstructured, error) {
 	var resourceBytes []byte
 	if fs == nil {
 		data, err := GetFileBytes(path)
@@ -79,18 +116,22 @@ func GetResourceFromPath(fs billy.Filesystem, path string) (*unstructured.Unstru
 		}
 		resourceBytes = data
 	}
-	resources, err := GetUnstructuredResources(resourceBytes)
-	if err != nil {
-		return nil, err
-	}
-	if len(resources) != 1 {
-		return nil, fmt.Errorf("exactly one resource expected, found %d", len(resources))
-	}
-	return resources[0], nil
+	return GetUnstructuredResources(resourceBytes)
 }
 
 func GetFileBytes(path string) ([]byte, error) {
-	if source.IsHttp(path) {
+	if source.IsOCI(path) {
+		layers, err := oci.PullResourceLayers(context.TODO(), path, oci.Keychain)
+		if err != nil {
+			return nil, err
+		}
+		var file []byte
+		for _, layer := range layers {
+			file = append(file, []byte("---\n")...)
+			file = append(file, layer...)
+		}
+		return file, nil
+	} else if source.IsHttp(path) {
 		// We accept here that a random URL might be called based on user provided input.
 		req, err := http.NewRequestWithContext(context.TODO(), http.MethodGet, path, nil)
 		if err != nil {
@@ -0,0 +1,79 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUnstructuredResources(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    []string
+		wantErr bool
+	}{{
+		name: "multi documents",
+		yaml: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: one
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: two
+`,
+		want: []string{"default/one", "default/two"},
+	}, {
+		name: "list",
+		yaml: `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: one
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    name: two
+    namespace: test
+`,
+		want: []string{"default/one", "test/two"},
+	}, {
+		name: "list and document",
+		yaml: `
+apiVersion: v1
+kind: PodList
+items:
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: one
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: two
+`,
+		want: []string{"default/one", "default/two"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources, err := GetUnstructuredResources([]byte(tt.yaml))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			var got []string
+			for _, resource := range resources {
+				got = append(got, resource.GetNamespace()+"/"+resource.GetName())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,5 +1,41 @@
 package source
 
+import (
+	"os"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+const (
+	gitUsernameEnv = "KYVERNO_GIT_USERNAME"
+	gitTokenEnv    = "KYVERNO_GIT_TOKEN"
+)
+
 func IsGit(in string) bool {
 	return IsHttp(in)
 }
+
+// IsGitRepository returns true when the URL points to a repository explicitly (ends with .git)
+func IsGitRepository(in string) bool {
+	return IsGit(in) && strings.HasSuffix(strings.TrimSuffix(in, "/"), ".git")
+}
+
+// GitAuth returns basic auth credentials read from KYVERNO_GIT_USERNAME and KYVERNO_GIT_TOKEN,
+// or nil when no token is configured
+func GitAuth() transport.AuthMethod {
+	token := os.Getenv(gitTokenEnv)
+	if token == "" {
+		return nil
+	}
+	username := os.Getenv(gitUsernameEnv)
+	if username == "" {
+		// most providers accept any non empty username when authenticating with a token
+		username = "kyverno"
+	}
+	return &http.BasicAuth{
+		Username: username,
+		Password: token,
+	}
+}
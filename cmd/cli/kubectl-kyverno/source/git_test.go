@@ -36,3 +36,50 @@ func TestIsGit(t *testing.T) {
 		})
 	}
 }
+
+func TestIsGitRepository(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want bool
+	}{{
+		name: "empty",
+		in:   "",
+		want: false,
+	}, {
+		name: "repository",
+		in:   "https://github.com/kyverno/policies.git",
+		want: true,
+	}, {
+		name: "repository with trailing slash",
+		in:   "https://github.com/kyverno/policies.git/",
+		want: true,
+	}, {
+		name: "path in repository",
+		in:   "https://github.com/kyverno/policies/pod-security",
+		want: false,
+	}, {
+		name: "local path",
+		in:   "/policies.git",
+		want: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsGitRepository(tt.in); got != tt.want {
+				t.Errorf("IsGitRepository() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitAuth(t *testing.T) {
+	t.Setenv(gitTokenEnv, "")
+	if got := GitAuth(); got != nil {
+		t.Errorf("GitAuth() = %v, want nil", got)
+	}
+	t.Setenv(gitTokenEnv, "token")
+	t.Setenv(gitUsernameEnv, "user")
+	if got := GitAuth(); got == nil || got.String() != "http-basic-auth - user:*******" {
+		t.Errorf("GitAuth() = %v, want basic auth", got)
+	}
+}
@@ -0,0 +1,16 @@
+package source
+
+import (
+	"strings"
+)
+
+const ociPrefix = "oci://"
+
+func IsOCI(in string) bool {
+	return strings.HasPrefix(in, ociPrefix)
+}
+
+// OCIReference returns the image reference without the oci:// scheme
+func OCIReference(in string) string {
+	return strings.TrimPrefix(in, ociPrefix)
+}
@@ -0,0 +1,57 @@
+package source
+
+import "testing"
+
+func TestIsOCI(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want bool
+	}{{
+		name: "empty",
+		in:   "",
+		want: false,
+	}, {
+		name: "oci",
+		in:   "oci://ghcr.io/kyverno/policies:latest",
+		want: true,
+	}, {
+		name: "https",
+		in:   "https://github.com/kyverno/policies",
+		want: false,
+	}, {
+		name: "local path",
+		in:   "/oci/kyverno/policies",
+		want: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsOCI(tt.in); got != tt.want {
+				t.Errorf("IsOCI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOCIReference(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{{
+		name: "oci",
+		in:   "oci://ghcr.io/kyverno/policies:latest",
+		want: "ghcr.io/kyverno/policies:latest",
+	}, {
+		name: "no scheme",
+		in:   "ghcr.io/kyverno/policies:latest",
+		want: "ghcr.io/kyverno/policies:latest",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OCIReference(tt.in); got != tt.want {
+				t.Errorf("OCIReference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package store
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type configMapResolver []corev1.ConfigMap
+
+func (r configMapResolver) Get(_ context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	for i := range r {
+		if r[i].GetNamespace() == namespace && r[i].GetName() == name {
+			return &r[i], nil
+		}
+	}
+	return nil, apierrors.NewNotFound(corev1.Resource("configmaps"), name)
+}
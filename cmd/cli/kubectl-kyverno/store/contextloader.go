@@ -2,8 +2,10 @@ package store
 
 import (
 	"context"
+	"fmt"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
@@ -36,15 +38,19 @@ func ContextLoaderFactory(s *Store, cmResolver engineapi.ConfigmapResolver) engi
 		}
 		factory := factories.DefaultContextLoaderFactory(cmResolver, factories.WithInitializer(init))
 		return wrapper{
-			store: s,
-			inner: factory(policy, rule),
+			store:  s,
+			policy: policy.GetName(),
+			rule:   rule.Name,
+			inner:  factory(policy, rule),
 		}
 	}
 }
 
 type wrapper struct {
-	store *Store
-	inner engineapi.ContextLoader
+	store  *Store
+	policy string
+	rule   string
+	inner  engineapi.ContextLoader
 }
 
 func (w wrapper) Load(
@@ -61,5 +67,62 @@ func (w wrapper) Load(
 	if !w.store.GetRegistryAccess() {
 		rclientFactory = nil
 	}
+	if rule := w.store.GetPolicyRule(w.policy, w.rule); rule != nil && len(rule.Context) > 0 {
+		entries, err := stubContextEntries(contextEntries, rule.Context)
+		if err != nil {
+			return err
+		}
+		contextEntries = entries
+	}
 	return w.inner.Load(ctx, jp, client, rclientFactory, contextEntries, jsonContext)
 }
+
+// stubContextEntries replaces the apiCall and imageRegistry context entries having a stubbed response
+// with variables holding the response, the order of the entries is preserved so that later entries
+// can reference the stubbed ones
+func stubContextEntries(contextEntries []kyvernov1.ContextEntry, stubs []v1alpha1.ContextEntry) ([]kyvernov1.ContextEntry, error) {
+	entries := make([]kyvernov1.ContextEntry, 0, len(contextEntries))
+	for _, entry := range contextEntries {
+		stub := findStub(stubs, entry.Name)
+		if stub == nil {
+			entries = append(entries, entry)
+			continue
+		}
+		switch {
+		case stub.APICall != nil:
+			if entry.APICall == nil {
+				return nil, fmt.Errorf("context entry %s has a stubbed apiCall response but is not an apiCall", entry.Name)
+			}
+			entries = append(entries, kyvernov1.ContextEntry{
+				Name: entry.Name,
+				Variable: &kyvernov1.Variable{
+					Value:    stub.APICall,
+					JMESPath: entry.APICall.JMESPath,
+				},
+			})
+		case stub.ImageRegistry != nil:
+			if entry.ImageRegistry == nil {
+				return nil, fmt.Errorf("context entry %s has a stubbed imageRegistry response but is not an imageRegistry", entry.Name)
+			}
+			entries = append(entries, kyvernov1.ContextEntry{
+				Name: entry.Name,
+				Variable: &kyvernov1.Variable{
+					Value:    stub.ImageRegistry,
+					JMESPath: entry.ImageRegistry.JMESPath,
+				},
+			})
+		default:
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func findStub(stubs []v1alpha1.ContextEntry, name string) *v1alpha1.ContextEntry {
+	for i := range stubs {
+		if stubs[i].Name == name {
+			return &stubs[i]
+		}
+	}
+	return nil
+}
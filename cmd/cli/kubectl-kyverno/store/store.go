@@ -1,7 +1,10 @@
 package store
 
 import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/registryclient"
+	corev1 "k8s.io/api/core/v1"
 )
 
 type Context struct {
@@ -17,6 +20,7 @@ type Rule struct {
 	Name          string                   `json:"name"`
 	Values        map[string]interface{}   `json:"values"`
 	ForEachValues map[string][]interface{} `json:"foreachValues"`
+	Context       []v1alpha1.ContextEntry  `json:"context"`
 }
 
 type Store struct {
@@ -25,6 +29,7 @@ type Store struct {
 	allowApiCalls  bool
 	policies       []Policy
 	foreachElement int
+	configMaps     []corev1.ConfigMap
 }
 
 // SetLocal sets local (clusterless) execution for the CLI
@@ -97,3 +102,16 @@ func (s *Store) AllowApiCall(allow bool) {
 func (s *Store) IsApiCallAllowed() bool {
 	return s.allowApiCalls
 }
+
+// SetConfigMaps sets the config maps loaded by configMap context entries
+func (s *Store) SetConfigMaps(configMaps ...corev1.ConfigMap) {
+	s.configMaps = configMaps
+}
+
+// GetConfigMapResolver returns a resolver serving the config maps of the store, or nil when there is none
+func (s *Store) GetConfigMapResolver() engineapi.ConfigmapResolver {
+	if len(s.configMaps) == 0 {
+		return nil
+	}
+	return configMapResolver(s.configMaps)
+}
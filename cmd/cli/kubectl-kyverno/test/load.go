@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-git/go-billy/v5"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	extyaml "github.com/kyverno/kyverno/ext/yaml"
 	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
@@ -65,6 +66,14 @@ func LoadTest(fs billy.Filesystem, path string) TestCase {
 		}
 		yamlBytes = data
 	}
+	yamlBytes, err := extyaml.FindDocument(yamlBytes, "Test")
+	if err != nil {
+		return TestCase{
+			Path: path,
+			Fs:   fs,
+			Err:  err,
+		}
+	}
 	var test v1alpha1.Test
 	if err := yaml.UnmarshalStrict(yamlBytes, &test); err != nil {
 		return TestCase{
@@ -1,11 +1,9 @@
 package common
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/go-git/go-billy/v5"
@@ -24,6 +22,7 @@ import (
 )
 
 // GetResourceAccordingToResourcePath - get resources according to the resource path
+// directories are walked recursively, include and exclude are optional glob patterns filtering the files found
 func GetResourceAccordingToResourcePath(
 	out io.Writer,
 	fs billy.Filesystem,
@@ -35,6 +34,8 @@ func GetResourceAccordingToResourcePath(
 	namespace string,
 	policyReport bool,
 	policyResourcePath string,
+	include []string,
+	exclude []string,
 ) (resources []*unstructured.Unstructured, err error) {
 	if fs != nil {
 		resources, err = GetResourcesWithTest(out, fs, policies, resourcePaths, policyResourcePath)
@@ -44,40 +45,22 @@ func GetResourceAccordingToResourcePath(
 	} else {
 		if len(resourcePaths) > 0 && resourcePaths[0] == "-" {
 			if source.IsStdin(resourcePaths[0]) {
-				resourceStr := ""
-				scanner := bufio.NewScanner(os.Stdin)
-				for scanner.Scan() {
-					resourceStr = resourceStr + scanner.Text() + "\n"
+				yamlBytes, err := io.ReadAll(os.Stdin)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read the resources from stdin (%w)", err)
 				}
-
-				yamlBytes := []byte(resourceStr)
 				resources, err = resource.GetUnstructuredResources(yamlBytes)
 				if err != nil {
 					return nil, fmt.Errorf("failed to extract the resources (%w)", err)
 				}
 			}
 		} else {
-			if len(resourcePaths) > 0 {
-				fileDesc, err := os.Stat(resourcePaths[0])
+			if !cluster {
+				resourcePaths, err = resource.ExpandPaths(resourcePaths, include, exclude)
 				if err != nil {
 					return nil, err
 				}
-				if fileDesc.IsDir() {
-					files, err := os.ReadDir(resourcePaths[0])
-					if err != nil {
-						return nil, fmt.Errorf("failed to parse %v (%w)", resourcePaths[0], err)
-					}
-					listOfFiles := make([]string, 0)
-					for _, file := range files {
-						ext := filepath.Ext(file.Name())
-						if ext == ".yaml" || ext == ".yml" {
-							listOfFiles = append(listOfFiles, filepath.Join(resourcePaths[0], file.Name()))
-						}
-					}
-					resourcePaths = listOfFiles
-				}
 			}
-
 			resources, err = GetResources(out, policies, validatingAdmissionPolicies, resourcePaths, dClient, cluster, namespace, policyReport)
 			if err != nil {
 				return resources, err
@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-git/go-billy/v5"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	extyaml "github.com/kyverno/kyverno/ext/yaml"
 	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
@@ -14,6 +15,10 @@ func Load(f billy.Filesystem, filepath string) (*v1alpha1.Values, error) {
 	if err != nil {
 		return nil, err
 	}
+	yamlBytes, err = extyaml.FindDocument(yamlBytes, "Values")
+	if err != nil {
+		return nil, err
+	}
 	vals := &v1alpha1.Values{}
 	if err := yaml.UnmarshalStrict(yamlBytes, vals); err != nil {
 		return nil, err
@@ -8,6 +8,7 @@ import (
 	"github.com/go-git/go-billy/v5"
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func Test_readFile(t *testing.T) {
@@ -133,6 +134,21 @@ func TestLoad(t *testing.T) {
 			},
 		},
 		wantErr: false,
+	}, {
+		name:     "policy and values",
+		filepath: "../_testdata/values/policy-and-values.yaml",
+		want: &v1alpha1.Values{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "cli.kyverno.io/v1alpha1",
+				Kind:       "Values",
+			},
+			ValuesSpec: v1alpha1.ValuesSpec{
+				GlobalValues: map[string]interface{}{
+					"request.operation": "UPDATE",
+				},
+			},
+		},
+		wantErr: false,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
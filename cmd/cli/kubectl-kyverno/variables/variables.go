@@ -91,6 +91,7 @@ func (v Variables) SetInStore(s *store.Store) {
 					Name:          r.Name,
 					Values:        r.Values,
 					ForEachValues: r.ForeachValues,
+					Context:       r.Context,
 				}
 				sp.Rules = append(sp.Rules, sr)
 			}
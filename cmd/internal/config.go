@@ -11,6 +11,10 @@ type Configuration interface {
 	UsesKubeconfig() bool
 	UsesPolicyExceptions() bool
 	UsesConfigMapCaching() bool
+	UsesCloudMetadata() bool
+	UsesSecretReferences() bool
+	UsesServiceCalls() bool
+	UsesImageAllowLists() bool
 	UsesDeferredLoading() bool
 	UsesCosign() bool
 	UsesRegistryClient() bool
@@ -71,6 +75,30 @@ func WithConfigMapCaching() ConfigurationOption {
 	}
 }
 
+func WithCloudMetadata() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesCloudMetadata = true
+	}
+}
+
+func WithSecretReferences() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesSecretReferences = true
+	}
+}
+
+func WithServiceCalls() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesServiceCalls = true
+	}
+}
+
+func WithImageAllowLists() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesImageAllowLists = true
+	}
+}
+
 func WithDeferredLoading() ConfigurationOption {
 	return func(c *configuration) {
 		c.usesDeferredLoading = true
@@ -152,6 +180,10 @@ type configuration struct {
 	usesKubeconfig           bool
 	usesPolicyExceptions     bool
 	usesConfigMapCaching     bool
+	usesCloudMetadata        bool
+	usesSecretReferences     bool
+	usesServiceCalls         bool
+	usesImageAllowLists      bool
 	usesDeferredLoading      bool
 	usesCosign               bool
 	usesRegistryClient       bool
@@ -190,6 +222,22 @@ func (c *configuration) UsesConfigMapCaching() bool {
 	return c.usesConfigMapCaching
 }
 
+func (c *configuration) UsesCloudMetadata() bool {
+	return c.usesCloudMetadata
+}
+
+func (c *configuration) UsesSecretReferences() bool {
+	return c.usesSecretReferences
+}
+
+func (c *configuration) UsesServiceCalls() bool {
+	return c.usesServiceCalls
+}
+
+func (c *configuration) UsesImageAllowLists() bool {
+	return c.usesImageAllowLists
+}
+
 func (c *configuration) UsesDeferredLoading() bool {
 	return c.usesDeferredLoading
 }
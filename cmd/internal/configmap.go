@@ -5,17 +5,20 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
 	"github.com/kyverno/kyverno/pkg/config"
 	genericconfigmapcontroller "github.com/kyverno/kyverno/pkg/controllers/generic/configmap"
+	"github.com/kyverno/kyverno/pkg/event"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/events"
 )
 
 const (
 	resyncPeriod = 15 * time.Minute
 )
 
-func startConfigController(ctx context.Context, logger logr.Logger, client kubernetes.Interface, skipResourceFilters bool) config.Configuration {
+func startConfigController(ctx context.Context, logger logr.Logger, client kubernetes.Interface, recorder events.EventRecorder, skipResourceFilters bool) config.Configuration {
 	configuration := config.NewDefaultConfiguration(skipResourceFilters)
 	configurationController := genericconfigmapcontroller.NewController(
 		"config-controller",
@@ -24,7 +27,9 @@ func startConfigController(ctx context.Context, logger logr.Logger, client kuber
 		config.KyvernoNamespace(),
 		config.KyvernoConfigMapName(),
 		func(ctx context.Context, cm *corev1.ConfigMap) error {
-			configuration.Load(cm)
+			if err := configuration.Load(cm); err != nil {
+				recordConfigRejected(recorder, cm, err)
+			}
 			return nil
 		},
 	)
@@ -33,7 +38,7 @@ func startConfigController(ctx context.Context, logger logr.Logger, client kuber
 	return configuration
 }
 
-func startMetricsConfigController(ctx context.Context, logger logr.Logger, client kubernetes.Interface) config.MetricsConfiguration {
+func startMetricsConfigController(ctx context.Context, logger logr.Logger, client kubernetes.Interface, recorder events.EventRecorder) config.MetricsConfiguration {
 	configuration := config.NewDefaultMetricsConfiguration()
 	configurationController := genericconfigmapcontroller.NewController(
 		"metrics-config-controller",
@@ -42,7 +47,9 @@ func startMetricsConfigController(ctx context.Context, logger logr.Logger, clien
 		config.KyvernoNamespace(),
 		config.KyvernoMetricsConfigMapName(),
 		func(ctx context.Context, cm *corev1.ConfigMap) error {
-			configuration.Load(cm)
+			if err := configuration.Load(cm); err != nil {
+				recordConfigRejected(recorder, cm, err)
+			}
 			return nil
 		},
 	)
@@ -50,3 +57,25 @@ func startMetricsConfigController(ctx context.Context, logger logr.Logger, clien
 	go configurationController.Run(ctx, 1)
 	return configuration
 }
+
+// createConfigEventRecorder creates the recorder used to report rejected configmaps,
+// it is independent from the events client as config controllers are started first
+func createConfigEventRecorder(ctx context.Context, logger logr.Logger, client kubernetes.Interface) events.EventRecorder {
+	broadcaster := events.NewBroadcaster(&events.EventSinkImpl{
+		Interface: client.EventsV1(),
+	})
+	checkError(logger, broadcaster.StartRecordingToSinkWithContext(ctx), "failed to start config event recorder")
+	return broadcaster.NewRecorder(scheme.Scheme, string(event.ConfigController))
+}
+
+func recordConfigRejected(recorder events.EventRecorder, cm *corev1.ConfigMap, err error) {
+	regarding := corev1.ObjectReference{
+		APIVersion:      "v1",
+		Kind:            "ConfigMap",
+		Namespace:       cm.Namespace,
+		Name:            cm.Name,
+		UID:             cm.UID,
+		ResourceVersion: cm.ResourceVersion,
+	}
+	recorder.Eventf(&regarding, nil, corev1.EventTypeWarning, string(event.ConfigRejected), "Load", "configuration rejected, the current configuration is kept: %v", err)
+}
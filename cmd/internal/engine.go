@@ -3,22 +3,30 @@ package internal
 import (
 	"context"
 	"errors"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/cloudmetadata"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/kyverno/kyverno/pkg/secrets"
+	apiserver "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apiextensionsinformers "k8s.io/apiextensions-apiserver/pkg/client/informers/externalversions"
+	apiextensionsv1listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/v1"
 	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 )
@@ -35,10 +43,25 @@ func NewEngine(
 	kubeClient kubernetes.Interface,
 	kyvernoClient versioned.Interface,
 	secretLister corev1listers.SecretNamespaceLister,
+	apiServerClient apiserver.Interface,
 	apiCallConfig apicall.APICallConfiguration,
 ) engineapi.Engine {
 	configMapResolver := NewConfigMapResolver(ctx, logger, kubeClient, 15*time.Minute)
 	exceptionsSelector := NewExceptionSelector(ctx, logger, kyvernoClient, 15*time.Minute)
+	secretResolver := NewSecretResolver(logger, kubeClient)
+	imageAllowListResolver := NewImageAllowListResolver(ctx, logger, kyvernoClient, 15*time.Minute)
+	crdLister := NewCRDLister(ctx, logger, apiServerClient, 15*time.Minute)
+	if secretResolver != nil {
+		apiCallConfig = apiCallConfig.WithSecretResolver(secretResolver)
+	}
+	apiCallConfig = newServiceCallConfig(logger, apiCallConfig)
+	contextLoaderOptions := []factories.ContextLoaderFactoryOptions{
+		factories.WithAPICallConfig(apiCallConfig),
+		factories.WithCircuitBreaker(NewDependencyBreaker(logger)),
+	}
+	if cloudMetadataResolver := NewCloudMetadataResolver(logger, kubeClient); cloudMetadataResolver != nil {
+		contextLoaderOptions = append(contextLoaderOptions, factories.WithCloudMetadataResolver(cloudMetadataResolver))
+	}
 	logger = logger.WithName("engine")
 	logger.Info("setup engine...")
 	return engine.NewEngine(
@@ -48,9 +71,39 @@ func NewEngine(
 		adapters.Client(client),
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), secretLister),
 		ivCache,
-		factories.DefaultContextLoaderFactory(configMapResolver, factories.WithAPICallConfig(apiCallConfig)),
+		factories.DefaultContextLoaderFactory(configMapResolver, contextLoaderOptions...),
 		exceptionsSelector,
 		imageSignatureRepository,
+		secretResolver,
+		imageAllowListResolver,
+		crdLister,
+	)
+}
+
+// NewEvaluationEngine returns an engine for policies supplied by the callers of the evaluation server.
+// The engine has no access to the cluster, to secrets, to cloud metadata or to image registries: apiCall, configMap,
+// cloudMetadata and imageRegistry context entries are not loaded and image verification fails.
+func NewEvaluationEngine(
+	logger logr.Logger,
+	configuration config.Configuration,
+	metricsConfiguration config.MetricsConfiguration,
+	jp jmespath.Interface,
+	ivCache imageverifycache.Client,
+) engineapi.Engine {
+	logger.WithName("evaluation-engine").Info("setup evaluation engine...")
+	return engine.NewEngine(
+		configuration,
+		metricsConfiguration,
+		jp,
+		nil,
+		factories.DisabledRegistryClientFactory(),
+		ivCache,
+		factories.DefaultContextLoaderFactory(nil),
+		nil,
+		imageSignatureRepository,
+		nil,
+		nil,
+		nil,
 	)
 }
 
@@ -79,6 +132,48 @@ func NewExceptionSelector(
 	return exceptionsLister
 }
 
+func NewImageAllowListResolver(
+	ctx context.Context,
+	logger logr.Logger,
+	kyvernoClient versioned.Interface,
+	resyncPeriod time.Duration,
+) engineapi.ImageAllowListResolver {
+	if !enableImageAllowLists {
+		return nil
+	}
+	logger = logger.WithName("image-allow-list-resolver")
+	logger.Info("setup image allow list resolver...")
+	factory := kyvernoinformer.NewSharedInformerFactory(kyvernoClient, resyncPeriod)
+	lister := factory.Kyverno().V2alpha1().ImageAllowLists().Lister()
+	// start informers and wait for cache sync
+	if !StartInformersAndWaitForCacheSync(ctx, logger, factory) {
+		checkError(logger, errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+	}
+	return lister
+}
+
+// NewCRDLister returns a custom resource definition lister used to convert custom resources, nil when no
+// API server client is available
+func NewCRDLister(
+	ctx context.Context,
+	logger logr.Logger,
+	apiServerClient apiserver.Interface,
+	resyncPeriod time.Duration,
+) apiextensionsv1listers.CustomResourceDefinitionLister {
+	if apiServerClient == nil {
+		return nil
+	}
+	logger = logger.WithName("crd-lister")
+	logger.Info("setup custom resource definition lister...")
+	factory := apiextensionsinformers.NewSharedInformerFactory(apiServerClient, resyncPeriod)
+	lister := factory.Apiextensions().V1().CustomResourceDefinitions().Lister()
+	// start informers and wait for cache sync
+	if !StartInformersAndWaitForCacheSync(ctx, logger, factory) {
+		checkError(logger, errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+	}
+	return lister
+}
+
 func NewConfigMapResolver(
 	ctx context.Context,
 	logger logr.Logger,
@@ -104,3 +199,62 @@ func NewConfigMapResolver(
 	}
 	return configMapResolver
 }
+
+func NewCloudMetadataResolver(
+	logger logr.Logger,
+	kubeClient kubernetes.Interface,
+) engineapi.CloudMetadataResolver {
+	providers, err := cloudmetadata.ParseProviders(cloudMetadataProviders)
+	checkError(logger, err, "failed to parse cloud metadata providers")
+	if len(providers) == 0 {
+		return nil
+	}
+	logger.WithName("cloud-metadata-resolver").Info("setup cloud metadata resolver...", "providers", providers, "ttl", cloudMetadataTTL)
+	return cloudmetadata.NewResolver(kubeClient, cloudMetadataTTL, providers...)
+}
+
+// newServiceCallConfig applies the egress controls configured for apiCall service calls
+func newServiceCallConfig(logger logr.Logger, apiCallConfig apicall.APICallConfiguration) apicall.APICallConfiguration {
+	var hosts []string
+	for _, host := range strings.Split(serviceCallAllowedHosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	apiCallConfig = apiCallConfig.WithAllowedHosts(hosts...).WithTimeout(serviceCallTimeout)
+	if serviceCallCABundle != "" {
+		caBundle, err := os.ReadFile(serviceCallCABundle)
+		checkError(logger, err, "failed to read service call CA bundle")
+		apiCallConfig, err = apiCallConfig.WithCABundle(caBundle)
+		checkError(logger, err, "failed to load service call CA bundle")
+	}
+	logger.WithName("service-calls").Info("setup service calls...", "allowedHosts", hosts, "caBundle", serviceCallCABundle, "timeout", serviceCallTimeout)
+	return apiCallConfig
+}
+
+// NewDependencyBreaker returns the circuit breaker guarding the dependencies of context entries
+func NewDependencyBreaker(logger logr.Logger) *loaders.CircuitBreaker {
+	logger.WithName("dependency-breaker").Info("setup dependency circuit breaker...", "threshold", dependencyFailureThreshold, "cooldown", dependencyCooldown)
+	return loaders.NewCircuitBreaker(dependencyFailureThreshold, dependencyCooldown)
+}
+
+func NewSecretResolver(
+	logger logr.Logger,
+	kubeClient kubernetes.Interface,
+) engineapi.SecretResolver {
+	if !enableSecretReferences {
+		return nil
+	}
+	var vaultConfig *secrets.VaultConfig
+	if vaultAddress != "" {
+		vaultConfig = &secrets.VaultConfig{
+			Address:             vaultAddress,
+			KubernetesRole:      vaultKubernetesRole,
+			KubernetesMountPath: vaultKubernetesMountPath,
+		}
+	}
+	logger.WithName("secret-resolver").Info("setup secret resolver...", "vaultAddress", vaultAddress, "ttl", secretReferencesTTL)
+	secretResolver, err := secrets.NewResolver(kubeClient, vaultConfig, secretReferencesTTL)
+	checkError(logger, err, "failed to create secret resolver")
+	return secretResolver
+}
@@ -0,0 +1,14 @@
+package internal
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/fips"
+)
+
+func checkFIPS(logger logr.Logger) {
+	logger = logger.WithName("fips")
+	checkError(logger, fips.Check(fipsRequired), "failed to verify the crypto backend")
+	if fips.Enabled() {
+		logger.Info("FIPS mode enabled")
+	}
+}
@@ -5,8 +5,11 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/cloudmetadata"
+	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/secrets"
 	"github.com/kyverno/kyverno/pkg/toggle"
 	"github.com/sigstore/sigstore/pkg/tuf"
 )
@@ -29,6 +32,7 @@ var (
 	metricsPort          string
 	transportCreds       string
 	disableMetricsExport bool
+	namespacedMetrics    bool
 	// kubeconfig
 	kubeconfig           string
 	clientRateLimitQPS   float64
@@ -39,6 +43,24 @@ var (
 	enablePolicyException  bool
 	exceptionNamespace     string
 	enableConfigMapCaching bool
+	// cloud metadata
+	cloudMetadataProviders string
+	cloudMetadataTTL       time.Duration
+	// secret references
+	enableSecretReferences   bool
+	secretReferencesTTL      time.Duration
+	vaultAddress             string
+	vaultKubernetesRole      string
+	vaultKubernetesMountPath string
+	// service calls
+	serviceCallAllowedHosts string
+	serviceCallCABundle     string
+	serviceCallTimeout      time.Duration
+	// context loader dependencies
+	dependencyFailureThreshold int
+	dependencyCooldown         time.Duration
+	// image allow lists
+	enableImageAllowLists bool
 	// cosign
 	imageSignatureRepository string
 	enableTUF                bool
@@ -56,6 +78,8 @@ var (
 	imageVerifyCacheEnabled     bool
 	imageVerifyCacheTTLDuration time.Duration
 	imageVerifyCacheMaxSize     int64
+	// fips
+	fipsRequired bool
 )
 
 func initLoggingFlags() {
@@ -83,6 +107,7 @@ func initMetricsFlags() {
 	flag.StringVar(&transportCreds, "transportCreds", "", "Set this flag to the CA secret containing the certificate which is used by our Opentelemetry Metrics Client. If empty string is set, means an insecure connection will be used")
 	flag.StringVar(&metricsPort, "metricsPort", "8000", "Expose prometheus metrics at the given port, default to 8000.")
 	flag.BoolVar(&disableMetricsExport, "disableMetrics", false, "Set this flag to 'true' to disable metrics.")
+	flag.BoolVar(&namespacedMetrics, "namespacedMetrics", false, "Set this flag to 'true' to expose the metrics of the namespaces a caller can list policy reports in at /namespaced-metrics.")
 }
 
 func initKubeconfigFlags(qps float64, burst int, eventsQPS float64, eventsBurst int) {
@@ -102,6 +127,27 @@ func initConfigMapCachingFlags() {
 	flag.BoolVar(&enableConfigMapCaching, "enableConfigMapCaching", true, "Enable config maps caching.")
 }
 
+func initCloudMetadataFlags() {
+	flag.StringVar(&cloudMetadataProviders, "cloudMetadataProviders", "", "Cloud providers (AWS,GCP,Azure) whose instance metadata can be used in cloudMetadata context entries. No provider is enabled when this flag is empty.")
+	flag.DurationVar(&cloudMetadataTTL, "cloudMetadataTTL", cloudmetadata.DefaultTTL, "Duration cloud metadata documents are cached for.")
+}
+
+func initSecretReferencesFlags() {
+	flag.BoolVar(&enableSecretReferences, "enableSecretReferences", false, "Enable resolving secret references (vault:// and k8s://) in image verification keys and service call headers.")
+	flag.DurationVar(&secretReferencesTTL, "secretReferencesTTL", secrets.DefaultTTL, "Duration resolved secret references are cached for before being fetched again.")
+	flag.StringVar(&vaultAddress, "vaultAddress", "", "Address of the HashiCorp Vault server used to resolve vault:// references. Vault references are disabled when this flag is empty.")
+	flag.StringVar(&vaultKubernetesRole, "vaultKubernetesRole", "", "Role used to login to Vault with the Kubernetes auth method. The VAULT_TOKEN environment variable is used when this flag is empty.")
+	flag.StringVar(&vaultKubernetesMountPath, "vaultKubernetesMountPath", "kubernetes", "Mount path of the Vault Kubernetes auth method.")
+}
+
+func initServiceCallFlags() {
+	flag.StringVar(&serviceCallAllowedHosts, "serviceCallAllowedHosts", "", "Comma separated list of hosts apiCall service URLs are allowed to reach, wildcards are supported (e.g. '*.svc,cmdb.example.com'). Only in-cluster services (*.svc) can be called when this flag is empty.")
+	flag.StringVar(&serviceCallCABundle, "serviceCallCABundle", "", "Path to a PEM encoded CA bundle trusted, in addition to the system roots, by the apiCall service calls declaring no caBundle.")
+	flag.DurationVar(&serviceCallTimeout, "serviceCallTimeout", 0, "Maximum duration of an apiCall service call. Service calls are only bounded by the request deadline when zero.")
+	flag.IntVar(&dependencyFailureThreshold, "dependencyFailureThreshold", loaders.DefaultBreakerThreshold, "Number of consecutive failures after which context entries stop calling a service, registry, cloud provider or Kubernetes API for the cooldown duration. Failing dependencies are always called when zero.")
+	flag.DurationVar(&dependencyCooldown, "dependencyCooldown", loaders.DefaultBreakerCooldown, "Duration during which context entries stop calling a dependency that reached the failure threshold.")
+}
+
 func initDeferredLoadingFlags() {
 	flag.Func(toggle.EnableDeferredLoadingFlagName, toggle.EnableDeferredLoadingDescription, toggle.EnableDeferredLoading.Parse)
 }
@@ -163,6 +209,14 @@ func WithDefaultBurst(burst int) Option {
 	}
 }
 
+func initImageAllowListsFlags() {
+	flag.BoolVar(&enableImageAllowLists, "enableImageAllowLists", false, "Enable validating container images against ImageAllowList resources.")
+}
+
+func initFIPSFlags() {
+	flag.BoolVar(&fipsRequired, "fipsRequired", false, "Exit on startup if the binary was not built with a FIPS validated crypto backend.")
+}
+
 func initFlags(config Configuration, opts ...Option) {
 	options := newOptions()
 	for _, o := range opts {
@@ -196,6 +250,22 @@ func initFlags(config Configuration, opts ...Option) {
 	if config.UsesConfigMapCaching() {
 		initConfigMapCachingFlags()
 	}
+	// cloud metadata
+	if config.UsesCloudMetadata() {
+		initCloudMetadataFlags()
+	}
+	// secret references
+	if config.UsesSecretReferences() {
+		initSecretReferencesFlags()
+	}
+	// service calls
+	if config.UsesServiceCalls() {
+		initServiceCallFlags()
+	}
+	// image allow lists
+	if config.UsesImageAllowLists() {
+		initImageAllowListsFlags()
+	}
 	// deferred loading
 	if config.UsesDeferredLoading() {
 		initDeferredLoadingFlags()
@@ -219,6 +289,8 @@ func initFlags(config Configuration, opts ...Option) {
 
 	initCleanupFlags()
 
+	initFIPSFlags()
+
 	for _, flagset := range config.FlagSets() {
 		flagset.VisitAll(func(f *flag.Flag) {
 			flag.CommandLine.Var(f.Value, f.Name, f.Usage)
@@ -6,14 +6,16 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/auth"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
 	otlp "go.opentelemetry.io/otel"
 	"k8s.io/client-go/kubernetes"
 )
 
-func SetupMetrics(ctx context.Context, logger logr.Logger, metricsConfiguration config.MetricsConfiguration, kubeClient kubernetes.Interface) (metrics.MetricsConfigManager, context.CancelFunc) {
+func SetupMetrics(ctx context.Context, logger logr.Logger, metricsConfiguration config.MetricsConfiguration, kubeClient kubernetes.Interface, tokenReviewer auth.TokenReviewer) (metrics.MetricsConfigManager, context.CancelFunc) {
 	logger = logger.WithName("metrics")
 	logger.Info("setup metrics...", "otel", otel, "port", metricsPort, "collector", otelCollector, "creds", transportCreds)
 	metricsAddr := ":" + metricsPort
@@ -40,6 +42,9 @@ func SetupMetrics(ctx context.Context, logger logr.Logger, metricsConfiguration
 		}
 	}
 	if otel == "prometheus" {
+		if namespacedMetrics && metricsServerMux != nil {
+			metricsServerMux.Handle(config.NamespacedMetricsPath, metrics.NamespacedHandler(prometheus.DefaultGatherer, metrics.NewNamespaceAuthorizer(tokenReviewer)))
+		}
 		go func() {
 			server := &http.Server{
 				Addr:              metricsAddr,
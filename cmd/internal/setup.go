@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/auth"
 	apiserverclient "github.com/kyverno/kyverno/pkg/clients/apiserver"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	dynamicclient "github.com/kyverno/kyverno/pkg/clients/dynamic"
@@ -48,6 +49,7 @@ type SetupResult struct {
 	MetadataClient         metadataclient.UpstreamInterface
 	KyvernoDynamicClient   dclient.Interface
 	EventsClient           eventsv1.EventsV1Interface
+	TokenReviewer          auth.TokenReviewer
 }
 
 func Setup(config Configuration, name string, skipResourceFilters bool) (context.Context, SetupResult, context.CancelFunc) {
@@ -56,14 +58,18 @@ func Setup(config Configuration, name string, skipResourceFilters bool) (context
 	printFlagSettings(logger)
 	showWarnings(config, logger)
 	check(logger)
+	checkFIPS(logger)
 	sdownMaxProcs := setupMaxProcs(logger)
 	setupProfiling(logger)
 	ctx, sdownSignals := setupSignals(logger)
 	client := kubeclient.From(createKubernetesClient(logger, clientRateLimitQPS, clientRateLimitBurst), kubeclient.WithTracing())
-	metricsConfiguration := startMetricsConfigController(ctx, logger, client)
-	metricsManager, sdownMetrics := SetupMetrics(ctx, logger, metricsConfiguration, client)
+	configRecorder := createConfigEventRecorder(ctx, logger, client)
+	metricsConfiguration := startMetricsConfigController(ctx, logger, client, configRecorder)
+	// the token reviewer is shared by the servers authenticating bearer tokens
+	tokenReviewer := auth.NewTokenReviewer(client, auth.DefaultTokenReviewTTL)
+	metricsManager, sdownMetrics := SetupMetrics(ctx, logger, metricsConfiguration, client, tokenReviewer)
 	client = client.WithMetrics(metricsManager, metrics.KubeClient)
-	configuration := startConfigController(ctx, logger, client, skipResourceFilters)
+	configuration := startConfigController(ctx, logger, client, configRecorder, skipResourceFilters)
 	sdownTracing := SetupTracing(logger, name, client)
 	var registryClient registryclient.Client
 	var registrySecretLister corev1listers.SecretNamespaceLister
@@ -123,6 +129,7 @@ func Setup(config Configuration, name string, skipResourceFilters bool) (context
 			MetadataClient:         metadataClient,
 			KyvernoDynamicClient:   dClient,
 			EventsClient:           eventsClient,
+			TokenReviewer:          tokenReviewer,
 		},
 		shutdown(logger.WithName("shutdown"), sdownMaxProcs, sdownMetrics, sdownTracing, sdownSignals)
 }
@@ -1,10 +1,12 @@
 /*
 Cleans up stale webhookconfigurations created by kyverno that were not cleanedup
+and migrates the stored policies using deprecated fields
 */
 package main
 
 import (
 	"context"
+	"flag"
 	"os"
 	"sync"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/policy/migration"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	coordinationv1 "k8s.io/api/coordination/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -26,12 +29,20 @@ const (
 )
 
 func main() {
+	var (
+		migratePolicies bool
+		migrationDryRun bool
+	)
+	flagset := flag.NewFlagSet("kyverno-init", flag.ExitOnError)
+	flagset.BoolVar(&migratePolicies, "migratePolicies", true, "Rewrite the stored policies using deprecated fields to their current shape.")
+	flagset.BoolVar(&migrationDryRun, "migrationDryRun", false, "Only report the stored policies that need to be migrated, without updating them.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithKubeconfig(),
 		internal.WithKyvernoClient(),
 		internal.WithDynamicClient(),
 		internal.WithKyvernoDynamicClient(),
+		internal.WithFlagSets(flagset),
 	)
 	// parse flags
 	internal.ParseFlags(appConfig)
@@ -79,6 +90,15 @@ func main() {
 				logging.Error(err, "failed to cleanup resource")
 			}
 		}
+		// migrate policies stored with deprecated fields, a policy failing to migrate is reported
+		// but does not prevent kyverno from starting as deprecated fields are still supported
+		if migratePolicies {
+			results, err := migration.Run(ctx, logging.WithName("migration"), setup.KyvernoClient, migrationDryRun, migration.Migrations...)
+			if err != nil {
+				logging.Error(err, "failed to list policies to migrate")
+			}
+			logging.V(2).Info("policies migration done", "policies", len(results), "dryRun", migrationDryRun)
+		}
 		// if there is any failure then we fail process
 		if failure {
 			logging.V(2).Info("failed to cleanup prior configurations")
@@ -22,10 +22,13 @@ import (
 	genericwebhookcontroller "github.com/kyverno/kyverno/pkg/controllers/generic/webhook"
 	policymetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/policy"
 	policycachecontroller "github.com/kyverno/kyverno/pkg/controllers/policycache"
+	replaycontroller "github.com/kyverno/kyverno/pkg/controllers/replay"
 	vapcontroller "github.com/kyverno/kyverno/pkg/controllers/validatingadmissionpolicy-generate"
 	webhookcontroller "github.com/kyverno/kyverno/pkg/controllers/webhook"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/evaluation"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/informers"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
@@ -36,6 +39,7 @@ import (
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	runtimeutils "github.com/kyverno/kyverno/pkg/utils/runtime"
 	"github.com/kyverno/kyverno/pkg/validation/exception"
+	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
 	"github.com/kyverno/kyverno/pkg/webhooks"
 	webhooksexception "github.com/kyverno/kyverno/pkg/webhooks/exception"
 	webhookspolicy "github.com/kyverno/kyverno/pkg/webhooks/policy"
@@ -120,6 +124,11 @@ func createrLeaderControllers(
 	webhookServerPort int32,
 	configuration config.Configuration,
 	eventGenerator event.Interface,
+	engine engineapi.Engine,
+	jp jmespath.Interface,
+	replayMissedRequests bool,
+	replayMaxWindow time.Duration,
+	excludedNamespaces []string,
 ) ([]internal.Controller, func(context.Context) error, error) {
 	var leaderControllers []internal.Controller
 
@@ -153,6 +162,7 @@ func createrLeaderControllers(
 		runtime,
 		configuration,
 		caSecretName,
+		excludedNamespaces,
 	)
 	exceptionWebhookController := genericwebhookcontroller.NewController(
 		exceptionWebhookControllerName,
@@ -199,9 +209,33 @@ func createrLeaderControllers(
 		)
 		leaderControllers = append(leaderControllers, internal.NewController(vapcontroller.ControllerName, vapController, vapcontroller.Workers))
 	}
+	if replayMissedRequests {
+		replayController := replaycontroller.NewController(
+			dynamicClient,
+			kyvernoClient,
+			kubeClient.CoordinationV1().Leases(config.KyvernoNamespace()),
+			kyvernoInformer.Kyverno().V1().Policies(),
+			kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+			kubeInformer.Core().V1().Namespaces(),
+			engine,
+			configuration,
+			jp,
+			admissionReports,
+			replayMaxWindow,
+		)
+		leaderControllers = append(leaderControllers, internal.NewController(replaycontroller.ControllerName, replayController, replaycontroller.Workers))
+	}
 	return leaderControllers, nil, nil
 }
 
+// latencyBudget returns the admission latency budget, derived from the webhook timeout when not set
+func latencyBudget(budget time.Duration, webhookTimeout int) time.Duration {
+	if budget != 0 {
+		return budget
+	}
+	return time.Duration(webhookTimeout) * time.Second * 8 / 10
+}
+
 func main() {
 	var (
 		// TODO: this has been added to backward support command line arguments
@@ -216,9 +250,28 @@ func main() {
 		dumpPayload                  bool
 		servicePort                  int
 		webhookServerPort            int
+		webhookServerReadTimeout     time.Duration
+		webhookServerWriteTimeout    time.Duration
+		webhookServerIdleTimeout     time.Duration
+		webhookServerMaxStreams      uint
+		webhookServerDisableHTTP2    bool
+		tlsMinVersion                string
+		tlsCipherSuites              string
 		backgroundServiceAccountName string
 		maxAPICallResponseLength     int64
 		renewBefore                  time.Duration
+		allowedVariablePrefixes      string
+		highChurnKindsAction         string
+		webhookExcludedNamespaces    string
+		replayMissedRequests         bool
+		replayMaxWindow              time.Duration
+		admissionLatencyBudget       time.Duration
+		maxAdmissionObjectSize       int64
+		evaluationServerAddress      string
+		admissionRecorderSize        int
+		exceptionRequireApproval     bool
+		exceptionMaxDuration         time.Duration
+		exceptionRestrictScope       bool
 	)
 	flagset := flag.NewFlagSet("kyverno", flag.ExitOnError)
 	flagset.BoolVar(&dumpPayload, "dumpPayload", false, "Set this flag to activate/deactivate debug mode.")
@@ -231,14 +284,34 @@ func main() {
 	flagset.Func(toggle.ProtectManagedResourcesFlagName, toggle.ProtectManagedResourcesDescription, toggle.ProtectManagedResources.Parse)
 	flagset.Func(toggle.ForceFailurePolicyIgnoreFlagName, toggle.ForceFailurePolicyIgnoreDescription, toggle.ForceFailurePolicyIgnore.Parse)
 	flagset.Func(toggle.GenerateValidatingAdmissionPolicyFlagName, toggle.GenerateValidatingAdmissionPolicyDescription, toggle.GenerateValidatingAdmissionPolicy.Parse)
+	flagset.Func(toggle.EnableRegoRulesFlagName, toggle.EnableRegoRulesDescription, toggle.EnableRegoRules.Parse)
 	flagset.BoolVar(&admissionReports, "admissionReports", true, "Enable or disable admission reports.")
 	flagset.IntVar(&servicePort, "servicePort", 443, "Port used by the Kyverno Service resource and for webhook configurations.")
 	flagset.IntVar(&webhookServerPort, "webhookServerPort", 9443, "Port used by the webhook server.")
+	flagset.DurationVar(&webhookServerReadTimeout, "webhookServerReadTimeout", 30*time.Second, "Maximum duration for reading an entire request, including its headers, in the webhook server.")
+	flagset.DurationVar(&webhookServerWriteTimeout, "webhookServerWriteTimeout", 30*time.Second, "Maximum duration before timing out writes of a response in the webhook server.")
+	flagset.DurationVar(&webhookServerIdleTimeout, "webhookServerIdleTimeout", 5*time.Minute, "Maximum duration a keep-alive connection to the webhook server is kept idle.")
+	flagset.UintVar(&webhookServerMaxStreams, "webhookServerMaxConcurrentStreams", 0, "Maximum number of concurrent HTTP/2 streams per connection to the webhook server, the Go default (250) is used when 0.")
+	flagset.BoolVar(&webhookServerDisableHTTP2, "webhookServerDisableHTTP2", false, "Restrict the webhook server to HTTP/1.1.")
+	flagset.StringVar(&tlsMinVersion, "tlsMinVersion", "VersionTLS12", "Minimum TLS version accepted by the webhook server, one of VersionTLS12 or VersionTLS13.")
+	flagset.StringVar(&tlsCipherSuites, "tlsCipherSuites", "", "Comma separated list of TLS 1.2 cipher suites accepted by the webhook server, e.g. --tlsCipherSuites=TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384, the ECDHE AEAD cipher suites are used when empty.")
 	flagset.StringVar(&backgroundServiceAccountName, "backgroundServiceAccountName", "", "Background service account name.")
 	flagset.StringVar(&caSecretName, "caSecretName", "", "Name of the secret containing CA.")
 	flagset.StringVar(&tlsSecretName, "tlsSecretName", "", "Name of the secret containing TLS pair.")
 	flagset.Int64Var(&maxAPICallResponseLength, "maxAPICallResponseLength", 10*1000*1000, "Configure the value of maximum allowed GET response size from API Calls")
 	flagset.DurationVar(&renewBefore, "renewBefore", 15*24*time.Hour, "The certificate renewal time before expiration")
+	flagset.DurationVar(&admissionLatencyBudget, "admissionLatencyBudget", 0, "Maximum time spent on an admission request before remaining audit rules are skipped, defaults to 80% of the webhook timeout, a negative value disables the budget.")
+	flagset.Int64Var(&maxAdmissionObjectSize, "maxAdmissionObjectSize", 0, "Maximum size in bytes of admission objects evaluated by audit rules, audit rules are skipped and reported as such for larger objects, 0 disables the limit.")
+	flagset.StringVar(&evaluationServerAddress, "evaluationServerAddress", "", "Address of the gRPC policy evaluation server, e.g. :9444, the server is disabled when empty. Callers must send a bearer token allowed to evaluate evaluations.kyverno.io.")
+	flagset.BoolVar(&exceptionRequireApproval, "exceptionRequireApproval", false, "Reject PolicyExceptions without the exceptions.kyverno.io/approved-by annotation.")
+	flagset.DurationVar(&exceptionMaxDuration, "exceptionMaxDuration", 0, "Maximum lifetime of PolicyExceptions, exceptions must set an expiration within this duration when set.")
+	flagset.BoolVar(&exceptionRestrictScope, "exceptionRestrictScope", false, "Reject PolicyExceptions using wildcard rule names or not scoped to namespaces or resource names.")
+	flagset.IntVar(&admissionRecorderSize, "admissionRecorderSize", 0, "Number of recent anonymized admission requests kept for replay through the evaluation server, recording is disabled when 0 or when the evaluation server is disabled. Callers must be allowed to replay evaluations.kyverno.io.")
+	flagset.StringVar(&allowedVariablePrefixes, "allowedVariablePrefixes", "", "Comma separated list of additional variable prefixes accepted when validating policies, e.g. --allowedVariablePrefixes=custom.,extra.")
+	flagset.BoolVar(&replayMissedRequests, "replayMissedRequests", false, "Evaluate resources created or updated while the webhooks were down against audit validate and generate policies on startup.")
+	flagset.DurationVar(&replayMaxWindow, "replayMaxWindow", replaycontroller.MaxWindow, "Maximum duration of the webhooks downtime window replayed on startup.")
+	flagset.StringVar(&webhookExcludedNamespaces, "webhookExcludedNamespaces", "", "Comma separated list of namespaces excluded from the resource webhooks, e.g. --webhookExcludedNamespaces=kube-system,kube-node-lease. The Kyverno namespace is always excluded unless protectManagedResources is enabled.")
+	flagset.StringVar(&highChurnKindsAction, "highChurnKindsAction", "warn", "Action taken when a policy matches high churn kinds (Events, Leases, EndpointSlices) through wildcards without listing them explicitly, one of warn, deny or ignore.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
@@ -247,6 +320,10 @@ func main() {
 		internal.WithKubeconfig(),
 		internal.WithPolicyExceptions(),
 		internal.WithConfigMapCaching(),
+		internal.WithCloudMetadata(),
+		internal.WithSecretReferences(),
+		internal.WithServiceCalls(),
+		internal.WithImageAllowLists(),
 		internal.WithDeferredLoading(),
 		internal.WithCosign(),
 		internal.WithRegistryClient(),
@@ -315,6 +392,28 @@ func main() {
 		caSecretName,
 		tlsSecretName,
 	)
+	if allowedVariablePrefixes != "" {
+		policyvalidation.SetAllowedVariablePrefixes(strings.Split(allowedVariablePrefixes, ",")...)
+	}
+	if err := policyvalidation.SetHighChurnKindsAction(highChurnKindsAction); err != nil {
+		setup.Logger.Error(err, "failed to configure high churn kinds action")
+		os.Exit(1)
+	}
+	excludedNamespaces, err := config.ParseNamespaces(webhookExcludedNamespaces)
+	if err != nil {
+		setup.Logger.Error(err, "failed to configure webhook excluded namespaces")
+		os.Exit(1)
+	}
+	serverTLSMinVersion, err := tls.ParseVersion(tlsMinVersion)
+	if err != nil {
+		setup.Logger.Error(err, "failed to configure webhook server TLS version")
+		os.Exit(1)
+	}
+	serverTLSCipherSuites, err := tls.ParseCipherSuites(tlsCipherSuites)
+	if err != nil {
+		setup.Logger.Error(err, "failed to configure webhook server TLS cipher suites")
+		os.Exit(1)
+	}
 	policyCache := policycache.NewCache()
 	omitEventsValues := strings.Split(omitEvents, ",")
 	if omitEvents == "" {
@@ -364,6 +463,7 @@ func main() {
 		setup.KubeClient,
 		setup.KyvernoClient,
 		setup.RegistrySecretLister,
+		setup.ApiServerClient,
 		apicall.NewAPICallConfiguration(maxAPICallResponseLength),
 	)
 	// create non leader controllers
@@ -425,6 +525,11 @@ func main() {
 				int32(webhookServerPort),
 				setup.Configuration,
 				eventGenerator,
+				engine,
+				setup.Jp,
+				replayMissedRequests,
+				replayMaxWindow,
+				excludedNamespaces,
 			)
 			if err != nil {
 				logger.Error(err, "failed to create leader controllers")
@@ -493,11 +598,28 @@ func main() {
 		admissionReports,
 		backgroundServiceAccountName,
 		setup.Jp,
+		latencyBudget(admissionLatencyBudget, webhookTimeout),
+		maxAdmissionObjectSize,
 	)
 	exceptionHandlers := webhooksexception.NewHandlers(exception.ValidationOptions{
-		Enabled:   internal.PolicyExceptionEnabled(),
-		Namespace: internal.ExceptionNamespace(),
+		Enabled:         internal.PolicyExceptionEnabled(),
+		Namespace:       internal.ExceptionNamespace(),
+		RequireApproval: exceptionRequireApproval,
+		MaxDuration:     exceptionMaxDuration,
+		RestrictScope:   exceptionRestrictScope,
 	})
+	tlsProvider := func() ([]byte, []byte, error) {
+		secret, err := tlsSecret.Lister().Secrets(config.KyvernoNamespace()).Get(tlsSecretName)
+		if err != nil {
+			return nil, nil, err
+		}
+		return secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], nil
+	}
+	// admission requests are only recorded when explicitly enabled and replayable through the evaluation server
+	var recorder evaluation.Recorder
+	if admissionRecorderSize > 0 && evaluationServerAddress != "" {
+		recorder = evaluation.NewRecorder(setup.Logger.WithName("recorder"), admissionRecorderSize, kubeInformer.Core().V1().Namespaces().Lister())
+	}
 	server := webhooks.NewServer(
 		signalCtx,
 		policyHandlers,
@@ -507,14 +629,19 @@ func main() {
 		setup.MetricsManager,
 		webhooks.DebugModeOptions{
 			DumpPayload: dumpPayload,
+			Recorder:    recorder,
 		},
-		func() ([]byte, []byte, error) {
-			secret, err := tlsSecret.Lister().Secrets(config.KyvernoNamespace()).Get(tlsSecretName)
-			if err != nil {
-				return nil, nil, err
-			}
-			return secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], nil
+		webhooks.ServerOptions{
+			ReadTimeout:          webhookServerReadTimeout,
+			WriteTimeout:         webhookServerWriteTimeout,
+			ReadHeaderTimeout:    webhookServerReadTimeout,
+			IdleTimeout:          webhookServerIdleTimeout,
+			MaxConcurrentStreams: uint32(webhookServerMaxStreams),
+			DisableHTTP2:         webhookServerDisableHTTP2,
+			TLSMinVersion:        serverTLSMinVersion,
+			TLSCipherSuites:      serverTLSCipherSuites,
 		},
+		tlsProvider,
 		setup.KubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations(),
 		setup.KubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations(),
 		setup.KubeClient.CoordinationV1().Leases(config.KyvernoNamespace()),
@@ -530,6 +657,32 @@ func main() {
 		setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
 		os.Exit(1)
 	}
+	// start evaluation server
+	if evaluationServerAddress != "" {
+		evaluationEngine := internal.NewEvaluationEngine(
+			setup.Logger,
+			setup.Configuration,
+			setup.MetricsConfiguration,
+			setup.Jp,
+			setup.ImageVerifyCacheClient,
+		)
+		evaluationServer := evaluation.NewEvaluationServer(evaluationEngine, setup.Jp, setup.Configuration, recorder)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger := setup.Logger.WithName("evaluation")
+			if err := evaluation.Serve(
+				signalCtx,
+				logger,
+				evaluationServerAddress,
+				evaluationServer,
+				evaluation.TLSCredentials(tlsProvider),
+				evaluation.WithAuthorizer(evaluation.NewAuthorizer(setup.TokenReviewer)),
+			); err != nil {
+				logger.Error(err, "failed to run evaluation server")
+			}
+		}()
+	}
 	// start webhooks server
 	server.Run(signalCtx.Done())
 	wg.Wait()
@@ -12,11 +12,14 @@ import (
 	"github.com/kyverno/kyverno/cmd/internal"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	kyvernov2beta1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2beta1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	admissionreportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/admission"
 	aggregatereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/aggregate/resource"
+	auditlogcontroller "github.com/kyverno/kyverno/pkg/controllers/report/auditlog"
 	backgroundscancontroller "github.com/kyverno/kyverno/pkg/controllers/report/background"
+	compliancesummarycontroller "github.com/kyverno/kyverno/pkg/controllers/report/compliancesummary"
 	resourcereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/resource"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
@@ -24,6 +27,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/toggle"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	kubeinformers "k8s.io/client-go/informers"
 	admissionregistrationv1alpha1informers "k8s.io/client-go/informers/admissionregistration/v1alpha1"
@@ -43,13 +47,18 @@ func createReportControllers(
 	policyReports bool,
 	validatingAdmissionPolicyReports bool,
 	reportsChunkSize int,
+	reportsMergeWindow time.Duration,
+	reportsFlushInterval time.Duration,
 	backgroundScanWorkers int,
+	complianceSummary bool,
+	complianceSummaryInterval time.Duration,
 	client dclient.Interface,
 	kyvernoClient versioned.Interface,
 	metadataFactory metadatainformers.SharedInformerFactory,
 	kubeInformer kubeinformers.SharedInformerFactory,
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
 	backgroundScanInterval time.Duration,
+	backgroundScanCache bool,
 	configuration config.Configuration,
 	jp jmespath.Interface,
 	eventGenerator event.Interface,
@@ -89,6 +98,8 @@ func createReportControllers(
 					vapInformer,
 					resourceReportController,
 					reportsChunkSize,
+					reportsMergeWindow,
+					reportsFlushInterval,
 				),
 				aggregatereportcontroller.Workers,
 			))
@@ -105,6 +116,10 @@ func createReportControllers(
 			))
 		}
 		if backgroundScan {
+			var polexInformer kyvernov2beta1informers.PolicyExceptionInformer
+			if internal.PolicyExceptionEnabled() {
+				polexInformer = kyvernoInformer.Kyverno().V2beta1().PolicyExceptions()
+			}
 			backgroundScanController := backgroundscancontroller.NewController(
 				client,
 				kyvernoClient,
@@ -114,8 +129,10 @@ func createReportControllers(
 				kyvernoV1.ClusterPolicies(),
 				vapInformer,
 				kubeInformer.Core().V1().Namespaces(),
+				polexInformer,
 				resourceReportController,
 				backgroundScanInterval,
+				backgroundScanCache,
 				configuration,
 				jp,
 				eventGenerator,
@@ -128,6 +145,13 @@ func createReportControllers(
 			)
 		}
 	}
+	if complianceSummary {
+		ctrls = append(ctrls, internal.NewController(
+			compliancesummarycontroller.ControllerName,
+			compliancesummarycontroller.NewController(kyvernoClient, complianceSummaryInterval),
+			compliancesummarycontroller.Workers,
+		))
+	}
 	return ctrls, func(ctx context.Context) error {
 		for _, warmup := range warmups {
 			if err := warmup(ctx); err != nil {
@@ -146,7 +170,11 @@ func createrLeaderControllers(
 	policyReports bool,
 	validatingAdmissionPolicyReports bool,
 	reportsChunkSize int,
+	reportsMergeWindow time.Duration,
+	reportsFlushInterval time.Duration,
 	backgroundScanWorkers int,
+	complianceSummary bool,
+	complianceSummaryInterval time.Duration,
 	kubeInformer kubeinformers.SharedInformerFactory,
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
 	metadataInformer metadatainformers.SharedInformerFactory,
@@ -156,6 +184,7 @@ func createrLeaderControllers(
 	jp jmespath.Interface,
 	eventGenerator event.Interface,
 	backgroundScanInterval time.Duration,
+	backgroundScanCache bool,
 ) ([]internal.Controller, func(context.Context) error, error) {
 	reportControllers, warmup := createReportControllers(
 		eng,
@@ -165,13 +194,18 @@ func createrLeaderControllers(
 		policyReports,
 		validatingAdmissionPolicyReports,
 		reportsChunkSize,
+		reportsMergeWindow,
+		reportsFlushInterval,
 		backgroundScanWorkers,
+		complianceSummary,
+		complianceSummaryInterval,
 		dynamicClient,
 		kyvernoClient,
 		metadataInformer,
 		kubeInformer,
 		kyvernoInformer,
 		backgroundScanInterval,
+		backgroundScanCache,
 		configuration,
 		jp,
 		eventGenerator,
@@ -187,12 +221,20 @@ func main() {
 		policyReports                    bool
 		validatingAdmissionPolicyReports bool
 		reportsChunkSize                 int
+		reportsMergeWindow               time.Duration
+		reportsFlushInterval             time.Duration
 		backgroundScanWorkers            int
 		backgroundScanInterval           time.Duration
+		backgroundScanCache              bool
+		complianceSummary                bool
+		complianceSummaryInterval        time.Duration
 		maxQueuedEvents                  int
 		omitEvents                       string
 		skipResourceFilters              bool
 		maxAPICallResponseLength         int64
+		auditLogAddress                  string
+		auditLogCertFile                 string
+		auditLogKeyFile                  string
 	)
 	flagset := flag.NewFlagSet("reports-controller", flag.ExitOnError)
 	flagset.BoolVar(&backgroundScan, "backgroundScan", true, "Enable or disable background scan.")
@@ -200,13 +242,22 @@ func main() {
 	flagset.BoolVar(&aggregateReports, "aggregateReports", true, "Enable or disable aggregated policy reports.")
 	flagset.BoolVar(&policyReports, "policyReports", true, "Enable or disable policy reports.")
 	flagset.BoolVar(&validatingAdmissionPolicyReports, "validatingAdmissionPolicyReports", false, "Enable or disable validating admission policy reports.")
+	flagset.Func(toggle.EnableRegoRulesFlagName, toggle.EnableRegoRulesDescription, toggle.EnableRegoRules.Parse)
 	flagset.IntVar(&reportsChunkSize, "reportsChunkSize", 1000, "Max number of results in generated reports, reports will be split accordingly if there are more results to be stored.")
+	flagset.DurationVar(&reportsMergeWindow, "reportsMergeWindow", aggregatereportcontroller.MergeWindow, "Delay during which changes of the reports of a namespace are merged before being written.")
+	flagset.DurationVar(&reportsFlushInterval, "reportsFlushInterval", aggregatereportcontroller.FlushInterval, "Minimum delay between two writes of the policy reports of a namespace.")
 	flagset.IntVar(&backgroundScanWorkers, "backgroundScanWorkers", backgroundscancontroller.Workers, "Configure the number of background scan workers.")
 	flagset.DurationVar(&backgroundScanInterval, "backgroundScanInterval", time.Hour, "Configure background scan interval.")
+	flagset.BoolVar(&backgroundScanCache, "backgroundScanCache", true, "Skip re-evaluating resources at every background scan interval when neither the resource, its namespace labels, the policies nor the policy exceptions changed since their last scan. Policies loading external data are always re-evaluated.")
+	flagset.BoolVar(&complianceSummary, "complianceSummary", false, "Enable or disable the cluster wide policy compliance summary.")
+	flagset.DurationVar(&complianceSummaryInterval, "complianceSummaryInterval", compliancesummarycontroller.RefreshInterval, "Configure the policy compliance summary refresh interval.")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
 	flagset.StringVar(&omitEvents, "omit-events", "", "Set this flag to a comma separated list of PolicyViolation, PolicyApplied, PolicyError, PolicySkipped to disable events, e.g. --omit-events=PolicyApplied,PolicyViolation")
 	flagset.BoolVar(&skipResourceFilters, "skipResourceFilters", true, "If true, resource filters wont be considered.")
 	flagset.Int64Var(&maxAPICallResponseLength, "maxAPICallResponseLength", 2*1000*1000, "Maximum allowed response size from API Calls. A value of 0 bypasses checks (not recommended).")
+	flagset.StringVar(&auditLogAddress, "auditLogAddress", "", "Address receiving events from the API server audit webhook backend to report on resources that bypassed admission, e.g. :9445, disabled when empty.")
+	flagset.StringVar(&auditLogCertFile, "auditLogCertFile", "", "Path to the TLS certificate of the audit log server, the server uses plain HTTP when not set.")
+	flagset.StringVar(&auditLogKeyFile, "auditLogKeyFile", "", "Path to the TLS private key of the audit log server.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
@@ -215,6 +266,10 @@ func main() {
 		internal.WithKubeconfig(),
 		internal.WithPolicyExceptions(),
 		internal.WithConfigMapCaching(),
+		internal.WithCloudMetadata(),
+		internal.WithSecretReferences(),
+		internal.WithServiceCalls(),
+		internal.WithImageAllowLists(),
 		internal.WithDeferredLoading(),
 		internal.WithCosign(),
 		internal.WithRegistryClient(),
@@ -224,6 +279,7 @@ func main() {
 		internal.WithDynamicClient(),
 		internal.WithMetadataClient(),
 		internal.WithKyvernoDynamicClient(),
+		internal.WithApiServerClient(),
 		internal.WithEventsClient(),
 		internal.WithFlagSets(flagset),
 	)
@@ -272,16 +328,42 @@ func main() {
 		setup.KubeClient,
 		setup.KyvernoClient,
 		setup.RegistrySecretLister,
+		setup.ApiServerClient,
 		apicall.NewAPICallConfiguration(maxAPICallResponseLength),
 	)
+	// audit log controller runs on every replica as the API server can send events to any of them
+	kubeInformer := kubeinformers.NewSharedInformerFactory(setup.KubeClient, resyncPeriod)
+	var auditLogController internal.Controller
+	if auditLogAddress != "" {
+		auditLogController = internal.NewController(
+			auditlogcontroller.ControllerName,
+			auditlogcontroller.NewController(
+				setup.KyvernoClient,
+				kyvernoInformer.Kyverno().V1().Policies(),
+				kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+				kubeInformer.Core().V1().Namespaces(),
+				engine,
+				setup.Configuration,
+				setup.Jp,
+				auditLogAddress,
+				auditLogCertFile,
+				auditLogKeyFile,
+			),
+			auditlogcontroller.Workers,
+		)
+	}
 	// start informers and wait for cache sync
-	if !internal.StartInformersAndWaitForCacheSync(ctx, setup.Logger, kyvernoInformer) {
+	if !internal.StartInformersAndWaitForCacheSync(ctx, setup.Logger, kyvernoInformer, kubeInformer) {
 		setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
 		os.Exit(1)
 	}
 	// start event generator
 	var wg sync.WaitGroup
 	go eventGenerator.Run(ctx, event.Workers, &wg)
+	// start audit log controller
+	if auditLogController != nil {
+		auditLogController.Run(ctx, setup.Logger.WithName("controllers"), &wg)
+	}
 	// setup leader election
 	le, err := leaderelection.New(
 		setup.Logger.WithName("leader-election"),
@@ -306,7 +388,11 @@ func main() {
 				policyReports,
 				validatingAdmissionPolicyReports,
 				reportsChunkSize,
+				reportsMergeWindow,
+				reportsFlushInterval,
 				backgroundScanWorkers,
+				complianceSummary,
+				complianceSummaryInterval,
 				kubeInformer,
 				kyvernoInformer,
 				metadataInformer,
@@ -316,6 +402,7 @@ func main() {
 				setup.Jp,
 				eventGenerator,
 				backgroundScanInterval,
+				backgroundScanCache,
 			)
 			if err != nil {
 				logger.Error(err, "failed to create leader controllers")
@@ -0,0 +1,47 @@
+package yaml
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// FindDocument returns the document of the given kind, content made of a single document is returned as is
+// so that documents declaring no kind are still supported
+func FindDocument(content document, kind string) (document, error) {
+	documents, err := SplitDocuments(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(documents) <= 1 {
+		return content, nil
+	}
+	var found []document
+	for _, document := range documents {
+		typeMeta, err := GetTypeMeta(document)
+		if err != nil {
+			return nil, err
+		}
+		if typeMeta.Kind == kind {
+			found = append(found, document)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("no %s document found", kind)
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("found %d %s documents, only one is allowed", len(found), kind)
+	}
+}
+
+// GetTypeMeta returns the api version and kind of a document
+func GetTypeMeta(document document) (metav1.TypeMeta, error) {
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal(document, &typeMeta); err != nil {
+		return metav1.TypeMeta{}, err
+	}
+	return typeMeta, nil
+}
@@ -0,0 +1,48 @@
+package yaml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDocument(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		kind    string
+		want    string
+		wantErr bool
+	}{{
+		name:    "single doc without kind",
+		content: "policies:\n- policy.yaml",
+		kind:    "Test",
+		want:    "policies:\n- policy.yaml",
+	}, {
+		name:    "mixed docs",
+		content: "kind: ClusterPolicy\n---\nkind: Test\nname: test\n---\nkind: Values",
+		kind:    "Test",
+		want:    "kind: Test\nname: test\n",
+	}, {
+		name:    "not found",
+		content: "kind: ClusterPolicy\n---\nkind: Values",
+		kind:    "Test",
+		wantErr: true,
+	}, {
+		name:    "duplicated",
+		content: "kind: Test\n---\nkind: Test",
+		kind:    "Test",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindDocument([]byte(tt.content), tt.kind)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultTokenReviewTTL is the default duration token authentication and authorization decisions are cached for
+const DefaultTokenReviewTTL = 30 * time.Second
+
+// TokenReviewer authenticates bearer tokens and checks the permissions of their owners
+type TokenReviewer interface {
+	// Authenticate returns the user owning the token, nil if the token is not authenticated
+	Authenticate(ctx context.Context, token string) (*authenticationv1.UserInfo, error)
+	// Authorize returns true if the user owning the token is allowed to perform the action described by the attributes
+	Authorize(ctx context.Context, token string, user authenticationv1.UserInfo, attributes authorizationv1.ResourceAttributes) (bool, error)
+}
+
+type userEntry struct {
+	user      *authenticationv1.UserInfo
+	expiresAt time.Time
+}
+
+type decisionKey struct {
+	token      string
+	attributes authorizationv1.ResourceAttributes
+}
+
+type decisionEntry struct {
+	allowed   bool
+	expiresAt time.Time
+}
+
+type tokenReviewer struct {
+	kubeClient kubernetes.Interface
+	ttl        time.Duration
+	now        func() time.Time
+	lock       sync.Mutex
+	nextPrune  time.Time
+	users      map[string]userEntry
+	decisions  map[decisionKey]decisionEntry
+}
+
+// NewTokenReviewer returns a TokenReviewer using TokenReviews and SubjectAccessReviews, decisions are cached
+// for the given duration, keyed by a hash of the token, so that callers sending the same token don't create
+// reviews on every call.
+func NewTokenReviewer(kubeClient kubernetes.Interface, ttl time.Duration) TokenReviewer {
+	return &tokenReviewer{
+		kubeClient: kubeClient,
+		ttl:        ttl,
+		now:        time.Now,
+		users:      map[string]userEntry{},
+		decisions:  map[decisionKey]decisionEntry{},
+	}
+}
+
+func (r *tokenReviewer) Authenticate(ctx context.Context, token string) (*authenticationv1.UserInfo, error) {
+	key := hashToken(token)
+	r.lock.Lock()
+	entry, ok := r.users[key]
+	r.lock.Unlock()
+	if ok && r.now().Before(entry.expiresAt) {
+		return entry.user, nil
+	}
+	review, err := r.kubeClient.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var user *authenticationv1.UserInfo
+	if review.Status.Authenticated {
+		user = &review.Status.User
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.prune()
+	r.users[key] = userEntry{user: user, expiresAt: r.now().Add(r.ttl)}
+	return user, nil
+}
+
+func (r *tokenReviewer) Authorize(ctx context.Context, token string, user authenticationv1.UserInfo, attributes authorizationv1.ResourceAttributes) (bool, error) {
+	key := decisionKey{token: hashToken(token), attributes: attributes}
+	r.lock.Lock()
+	entry, ok := r.decisions[key]
+	r.lock.Unlock()
+	if ok && r.now().Before(entry.expiresAt) {
+		return entry.allowed, nil
+	}
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	sar, err := r.kubeClient.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &attributes,
+			User:               user.Username,
+			Groups:             user.Groups,
+			UID:                user.UID,
+			Extra:              extra,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.prune()
+	r.decisions[key] = decisionEntry{allowed: sar.Status.Allowed, expiresAt: r.now().Add(r.ttl)}
+	return sar.Status.Allowed, nil
+}
+
+// prune removes the expired decisions, at most once per ttl, the lock must be held
+func (r *tokenReviewer) prune() {
+	now := r.now()
+	if now.Before(r.nextPrune) {
+		return
+	}
+	for key, entry := range r.users {
+		if !now.Before(entry.expiresAt) {
+			delete(r.users, key)
+		}
+	}
+	for key, entry := range r.decisions {
+		if !now.Before(entry.expiresAt) {
+			delete(r.decisions, key)
+		}
+	}
+	r.nextPrune = now.Add(r.ttl)
+}
+
+// hashToken returns the hash of a token so that tokens are not kept in memory
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
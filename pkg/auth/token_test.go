@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestTokenReviewer(t *testing.T) {
+	var tokenReviews, accessReviews int
+	kubeClient := fake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "tokenreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		tokenReviews++
+		review := action.(clienttesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		review.Status.Authenticated = review.Spec.Token != "invalid"
+		review.Status.User = authenticationv1.UserInfo{Username: review.Spec.Token}
+		return true, review, nil
+	})
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		accessReviews++
+		sar := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		sar.Status.Allowed = sar.Spec.User == "admin" && sar.Spec.ResourceAttributes.Namespace == "default"
+		return true, sar, nil
+	})
+	now := time.Now()
+	reviewer := NewTokenReviewer(kubeClient, time.Minute).(*tokenReviewer)
+	reviewer.now = func() time.Time { return now }
+	ctx := context.TODO()
+
+	user, err := reviewer.Authenticate(ctx, "invalid")
+	assert.NoError(t, err)
+	assert.Nil(t, user)
+	for i := 0; i < 2; i++ {
+		user, err = reviewer.Authenticate(ctx, "admin")
+		assert.NoError(t, err)
+		assert.Equal(t, "admin", user.Username)
+		allowed, err := reviewer.Authorize(ctx, "admin", *user, authorizationv1.ResourceAttributes{Namespace: "default", Verb: "list"})
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+		allowed, err = reviewer.Authorize(ctx, "admin", *user, authorizationv1.ResourceAttributes{Namespace: "other", Verb: "list"})
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+	}
+	// decisions are cached until they expire
+	assert.Equal(t, 2, tokenReviews)
+	assert.Equal(t, 2, accessReviews)
+	_, ok := reviewer.users["admin"]
+	assert.False(t, ok, "tokens must be hashed")
+
+	now = now.Add(time.Minute)
+	user, err = reviewer.Authenticate(ctx, "admin")
+	assert.NoError(t, err)
+	_, err = reviewer.Authorize(ctx, "admin", *user, authorizationv1.ResourceAttributes{Namespace: "default", Verb: "list"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, tokenReviews)
+	assert.Equal(t, 3, accessReviews)
+	// expired decisions are pruned
+	assert.Len(t, reviewer.users, 1)
+	assert.Len(t, reviewer.decisions, 1)
+}
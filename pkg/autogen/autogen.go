@@ -68,7 +68,7 @@ func stripCronJob(controllers string) string {
 func CanAutoGen(spec *kyvernov1.Spec) (applyAutoGen bool, controllers string) {
 	needed := false
 	for _, rule := range spec.Rules {
-		if rule.Mutation.PatchesJSON6902 != "" || rule.HasGenerate() {
+		if rule.Mutation.PatchesJSON6902 != "" || rule.HasGenerate() || rule.HasValidateBaseline() || rule.HasValidateRego() {
 			return false, "none"
 		}
 		for _, foreach := range rule.Mutation.ForEachMutation {
@@ -209,8 +209,10 @@ func convertRule(rule kyvernoRule, kind string) (*kyvernov1.Rule, error) {
 	}
 
 	out := kyvernov1.Rule{
-		Name:         rule.Name,
-		VerifyImages: rule.VerifyImages,
+		Name:             rule.Name,
+		DependsOn:        rule.DependsOn,
+		VerifyImages:     rule.VerifyImages,
+		ReportProperties: rule.ReportProperties,
 	}
 	if rule.MatchResources != nil {
 		out.MatchResources = *rule.MatchResources
@@ -238,7 +240,7 @@ func ComputeRules(p kyvernov1.PolicyInterface) []kyvernov1.Rule {
 }
 
 func computeRules(p kyvernov1.PolicyInterface) []kyvernov1.Rule {
-	spec := p.GetSpec()
+	spec := expandMetaKinds(p)
 	applyAutoGen, desiredControllers := CanAutoGen(spec)
 	if !applyAutoGen {
 		desiredControllers = "none"
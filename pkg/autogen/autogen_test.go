@@ -90,6 +90,16 @@ func Test_CanAutoGen(t *testing.T) {
 			policy:              []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"test"},"spec":{"rules":[{"name":"require-network-policy","match":{"resources":{"kinds":["Pod"]}},"validate":{"message":"testpolicy","deny":{"conditions":[{"key":"{{request.object.metadata.labels.foo}}","operator":"Equals","value":"bar"}]}}}]}}`),
 			expectedControllers: PodControllers,
 		},
+		{
+			name:                "rule-with-baseline",
+			policy:              []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"test"},"spec":{"rules":[{"name":"baseline-images","match":{"resources":{"kinds":["Pod"]}},"validate":{"baseline":{"mode":"Record","jmesPath":"spec.containers[].image","configMap":{"name":"images","namespace":"kyverno"}}}}]}}`),
+			expectedControllers: "none",
+		},
+		{
+			name:                "rule-with-rego",
+			policy:              []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"test"},"spec":{"rules":[{"name":"rego-labels","match":{"resources":{"kinds":["Pod"]}},"validate":{"rego":{"module":"package kubernetes\n\ndeny[msg] {\n  not input.request.object.metadata.labels.app\n  msg := \"label app is required\"\n}\n"}}}]}}`),
+			expectedControllers: "none",
+		},
 		{
 			name:                "rule-with-match-mixed-kinds-pod-podcontrollers",
 			policy:              []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"set-service-labels-env"},"spec":{"background":false,"rules":[{"name":"set-service-label","match":{"resources":{"kinds":["Pod","Deployment"]}},"preconditions":{"any":[{"key":"{{request.operation}}","operator":"Equals","value":"CREATE"}]},"mutate":{"patchStrategicMerge":{"metadata":{"labels":{"+(service)":"{{request.object.spec.template.metadata.labels.app}}"}}}}}]}}`),
@@ -635,3 +645,41 @@ func Test_ValidateWithCELExpressions(t *testing.T) {
 	rules := computeRules(policies[0])
 	assert.Equal(t, 3, len(rules))
 }
+
+func Test_PatternAndAnyPattern(t *testing.T) {
+	policy := []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"require-team"},"spec":{"rules":[{"name":"team","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"pattern":{"metadata":{"labels":{"team":"?*"}}},"anyPattern":[{"spec":{"securityContext":{"runAsNonRoot":true}}},{"spec":{"containers":[{"securityContext":{"runAsNonRoot":true}}]}}]}}]}}`)
+	policies, _, err := yamlutils.GetPolicy([]byte(policy))
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(policies))
+
+	rules := computeRules(policies[0])
+	assert.Equal(t, 3, len(rules))
+	for _, rule := range rules {
+		assert.Assert(t, rule.Validation.GetPattern() != nil, rule.Name)
+		anyPatterns, err := rule.Validation.DeserializeAnyPattern()
+		assert.NilError(t, err)
+		assert.Equal(t, 2, len(anyPatterns), rule.Name)
+	}
+	assert.DeepEqual(t, map[string]interface{}{"spec": map[string]interface{}{"securityContext": map[string]interface{}{"runAsNonRoot": true}}},
+		rules[1].Validation.GetAnyPattern().([]interface{})[0].(map[string]interface{})["spec"].(map[string]interface{})["template"])
+}
+
+func Test_RuleDependencies(t *testing.T) {
+	policy := []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"add-labels"},"spec":{"rules":[{"name":"add-team","dependsOn":["add-owner"],"match":{"any":[{"resources":{"kinds":["Pod"]}}]},"mutate":{"patchStrategicMerge":{"metadata":{"labels":{"team":"foo"}}}}},{"name":"add-owner","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"mutate":{"patchStrategicMerge":{"metadata":{"labels":{"owner":"bar"}}}}}]}}`)
+	policies, _, err := yamlutils.GetPolicy([]byte(policy))
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(policies))
+
+	dependencies := map[string][]string{}
+	for _, rule := range computeRules(policies[0]) {
+		dependencies[rule.Name] = rule.DependsOn
+	}
+	assert.DeepEqual(t, dependencies, map[string][]string{
+		"add-team":                  {"add-owner"},
+		"add-owner":                 nil,
+		"autogen-add-team":          {"autogen-add-owner"},
+		"autogen-add-owner":         nil,
+		"autogen-cronjob-add-team":  {"autogen-cronjob-add-owner"},
+		"autogen-cronjob-add-owner": nil,
+	})
+}
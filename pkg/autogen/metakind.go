@@ -0,0 +1,85 @@
+package autogen
+
+import (
+	"slices"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+)
+
+// MetaKindWorkload is a meta kind matching the workload kinds
+const MetaKindWorkload = "Workload"
+
+// metaKinds maps the meta kinds that can be used in match and exclude blocks to the kinds they expand to
+var metaKinds = map[string][]string{
+	MetaKindWorkload: {"Deployment", "StatefulSet", "DaemonSet", "Job", "CronJob", "ReplicaSet"},
+}
+
+// IsMetaKind returns true if the kind is a meta kind
+func IsMetaKind(kind string) bool {
+	_, ok := metaKinds[kind]
+	return ok
+}
+
+// expandMetaKinds replaces the meta kinds in the rules of a spec.
+// When autogen applies to the spec with Workload replaced by Pod, Workload is expanded to Pod and the
+// rules for the pod controllers are generated by autogen, otherwise it is expanded to the workload kinds.
+func expandMetaKinds(p kyvernov1.PolicyInterface) *kyvernov1.Spec {
+	spec := p.GetSpec()
+	if !hasMetaKinds(spec) {
+		return spec
+	}
+	if p.GetAnnotations()[kyverno.AnnotationAutogenControllers] != "none" {
+		podSpec := spec.DeepCopy()
+		replaceMetaKinds(podSpec, func(string) []string { return []string{"Pod"} })
+		if applyAutoGen, _ := CanAutoGen(podSpec); applyAutoGen {
+			return podSpec
+		}
+	}
+	spec = spec.DeepCopy()
+	replaceMetaKinds(spec, func(kind string) []string { return metaKinds[kind] })
+	return spec
+}
+
+func hasMetaKinds(spec *kyvernov1.Spec) bool {
+	found := false
+	forEachResourceDescription(spec, func(description *kyvernov1.ResourceDescription) {
+		found = found || slices.ContainsFunc(description.Kinds, IsMetaKind)
+	})
+	return found
+}
+
+func replaceMetaKinds(spec *kyvernov1.Spec, expand func(string) []string) {
+	forEachResourceDescription(spec, func(description *kyvernov1.ResourceDescription) {
+		if !slices.ContainsFunc(description.Kinds, IsMetaKind) {
+			return
+		}
+		var kinds []string
+		for _, kind := range description.Kinds {
+			expanded := []string{kind}
+			if IsMetaKind(kind) {
+				expanded = expand(kind)
+			}
+			for _, k := range expanded {
+				if !slices.Contains(kinds, k) {
+					kinds = append(kinds, k)
+				}
+			}
+		}
+		description.Kinds = kinds
+	})
+}
+
+func forEachResourceDescription(spec *kyvernov1.Spec, fn func(*kyvernov1.ResourceDescription)) {
+	for i := range spec.Rules {
+		for _, match := range []*kyvernov1.MatchResources{&spec.Rules[i].MatchResources, &spec.Rules[i].ExcludeResources} {
+			fn(&match.ResourceDescription)
+			for j := range match.Any {
+				fn(&match.Any[j].ResourceDescription)
+			}
+			for j := range match.All {
+				fn(&match.All[j].ResourceDescription)
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package autogen
+
+import (
+	"testing"
+
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"gotest.tools/assert"
+)
+
+func Test_computeRulesWithMetaKinds(t *testing.T) {
+	testCases := []struct {
+		name          string
+		policy        string
+		expectedKinds [][]string
+	}{{
+		name:          "autogen",
+		policy:        `{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"test"},"spec":{"rules":[{"name":"require-labels","match":{"any":[{"resources":{"kinds":["Workload"]}}]},"validate":{"pattern":{"metadata":{"labels":{"app":"?*"}}}}}]}}`,
+		expectedKinds: [][]string{{"Pod"}, {"DaemonSet", "Deployment", "Job", "StatefulSet", "ReplicaSet", "ReplicationController"}, {"CronJob"}},
+	}, {
+		name:          "autogen disabled",
+		policy:        `{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"test","annotations":{"pod-policies.kyverno.io/autogen-controllers":"none"}},"spec":{"rules":[{"name":"require-labels","match":{"any":[{"resources":{"kinds":["Workload"]}}]},"validate":{"pattern":{"metadata":{"labels":{"app":"?*"}}}}}]}}`,
+		expectedKinds: [][]string{{"Deployment", "StatefulSet", "DaemonSet", "Job", "CronJob", "ReplicaSet"}},
+	}, {
+		name:          "autogen not supported",
+		policy:        `{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"test"},"spec":{"rules":[{"name":"require-labels","match":{"any":[{"resources":{"kinds":["Service","Workload","Deployment"]}}]},"validate":{"pattern":{"metadata":{"labels":{"app":"?*"}}}}}]}}`,
+		expectedKinds: [][]string{{"Service", "Deployment", "StatefulSet", "DaemonSet", "Job", "CronJob", "ReplicaSet"}},
+	}, {
+		name:          "no meta kind",
+		policy:        `{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"test"},"spec":{"rules":[{"name":"require-labels","match":{"any":[{"resources":{"kinds":["Service"]}}]},"validate":{"pattern":{"metadata":{"labels":{"app":"?*"}}}}}]}}`,
+		expectedKinds: [][]string{{"Service"}},
+	}}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			policies, _, err := yamlutils.GetPolicy([]byte(test.policy))
+			assert.NilError(t, err)
+			assert.Equal(t, 1, len(policies))
+			kinds := append([]string{}, policies[0].GetSpec().Rules[0].MatchResources.Any[0].Kinds...)
+			rules := computeRules(policies[0])
+			assert.Equal(t, len(test.expectedKinds), len(rules))
+			for i, rule := range rules {
+				assert.DeepEqual(t, test.expectedKinds[i], rule.MatchResources.Any[0].Kinds)
+			}
+			// the policy itself is not modified
+			assert.DeepEqual(t, kinds, policies[0].GetSpec().Rules[0].MatchResources.Any[0].Kinds)
+		})
+	}
+}
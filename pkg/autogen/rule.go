@@ -25,10 +25,12 @@ type kyvernoRule struct {
 	MatchResources   *kyvernov1.MatchResources     `json:"match"`
 	ExcludeResources *kyvernov1.MatchResources     `json:"exclude,omitempty"`
 	Context          *[]kyvernov1.ContextEntry     `json:"context,omitempty"`
+	DependsOn        []string                      `json:"dependsOn,omitempty"`
 	AnyAllConditions *apiextensions.JSON           `json:"preconditions,omitempty"`
 	Mutation         *kyvernov1.Mutation           `json:"mutate,omitempty"`
 	Validation       *kyvernov1.Validation         `json:"validate,omitempty"`
 	VerifyImages     []kyvernov1.ImageVerification `json:"verifyImages,omitempty" yaml:"verifyImages,omitempty"`
+	ReportProperties map[string]string             `json:"reportProperties,omitempty"`
 }
 
 func createRule(rule *kyvernov1.Rule) *kyvernoRule {
@@ -36,8 +38,10 @@ func createRule(rule *kyvernov1.Rule) *kyvernoRule {
 		return nil
 	}
 	jsonFriendlyStruct := kyvernoRule{
-		Name:         rule.Name,
-		VerifyImages: rule.VerifyImages,
+		Name:             rule.Name,
+		DependsOn:        rule.DependsOn,
+		VerifyImages:     rule.VerifyImages,
+		ReportProperties: rule.ReportProperties,
 	}
 	if !datautils.DeepEqual(rule.MatchResources, kyvernov1.MatchResources{}) {
 		jsonFriendlyStruct.MatchResources = rule.MatchResources.DeepCopy()
@@ -138,6 +142,10 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 				},
 			},
 		)
+		if rule.Validation.GetAnyPattern() != nil {
+			newValidate.Message = variables.FindAndShiftReferences(logger, newValidate.Message, shift, "anyPattern")
+			newValidate.SetAnyPattern(generateAnyPatterns(rule.Validation, tplKey))
+		}
 		rule.Validation = newValidate
 		return rule
 	}
@@ -164,19 +172,7 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 		return rule
 	}
 	if rule.Validation.GetAnyPattern() != nil {
-		anyPatterns, err := rule.Validation.DeserializeAnyPattern()
-		if err != nil {
-			logger.Error(err, "failed to deserialize anyPattern, expect type array")
-		}
-		var patterns []interface{}
-		for _, pattern := range anyPatterns {
-			newPattern := map[string]interface{}{
-				"spec": map[string]interface{}{
-					tplKey: pattern,
-				},
-			}
-			patterns = append(patterns, newPattern)
-		}
+		patterns := generateAnyPatterns(rule.Validation, tplKey)
 		rule.Validation = kyvernov1.Validation{
 			Message: variables.FindAndShiftReferences(logger, rule.Validation.Message, shift, "anyPattern"),
 		}
@@ -216,6 +212,18 @@ func getAutogenRuleName(prefix string, name string) string {
 	return name
 }
 
+// getAutogenDependencies maps the dependencies of a rule to the rules generated with the same prefix
+func getAutogenDependencies(prefix string, dependsOn []string) []string {
+	if len(dependsOn) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(dependsOn))
+	for _, dependency := range dependsOn {
+		out = append(out, getAutogenRuleName(prefix, dependency))
+	}
+	return out
+}
+
 func isAutogenRuleName(name string) bool {
 	return strings.HasPrefix(name, "autogen-")
 }
@@ -230,6 +238,24 @@ func getAnyAllAutogenRule(v kyvernov1.ResourceFilters, match string, kinds []str
 	return anyKind
 }
 
+// generateAnyPatterns nests the anyPattern entries of a validation under the pod template key
+func generateAnyPatterns(validation kyvernov1.Validation, tplKey string) []interface{} {
+	anyPatterns, err := validation.DeserializeAnyPattern()
+	if err != nil {
+		logger.Error(err, "failed to deserialize anyPattern, expect type array")
+	}
+	var patterns []interface{}
+	for _, pattern := range anyPatterns {
+		newPattern := map[string]interface{}{
+			"spec": map[string]interface{}{
+				tplKey: pattern,
+			},
+		}
+		patterns = append(patterns, newPattern)
+	}
+	return patterns
+}
+
 func generateRuleForControllers(rule *kyvernov1.Rule, controllers string) *kyvernov1.Rule {
 	if isAutogenRuleName(rule.Name) || controllers == "" {
 		debug.Info("skip generateRuleForControllers")
@@ -271,7 +297,7 @@ func generateRuleForControllers(rule *kyvernov1.Rule, controllers string) *kyver
 			controllers = strings.Join(controllersValidated, ",")
 		}
 	}
-	return generateRule(
+	genRule := generateRule(
 		getAutogenRuleName("autogen", rule.Name),
 		rule,
 		"template",
@@ -281,6 +307,10 @@ func generateRuleForControllers(rule *kyvernov1.Rule, controllers string) *kyver
 			return getAnyAllAutogenRule(r, "Pod", kinds)
 		},
 	)
+	if genRule != nil {
+		genRule.DependsOn = getAutogenDependencies("autogen", rule.DependsOn)
+	}
+	return genRule
 }
 
 func generateCronJobRule(rule *kyvernov1.Rule, controllers string) *kyvernov1.Rule {
@@ -289,7 +319,7 @@ func generateCronJobRule(rule *kyvernov1.Rule, controllers string) *kyvernov1.Ru
 		return nil
 	}
 	debug.Info("generating rule for cronJob")
-	return generateRule(
+	genRule := generateRule(
 		getAutogenRuleName("autogen-cronjob", rule.Name),
 		generateRuleForControllers(rule, controllers),
 		"jobTemplate",
@@ -303,6 +333,10 @@ func generateCronJobRule(rule *kyvernov1.Rule, controllers string) *kyvernov1.Ru
 			return anyKind
 		},
 	)
+	if genRule != nil {
+		genRule.DependsOn = getAutogenDependencies("autogen-cronjob", rule.DependsOn)
+	}
+	return genRule
 }
 
 func updateGenRuleByte(pbyte []byte, kind string) (obj []byte) {
@@ -18,3 +18,7 @@ const (
 	GenerateSourceGroupLabel     = "generate.kyverno.io/source-group"
 	GenerateTypeCloneSourceLabel = "generate.kyverno.io/clone-source"
 )
+
+// GenerateDownstreamFinalizer is added to trigger resources of generate rules with cascade enabled,
+// it is removed once the downstream resources are deleted.
+const GenerateDownstreamFinalizer = "generate.kyverno.io/downstream"
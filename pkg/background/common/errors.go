@@ -0,0 +1,57 @@
+package common
+
+import (
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	errors "github.com/pkg/errors"
+	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// TargetError is returned when a downstream resource could not be created or updated
+type TargetError struct {
+	Target kyvernov1.ResourceSpec
+	Err    error
+}
+
+func NewTargetError(target kyvernov1.ResourceSpec, err error) error {
+	return TargetError{Target: target, Err: err}
+}
+
+func (e TargetError) Error() string {
+	return fmt.Sprintf("failed to apply downstream resource %s: %v", e.Target.String(), e.Err)
+}
+
+func (e TargetError) Unwrap() error {
+	return e.Err
+}
+
+// failedTargets returns the downstream resources of the target errors contained in err
+func failedTargets(err error) []kyvernov1.ResourceSpec {
+	var targets []kyvernov1.ResourceSpec
+	for _, err := range multierr.Errors(err) {
+		var targetErr TargetError
+		if errors.As(err, &targetErr) {
+			targets = append(targets, targetErr.Target)
+		}
+	}
+	return targets
+}
+
+// failureReason returns the reason of the Processed condition for a failed attempt
+func failureReason(err error) string {
+	switch {
+	case apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err):
+		return kyvernov1beta1.URReasonForbidden
+	case apierrors.IsNotFound(err):
+		return kyvernov1beta1.URReasonNotFound
+	case apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err):
+		return kyvernov1beta1.URReasonConflict
+	case apierrors.IsInvalid(err) || apierrors.IsBadRequest(err):
+		return kyvernov1beta1.URReasonInvalid
+	default:
+		return kyvernov1beta1.URReasonFailed
+	}
+}
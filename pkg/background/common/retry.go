@@ -0,0 +1,52 @@
+package common
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// RetryPolicy controls how failed update requests are retried
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts after which a failed update request is given up, zero means no limit
+	MaxAttempts int
+	// Backoff is the delay before the first retry, it doubles after every failed attempt
+	Backoff time.Duration
+	// MaxBackoff caps the delay between two attempts
+	MaxBackoff time.Duration
+	// Jitter randomly extends the delay by up to the given fraction of it
+	Jitter float64
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	Backoff:     2 * time.Second,
+	MaxBackoff:  5 * time.Minute,
+	Jitter:      0.1,
+}
+
+// Delay returns the delay before the next attempt, given the number of failed attempts
+func (p RetryPolicy) Delay(attempts int) time.Duration {
+	delay := p.Backoff
+	for i := 1; i < attempts && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		delay = wait.Jitter(delay, p.Jitter)
+	}
+	return delay
+}
+
+// Exhausted returns true when no attempt is left after the given number of failed attempts
+func (p RetryPolicy) Exhausted(attempts int) bool {
+	return p.MaxAttempts > 0 && attempts >= p.MaxAttempts
+}
+
+// RateLimiter returns a queue rate limiter applying the same backoff
+func (p RetryPolicy) RateLimiter() workqueue.RateLimiter {
+	return workqueue.NewItemExponentialFailureRateLimiter(p.Backoff, p.MaxBackoff)
+}
@@ -0,0 +1,41 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{
+		Backoff:    time.Second,
+		MaxBackoff: 10 * time.Second,
+	}
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: time.Second},
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 4, want: 8 * time.Second},
+		{attempts: 5, want: 10 * time.Second},
+		{attempts: 100, want: 10 * time.Second},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, policy.Delay(tt.attempts), "attempts %d", tt.attempts)
+	}
+	policy.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		delay := policy.Delay(2)
+		assert.GreaterOrEqual(t, delay, 2*time.Second)
+		assert.LessOrEqual(t, delay, 3*time.Second)
+	}
+}
+
+func TestRetryPolicy_Exhausted(t *testing.T) {
+	assert.False(t, RetryPolicy{MaxAttempts: 3}.Exhausted(2))
+	assert.True(t, RetryPolicy{MaxAttempts: 3}.Exhausted(3))
+	assert.False(t, RetryPolicy{}.Exhausted(100))
+}
@@ -9,9 +9,9 @@ import (
 
 // StatusControlInterface provides interface to update status subresource
 type StatusControlInterface interface {
-	Failed(name string, message string, genResources []kyvernov1.ResourceSpec) (*kyvernov1beta1.UpdateRequest, error)
-	Success(name string, genResources []kyvernov1.ResourceSpec) (*kyvernov1beta1.UpdateRequest, error)
-	Skip(name string, genResources []kyvernov1.ResourceSpec) (*kyvernov1beta1.UpdateRequest, error)
+	Failed(name string, err error, genResources []kyvernov1.ResourceSpec, namespaces []kyvernov1beta1.NamespaceStatus, targets []kyvernov1beta1.TargetStatus) (*kyvernov1beta1.UpdateRequest, error)
+	Success(name string, genResources []kyvernov1.ResourceSpec, namespaces []kyvernov1beta1.NamespaceStatus, targets []kyvernov1beta1.TargetStatus) (*kyvernov1beta1.UpdateRequest, error)
+	Skip(name string, genResources []kyvernov1.ResourceSpec, namespaces []kyvernov1beta1.NamespaceStatus, targets []kyvernov1beta1.TargetStatus) (*kyvernov1beta1.UpdateRequest, error)
 }
 
 // statusControl is default implementaation of GRStatusControlInterface
@@ -27,17 +27,17 @@ func NewStatusControl(client versioned.Interface, urLister kyvernov1beta1listers
 	}
 }
 
-// Failed sets ur status.state to failed with message
-func (sc *statusControl) Failed(name, message string, genResources []kyvernov1.ResourceSpec) (*kyvernov1beta1.UpdateRequest, error) {
-	return UpdateStatus(sc.client, sc.urLister, name, kyvernov1beta1.Failed, message, genResources)
+// Failed sets ur status.state to failed with the error message and reason
+func (sc *statusControl) Failed(name string, err error, genResources []kyvernov1.ResourceSpec, namespaces []kyvernov1beta1.NamespaceStatus, targets []kyvernov1beta1.TargetStatus) (*kyvernov1beta1.UpdateRequest, error) {
+	return UpdateStatus(sc.client, sc.urLister, name, kyvernov1beta1.Failed, err, genResources, namespaces, targets)
 }
 
 // Success sets the ur status.state to completed and clears message
-func (sc *statusControl) Success(name string, genResources []kyvernov1.ResourceSpec) (*kyvernov1beta1.UpdateRequest, error) {
-	return UpdateStatus(sc.client, sc.urLister, name, kyvernov1beta1.Completed, "", genResources)
+func (sc *statusControl) Success(name string, genResources []kyvernov1.ResourceSpec, namespaces []kyvernov1beta1.NamespaceStatus, targets []kyvernov1beta1.TargetStatus) (*kyvernov1beta1.UpdateRequest, error) {
+	return UpdateStatus(sc.client, sc.urLister, name, kyvernov1beta1.Completed, nil, genResources, namespaces, targets)
 }
 
 // Success sets the ur status.state to completed and clears message
-func (sc *statusControl) Skip(name string, genResources []kyvernov1.ResourceSpec) (*kyvernov1beta1.UpdateRequest, error) {
-	return UpdateStatus(sc.client, sc.urLister, name, kyvernov1beta1.Skip, "", genResources)
+func (sc *statusControl) Skip(name string, genResources []kyvernov1.ResourceSpec, namespaces []kyvernov1beta1.NamespaceStatus, targets []kyvernov1beta1.TargetStatus) (*kyvernov1beta1.UpdateRequest, error) {
+	return UpdateStatus(sc.client, sc.urLister, name, kyvernov1beta1.Skip, nil, genResources, namespaces, targets)
 }
@@ -2,6 +2,7 @@ package common
 
 import (
 	"context"
+	"slices"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
@@ -11,27 +12,29 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/logging"
 	errors "github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
-func UpdateStatus(client versioned.Interface, urLister kyvernov1beta1listers.UpdateRequestNamespaceLister, name string, state kyvernov1beta1.UpdateRequestState, message string, genResources []kyvernov1.ResourceSpec) (*kyvernov1beta1.UpdateRequest, error) {
+func UpdateStatus(client versioned.Interface, urLister kyvernov1beta1listers.UpdateRequestNamespaceLister, name string, state kyvernov1beta1.UpdateRequestState, failure error, genResources []kyvernov1.ResourceSpec, namespaces []kyvernov1beta1.NamespaceStatus, targets []kyvernov1beta1.TargetStatus) (*kyvernov1beta1.UpdateRequest, error) {
 	var latest *kyvernov1beta1.UpdateRequest
 	ur, err := client.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), name, metav1.GetOptions{})
 	if err != nil {
 		return ur, errors.Wrapf(err, "failed to fetch update request")
 	}
 	latest = ur.DeepCopy()
-	latest.Status.State = state
-	latest.Status.Message = message
-	if genResources != nil {
-		latest.Status.GeneratedResources = genResources
+	setStatus(&latest.Status, latest.GetGeneration(), state, failure, genResources)
+	if namespaces != nil {
+		latest.Status.Namespaces = namespaces
+	}
+	if targets != nil {
+		latest.Status.Targets = targets
 	}
 
 	if state == kyvernov1beta1.Failed {
-		if latest, err = retryOrDeleteOnFailure(client, latest, 3); err != nil {
-			return nil, err
-		}
+		// the controller retries or gives up the update request based on the retry count
+		latest.Status.RetryCount++
 	}
 	new, err := client.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), latest, metav1.UpdateOptions{})
 	if err != nil {
@@ -42,6 +45,32 @@ func UpdateStatus(client versioned.Interface, urLister kyvernov1beta1listers.Upd
 	return ur, nil
 }
 
+func setStatus(status *kyvernov1beta1.UpdateRequestStatus, generation int64, state kyvernov1beta1.UpdateRequestState, failure error, genResources []kyvernov1.ResourceSpec) {
+	status.State = state
+	status.Message = ""
+	if genResources != nil {
+		status.GeneratedResources = genResources
+	}
+	status.AttemptedResources = append(slices.Clone(genResources), failedTargets(failure)...)
+	condition := metav1.Condition{
+		Type:               kyvernov1beta1.URConditionProcessed,
+		Status:             metav1.ConditionTrue,
+		Reason:             kyvernov1beta1.URReasonSucceeded,
+		ObservedGeneration: generation,
+	}
+	if state == kyvernov1beta1.Skip {
+		condition.Reason = kyvernov1beta1.URReasonSkipped
+	}
+	if failure != nil {
+		status.Message = failure.Error()
+		status.LastError = failure.Error()
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = failureReason(failure)
+		condition.Message = failure.Error()
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
 func PolicyKey(namespace, name string) string {
 	if namespace != "" {
 		return namespace + "/" + name
@@ -59,19 +88,6 @@ func ResourceSpecFromUnstructured(obj unstructured.Unstructured) kyvernov1.Resou
 	}
 }
 
-func retryOrDeleteOnFailure(kyvernoClient versioned.Interface, ur *kyvernov1beta1.UpdateRequest, limit int) (latest *kyvernov1beta1.UpdateRequest, err error) {
-	if ur.Status.RetryCount > limit {
-		err = kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Delete(context.TODO(), ur.GetName(), metav1.DeleteOptions{})
-		if err != nil {
-			return nil, errors.Wrapf(err, "exceeds retry limit, failed to delete the UR: %s, retry: %v, resourceVersion: %s", ur.Name, ur.Status.RetryCount, ur.GetResourceVersion())
-		}
-	} else {
-		ur.Status.RetryCount++
-	}
-
-	return ur, nil
-}
-
 func FindDownstream(client dclient.Interface, apiVersion, kind string, labels map[string]string) (*unstructured.UnstructuredList, error) {
 	selector := &metav1.LabelSelector{MatchLabels: labels}
 	return client.ListResource(context.TODO(), apiVersion, kind, "", selector)
@@ -0,0 +1,88 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_setStatus(t *testing.T) {
+	secret := kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "Secret", Namespace: "tenant", Name: "regcred"}
+	configmap := kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Namespace: "tenant", Name: "settings"}
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "regcred", errors.New("denied"))
+	tests := []struct {
+		name          string
+		status        kyvernov1beta1.UpdateRequestStatus
+		state         kyvernov1beta1.UpdateRequestState
+		failure       error
+		genResources  []kyvernov1.ResourceSpec
+		wantGenerated []kyvernov1.ResourceSpec
+		wantAttempted []kyvernov1.ResourceSpec
+		wantCondition metav1.ConditionStatus
+		wantReason    string
+		wantLastError string
+	}{{
+		name:          "completed",
+		state:         kyvernov1beta1.Completed,
+		genResources:  []kyvernov1.ResourceSpec{secret},
+		wantGenerated: []kyvernov1.ResourceSpec{secret},
+		wantAttempted: []kyvernov1.ResourceSpec{secret},
+		wantCondition: metav1.ConditionTrue,
+		wantReason:    kyvernov1beta1.URReasonSucceeded,
+	}, {
+		name:          "skipped",
+		state:         kyvernov1beta1.Skip,
+		wantCondition: metav1.ConditionTrue,
+		wantReason:    kyvernov1beta1.URReasonSkipped,
+	}, {
+		name:          "forbidden",
+		status:        kyvernov1beta1.UpdateRequestStatus{GeneratedResources: []kyvernov1.ResourceSpec{configmap}},
+		state:         kyvernov1beta1.Failed,
+		failure:       multierr.Combine(NewTargetError(secret, forbidden), errors.New("other")),
+		wantGenerated: []kyvernov1.ResourceSpec{configmap},
+		wantAttempted: []kyvernov1.ResourceSpec{secret},
+		wantCondition: metav1.ConditionFalse,
+		wantReason:    kyvernov1beta1.URReasonForbidden,
+		wantLastError: multierr.Combine(NewTargetError(secret, forbidden), errors.New("other")).Error(),
+	}, {
+		name:          "failed",
+		state:         kyvernov1beta1.Failed,
+		failure:       errors.New("failed"),
+		wantCondition: metav1.ConditionFalse,
+		wantReason:    kyvernov1beta1.URReasonFailed,
+		wantLastError: "failed",
+	}, {
+		name:          "last error is kept",
+		status:        kyvernov1beta1.UpdateRequestStatus{LastError: "failed", Message: "failed"},
+		state:         kyvernov1beta1.Completed,
+		wantCondition: metav1.ConditionTrue,
+		wantReason:    kyvernov1beta1.URReasonSucceeded,
+		wantLastError: "failed",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := tt.status
+			setStatus(&status, 1, tt.state, tt.failure, tt.genResources)
+			assert.Equal(t, tt.state, status.State)
+			assert.Equal(t, tt.wantGenerated, status.GeneratedResources)
+			assert.Equal(t, tt.wantAttempted, status.AttemptedResources)
+			assert.Equal(t, tt.wantLastError, status.LastError)
+			if tt.failure == nil {
+				assert.Empty(t, status.Message)
+			}
+			condition := meta.FindStatusCondition(status.Conditions, kyvernov1beta1.URConditionProcessed)
+			assert.NotNil(t, condition)
+			assert.Equal(t, tt.wantCondition, condition.Status)
+			assert.Equal(t, tt.wantReason, condition.Reason)
+			assert.Equal(t, int64(1), condition.ObservedGeneration)
+		})
+	}
+}
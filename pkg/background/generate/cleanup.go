@@ -34,10 +34,13 @@ func (c *GenerateController) deleteDownstream(policy kyvernov1.PolicyInterface,
 		if len(errs) != 0 {
 			c.log.Error(multierr.Combine(errs...), "failed to clean up downstream resources on policy deletion")
 			_, err = c.statusControl.Failed(ur.GetName(),
-				fmt.Sprintf("failed to clean up downstream resources on policy deletion: %v", multierr.Combine(errs...)),
-				failedDownstreams)
+				fmt.Errorf("failed to clean up downstream resources on policy deletion: %w", multierr.Combine(errs...)),
+				failedDownstreams, nil, nil)
 		} else {
-			_, err = c.statusControl.Success(ur.GetName(), nil)
+			if err := removeTriggerFinalizer(c.client, ur.Spec.GetResource()); err != nil {
+				c.log.Error(err, "failed to remove the finalizer from the trigger", "trigger", ur.Spec.GetResource().String())
+			}
+			_, err = c.statusControl.Success(ur.GetName(), nil, nil, nil)
 		}
 		return
 	}
@@ -82,10 +85,13 @@ func (c *GenerateController) handleNonPolicyChanges(policy kyvernov1.PolicyInter
 		}
 		if len(errs) != 0 {
 			_, err = c.statusControl.Failed(ur.GetName(),
-				fmt.Sprintf("failed to clean up downstream resources on source deletion: %v", multierr.Combine(errs...)),
-				failedDownstreams)
+				fmt.Errorf("failed to clean up downstream resources on source deletion: %w", multierr.Combine(errs...)),
+				failedDownstreams, nil, nil)
 		} else {
-			_, err = c.statusControl.Success(ur.GetName(), nil)
+			if err := removeTriggerFinalizer(c.client, ur.Spec.GetResource()); err != nil {
+				c.log.Error(err, "failed to remove the finalizer from the trigger", "trigger", ur.Spec.GetResource().String())
+			}
+			_, err = c.statusControl.Success(ur.GetName(), nil, nil, nil)
 		}
 		if err != nil {
 			c.log.Error(err, "failed to update ur status")
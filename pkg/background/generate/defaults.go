@@ -0,0 +1,96 @@
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/openapi"
+	"sigs.k8s.io/kubectl-validate/pkg/validator"
+)
+
+// schemaReloadInterval is the minimum delay between two loads of the schemas, they are reloaded
+// when a kind is unknown so that the CRDs installed after the schemas were loaded are supported
+const schemaReloadInterval = time.Minute
+
+// schemaDefaulter sets the defaults declared by the OpenAPI schemas, including the structural schemas of CRDs,
+// on the generated resources so that they are compared and applied the way the API server persists them
+type schemaDefaulter struct {
+	client    openapi.Client
+	lock      sync.Mutex
+	validator *validator.Validator
+	loadedAt  time.Time
+	loadErr   error
+}
+
+func newSchemaDefaulter(client openapi.Client) *schemaDefaulter {
+	if client == nil {
+		return nil
+	}
+	return &schemaDefaulter{client: client}
+}
+
+// newClusterSchemaDefaulter returns a defaulter using the cluster OpenAPI schemas, or nil without a cluster
+func newClusterSchemaDefaulter(client dclient.Interface) *schemaDefaulter {
+	if client == nil || client.GetKubeClient() == nil {
+		return nil
+	}
+	return newSchemaDefaulter(client.GetKubeClient().Discovery().OpenAPIV3())
+}
+
+// Default returns a copy of the resource with the defaults declared by its schema set,
+// a nil defaulter returns the resource unchanged
+func (d *schemaDefaulter) Default(resource *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if d == nil {
+		return resource, nil
+	}
+	document, err := json.Marshal(resource.Object)
+	if err != nil {
+		return nil, err
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if _, err := d.load(false); err != nil {
+		return nil, err
+	}
+	_, defaulted, err := d.validator.Parse(document)
+	if err != nil && strings.Contains(err.Error(), "failed to retrieve validator") {
+		if reloaded, loadErr := d.load(true); loadErr == nil && reloaded {
+			_, defaulted, err = d.validator.Parse(document)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	// decoding sets an empty creation timestamp and status, they would never match the persisted resource
+	if _, found, _ := unstructured.NestedFieldNoCopy(resource.Object, "metadata", "creationTimestamp"); !found {
+		unstructured.RemoveNestedField(defaulted.Object, "metadata", "creationTimestamp")
+	}
+	if _, found := resource.Object["status"]; !found {
+		delete(defaulted.Object, "status")
+	}
+	return defaulted, nil
+}
+
+// load loads the schemas if they were not loaded yet or if reload is true, at most once per schemaReloadInterval,
+// it returns true when the schemas were loaded
+func (d *schemaDefaulter) load(reload bool) (bool, error) {
+	if d.validator != nil && !reload {
+		return false, nil
+	}
+	if !d.loadedAt.IsZero() && time.Since(d.loadedAt) < schemaReloadInterval {
+		return false, d.loadErr
+	}
+	d.loadedAt = time.Now()
+	v, err := validator.New(d.client)
+	if err != nil {
+		d.loadErr = fmt.Errorf("failed to load the OpenAPI schemas: %w", err)
+		return false, d.loadErr
+	}
+	d.validator, d.loadErr = v, nil
+	return true, nil
+}
@@ -0,0 +1,42 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kubectl-validate/pkg/openapiclient"
+)
+
+func Test_schemaDefaulter(t *testing.T) {
+	defaulter := newSchemaDefaulter(openapiclient.NewHardcodedBuiltins("1.28"))
+	service := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata":   map[string]interface{}{"name": "app", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"ports": []interface{}{map[string]interface{}{"port": int64(80), "targetPort": int64(8080)}},
+		},
+	}}
+	defaulted, err := defaulter.Default(service)
+	assert.NoError(t, err)
+	ports, _, _ := unstructured.NestedSlice(defaulted.Object, "spec", "ports")
+	assert.Equal(t, []interface{}{map[string]interface{}{"port": int64(80), "targetPort": int64(8080), "protocol": "TCP"}}, ports)
+	assert.Equal(t, map[string]interface{}{"name": "app", "namespace": "default"}, defaulted.Object["metadata"])
+	assert.NotContains(t, defaulted.Object, "status")
+	// the input is not modified
+	ports, _, _ = unstructured.NestedSlice(service.Object, "spec", "ports")
+	assert.Equal(t, []interface{}{map[string]interface{}{"port": int64(80), "targetPort": int64(8080)}}, ports)
+
+	unknown := &unstructured.Unstructured{}
+	unknown.SetAPIVersion("example.com/v1")
+	unknown.SetKind("Unknown")
+	unknown.SetName("unknown")
+	_, err = defaulter.Default(unknown)
+	assert.Error(t, err)
+
+	var nilDefaulter *schemaDefaulter
+	same, err := nilDefaulter.Default(service)
+	assert.NoError(t, err)
+	assert.Same(t, service, same)
+}
@@ -0,0 +1,52 @@
+package generate
+
+import (
+	"context"
+	"slices"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// addTriggerFinalizer blocks the deletion of the trigger until its downstream resources are deleted
+func addTriggerFinalizer(client dclient.Interface, trigger kyvernov1.ResourceSpec) error {
+	return updateTriggerFinalizer(client, trigger, true)
+}
+
+// removeTriggerFinalizer releases the trigger once its downstream resources are deleted
+func removeTriggerFinalizer(client dclient.Interface, trigger kyvernov1.ResourceSpec) error {
+	return updateTriggerFinalizer(client, trigger, false)
+}
+
+func updateTriggerFinalizer(client dclient.Interface, trigger kyvernov1.ResourceSpec, add bool) error {
+	obj, err := client.GetResource(context.TODO(), trigger.GetAPIVersion(), trigger.GetKind(), trigger.GetNamespace(), trigger.GetName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if trigger.GetUID() != "" && obj.GetUID() != trigger.GetUID() {
+		return nil
+	}
+	finalizers := obj.GetFinalizers()
+	if slices.Contains(finalizers, common.GenerateDownstreamFinalizer) == add {
+		return nil
+	}
+	if add {
+		// the downstream resources are being deleted already
+		if obj.GetDeletionTimestamp() != nil {
+			return nil
+		}
+		finalizers = append(finalizers, common.GenerateDownstreamFinalizer)
+	} else {
+		finalizers = slices.DeleteFunc(finalizers, func(finalizer string) bool {
+			return finalizer == common.GenerateDownstreamFinalizer
+		})
+	}
+	obj.SetFinalizers(finalizers)
+	_, err = client.UpdateResource(context.TODO(), obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj, false)
+	return err
+}
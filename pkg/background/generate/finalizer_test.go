@@ -0,0 +1,48 @@
+package generate
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_triggerFinalizer(t *testing.T) {
+	trigger := &unstructured.Unstructured{}
+	trigger.SetAPIVersion("v1")
+	trigger.SetKind("ConfigMap")
+	trigger.SetNamespace("default")
+	trigger.SetName("trigger")
+	trigger.SetFinalizers([]string{"example.com/other"})
+	client, err := dclient.NewFakeClient(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{{Version: "v1", Resource: "configmaps"}: "ConfigMapList"},
+		trigger,
+	)
+	assert.NoError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+	spec := kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "trigger"}
+	getFinalizers := func() []string {
+		obj, err := client.GetResource(context.TODO(), "v1", "ConfigMap", "default", "trigger")
+		assert.NoError(t, err)
+		return obj.GetFinalizers()
+	}
+
+	assert.NoError(t, addTriggerFinalizer(client, spec))
+	assert.Equal(t, []string{"example.com/other", common.GenerateDownstreamFinalizer}, getFinalizers())
+	// adding twice is a no-op
+	assert.NoError(t, addTriggerFinalizer(client, spec))
+	assert.Equal(t, []string{"example.com/other", common.GenerateDownstreamFinalizer}, getFinalizers())
+
+	assert.NoError(t, removeTriggerFinalizer(client, spec))
+	assert.Equal(t, []string{"example.com/other"}, getFinalizers())
+
+	// a missing trigger is not an error
+	assert.NoError(t, removeTriggerFinalizer(client, kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "missing"}))
+}
@@ -31,7 +31,9 @@ import (
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	validationpolicy "github.com/kyverno/kyverno/pkg/validation/policy"
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 	admissionv1 "k8s.io/api/admission/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -48,6 +50,7 @@ type GenerateController struct {
 	kyvernoClient versioned.Interface
 	statusControl common.StatusControlInterface
 	engine        engineapi.Engine
+	defaulter     *schemaDefaulter
 
 	// listers
 	urLister      kyvernov1beta1listers.UpdateRequestNamespaceLister
@@ -82,6 +85,7 @@ func NewGenerateController(
 		kyvernoClient: kyvernoClient,
 		statusControl: statusControl,
 		engine:        engine,
+		defaulter:     newClusterSchemaDefaulter(client),
 		policyLister:  policyLister,
 		npolicyLister: npolicyLister,
 		urLister:      urLister,
@@ -98,19 +102,21 @@ func (c *GenerateController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) error {
 	logger := c.log.WithValues("name", ur.GetName(), "policy", ur.Spec.GetPolicyKey(), "resource", ur.Spec.GetResource().String())
 	var err error
 	var genResources []kyvernov1.ResourceSpec
+	var namespaces []kyvernov1beta1.NamespaceStatus
+	var targets []kyvernov1beta1.TargetStatus
 	logger.Info("start processing UR", "ur", ur.Name, "resourceVersion", ur.GetResourceVersion())
 
 	trigger, err := c.getTrigger(ur.Spec)
 	if err != nil || trigger == nil {
 		logger.V(3).Info("the trigger resource does not exist or is pending creation")
-		if err := updateStatus(c.statusControl, *ur, err, nil); err != nil {
+		if err := updateStatus(c.statusControl, *ur, err, nil, nil, nil); err != nil {
 			return err
 		}
 		return nil
 	}
 
 	namespaceLabels := engineutils.GetNamespaceSelectorsFromNamespaceLister(trigger.GetKind(), trigger.GetNamespace(), c.nsLister, logger)
-	genResources, err = c.applyGenerate(*trigger, *ur, namespaceLabels)
+	genResources, namespaces, targets, err = c.applyGenerate(*trigger, *ur, namespaceLabels)
 	if err != nil {
 		if strings.Contains(err.Error(), doesNotApply) {
 			ur.Status.State = kyvernov1beta1.Completed
@@ -129,7 +135,7 @@ func (c *GenerateController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) error {
 		c.eventGen.Add(events...)
 	}
 
-	if err = updateStatus(c.statusControl, *ur, err, genResources); err != nil {
+	if err = updateStatus(c.statusControl, *ur, err, genResources, namespaces, targets); err != nil {
 		return err
 	}
 	return err
@@ -197,24 +203,24 @@ func (c *GenerateController) getTriggerForCreateOperation(spec kyvernov1beta1.Up
 	return trigger, err
 }
 
-func (c *GenerateController) applyGenerate(resource unstructured.Unstructured, ur kyvernov1beta1.UpdateRequest, namespaceLabels map[string]string) ([]kyvernov1.ResourceSpec, error) {
+func (c *GenerateController) applyGenerate(resource unstructured.Unstructured, ur kyvernov1beta1.UpdateRequest, namespaceLabels map[string]string) ([]kyvernov1.ResourceSpec, []kyvernov1beta1.NamespaceStatus, []kyvernov1beta1.TargetStatus, error) {
 	logger := c.log.WithValues("name", ur.GetName(), "policy", ur.Spec.GetPolicyKey(), "resource", ur.Spec.GetResource().String())
 	logger.V(3).Info("applying generate policy rule")
 
 	policy, err := c.getPolicySpec(ur)
 	if err != nil && !apierrors.IsNotFound(err) {
 		logger.Error(err, "error in fetching policy")
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	if ur.Spec.DeleteDownstream || apierrors.IsNotFound(err) {
 		err = c.deleteDownstream(policy, &ur)
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	policyContext, err := common.NewBackgroundContext(logger, c.client, &ur, policy, &resource, c.configuration, c.jp, namespaceLabels)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	admissionRequest := ur.Spec.Context.AdmissionRequestInfo.AdmissionRequest
@@ -222,7 +228,7 @@ func (c *GenerateController) applyGenerate(resource unstructured.Unstructured, u
 		var gvk schema.GroupVersionKind
 		gvk, err = c.client.Discovery().GetGVKFromGVR(schema.GroupVersionResource(admissionRequest.Resource))
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, err
 		}
 		policyContext = policyContext.WithResourceKind(gvk, admissionRequest.SubResource)
 	}
@@ -231,7 +237,7 @@ func (c *GenerateController) applyGenerate(resource unstructured.Unstructured, u
 	engineResponse := c.engine.Generate(context.Background(), policyContext)
 	if len(engineResponse.PolicyResponse.Rules) == 0 {
 		logger.V(4).Info(doesNotApply)
-		return nil, errors.New(doesNotApply)
+		return nil, nil, nil, errors.New(doesNotApply)
 	}
 
 	var applicableRules []string
@@ -283,7 +289,7 @@ func (c *GenerateController) applyGenerate(resource unstructured.Unstructured, u
 	}
 
 	// Apply the generate rule on resource
-	genResources, err := c.ApplyGeneratePolicy(logger, policyContext, ur, applicableRules)
+	genResources, namespaces, targets, err := c.ApplyGeneratePolicy(logger, policyContext, ur, applicableRules)
 	if err == nil {
 		for _, res := range genResources {
 			e := event.NewResourceGenerationEvent(ur.Spec.Policy, ur.Spec.Rule, event.GeneratePolicyController, res)
@@ -294,7 +300,7 @@ func (c *GenerateController) applyGenerate(resource unstructured.Unstructured, u
 		c.eventGen.Add(e...)
 	}
 
-	return genResources, err
+	return genResources, namespaces, targets, err
 }
 
 // getPolicySpec gets the policy spec from the ClusterPolicy/Policy
@@ -318,20 +324,20 @@ func (c *GenerateController) getPolicySpec(ur kyvernov1beta1.UpdateRequest) (kyv
 	return npolicyObj, nil
 }
 
-func updateStatus(statusControl common.StatusControlInterface, ur kyvernov1beta1.UpdateRequest, err error, genResources []kyvernov1.ResourceSpec) error {
+func updateStatus(statusControl common.StatusControlInterface, ur kyvernov1beta1.UpdateRequest, err error, genResources []kyvernov1.ResourceSpec, namespaces []kyvernov1beta1.NamespaceStatus, targets []kyvernov1beta1.TargetStatus) error {
 	if err != nil {
-		if _, err := statusControl.Failed(ur.GetName(), err.Error(), genResources); err != nil {
+		if _, err := statusControl.Failed(ur.GetName(), err, genResources, namespaces, targets); err != nil {
 			return err
 		}
 	} else {
-		if _, err := statusControl.Success(ur.GetName(), genResources); err != nil {
+		if _, err := statusControl.Success(ur.GetName(), genResources, namespaces, targets); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext *engine.PolicyContext, ur kyvernov1beta1.UpdateRequest, applicableRules []string) (genResources []kyvernov1.ResourceSpec, err error) {
+func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext *engine.PolicyContext, ur kyvernov1beta1.UpdateRequest, applicableRules []string) (genResources []kyvernov1.ResourceSpec, namespaces []kyvernov1beta1.NamespaceStatus, targets []kyvernov1beta1.TargetStatus, err error) {
 	// Get the response as the actions to be performed on the resource
 	// - - substitute values
 	policy := policyContext.Policy()
@@ -355,7 +361,7 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 		if rule.Generation.Synchronize {
 			ruleRaw, err := json.Marshal(rule.DeepCopy())
 			if err != nil {
-				return nil, fmt.Errorf("failed to serialize the policy: %v", err)
+				return nil, nil, nil, fmt.Errorf("failed to serialize the policy: %v", err)
 			}
 			vars := regex.RegexVariables.FindAllStringSubmatch(string(ruleRaw), -1)
 
@@ -370,40 +376,103 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 
 		startTime := time.Now()
 		var genResource []kyvernov1.ResourceSpec
+		var genNamespaces []kyvernov1beta1.NamespaceStatus
+		var genTargets []kyvernov1beta1.TargetStatus
 		if applyRules == kyvernov1.ApplyOne && applyCount > 0 {
 			break
 		}
 
+		if err := jsonContext.AddRuleInfo(policy.GetName(), rule.Name, string(engineapi.Generation)); err != nil {
+			log.Error(err, "cannot add rule info to context")
+			return nil, nil, nil, err
+		}
+
 		// add configmap json data to context
 		if err := c.engine.ContextLoader(policyContext.Policy(), rule)(context.TODO(), rule.Context, policyContext.JSONContext()); err != nil {
 			log.Error(err, "cannot add configmaps to context")
-			return nil, err
+			return nil, nil, nil, err
 		}
 
 		if rule, err = variables.SubstituteAllInRule(log, policyContext.JSONContext(), rule); err != nil {
 			log.Error(err, "variable substitution failed for rule", "rule", rule.Name)
-			return nil, err
+			return nil, nil, nil, err
 		}
 
-		genResource, err = applyRule(log, c.client, rule, resource, jsonContext, policy, ur)
+		genResource, genNamespaces, genTargets, err = applyRule(log, c.client, c.defaulter, rule, resource, jsonContext, policy, ur)
+		namespaces = append(namespaces, genNamespaces...)
+		targets = append(targets, genTargets...)
 		if err != nil {
 			log.Error(err, "failed to apply generate rule", "policy", policy.GetName(), "rule", rule.Name, "resource", resource.GetName())
-			return nil, err
+			return nil, namespaces, targets, err
+		}
+		if rule.Generation.Cascade {
+			if err := addTriggerFinalizer(c.client, common.ResourceSpecFromUnstructured(resource)); err != nil {
+				log.Error(err, "failed to add the finalizer to the trigger", "rule", rule.Name, "resource", resource.GetName())
+				return nil, namespaces, targets, err
+			}
 		}
 		ruleNameToProcessingTime[rule.Name] = time.Since(startTime)
 		genResources = append(genResources, genResource...)
 		applyCount++
 	}
 
-	return genResources, nil
+	return genResources, namespaces, targets, nil
 }
 
-func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, trigger unstructured.Unstructured, ctx enginecontext.EvalInterface, policy kyvernov1.PolicyInterface, ur kyvernov1beta1.UpdateRequest) ([]kyvernov1.ResourceSpec, error) {
+func applyRule(log logr.Logger, client dclient.Interface, defaulter *schemaDefaulter, rule kyvernov1.Rule, trigger unstructured.Unstructured, ctx enginecontext.EvalInterface, policy kyvernov1.PolicyInterface, ur kyvernov1beta1.UpdateRequest) ([]kyvernov1.ResourceSpec, []kyvernov1beta1.NamespaceStatus, []kyvernov1beta1.TargetStatus, error) {
+	target := rule.Generation.ResourceSpec
+	if !rule.Generation.HasMultipleNamespaces() {
+		genResources, targets, err := applyTarget(log, client, defaulter, rule, target, trigger, policy, ur)
+		return genResources, nil, targets, err
+	}
+
+	targetNamespaces, err := getTargetNamespaces(client, rule.Generation)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var genResources []kyvernov1.ResourceSpec
+	var namespaces []kyvernov1beta1.NamespaceStatus
+	var targets []kyvernov1beta1.TargetStatus
+	var errs []error
+	for _, namespace := range targetNamespaces {
+		target.Namespace = namespace
+		resources, namespaceTargets, err := applyTarget(log, client, defaulter, rule, target, trigger, policy, ur)
+		genResources = append(genResources, resources...)
+		targets = append(targets, namespaceTargets...)
+		status := kyvernov1beta1.NamespaceStatus{Namespace: namespace, State: kyvernov1beta1.Completed}
+		if err != nil {
+			status.State = kyvernov1beta1.Failed
+			status.Message = err.Error()
+			errs = append(errs, fmt.Errorf("failed to generate resource in namespace %s: %w", namespace, err))
+		}
+		namespaces = append(namespaces, status)
+	}
+	return genResources, namespaces, targets, multierr.Combine(errs...)
+}
+
+// getTargetNamespaces returns the namespaces listed or selected by a generate rule, namespaces being deleted are skipped
+func getTargetNamespaces(client dclient.Interface, generation kyvernov1.Generation) ([]string, error) {
+	if generation.NamespaceSelector == nil {
+		return generation.Namespaces, nil
+	}
+	list, err := client.ListResource(context.TODO(), "v1", "Namespace", "", generation.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list target namespaces: %w", err)
+	}
+	var namespaces []string
+	for _, namespace := range list.Items {
+		if namespace.GetDeletionTimestamp() != nil {
+			continue
+		}
+		namespaces = append(namespaces, namespace.GetName())
+	}
+	return namespaces, nil
+}
+
+func applyTarget(log logr.Logger, client dclient.Interface, defaulter *schemaDefaulter, rule kyvernov1.Rule, target kyvernov1.ResourceSpec, trigger unstructured.Unstructured, policy kyvernov1.PolicyInterface, ur kyvernov1beta1.UpdateRequest) ([]kyvernov1.ResourceSpec, []kyvernov1beta1.TargetStatus, error) {
 	responses := []generateResponse{}
 	var err error
-	var newGenResources []kyvernov1.ResourceSpec
 
-	target := rule.Generation.ResourceSpec
 	logger := log.WithValues("target", target.String())
 
 	if rule.Generation.Clone.Name != "" {
@@ -412,96 +481,180 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, t
 	} else if len(rule.Generation.CloneList.Kinds) != 0 {
 		responses = manageCloneList(logger.WithValues("type", "cloneList"), target.GetNamespace(), ur, policy, rule, client)
 	} else {
-		resp := manageData(logger.WithValues("type", "data"), target, rule.Generation.RawData, rule.Generation.Synchronize, ur, client)
+		data := rule.Generation.RawData
+		if rule.Generation.DataOverride != nil {
+			if data, err = applyDataOverride(client, rule.Generation.DataOverride, target.GetNamespace(), data); err != nil {
+				status := kyvernov1beta1.TargetStatus{Resource: target, State: kyvernov1beta1.Failed, Message: err.Error()}
+				return nil, []kyvernov1beta1.TargetStatus{status}, common.NewTargetError(target, err)
+			}
+		}
+		resp := manageData(logger.WithValues("type", "data"), target, data, rule.Generation.Synchronize, ur, client)
 		responses = append(responses, resp)
 	}
+	return applyResponses(logger, client, defaulter, rule, trigger, policy, responses)
+}
 
+// applyResponses applies the downstream resources of the generate responses, a downstream resource
+// that could not be applied is reported in the returned target statuses and does not stop the others
+func applyResponses(logger logr.Logger, client dclient.Interface, defaulter *schemaDefaulter, rule kyvernov1.Rule, trigger unstructured.Unstructured, policy kyvernov1.PolicyInterface, responses []generateResponse) ([]kyvernov1.ResourceSpec, []kyvernov1beta1.TargetStatus, error) {
+	var newGenResources []kyvernov1.ResourceSpec
+	var targets []kyvernov1beta1.TargetStatus
+	var errs []error
 	for _, response := range responses {
 		targetMeta := response.GetTarget()
-		if response.GetError() != nil {
-			logger.Error(response.GetError(), "failed to generate resource", "mode", response.GetAction())
-			return newGenResources, err
-		}
-
+		status := kyvernov1beta1.TargetStatus{Resource: targetMeta, State: kyvernov1beta1.Completed}
 		if response.GetAction() == Skip {
-			continue
+			status.State = kyvernov1beta1.Skip
 		}
-
-		logger.V(3).Info("applying generate rule", "mode", response.GetAction())
-		if response.GetData() == nil && response.GetAction() == Update {
-			logger.V(4).Info("no changes required for generate target resource")
-			return newGenResources, nil
+		generated, applied, err := applyResponse(logger, client, defaulter, rule, trigger, policy, response)
+		if err != nil {
+			logger.Error(err, "failed to generate resource", "mode", response.GetAction(), "resource", targetMeta.String())
+			status.State = kyvernov1beta1.Failed
+			status.Message = err.Error()
+			errs = append(errs, common.NewTargetError(targetMeta, err))
+		} else if generated {
+			newGenResources = append(newGenResources, targetMeta)
 		}
-
-		newResource := &unstructured.Unstructured{}
-		newResource.SetUnstructuredContent(response.GetData())
-		newResource.SetName(targetMeta.GetName())
-		newResource.SetNamespace(targetMeta.GetNamespace())
-		if newResource.GetKind() == "" {
-			newResource.SetKind(targetMeta.GetKind())
+		// secrets are not recorded to avoid copying their data to the update request
+		if applied != nil && applied.GetKind() != "Secret" {
+			if raw, err := json.Marshal(applied.Object); err == nil {
+				status.Applied = &apiextv1.JSON{Raw: raw}
+			}
 		}
+		targets = append(targets, status)
+	}
+	return newGenResources, targets, multierr.Combine(errs...)
+}
 
-		newResource.SetAPIVersion(targetMeta.GetAPIVersion())
-		common.ManageLabels(newResource, trigger, policy, rule.Name)
-		if response.GetAction() == Create {
-			newResource.SetResourceVersion("")
+// applyResponse creates or updates the downstream resource of a generate response, it returns true when the
+// resource was created and, for resources generated from data, the resource applied with its schema defaults set
+func applyResponse(logger logr.Logger, client dclient.Interface, defaulter *schemaDefaulter, rule kyvernov1.Rule, trigger unstructured.Unstructured, policy kyvernov1.PolicyInterface, response generateResponse) (bool, *unstructured.Unstructured, error) {
+	targetMeta := response.GetTarget()
+	if response.GetError() != nil {
+		return false, nil, response.GetError()
+	}
+
+	if response.GetAction() == Skip {
+		return false, nil, nil
+	}
+
+	logger.V(3).Info("applying generate rule", "mode", response.GetAction())
+	if response.GetData() == nil && response.GetAction() == Update {
+		logger.V(4).Info("no changes required for generate target resource")
+		return false, nil, nil
+	}
+
+	var err error
+	newResource := &unstructured.Unstructured{}
+	newResource.SetUnstructuredContent(response.GetData())
+	newResource.SetName(targetMeta.GetName())
+	newResource.SetNamespace(targetMeta.GetNamespace())
+	if newResource.GetKind() == "" {
+		newResource.SetKind(targetMeta.GetKind())
+	}
+
+	newResource.SetAPIVersion(targetMeta.GetAPIVersion())
+	common.ManageLabels(newResource, trigger, policy, rule.Name)
+	// cloned resources are copies of persisted resources, their defaults are already set
+	var applied *unstructured.Unstructured
+	if genType, _ := rule.Generation.GetTypeAndSync(); genType == kyvernov1.Data {
+		defaulted, err := defaulter.Default(newResource)
+		if err != nil {
+			logger.V(3).Info("failed to set the schema defaults, the resource is applied as is", "reason", err.Error())
+		} else {
+			newResource = defaulted
+		}
+		applied = newResource
+	}
+	if response.GetAction() == Create {
+		newResource.SetResourceVersion("")
+		if policy.GetSpec().UseServerSideApply {
+			_, err = client.ApplyResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName(), newResource, false, "generate")
+		} else {
+			_, err = client.CreateResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), newResource, false)
+		}
+		if err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				return false, nil, err
+			}
+			if err := checkClusterScopedTargetOwner(client, policy, targetMeta); err != nil {
+				return false, nil, err
+			}
+		}
+		logger.V(2).Info("created generate target resource")
+		return true, applied, nil
+	} else if response.GetAction() == Update {
+		generatedObj, err := client.GetResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName())
+		if err != nil {
+			logger.V(2).Info("target resource not found, creating new target")
 			if policy.GetSpec().UseServerSideApply {
 				_, err = client.ApplyResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName(), newResource, false, "generate")
 			} else {
 				_, err = client.CreateResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), newResource, false)
 			}
 			if err != nil {
-				if !apierrors.IsAlreadyExists(err) {
-					return newGenResources, err
-				}
+				return false, nil, err
 			}
-			logger.V(2).Info("created generate target resource")
-			newGenResources = append(newGenResources, targetMeta)
-		} else if response.GetAction() == Update {
-			generatedObj, err := client.GetResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName())
-			if err != nil {
-				logger.V(2).Info("target resource not found, creating new target")
-				if policy.GetSpec().UseServerSideApply {
-					_, err = client.ApplyResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName(), newResource, false, "generate")
-				} else {
-					_, err = client.CreateResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), newResource, false)
-				}
-				if err != nil {
-					return newGenResources, err
-				}
-				newGenResources = append(newGenResources, targetMeta)
-			} else {
-				if !rule.Generation.Synchronize {
-					logger.V(4).Info("synchronize disabled, skip syncing changes")
-					continue
-				}
-				if err := validate.MatchPattern(logger, generatedObj.Object, newResource.Object); err == nil {
-					logger.V(4).Info("patterns match, skipping updates")
-					continue
-				}
-				logger.V(4).Info("updating existing resource")
-				if targetMeta.GetAPIVersion() == "" {
-					generatedResourceAPIVersion := generatedObj.GetAPIVersion()
-					newResource.SetAPIVersion(generatedResourceAPIVersion)
-				}
-				if targetMeta.GetNamespace() == "" {
-					newResource.SetNamespace("default")
-				}
+			return true, applied, nil
+		}
+		if err := validateClusterScopedTargetOwner(generatedObj, policy); err != nil {
+			return false, nil, err
+		}
+		if !rule.Generation.Synchronize {
+			logger.V(4).Info("synchronize disabled, skip syncing changes")
+			return false, nil, nil
+		}
+		if err := validate.MatchPattern(logger, generatedObj.Object, newResource.Object); err == nil {
+			logger.V(4).Info("patterns match, skipping updates")
+			return false, applied, nil
+		}
+		logger.V(4).Info("updating existing resource")
+		if targetMeta.GetAPIVersion() == "" {
+			generatedResourceAPIVersion := generatedObj.GetAPIVersion()
+			newResource.SetAPIVersion(generatedResourceAPIVersion)
+		}
+		if targetMeta.GetNamespace() == "" {
+			newResource.SetNamespace("default")
+		}
 
-				if policy.GetSpec().UseServerSideApply {
-					_, err = client.ApplyResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName(), newResource, false, "generate")
-				} else {
-					_, err = client.UpdateResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), newResource, false)
-				}
-				if err != nil {
-					logger.Error(err, "failed to update resource")
-					return newGenResources, err
-				}
-			}
-			logger.V(3).Info("updated generate target resource")
+		if policy.GetSpec().UseServerSideApply {
+			_, err = client.ApplyResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName(), newResource, false, "generate")
+		} else {
+			_, err = client.UpdateResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), newResource, false)
+		}
+		if err != nil {
+			logger.Error(err, "failed to update resource")
+			return false, nil, err
 		}
+		logger.V(3).Info("updated generate target resource")
+		return false, applied, nil
 	}
-	return newGenResources, nil
+	return false, nil, nil
+}
+
+// checkClusterScopedTargetOwner fetches an existing cluster-scoped target and checks it is managed by the policy
+func checkClusterScopedTargetOwner(client dclient.Interface, policy kyvernov1.PolicyInterface, target kyvernov1.ResourceSpec) error {
+	if !policy.IsNamespaced() || target.GetNamespace() != "" {
+		return nil
+	}
+	existing, err := client.GetResource(context.TODO(), target.GetAPIVersion(), target.GetKind(), "", target.GetName())
+	if err != nil {
+		return err
+	}
+	return validateClusterScopedTargetOwner(existing, policy)
+}
+
+// validateClusterScopedTargetOwner prevents a namespaced policy from taking over a cluster-scoped resource
+// that was not generated by the same policy, e.g. created by a policy from another namespace or by a user
+func validateClusterScopedTargetOwner(existing *unstructured.Unstructured, policy kyvernov1.PolicyInterface) error {
+	if !policy.IsNamespaced() || existing.GetNamespace() != "" {
+		return nil
+	}
+	labels := existing.GetLabels()
+	if labels[common.GeneratePolicyLabel] != policy.GetName() || labels[common.GeneratePolicyNamespaceLabel] != policy.GetNamespace() {
+		return fmt.Errorf("the cluster-scoped resource %s/%s is not managed by the policy %s/%s", existing.GetKind(), existing.GetName(), policy.GetNamespace(), policy.GetName())
+	}
+	return nil
 }
 
 func GetUnstrRule(rule *kyvernov1.Generation) (*unstructured.Unstructured, error) {
@@ -0,0 +1,159 @@
+package generate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func newNamespace(name string, labels map[string]string) *unstructured.Unstructured {
+	namespace := &unstructured.Unstructured{}
+	namespace.SetAPIVersion("v1")
+	namespace.SetKind("Namespace")
+	namespace.SetName(name)
+	namespace.SetLabels(labels)
+	return namespace
+}
+
+func Test_getTargetNamespaces(t *testing.T) {
+	client, err := dclient.NewFakeClient(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{{Version: "v1", Resource: "namespaces"}: "NamespaceList"},
+		newNamespace("tenant-a", map[string]string{"tenant": "foo"}),
+		newNamespace("tenant-b", map[string]string{"tenant": "foo"}),
+		newNamespace("other", map[string]string{"tenant": "bar"}),
+	)
+	assert.NoError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+	tests := []struct {
+		name       string
+		generation kyvernov1.Generation
+		want       []string
+	}{{
+		name:       "namespaces",
+		generation: kyvernov1.Generation{Namespaces: []string{"tenant-a", "other"}},
+		want:       []string{"tenant-a", "other"},
+	}, {
+		name:       "namespace selector",
+		generation: kyvernov1.Generation{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "foo"}}},
+		want:       []string{"tenant-a", "tenant-b"},
+	}, {
+		name:       "no match",
+		generation: kyvernov1.Generation{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "baz"}}},
+		want:       nil,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getTargetNamespaces(client, tt.generation)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func Test_validateClusterScopedTargetOwner(t *testing.T) {
+	newConfigMap := func() *unstructured.Unstructured {
+		configMap := &unstructured.Unstructured{}
+		configMap.SetAPIVersion("v1")
+		configMap.SetKind("ConfigMap")
+		configMap.SetName("viewer")
+		configMap.SetNamespace("tenant-a")
+		return configMap
+	}
+	newClusterRole := func(labels map[string]string) *unstructured.Unstructured {
+		clusterRole := &unstructured.Unstructured{}
+		clusterRole.SetAPIVersion("rbac.authorization.k8s.io/v1")
+		clusterRole.SetKind("ClusterRole")
+		clusterRole.SetName("tenant-a-viewer")
+		clusterRole.SetLabels(labels)
+		return clusterRole
+	}
+	policy := &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Name: "viewer", Namespace: "tenant-a"}}
+	clusterPolicy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "viewer"}}
+	tests := []struct {
+		name     string
+		existing *unstructured.Unstructured
+		policy   kyvernov1.PolicyInterface
+		wantErr  bool
+	}{{
+		name:     "managed by the policy",
+		existing: newClusterRole(map[string]string{common.GeneratePolicyLabel: "viewer", common.GeneratePolicyNamespaceLabel: "tenant-a"}),
+		policy:   policy,
+	}, {
+		name:     "managed by a policy from another namespace",
+		existing: newClusterRole(map[string]string{common.GeneratePolicyLabel: "viewer", common.GeneratePolicyNamespaceLabel: "tenant-b"}),
+		policy:   policy,
+		wantErr:  true,
+	}, {
+		name:     "not generated",
+		existing: newClusterRole(nil),
+		policy:   policy,
+		wantErr:  true,
+	}, {
+		name:     "cluster policy",
+		existing: newClusterRole(nil),
+		policy:   clusterPolicy,
+	}, {
+		name:     "namespaced resource",
+		existing: newConfigMap(),
+		policy:   policy,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateClusterScopedTargetOwner(tt.existing, tt.policy)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_applyResponses(t *testing.T) {
+	trigger := &unstructured.Unstructured{}
+	trigger.SetAPIVersion("v1")
+	trigger.SetKind("Namespace")
+	trigger.SetName("tenant-a")
+	client, err := dclient.NewFakeClient(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{{Version: "v1", Resource: "configmaps"}: "ConfigMapList"},
+	)
+	assert.NoError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "tenant"}}
+	rule := kyvernov1.Rule{Name: "generate"}
+	settings := kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Namespace: "tenant-a", Name: "settings"}
+	regcred := kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "Secret", Namespace: "tenant-a", Name: "regcred"}
+	quota := kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ResourceQuota", Namespace: "tenant-a", Name: "quota"}
+	responses := []generateResponse{
+		newCreateGenerateResponse(map[string]interface{}{"data": map[string]interface{}{"foo": "bar"}}, settings, nil),
+		newSkipGenerateResponse(nil, regcred, errors.New("source not found")),
+		newSkipGenerateResponse(nil, quota, nil),
+	}
+
+	genResources, targets, err := applyResponses(logr.Discard(), client, nil, rule, *trigger, policy, responses)
+	assert.Error(t, err)
+	assert.Equal(t, []kyvernov1.ResourceSpec{settings}, genResources)
+	assert.Equal(t, []kyvernov1beta1.TargetStatus{
+		{Resource: settings, State: kyvernov1beta1.Completed},
+		{Resource: regcred, State: kyvernov1beta1.Failed, Message: "source not found"},
+		{Resource: quota, State: kyvernov1beta1.Skip},
+	}, targets)
+	var targetErr common.TargetError
+	assert.ErrorAs(t, err, &targetErr)
+	assert.Equal(t, regcred, targetErr.Target)
+	_, err = client.GetResource(context.TODO(), "v1", "ConfigMap", "tenant-a", "settings")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,87 @@
+package generate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// applyDataOverride merges the override read from the annotation of the target namespace into the data,
+// the data is returned unchanged when the namespace doesn't have the annotation
+func applyDataOverride(client dclient.Interface, override *kyvernov1.DataOverride, namespace string, data *apiextv1.JSON) (*apiextv1.JSON, error) {
+	if namespace == "" || data == nil {
+		return data, nil
+	}
+	ns, err := client.GetResource(context.TODO(), "v1", "Namespace", "", namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target namespace %s: %w", namespace, err)
+	}
+	raw, ok := ns.GetAnnotations()[override.Annotation]
+	if !ok {
+		return data, nil
+	}
+	if err := validateDataOverride(override, raw); err != nil {
+		return nil, fmt.Errorf("invalid override in annotation %s of namespace %s: %w", override.Annotation, namespace, err)
+	}
+	merged, err := jsonpatch.MergePatch(data.Raw, []byte(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge override in annotation %s of namespace %s: %w", override.Annotation, namespace, err)
+	}
+	return &apiextv1.JSON{Raw: merged}, nil
+}
+
+// validateDataOverride checks the override size and that it only sets allowed fields
+func validateDataOverride(override *kyvernov1.DataOverride, raw string) error {
+	if len(raw) > override.GetMaxSize() {
+		return fmt.Errorf("size %d exceeds the maximum of %d bytes", len(raw), override.GetMaxSize())
+	}
+	var patch map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &patch); err != nil {
+		return fmt.Errorf("not a JSON object: %w", err)
+	}
+	var allowed [][]string
+	for _, path := range override.GetAllowedPaths() {
+		allowed = append(allowed, strings.Split(path, "."))
+	}
+	return checkAllowedPaths(patch, nil, allowed)
+}
+
+func checkAllowedPaths(patch map[string]interface{}, prefix []string, allowed [][]string) error {
+	keys := make([]string, 0, len(patch))
+	for key := range patch {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		path := append(slices.Clone(prefix), key)
+		covered, parent := false, false
+		for _, a := range allowed {
+			if slices.Equal(a, path) {
+				covered = true
+				break
+			}
+			if len(a) > len(path) && slices.Equal(a[:len(path)], path) {
+				parent = true
+			}
+		}
+		if covered {
+			continue
+		}
+		nested, ok := patch[key].(map[string]interface{})
+		if !parent || !ok {
+			return fmt.Errorf("field %s can't be overridden", strings.Join(path, "."))
+		}
+		if err := checkAllowedPaths(nested, path, allowed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
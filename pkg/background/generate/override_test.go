@@ -0,0 +1,83 @@
+package generate
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/stretchr/testify/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_applyDataOverride(t *testing.T) {
+	const annotation = "quota.example.com/override"
+	withOverride := func(name, value string) *unstructured.Unstructured {
+		namespace := newNamespace(name, nil)
+		namespace.SetAnnotations(map[string]string{annotation: value})
+		return namespace
+	}
+	client, err := dclient.NewFakeClient(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{{Version: "v1", Resource: "namespaces"}: "NamespaceList"},
+		newNamespace("default", nil),
+		withOverride("tenant-a", `{"spec":{"hard":{"pods":"20"}}}`),
+		withOverride("tenant-b", `{"metadata":{"name":"other"}}`),
+		withOverride("tenant-c", `{"spec":{"hard":{"pods":"20","requests.cpu":"100"}}}`),
+		withOverride("tenant-d", `["spec"]`),
+	)
+	assert.NoError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+	data := &apiextv1.JSON{Raw: []byte(`{"spec":{"hard":{"pods":"10","requests.cpu":"4"}}}`)}
+	maxSize := 32
+	tests := []struct {
+		name      string
+		namespace string
+		override  kyvernov1.DataOverride
+		want      string
+		wantErr   string
+	}{{
+		name:      "no annotation",
+		namespace: "default",
+		override:  kyvernov1.DataOverride{Annotation: annotation},
+		want:      `{"spec":{"hard":{"pods":"10","requests.cpu":"4"}}}`,
+	}, {
+		name:      "merged",
+		namespace: "tenant-a",
+		override:  kyvernov1.DataOverride{Annotation: annotation},
+		want:      `{"spec":{"hard":{"pods":"20","requests.cpu":"4"}}}`,
+	}, {
+		name:      "metadata",
+		namespace: "tenant-b",
+		override:  kyvernov1.DataOverride{Annotation: annotation},
+		wantErr:   "field metadata can't be overridden",
+	}, {
+		name:      "allowed paths",
+		namespace: "tenant-c",
+		override:  kyvernov1.DataOverride{Annotation: annotation, AllowedPaths: []string{"spec.hard.pods"}},
+		wantErr:   "field spec.hard.requests.cpu can't be overridden",
+	}, {
+		name:      "max size",
+		namespace: "tenant-c",
+		override:  kyvernov1.DataOverride{Annotation: annotation, MaxSize: &maxSize},
+		wantErr:   "exceeds the maximum of 32 bytes",
+	}, {
+		name:      "not an object",
+		namespace: "tenant-d",
+		override:  kyvernov1.DataOverride{Annotation: annotation},
+		wantErr:   "not a JSON object",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyDataOverride(client, &tt.override, tt.namespace, data)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got.Raw))
+		})
+	}
+}
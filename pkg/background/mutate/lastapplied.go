@@ -0,0 +1,113 @@
+package mutate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// lastApplied is the content of the kyverno.io/last-applied annotation,
+// it maps a policy rule key to the hashes of the patch operations it last applied to the target
+type lastApplied map[string][]string
+
+// getLastApplied returns the patch operations recorded on the resource, an invalid annotation is ignored
+func getLastApplied(resource *unstructured.Unstructured) lastApplied {
+	recorded := lastApplied{}
+	if value, ok := resource.GetAnnotations()[kyverno.AnnotationLastApplied]; ok {
+		if err := json.Unmarshal([]byte(value), &recorded); err != nil {
+			return lastApplied{}
+		}
+	}
+	return recorded
+}
+
+// desiredPatch returns the hashes of the patch operations turning the current target into the patched one,
+// fields maintained by the API server and the tracking annotation are not considered
+func desiredPatch(current, patched *unstructured.Unstructured) ([]string, error) {
+	currentBytes, err := json.Marshal(baseline(current).Object)
+	if err != nil {
+		return nil, err
+	}
+	patchedBytes, err := json.Marshal(baseline(patched).Object)
+	if err != nil {
+		return nil, err
+	}
+	operations, err := jsonpatch.CreatePatch(currentBytes, patchedBytes)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]string, 0, len(operations))
+	for _, operation := range operations {
+		data, err := json.Marshal(operation)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(data)
+		hashes = append(hashes, hex.EncodeToString(sum[:8]))
+	}
+	sort.Strings(hashes)
+	return hashes, nil
+}
+
+func baseline(resource *unstructured.Unstructured) *unstructured.Unstructured {
+	resource = resource.DeepCopy()
+	resource.SetResourceVersion("")
+	resource.SetGeneration(0)
+	resource.SetManagedFields(nil)
+	if annotations := resource.GetAnnotations(); annotations != nil {
+		delete(annotations, kyverno.AnnotationLastApplied)
+		if len(annotations) == 0 {
+			annotations = nil
+		}
+		resource.SetAnnotations(annotations)
+	}
+	return resource
+}
+
+// trackLastApplied compares the patch needed to reach the patched target with the one last applied by the rule,
+// it returns false when the target doesn't need to be updated, either because it's already up to date or
+// because the same patch was already applied and reverted by someone else since.
+// When an update is needed the patched target annotation is set to record the applied patch.
+func trackLastApplied(current, patched *unstructured.Unstructured, key string) (bool, error) {
+	hashes, err := desiredPatch(current, patched)
+	if err != nil {
+		return false, err
+	}
+	if len(hashes) == 0 {
+		return false, nil
+	}
+	recorded := getLastApplied(current)
+	if contains(recorded[key], hashes) {
+		return false, nil
+	}
+	recorded[key] = hashes
+	data, err := json.Marshal(recorded)
+	if err != nil {
+		return false, err
+	}
+	annotations := patched.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[kyverno.AnnotationLastApplied] = string(data)
+	patched.SetAnnotations(annotations)
+	return true, nil
+}
+
+func contains(recorded, hashes []string) bool {
+	set := make(map[string]struct{}, len(recorded))
+	for _, hash := range recorded {
+		set[hash] = struct{}{}
+	}
+	for _, hash := range hashes {
+		if _, ok := set[hash]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package mutate
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func configMap(data map[string]interface{}, annotations map[string]string) *unstructured.Unstructured {
+	resource := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "target",
+			"namespace": "default",
+		},
+		"data": data,
+	}}
+	resource.SetAnnotations(annotations)
+	return resource
+}
+
+func Test_trackLastApplied(t *testing.T) {
+	const key = "policy/rule"
+	// apply the patch a first time
+	current := configMap(map[string]interface{}{"foo": "bar"}, nil)
+	current.SetResourceVersion("1")
+	patched := configMap(map[string]interface{}{"foo": "baz"}, nil)
+	patched.SetResourceVersion("2")
+	update, err := trackLastApplied(current, patched, key)
+	assert.NilError(t, err)
+	assert.Assert(t, update)
+	annotation := patched.GetAnnotations()[kyverno.AnnotationLastApplied]
+	assert.Assert(t, annotation != "")
+	// the target is up to date
+	current = patched.DeepCopy()
+	update, err = trackLastApplied(current, current.DeepCopy(), key)
+	assert.NilError(t, err)
+	assert.Assert(t, !update)
+	// another controller reverted the field, the same patch is not applied again
+	current = configMap(map[string]interface{}{"foo": "bar"}, map[string]string{kyverno.AnnotationLastApplied: annotation})
+	patched = configMap(map[string]interface{}{"foo": "baz"}, map[string]string{kyverno.AnnotationLastApplied: annotation})
+	update, err = trackLastApplied(current, patched, key)
+	assert.NilError(t, err)
+	assert.Assert(t, !update)
+	// another rule applying the same patch is tracked separately
+	update, err = trackLastApplied(current, patched, "policy/other")
+	assert.NilError(t, err)
+	assert.Assert(t, update)
+	recorded := getLastApplied(patched)
+	assert.Equal(t, len(recorded), 2)
+	assert.DeepEqual(t, recorded["policy/rule"], recorded["policy/other"])
+	// the desired patch changed
+	patched = configMap(map[string]interface{}{"foo": "qux"}, map[string]string{kyverno.AnnotationLastApplied: annotation})
+	update, err = trackLastApplied(current, patched, key)
+	assert.NilError(t, err)
+	assert.Assert(t, update)
+	assert.Assert(t, patched.GetAnnotations()[kyverno.AnnotationLastApplied] != annotation)
+}
+
+func Test_getLastApplied(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        lastApplied
+	}{{
+		name: "no annotation",
+		want: lastApplied{},
+	}, {
+		name:        "invalid annotation",
+		annotations: map[string]string{kyverno.AnnotationLastApplied: "foo"},
+		want:        lastApplied{},
+	}, {
+		name:        "valid annotation",
+		annotations: map[string]string{kyverno.AnnotationLastApplied: `{"policy/rule":["0011223344556677"]}`},
+		want:        lastApplied{"policy/rule": {"0011223344556677"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.DeepEqual(t, getLastApplied(configMap(nil, tt.annotations)), tt.want)
+		})
+	}
+}
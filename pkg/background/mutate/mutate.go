@@ -193,6 +193,23 @@ func (c *mutateExistingController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) e
 					}
 					_, updateErr = c.client.UpdateResource(context.TODO(), parentResourceGV.String(), parentResourceGVK.Kind, patchedNew.GetNamespace(), patchedNew.Object, false, patchedSubresource)
 				} else {
+					current, err := c.client.GetResource(context.TODO(), patchedNew.GetAPIVersion(), patchedNew.GetKind(), patchedNew.GetNamespace(), patchedNew.GetName())
+					if err != nil {
+						logger.WithName(rule.Name).Error(err, "failed to get target resource", "namespace", patchedNew.GetNamespace(), "name", patchedNew.GetName())
+						errs = append(errs, err)
+						continue
+					}
+					// only patch the target if the desired patch differs from the one last applied
+					update, err := trackLastApplied(current, patchedNew, ur.Spec.Policy+"/"+rule.Name)
+					if err != nil {
+						logger.WithName(rule.Name).Error(err, "failed to compute target resource patch", "namespace", patchedNew.GetNamespace(), "name", patchedNew.GetName())
+						errs = append(errs, err)
+						continue
+					}
+					if !update {
+						logger.WithName(rule.Name).V(4).Info("target resource already patched", "namespace", patchedNew.GetNamespace(), "name", patchedNew.GetName())
+						continue
+					}
 					_, updateErr = c.client.UpdateResource(context.TODO(), patchedNew.GetAPIVersion(), patchedNew.GetKind(), patchedNew.GetNamespace(), patchedNew.Object, false)
 				}
 				if updateErr != nil {
@@ -245,11 +262,11 @@ func (c *mutateExistingController) report(err error, policy kyvernov1.PolicyInte
 
 func updateURStatus(statusControl common.StatusControlInterface, ur kyvernov1beta1.UpdateRequest, err error) error {
 	if err != nil {
-		if _, err := statusControl.Failed(ur.GetName(), err.Error(), nil); err != nil {
+		if _, err := statusControl.Failed(ur.GetName(), err, nil, nil, nil); err != nil {
 			return err
 		}
 	} else {
-		if _, err := statusControl.Success(ur.GetName(), nil); err != nil {
+		if _, err := statusControl.Success(ur.GetName(), nil, nil, nil); err != nil {
 			return err
 		}
 	}
@@ -2,6 +2,7 @@ package background
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
@@ -60,6 +62,7 @@ type controller struct {
 	eventGen      event.Interface
 	configuration config.Configuration
 	jp            jmespath.Interface
+	retryPolicy   common.RetryPolicy
 }
 
 // NewController returns an instance of the Generate-Request Controller
@@ -74,6 +77,7 @@ func NewController(
 	eventGen event.Interface,
 	configuration config.Configuration,
 	jp jmespath.Interface,
+	retryPolicy common.RetryPolicy,
 ) Controller {
 	urLister := urInformer.Lister().UpdateRequests(config.KyvernoNamespace())
 	c := controller{
@@ -84,10 +88,11 @@ func NewController(
 		polLister:     polInformer.Lister(),
 		urLister:      urLister,
 		nsLister:      namespaceInformer.Lister(),
-		queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "background"),
+		queue:         workqueue.NewNamedRateLimitingQueue(retryPolicy.RateLimiter(), "background"),
 		eventGen:      eventGen,
 		configuration: configuration,
 		jp:            jp,
+		retryPolicy:   retryPolicy,
 	}
 	_, _ = urInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.addUR,
@@ -150,7 +155,7 @@ func (c *controller) handleErr(err error, key interface{}) {
 
 	if c.queue.NumRequeues(key) < maxRetries {
 		logger.V(3).Info("retrying update request", "key", key, "error", err.Error())
-		c.queue.AddAfter(key, time.Second)
+		c.queue.AddRateLimited(key)
 		return
 	}
 
@@ -178,16 +183,20 @@ func (c *controller) syncUpdateRequest(key string) error {
 		}
 	}
 
-	if ur.Status.State == kyvernov1beta1.Pending {
-		if err := c.processUR(ur); err != nil {
-			return fmt.Errorf("failed to process UR %s: %v", key, err)
-		}
+	var processErr error
+	processed := ur.Status.State == kyvernov1beta1.Pending
+	if processed {
+		processErr = c.processUR(ur)
 	}
 
-	urStatus, err := c.reconcileURStatus(ur)
+	urStatus, err := c.reconcileURStatus(key, ur, processed)
 	if err != nil {
 		return err
 	}
+	// the failure was not recorded in the update request status
+	if processErr != nil && urStatus == kyvernov1beta1.Pending {
+		return fmt.Errorf("failed to process UR %s: %v", key, processErr)
+	}
 
 	logger.V(4).Info("synced update request", "key", key, "processingTime", time.Since(startTime).String(), "ur status", urStatus)
 	return nil
@@ -210,7 +219,8 @@ func (c *controller) addUR(obj interface{}) {
 
 func (c *controller) updateUR(_, cur interface{}) {
 	curUr := cur.(*kyvernov1beta1.UpdateRequest)
-	if curUr.Status.State == kyvernov1beta1.Skip || curUr.Status.State == kyvernov1beta1.Completed {
+	// failed update requests are requeued by the controller once their backoff expires
+	if curUr.Status.State == kyvernov1beta1.Skip || curUr.Status.State == kyvernov1beta1.Completed || curUr.Status.State == kyvernov1beta1.Failed {
 		return
 	}
 	c.enqueueUpdateRequest(curUr)
@@ -229,7 +239,7 @@ func (c *controller) processUR(ur *kyvernov1beta1.UpdateRequest) error {
 	return nil
 }
 
-func (c *controller) reconcileURStatus(ur *kyvernov1beta1.UpdateRequest) (kyvernov1beta1.UpdateRequestState, error) {
+func (c *controller) reconcileURStatus(key string, ur *kyvernov1beta1.UpdateRequest, processed bool) (kyvernov1beta1.UpdateRequestState, error) {
 	new, err := c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), ur.GetName(), metav1.GetOptions{})
 	if err != nil {
 		logger.V(2).Info("cannot fetch latest UR, fallback to the existing one", "reason", err.Error())
@@ -239,10 +249,22 @@ func (c *controller) reconcileURStatus(ur *kyvernov1beta1.UpdateRequest) (kyvern
 	var errUpdate error
 	switch new.Status.State {
 	case kyvernov1beta1.Completed:
+		if policy, err := c.getPolicy(new.Spec.Policy); err == nil && policy.GetStatus().IsBackgroundFailed() {
+			c.updatePolicyStatus(policy, false, "")
+		}
 		errUpdate = c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Delete(context.TODO(), ur.GetName(), metav1.DeleteOptions{})
 	case kyvernov1beta1.Failed:
-		new.Status.State = kyvernov1beta1.Pending
-		_, errUpdate = c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), new, metav1.UpdateOptions{})
+		if c.retryPolicy.Exhausted(new.Status.RetryCount) {
+			c.giveUp(new)
+			errUpdate = c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Delete(context.TODO(), ur.GetName(), metav1.DeleteOptions{})
+		} else if processed {
+			delay := c.retryPolicy.Delay(new.Status.RetryCount)
+			logger.V(3).Info("retrying failed update request", "key", key, "attempts", new.Status.RetryCount, "delay", delay.String())
+			c.queue.AddAfter(key, delay)
+		} else {
+			new.Status.State = kyvernov1beta1.Pending
+			_, errUpdate = c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), new, metav1.UpdateOptions{})
+		}
 	}
 	return new.Status.State, errUpdate
 }
@@ -257,3 +279,50 @@ func (c *controller) getPolicy(key string) (kyvernov1.PolicyInterface, error) {
 	}
 	return c.polLister.Policies(namespace).Get(name)
 }
+
+// giveUp reports an update request that failed after all attempts
+func (c *controller) giveUp(ur *kyvernov1beta1.UpdateRequest) {
+	message := fmt.Sprintf("update request %s failed after %d attempts: %s", ur.GetName(), ur.Status.RetryCount, ur.Status.LastError)
+	logger.Error(errors.New(ur.Status.LastError), "giving up update request", "name", ur.GetName(), "attempts", ur.Status.RetryCount)
+	policy, err := c.getPolicy(ur.Spec.Policy)
+	if err != nil {
+		return
+	}
+	source := event.GeneratePolicyController
+	if ur.Spec.GetRequestType() == kyvernov1beta1.Mutate {
+		source = event.MutateExistingController
+	}
+	c.eventGen.Add(event.NewBackgroundFailedEvent(errors.New(message), policy, ur.Spec.Rule, source, ur.Spec.GetResource())...)
+	c.updatePolicyStatus(policy, true, message)
+}
+
+// updatePolicyStatus sets the background failed condition of the policy
+func (c *controller) updatePolicyStatus(policy kyvernov1.PolicyInterface, failed bool, message string) {
+	var err error
+	build := func(policy kyvernov1.PolicyInterface) error {
+		policy.GetStatus().SetBackgroundFailed(failed, message)
+		return nil
+	}
+	if policy.GetNamespace() == "" {
+		_, err = controllerutils.UpdateStatus(
+			context.TODO(),
+			policy.(*kyvernov1.ClusterPolicy),
+			c.kyvernoClient.KyvernoV1().ClusterPolicies(),
+			func(policy *kyvernov1.ClusterPolicy) error {
+				return build(policy)
+			},
+		)
+	} else {
+		_, err = controllerutils.UpdateStatus(
+			context.TODO(),
+			policy.(*kyvernov1.Policy),
+			c.kyvernoClient.KyvernoV1().Policies(policy.GetNamespace()),
+			func(policy *kyvernov1.Policy) error {
+				return build(policy)
+			},
+		)
+	}
+	if err != nil {
+		logger.Error(err, "failed to update policy status", "policy", policy.GetName())
+	}
+}
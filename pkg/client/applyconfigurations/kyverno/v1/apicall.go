@@ -30,6 +30,8 @@ type APICallApplyConfiguration struct {
 	Data     []RequestDataApplyConfiguration `json:"data,omitempty"`
 	Service  *ServiceCallApplyConfiguration  `json:"service,omitempty"`
 	JMESPath *string                         `json:"jmesPath,omitempty"`
+	PageSize *int64                          `json:"pageSize,omitempty"`
+	MaxItems *int64                          `json:"maxItems,omitempty"`
 }
 
 // APICallApplyConfiguration constructs an declarative configuration of the APICall type for use with
@@ -82,3 +84,19 @@ func (b *APICallApplyConfiguration) WithJMESPath(value string) *APICallApplyConf
 	b.JMESPath = &value
 	return b
 }
+
+// WithPageSize sets the PageSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PageSize field is set to the value of the last call.
+func (b *APICallApplyConfiguration) WithPageSize(value int64) *APICallApplyConfiguration {
+	b.PageSize = &value
+	return b
+}
+
+// WithMaxItems sets the MaxItems field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxItems field is set to the value of the last call.
+func (b *APICallApplyConfiguration) WithMaxItems(value int64) *APICallApplyConfiguration {
+	b.MaxItems = &value
+	return b
+}
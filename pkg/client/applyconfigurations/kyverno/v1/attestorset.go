@@ -21,8 +21,9 @@ package v1
 // AttestorSetApplyConfiguration represents an declarative configuration of the AttestorSet type for use
 // with apply.
 type AttestorSetApplyConfiguration struct {
-	Count   *int                         `json:"count,omitempty"`
-	Entries []AttestorApplyConfiguration `json:"entries,omitempty"`
+	Count           *int                         `json:"count,omitempty"`
+	Entries         []AttestorApplyConfiguration `json:"entries,omitempty"`
+	ImageReferences []string                     `json:"imageReferences,omitempty"`
 }
 
 // AttestorSetApplyConfiguration constructs an declarative configuration of the AttestorSet type for use with
@@ -51,3 +52,13 @@ func (b *AttestorSetApplyConfiguration) WithEntries(values ...*AttestorApplyConf
 	}
 	return b
 }
+
+// WithImageReferences adds the given value to the ImageReferences field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ImageReferences field.
+func (b *AttestorSetApplyConfiguration) WithImageReferences(values ...string) *AttestorSetApplyConfiguration {
+	for i := range values {
+		b.ImageReferences = append(b.ImageReferences, values[i])
+	}
+	return b
+}
@@ -18,6 +18,12 @@ limitations under the License.
 
 package v1
 
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // ContextEntryApplyConfiguration represents an declarative configuration of the ContextEntry type for use
 // with apply.
 type ContextEntryApplyConfiguration struct {
@@ -25,7 +31,12 @@ type ContextEntryApplyConfiguration struct {
 	ConfigMap     *ConfigMapReferenceApplyConfiguration `json:"configMap,omitempty"`
 	APICall       *APICallApplyConfiguration            `json:"apiCall,omitempty"`
 	ImageRegistry *ImageRegistryApplyConfiguration      `json:"imageRegistry,omitempty"`
+	CloudMetadata *CloudMetadataApplyConfiguration      `json:"cloudMetadata,omitempty"`
 	Variable      *VariableApplyConfiguration           `json:"variable,omitempty"`
+	Timeout       *metav1.Duration                      `json:"timeout,omitempty"`
+	FailurePolicy *kyvernov1.FailurePolicyType          `json:"failurePolicy,omitempty"`
+	Required      *bool                                 `json:"required,omitempty"`
+	Default       *apiextensionsv1.JSON                 `json:"default,omitempty"`
 }
 
 // ContextEntryApplyConfiguration constructs an declarative configuration of the ContextEntry type for use with
@@ -66,6 +77,14 @@ func (b *ContextEntryApplyConfiguration) WithImageRegistry(value *ImageRegistryA
 	return b
 }
 
+// WithCloudMetadata sets the CloudMetadata field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CloudMetadata field is set to the value of the last call.
+func (b *ContextEntryApplyConfiguration) WithCloudMetadata(value *CloudMetadataApplyConfiguration) *ContextEntryApplyConfiguration {
+	b.CloudMetadata = value
+	return b
+}
+
 // WithVariable sets the Variable field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Variable field is set to the value of the last call.
@@ -73,3 +92,35 @@ func (b *ContextEntryApplyConfiguration) WithVariable(value *VariableApplyConfig
 	b.Variable = value
 	return b
 }
+
+// WithTimeout sets the Timeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Timeout field is set to the value of the last call.
+func (b *ContextEntryApplyConfiguration) WithTimeout(value metav1.Duration) *ContextEntryApplyConfiguration {
+	b.Timeout = &value
+	return b
+}
+
+// WithFailurePolicy sets the FailurePolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailurePolicy field is set to the value of the last call.
+func (b *ContextEntryApplyConfiguration) WithFailurePolicy(value kyvernov1.FailurePolicyType) *ContextEntryApplyConfiguration {
+	b.FailurePolicy = &value
+	return b
+}
+
+// WithRequired sets the Required field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Required field is set to the value of the last call.
+func (b *ContextEntryApplyConfiguration) WithRequired(value bool) *ContextEntryApplyConfiguration {
+	b.Required = &value
+	return b
+}
+
+// WithDefault sets the Default field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Default field is set to the value of the last call.
+func (b *ContextEntryApplyConfiguration) WithDefault(value apiextensionsv1.JSON) *ContextEntryApplyConfiguration {
+	b.Default = &value
+	return b
+}
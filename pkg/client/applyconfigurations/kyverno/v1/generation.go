@@ -20,6 +20,7 @@ package v1
 
 import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 )
 
@@ -27,10 +28,15 @@ import (
 // with apply.
 type GenerationApplyConfiguration struct {
 	*ResourceSpecApplyConfiguration `json:"ResourceSpec,omitempty"`
-	Synchronize                     *bool                        `json:"synchronize,omitempty"`
-	RawData                         *apiextensionsv1.JSON        `json:"data,omitempty"`
-	Clone                           *CloneFromApplyConfiguration `json:"clone,omitempty"`
-	CloneList                       *CloneListApplyConfiguration `json:"cloneList,omitempty"`
+	Namespaces                      []string                        `json:"namespaces,omitempty"`
+	NamespaceSelector               *v1.LabelSelector               `json:"namespaceSelector,omitempty"`
+	Synchronize                     *bool                           `json:"synchronize,omitempty"`
+	Cascade                         *bool                           `json:"cascade,omitempty"`
+	AllowClusterScopedTarget        *bool                           `json:"allowClusterScopedTarget,omitempty"`
+	RawData                         *apiextensionsv1.JSON           `json:"data,omitempty"`
+	DataOverride                    *DataOverrideApplyConfiguration `json:"dataOverride,omitempty"`
+	Clone                           *CloneFromApplyConfiguration    `json:"clone,omitempty"`
+	CloneList                       *CloneListApplyConfiguration    `json:"cloneList,omitempty"`
 }
 
 // GenerationApplyConfiguration constructs an declarative configuration of the Generation type for use with
@@ -90,6 +96,24 @@ func (b *GenerationApplyConfiguration) ensureResourceSpecApplyConfigurationExist
 	}
 }
 
+// WithNamespaces adds the given value to the Namespaces field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Namespaces field.
+func (b *GenerationApplyConfiguration) WithNamespaces(values ...string) *GenerationApplyConfiguration {
+	for i := range values {
+		b.Namespaces = append(b.Namespaces, values[i])
+	}
+	return b
+}
+
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *GenerationApplyConfiguration) WithNamespaceSelector(value v1.LabelSelector) *GenerationApplyConfiguration {
+	b.NamespaceSelector = &value
+	return b
+}
+
 // WithSynchronize sets the Synchronize field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Synchronize field is set to the value of the last call.
@@ -98,6 +122,22 @@ func (b *GenerationApplyConfiguration) WithSynchronize(value bool) *GenerationAp
 	return b
 }
 
+// WithCascade sets the Cascade field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cascade field is set to the value of the last call.
+func (b *GenerationApplyConfiguration) WithCascade(value bool) *GenerationApplyConfiguration {
+	b.Cascade = &value
+	return b
+}
+
+// WithAllowClusterScopedTarget sets the AllowClusterScopedTarget field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AllowClusterScopedTarget field is set to the value of the last call.
+func (b *GenerationApplyConfiguration) WithAllowClusterScopedTarget(value bool) *GenerationApplyConfiguration {
+	b.AllowClusterScopedTarget = &value
+	return b
+}
+
 // WithRawData sets the RawData field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the RawData field is set to the value of the last call.
@@ -106,6 +146,14 @@ func (b *GenerationApplyConfiguration) WithRawData(value apiextensionsv1.JSON) *
 	return b
 }
 
+// WithDataOverride sets the DataOverride field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DataOverride field is set to the value of the last call.
+func (b *GenerationApplyConfiguration) WithDataOverride(value *DataOverrideApplyConfiguration) *GenerationApplyConfiguration {
+	b.DataOverride = value
+	return b
+}
+
 // WithClone sets the Clone field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Clone field is set to the value of the last call.
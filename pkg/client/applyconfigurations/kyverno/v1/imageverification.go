@@ -20,6 +20,7 @@ package v1
 
 import (
 	v1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // ImageVerificationApplyConfiguration represents an declarative configuration of the ImageVerification type for use
@@ -39,6 +40,8 @@ type ImageVerificationApplyConfiguration struct {
 	Repository               *string                                     `json:"repository,omitempty"`
 	MutateDigest             *bool                                       `json:"mutateDigest,omitempty"`
 	VerifyDigest             *bool                                       `json:"verifyDigest,omitempty"`
+	VerifyTagDigest          *bool                                       `json:"verifyTagDigest,omitempty"`
+	MaxSignatureAge          *metav1.Duration                            `json:"maxSignatureAge,omitempty"`
 	Required                 *bool                                       `json:"required,omitempty"`
 	ImageRegistryCredentials *ImageRegistryCredentialsApplyConfiguration `json:"imageRegistryCredentials,omitempty"`
 	UseCache                 *bool                                       `json:"useCache,omitempty"`
@@ -186,6 +189,22 @@ func (b *ImageVerificationApplyConfiguration) WithVerifyDigest(value bool) *Imag
 	return b
 }
 
+// WithVerifyTagDigest sets the VerifyTagDigest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the VerifyTagDigest field is set to the value of the last call.
+func (b *ImageVerificationApplyConfiguration) WithVerifyTagDigest(value bool) *ImageVerificationApplyConfiguration {
+	b.VerifyTagDigest = &value
+	return b
+}
+
+// WithMaxSignatureAge sets the MaxSignatureAge field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxSignatureAge field is set to the value of the last call.
+func (b *ImageVerificationApplyConfiguration) WithMaxSignatureAge(value metav1.Duration) *ImageVerificationApplyConfiguration {
+	b.MaxSignatureAge = &value
+	return b
+}
+
 // WithRequired sets the Required field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Required field is set to the value of the last call.
@@ -180,6 +180,20 @@ func (b *MatchResourcesApplyConfiguration) WithOperations(values ...v1.Admission
 	return b
 }
 
+// WithOwnerReferences adds the given value to the OwnerReferences field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the OwnerReferences field.
+func (b *MatchResourcesApplyConfiguration) WithOwnerReferences(values ...*OwnerReferenceFilterApplyConfiguration) *MatchResourcesApplyConfiguration {
+	b.ensureResourceDescriptionApplyConfigurationExists()
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOwnerReferences")
+		}
+		b.OwnerReferences = append(b.OwnerReferences, *values[i])
+	}
+	return b
+}
+
 func (b *MatchResourcesApplyConfiguration) ensureResourceDescriptionApplyConfigurationExists() {
 	if b.ResourceDescriptionApplyConfiguration == nil {
 		b.ResourceDescriptionApplyConfiguration = &ResourceDescriptionApplyConfiguration{}
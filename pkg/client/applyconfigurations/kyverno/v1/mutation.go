@@ -29,6 +29,9 @@ type MutationApplyConfiguration struct {
 	RawPatchStrategicMerge *apiextensionsv1.JSON                  `json:"patchStrategicMerge,omitempty"`
 	PatchesJSON6902        *string                                `json:"patchesJson6902,omitempty"`
 	ForEachMutation        []ForEachMutationApplyConfiguration    `json:"foreach,omitempty"`
+	DryRun                 *bool                                  `json:"dryRun,omitempty"`
+	Audit                  *bool                                  `json:"audit,omitempty"`
+	ResolveDigest          *ResolveDigestApplyConfiguration       `json:"resolveDigest,omitempty"`
 }
 
 // MutationApplyConfiguration constructs an declarative configuration of the Mutation type for use with
@@ -78,3 +81,27 @@ func (b *MutationApplyConfiguration) WithForEachMutation(values ...*ForEachMutat
 	}
 	return b
 }
+
+// WithDryRun sets the DryRun field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DryRun field is set to the value of the last call.
+func (b *MutationApplyConfiguration) WithDryRun(value bool) *MutationApplyConfiguration {
+	b.DryRun = &value
+	return b
+}
+
+// WithAudit sets the Audit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Audit field is set to the value of the last call.
+func (b *MutationApplyConfiguration) WithAudit(value bool) *MutationApplyConfiguration {
+	b.Audit = &value
+	return b
+}
+
+// WithResolveDigest sets the ResolveDigest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResolveDigest field is set to the value of the last call.
+func (b *MutationApplyConfiguration) WithResolveDigest(value *ResolveDigestApplyConfiguration) *MutationApplyConfiguration {
+	b.ResolveDigest = value
+	return b
+}
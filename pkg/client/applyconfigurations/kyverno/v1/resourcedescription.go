@@ -26,14 +26,16 @@ import (
 // ResourceDescriptionApplyConfiguration represents an declarative configuration of the ResourceDescription type for use
 // with apply.
 type ResourceDescriptionApplyConfiguration struct {
-	Kinds             []string                       `json:"kinds,omitempty"`
-	Name              *string                        `json:"name,omitempty"`
-	Names             []string                       `json:"names,omitempty"`
-	Namespaces        []string                       `json:"namespaces,omitempty"`
-	Annotations       map[string]string              `json:"annotations,omitempty"`
-	Selector          *v1.LabelSelector              `json:"selector,omitempty"`
-	NamespaceSelector *v1.LabelSelector              `json:"namespaceSelector,omitempty"`
-	Operations        []kyvernov1.AdmissionOperation `json:"operations,omitempty"`
+	Kinds             []string                                 `json:"kinds,omitempty"`
+	Name              *string                                  `json:"name,omitempty"`
+	Names             []string                                 `json:"names,omitempty"`
+	Namespaces        []string                                 `json:"namespaces,omitempty"`
+	Annotations       map[string]string                        `json:"annotations,omitempty"`
+	Selector          *v1.LabelSelector                        `json:"selector,omitempty"`
+	NamespaceSelector *v1.LabelSelector                        `json:"namespaceSelector,omitempty"`
+	Operations        []kyvernov1.AdmissionOperation           `json:"operations,omitempty"`
+	OwnerReferences   []OwnerReferenceFilterApplyConfiguration `json:"ownerReferences,omitempty"`
+	Resources         []string                                 `json:"resources,omitempty"`
 }
 
 // ResourceDescriptionApplyConfiguration constructs an declarative configuration of the ResourceDescription type for use with
@@ -119,3 +121,26 @@ func (b *ResourceDescriptionApplyConfiguration) WithOperations(values ...kyverno
 	}
 	return b
 }
+
+// WithOwnerReferences adds the given value to the OwnerReferences field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the OwnerReferences field.
+func (b *ResourceDescriptionApplyConfiguration) WithOwnerReferences(values ...*OwnerReferenceFilterApplyConfiguration) *ResourceDescriptionApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOwnerReferences")
+		}
+		b.OwnerReferences = append(b.OwnerReferences, *values[i])
+	}
+	return b
+}
+
+// WithResources adds the given value to the Resources field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Resources field.
+func (b *ResourceDescriptionApplyConfiguration) WithResources(values ...string) *ResourceDescriptionApplyConfiguration {
+	for i := range values {
+		b.Resources = append(b.Resources, values[i])
+	}
+	return b
+}
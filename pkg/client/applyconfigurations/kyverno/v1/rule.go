@@ -22,23 +22,28 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	v1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // RuleApplyConfiguration represents an declarative configuration of the Rule type for use
 // with apply.
 type RuleApplyConfiguration struct {
-	Name                   *string                               `json:"name,omitempty"`
-	Context                []ContextEntryApplyConfiguration      `json:"context,omitempty"`
-	MatchResources         *MatchResourcesApplyConfiguration     `json:"match,omitempty"`
-	ExcludeResources       *MatchResourcesApplyConfiguration     `json:"exclude,omitempty"`
-	ImageExtractors        *kyvernov1.ImageExtractorConfigs      `json:"imageExtractors,omitempty"`
-	RawAnyAllConditions    *apiextensionsv1.JSON                 `json:"preconditions,omitempty"`
-	CELPreconditions       []v1alpha1.MatchCondition             `json:"celPreconditions,omitempty"`
-	Mutation               *MutationApplyConfiguration           `json:"mutate,omitempty"`
-	Validation             *ValidationApplyConfiguration         `json:"validate,omitempty"`
-	Generation             *GenerationApplyConfiguration         `json:"generate,omitempty"`
-	VerifyImages           []ImageVerificationApplyConfiguration `json:"verifyImages,omitempty"`
-	SkipBackgroundRequests *bool                                 `json:"skipBackgroundRequests,omitempty"`
+	Name                               *string                               `json:"name,omitempty"`
+	Context                            []ContextEntryApplyConfiguration      `json:"context,omitempty"`
+	DependsOn                          []string                              `json:"dependsOn,omitempty"`
+	MatchResources                     *MatchResourcesApplyConfiguration     `json:"match,omitempty"`
+	ExcludeResources                   *MatchResourcesApplyConfiguration     `json:"exclude,omitempty"`
+	ImageExtractors                    *kyvernov1.ImageExtractorConfigs      `json:"imageExtractors,omitempty"`
+	RawAnyAllConditions                *apiextensionsv1.JSON                 `json:"preconditions,omitempty"`
+	CELPreconditions                   []v1alpha1.MatchCondition             `json:"celPreconditions,omitempty"`
+	Mutation                           *MutationApplyConfiguration           `json:"mutate,omitempty"`
+	Validation                         *ValidationApplyConfiguration         `json:"validate,omitempty"`
+	Generation                         *GenerationApplyConfiguration         `json:"generate,omitempty"`
+	VerifyImages                       []ImageVerificationApplyConfiguration `json:"verifyImages,omitempty"`
+	SkipBackgroundRequests             *bool                                 `json:"skipBackgroundRequests,omitempty"`
+	SkipRequestsFromSameServiceAccount *bool                                 `json:"skipRequestsFromSameServiceAccount,omitempty"`
+	ReportProperties                   map[string]string                     `json:"reportProperties,omitempty"`
+	Timeout                            *metav1.Duration                      `json:"timeout,omitempty"`
 }
 
 // RuleApplyConfiguration constructs an declarative configuration of the Rule type for use with
@@ -68,6 +73,16 @@ func (b *RuleApplyConfiguration) WithContext(values ...*ContextEntryApplyConfigu
 	return b
 }
 
+// WithDependsOn adds the given value to the DependsOn field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DependsOn field.
+func (b *RuleApplyConfiguration) WithDependsOn(values ...string) *RuleApplyConfiguration {
+	for i := range values {
+		b.DependsOn = append(b.DependsOn, values[i])
+	}
+	return b
+}
+
 // WithMatchResources sets the MatchResources field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the MatchResources field is set to the value of the last call.
@@ -154,3 +169,33 @@ func (b *RuleApplyConfiguration) WithSkipBackgroundRequests(value bool) *RuleApp
 	b.SkipBackgroundRequests = &value
 	return b
 }
+
+// WithSkipRequestsFromSameServiceAccount sets the SkipRequestsFromSameServiceAccount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SkipRequestsFromSameServiceAccount field is set to the value of the last call.
+func (b *RuleApplyConfiguration) WithSkipRequestsFromSameServiceAccount(value bool) *RuleApplyConfiguration {
+	b.SkipRequestsFromSameServiceAccount = &value
+	return b
+}
+
+// WithReportProperties puts the entries into the ReportProperties field in the declarative configuration
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the ReportProperties field,
+// overwriting an existing map entries in ReportProperties field with the same key.
+func (b *RuleApplyConfiguration) WithReportProperties(entries map[string]string) *RuleApplyConfiguration {
+	if b.ReportProperties == nil && len(entries) > 0 {
+		b.ReportProperties = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.ReportProperties[k] = v
+	}
+	return b
+}
+
+// WithTimeout sets the Timeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Timeout field is set to the value of the last call.
+func (b *RuleApplyConfiguration) WithTimeout(value metav1.Duration) *RuleApplyConfiguration {
+	b.Timeout = &value
+	return b
+}
@@ -20,6 +20,7 @@ package v1
 
 import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 )
 
 // SpecApplyConfiguration represents an declarative configuration of the Spec type for use
@@ -38,6 +39,8 @@ type SpecApplyConfiguration struct {
 	GenerateExistingOnPolicyUpdate   *bool                                               `json:"generateExistingOnPolicyUpdate,omitempty"`
 	GenerateExisting                 *bool                                               `json:"generateExisting,omitempty"`
 	UseServerSideApply               *bool                                               `json:"useServerSideApply,omitempty"`
+	ReinvocationPolicy               *admissionregistrationv1.ReinvocationPolicyType     `json:"reinvocationPolicy,omitempty"`
+	FailFast                         *bool                                               `json:"failFast,omitempty"`
 }
 
 // SpecApplyConfiguration constructs an declarative configuration of the Spec type for use with
@@ -159,3 +162,19 @@ func (b *SpecApplyConfiguration) WithUseServerSideApply(value bool) *SpecApplyCo
 	b.UseServerSideApply = &value
 	return b
 }
+
+// WithReinvocationPolicy sets the ReinvocationPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReinvocationPolicy field is set to the value of the last call.
+func (b *SpecApplyConfiguration) WithReinvocationPolicy(value admissionregistrationv1.ReinvocationPolicyType) *SpecApplyConfiguration {
+	b.ReinvocationPolicy = &value
+	return b
+}
+
+// WithFailFast sets the FailFast field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailFast field is set to the value of the last call.
+func (b *SpecApplyConfiguration) WithFailFast(value bool) *SpecApplyConfiguration {
+	b.FailFast = &value
+	return b
+}
@@ -19,20 +19,30 @@ limitations under the License.
 package v1
 
 import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 )
 
 // ValidationApplyConfiguration represents an declarative configuration of the Validation type for use
 // with apply.
 type ValidationApplyConfiguration struct {
-	Message           *string                               `json:"message,omitempty"`
-	Manifests         *ManifestsApplyConfiguration          `json:"manifests,omitempty"`
-	ForEachValidation []ForEachValidationApplyConfiguration `json:"foreach,omitempty"`
-	RawPattern        *apiextensionsv1.JSON                 `json:"pattern,omitempty"`
-	RawAnyPattern     *apiextensionsv1.JSON                 `json:"anyPattern,omitempty"`
-	Deny              *DenyApplyConfiguration               `json:"deny,omitempty"`
-	PodSecurity       *PodSecurityApplyConfiguration        `json:"podSecurity,omitempty"`
-	CEL               *CELApplyConfiguration                `json:"cel,omitempty"`
+	Message           *string                                     `json:"message,omitempty"`
+	MessageTemplate   *string                                     `json:"messageTemplate,omitempty"`
+	RemediationURL    *string                                     `json:"remediationUrl,omitempty"`
+	FailureAction     *kyvernov1.ValidationFailureAction          `json:"failureAction,omitempty"`
+	Severity          *string                                     `json:"severity,omitempty"`
+	Manifests         *ManifestsApplyConfiguration                `json:"manifests,omitempty"`
+	ForEachValidation []ForEachValidationApplyConfiguration       `json:"foreach,omitempty"`
+	RawPattern        *apiextensionsv1.JSON                       `json:"pattern,omitempty"`
+	RawAnyPattern     *apiextensionsv1.JSON                       `json:"anyPattern,omitempty"`
+	Deny              *DenyApplyConfiguration                     `json:"deny,omitempty"`
+	PodSecurity       *PodSecurityApplyConfiguration              `json:"podSecurity,omitempty"`
+	CEL               *CELApplyConfiguration                      `json:"cel,omitempty"`
+	Immutable         *ImmutableApplyConfiguration                `json:"immutable,omitempty"`
+	ImageAllowList    *ImageAllowListValidationApplyConfiguration `json:"imageAllowList,omitempty"`
+	Baseline          *BaselineApplyConfiguration                 `json:"baseline,omitempty"`
+	Rego              *RegoApplyConfiguration                     `json:"rego,omitempty"`
+	ServerMetadata    *kyvernov1.ServerMetadataMode               `json:"serverMetadata,omitempty"`
 }
 
 // ValidationApplyConfiguration constructs an declarative configuration of the Validation type for use with
@@ -49,6 +59,38 @@ func (b *ValidationApplyConfiguration) WithMessage(value string) *ValidationAppl
 	return b
 }
 
+// WithMessageTemplate sets the MessageTemplate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MessageTemplate field is set to the value of the last call.
+func (b *ValidationApplyConfiguration) WithMessageTemplate(value string) *ValidationApplyConfiguration {
+	b.MessageTemplate = &value
+	return b
+}
+
+// WithRemediationURL sets the RemediationURL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RemediationURL field is set to the value of the last call.
+func (b *ValidationApplyConfiguration) WithRemediationURL(value string) *ValidationApplyConfiguration {
+	b.RemediationURL = &value
+	return b
+}
+
+// WithFailureAction sets the FailureAction field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailureAction field is set to the value of the last call.
+func (b *ValidationApplyConfiguration) WithFailureAction(value kyvernov1.ValidationFailureAction) *ValidationApplyConfiguration {
+	b.FailureAction = &value
+	return b
+}
+
+// WithSeverity sets the Severity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Severity field is set to the value of the last call.
+func (b *ValidationApplyConfiguration) WithSeverity(value string) *ValidationApplyConfiguration {
+	b.Severity = &value
+	return b
+}
+
 // WithManifests sets the Manifests field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Manifests field is set to the value of the last call.
@@ -109,3 +151,43 @@ func (b *ValidationApplyConfiguration) WithCEL(value *CELApplyConfiguration) *Va
 	b.CEL = value
 	return b
 }
+
+// WithImmutable sets the Immutable field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Immutable field is set to the value of the last call.
+func (b *ValidationApplyConfiguration) WithImmutable(value *ImmutableApplyConfiguration) *ValidationApplyConfiguration {
+	b.Immutable = value
+	return b
+}
+
+// WithImageAllowList sets the ImageAllowList field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ImageAllowList field is set to the value of the last call.
+func (b *ValidationApplyConfiguration) WithImageAllowList(value *ImageAllowListValidationApplyConfiguration) *ValidationApplyConfiguration {
+	b.ImageAllowList = value
+	return b
+}
+
+// WithBaseline sets the Baseline field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Baseline field is set to the value of the last call.
+func (b *ValidationApplyConfiguration) WithBaseline(value *BaselineApplyConfiguration) *ValidationApplyConfiguration {
+	b.Baseline = value
+	return b
+}
+
+// WithRego sets the Rego field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Rego field is set to the value of the last call.
+func (b *ValidationApplyConfiguration) WithRego(value *RegoApplyConfiguration) *ValidationApplyConfiguration {
+	b.Rego = value
+	return b
+}
+
+// WithServerMetadata sets the ServerMetadata field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServerMetadata field is set to the value of the last call.
+func (b *ValidationApplyConfiguration) WithServerMetadata(value kyvernov1.ServerMetadataMode) *ValidationApplyConfiguration {
+	b.ServerMetadata = &value
+	return b
+}
@@ -21,6 +21,7 @@ package v1beta1
 import (
 	v1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	v1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // UpdateRequestStatusApplyConfiguration represents an declarative configuration of the UpdateRequestStatus type for use
@@ -30,7 +31,12 @@ type UpdateRequestStatusApplyConfiguration struct {
 	State              *v1beta1.UpdateRequestState         `json:"state,omitempty"`
 	Message            *string                             `json:"message,omitempty"`
 	GeneratedResources []v1.ResourceSpecApplyConfiguration `json:"generatedResources,omitempty"`
+	Namespaces         []NamespaceStatusApplyConfiguration `json:"namespaces,omitempty"`
 	RetryCount         *int                                `json:"retryCount,omitempty"`
+	Conditions         []metav1.Condition                  `json:"conditions,omitempty"`
+	LastError          *string                             `json:"lastError,omitempty"`
+	AttemptedResources []v1.ResourceSpecApplyConfiguration `json:"attemptedResources,omitempty"`
+	Targets            []TargetStatusApplyConfiguration    `json:"targets,omitempty"`
 }
 
 // UpdateRequestStatusApplyConfiguration constructs an declarative configuration of the UpdateRequestStatus type for use with
@@ -76,6 +82,19 @@ func (b *UpdateRequestStatusApplyConfiguration) WithGeneratedResources(values ..
 	return b
 }
 
+// WithNamespaces adds the given value to the Namespaces field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Namespaces field.
+func (b *UpdateRequestStatusApplyConfiguration) WithNamespaces(values ...*NamespaceStatusApplyConfiguration) *UpdateRequestStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNamespaces")
+		}
+		b.Namespaces = append(b.Namespaces, *values[i])
+	}
+	return b
+}
+
 // WithRetryCount sets the RetryCount field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the RetryCount field is set to the value of the last call.
@@ -83,3 +102,47 @@ func (b *UpdateRequestStatusApplyConfiguration) WithRetryCount(value int) *Updat
 	b.RetryCount = &value
 	return b
 }
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *UpdateRequestStatusApplyConfiguration) WithConditions(values ...metav1.Condition) *UpdateRequestStatusApplyConfiguration {
+	for i := range values {
+		b.Conditions = append(b.Conditions, values[i])
+	}
+	return b
+}
+
+// WithLastError sets the LastError field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastError field is set to the value of the last call.
+func (b *UpdateRequestStatusApplyConfiguration) WithLastError(value string) *UpdateRequestStatusApplyConfiguration {
+	b.LastError = &value
+	return b
+}
+
+// WithAttemptedResources adds the given value to the AttemptedResources field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AttemptedResources field.
+func (b *UpdateRequestStatusApplyConfiguration) WithAttemptedResources(values ...*v1.ResourceSpecApplyConfiguration) *UpdateRequestStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAttemptedResources")
+		}
+		b.AttemptedResources = append(b.AttemptedResources, *values[i])
+	}
+	return b
+}
+
+// WithTargets adds the given value to the Targets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Targets field.
+func (b *UpdateRequestStatusApplyConfiguration) WithTargets(values ...*TargetStatusApplyConfiguration) *UpdateRequestStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithTargets")
+		}
+		b.Targets = append(b.Targets, *values[i])
+	}
+	return b
+}
@@ -20,6 +20,7 @@ package v2
 
 import (
 	v2beta1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v2beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // PolicyExceptionSpecApplyConfiguration represents an declarative configuration of the PolicyExceptionSpec type for use
@@ -29,6 +30,7 @@ type PolicyExceptionSpecApplyConfiguration struct {
 	Match      *v2beta1.MatchResourcesApplyConfiguration   `json:"match,omitempty"`
 	Conditions *v2beta1.AnyAllConditionsApplyConfiguration `json:"conditions,omitempty"`
 	Exceptions []ExceptionApplyConfiguration               `json:"exceptions,omitempty"`
+	ExpiresAt  *v1.Time                                    `json:"expiresAt,omitempty"`
 }
 
 // PolicyExceptionSpecApplyConfiguration constructs an declarative configuration of the PolicyExceptionSpec type for use with
@@ -73,3 +75,11 @@ func (b *PolicyExceptionSpecApplyConfiguration) WithExceptions(values ...*Except
 	}
 	return b
 }
+
+// WithExpiresAt sets the ExpiresAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExpiresAt field is set to the value of the last call.
+func (b *PolicyExceptionSpecApplyConfiguration) WithExpiresAt(value v1.Time) *PolicyExceptionSpecApplyConfiguration {
+	b.ExpiresAt = &value
+	return b
+}
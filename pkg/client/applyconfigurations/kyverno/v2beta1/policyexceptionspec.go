@@ -18,6 +18,10 @@ limitations under the License.
 
 package v2beta1
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // PolicyExceptionSpecApplyConfiguration represents an declarative configuration of the PolicyExceptionSpec type for use
 // with apply.
 type PolicyExceptionSpecApplyConfiguration struct {
@@ -25,6 +29,7 @@ type PolicyExceptionSpecApplyConfiguration struct {
 	Match      *MatchResourcesApplyConfiguration   `json:"match,omitempty"`
 	Conditions *AnyAllConditionsApplyConfiguration `json:"conditions,omitempty"`
 	Exceptions []ExceptionApplyConfiguration       `json:"exceptions,omitempty"`
+	ExpiresAt  *v1.Time                            `json:"expiresAt,omitempty"`
 }
 
 // PolicyExceptionSpecApplyConfiguration constructs an declarative configuration of the PolicyExceptionSpec type for use with
@@ -69,3 +74,11 @@ func (b *PolicyExceptionSpecApplyConfiguration) WithExceptions(values ...*Except
 	}
 	return b
 }
+
+// WithExpiresAt sets the ExpiresAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExpiresAt field is set to the value of the last call.
+func (b *PolicyExceptionSpecApplyConfiguration) WithExpiresAt(value v1.Time) *PolicyExceptionSpecApplyConfiguration {
+	b.ExpiresAt = &value
+	return b
+}
@@ -53,6 +53,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.AttestorSetApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("AutogenStatus"):
 		return &kyvernov1.AutogenStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("Baseline"):
+		return &kyvernov1.BaselineApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CEL"):
 		return &kyvernov1.CELApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CertificateAttestor"):
@@ -61,6 +63,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.CloneFromApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CloneList"):
 		return &kyvernov1.CloneListApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("CloudMetadata"):
+		return &kyvernov1.CloudMetadataApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ClusterPolicy"):
 		return &kyvernov1.ClusterPolicyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Condition"):
@@ -71,6 +75,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.ContextEntryApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CTLog"):
 		return &kyvernov1.CTLogApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("DataOverride"):
+		return &kyvernov1.DataOverrideApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Deny"):
 		return &kyvernov1.DenyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("DryRunOption"):
@@ -81,6 +87,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.ForEachValidationApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Generation"):
 		return &kyvernov1.GenerationApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("HTTPHeader"):
+		return &kyvernov1.HTTPHeaderApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ImageAllowListValidation"):
+		return &kyvernov1.ImageAllowListValidationApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ImageExtractorConfig"):
 		return &kyvernov1.ImageExtractorConfigApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ImageRegistry"):
@@ -89,6 +99,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.ImageRegistryCredentialsApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ImageVerification"):
 		return &kyvernov1.ImageVerificationApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("Immutable"):
+		return &kyvernov1.ImmutableApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("KeylessAttestor"):
 		return &kyvernov1.KeylessAttestorApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Manifests"):
@@ -99,6 +111,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.MutationApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ObjectFieldBinding"):
 		return &kyvernov1.ObjectFieldBindingApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OwnerReferenceFilter"):
+		return &kyvernov1.OwnerReferenceFilterApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PodSecurity"):
 		return &kyvernov1.PodSecurityApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PodSecurityStandard"):
@@ -107,10 +121,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.PolicyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PolicyStatus"):
 		return &kyvernov1.PolicyStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("Rego"):
+		return &kyvernov1.RegoApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Rekor"):
 		return &kyvernov1.RekorApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RequestData"):
 		return &kyvernov1.RequestDataApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ResolveDigest"):
+		return &kyvernov1.ResolveDigestApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ResourceDescription"):
 		return &kyvernov1.ResourceDescriptionApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ResourceFilter"):
@@ -131,6 +149,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.StaticKeyAttestorApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("TargetResourceSpec"):
 		return &kyvernov1.TargetResourceSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("TimeWindow"):
+		return &kyvernov1.TimeWindowApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("UserInfo"):
 		return &kyvernov1.UserInfoApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ValidatingAdmissionPolicyStatus"):
@@ -155,8 +175,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		// Group=kyverno.io, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithKind("AdmissionRequestInfoObject"):
 		return &kyvernov1beta1.AdmissionRequestInfoObjectApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NamespaceStatus"):
+		return &kyvernov1beta1.NamespaceStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("RequestInfo"):
 		return &kyvernov1beta1.RequestInfoApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("TargetStatus"):
+		return &kyvernov1beta1.TargetStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("UpdateRequest"):
 		return &kyvernov1beta1.UpdateRequestApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("UpdateRequestSpec"):
@@ -197,12 +221,16 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov2.ExceptionApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("MatchResources"):
 		return &kyvernov2.MatchResourcesApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("NamespaceStatus"):
+		return &kyvernov2.NamespaceStatusApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("PolicyException"):
 		return &kyvernov2.PolicyExceptionApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("PolicyExceptionSpec"):
 		return &kyvernov2.PolicyExceptionSpecApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("RequestInfo"):
 		return &kyvernov2.RequestInfoApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("TargetStatus"):
+		return &kyvernov2.TargetStatusApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("UpdateRequest"):
 		return &kyvernov2.UpdateRequestApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("UpdateRequestSpec"):
@@ -213,12 +241,34 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov2.UpdateRequestStatusApplyConfiguration{}
 
 		// Group=kyverno.io, Version=v2alpha1
+	case v2alpha1.SchemeGroupVersion.WithKind("AllowedImage"):
+		return &kyvernov2alpha1.AllowedImageApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("CleanupPolicy"):
 		return &kyvernov2alpha1.CleanupPolicyApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("ClusterCleanupPolicy"):
 		return &kyvernov2alpha1.ClusterCleanupPolicyApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("ClusterStatus"):
+		return &kyvernov2alpha1.ClusterStatusApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("ComplianceGroup"):
+		return &kyvernov2alpha1.ComplianceGroupApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("ComplianceSummary"):
+		return &kyvernov2alpha1.ComplianceSummaryApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("ImageAllowList"):
+		return &kyvernov2alpha1.ImageAllowListApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("ImageAllowListSpec"):
+		return &kyvernov2alpha1.ImageAllowListSpecApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("Placement"):
+		return &kyvernov2alpha1.PlacementApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("PolicyComplianceSummary"):
+		return &kyvernov2alpha1.PolicyComplianceSummaryApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("PolicyException"):
 		return &kyvernov2alpha1.PolicyExceptionApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("PolicySet"):
+		return &kyvernov2alpha1.PolicySetApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("PolicySetSpec"):
+		return &kyvernov2alpha1.PolicySetSpecApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("PolicySetStatus"):
+		return &kyvernov2alpha1.PolicySetStatusApplyConfiguration{}
 
 		// Group=kyverno.io, Version=v2beta1
 	case v2beta1.SchemeGroupVersion.WithKind("AnyAllConditions"):
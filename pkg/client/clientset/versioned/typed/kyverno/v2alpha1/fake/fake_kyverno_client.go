@@ -36,10 +36,22 @@ func (c *FakeKyvernoV2alpha1) ClusterCleanupPolicies() v2alpha1.ClusterCleanupPo
 	return &FakeClusterCleanupPolicies{c}
 }
 
+func (c *FakeKyvernoV2alpha1) ImageAllowLists() v2alpha1.ImageAllowListInterface {
+	return &FakeImageAllowLists{c}
+}
+
+func (c *FakeKyvernoV2alpha1) PolicyComplianceSummaries() v2alpha1.PolicyComplianceSummaryInterface {
+	return &FakePolicyComplianceSummaries{c}
+}
+
 func (c *FakeKyvernoV2alpha1) PolicyExceptions(namespace string) v2alpha1.PolicyExceptionInterface {
 	return &FakePolicyExceptions{c, namespace}
 }
 
+func (c *FakeKyvernoV2alpha1) PolicySets() v2alpha1.PolicySetInterface {
+	return &FakePolicySets{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeKyvernoV2alpha1) RESTClient() rest.Interface {
@@ -22,4 +22,10 @@ type CleanupPolicyExpansion interface{}
 
 type ClusterCleanupPolicyExpansion interface{}
 
+type ImageAllowListExpansion interface{}
+
+type PolicyComplianceSummaryExpansion interface{}
+
 type PolicyExceptionExpansion interface{}
+
+type PolicySetExpansion interface{}
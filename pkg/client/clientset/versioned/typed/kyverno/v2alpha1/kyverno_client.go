@@ -30,7 +30,10 @@ type KyvernoV2alpha1Interface interface {
 	RESTClient() rest.Interface
 	CleanupPoliciesGetter
 	ClusterCleanupPoliciesGetter
+	ImageAllowListsGetter
+	PolicyComplianceSummariesGetter
 	PolicyExceptionsGetter
+	PolicySetsGetter
 }
 
 // KyvernoV2alpha1Client is used to interact with features provided by the kyverno.io group.
@@ -46,10 +49,22 @@ func (c *KyvernoV2alpha1Client) ClusterCleanupPolicies() ClusterCleanupPolicyInt
 	return newClusterCleanupPolicies(c)
 }
 
+func (c *KyvernoV2alpha1Client) ImageAllowLists() ImageAllowListInterface {
+	return newImageAllowLists(c)
+}
+
+func (c *KyvernoV2alpha1Client) PolicyComplianceSummaries() PolicyComplianceSummaryInterface {
+	return newPolicyComplianceSummaries(c)
+}
+
 func (c *KyvernoV2alpha1Client) PolicyExceptions(namespace string) PolicyExceptionInterface {
 	return newPolicyExceptions(c, namespace)
 }
 
+func (c *KyvernoV2alpha1Client) PolicySets() PolicySetInterface {
+	return newPolicySets(c)
+}
+
 // NewForConfig creates a new KyvernoV2alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
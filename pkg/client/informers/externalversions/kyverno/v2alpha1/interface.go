@@ -28,8 +28,14 @@ type Interface interface {
 	CleanupPolicies() CleanupPolicyInformer
 	// ClusterCleanupPolicies returns a ClusterCleanupPolicyInformer.
 	ClusterCleanupPolicies() ClusterCleanupPolicyInformer
+	// ImageAllowLists returns a ImageAllowListInformer.
+	ImageAllowLists() ImageAllowListInformer
+	// PolicyComplianceSummaries returns a PolicyComplianceSummaryInformer.
+	PolicyComplianceSummaries() PolicyComplianceSummaryInformer
 	// PolicyExceptions returns a PolicyExceptionInformer.
 	PolicyExceptions() PolicyExceptionInformer
+	// PolicySets returns a PolicySetInformer.
+	PolicySets() PolicySetInformer
 }
 
 type version struct {
@@ -53,7 +59,22 @@ func (v *version) ClusterCleanupPolicies() ClusterCleanupPolicyInformer {
 	return &clusterCleanupPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ImageAllowLists returns a ImageAllowListInformer.
+func (v *version) ImageAllowLists() ImageAllowListInformer {
+	return &imageAllowListInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// PolicyComplianceSummaries returns a PolicyComplianceSummaryInformer.
+func (v *version) PolicyComplianceSummaries() PolicyComplianceSummaryInformer {
+	return &policyComplianceSummaryInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // PolicyExceptions returns a PolicyExceptionInformer.
 func (v *version) PolicyExceptions() PolicyExceptionInformer {
 	return &policyExceptionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// PolicySets returns a PolicySetInformer.
+func (v *version) PolicySets() PolicySetInformer {
+	return &policySetInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
@@ -30,6 +30,14 @@ type CleanupPolicyNamespaceListerExpansion interface{}
 // ClusterCleanupPolicyLister.
 type ClusterCleanupPolicyListerExpansion interface{}
 
+// ImageAllowListListerExpansion allows custom methods to be added to
+// ImageAllowListLister.
+type ImageAllowListListerExpansion interface{}
+
+// PolicyComplianceSummaryListerExpansion allows custom methods to be added to
+// PolicyComplianceSummaryLister.
+type PolicyComplianceSummaryListerExpansion interface{}
+
 // PolicyExceptionListerExpansion allows custom methods to be added to
 // PolicyExceptionLister.
 type PolicyExceptionListerExpansion interface{}
@@ -37,3 +45,7 @@ type PolicyExceptionListerExpansion interface{}
 // PolicyExceptionNamespaceListerExpansion allows custom methods to be added to
 // PolicyExceptionNamespaceLister.
 type PolicyExceptionNamespaceListerExpansion interface{}
+
+// PolicySetListerExpansion allows custom methods to be added to
+// PolicySetLister.
+type PolicySetListerExpansion interface{}
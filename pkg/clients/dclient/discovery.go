@@ -52,6 +52,33 @@ type IDiscovery interface {
 	CachedDiscoveryInterface() discovery.CachedDiscoveryInterface
 }
 
+// ResourceFinder finds the resources matching a kind selector
+type ResourceFinder interface {
+	FindResources(group, version, kind, subresource string) (map[TopLevelApiDescription]metav1.APIResource, error)
+}
+
+// FindResourcesByName finds the resources matching a resource selector, resource[/subresource][.group]
+func FindResourcesByName(finder ResourceFinder, selector string) (map[TopLevelApiDescription]metav1.APIResource, error) {
+	group, resource, subresource := kubeutils.ParseResourceSelector(selector)
+	found, err := finder.FindResources(group, "*", "*", subresource)
+	if err != nil {
+		return nil, err
+	}
+	resources := map[TopLevelApiDescription]metav1.APIResource{}
+	for gvrs, api := range found {
+		if subresource != "" && gvrs.SubResource == "" {
+			continue
+		}
+		if wildcard.Match(resource, gvrs.Resource) {
+			resources[gvrs] = api
+		}
+	}
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("failed to find resource (%s): %w", selector, ErrResourceNotFound)
+	}
+	return resources, nil
+}
+
 // apiResourceWithListGV is a wrapper for metav1.APIResource with the group-version of its metav1.APIResourceList
 type apiResourceWithListGV struct {
 	apiResource metav1.APIResource
@@ -171,7 +198,7 @@ func (c serverResources) FindResources(group, version, kind, subresource string)
 			if err != nil {
 				return nil, err
 			} else if len(resources) == 0 {
-				return nil, fmt.Errorf("failed to find resource (%s/%s/%s/%s)", group, version, kind, subresource)
+				return nil, fmt.Errorf("failed to find resource (%s/%s/%s/%s): %w", group, version, kind, subresource, ErrResourceNotFound)
 			}
 			return resources, err
 		}
@@ -3,6 +3,7 @@ package dclient
 import (
 	"testing"
 
+	"github.com/kyverno/kyverno/ext/wildcard"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -203,3 +204,52 @@ func Test_findResourceFromResourceName(t *testing.T) {
 	assert.Equal(t, apiResource.Group, podEvictionAPIResource.Group)
 	assert.Equal(t, apiResource.Version, podEvictionAPIResource.Version)
 }
+
+type testResourceFinder map[TopLevelApiDescription]metav1.APIResource
+
+func (f testResourceFinder) FindResources(group, version, kind, subresource string) (map[TopLevelApiDescription]metav1.APIResource, error) {
+	found := map[TopLevelApiDescription]metav1.APIResource{}
+	for gvrs, api := range f {
+		if wildcard.Match(group, gvrs.Group) && wildcard.Match(subresource, gvrs.SubResource) {
+			found[gvrs] = api
+		}
+	}
+	if len(found) == 0 {
+		return nil, ErrResourceNotFound
+	}
+	return found, nil
+}
+
+func Test_FindResourcesByName(t *testing.T) {
+	pods := TopLevelApiDescription{GroupVersion: schema.GroupVersion{Version: "v1"}, Kind: "Pod", Resource: "pods"}
+	podsLog := TopLevelApiDescription{GroupVersion: schema.GroupVersion{Version: "v1"}, Kind: "Pod", Resource: "pods", SubResource: "log"}
+	networkPolicies := TopLevelApiDescription{GroupVersion: schema.GroupVersion{Group: "networking.k8s.io", Version: "v1"}, Kind: "NetworkPolicy", Resource: "networkpolicies"}
+	finder := testResourceFinder{pods: podAPIResource, podsLog: podLogAPIResource, networkPolicies: networkPolicyAPIResource}
+	tests := []struct {
+		selector string
+		want     []TopLevelApiDescription
+		wantErr  bool
+	}{
+		{selector: "pods", want: []TopLevelApiDescription{pods}},
+		{selector: "pods/log", want: []TopLevelApiDescription{podsLog}},
+		{selector: "networkpolicies.networking.k8s.io", want: []TopLevelApiDescription{networkPolicies}},
+		{selector: "*.networking.k8s.io", want: []TopLevelApiDescription{networkPolicies}},
+		{selector: "pods.networking.k8s.io", wantErr: true},
+		{selector: "deployments", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got, err := FindResourcesByName(finder, tt.selector)
+			if tt.wantErr {
+				assert.True(t, IsResourceNotFound(err))
+				return
+			}
+			assert.NoError(t, err)
+			var keys []TopLevelApiDescription
+			for gvrs := range got {
+				keys = append(keys, gvrs)
+			}
+			assert.ElementsMatch(t, tt.want, keys)
+		})
+	}
+}
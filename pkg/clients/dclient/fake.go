@@ -2,7 +2,6 @@ package dclient
 
 import (
 	"errors"
-	"fmt"
 	"strings"
 
 	openapiv2 "github.com/google/gnostic-models/openapiv2"
@@ -91,7 +90,7 @@ func (c *fakeDiscoveryClient) FindResources(group, version, kind, subresource st
 			}, nil
 		}
 	}
-	return nil, fmt.Errorf("not found")
+	return nil, ErrResourceNotFound
 }
 
 func (c *fakeDiscoveryClient) OpenAPISchema() (*openapiv2.Document, error) {
@@ -1,11 +1,20 @@
 package dclient
 
 import (
+	"errors"
 	"strings"
 
 	"k8s.io/client-go/discovery"
 )
 
+// ErrResourceNotFound is returned when discovery doesn't know about a resource, e.g. when its CRD is not installed
+var ErrResourceNotFound = errors.New("resource not found")
+
+// IsResourceNotFound returns true if the error is returned because discovery doesn't know about a resource
+func IsResourceNotFound(err error) bool {
+	return errors.Is(err, ErrResourceNotFound)
+}
+
 func logDiscoveryErrors(err error) {
 	discoveryError := err.(*discovery.ErrGroupDiscoveryFailed)
 	for gv, e := range discoveryError.Groups {
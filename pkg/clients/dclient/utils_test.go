@@ -2,6 +2,7 @@ package dclient
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -52,3 +53,34 @@ func Test_isServerCurrentlyUnableToHandleRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestIsResourceNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{{
+		name: "nil",
+		err:  nil,
+		want: false,
+	}, {
+		name: "other error",
+		err:  errors.New("another error"),
+		want: false,
+	}, {
+		name: "not found",
+		err:  ErrResourceNotFound,
+		want: true,
+	}, {
+		name: "wrapped not found",
+		err:  fmt.Errorf("failed to find resource (example.com/v1/Foo/): %w", ErrResourceNotFound),
+		want: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsResourceNotFound(tt.err); got != tt.want {
+				t.Errorf("IsResourceNotFound() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,7 +5,10 @@ import (
 	github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1 "github.com/kyverno/kyverno/pkg/client/clientset/versioned/typed/kyverno/v2alpha1"
 	cleanuppolicies "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/cleanuppolicies"
 	clustercleanuppolicies "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/clustercleanuppolicies"
+	imageallowlists "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/imageallowlists"
+	policycompliancesummaries "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/policycompliancesummaries"
 	policyexceptions "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/policyexceptions"
+	policysets "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/policysets"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"k8s.io/client-go/rest"
 )
@@ -39,10 +42,22 @@ func (c *withMetrics) ClusterCleanupPolicies() github_com_kyverno_kyverno_pkg_cl
 	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "ClusterCleanupPolicy", c.clientType)
 	return clustercleanuppolicies.WithMetrics(c.inner.ClusterCleanupPolicies(), recorder)
 }
+func (c *withMetrics) ImageAllowLists() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ImageAllowListInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "ImageAllowList", c.clientType)
+	return imageallowlists.WithMetrics(c.inner.ImageAllowLists(), recorder)
+}
+func (c *withMetrics) PolicyComplianceSummaries() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyComplianceSummaryInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "PolicyComplianceSummary", c.clientType)
+	return policycompliancesummaries.WithMetrics(c.inner.PolicyComplianceSummaries(), recorder)
+}
 func (c *withMetrics) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyExceptionInterface {
 	recorder := metrics.NamespacedClientQueryRecorder(c.metrics, namespace, "PolicyException", c.clientType)
 	return policyexceptions.WithMetrics(c.inner.PolicyExceptions(namespace), recorder)
 }
+func (c *withMetrics) PolicySets() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySetInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "PolicySet", c.clientType)
+	return policysets.WithMetrics(c.inner.PolicySets(), recorder)
+}
 
 type withTracing struct {
 	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface
@@ -58,9 +73,18 @@ func (c *withTracing) CleanupPolicies(namespace string) github_com_kyverno_kyver
 func (c *withTracing) ClusterCleanupPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterCleanupPolicyInterface {
 	return clustercleanuppolicies.WithTracing(c.inner.ClusterCleanupPolicies(), c.client, "ClusterCleanupPolicy")
 }
+func (c *withTracing) ImageAllowLists() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ImageAllowListInterface {
+	return imageallowlists.WithTracing(c.inner.ImageAllowLists(), c.client, "ImageAllowList")
+}
+func (c *withTracing) PolicyComplianceSummaries() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyComplianceSummaryInterface {
+	return policycompliancesummaries.WithTracing(c.inner.PolicyComplianceSummaries(), c.client, "PolicyComplianceSummary")
+}
 func (c *withTracing) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyExceptionInterface {
 	return policyexceptions.WithTracing(c.inner.PolicyExceptions(namespace), c.client, "PolicyException")
 }
+func (c *withTracing) PolicySets() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySetInterface {
+	return policysets.WithTracing(c.inner.PolicySets(), c.client, "PolicySet")
+}
 
 type withLogging struct {
 	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface
@@ -76,6 +100,15 @@ func (c *withLogging) CleanupPolicies(namespace string) github_com_kyverno_kyver
 func (c *withLogging) ClusterCleanupPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterCleanupPolicyInterface {
 	return clustercleanuppolicies.WithLogging(c.inner.ClusterCleanupPolicies(), c.logger.WithValues("resource", "ClusterCleanupPolicies"))
 }
+func (c *withLogging) ImageAllowLists() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ImageAllowListInterface {
+	return imageallowlists.WithLogging(c.inner.ImageAllowLists(), c.logger.WithValues("resource", "ImageAllowLists"))
+}
+func (c *withLogging) PolicyComplianceSummaries() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyComplianceSummaryInterface {
+	return policycompliancesummaries.WithLogging(c.inner.PolicyComplianceSummaries(), c.logger.WithValues("resource", "PolicyComplianceSummaries"))
+}
 func (c *withLogging) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyExceptionInterface {
 	return policyexceptions.WithLogging(c.inner.PolicyExceptions(namespace), c.logger.WithValues("resource", "PolicyExceptions").WithValues("namespace", namespace))
 }
+func (c *withLogging) PolicySets() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySetInterface {
+	return policysets.WithLogging(c.inner.PolicySets(), c.logger.WithValues("resource", "PolicySets"))
+}
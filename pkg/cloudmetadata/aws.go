@@ -0,0 +1,123 @@
+package cloudmetadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+const awsEndpoint = "http://169.254.169.254"
+
+// awsProvider reads the EC2 instance metadata service (IMDSv2) and the aws-auth ConfigMap of EKS clusters
+type awsProvider struct {
+	client     *http.Client
+	endpoint   string
+	kubeClient kubernetes.Interface
+}
+
+type awsIdentity struct {
+	AccountID        string `json:"accountId"`
+	Region           string `json:"region"`
+	AvailabilityZone string `json:"availabilityZone"`
+	InstanceID       string `json:"instanceId"`
+	InstanceType     string `json:"instanceType"`
+}
+
+func (p *awsProvider) fetch(ctx context.Context) (map[string]interface{}, error) {
+	token, err := get(ctx, p.client, http.MethodPut, p.endpoint+"/latest/api/token", map[string]string{
+		"X-aws-ec2-metadata-token-ttl-seconds": "300",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get IMDS token: %w", err)
+	}
+	headers := map[string]string{"X-aws-ec2-metadata-token": string(token)}
+	raw, err := get(ctx, p.client, http.MethodGet, p.endpoint+"/latest/dynamic/instance-identity/document", headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance identity: %w", err)
+	}
+	var identity awsIdentity
+	if err := json.Unmarshal(raw, &identity); err != nil {
+		return nil, fmt.Errorf("failed to decode instance identity: %w", err)
+	}
+	tags, err := p.tags(ctx, headers)
+	if err != nil {
+		return nil, err
+	}
+	clusterName := tags["eks:cluster-name"]
+	if clusterName == "" {
+		clusterName = tags["aws:eks:cluster-name"]
+	}
+	roles, users, err := p.iamMappings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"accountId":        identity.AccountID,
+		"region":           identity.Region,
+		"availabilityZone": identity.AvailabilityZone,
+		"instanceId":       identity.InstanceID,
+		"instanceType":     identity.InstanceType,
+		"ecrRegistry":      fmt.Sprintf("%s.dkr.ecr.%s.amazonaws.com", identity.AccountID, identity.Region),
+		"clusterName":      clusterName,
+		"tags":             tags,
+		"iamRoleMappings":  roles,
+		"iamUserMappings":  users,
+	}, nil
+}
+
+// tags returns the instance tags, they are only available when tags access is enabled in the instance metadata options
+func (p *awsProvider) tags(ctx context.Context, headers map[string]string) (map[string]string, error) {
+	tags := map[string]string{}
+	keys, err := get(ctx, p.client, http.MethodGet, p.endpoint+"/latest/meta-data/tags/instance", headers)
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			return tags, nil
+		}
+		return nil, fmt.Errorf("failed to get instance tags: %w", err)
+	}
+	for _, key := range strings.Split(string(keys), "\n") {
+		if key = strings.TrimSpace(key); key == "" {
+			continue
+		}
+		value, err := get(ctx, p.client, http.MethodGet, p.endpoint+"/latest/meta-data/tags/instance/"+key, headers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get instance tag %s: %w", key, err)
+		}
+		tags[key] = string(value)
+	}
+	return tags, nil
+}
+
+// iamMappings returns the IAM roles and users mapped to Kubernetes identities in the aws-auth ConfigMap
+func (p *awsProvider) iamMappings(ctx context.Context) ([]interface{}, []interface{}, error) {
+	roles, users := []interface{}{}, []interface{}{}
+	if p.kubeClient == nil {
+		return roles, users, nil
+	}
+	cm, err := p.kubeClient.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(ctx, "aws-auth", metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return roles, users, nil
+		}
+		return nil, nil, fmt.Errorf("failed to get aws-auth config map: %w", err)
+	}
+	if data := cm.Data["mapRoles"]; data != "" {
+		if err := yaml.Unmarshal([]byte(data), &roles); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode aws-auth role mappings: %w", err)
+		}
+	}
+	if data := cm.Data["mapUsers"]; data != "" {
+		if err := yaml.Unmarshal([]byte(data), &users); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode aws-auth user mappings: %w", err)
+		}
+	}
+	return roles, users, nil
+}
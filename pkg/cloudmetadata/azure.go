@@ -0,0 +1,56 @@
+package cloudmetadata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const azureEndpoint = "http://169.254.169.254"
+
+// azureProvider reads the Azure instance metadata service
+type azureProvider struct {
+	client   *http.Client
+	endpoint string
+}
+
+type azureCompute struct {
+	Location          string `json:"location"`
+	SubscriptionID    string `json:"subscriptionId"`
+	ResourceGroupName string `json:"resourceGroupName"`
+	Name              string `json:"name"`
+	VMID              string `json:"vmId"`
+	VMSize            string `json:"vmSize"`
+	Zone              string `json:"zone"`
+	TagsList          []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"tagsList"`
+}
+
+func (p *azureProvider) fetch(ctx context.Context) (map[string]interface{}, error) {
+	raw, err := get(ctx, p.client, http.MethodGet, p.endpoint+"/metadata/instance/compute?api-version=2021-02-01", map[string]string{"Metadata": "true"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance metadata: %w", err)
+	}
+	var compute azureCompute
+	if err := json.Unmarshal(raw, &compute); err != nil {
+		return nil, fmt.Errorf("failed to decode instance metadata: %w", err)
+	}
+	tags := map[string]string{}
+	for _, tag := range compute.TagsList {
+		tags[tag.Name] = tag.Value
+	}
+	return map[string]interface{}{
+		"location":          compute.Location,
+		"subscriptionId":    compute.SubscriptionID,
+		"resourceGroupName": compute.ResourceGroupName,
+		"vmName":            compute.Name,
+		"vmId":              compute.VMID,
+		"vmSize":            compute.VMSize,
+		"zone":              compute.Zone,
+		"clusterName":       tags["aks-managed-cluster-name"],
+		"tags":              tags,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package cloudmetadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+)
+
+const gcpEndpoint = "http://metadata.google.internal"
+
+// gcpProvider reads the Compute Engine metadata server
+type gcpProvider struct {
+	client   *http.Client
+	endpoint string
+}
+
+func (p *gcpProvider) fetch(ctx context.Context) (map[string]interface{}, error) {
+	projectID, err := p.get(ctx, "project/project-id", true)
+	if err != nil {
+		return nil, err
+	}
+	numericProjectID, err := p.get(ctx, "project/numeric-project-id", true)
+	if err != nil {
+		return nil, err
+	}
+	instanceID, err := p.get(ctx, "instance/id", true)
+	if err != nil {
+		return nil, err
+	}
+	// the zone is returned as projects/<number>/zones/<zone>
+	zone, err := p.get(ctx, "instance/zone", true)
+	if err != nil {
+		return nil, err
+	}
+	zone = path.Base(zone)
+	region := zone
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		region = zone[:i]
+	}
+	// GKE nodes carry the cluster name and location in their attributes
+	clusterName, err := p.get(ctx, "instance/attributes/cluster-name", false)
+	if err != nil {
+		return nil, err
+	}
+	clusterLocation, err := p.get(ctx, "instance/attributes/cluster-location", false)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"projectId":        projectID,
+		"numericProjectId": numericProjectID,
+		"instanceId":       instanceID,
+		"zone":             zone,
+		"region":           region,
+		"artifactRegistry": region + "-docker.pkg.dev",
+		"clusterName":      clusterName,
+		"clusterLocation":  clusterLocation,
+	}, nil
+}
+
+func (p *gcpProvider) get(ctx context.Context, key string, required bool) (string, error) {
+	value, err := get(ctx, p.client, http.MethodGet, p.endpoint+"/computeMetadata/v1/"+key, map[string]string{"Metadata-Flavor": "Google"})
+	if err != nil {
+		if !required && errors.Is(err, errNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get %s metadata: %w", key, err)
+	}
+	return strings.TrimSpace(string(value)), nil
+}
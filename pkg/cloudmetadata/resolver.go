@@ -0,0 +1,133 @@
+package cloudmetadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultTTL is the default duration metadata documents are cached for
+	DefaultTTL = 10 * time.Minute
+	// requestTimeout is the timeout of a single request to a metadata service
+	requestTimeout = 5 * time.Second
+)
+
+var errNotFound = errors.New("not found")
+
+// provider fetches the metadata document of a cloud provider
+type provider interface {
+	fetch(ctx context.Context) (map[string]interface{}, error)
+}
+
+type cacheEntry struct {
+	document  map[string]interface{}
+	expiresAt time.Time
+}
+
+type resolver struct {
+	lock      sync.Mutex
+	ttl       time.Duration
+	now       func() time.Time
+	providers map[kyvernov1.CloudProvider]provider
+	cache     map[kyvernov1.CloudProvider]cacheEntry
+}
+
+// ParseProviders parses a comma separated list of cloud providers
+func ParseProviders(value string) ([]kyvernov1.CloudProvider, error) {
+	var providers []kyvernov1.CloudProvider
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		var found bool
+		for _, p := range []kyvernov1.CloudProvider{kyvernov1.CloudProviderAWS, kyvernov1.CloudProviderGCP, kyvernov1.CloudProviderAzure} {
+			if strings.EqualFold(name, string(p)) {
+				providers = append(providers, p)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown cloud provider %s, must be one of AWS, GCP or Azure", name)
+		}
+	}
+	return providers, nil
+}
+
+// NewResolver returns a resolver fetching the metadata of the given cloud providers from their instance
+// metadata services, documents are cached for the given duration as they rarely change.
+func NewResolver(kubeClient kubernetes.Interface, ttl time.Duration, providers ...kyvernov1.CloudProvider) engineapi.CloudMetadataResolver {
+	client := &http.Client{Timeout: requestTimeout}
+	r := &resolver{
+		ttl:       ttl,
+		now:       time.Now,
+		providers: map[kyvernov1.CloudProvider]provider{},
+		cache:     map[kyvernov1.CloudProvider]cacheEntry{},
+	}
+	for _, p := range providers {
+		switch p {
+		case kyvernov1.CloudProviderAWS:
+			r.providers[p] = &awsProvider{client: client, endpoint: awsEndpoint, kubeClient: kubeClient}
+		case kyvernov1.CloudProviderGCP:
+			r.providers[p] = &gcpProvider{client: client, endpoint: gcpEndpoint}
+		case kyvernov1.CloudProviderAzure:
+			r.providers[p] = &azureProvider{client: client, endpoint: azureEndpoint}
+		}
+	}
+	return r
+}
+
+func (r *resolver) Get(ctx context.Context, cloud kyvernov1.CloudProvider) (map[string]interface{}, error) {
+	p, ok := r.providers[cloud]
+	if !ok {
+		return nil, fmt.Errorf("cloud metadata provider %s is not enabled", cloud)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if entry, ok := r.cache[cloud]; ok && r.now().Before(entry.expiresAt) {
+		return entry.document, nil
+	}
+	document, err := p.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.cache[cloud] = cacheEntry{document: document, expiresAt: r.now().Add(r.ttl)}
+	return document, nil
+}
+
+// get sends a request to a metadata service and returns the response body, errNotFound is returned on 404
+func get(ctx context.Context, client *http.Client, method, url string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s: %w", url, errNotFound)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s: unexpected status code %d", url, resp.StatusCode)
+	}
+	return body, nil
+}
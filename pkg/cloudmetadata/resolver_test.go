@@ -0,0 +1,159 @@
+package cloudmetadata
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseProviders(t *testing.T) {
+	providers, err := ParseProviders("aws, GCP,,Azure")
+	assert.NoError(t, err)
+	assert.Equal(t, []kyvernov1.CloudProvider{kyvernov1.CloudProviderAWS, kyvernov1.CloudProviderGCP, kyvernov1.CloudProviderAzure}, providers)
+	providers, err = ParseProviders("")
+	assert.NoError(t, err)
+	assert.Empty(t, providers)
+	_, err = ParseProviders("aws,foo")
+	assert.Error(t, err)
+}
+
+func TestAWSProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/latest/api/token" {
+			if r.Method != http.MethodPut {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			_, _ = w.Write([]byte("token"))
+			return
+		}
+		if r.Header.Get("X-aws-ec2-metadata-token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/latest/dynamic/instance-identity/document":
+			_, _ = w.Write([]byte(`{"accountId":"123456789012","region":"eu-west-1","availabilityZone":"eu-west-1a","instanceId":"i-1234","instanceType":"m5.large"}`))
+		case "/latest/meta-data/tags/instance":
+			_, _ = w.Write([]byte("eks:cluster-name\nteam"))
+		case "/latest/meta-data/tags/instance/eks:cluster-name":
+			_, _ = w.Write([]byte("prod"))
+		case "/latest/meta-data/tags/instance/team":
+			_, _ = w.Write([]byte("platform"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	kubeClient := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws-auth", Namespace: metav1.NamespaceSystem},
+		Data: map[string]string{
+			"mapRoles": "- rolearn: arn:aws:iam::123456789012:role/admin\n  username: admin\n  groups:\n  - system:masters\n",
+		},
+	})
+	p := &awsProvider{client: server.Client(), endpoint: server.URL, kubeClient: kubeClient}
+	document, err := p.fetch(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "eu-west-1", document["region"])
+	assert.Equal(t, "123456789012.dkr.ecr.eu-west-1.amazonaws.com", document["ecrRegistry"])
+	assert.Equal(t, "prod", document["clusterName"])
+	assert.Equal(t, map[string]string{"eks:cluster-name": "prod", "team": "platform"}, document["tags"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"rolearn":  "arn:aws:iam::123456789012:role/admin",
+		"username": "admin",
+		"groups":   []interface{}{"system:masters"},
+	}}, document["iamRoleMappings"])
+	assert.Equal(t, []interface{}{}, document["iamUserMappings"])
+}
+
+func TestGCPProvider(t *testing.T) {
+	values := map[string]string{
+		"/computeMetadata/v1/project/project-id":               "my-project",
+		"/computeMetadata/v1/project/numeric-project-id":       "1234",
+		"/computeMetadata/v1/instance/id":                      "5678",
+		"/computeMetadata/v1/instance/zone":                    "projects/1234/zones/us-central1-a",
+		"/computeMetadata/v1/instance/attributes/cluster-name": "prod",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if value, ok := values[r.URL.Path]; ok {
+			_, _ = w.Write([]byte(value))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	p := &gcpProvider{client: server.Client(), endpoint: server.URL}
+	document, err := p.fetch(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"projectId":        "my-project",
+		"numericProjectId": "1234",
+		"instanceId":       "5678",
+		"zone":             "us-central1-a",
+		"region":           "us-central1",
+		"artifactRegistry": "us-central1-docker.pkg.dev",
+		"clusterName":      "prod",
+		"clusterLocation":  "",
+	}, document)
+}
+
+func TestAzureProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" || r.URL.Path != "/metadata/instance/compute" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"location":"westeurope","subscriptionId":"sub","resourceGroupName":"rg","name":"vm","vmId":"id","vmSize":"Standard_D2s_v3","zone":"1","tagsList":[{"name":"aks-managed-cluster-name","value":"prod"}]}`))
+	}))
+	defer server.Close()
+	p := &azureProvider{client: server.Client(), endpoint: server.URL}
+	document, err := p.fetch(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "westeurope", document["location"])
+	assert.Equal(t, "prod", document["clusterName"])
+	assert.Equal(t, map[string]string{"aks-managed-cluster-name": "prod"}, document["tags"])
+}
+
+type fakeProvider struct {
+	calls int
+}
+
+func (p *fakeProvider) fetch(context.Context) (map[string]interface{}, error) {
+	p.calls++
+	return map[string]interface{}{"calls": p.calls}, nil
+}
+
+func TestResolverCache(t *testing.T) {
+	now := time.Now()
+	p := &fakeProvider{}
+	r := &resolver{
+		ttl:       time.Minute,
+		now:       func() time.Time { return now },
+		providers: map[kyvernov1.CloudProvider]provider{kyvernov1.CloudProviderAWS: p},
+		cache:     map[kyvernov1.CloudProvider]cacheEntry{},
+	}
+	document, err := r.Get(context.TODO(), kyvernov1.CloudProviderAWS)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, document["calls"])
+	document, err = r.Get(context.TODO(), kyvernov1.CloudProviderAWS)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, document["calls"])
+	now = now.Add(2 * time.Minute)
+	document, err = r.Get(context.TODO(), kyvernov1.CloudProviderAWS)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, document["calls"])
+	_, err = r.Get(context.TODO(), kyvernov1.CloudProviderGCP)
+	assert.Error(t, err)
+}
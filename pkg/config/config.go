@@ -1,7 +1,6 @@
 package config
 
 import (
-	"errors"
 	"fmt"
 	"strconv"
 	"sync"
@@ -9,6 +8,7 @@ import (
 	valid "github.com/asaskevich/govalidator"
 	"github.com/kyverno/kyverno/ext/wildcard"
 	osutils "github.com/kyverno/kyverno/pkg/utils/os"
+	"go.uber.org/multierr"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -72,8 +72,12 @@ const (
 	LivenessServicePath = "/health/liveness"
 	// ReadinessServicePath is the path for check readness health
 	ReadinessServicePath = "/health/readiness"
+	// VersionServicePath is the path for version information
+	VersionServicePath = "/version"
 	// MetricsPath is the path for exposing metrics
 	MetricsPath = "/metrics"
+	// NamespacedMetricsPath is the path for exposing the metrics of the namespaces a caller has access to
+	NamespacedMetricsPath = "/namespaced-metrics"
 )
 
 // keys in config map
@@ -86,12 +90,23 @@ const (
 	excludeRoles                  = "excludeRoles"
 	excludeClusterRoles           = "excludeClusterRoles"
 	generateSuccessEvents         = "generateSuccessEvents"
+	failFast                      = "failFast"
+	denialMessageFormat           = "denialMessageFormat"
 	webhooks                      = "webhooks"
 	webhookAnnotations            = "webhookAnnotations"
 	webhookLabels                 = "webhookLabels"
+	webhookExcludedNamespaces     = "webhookExcludedNamespaces"
 	matchConditions               = "matchConditions"
 )
 
+// denial message formats
+const (
+	// DenialMessageFormatText lists the failed rules per policy in a human readable message
+	DenialMessageFormatText = "text"
+	// DenialMessageFormatJSON encodes the failed rules, in evaluation order, as a JSON document
+	DenialMessageFormatJSON = "json"
+)
+
 var (
 	// kyvernoNamespace is the Kyverno namespace
 	kyvernoNamespace = osutils.GetEnvWithFallback("KYVERNO_NAMESPACE", "kyverno")
@@ -159,35 +174,57 @@ type Configuration interface {
 	ToFilter(kind schema.GroupVersionKind, subresource, namespace, name string) bool
 	// GetGenerateSuccessEvents return if should generate success events
 	GetGenerateSuccessEvents() bool
+	// GetFailFast returns true if admission evaluation should stop at the first enforce failure by default
+	GetFailFast() bool
+	// GetDenialMessageFormat returns the format of the message of denied admission requests
+	GetDenialMessageFormat() string
 	// GetWebhooks returns the webhook configs
 	GetWebhooks() []WebhookConfig
 	// GetWebhookAnnotations returns annotations to set on webhook configs
 	GetWebhookAnnotations() map[string]string
 	// GetWebhookLabels returns labels to set on webhook configs
 	GetWebhookLabels() map[string]string
+	// GetWebhookExcludedNamespaces returns the namespaces excluded from the resource webhooks
+	GetWebhookExcludedNamespaces() []string
 	// GetMatchConditions returns match conditions to set on webhook configs
 	GetMatchConditions() []admissionregistrationv1.MatchCondition
-	// Load loads configuration from a configmap
-	Load(*corev1.ConfigMap)
+	// Load loads configuration from a configmap, an invalid configmap is rejected and the current configuration is kept
+	Load(*corev1.ConfigMap) error
 	// OnChanged adds a callback to be invoked when the configuration is reloaded
 	OnChanged(func())
 }
 
 // configuration stores the configuration
 type configuration struct {
-	skipResourceFilters           bool
+	settings
+	skipResourceFilters bool
+	mux                 sync.RWMutex
+	callbacks           []func()
+}
+
+// settings stores the values loaded from the configmap, they are replaced as a whole on reload
+type settings struct {
 	defaultRegistry               string
 	enableDefaultRegistryMutation bool
 	exclusions                    match
 	inclusions                    match
 	filters                       []filter
 	generateSuccessEvents         bool
+	failFast                      bool
+	denialMessageFormat           string
 	webhooks                      []WebhookConfig
 	webhookAnnotations            map[string]string
 	webhookLabels                 map[string]string
+	webhookExcludedNamespaces     []string
 	matchConditions               []admissionregistrationv1.MatchCondition
-	mux                           sync.RWMutex
-	callbacks                     []func()
+}
+
+func defaultSettings() settings {
+	return settings{
+		defaultRegistry:               "docker.io",
+		enableDefaultRegistryMutation: true,
+		filters:                       []filter{},
+	}
 }
 
 type match struct {
@@ -234,9 +271,8 @@ func (c match) matches(username string, groups []string, roles []string, cluster
 // NewDefaultConfiguration ...
 func NewDefaultConfiguration(skipResourceFilters bool) *configuration {
 	return &configuration{
-		skipResourceFilters:           skipResourceFilters,
-		defaultRegistry:               "docker.io",
-		enableDefaultRegistryMutation: true,
+		settings:            defaultSettings(),
+		skipResourceFilters: skipResourceFilters,
 	}
 }
 
@@ -247,6 +283,8 @@ func (cd *configuration) OnChanged(callback func()) {
 }
 
 func (c *configuration) IsExcluded(username string, groups []string, roles []string, clusterroles []string) bool {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
 	if c.inclusions.matches(username, groups, roles, clusterroles) {
 		return false
 	}
@@ -292,6 +330,18 @@ func (cd *configuration) GetGenerateSuccessEvents() bool {
 	return cd.generateSuccessEvents
 }
 
+func (cd *configuration) GetFailFast() bool {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.failFast
+}
+
+func (cd *configuration) GetDenialMessageFormat() string {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.denialMessageFormat
+}
+
 func (cd *configuration) GetWebhooks() []WebhookConfig {
 	cd.mux.RLock()
 	defer cd.mux.RUnlock()
@@ -310,54 +360,53 @@ func (cd *configuration) GetWebhookLabels() map[string]string {
 	return cd.webhookLabels
 }
 
+func (cd *configuration) GetWebhookExcludedNamespaces() []string {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.webhookExcludedNamespaces
+}
+
 func (cd *configuration) GetMatchConditions() []admissionregistrationv1.MatchCondition {
 	cd.mux.RLock()
 	defer cd.mux.RUnlock()
 	return cd.matchConditions
 }
 
-func (cd *configuration) Load(cm *corev1.ConfigMap) {
-	if cm != nil {
-		cd.load(cm)
-	} else {
+func (cd *configuration) Load(cm *corev1.ConfigMap) error {
+	if cm == nil {
 		cd.unload()
+		return nil
 	}
+	return cd.load(cm)
 }
 
-func (cd *configuration) load(cm *corev1.ConfigMap) {
+func (cd *configuration) load(cm *corev1.ConfigMap) error {
 	logger := logger.WithValues("name", cm.Name, "namespace", cm.Namespace)
-	cd.mux.Lock()
-	defer cd.mux.Unlock()
-	defer cd.notify()
 	data := cm.Data
 	if data == nil {
 		data = map[string]string{}
 	}
-	// reset
-	cd.defaultRegistry = "docker.io"
-	cd.enableDefaultRegistryMutation = true
-	cd.exclusions = match{}
-	cd.inclusions = match{}
-	cd.filters = []filter{}
-	cd.generateSuccessEvents = false
-	cd.webhooks = nil
-	cd.webhookAnnotations = nil
-	cd.webhookLabels = nil
-	cd.matchConditions = nil
+	// every key is parsed into fresh settings, they replace the current ones only if the whole configmap is valid
+	s := defaultSettings()
+	var errs []error
 	// load filters
-	cd.filters = parseKinds(data[resourceFilters])
-	logger.Info("filters configured", "filters", cd.filters)
+	if err := validateKinds(data[resourceFilters]); err != nil {
+		errs = append(errs, fmt.Errorf("invalid resourceFilters: %w", err))
+	} else {
+		s.filters = parseKinds(data[resourceFilters])
+		logger.Info("filters configured", "filters", s.filters)
+	}
 	// load defaultRegistry
-	defaultRegistry, ok := data[defaultRegistry]
+	registry, ok := data[defaultRegistry]
 	if !ok {
 		logger.Info("defaultRegistry not set")
 	} else {
-		logger := logger.WithValues("defaultRegistry", defaultRegistry)
-		if valid.IsDNSName(defaultRegistry) {
-			cd.defaultRegistry = defaultRegistry
+		logger := logger.WithValues("defaultRegistry", registry)
+		if valid.IsDNSName(registry) {
+			s.defaultRegistry = registry
 			logger.Info("defaultRegistry configured")
 		} else {
-			logger.Error(errors.New("defaultRegistry is not a valid DNS hostname"), "failed to configure defaultRegistry")
+			errs = append(errs, fmt.Errorf("invalid defaultRegistry: %s is not a valid DNS hostname", registry))
 		}
 	}
 	// load enableDefaultRegistryMutation
@@ -368,9 +417,9 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 		logger := logger.WithValues("enableDefaultRegistryMutation", enableDefaultRegistryMutation)
 		enableDefaultRegistryMutation, err := strconv.ParseBool(enableDefaultRegistryMutation)
 		if err != nil {
-			logger.Error(err, "enableDefaultRegistryMutation is not a boolean")
+			errs = append(errs, fmt.Errorf("invalid enableDefaultRegistryMutation: %w", err))
 		} else {
-			cd.enableDefaultRegistryMutation = enableDefaultRegistryMutation
+			s.enableDefaultRegistryMutation = enableDefaultRegistryMutation
 			logger.Info("enableDefaultRegistryMutation configured")
 		}
 	}
@@ -379,32 +428,32 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 	if !ok {
 		logger.Info("excludeGroups not set")
 	} else {
-		cd.exclusions.groups, cd.inclusions.groups = parseExclusions(excludedGroups)
-		logger.Info("excludedGroups configured", "excludeGroups", cd.exclusions.groups, "includeGroups", cd.inclusions.groups)
+		s.exclusions.groups, s.inclusions.groups = parseExclusions(excludedGroups)
+		logger.Info("excludedGroups configured", "excludeGroups", s.exclusions.groups, "includeGroups", s.inclusions.groups)
 	}
 	// load excludeUsername
 	excludedUsernames, ok := data[excludeUsernames]
 	if !ok {
 		logger.Info("excludeUsernames not set")
 	} else {
-		cd.exclusions.usernames, cd.inclusions.usernames = parseExclusions(excludedUsernames)
-		logger.Info("excludedUsernames configured", "excludeUsernames", cd.exclusions.usernames, "includeUsernames", cd.inclusions.usernames)
+		s.exclusions.usernames, s.inclusions.usernames = parseExclusions(excludedUsernames)
+		logger.Info("excludedUsernames configured", "excludeUsernames", s.exclusions.usernames, "includeUsernames", s.inclusions.usernames)
 	}
 	// load excludeRoles
 	excludedRoles, ok := data[excludeRoles]
 	if !ok {
 		logger.Info("excludeRoles not set")
 	} else {
-		cd.exclusions.roles, cd.inclusions.roles = parseExclusions(excludedRoles)
-		logger.Info("excludedRoles configured", "excludeRoles", cd.exclusions.roles, "includeRoles", cd.inclusions.roles)
+		s.exclusions.roles, s.inclusions.roles = parseExclusions(excludedRoles)
+		logger.Info("excludedRoles configured", "excludeRoles", s.exclusions.roles, "includeRoles", s.inclusions.roles)
 	}
 	// load excludeClusterRoles
 	excludedClusterRoles, ok := data[excludeClusterRoles]
 	if !ok {
 		logger.Info("excludeClusterRoles not set")
 	} else {
-		cd.exclusions.clusterroles, cd.inclusions.clusterroles = parseExclusions(excludedClusterRoles)
-		logger.Info("excludedClusterRoles configured", "excludeClusterRoles", cd.exclusions.clusterroles, "includeClusterRoles", cd.inclusions.clusterroles)
+		s.exclusions.clusterroles, s.inclusions.clusterroles = parseExclusions(excludedClusterRoles)
+		logger.Info("excludedClusterRoles configured", "excludeClusterRoles", s.exclusions.clusterroles, "includeClusterRoles", s.inclusions.clusterroles)
 	}
 	// load generateSuccessEvents
 	generateSuccessEvents, ok := data[generateSuccessEvents]
@@ -414,12 +463,40 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 		logger := logger.WithValues("generateSuccessEvents", generateSuccessEvents)
 		generateSuccessEvents, err := strconv.ParseBool(generateSuccessEvents)
 		if err != nil {
-			logger.Error(err, "generateSuccessEvents is not a boolean")
+			errs = append(errs, fmt.Errorf("invalid generateSuccessEvents: %w", err))
 		} else {
-			cd.generateSuccessEvents = generateSuccessEvents
+			s.generateSuccessEvents = generateSuccessEvents
 			logger.Info("generateSuccessEvents configured")
 		}
 	}
+	// load failFast
+	failFast, ok := data[failFast]
+	if !ok {
+		logger.Info("failFast not set")
+	} else {
+		logger := logger.WithValues("failFast", failFast)
+		failFast, err := strconv.ParseBool(failFast)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid failFast: %w", err))
+		} else {
+			s.failFast = failFast
+			logger.Info("failFast configured")
+		}
+	}
+	// load denialMessageFormat
+	denialMessageFormat, ok := data[denialMessageFormat]
+	if !ok {
+		logger.Info("denialMessageFormat not set")
+	} else {
+		logger := logger.WithValues("denialMessageFormat", denialMessageFormat)
+		switch denialMessageFormat {
+		case DenialMessageFormatText, DenialMessageFormatJSON:
+			s.denialMessageFormat = denialMessageFormat
+			logger.Info("denialMessageFormat configured")
+		default:
+			errs = append(errs, fmt.Errorf("invalid denialMessageFormat: %s, must be %s or %s", denialMessageFormat, DenialMessageFormatText, DenialMessageFormatJSON))
+		}
+	}
 	// load webhooks
 	webhooks, ok := data[webhooks]
 	if !ok {
@@ -428,9 +505,9 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 		logger := logger.WithValues("webhooks", webhooks)
 		webhooks, err := parseWebhooks(webhooks)
 		if err != nil {
-			logger.Error(err, "failed to parse webhooks")
+			errs = append(errs, fmt.Errorf("invalid webhooks: %w", err))
 		} else {
-			cd.webhooks = webhooks
+			s.webhooks = webhooks
 			logger.Info("webhooks configured")
 		}
 	}
@@ -442,9 +519,9 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 		logger := logger.WithValues("webhookAnnotations", webhookAnnotations)
 		webhookAnnotations, err := parseWebhookAnnotations(webhookAnnotations)
 		if err != nil {
-			logger.Error(err, "failed to parse webhook annotations")
+			errs = append(errs, fmt.Errorf("invalid webhookAnnotations: %w", err))
 		} else {
-			cd.webhookAnnotations = webhookAnnotations
+			s.webhookAnnotations = webhookAnnotations
 			logger.Info("webhookAnnotations configured")
 		}
 	}
@@ -456,12 +533,26 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 		logger := logger.WithValues("webhookLabels", webhookLabels)
 		webhookLabels, err := parseWebhookLabels(webhookLabels)
 		if err != nil {
-			logger.Error(err, "failed to parse webhook labels")
+			errs = append(errs, fmt.Errorf("invalid webhookLabels: %w", err))
 		} else {
-			cd.webhookLabels = webhookLabels
+			s.webhookLabels = webhookLabels
 			logger.Info("webhookLabels configured")
 		}
 	}
+	// load webhook excluded namespaces
+	webhookExcludedNamespaces, ok := data[webhookExcludedNamespaces]
+	if !ok {
+		logger.Info("webhookExcludedNamespaces not set")
+	} else {
+		logger := logger.WithValues("webhookExcludedNamespaces", webhookExcludedNamespaces)
+		webhookExcludedNamespaces, err := ParseNamespaces(webhookExcludedNamespaces)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid webhookExcludedNamespaces: %w", err))
+		} else {
+			s.webhookExcludedNamespaces = webhookExcludedNamespaces
+			logger.Info("webhookExcludedNamespaces configured")
+		}
+	}
 	// load match conditions
 	matchConditions, ok := data[matchConditions]
 	if !ok {
@@ -470,27 +561,29 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 		logger := logger.WithValues("matchConditions", matchConditions)
 		matchConditions, err := parseMatchConditions(matchConditions)
 		if err != nil {
-			logger.Error(err, "failed to parse match conditions")
+			errs = append(errs, fmt.Errorf("invalid matchConditions: %w", err))
 		} else {
-			cd.matchConditions = matchConditions
+			s.matchConditions = matchConditions
 			logger.Info("matchConditions configured")
 		}
 	}
+	if len(errs) != 0 {
+		err := multierr.Combine(errs...)
+		logger.Error(err, "configuration rejected, keeping the current configuration")
+		return err
+	}
+	cd.mux.Lock()
+	defer cd.mux.Unlock()
+	defer cd.notify()
+	cd.settings = s
+	return nil
 }
 
 func (cd *configuration) unload() {
 	cd.mux.Lock()
 	defer cd.mux.Unlock()
 	defer cd.notify()
-	cd.defaultRegistry = "docker.io"
-	cd.enableDefaultRegistryMutation = true
-	cd.exclusions = match{}
-	cd.inclusions = match{}
-	cd.filters = []filter{}
-	cd.generateSuccessEvents = false
-	cd.webhooks = nil
-	cd.webhookAnnotations = nil
-	cd.webhookLabels = nil
+	cd.settings = defaultSettings()
 	logger.Info("configuration unloaded")
 }
 
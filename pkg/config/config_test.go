@@ -0,0 +1,108 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_configuration_load(t *testing.T) {
+	tests := []struct {
+		name       string
+		configMap  *corev1.ConfigMap
+		wantErr    bool
+		wantFilter []filter
+	}{
+		{
+			name: "valid",
+			configMap: &corev1.ConfigMap{
+				Data: map[string]string{
+					"resourceFilters":     "[Event,*,*][*,kube-system,*]",
+					"failFast":            "true",
+					"denialMessageFormat": "json",
+				},
+			},
+			wantFilter: []filter{newFilter("Event", "*", "*"), newFilter("*", "kube-system", "*")},
+		},
+		{
+			name: "invalid boolean",
+			configMap: &corev1.ConfigMap{
+				Data: map[string]string{
+					"resourceFilters": "[Event,*,*]",
+					"failFast":        "yes please",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid filter",
+			configMap: &corev1.ConfigMap{
+				Data: map[string]string{
+					"resourceFilters": "[Event,*,*,*]",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid denial message format",
+			configMap: &corev1.ConfigMap{
+				Data: map[string]string{
+					"denialMessageFormat": "xml",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid webhooks",
+			configMap: &corev1.ConfigMap{
+				Data: map[string]string{
+					"webhooks": "{",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid webhook excluded namespaces",
+			configMap: &corev1.ConfigMap{
+				Data: map[string]string{
+					"webhookExcludedNamespaces": "kube-system,*",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cd := NewDefaultConfiguration(false)
+			if err := cd.Load(&corev1.ConfigMap{Data: map[string]string{"resourceFilters": "[Pod,default,*]", "defaultRegistry": "ghcr.io"}}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			notified := false
+			cd.OnChanged(func() { notified = true })
+			err := cd.Load(tt.configMap)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if notified == tt.wantErr {
+				t.Errorf("Expected notified to be %v", !tt.wantErr)
+			}
+			if tt.wantErr {
+				// the previous configuration must be kept as a whole
+				if !reflect.DeepEqual(cd.filters, []filter{newFilter("Pod", "default", "*")}) {
+					t.Errorf("Expected previous filters to be kept, but got %+v", cd.filters)
+				}
+				if cd.GetDefaultRegistry() != "ghcr.io" {
+					t.Errorf("Expected previous default registry to be kept, but got %s", cd.GetDefaultRegistry())
+				}
+				return
+			}
+			if !reflect.DeepEqual(cd.filters, tt.wantFilter) {
+				t.Errorf("Expected %+v, but got %+v", tt.wantFilter, cd.filters)
+			}
+			if cd.GetDefaultRegistry() != "docker.io" {
+				t.Errorf("Expected default registry to be reset, but got %s", cd.GetDefaultRegistry())
+			}
+		})
+	}
+}
@@ -1,12 +1,14 @@
 package config
 
 import (
+	"fmt"
 	"slices"
 	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.uber.org/multierr"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -24,20 +26,25 @@ type MetricsConfiguration interface {
 	GetBucketBoundaries() []float64
 	// BuildMeterProviderViews returns OTL view removing attributes which were disabled in the config
 	BuildMeterProviderViews() []sdkmetric.View
-	// Load loads configuration from a configmap
-	Load(*corev1.ConfigMap)
+	// Load loads configuration from a configmap, an invalid configmap is rejected and the current configuration is kept
+	Load(*corev1.ConfigMap) error
 	// OnChanged adds a callback to be invoked when the configuration is reloaded
 	OnChanged(func())
 }
 
 // metricsConfig stores the config for metrics
 type metricsConfig struct {
+	metricsSettings
+	mux       sync.RWMutex
+	callbacks []func()
+}
+
+// metricsSettings stores the values loaded from the configmap, they are replaced as a whole on reload
+type metricsSettings struct {
 	namespaces             namespacesConfig
 	metricsRefreshInterval time.Duration
 	bucketBoundaries       []float64
 	metricsExposure        map[string]metricExposureConfig
-	mux                    sync.RWMutex
-	callbacks              []func()
 }
 
 // NewDefaultMetricsConfiguration ...
@@ -128,25 +135,23 @@ func (mcd *metricsConfig) CheckNamespace(namespace string) bool {
 	return slices.Contains(mcd.namespaces.IncludeNamespaces, namespace)
 }
 
-func (mcd *metricsConfig) Load(cm *corev1.ConfigMap) {
-	if cm != nil {
-		mcd.load(cm)
-	} else {
+func (mcd *metricsConfig) Load(cm *corev1.ConfigMap) error {
+	if cm == nil {
 		mcd.unload()
+		return nil
 	}
+	return mcd.load(cm)
 }
 
-func (cd *metricsConfig) load(cm *corev1.ConfigMap) {
+func (cd *metricsConfig) load(cm *corev1.ConfigMap) error {
 	logger := logger.WithValues("name", cm.Name, "namespace", cm.Namespace)
-	cd.mux.Lock()
-	defer cd.mux.Unlock()
-	defer cd.notify()
 	data := cm.Data
 	if data == nil {
 		data = map[string]string{}
 	}
-	// reset
-	cd.reset()
+	// every key is parsed into fresh settings, they replace the current ones only if the whole configmap is valid
+	s := defaultMetricsSettings()
+	var errs []error
 	// load metricsRefreshInterval
 	metricsRefreshInterval, ok := data["metricsRefreshInterval"]
 	if !ok {
@@ -155,9 +160,9 @@ func (cd *metricsConfig) load(cm *corev1.ConfigMap) {
 		logger := logger.WithValues("metricsRefreshInterval", metricsRefreshInterval)
 		metricsRefreshInterval, err := time.ParseDuration(metricsRefreshInterval)
 		if err != nil {
-			logger.Error(err, "failed to parse metricsRefreshInterval")
+			errs = append(errs, fmt.Errorf("invalid metricsRefreshInterval: %w", err))
 		} else {
-			cd.metricsRefreshInterval = metricsRefreshInterval
+			s.metricsRefreshInterval = metricsRefreshInterval
 			logger.Info("metricsRefreshInterval configured")
 		}
 	}
@@ -169,9 +174,9 @@ func (cd *metricsConfig) load(cm *corev1.ConfigMap) {
 		logger := logger.WithValues("namespaces", namespaces)
 		namespaces, err := parseIncludeExcludeNamespacesFromNamespacesConfig(namespaces)
 		if err != nil {
-			logger.Error(err, "failed to parse namespaces")
+			errs = append(errs, fmt.Errorf("invalid namespaces: %w", err))
 		} else {
-			cd.namespaces = namespaces
+			s.namespaces = namespaces
 			logger.Info("namespaces configured")
 		}
 	}
@@ -183,9 +188,9 @@ func (cd *metricsConfig) load(cm *corev1.ConfigMap) {
 		logger := logger.WithValues("bucketBoundaries", bucketBoundariesString)
 		bucketBoundaries, err := parseBucketBoundariesConfig(bucketBoundariesString)
 		if err != nil {
-			logger.Error(err, "failed to parse bucketBoundariesString")
+			errs = append(errs, fmt.Errorf("invalid bucketBoundaries: %w", err))
 		} else {
-			cd.bucketBoundaries = bucketBoundaries
+			s.bucketBoundaries = bucketBoundaries
 			logger.Info("bucketBoundaries configured")
 		}
 	}
@@ -195,14 +200,24 @@ func (cd *metricsConfig) load(cm *corev1.ConfigMap) {
 		logger.Info("metricsExposure not set")
 	} else {
 		logger := logger.WithValues("metricsExposure", metricsExposureString)
-		metricsExposure, err := parseMetricExposureConfig(metricsExposureString, cd.bucketBoundaries)
+		metricsExposure, err := parseMetricExposureConfig(metricsExposureString, s.bucketBoundaries)
 		if err != nil {
-			logger.Error(err, "failed to parse metricsExposure")
+			errs = append(errs, fmt.Errorf("invalid metricsExposure: %w", err))
 		} else {
-			cd.metricsExposure = metricsExposure
+			s.metricsExposure = metricsExposure
 			logger.Info("metricsExposure configured")
 		}
 	}
+	if len(errs) != 0 {
+		err := multierr.Combine(errs...)
+		logger.Error(err, "metrics configuration rejected, keeping the current configuration")
+		return err
+	}
+	cd.mux.Lock()
+	defer cd.mux.Unlock()
+	defer cd.notify()
+	cd.metricsSettings = s
+	return nil
 }
 
 func (mcd *metricsConfig) unload() {
@@ -213,29 +228,34 @@ func (mcd *metricsConfig) unload() {
 }
 
 func (mcd *metricsConfig) reset() {
-	mcd.metricsRefreshInterval = 0
-	mcd.namespaces = namespacesConfig{
-		IncludeNamespaces: []string{},
-		ExcludeNamespaces: []string{},
-	}
-	mcd.bucketBoundaries = []float64{
-		0.005,
-		0.01,
-		0.025,
-		0.05,
-		0.1,
-		0.25,
-		0.5,
-		1,
-		2.5,
-		5,
-		10,
-		15,
-		20,
-		25,
-		30,
+	mcd.metricsSettings = defaultMetricsSettings()
+}
+
+func defaultMetricsSettings() metricsSettings {
+	return metricsSettings{
+		namespaces: namespacesConfig{
+			IncludeNamespaces: []string{},
+			ExcludeNamespaces: []string{},
+		},
+		bucketBoundaries: []float64{
+			0.005,
+			0.01,
+			0.025,
+			0.05,
+			0.1,
+			0.25,
+			0.5,
+			1,
+			2.5,
+			5,
+			10,
+			15,
+			20,
+			25,
+			30,
+		},
+		metricsExposure: map[string]metricExposureConfig{},
 	}
-	mcd.metricsExposure = map[string]metricExposureConfig{}
 }
 
 func (mcd *metricsConfig) notify() {
@@ -18,14 +18,14 @@ func Test_metricsConfig_load(t *testing.T) {
 	tests := []struct {
 		name          string
 		configMap     *corev1.ConfigMap
-		expectedValue *metricsConfig
+		expectedValue *metricsSettings
 	}{
 		{
 			name: "Case 1: Test defaults",
 			configMap: &corev1.ConfigMap{
 				Data: map[string]string{},
 			},
-			expectedValue: &metricsConfig{
+			expectedValue: &metricsSettings{
 				metricsRefreshInterval: 0,
 				namespaces:             namespacesConfig{IncludeNamespaces: []string{}, ExcludeNamespaces: []string{}},
 				bucketBoundaries:       []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 15, 20, 25, 30},
@@ -42,7 +42,7 @@ func Test_metricsConfig_load(t *testing.T) {
 					"metricsExposure":        `{"metric1": {"enabled": true, "disabledLabelDimensions": ["dim1"]}, "metric2": {"enabled": true, "disabledLabelDimensions": ["dim1","dim2"], "bucketBoundaries": [0.025, 0.05]}}`,
 				},
 			},
-			expectedValue: &metricsConfig{
+			expectedValue: &metricsSettings{
 				metricsRefreshInterval: 10 * time.Second,
 				namespaces:             namespacesConfig{IncludeNamespaces: []string{"namespace1"}, ExcludeNamespaces: []string{"namespace2"}},
 				bucketBoundaries:       []float64{0.005, 0.01, 0.025, 0.05},
@@ -60,7 +60,7 @@ func Test_metricsConfig_load(t *testing.T) {
 					"metricsExposure": `{"metric1": {"enabled": true, "disabledLabelDimensions": ["dim1"]}, "metric2": {"enabled": true, "disabledLabelDimensions": ["dim1","dim2"], "bucketBoundaries": [0.025, 0.05]}}`,
 				},
 			},
-			expectedValue: &metricsConfig{
+			expectedValue: &metricsSettings{
 				metricsRefreshInterval: 0,
 				namespaces:             namespacesConfig{IncludeNamespaces: []string{"namespace1"}, ExcludeNamespaces: []string{"namespace2"}},
 				bucketBoundaries:       []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 15, 20, 25, 30},
@@ -182,3 +182,22 @@ func Test_metricsConfig_GetBucketBoundaries(t *testing.T) {
 		})
 	}
 }
+
+func Test_metricsConfig_load_rejected(t *testing.T) {
+	mcd := NewDefaultMetricsConfiguration()
+	if err := mcd.Load(&corev1.ConfigMap{Data: map[string]string{"metricsRefreshInterval": "10s"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	notified := false
+	mcd.OnChanged(func() { notified = true })
+	err := mcd.Load(&corev1.ConfigMap{Data: map[string]string{"metricsRefreshInterval": "20s", "bucketBoundaries": "0.1, foo"}})
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if notified {
+		t.Errorf("Expected callbacks not to be invoked")
+	}
+	if got := mcd.GetMetricsRefreshInterval(); got != 10*time.Second {
+		t.Errorf("Expected previous refresh interval to be kept, but got %v", got)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 type WebhookConfig struct {
@@ -63,6 +64,22 @@ func parseWebhookLabels(in string) (map[string]string, error) {
 	return out, nil
 }
 
+// ParseNamespaces parses a comma separated list of namespace names
+func ParseNamespaces(in string) ([]string, error) {
+	var namespaces []string
+	for _, namespace := range strings.Split(in, ",") {
+		namespace := strings.TrimSpace(namespace)
+		if namespace == "" {
+			continue
+		}
+		if errs := validation.IsDNS1123Label(namespace); len(errs) != 0 {
+			return nil, fmt.Errorf("%s is not a valid namespace name: %s", namespace, strings.Join(errs, ", "))
+		}
+		namespaces = append(namespaces, namespace)
+	}
+	return namespaces, nil
+}
+
 func parseMatchConditions(in string) ([]admissionregistrationv1.MatchCondition, error) {
 	var out []admissionregistrationv1.MatchCondition
 	if err := json.Unmarshal([]byte(in), &out); err != nil {
@@ -165,6 +182,16 @@ func parseKinds(in string) []filter {
 	return resources
 }
 
+// validateKinds checks that every filter has at most the kind, namespace and name elements
+func validateKinds(in string) error {
+	for _, element := range submatchallRegex.FindAllString(in, -1) {
+		if elements := strings.Split(strings.Trim(element, "[]"), ","); len(elements) > 3 {
+			return fmt.Errorf("filter %s has more than 3 elements", element)
+		}
+	}
+	return nil
+}
+
 func parseBucketBoundariesConfig(boundariesString string) ([]float64, error) {
 	var boundaries []float64
 	boundariesString = strings.TrimSpace(boundariesString)
@@ -295,6 +295,38 @@ func Test_parseWebhookLabels(t *testing.T) {
 	}
 }
 
+func Test_ParseNamespaces(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []string
+		wantErr bool
+	}{{
+		name: "empty",
+		in:   "",
+	}, {
+		name: "list",
+		in:   "kube-system, kube-node-lease,,",
+		want: []string{"kube-system", "kube-node-lease"},
+	}, {
+		name:    "invalid name",
+		in:      "kube-system,Kube_Public",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseNamespaces(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseNamespaces() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseNamespaces() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_parseBucketBoundariesConfig(t *testing.T) {
 	var emptyBoundaries []float64
 
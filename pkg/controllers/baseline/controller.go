@@ -0,0 +1,292 @@
+package baseline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"go.uber.org/multierr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "baseline-controller"
+	// ValuesKey is the ConfigMap key holding the recorded values
+	ValuesKey = "values"
+)
+
+// baselineKey identifies the values recorded in a baseline ConfigMap by the policies of a namespace,
+// the policy namespace is empty for cluster policies
+type baselineKey struct {
+	policyNamespace string
+	configMap       kyvernov1.ConfigMapReference
+}
+
+type controller struct {
+	// clients
+	client          dclient.Interface
+	configMapClient corev1client.ConfigMapsGetter
+
+	// listers
+	polLister  kyvernov1listers.PolicyLister
+	cpolLister kyvernov1listers.ClusterPolicyLister
+	nsLister   corev1listers.NamespaceLister
+
+	// config
+	engine   engineapi.Engine
+	config   config.Configuration
+	jp       jmespath.Interface
+	interval time.Duration
+}
+
+// NewController returns a controller recording the baselines of validate rules in Record mode.
+// Every interval the resources matched by those rules are evaluated and the observed values
+// are added to the baseline ConfigMaps, recorded values are never removed.
+func NewController(
+	client dclient.Interface,
+	configMapClient corev1client.ConfigMapsGetter,
+	polInformer kyvernov1informers.PolicyInformer,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	nsInformer corev1informers.NamespaceInformer,
+	engine engineapi.Engine,
+	config config.Configuration,
+	jp jmespath.Interface,
+	interval time.Duration,
+) controllers.Controller {
+	return &controller{
+		client:          client,
+		configMapClient: configMapClient,
+		polLister:       polInformer.Lister(),
+		cpolLister:      cpolInformer.Lister(),
+		nsLister:        nsInformer.Lister(),
+		engine:          engine,
+		config:          config,
+		jp:              jp,
+		interval:        interval,
+	}
+}
+
+func (c *controller) Run(ctx context.Context, _ int) {
+	logger.Info("starting ...")
+	defer logger.Info("stopped")
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := c.record(ctx); err != nil {
+			logger.Error(err, "failed to record baselines")
+		}
+	}, c.interval)
+}
+
+func (c *controller) record(ctx context.Context) error {
+	policies, err := c.fetchPolicies()
+	if err != nil {
+		return err
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+	observed := map[baselineKey]sets.Set[string]{}
+	var errs []error
+	for _, kind := range sets.List(kinds(policies)) {
+		apiVersion, kind := kubeutils.GetKindFromGVK(kind)
+		list, err := c.client.ListResource(ctx, apiVersion, kind, "", nil)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, resource := range list.Items {
+			if err := c.process(ctx, resource, policies, observed); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	for key, values := range observed {
+		if err := c.storeValues(ctx, key.policyNamespace, key.configMap, values); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update baseline configmap %s/%s: %w", key.configMap.Namespace, key.configMap.Name, err))
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+// process evaluates the resource against the policies and collects the values observed by baseline rules
+func (c *controller) process(ctx context.Context, resource unstructured.Unstructured, policies []kyvernov1.PolicyInterface, observed map[baselineKey]sets.Set[string]) error {
+	var nsLabels map[string]string
+	if namespace := resource.GetNamespace(); namespace != "" {
+		ns, err := c.nsLister.Get(namespace)
+		if err != nil {
+			return err
+		}
+		nsLabels = ns.GetLabels()
+	}
+	var errs []error
+	for _, policy := range policies {
+		if policy.IsNamespaced() && policy.GetNamespace() != resource.GetNamespace() {
+			continue
+		}
+		policyContext, err := engine.NewPolicyContext(c.jp, resource, kyvernov1.Create, nil, c.config)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		policyContext = policyContext.
+			WithNewResource(resource).
+			WithPolicy(policy).
+			WithNamespaceLabels(nsLabels)
+		response := c.engine.Validate(ctx, policyContext)
+		baselines := recordedBaselines(policy)
+		for _, rule := range response.PolicyResponse.Rules {
+			ref, ok := baselines[rule.Name()]
+			if !ok || rule.Status() != engineapi.RuleStatusPass {
+				continue
+			}
+			raw, ok := rule.Properties()[engineapi.BaselineValuesProperty]
+			if !ok {
+				continue
+			}
+			var values []string
+			if err := json.Unmarshal([]byte(raw), &values); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			key := baselineKey{policyNamespace: policy.GetNamespace(), configMap: ref}
+			if observed[key] == nil {
+				observed[key] = sets.New[string]()
+			}
+			observed[key].Insert(values...)
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+// storeValues adds the observed values to the baseline ConfigMap, creating it if needed.
+// Namespaced policies can only record their baselines in their own namespace.
+func (c *controller) storeValues(ctx context.Context, policyNamespace string, ref kyvernov1.ConfigMapReference, values sets.Set[string]) error {
+	if policyNamespace != "" && ref.Namespace != policyNamespace {
+		return fmt.Errorf("policies of namespace %s can not record baselines in namespace %s", policyNamespace, ref.Namespace)
+	}
+	client := c.configMapClient.ConfigMaps(ref.Namespace)
+	configMap, err := client.Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		data, err := json.Marshal(sets.List(values))
+		if err != nil {
+			return err
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ref.Name,
+				Namespace: ref.Namespace,
+				Labels: map[string]string{
+					kyverno.LabelAppManagedBy: kyverno.ValueKyvernoApp,
+				},
+			},
+			Data: map[string]string{ValuesKey: string(data)},
+		}
+		_, err = client.Create(ctx, configMap, metav1.CreateOptions{})
+		return err
+	}
+	recorded := sets.New[string]()
+	if raw := configMap.Data[ValuesKey]; raw != "" {
+		var existing []string
+		if err := json.Unmarshal([]byte(raw), &existing); err != nil {
+			return fmt.Errorf("invalid recorded values: %w", err)
+		}
+		recorded.Insert(existing...)
+	}
+	if recorded.IsSuperset(values) {
+		return nil
+	}
+	data, err := json.Marshal(sets.List(recorded.Union(values)))
+	if err != nil {
+		return err
+	}
+	configMap = configMap.DeepCopy()
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[ValuesKey] = string(data)
+	_, err = client.Update(ctx, configMap, metav1.UpdateOptions{})
+	return err
+}
+
+// fetchPolicies returns the policies with baseline rules in Record mode
+func (c *controller) fetchPolicies() ([]kyvernov1.PolicyInterface, error) {
+	var policies []kyvernov1.PolicyInterface
+	cpols, err := c.cpolLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, cpol := range cpols {
+		if len(recordedBaselines(cpol)) != 0 {
+			policies = append(policies, cpol)
+		}
+	}
+	pols, err := c.polLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, pol := range pols {
+		if len(recordedBaselines(pol)) != 0 {
+			policies = append(policies, pol)
+		}
+	}
+	return policies, nil
+}
+
+// recordedBaselines returns the baseline ConfigMaps of the policy rules in Record mode, indexed by rule name
+func recordedBaselines(policy kyvernov1.PolicyInterface) map[string]kyvernov1.ConfigMapReference {
+	baselines := map[string]kyvernov1.ConfigMapReference{}
+	for _, rule := range policy.GetSpec().Rules {
+		if rule.HasValidateBaseline() && rule.Validation.Baseline.Mode == kyvernov1.BaselineRecord {
+			baselines[rule.Name] = rule.Validation.Baseline.ConfigMap
+		}
+	}
+	return baselines
+}
+
+// kinds returns the kinds matched by baseline rules in Record mode, wildcards and subresources are ignored
+func kinds(policies []kyvernov1.PolicyInterface) sets.Set[string] {
+	kinds := sets.New[string]()
+	for _, policy := range policies {
+		for _, rule := range policy.GetSpec().Rules {
+			if !rule.HasValidateBaseline() || rule.Validation.Baseline.Mode != kyvernov1.BaselineRecord {
+				continue
+			}
+			for _, kind := range rule.MatchResources.GetKinds() {
+				if strings.ContainsAny(kind, "*?") {
+					continue
+				}
+				if _, k := kubeutils.GetKindFromGVK(kind); strings.Contains(k, "/") {
+					continue
+				}
+				kinds.Insert(kind)
+			}
+		}
+	}
+	return kinds
+}
@@ -0,0 +1,89 @@
+package baseline
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_storeValues(t *testing.T) {
+	ref := kyvernov1.ConfigMapReference{Name: "registries", Namespace: "kyverno"}
+	configMap := func(values string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: ref.Namespace},
+			Data:       map[string]string{ValuesKey: values},
+		}
+	}
+	tests := []struct {
+		name            string
+		policyNamespace string
+		existing        []runtime.Object
+		values          []string
+		want            string
+		wantErr         bool
+	}{{
+		name:   "create",
+		values: []string{"nginx", "busybox"},
+		want:   `["busybox","nginx"]`,
+	}, {
+		name:     "merge",
+		existing: []runtime.Object{configMap(`["alpine","nginx"]`)},
+		values:   []string{"nginx", "busybox"},
+		want:     `["alpine","busybox","nginx"]`,
+	}, {
+		name:     "unchanged",
+		existing: []runtime.Object{configMap(`["alpine","nginx"]`)},
+		values:   []string{"nginx"},
+		want:     `["alpine","nginx"]`,
+	}, {
+		name:     "invalid values",
+		existing: []runtime.Object{configMap(`alpine`)},
+		values:   []string{"nginx"},
+		want:     `alpine`,
+		wantErr:  true,
+	}, {
+		name:            "same namespace",
+		policyNamespace: "kyverno",
+		existing:        []runtime.Object{configMap(`["alpine"]`)},
+		values:          []string{"nginx"},
+		want:            `["alpine","nginx"]`,
+	}, {
+		name:            "other namespace",
+		policyNamespace: "team",
+		existing:        []runtime.Object{configMap(`["alpine"]`)},
+		values:          []string{"nginx"},
+		want:            `["alpine"]`,
+		wantErr:         true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := kubefake.NewSimpleClientset(tt.existing...)
+			c := &controller{configMapClient: client.CoreV1()}
+			err := c.storeValues(context.TODO(), tt.policyNamespace, ref, sets.New(tt.values...))
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			configMap, err := client.CoreV1().ConfigMaps(ref.Namespace).Get(context.TODO(), ref.Name, metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, configMap.Data[ValuesKey])
+		})
+	}
+}
+
+func Test_recordedBaselines(t *testing.T) {
+	raw := []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"images"},"spec":{"rules":[
+		{"name":"record","match":{"any":[{"resources":{"kinds":["Pod","apps/v1/Deployment","*"]}}]},"validate":{"baseline":{"mode":"Record","jmesPath":"spec.containers[].image","configMap":{"name":"pods","namespace":"kyverno"}}}},
+		{"name":"enforce","match":{"any":[{"resources":{"kinds":["Service"]}}]},"validate":{"baseline":{"mode":"Enforce","jmesPath":"spec.type","configMap":{"name":"services","namespace":"kyverno"}}}},
+		{"name":"pattern","match":{"any":[{"resources":{"kinds":["ConfigMap"]}}]},"validate":{"pattern":{"data":{}}}}
+	]}}`)
+	var policy kyvernov1.ClusterPolicy
+	assert.NoError(t, json.Unmarshal(raw, &policy))
+	assert.Equal(t, map[string]kyvernov1.ConfigMapReference{"record": {Name: "pods", Namespace: "kyverno"}}, recordedBaselines(&policy))
+	assert.Equal(t, sets.New("Pod", "apps/v1/Deployment"), kinds([]kyvernov1.PolicyInterface{&policy}))
+}
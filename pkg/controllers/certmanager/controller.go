@@ -6,10 +6,15 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/tls"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	retryutils "github.com/kyverno/kyverno/pkg/utils/retry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	corev1informers "k8s.io/client-go/informers/core/v1"
@@ -39,6 +44,9 @@ type controller struct {
 	caSecretName  string
 	tlsSecretName string
 	namespace     string
+
+	// metrics
+	expiryMetric metric.Float64ObservableGauge
 }
 
 func NewController(
@@ -63,6 +71,19 @@ func NewController(
 		tlsSecretName: tlsSecretName,
 		namespace:     namespace,
 	}
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	expiryMetric, err := meter.Float64ObservableGauge(
+		"kyverno_certificate_expiry_timestamp_seconds",
+		metric.WithDescription("can be used to track the expiry time (unix timestamp) of the certificates managed by kyverno"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_certificate_expiry_timestamp_seconds")
+	} else {
+		c.expiryMetric = expiryMetric
+		if _, err := meter.RegisterCallback(c.report, expiryMetric); err != nil {
+			logger.Error(err, "failed to register callback")
+		}
+	}
 	return &c
 }
 
@@ -143,3 +164,33 @@ func (c *controller) renewCertificates(ctx context.Context) error {
 	}
 	return nil
 }
+
+func (c *controller) report(ctx context.Context, observer metric.Observer) error {
+	c.reportSecret(observer, c.caLister, c.caSecretName, "ca")
+	c.reportSecret(observer, c.tlsLister, c.tlsSecretName, "tls")
+	return nil
+}
+
+func (c *controller) reportSecret(observer metric.Observer, lister corev1listers.SecretLister, name, certificateType string) {
+	secret, err := lister.Secrets(c.namespace).Get(name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "failed to get secret", "name", name)
+		}
+		return
+	}
+	expiry, err := tls.ReadCertificateExpiry(secret)
+	if err != nil {
+		logger.Error(err, "failed to read certificate expiry", "name", name)
+		return
+	}
+	observer.ObserveFloat64(
+		c.expiryMetric,
+		float64(expiry.Unix()),
+		metric.WithAttributes(
+			attribute.String("secret_namespace", c.namespace),
+			attribute.String("secret_name", name),
+			attribute.String("certificate_type", certificateType),
+		),
+	)
+}
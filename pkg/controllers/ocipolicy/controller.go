@@ -0,0 +1,241 @@
+package ocipolicy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/oci"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "oci-policy-controller"
+	// SyncInterval is the default interval at which the policy artifacts are pulled again
+	SyncInterval = 5 * time.Minute
+)
+
+// Source is an OCI artifact policies are loaded from
+type Source struct {
+	// Reference is the image reference of the artifact, with or without the oci:// scheme
+	Reference string
+	// Key is the public key (file path, KMS or k8s:// reference) used to verify the cosign signature of the
+	// artifact, the signature is not verified when empty
+	Key string
+}
+
+// PullFunc returns the policy layers of a source
+type PullFunc func(context.Context, Source) ([][]byte, error)
+
+// NewPullFunc returns a PullFunc verifying and pulling artifacts with the given registry client.
+// When a source has a key, the layers are pulled from the verified digest.
+func NewPullFunc(rclient registryclient.Client) PullFunc {
+	return func(ctx context.Context, source Source) ([][]byte, error) {
+		ref := source.Reference
+		if source.Key != "" {
+			verified, err := oci.Verify(ctx, rclient, ref, source.Key)
+			if err != nil {
+				return nil, err
+			}
+			ref = verified
+		}
+		options, err := rclient.Options(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return oci.PullLayers(ctx, ref, oci.PolicyLayerMediaType, options...)
+	}
+}
+
+type controller struct {
+	// clients
+	kyvernoClient versioned.Interface
+
+	// config
+	sources  []Source
+	pull     PullFunc
+	interval time.Duration
+}
+
+// NewController returns a controller keeping the policies of the cluster in sync with the given OCI artifacts.
+// Policies are labelled with their source, a policy existing with the same name but another source is not updated.
+// Policies removed from an artifact are deleted from the cluster.
+func NewController(
+	kyvernoClient versioned.Interface,
+	sources []Source,
+	pull PullFunc,
+	interval time.Duration,
+) controllers.Controller {
+	return &controller{
+		kyvernoClient: kyvernoClient,
+		sources:       sources,
+		pull:          pull,
+		interval:      interval,
+	}
+}
+
+func (c *controller) Run(ctx context.Context, _ int) {
+	logger.Info("starting ...")
+	defer logger.Info("stopped")
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		for _, source := range c.sources {
+			if err := c.sync(ctx, source); err != nil {
+				logger.Error(err, "failed to sync policies", "source", source.Reference)
+			}
+		}
+	}, c.interval)
+}
+
+// sourceName returns the value of the source label, image references are too long to be used as label values
+func sourceName(source Source) string {
+	hash := sha256.Sum256([]byte(oci.Reference(source.Reference)))
+	return hex.EncodeToString(hash[:])[:16]
+}
+
+func (c *controller) sync(ctx context.Context, source Source) error {
+	layers, err := c.pull(ctx, source)
+	if err != nil {
+		return err
+	}
+	name := sourceName(source)
+	var policies []kyvernov1.PolicyInterface
+	for _, layer := range layers {
+		loaded, _, err := yamlutils.GetPolicy(layer)
+		if err != nil {
+			return fmt.Errorf("failed to load policies from %s (%w)", source.Reference, err)
+		}
+		policies = append(policies, loaded...)
+	}
+	cpols := sets.New[string]()
+	pols := sets.New[string]()
+	var errs error
+	for _, policy := range policies {
+		labels := map[string]string{}
+		for key, value := range policy.GetLabels() {
+			labels[key] = value
+		}
+		labels[kyverno.LabelOCISource] = name
+		annotations := map[string]string{}
+		for key, value := range policy.GetAnnotations() {
+			annotations[key] = value
+		}
+		annotations[kyverno.AnnotationOCISource] = source.Reference
+		meta := metav1.ObjectMeta{
+			Name:        policy.GetName(),
+			Namespace:   policy.GetNamespace(),
+			Labels:      labels,
+			Annotations: annotations,
+		}
+		if policy.IsNamespaced() {
+			if policy.GetNamespace() == "" {
+				errs = multierr.Append(errs, fmt.Errorf("policy %s from %s has no namespace", policy.GetName(), source.Reference))
+				continue
+			}
+			pols.Insert(policy.GetNamespace() + "/" + policy.GetName())
+			errs = multierr.Append(errs, c.applyPolicy(ctx, name, &kyvernov1.Policy{ObjectMeta: meta, Spec: *policy.GetSpec().DeepCopy()}))
+		} else {
+			cpols.Insert(policy.GetName())
+			errs = multierr.Append(errs, c.applyClusterPolicy(ctx, name, &kyvernov1.ClusterPolicy{ObjectMeta: meta, Spec: *policy.GetSpec().DeepCopy()}))
+		}
+	}
+	errs = multierr.Append(errs, c.prune(ctx, name, cpols, pols))
+	return errs
+}
+
+func (c *controller) applyClusterPolicy(ctx context.Context, source string, desired *kyvernov1.ClusterPolicy) error {
+	client := c.kyvernoClient.KyvernoV1().ClusterPolicies()
+	observed, err := client.Get(ctx, desired.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		_, err := client.Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+	if owner := observed.Labels[kyverno.LabelOCISource]; owner != source {
+		return fmt.Errorf("cluster policy %s already exists and is not managed by the oci source", desired.Name)
+	}
+	if datautils.DeepEqual(observed.Spec, desired.Spec) &&
+		datautils.DeepEqual(observed.Labels, desired.Labels) &&
+		datautils.DeepEqual(observed.Annotations, desired.Annotations) {
+		return nil
+	}
+	updated := observed.DeepCopy()
+	updated.Labels = desired.Labels
+	updated.Annotations = desired.Annotations
+	updated.Spec = desired.Spec
+	_, err = client.Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+func (c *controller) applyPolicy(ctx context.Context, source string, desired *kyvernov1.Policy) error {
+	client := c.kyvernoClient.KyvernoV1().Policies(desired.Namespace)
+	observed, err := client.Get(ctx, desired.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		_, err := client.Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+	if owner := observed.Labels[kyverno.LabelOCISource]; owner != source {
+		return fmt.Errorf("policy %s/%s already exists and is not managed by the oci source", desired.Namespace, desired.Name)
+	}
+	if datautils.DeepEqual(observed.Spec, desired.Spec) &&
+		datautils.DeepEqual(observed.Labels, desired.Labels) &&
+		datautils.DeepEqual(observed.Annotations, desired.Annotations) {
+		return nil
+	}
+	updated := observed.DeepCopy()
+	updated.Labels = desired.Labels
+	updated.Annotations = desired.Annotations
+	updated.Spec = desired.Spec
+	_, err = client.Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+// prune deletes the policies loaded from the source that are not part of the artifact anymore
+func (c *controller) prune(ctx context.Context, source string, cpols sets.Set[string], pols sets.Set[string]) error {
+	selector := labels.SelectorFromSet(labels.Set{kyverno.LabelOCISource: source}).String()
+	cpolList, err := c.kyvernoClient.KyvernoV1().ClusterPolicies().List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	for _, policy := range cpolList.Items {
+		if cpols.Has(policy.Name) {
+			continue
+		}
+		if err := c.kyvernoClient.KyvernoV1().ClusterPolicies().Delete(ctx, policy.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	polList, err := c.kyvernoClient.KyvernoV1().Policies(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	for _, policy := range polList.Items {
+		if pols.Has(policy.Namespace + "/" + policy.Name) {
+			continue
+		}
+		if err := c.kyvernoClient.KyvernoV1().Policies(policy.Namespace).Delete(ctx, policy.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
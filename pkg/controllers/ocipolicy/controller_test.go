@@ -0,0 +1,119 @@
+package ocipolicy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const layer = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  rules:
+  - name: rule
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      pattern:
+        metadata:
+          labels:
+            app: ?*
+---
+apiVersion: kyverno.io/v1
+kind: Policy
+metadata:
+  name: require-team
+  namespace: dev
+spec:
+  rules:
+  - name: rule
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      pattern:
+        metadata:
+          labels:
+            team: ?*
+`
+
+func Test_controller_sync(t *testing.T) {
+	ctx := context.Background()
+	source := Source{Reference: "oci://ghcr.io/org/policies:v1"}
+	name := sourceName(source)
+	kyvernoClient := fake.NewSimpleClientset(
+		&kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "stale", Labels: map[string]string{kyverno.LabelOCISource: name}}},
+		&kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "unrelated"}},
+		&kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "stale", Labels: map[string]string{kyverno.LabelOCISource: name}}},
+	)
+	pull := func(_ context.Context, s Source) ([][]byte, error) {
+		assert.Equal(t, source, s)
+		return [][]byte{[]byte(layer)}, nil
+	}
+	c := NewController(kyvernoClient, []Source{source}, pull, SyncInterval).(*controller)
+	assert.NoError(t, c.sync(ctx, source))
+	cpol, err := kyvernoClient.KyvernoV1().ClusterPolicies().Get(ctx, "require-labels", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, name, cpol.Labels[kyverno.LabelOCISource])
+	assert.Equal(t, source.Reference, cpol.Annotations[kyverno.AnnotationOCISource])
+	assert.Len(t, cpol.Spec.Rules, 1)
+	pol, err := kyvernoClient.KyvernoV1().Policies("dev").Get(ctx, "require-team", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, name, pol.Labels[kyverno.LabelOCISource])
+	// policies removed from the artifact are deleted, other policies are left untouched
+	_, err = kyvernoClient.KyvernoV1().ClusterPolicies().Get(ctx, "stale", metav1.GetOptions{})
+	assert.Error(t, err)
+	_, err = kyvernoClient.KyvernoV1().Policies("prod").Get(ctx, "stale", metav1.GetOptions{})
+	assert.Error(t, err)
+	_, err = kyvernoClient.KyvernoV1().ClusterPolicies().Get(ctx, "unrelated", metav1.GetOptions{})
+	assert.NoError(t, err)
+}
+
+func Test_controller_syncConflict(t *testing.T) {
+	ctx := context.Background()
+	source := Source{Reference: "ghcr.io/org/policies:v1"}
+	kyvernoClient := fake.NewSimpleClientset(
+		&kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "require-labels"}},
+	)
+	pull := func(context.Context, Source) ([][]byte, error) {
+		return [][]byte{[]byte(layer)}, nil
+	}
+	c := NewController(kyvernoClient, []Source{source}, pull, SyncInterval).(*controller)
+	assert.Error(t, c.sync(ctx, source))
+	cpol, err := kyvernoClient.KyvernoV1().ClusterPolicies().Get(ctx, "require-labels", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, cpol.Labels)
+	// the other policies of the artifact are still applied
+	_, err = kyvernoClient.KyvernoV1().Policies("dev").Get(ctx, "require-team", metav1.GetOptions{})
+	assert.NoError(t, err)
+}
+
+func Test_controller_syncPullError(t *testing.T) {
+	ctx := context.Background()
+	source := Source{Reference: "ghcr.io/org/policies:v1", Key: "cosign.pub"}
+	kyvernoClient := fake.NewSimpleClientset(
+		&kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "require-labels", Labels: map[string]string{kyverno.LabelOCISource: sourceName(source)}}},
+	)
+	pull := func(context.Context, Source) ([][]byte, error) {
+		return nil, errors.New("signature mismatch")
+	}
+	c := NewController(kyvernoClient, []Source{source}, pull, SyncInterval).(*controller)
+	assert.Error(t, c.sync(ctx, source))
+	// nothing is pruned when the artifact can't be pulled
+	_, err := kyvernoClient.KyvernoV1().ClusterPolicies().Get(ctx, "require-labels", metav1.GetOptions{})
+	assert.NoError(t, err)
+}
@@ -2,6 +2,7 @@ package policycache
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -15,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 )
@@ -24,6 +26,8 @@ const (
 	Workers        = 3
 	ControllerName = "policycache-controller"
 	maxRetries     = 10
+	// pendingResyncInterval is the interval at which policies matching kinds unknown to discovery are reconciled again
+	pendingResyncInterval = 10 * time.Second
 )
 
 type Controller interface {
@@ -43,6 +47,10 @@ type controller struct {
 
 	// client
 	client dclient.Interface
+
+	// state
+	lock    sync.Mutex
+	pending sets.Set[string]
 }
 
 func NewController(client dclient.Interface, pcache pcache.Cache, cpolInformer kyvernov1informers.ClusterPolicyInformer, polInformer kyvernov1informers.PolicyInformer) Controller {
@@ -52,6 +60,7 @@ func NewController(client dclient.Interface, pcache pcache.Cache, cpolInformer k
 		polLister:  polInformer.Lister(),
 		queue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
 		client:     client,
+		pending:    sets.New[string](),
 	}
 	if _, _, err := controllerutils.AddDefaultEventHandlers(logger, cpolInformer.Informer(), c.queue); err != nil {
 		logger.Error(err, "failed to register event handlers")
@@ -71,10 +80,14 @@ func (c *controller) WarmUp() error {
 		return err
 	}
 	for _, policy := range pols {
+		if !policy.AdmissionProcessingEnabled() {
+			continue
+		}
 		if key, err := cache.MetaNamespaceKeyFunc(policy); err != nil {
 			return err
-		} else {
-			return c.cache.Set(key, policy, c.client.Discovery())
+		} else if err := c.setPolicy(key, policy); err != nil {
+			// the policy will be reconciled again by the controller
+			logger.Error(err, "failed to warm up policy", "key", key)
 		}
 	}
 	cpols, err := c.cpolLister.List(labels.Everything())
@@ -82,31 +95,80 @@ func (c *controller) WarmUp() error {
 		return err
 	}
 	for _, policy := range cpols {
+		if !policy.AdmissionProcessingEnabled() {
+			continue
+		}
 		if key, err := cache.MetaNamespaceKeyFunc(policy); err != nil {
 			return err
-		} else {
-			return c.cache.Set(key, policy, c.client.Discovery())
+		} else if err := c.setPolicy(key, policy); err != nil {
+			// the policy will be reconciled again by the controller
+			logger.Error(err, "failed to warm up policy", "key", key)
 		}
 	}
 	return nil
 }
 
 func (c *controller) Run(ctx context.Context, workers int) {
-	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.resyncPending)
+}
+
+// resyncPending periodically reconciles the policies matching kinds unknown to discovery so that their rules
+// are activated as soon as the CRDs of these kinds are installed
+func (c *controller) resyncPending(ctx context.Context, logger logr.Logger) {
+	ticker := time.NewTicker(pendingResyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.lock.Lock()
+			keys := sets.List(c.pending)
+			c.lock.Unlock()
+			for _, key := range keys {
+				c.queue.Add(key)
+			}
+		}
+	}
+}
+
+// setPolicy adds the policy to the cache, kinds unknown to discovery are not considered an error
+// but the policy is marked as pending until they become available
+func (c *controller) setPolicy(key string, policy kyvernov1.PolicyInterface) error {
+	err := c.cache.Set(key, policy, c.client.Discovery())
+	pending := dclient.IsResourceNotFound(err)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if pending {
+		if !c.pending.Has(key) {
+			logger.Info("policy matches kinds that are not installed yet, rules are pending", "key", key)
+		}
+		c.pending.Insert(key)
+		return nil
+	}
+	c.pending.Delete(key)
+	return err
+}
+
+func (c *controller) unsetPolicy(key string) {
+	c.cache.Unset(key)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.pending.Delete(key)
 }
 
 func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
 	policy, err := c.loadPolicy(namespace, name)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			c.cache.Unset(key)
+			c.unsetPolicy(key)
 		}
 		return err
 	}
 	if policy.AdmissionProcessingEnabled() {
-		return c.cache.Set(key, policy, c.client.Discovery())
+		return c.setPolicy(key, policy)
 	} else {
-		c.cache.Unset(key)
+		c.unsetPolicy(key)
 		return nil
 	}
 }
@@ -0,0 +1,422 @@
+package policyset
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	"go.uber.org/multierr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "policyset-controller"
+	maxRetries     = 10
+	// KubeconfigKey is the member cluster secret data key holding the kubeconfig
+	KubeconfigKey = "kubeconfig"
+	// syncInterval is the interval at which compliance summaries are collected again
+	syncInterval = 5 * time.Minute
+)
+
+// ClientFactory creates a kyverno client for a member cluster from its kubeconfig.
+type ClientFactory func(kubeconfig []byte) (versioned.Interface, error)
+
+// NewClientFactory returns a ClientFactory creating clients from kubeconfig bytes.
+func NewClientFactory() ClientFactory {
+	return func(kubeconfig []byte) (versioned.Interface, error) {
+		config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+		if err != nil {
+			return nil, err
+		}
+		return versioned.NewForConfig(config)
+	}
+}
+
+type controller struct {
+	// clients
+	kyvernoClient versioned.Interface
+	clientFactory ClientFactory
+
+	// listers
+	polsetLister kyvernov2alpha1listers.PolicySetLister
+	cpolLister   kyvernov1listers.ClusterPolicyLister
+	secretLister corev1listers.SecretNamespaceLister
+
+	// queue
+	queue   workqueue.RateLimitingInterface
+	enqueue controllerutils.EnqueueFunc
+}
+
+func NewController(
+	kyvernoClient versioned.Interface,
+	polsetInformer kyvernov2alpha1informers.PolicySetInformer,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	secretInformer corev1informers.SecretInformer,
+	namespace string,
+	clientFactory ClientFactory,
+) controllers.Controller {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	enqueue, _, _ := controllerutils.AddDefaultEventHandlers(logger, polsetInformer.Informer(), queue)
+	c := &controller{
+		kyvernoClient: kyvernoClient,
+		clientFactory: clientFactory,
+		polsetLister:  polsetInformer.Lister(),
+		cpolLister:    cpolInformer.Lister(),
+		secretLister:  secretInformer.Lister().Secrets(namespace),
+		queue:         queue,
+		enqueue:       enqueue,
+	}
+	// policies are only enqueued with the policy sets selecting them, before or after the change
+	if _, err := controllerutils.AddEventHandlers(
+		cpolInformer.Informer(),
+		func(obj interface{}) { c.enqueueSelecting(obj) },
+		func(old, obj interface{}) { c.enqueueSelecting(old, obj) },
+		func(obj interface{}) { c.enqueueSelecting(obj) },
+	); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	// member clusters can be selected by any policy set
+	enqueueAll := func(interface{}) error {
+		c.enqueueAll()
+		return nil
+	}
+	if _, err := controllerutils.AddEventHandlers(
+		secretInformer.Informer(),
+		controllerutils.AddFunc(logger, enqueueAll),
+		controllerutils.UpdateFunc(logger, enqueueAll),
+		controllerutils.DeleteFunc(logger, enqueueAll),
+	); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.ticker)
+}
+
+func (c *controller) enqueueAll() {
+	polsets, err := c.polsetLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list policy sets")
+		return
+	}
+	for _, polset := range polsets {
+		if err := c.enqueue(polset); err != nil {
+			logger.Error(err, "failed to enqueue policy set", "name", polset.Name)
+		}
+	}
+}
+
+// enqueueSelecting enqueues the policy sets selecting one of the policies, by name or by label selector
+func (c *controller) enqueueSelecting(objs ...interface{}) {
+	polsets, err := c.polsetLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list policy sets")
+		return
+	}
+	for _, polset := range polsets {
+		for _, obj := range objs {
+			policy, ok := obj.(*kyvernov1.ClusterPolicy)
+			if !ok || !selects(polset, policy) {
+				continue
+			}
+			if err := c.enqueue(polset); err != nil {
+				logger.Error(err, "failed to enqueue policy set", "name", polset.Name)
+			}
+			break
+		}
+	}
+}
+
+func selects(polset *kyvernov2alpha1.PolicySet, policy *kyvernov1.ClusterPolicy) bool {
+	for _, name := range polset.Spec.Policies {
+		if name == policy.Name {
+			return true
+		}
+	}
+	if polset.Spec.PolicySelector == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(polset.Spec.PolicySelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(policy.Labels))
+}
+
+func (c *controller) ticker(ctx context.Context, logger logr.Logger) {
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.enqueueAll()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	clusters, err := c.secretLister.List(labels.SelectorFromSet(labels.Set{kyverno.LabelMemberCluster: "true"}))
+	if err != nil {
+		return err
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Name < clusters[j].Name })
+	polset, err := c.polsetLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// the policy set was deleted, remove its policies from all member clusters
+			var errs error
+			for _, cluster := range clusters {
+				errs = multierr.Append(errs, c.prune(ctx, cluster, name, nil))
+			}
+			return errs
+		}
+		return err
+	}
+	policies, err := c.selectPolicies(polset)
+	if err != nil {
+		return err
+	}
+	clusterSelector := labels.Everything()
+	if polset.Spec.Placement.ClusterSelector != nil {
+		if clusterSelector, err = metav1.LabelSelectorAsSelector(polset.Spec.Placement.ClusterSelector); err != nil {
+			return err
+		}
+	}
+	var statuses []kyvernov2alpha1.ClusterStatus
+	for _, cluster := range clusters {
+		if !clusterSelector.Matches(labels.Set(cluster.GetLabels())) {
+			if err := c.prune(ctx, cluster, polset.Name, nil); err != nil {
+				logger.Error(err, "failed to prune deselected cluster", "cluster", cluster.Name)
+			}
+			continue
+		}
+		status := kyvernov2alpha1.ClusterStatus{Name: cluster.Name}
+		if summary, err := c.sync(ctx, cluster, polset.Name, policies); err != nil {
+			logger.Error(err, "failed to sync cluster", "cluster", cluster.Name)
+			status.Message = err.Error()
+		} else {
+			status.Synced = true
+			status.Summary = summary
+		}
+		statuses = append(statuses, status)
+	}
+	return c.updateStatus(ctx, polset, statuses)
+}
+
+func (c *controller) selectPolicies(polset *kyvernov2alpha1.PolicySet) ([]*kyvernov1.ClusterPolicy, error) {
+	selected := map[string]*kyvernov1.ClusterPolicy{}
+	for _, name := range polset.Spec.Policies {
+		policy, err := c.cpolLister.Get(name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		selected[policy.Name] = policy
+	}
+	if polset.Spec.PolicySelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(polset.Spec.PolicySelector)
+		if err != nil {
+			return nil, err
+		}
+		policies, err := c.cpolLister.List(selector)
+		if err != nil {
+			return nil, err
+		}
+		for _, policy := range policies {
+			selected[policy.Name] = policy
+		}
+	}
+	var policies []*kyvernov1.ClusterPolicy
+	for _, policy := range selected {
+		policies = append(policies, policy)
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+	return policies, nil
+}
+
+func (c *controller) memberClient(cluster *corev1.Secret) (versioned.Interface, error) {
+	kubeconfig, ok := cluster.Data[KubeconfigKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s does not contain the %s key", cluster.Name, KubeconfigKey)
+	}
+	return c.clientFactory(kubeconfig)
+}
+
+func (c *controller) sync(ctx context.Context, cluster *corev1.Secret, polset string, policies []*kyvernov1.ClusterPolicy) (kyvernov2alpha1.ComplianceSummary, error) {
+	var summary kyvernov2alpha1.ComplianceSummary
+	client, err := c.memberClient(cluster)
+	if err != nil {
+		return summary, err
+	}
+	names := sets.New[string]()
+	for _, policy := range policies {
+		names.Insert(policy.Name)
+		if err := c.apply(ctx, client, polset, policy); err != nil {
+			return summary, err
+		}
+	}
+	if err := c.pruneWithClient(ctx, client, polset, names); err != nil {
+		return summary, err
+	}
+	return aggregate(ctx, client, names)
+}
+
+func (c *controller) apply(ctx context.Context, client versioned.Interface, polset string, policy *kyvernov1.ClusterPolicy) error {
+	desired := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        policy.Name,
+			Labels:      map[string]string{},
+			Annotations: policy.Annotations,
+		},
+		Spec: *policy.Spec.DeepCopy(),
+	}
+	for k, v := range policy.Labels {
+		desired.Labels[k] = v
+	}
+	desired.Labels[kyverno.LabelPolicySet] = polset
+	observed, err := client.KyvernoV1().ClusterPolicies().Get(ctx, policy.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		_, err := client.KyvernoV1().ClusterPolicies().Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+	if owner := observed.Labels[kyverno.LabelPolicySet]; owner != polset {
+		return fmt.Errorf("policy %s already exists and is not managed by policy set %s", policy.Name, polset)
+	}
+	if datautils.DeepEqual(observed.Spec, desired.Spec) &&
+		datautils.DeepEqual(observed.Labels, desired.Labels) &&
+		datautils.DeepEqual(observed.Annotations, desired.Annotations) {
+		return nil
+	}
+	updated := observed.DeepCopy()
+	updated.Labels = desired.Labels
+	updated.Annotations = desired.Annotations
+	updated.Spec = desired.Spec
+	_, err = client.KyvernoV1().ClusterPolicies().Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+// prune deletes the policies distributed by the given policy set that are not part of keep.
+func (c *controller) prune(ctx context.Context, cluster *corev1.Secret, polset string, keep sets.Set[string]) error {
+	client, err := c.memberClient(cluster)
+	if err != nil {
+		return err
+	}
+	return c.pruneWithClient(ctx, client, polset, keep)
+}
+
+func (c *controller) pruneWithClient(ctx context.Context, client versioned.Interface, polset string, keep sets.Set[string]) error {
+	list, err := client.KyvernoV1().ClusterPolicies().List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{kyverno.LabelPolicySet: polset}).String(),
+	})
+	if err != nil {
+		return err
+	}
+	for _, policy := range list.Items {
+		if keep.Has(policy.Name) {
+			continue
+		}
+		if err := client.KyvernoV1().ClusterPolicies().Delete(ctx, policy.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// aggregate sums the report results produced by the given policies in a member cluster.
+func aggregate(ctx context.Context, client versioned.Interface, policies sets.Set[string]) (kyvernov2alpha1.ComplianceSummary, error) {
+	var summary kyvernov2alpha1.ComplianceSummary
+	add := func(results []policyreportv1alpha2.PolicyReportResult) {
+		for _, result := range results {
+			if !policies.Has(result.Policy) {
+				continue
+			}
+			switch result.Result {
+			case policyreportv1alpha2.StatusPass:
+				summary.Pass++
+			case policyreportv1alpha2.StatusFail:
+				summary.Fail++
+			case policyreportv1alpha2.StatusWarn:
+				summary.Warn++
+			case policyreportv1alpha2.StatusError:
+				summary.Error++
+			case policyreportv1alpha2.StatusSkip:
+				summary.Skip++
+			}
+		}
+	}
+	cpolrs, err := client.Wgpolicyk8sV1alpha2().ClusterPolicyReports().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return summary, err
+	}
+	for _, report := range cpolrs.Items {
+		add(report.Results)
+	}
+	polrs, err := client.Wgpolicyk8sV1alpha2().PolicyReports(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return summary, err
+	}
+	for _, report := range polrs.Items {
+		add(report.Results)
+	}
+	return summary, nil
+}
+
+func (c *controller) updateStatus(ctx context.Context, polset *kyvernov2alpha1.PolicySet, clusters []kyvernov2alpha1.ClusterStatus) error {
+	updated := polset.DeepCopy()
+	updated.Status.Clusters = clusters
+	condition := metav1.Condition{
+		Type:               kyvernov2alpha1.PolicySetConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Succeeded",
+		Message:            fmt.Sprintf("policies synced to %d cluster(s)", len(clusters)),
+		ObservedGeneration: polset.GetGeneration(),
+	}
+	for _, cluster := range clusters {
+		if !cluster.Synced {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "Failed"
+			condition.Message = fmt.Sprintf("failed to sync policies to cluster %s", cluster.Name)
+			break
+		}
+	}
+	meta.SetStatusCondition(&updated.Status.Conditions, condition)
+	if datautils.DeepEqual(polset.Status, updated.Status) {
+		return nil
+	}
+	_, err := c.kyvernoClient.KyvernoV2alpha1().PolicySets().UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
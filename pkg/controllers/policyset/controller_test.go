@@ -0,0 +1,146 @@
+package policyset
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformers "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func member(name string, labels map[string]string) *corev1.Secret {
+	l := map[string]string{kyverno.LabelMemberCluster: "true"}
+	for k, v := range labels {
+		l[k] = v
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kyverno", Name: name, Labels: l},
+		Data:       map[string][]byte{KubeconfigKey: []byte(name)},
+	}
+}
+
+func clusterPolicy(name string, labels map[string]string) *kyvernov1.ClusterPolicy {
+	return &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec:       kyvernov1.Spec{Rules: []kyvernov1.Rule{{Name: "rule"}}},
+	}
+}
+
+func Test_controller_reconcile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	polset := &kyvernov2alpha1.PolicySet{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+		Spec: kyvernov2alpha1.PolicySetSpec{
+			Policies:       []string{"require-labels"},
+			PolicySelector: &metav1.LabelSelector{MatchLabels: map[string]string{"fleet": "true"}},
+			Placement: kyvernov2alpha1.Placement{
+				ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			},
+		},
+	}
+	kyvernoClient := fake.NewSimpleClientset(
+		polset,
+		clusterPolicy("require-labels", nil),
+		clusterPolicy("disallow-latest", map[string]string{"fleet": "true"}),
+		clusterPolicy("unrelated", nil),
+	)
+	kubeClient := kubefake.NewSimpleClientset(
+		member("prod-1", map[string]string{"env": "prod"}),
+		member("dev-1", map[string]string{"env": "dev"}),
+	)
+	stale := clusterPolicy("stale", map[string]string{kyverno.LabelPolicySet: "baseline"})
+	members := map[string]*fake.Clientset{
+		"prod-1": fake.NewSimpleClientset(&policyreportv1alpha2.ClusterPolicyReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "cpolr"},
+			Results: []policyreportv1alpha2.PolicyReportResult{
+				{Policy: "require-labels", Result: policyreportv1alpha2.StatusPass},
+				{Policy: "disallow-latest", Result: policyreportv1alpha2.StatusFail},
+				{Policy: "unrelated", Result: policyreportv1alpha2.StatusFail},
+			},
+		}, &policyreportv1alpha2.PolicyReport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "polr"},
+			Results: []policyreportv1alpha2.PolicyReportResult{
+				{Policy: "require-labels", Result: policyreportv1alpha2.StatusWarn},
+			},
+		}),
+		"dev-1": fake.NewSimpleClientset(stale),
+	}
+	factory := func(kubeconfig []byte) (versioned.Interface, error) {
+		return members[string(kubeconfig)], nil
+	}
+	kyvernoInformer := kyvernoinformers.NewSharedInformerFactory(kyvernoClient, 0)
+	kubeInformer := kubeinformers.NewSharedInformerFactory(kubeClient, 0)
+	c := NewController(
+		kyvernoClient,
+		kyvernoInformer.Kyverno().V2alpha1().PolicySets(),
+		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+		kubeInformer.Core().V1().Secrets(),
+		"kyverno",
+		factory,
+	).(*controller)
+	kyvernoInformer.Start(ctx.Done())
+	kubeInformer.Start(ctx.Done())
+	kyvernoInformer.WaitForCacheSync(ctx.Done())
+	kubeInformer.WaitForCacheSync(ctx.Done())
+
+	assert.NoError(t, c.reconcile(ctx, logging.GlobalLogger(), "baseline", "", "baseline"))
+
+	// policies are distributed to the selected cluster only
+	prod, err := members["prod-1"].KyvernoV1().ClusterPolicies().List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	var names []string
+	for _, policy := range prod.Items {
+		names = append(names, policy.Name)
+		assert.Equal(t, "baseline", policy.Labels[kyverno.LabelPolicySet])
+	}
+	assert.ElementsMatch(t, []string{"require-labels", "disallow-latest"}, names)
+	// policies are pruned from deselected clusters
+	dev, err := members["dev-1"].KyvernoV1().ClusterPolicies().List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, dev.Items)
+	// compliance is aggregated in the status
+	updated, err := kyvernoClient.KyvernoV2alpha1().PolicySets().Get(ctx, "baseline", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []kyvernov2alpha1.ClusterStatus{{
+		Name:    "prod-1",
+		Synced:  true,
+		Summary: kyvernov2alpha1.ComplianceSummary{Pass: 1, Fail: 1, Warn: 1},
+	}}, updated.Status.Clusters)
+	assert.Len(t, updated.Status.Conditions, 1)
+	assert.Equal(t, metav1.ConditionTrue, updated.Status.Conditions[0].Status)
+}
+
+func Test_controller_apply_conflict(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(clusterPolicy("require-labels", nil))
+	c := &controller{}
+	err := c.apply(ctx, client, "baseline", clusterPolicy("require-labels", nil))
+	assert.Error(t, err)
+}
+
+func Test_selects(t *testing.T) {
+	polset := &kyvernov2alpha1.PolicySet{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+		Spec: kyvernov2alpha1.PolicySetSpec{
+			Policies:       []string{"require-labels"},
+			PolicySelector: &metav1.LabelSelector{MatchLabels: map[string]string{"fleet": "true"}},
+		},
+	}
+	assert.True(t, selects(polset, clusterPolicy("require-labels", nil)))
+	assert.True(t, selects(polset, clusterPolicy("disallow-latest", map[string]string{"fleet": "true"})))
+	assert.False(t, selects(polset, clusterPolicy("unrelated", map[string]string{"fleet": "false"})))
+	polset.Spec.PolicySelector = nil
+	assert.False(t, selects(polset, clusterPolicy("disallow-latest", map[string]string{"fleet": "true"})))
+}
@@ -0,0 +1,300 @@
+package replay
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	backgroundcommon "github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	webhookcontroller "github.com/kyverno/kyverno/pkg/controllers/webhook"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "replay-controller"
+	// MaxWindow is the default maximum duration of the downtime window replayed
+	MaxWindow = 24 * time.Hour
+)
+
+type controller struct {
+	// clients
+	client        dclient.Interface
+	kyvernoClient versioned.Interface
+	leaseClient   coordinationv1client.LeaseInterface
+
+	// listers
+	polLister  kyvernov1listers.PolicyLister
+	cpolLister kyvernov1listers.ClusterPolicyLister
+	nsLister   corev1listers.NamespaceLister
+
+	// config
+	engine           engineapi.Engine
+	config           config.Configuration
+	jp               jmespath.Interface
+	admissionReports bool
+	maxWindow        time.Duration
+}
+
+// NewController returns a controller replaying, once at startup, the resources created or updated while the
+// admission webhooks were down. The downtime window starts at the last request received by the verify webhook,
+// resources modified since then are evaluated against validate policies in Audit mode and generate policies.
+func NewController(
+	client dclient.Interface,
+	kyvernoClient versioned.Interface,
+	leaseClient coordinationv1client.LeaseInterface,
+	polInformer kyvernov1informers.PolicyInformer,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	nsInformer corev1informers.NamespaceInformer,
+	engine engineapi.Engine,
+	config config.Configuration,
+	jp jmespath.Interface,
+	admissionReports bool,
+	maxWindow time.Duration,
+) controllers.Controller {
+	return &controller{
+		client:           client,
+		kyvernoClient:    kyvernoClient,
+		leaseClient:      leaseClient,
+		polLister:        polInformer.Lister(),
+		cpolLister:       cpolInformer.Lister(),
+		nsLister:         nsInformer.Lister(),
+		engine:           engine,
+		config:           config,
+		jp:               jp,
+		admissionReports: admissionReports,
+		maxWindow:        maxWindow,
+	}
+}
+
+func (c *controller) Run(ctx context.Context, _ int) {
+	logger.Info("starting ...")
+	defer logger.Info("stopped")
+	since, ok := c.downtimeStart(ctx)
+	if !ok {
+		return
+	}
+	logger.Info("replaying resources modified during webhooks downtime", "since", since)
+	if err := c.replay(ctx, since); err != nil {
+		logger.Error(err, "failed to replay resources modified during webhooks downtime")
+	}
+}
+
+// downtimeStart returns the time of the last request received by the webhooks if they were idle for too long
+func (c *controller) downtimeStart(ctx context.Context) (time.Time, bool) {
+	lease, err := c.leaseClient.Get(ctx, "kyverno-health", metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "failed to get lease")
+		}
+		return time.Time{}, false
+	}
+	lastRequest, err := time.Parse(time.RFC3339, lease.GetAnnotations()[webhookcontroller.AnnotationLastRequestTime])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return computeWindow(lastRequest, time.Now(), c.maxWindow)
+}
+
+func computeWindow(lastRequest, now time.Time, maxWindow time.Duration) (time.Time, bool) {
+	if now.Before(lastRequest.Add(webhookcontroller.IdleDeadline)) {
+		return time.Time{}, false
+	}
+	if maxWindow > 0 && now.Sub(lastRequest) > maxWindow {
+		return now.Add(-maxWindow), true
+	}
+	return lastRequest, true
+}
+
+func (c *controller) replay(ctx context.Context, since time.Time) error {
+	policies, err := c.fetchPolicies()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, kind := range sets.List(kinds(policies)) {
+		apiVersion, kind := kubeutils.GetKindFromGVK(kind)
+		list, err := c.client.ListResource(ctx, apiVersion, kind, "", nil)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, resource := range list.Items {
+			if !lastModified(resource).After(since) {
+				continue
+			}
+			if err := c.process(ctx, logger, resource, policies); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+func (c *controller) process(ctx context.Context, logger logr.Logger, resource unstructured.Unstructured, policies []kyvernov1.PolicyInterface) error {
+	logger = logger.WithValues("kind", resource.GetKind(), "namespace", resource.GetNamespace(), "name", resource.GetName())
+	var nsLabels map[string]string
+	if namespace := resource.GetNamespace(); namespace != "" {
+		ns, err := c.nsLister.Get(namespace)
+		if err != nil {
+			return err
+		}
+		nsLabels = ns.GetLabels()
+	}
+	var errs []error
+	var responses []engineapi.EngineResponse
+	for _, policy := range policies {
+		if policy.IsNamespaced() && policy.GetNamespace() != resource.GetNamespace() {
+			continue
+		}
+		policyContext, err := engine.NewPolicyContext(c.jp, resource, kyvernov1.Create, nil, c.config)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		policyContext = policyContext.
+			WithNewResource(resource).
+			WithPolicy(policy).
+			WithNamespaceLabels(nsLabels)
+		spec := policy.GetSpec()
+		if isAuditPolicy(policy) {
+			responses = append(responses, c.engine.Validate(ctx, policyContext))
+		}
+		if spec.HasGenerate() {
+			response := c.engine.ApplyBackgroundChecks(ctx, policyContext)
+			for _, rule := range response.PolicyResponse.Rules {
+				if rule.RuleType() != engineapi.Generation || rule.Status() != engineapi.RuleStatusPass {
+					continue
+				}
+				logger.V(2).Info("creating update request for missed generate rule", "policy", policy.GetName(), "rule", rule.Name())
+				if err := c.createUpdateRequest(ctx, policy, rule.Name(), resource); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+	if c.admissionReports && reportutils.IsGvkSupported(resource.GroupVersionKind()) && resource.GetUID() != "" {
+		gvr, err := c.client.Discovery().GetGVRFromGVK(resource.GroupVersionKind())
+		if err != nil {
+			return multierr.Append(multierr.Combine(errs...), err)
+		}
+		report := reportutils.NewAdmissionReport(resource.GetNamespace(), string(uuid.NewUUID()), gvr, resource)
+		reportutils.SetResponses(report, responses...)
+		if len(report.GetResults()) > 0 {
+			if _, err := reportutils.CreateReport(ctx, report, c.kyvernoClient); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+func (c *controller) createUpdateRequest(ctx context.Context, policy kyvernov1.PolicyInterface, rule string, trigger unstructured.Unstructured) error {
+	policyKey := backgroundcommon.PolicyKey(policy.GetNamespace(), policy.GetName())
+	resource := backgroundcommon.ResourceSpecFromUnstructured(trigger)
+	ur := &kyvernov1beta1.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "ur-",
+			Namespace:    config.KyvernoNamespace(),
+			Labels:       backgroundcommon.GenerateLabelsSet(policyKey, resource),
+		},
+		Spec: kyvernov1beta1.UpdateRequestSpec{
+			Type:     kyvernov1beta1.Generate,
+			Policy:   policyKey,
+			Rule:     rule,
+			Resource: resource,
+		},
+	}
+	created, err := c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Create(ctx, ur, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	updated := created.DeepCopy()
+	updated.Status.State = kyvernov1beta1.Pending
+	_, err = c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+// fetchPolicies returns the validate policies in Audit mode and the generate policies
+func (c *controller) fetchPolicies() ([]kyvernov1.PolicyInterface, error) {
+	var policies []kyvernov1.PolicyInterface
+	cpols, err := c.cpolLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, cpol := range cpols {
+		if isAuditPolicy(cpol) || cpol.GetSpec().HasGenerate() {
+			policies = append(policies, cpol)
+		}
+	}
+	pols, err := c.polLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, pol := range pols {
+		if isAuditPolicy(pol) || pol.GetSpec().HasGenerate() {
+			policies = append(policies, pol)
+		}
+	}
+	return policies, nil
+}
+
+func isAuditPolicy(policy kyvernov1.PolicyInterface) bool {
+	spec := policy.GetSpec()
+	return spec.HasValidate() && spec.ValidationFailureAction.Audit()
+}
+
+// kinds returns the kinds matched by the policies, wildcards and subresources are ignored
+func kinds(policies []kyvernov1.PolicyInterface) sets.Set[string] {
+	kinds := sets.New[string]()
+	for _, policy := range policies {
+		for _, rule := range autogen.ComputeRules(policy) {
+			for _, kind := range rule.MatchResources.GetKinds() {
+				if strings.ContainsAny(kind, "*?") {
+					continue
+				}
+				if _, k := kubeutils.GetKindFromGVK(kind); strings.Contains(k, "/") {
+					continue
+				}
+				kinds.Insert(kind)
+			}
+		}
+	}
+	return kinds
+}
+
+// lastModified returns the last time a resource was created or updated according to its managed fields
+func lastModified(resource unstructured.Unstructured) time.Time {
+	modified := resource.GetCreationTimestamp().Time
+	for _, entry := range resource.GetManagedFields() {
+		if entry.Time != nil && entry.Time.After(modified) {
+			modified = entry.Time.Time
+		}
+	}
+	return modified
+}
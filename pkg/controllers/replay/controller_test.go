@@ -0,0 +1,88 @@
+package replay
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func Test_computeWindow(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		lastRequest time.Time
+		maxWindow   time.Duration
+		want        time.Time
+		wantOk      bool
+	}{{
+		name:        "webhooks not idle",
+		lastRequest: now.Add(-10 * time.Second),
+		maxWindow:   MaxWindow,
+	}, {
+		name:        "webhooks idle",
+		lastRequest: now.Add(-time.Hour),
+		maxWindow:   MaxWindow,
+		want:        now.Add(-time.Hour),
+		wantOk:      true,
+	}, {
+		name:        "window capped",
+		lastRequest: now.Add(-48 * time.Hour),
+		maxWindow:   MaxWindow,
+		want:        now.Add(-MaxWindow),
+		wantOk:      true,
+	}, {
+		name:        "window not capped",
+		lastRequest: now.Add(-48 * time.Hour),
+		want:        now.Add(-48 * time.Hour),
+		wantOk:      true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := computeWindow(tt.lastRequest, now, tt.maxWindow)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_lastModified(t *testing.T) {
+	created := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+	var resource unstructured.Unstructured
+	resource.SetCreationTimestamp(metav1.NewTime(created))
+	assert.True(t, created.Equal(lastModified(resource)))
+	resource.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{Manager: "kubectl", Time: &metav1.Time{Time: updated}},
+		{Manager: "kubelet"},
+	})
+	assert.True(t, updated.Equal(lastModified(resource)))
+}
+
+func Test_kinds(t *testing.T) {
+	rule := func(kinds ...string) kyvernov1.Rule {
+		return kyvernov1.Rule{
+			Name: "test",
+			MatchResources: kyvernov1.MatchResources{
+				ResourceDescription: kyvernov1.ResourceDescription{Kinds: kinds},
+			},
+			Validation: kyvernov1.Validation{Message: "test", RawPattern: &apiextv1.JSON{Raw: []byte(`{"metadata":{"name":"?*"}}`)}},
+		}
+	}
+	policies := []kyvernov1.PolicyInterface{
+		&kyvernov1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec:       kyvernov1.Spec{Rules: []kyvernov1.Rule{rule("ConfigMap", "*", "Pod/status")}},
+		},
+		&kyvernov1.Policy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec:       kyvernov1.Spec{Rules: []kyvernov1.Rule{rule("apps/v1/Deployment", "Secret", "*Map")}},
+		},
+	}
+	assert.Equal(t, sets.New("ConfigMap", "apps/v1/Deployment", "Secret"), kinds(policies))
+}
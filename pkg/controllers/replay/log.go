@@ -0,0 +1,5 @@
+package replay
+
+import "github.com/kyverno/kyverno/pkg/logging"
+
+var logger = logging.WithName(ControllerName)
@@ -0,0 +1,46 @@
+package resource
+
+import (
+	"sync"
+	"time"
+)
+
+// batcher aligns the processing of the reports of a namespace on a common flush time,
+// changes received before the flush are written together and a namespace is not flushed
+// more than once per flush interval.
+type batcher struct {
+	lock          sync.Mutex
+	mergeWindow   time.Duration
+	flushInterval time.Duration
+	flushes       map[string]time.Time
+	now           func() time.Time
+}
+
+func newBatcher(mergeWindow, flushInterval time.Duration) *batcher {
+	return &batcher{
+		mergeWindow:   mergeWindow,
+		flushInterval: flushInterval,
+		flushes:       map[string]time.Time{},
+		now:           time.Now,
+	}
+}
+
+// delay returns how long to wait before processing a report of the given namespace
+func (b *batcher) delay(namespace string) time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	now := b.now()
+	flush, scheduled := b.flushes[namespace]
+	// join the flush already scheduled for the namespace
+	if scheduled && flush.After(now) {
+		return flush.Sub(now)
+	}
+	next := now.Add(b.mergeWindow)
+	if scheduled {
+		if earliest := flush.Add(b.flushInterval); earliest.After(next) {
+			next = earliest
+		}
+	}
+	b.flushes[namespace] = next
+	return next.Sub(now)
+}
@@ -0,0 +1,36 @@
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_batcher(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newBatcher(10*time.Second, time.Minute)
+	b.now = func() time.Time { return now }
+	// first change waits for the merge window
+	assert.Equal(t, 10*time.Second, b.delay("default"))
+	// later changes join the scheduled flush
+	now = now.Add(4 * time.Second)
+	assert.Equal(t, 6*time.Second, b.delay("default"))
+	// other namespaces are batched separately
+	assert.Equal(t, 10*time.Second, b.delay("kube-system"))
+	// after the flush, the next one waits for the flush interval
+	now = now.Add(11 * time.Second)
+	assert.Equal(t, 55*time.Second, b.delay("default"))
+	// when the flush interval already expired only the merge window applies
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, 10*time.Second, b.delay("default"))
+}
+
+func Test_batcherWithoutFlushInterval(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newBatcher(0, 0)
+	b.now = func() time.Time { return now }
+	assert.Equal(t, time.Duration(0), b.delay("default"))
+	now = now.Add(time.Second)
+	assert.Equal(t, time.Duration(0), b.delay("default"))
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/controllers/report/resource"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -34,7 +35,10 @@ const (
 	Workers        = 10
 	ControllerName = "resource-aggregate-report-controller"
 	maxRetries     = 10
-	enqueueDelay   = 10 * time.Second
+	// MergeWindow is the default delay before changes of the reports of a namespace are written
+	MergeWindow = 10 * time.Second
+	// FlushInterval is the default minimum delay between two writes of the reports of a namespace
+	FlushInterval = 10 * time.Second
 )
 
 type controller struct {
@@ -52,6 +56,8 @@ type controller struct {
 	// cache
 	metadataCache resource.MetadataCache
 
+	batcher *batcher
+
 	chunkSize int
 }
 
@@ -68,6 +74,8 @@ func NewController(
 	vapInformer admissionregistrationv1alpha1informers.ValidatingAdmissionPolicyInformer,
 	metadataCache resource.MetadataCache,
 	chunkSize int,
+	mergeWindow time.Duration,
+	flushInterval time.Duration,
 ) controllers.Controller {
 	admrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("admissionreports"))
 	cadmrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("clusteradmissionreports"))
@@ -81,17 +89,18 @@ func NewController(
 		cpolLister:    cpolInformer.Lister(),
 		queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
 		metadataCache: metadataCache,
+		batcher:       newBatcher(mergeWindow, flushInterval),
 		chunkSize:     chunkSize,
 	}
 	enqueueAll := func() {
 		if list, err := polrInformer.Lister().List(labels.Everything()); err == nil {
 			for _, item := range list {
-				c.queue.AddAfter(controllerutils.MetaObjectToName(item.(*metav1.PartialObjectMetadata)), enqueueDelay)
+				c.enqueue(item.(*metav1.PartialObjectMetadata))
 			}
 		}
 		if list, err := cpolrInformer.Lister().List(labels.Everything()); err == nil {
 			for _, item := range list {
-				c.queue.AddAfter(controllerutils.MetaObjectToName(item.(*metav1.PartialObjectMetadata)), enqueueDelay)
+				c.enqueue(item.(*metav1.PartialObjectMetadata))
 			}
 		}
 	}
@@ -122,16 +131,34 @@ func NewController(
 			logger.Error(err, "failed to register event handlers")
 		}
 	}
-	if _, _, err := controllerutils.AddDelayedDefaultEventHandlers(logger, bgscanrInformer.Informer(), c.queue, enqueueDelay); err != nil {
+	if _, err := controllerutils.AddEventHandlersT(
+		bgscanrInformer.Informer(),
+		func(obj metav1.Object) { c.enqueue(obj) },
+		func(old, obj metav1.Object) {
+			if old.GetResourceVersion() != obj.GetResourceVersion() {
+				c.enqueue(obj)
+			}
+		},
+		func(obj metav1.Object) { c.enqueue(obj) },
+	); err != nil {
 		logger.Error(err, "failed to register event handlers")
 	}
-	if _, _, err := controllerutils.AddDelayedDefaultEventHandlers(logger, cbgscanrInformer.Informer(), c.queue, enqueueDelay); err != nil {
+	if _, err := controllerutils.AddEventHandlersT(
+		cbgscanrInformer.Informer(),
+		func(obj metav1.Object) { c.enqueue(obj) },
+		func(old, obj metav1.Object) {
+			if old.GetResourceVersion() != obj.GetResourceVersion() {
+				c.enqueue(obj)
+			}
+		},
+		func(obj metav1.Object) { c.enqueue(obj) },
+	); err != nil {
 		logger.Error(err, "failed to register event handlers")
 	}
 	enqueueFromAdmr := func(obj metav1.Object) {
 		// no need to consider non aggregated reports
 		if controllerutils.HasLabel(obj, reportutils.LabelAggregatedReport) {
-			c.queue.AddAfter(controllerutils.MetaObjectToName(obj), enqueueDelay)
+			c.enqueue(obj)
 		}
 	}
 	if _, err := controllerutils.AddEventHandlersT(
@@ -157,6 +184,11 @@ func (c *controller) Run(ctx context.Context, workers int) {
 	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
 }
 
+// enqueue delays the reconciliation of the report until the next flush of its namespace
+func (c *controller) enqueue(obj metav1.Object) {
+	c.queue.AddAfter(controllerutils.MetaObjectToName(obj), c.batcher.delay(obj.GetNamespace()))
+}
+
 func (c *controller) createPolicyMap() (map[string]policyMapEntry, error) {
 	results := map[string]policyMapEntry{}
 	cpols, err := c.cpolLister.List(labels.Everything())
@@ -339,12 +371,13 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, _, names
 				}
 			}
 		} else {
+			before := reportutils.DeepCopy(policyReport)
 			reportutils.SetResults(policyReport, results...)
 			if create {
 				if _, err := reportutils.CreateReport(ctx, policyReport, c.client); err != nil {
 					return err
 				}
-			} else {
+			} else if !datautils.DeepEqual(before, policyReport) {
 				if _, err := updateReport(ctx, policyReport, c.client); err != nil {
 					return err
 				}
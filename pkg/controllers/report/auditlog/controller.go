@@ -0,0 +1,261 @@
+package auditlog
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 2
+	ControllerName = "audit-log-controller"
+	maxRetries     = 5
+	// maxBodySize is the maximum size of an audit event batch
+	maxBodySize = 32 * 1024 * 1024
+)
+
+type controller struct {
+	// clients
+	kyvernoClient versioned.Interface
+
+	// listers
+	polLister  kyvernov1listers.PolicyLister
+	cpolLister kyvernov1listers.ClusterPolicyLister
+	nsLister   corev1listers.NamespaceLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// pending audit events indexed by audit id
+	lock   sync.Mutex
+	events map[string]auditv1.Event
+
+	// config
+	address  string
+	certFile string
+	keyFile  string
+	scanner  utils.Scanner
+}
+
+// NewController returns a controller receiving audit events from the API server audit webhook backend.
+// Changes recorded in the audit log are evaluated against the validate policies in Audit mode and
+// admission reports are created for them, this allows reporting on resources admitted while the
+// admission webhooks were not available.
+func NewController(
+	kyvernoClient versioned.Interface,
+	polInformer kyvernov1informers.PolicyInformer,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	nsInformer corev1informers.NamespaceInformer,
+	engine engineapi.Engine,
+	config config.Configuration,
+	jp jmespath.Interface,
+	address string,
+	certFile string,
+	keyFile string,
+) controllers.Controller {
+	return &controller{
+		kyvernoClient: kyvernoClient,
+		polLister:     polInformer.Lister(),
+		cpolLister:    cpolInformer.Lister(),
+		nsLister:      nsInformer.Lister(),
+		queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		events:        map[string]auditv1.Event{},
+		address:       address,
+		certFile:      certFile,
+		keyFile:       keyFile,
+		scanner:       utils.NewScanner(logger, engine, config, jp),
+	}
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.serve)
+}
+
+func (c *controller) serve(ctx context.Context, logger logr.Logger) {
+	server := &http.Server{
+		Addr:              c.address,
+		Handler:           c,
+		ReadHeaderTimeout: 30 * time.Second,
+		TLSConfig:         &tls.Config{MinVersion: tls.VersionTLS12},
+	}
+	go func() {
+		<-ctx.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			logger.Error(err, "failed to shutdown audit log server")
+		}
+	}()
+	logger.Info("starting audit log server", "address", c.address)
+	var err error
+	if c.certFile != "" && c.keyFile != "" {
+		err = server.ListenAndServeTLS(c.certFile, c.keyFile)
+	} else {
+		err = server.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Error(err, "audit log server failed")
+	}
+}
+
+// ServeHTTP receives the batches of audit events sent by the API server webhook backend
+func (c *controller) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var list auditv1.EventList
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&list); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, event := range list.Items {
+		if !isRelevant(event) {
+			continue
+		}
+		c.enqueue(event)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (c *controller) enqueue(event auditv1.Event) {
+	key := string(event.AuditID)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.events[key] = event
+	c.queue.Add(key)
+}
+
+func (c *controller) pop(key string) (auditv1.Event, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	event, ok := c.events[key]
+	delete(c.events, key)
+	return event, ok
+}
+
+// isRelevant returns true for successful create and update requests recorded with their response object
+func isRelevant(event auditv1.Event) bool {
+	if event.Stage != auditv1.StageResponseComplete || event.AuditID == "" {
+		return false
+	}
+	switch event.Verb {
+	case "create", "update", "patch":
+	default:
+		return false
+	}
+	if event.ObjectRef == nil || event.ObjectRef.Subresource != "" {
+		return false
+	}
+	if event.ResponseStatus != nil && (event.ResponseStatus.Code < 200 || event.ResponseStatus.Code >= 300) {
+		return false
+	}
+	return event.ResponseObject != nil && len(event.ResponseObject.Raw) != 0
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, _ string) error {
+	event, ok := c.pop(key)
+	if !ok {
+		return nil
+	}
+	var resource unstructured.Unstructured
+	if err := resource.UnmarshalJSON(event.ResponseObject.Raw); err != nil {
+		logger.Error(err, "failed to decode audit event response object", "auditID", key)
+		return nil
+	}
+	if !reportutils.IsGvkSupported(resource.GroupVersionKind()) || resource.GetUID() == "" {
+		return nil
+	}
+	policies, err := c.fetchPolicies(resource.GetNamespace())
+	if err != nil {
+		return err
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+	var nsLabels map[string]string
+	if namespace := resource.GetNamespace(); namespace != "" {
+		ns, err := c.nsLister.Get(namespace)
+		if err != nil {
+			return err
+		}
+		nsLabels = ns.GetLabels()
+	}
+	var responses []engineapi.EngineResponse
+	for _, result := range c.scanner.ScanResource(ctx, resource, nsLabels, policies...) {
+		if result.Error != nil {
+			logger.Error(result.Error, "failed to scan resource", "auditID", key)
+		} else if result.EngineResponse != nil {
+			responses = append(responses, *result.EngineResponse)
+		}
+	}
+	gvr := schema.GroupVersionResource{
+		Group:    event.ObjectRef.APIGroup,
+		Version:  event.ObjectRef.APIVersion,
+		Resource: event.ObjectRef.Resource,
+	}
+	report := reportutils.NewAdmissionReport(resource.GetNamespace(), strings.ToLower(key), gvr, resource)
+	reportutils.SetResponses(report, responses...)
+	if len(report.GetResults()) == 0 {
+		return nil
+	}
+	_, err = reportutils.CreateReport(ctx, report, c.kyvernoClient)
+	return err
+}
+
+// fetchPolicies returns the validate policies in Audit mode applying to the given namespace
+func (c *controller) fetchPolicies(namespace string) ([]engineapi.GenericPolicy, error) {
+	var policies []engineapi.GenericPolicy
+	cpols, err := c.cpolLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, cpol := range cpols {
+		if isAuditPolicy(cpol) {
+			policies = append(policies, engineapi.NewKyvernoPolicy(cpol))
+		}
+	}
+	if namespace != "" {
+		pols, err := c.polLister.Policies(namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, pol := range pols {
+			if isAuditPolicy(pol) {
+				policies = append(policies, engineapi.NewKyvernoPolicy(pol))
+			}
+		}
+	}
+	return policies, nil
+}
+
+func isAuditPolicy(policy kyvernov1.PolicyInterface) bool {
+	spec := policy.GetSpec()
+	return spec.HasValidate() && spec.ValidationFailureAction.Audit()
+}
@@ -0,0 +1,144 @@
+package auditlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func event(id, verb string, code int32, object string) auditv1.Event {
+	e := auditv1.Event{
+		AuditID:        types.UID(id),
+		Stage:          auditv1.StageResponseComplete,
+		Verb:           verb,
+		ObjectRef:      &auditv1.ObjectReference{Resource: "pods", Namespace: "default", Name: "test", APIVersion: "v1"},
+		ResponseStatus: &metav1.Status{Code: code},
+	}
+	if object != "" {
+		e.ResponseObject = &runtime.Unknown{Raw: []byte(object)}
+	}
+	return e
+}
+
+func Test_isRelevant(t *testing.T) {
+	pod := `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test","namespace":"default","uid":"abc"}}`
+	tests := []struct {
+		name  string
+		event auditv1.Event
+		want  bool
+	}{{
+		name:  "create",
+		event: event("1", "create", 201, pod),
+		want:  true,
+	}, {
+		name:  "patch",
+		event: event("1", "patch", 200, pod),
+		want:  true,
+	}, {
+		name:  "delete",
+		event: event("1", "delete", 200, pod),
+	}, {
+		name:  "get",
+		event: event("1", "get", 200, pod),
+	}, {
+		name:  "failed",
+		event: event("1", "create", 403, pod),
+	}, {
+		name:  "no response object",
+		event: event("1", "create", 201, ""),
+	}, {
+		name: "request received",
+		event: func() auditv1.Event {
+			e := event("1", "create", 201, pod)
+			e.Stage = auditv1.StageRequestReceived
+			return e
+		}(),
+	}, {
+		name: "subresource",
+		event: func() auditv1.Event {
+			e := event("1", "update", 200, pod)
+			e.ObjectRef.Subresource = "status"
+			return e
+		}(),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRelevant(tt.event))
+		})
+	}
+}
+
+func Test_ServeHTTP(t *testing.T) {
+	pod := `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test","namespace":"default","uid":"abc"}}`
+	c := &controller{
+		queue:  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		events: map[string]auditv1.Event{},
+	}
+	defer c.queue.ShutDown()
+	list := auditv1.EventList{Items: []auditv1.Event{
+		event("1", "create", 201, pod),
+		event("2", "get", 200, pod),
+		event("3", "update", 200, pod),
+	}}
+	body, err := json.Marshal(list)
+	assert.NoError(t, err)
+	w := httptest.NewRecorder()
+	c.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 2, c.queue.Len())
+	_, ok := c.pop("1")
+	assert.True(t, ok)
+	_, ok = c.pop("1")
+	assert.False(t, ok)
+	_, ok = c.pop("2")
+	assert.False(t, ok)
+
+	w = httptest.NewRecorder()
+	c.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("{"))))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = httptest.NewRecorder()
+	c.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func Test_fetchPolicies(t *testing.T) {
+	validate := []kyvernov1.Rule{{Name: "validate", Validation: kyvernov1.Validation{Message: "test", RawPattern: &apiextv1.JSON{Raw: []byte(`{"metadata":{"name":"?*"}}`)}}}}
+	cpolIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	polIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	assert.NoError(t, cpolIndexer.Add(&kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "audit"},
+		Spec:       kyvernov1.Spec{ValidationFailureAction: kyvernov1.Audit, Rules: validate},
+	}))
+	assert.NoError(t, cpolIndexer.Add(&kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "enforce"},
+		Spec:       kyvernov1.Spec{ValidationFailureAction: kyvernov1.Enforce, Rules: validate},
+	}))
+	assert.NoError(t, polIndexer.Add(&kyvernov1.Policy{
+		ObjectMeta: metav1.ObjectMeta{Name: "audit", Namespace: "default"},
+		Spec:       kyvernov1.Spec{ValidationFailureAction: kyvernov1.Audit, Rules: validate},
+	}))
+	c := &controller{
+		cpolLister: kyvernov1listers.NewClusterPolicyLister(cpolIndexer),
+		polLister:  kyvernov1listers.NewPolicyLister(polIndexer),
+	}
+	policies, err := c.fetchPolicies("default")
+	assert.NoError(t, err)
+	assert.Len(t, policies, 2)
+	policies, err = c.fetchPolicies("")
+	assert.NoError(t, err)
+	assert.Len(t, policies, 1)
+	assert.Equal(t, "audit", policies[0].GetName())
+}
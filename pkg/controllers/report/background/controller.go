@@ -2,15 +2,20 @@ package background
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov2beta1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2beta1"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	kyvernov2beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2beta1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers"
@@ -24,7 +29,9 @@ import (
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	admissionregistrationv1alpha1informers "k8s.io/client-go/informers/admissionregistration/v1alpha1"
@@ -58,6 +65,7 @@ type controller struct {
 	bgscanrLister  cache.GenericLister
 	cbgscanrLister cache.GenericLister
 	nsLister       corev1listers.NamespaceLister
+	polexLister    kyvernov2beta1listers.PolicyExceptionLister
 
 	// queue
 	queue workqueue.RateLimitingInterface
@@ -65,6 +73,7 @@ type controller struct {
 	// cache
 	metadataCache resource.MetadataCache
 	forceDelay    time.Duration
+	scanCache     bool
 
 	// config
 	config        config.Configuration
@@ -82,8 +91,10 @@ func NewController(
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	vapInformer admissionregistrationv1alpha1informers.ValidatingAdmissionPolicyInformer,
 	nsInformer corev1informers.NamespaceInformer,
+	polexInformer kyvernov2beta1informers.PolicyExceptionInformer,
 	metadataCache resource.MetadataCache,
 	forceDelay time.Duration,
+	scanCache bool,
 	config config.Configuration,
 	jp jmespath.Interface,
 	eventGen event.Interface,
@@ -104,11 +115,15 @@ func NewController(
 		queue:          queue,
 		metadataCache:  metadataCache,
 		forceDelay:     forceDelay,
+		scanCache:      scanCache,
 		config:         config,
 		jp:             jp,
 		eventGen:       eventGen,
 		policyReports:  policyReports,
 	}
+	if polexInformer != nil {
+		c.polexLister = polexInformer.Lister()
+	}
 	if vapInformer != nil {
 		c.vapLister = vapInformer.Lister()
 		if _, err := controllerutils.AddEventHandlersT(vapInformer.Informer(), c.addVAP, c.updateVAP, c.deleteVAP); err != nil {
@@ -198,31 +213,48 @@ func (c *controller) getMeta(namespace, name string) (metav1.Object, error) {
 	}
 }
 
-func (c *controller) needsReconcile(namespace, name, hash string, policies ...engineapi.GenericPolicy) (bool, bool, error) {
+// needsReconcile returns whether the report needs to be reconciled, whether all policies must be evaluated again
+// and whether the policies depending on external data must be evaluated again
+func (c *controller) needsReconcile(namespace, name, hash string, policies ...engineapi.GenericPolicy) (bool, bool, bool, error) {
 	// if the reportMetadata does not exist, we need a full reconcile
 	reportMetadata, err := c.getMeta(namespace, name)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return true, true, nil
+			return true, true, false, nil
 		}
-		return false, false, err
+		return false, false, false, err
 	}
 	// if the resource changed, we need a full reconcile
 	if !reportutils.CompareHash(reportMetadata, hash) {
-		return true, true, nil
+		return true, true, false, nil
 	}
 	// if the last scan time is older than recomputation interval, we need a full reconcile
+	// unless the inputs of the last evaluation did not change, then only the policies depending on external data are evaluated again
 	reportAnnotations := reportMetadata.GetAnnotations()
 	if reportAnnotations == nil || reportAnnotations[annotationLastScanTime] == "" {
-		return true, true, nil
+		return true, true, false, nil
 	} else {
 		annTime, err := time.Parse(time.RFC3339, reportAnnotations[annotationLastScanTime])
 		if err != nil {
 			logger.Error(err, "failed to parse last scan time annotation", "namespace", namespace, "name", name, "hash", hash)
-			return true, true, nil
+			return true, true, false, nil
 		}
 		if time.Now().After(annTime.Add(c.forceDelay)) {
-			return true, true, nil
+			if !c.scanCache {
+				return true, true, false, nil
+			}
+			fingerprint, err := c.fingerprint(namespace, hash, policies...)
+			if err != nil {
+				return false, false, false, err
+			}
+			if reportAnnotations[annotationScanFingerprint] != fingerprint {
+				return true, true, false, nil
+			}
+			for _, policy := range policies {
+				if !isCacheable(policy) {
+					return true, false, true, nil
+				}
+			}
 		}
 	}
 	// if a policy changed, we need a partial reconcile
@@ -237,10 +269,31 @@ func (c *controller) needsReconcile(namespace, name, hash string, policies ...en
 		}
 	}
 	if !datautils.DeepEqual(expected, actual) {
-		return true, false, nil
+		return true, false, false, nil
 	}
 	// no need to reconcile
-	return false, false, nil
+	return false, false, false, nil
+}
+
+// fingerprint returns the fingerprint of the inputs of the evaluation of a resource
+func (c *controller) fingerprint(namespace, hash string, policies ...engineapi.GenericPolicy) (string, error) {
+	var nsLabels map[string]string
+	if namespace != "" {
+		ns, err := c.nsLister.Get(namespace)
+		if err != nil {
+			return "", err
+		}
+		nsLabels = ns.GetLabels()
+	}
+	var exceptions []*kyvernov2beta1.PolicyException
+	if c.polexLister != nil {
+		list, err := c.polexLister.List(labels.Everything())
+		if err != nil {
+			return "", err
+		}
+		exceptions = list
+	}
+	return fingerprint(hash, nsLabels, policies, exceptions), nil
 }
 
 func (c *controller) reconcileReport(
@@ -248,6 +301,7 @@ func (c *controller) reconcileReport(
 	namespace string,
 	name string,
 	full bool,
+	refresh bool,
 	uid types.UID,
 	gvk schema.GroupVersionKind,
 	resource resource.Resource,
@@ -287,6 +341,7 @@ func (c *controller) reconcileReport(
 		}
 	}
 	var ruleResults []policyreportv1alpha2.PolicyReportResult
+	cacheable := map[string]bool{}
 	if !full {
 		policyNameToLabel := map[string]string{}
 		for _, policy := range policies {
@@ -301,28 +356,40 @@ func (c *controller) reconcileReport(
 				return err
 			}
 			policyNameToLabel[key] = reportutils.PolicyLabel(policy)
+			cacheable[reportutils.PolicyLabel(policy)] = isCacheable(policy)
 		}
 		// keep up to date results
 		for _, result := range observed.GetResults() {
 			// if the policy did not change, keep the result
+			// unless it depends on external data and the results are being refreshed
 			label := policyNameToLabel[result.Policy]
-			if label != "" && expected[label] == actual[label] {
+			if label != "" && expected[label] == actual[label] && !(refresh && !cacheable[label]) {
 				ruleResults = append(ruleResults, result)
 			}
 		}
 	}
 	// calculate necessary results
 	for _, policy := range policies {
-		if full || actual[reportutils.PolicyLabel(policy)] != policy.GetResourceVersion() {
+		label := reportutils.PolicyLabel(policy)
+		if full || actual[label] != policy.GetResourceVersion() || refresh && !cacheable[label] {
 			scanner := utils.NewScanner(logger, c.engine, c.config, c.jp)
+			var ruleErrors []string
 			for _, result := range scanner.ScanResource(ctx, *target, nsLabels, policy) {
 				if result.Error != nil {
 					return result.Error
 				} else if result.EngineResponse != nil {
 					ruleResults = append(ruleResults, reportutils.EngineResponseToReportResults(*result.EngineResponse)...)
 					utils.GenerateEvents(logger, c.eventGen, c.config, *result.EngineResponse)
+					for _, rule := range result.EngineResponse.PolicyResponse.Rules {
+						if rule.Status() == engineapi.RuleStatusError {
+							ruleErrors = append(ruleErrors, fmt.Sprintf("rule %s failed on %s %s/%s: %s", rule.Name(), target.GetKind(), target.GetNamespace(), target.GetName(), rule.Message()))
+						}
+					}
 				}
 			}
+			if policy.GetType() == engineapi.KyvernoPolicyType {
+				c.updateExecutionStatus(ctx, policy.GetPolicy().(kyvernov1.PolicyInterface), ruleErrors)
+			}
 		}
 	}
 	desired := reportutils.DeepCopy(observed)
@@ -336,15 +403,64 @@ func (c *controller) reconcileReport(
 	}
 	reportutils.SetResourceVersionLabels(desired, target)
 	reportutils.SetResults(desired, ruleResults...)
-	if full || !controllerutils.HasAnnotation(desired, annotationLastScanTime) {
+	if full || refresh || !controllerutils.HasAnnotation(desired, annotationLastScanTime) {
 		controllerutils.SetAnnotation(desired, annotationLastScanTime, time.Now().Format(time.RFC3339))
 	}
+	if c.scanCache {
+		fingerprint, err := c.fingerprint(namespace, resource.Hash, policies...)
+		if err != nil {
+			return err
+		}
+		controllerutils.SetAnnotation(desired, annotationScanFingerprint, fingerprint)
+	}
 	if c.policyReports {
 		return c.storeReport(ctx, observed, desired)
 	}
 	return nil
 }
 
+// updateExecutionStatus sets the execution failed condition of the policy when some of its rules returned an error,
+// the condition is cleared by the first scan without error once the policy changed
+func (c *controller) updateExecutionStatus(ctx context.Context, policy kyvernov1.PolicyInterface, ruleErrors []string) {
+	failed := len(ruleErrors) != 0
+	condition := meta.FindStatusCondition(policy.GetStatus().Conditions, kyvernov1.PolicyConditionExecutionFailed)
+	if failed {
+		if condition != nil && condition.Status == metav1.ConditionTrue && condition.ObservedGeneration == policy.GetGeneration() {
+			return
+		}
+	} else if condition == nil || condition.Status != metav1.ConditionTrue || condition.ObservedGeneration == policy.GetGeneration() {
+		return
+	}
+	message := strings.Join(ruleErrors, "; ")
+	build := func(policy kyvernov1.PolicyInterface) error {
+		policy.GetStatus().SetExecutionFailed(failed, message, policy.GetGeneration())
+		return nil
+	}
+	var err error
+	if policy.GetNamespace() == "" {
+		_, err = controllerutils.UpdateStatus(
+			ctx,
+			policy.(*kyvernov1.ClusterPolicy),
+			c.kyvernoClient.KyvernoV1().ClusterPolicies(),
+			func(policy *kyvernov1.ClusterPolicy) error {
+				return build(policy)
+			},
+		)
+	} else {
+		_, err = controllerutils.UpdateStatus(
+			ctx,
+			policy.(*kyvernov1.Policy),
+			c.kyvernoClient.KyvernoV1().Policies(policy.GetNamespace()),
+			func(policy *kyvernov1.Policy) error {
+				return build(policy)
+			},
+		)
+	}
+	if err != nil {
+		logger.Error(err, "failed to update policy status", "namespace", policy.GetNamespace(), "name", policy.GetName())
+	}
+}
+
 func (c *controller) storeReport(ctx context.Context, observed, desired kyvernov1alpha2.ReportInterface) error {
 	var err error
 	hasReport := observed.GetResourceVersion() != ""
@@ -419,14 +535,14 @@ func (c *controller) reconcile(ctx context.Context, log logr.Logger, key, namesp
 		}
 	}
 	// we have the resource, check if we need to reconcile
-	if needsReconcile, full, err := c.needsReconcile(namespace, name, resource.Hash, policies...); err != nil {
+	if needsReconcile, full, refresh, err := c.needsReconcile(namespace, name, resource.Hash, policies...); err != nil {
 		return err
 	} else {
 		defer func() {
 			c.queue.AddAfter(key, c.forceDelay)
 		}()
 		if needsReconcile {
-			return c.reconcileReport(ctx, namespace, name, full, uid, gvk, resource, policies...)
+			return c.reconcileReport(ctx, namespace, name, full, refresh, uid, gvk, resource, policies...)
 		}
 	}
 	return nil
@@ -0,0 +1,65 @@
+package background
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+)
+
+// annotationScanFingerprint stores the fingerprint of the inputs of the last evaluation of a resource
+const annotationScanFingerprint = "audit.kyverno.io/scan-fingerprint"
+
+// fingerprint returns a digest of the inputs the evaluation of a resource depends on: the resource hash,
+// the labels of its namespace, the versions of the policies and of the policy exceptions
+func fingerprint(hash string, nsLabels map[string]string, policies []engineapi.GenericPolicy, exceptions []*kyvernov2beta1.PolicyException) string {
+	var entries []string
+	for key, value := range nsLabels {
+		entries = append(entries, "ns:"+key+"="+value)
+	}
+	for _, policy := range policies {
+		entries = append(entries, "policy:"+reportutils.PolicyLabel(policy)+"="+policy.GetResourceVersion())
+	}
+	for _, exception := range exceptions {
+		entries = append(entries, "exception:"+exception.GetNamespace()+"/"+exception.GetName()+"="+exception.GetResourceVersion())
+	}
+	sort.Strings(entries)
+	digest := sha256.New()
+	digest.Write([]byte(hash))
+	for _, entry := range entries {
+		digest.Write([]byte{0})
+		digest.Write([]byte(entry))
+	}
+	return hex.EncodeToString(digest.Sum(nil))
+}
+
+// isCacheable returns true when the results of a policy only depend on the inputs covered by the fingerprint,
+// policies loading external data (context entries, image verification, digest resolution, parameters) are re-evaluated at every scan interval
+func isCacheable(policy engineapi.GenericPolicy) bool {
+	if policy.GetType() != engineapi.KyvernoPolicyType {
+		vap, ok := policy.GetPolicy().(admissionregistrationv1alpha1.ValidatingAdmissionPolicy)
+		return ok && vap.Spec.ParamKind == nil
+	}
+	for _, rule := range autogen.ComputeRules(policy.GetPolicy().(kyvernov1.PolicyInterface)) {
+		if len(rule.Context) != 0 || rule.HasVerifyImages() || rule.Mutation.ResolveDigest != nil {
+			return false
+		}
+		for _, foreach := range rule.Validation.ForEachValidation {
+			if len(foreach.Context) != 0 || foreach.ForEachValidation != nil {
+				return false
+			}
+		}
+		for _, foreach := range rule.Mutation.ForEachMutation {
+			if len(foreach.Context) != 0 || foreach.ForEachMutation != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
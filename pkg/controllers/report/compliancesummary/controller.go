@@ -0,0 +1,195 @@
+package compliancesummary
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "compliance-summary-controller"
+	maxRetries     = 10
+	// SummaryName is the name of the cluster wide compliance summary
+	SummaryName = "cluster"
+	// RefreshInterval is the default interval at which the summary is computed again
+	RefreshInterval = 5 * time.Minute
+)
+
+type controller struct {
+	// clients
+	kyvernoClient versioned.Interface
+
+	// config
+	refreshInterval time.Duration
+
+	// queue
+	queue workqueue.RateLimitingInterface
+}
+
+func NewController(kyvernoClient versioned.Interface, refreshInterval time.Duration) controllers.Controller {
+	return &controller{
+		kyvernoClient:   kyvernoClient,
+		refreshInterval: refreshInterval,
+		queue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+	}
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	c.queue.Add(SummaryName)
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.ticker)
+}
+
+func (c *controller) ticker(ctx context.Context, logger logr.Logger) {
+	ticker := time.NewTicker(c.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.queue.Add(SummaryName)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, name string) error {
+	aggregator := newAggregator()
+	cpolrs, err := c.kyvernoClient.Wgpolicyk8sV1alpha2().ClusterPolicyReports().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, report := range cpolrs.Items {
+		aggregator.add("", report.Results)
+	}
+	polrs, err := c.kyvernoClient.Wgpolicyk8sV1alpha2().PolicyReports(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, report := range polrs.Items {
+		aggregator.add(report.Namespace, report.Results)
+	}
+	desired := aggregator.summary(name)
+	client := c.kyvernoClient.KyvernoV2alpha1().PolicyComplianceSummaries()
+	observed, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		desired.LastUpdateTime = metav1.Now()
+		_, err := client.Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+	if datautils.DeepEqual(observed.Summary, desired.Summary) &&
+		datautils.DeepEqual(observed.Policies, desired.Policies) &&
+		datautils.DeepEqual(observed.Categories, desired.Categories) &&
+		datautils.DeepEqual(observed.Severities, desired.Severities) &&
+		datautils.DeepEqual(observed.Namespaces, desired.Namespaces) {
+		return nil
+	}
+	updated := observed.DeepCopy()
+	updated.Summary = desired.Summary
+	updated.Policies = desired.Policies
+	updated.Categories = desired.Categories
+	updated.Severities = desired.Severities
+	updated.Namespaces = desired.Namespaces
+	updated.LastUpdateTime = metav1.Now()
+	_, err = client.Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+type aggregator struct {
+	total      kyvernov2alpha1.ComplianceSummary
+	policies   map[string]*kyvernov2alpha1.ComplianceSummary
+	categories map[string]*kyvernov2alpha1.ComplianceSummary
+	severities map[string]*kyvernov2alpha1.ComplianceSummary
+	namespaces map[string]*kyvernov2alpha1.ComplianceSummary
+}
+
+func newAggregator() *aggregator {
+	return &aggregator{
+		policies:   map[string]*kyvernov2alpha1.ComplianceSummary{},
+		categories: map[string]*kyvernov2alpha1.ComplianceSummary{},
+		severities: map[string]*kyvernov2alpha1.ComplianceSummary{},
+		namespaces: map[string]*kyvernov2alpha1.ComplianceSummary{},
+	}
+}
+
+// add counts the results of a report, namespace is empty for cluster wide reports.
+func (a *aggregator) add(namespace string, results []policyreportv1alpha2.PolicyReportResult) {
+	for _, result := range results {
+		increment(&a.total, result.Result)
+		increment(group(a.policies, result.Policy), result.Result)
+		if result.Category != "" {
+			increment(group(a.categories, result.Category), result.Result)
+		}
+		if result.Severity != "" {
+			increment(group(a.severities, string(result.Severity)), result.Result)
+		}
+		if namespace != "" {
+			increment(group(a.namespaces, namespace), result.Result)
+		}
+	}
+}
+
+func (a *aggregator) summary(name string) *kyvernov2alpha1.PolicyComplianceSummary {
+	return &kyvernov2alpha1.PolicyComplianceSummary{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Summary:    a.total,
+		Policies:   groups(a.policies),
+		Categories: groups(a.categories),
+		Severities: groups(a.severities),
+		Namespaces: groups(a.namespaces),
+	}
+}
+
+func group(groups map[string]*kyvernov2alpha1.ComplianceSummary, name string) *kyvernov2alpha1.ComplianceSummary {
+	summary := groups[name]
+	if summary == nil {
+		summary = &kyvernov2alpha1.ComplianceSummary{}
+		groups[name] = summary
+	}
+	return summary
+}
+
+// groups returns the groups sorted by name so that the summary is stable between two computations.
+func groups(groups map[string]*kyvernov2alpha1.ComplianceSummary) []kyvernov2alpha1.ComplianceGroup {
+	if len(groups) == 0 {
+		return nil
+	}
+	out := make([]kyvernov2alpha1.ComplianceGroup, 0, len(groups))
+	for name, summary := range groups {
+		out = append(out, kyvernov2alpha1.ComplianceGroup{Name: name, Summary: *summary})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+func increment(summary *kyvernov2alpha1.ComplianceSummary, result policyreportv1alpha2.PolicyResult) {
+	switch result {
+	case policyreportv1alpha2.StatusPass:
+		summary.Pass++
+	case policyreportv1alpha2.StatusFail:
+		summary.Fail++
+	case policyreportv1alpha2.StatusWarn:
+		summary.Warn++
+	case policyreportv1alpha2.StatusError:
+		summary.Error++
+	case policyreportv1alpha2.StatusSkip:
+		summary.Skip++
+	}
+}
@@ -0,0 +1,63 @@
+package compliancesummary
+
+import (
+	"context"
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_controller_reconcile(t *testing.T) {
+	ctx := context.Background()
+	kyvernoClient := fake.NewSimpleClientset(&policyreportv1alpha2.ClusterPolicyReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "cpolr"},
+		Results: []policyreportv1alpha2.PolicyReportResult{
+			{Policy: "require-labels", Category: "Best Practices", Severity: policyreportv1alpha2.SeverityMedium, Result: policyreportv1alpha2.StatusPass},
+			{Policy: "disallow-latest", Result: policyreportv1alpha2.StatusFail},
+		},
+	}, &policyreportv1alpha2.PolicyReport{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "polr"},
+		Results: []policyreportv1alpha2.PolicyReportResult{
+			{Policy: "require-labels", Category: "Best Practices", Severity: policyreportv1alpha2.SeverityMedium, Result: policyreportv1alpha2.StatusFail},
+			{Policy: "require-labels", Category: "Best Practices", Severity: policyreportv1alpha2.SeverityHigh, Result: policyreportv1alpha2.StatusWarn},
+		},
+	}, &policyreportv1alpha2.PolicyReport{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "polr"},
+		Results: []policyreportv1alpha2.PolicyReportResult{
+			{Policy: "disallow-latest", Result: policyreportv1alpha2.StatusSkip},
+		},
+	})
+	c := NewController(kyvernoClient, RefreshInterval).(*controller)
+	assert.NoError(t, c.reconcile(ctx, logging.GlobalLogger(), SummaryName, "", SummaryName))
+	summary, err := kyvernoClient.KyvernoV2alpha1().PolicyComplianceSummaries().Get(ctx, SummaryName, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, kyvernov2alpha1.ComplianceSummary{Pass: 1, Fail: 2, Warn: 1, Skip: 1}, summary.Summary)
+	assert.Equal(t, []kyvernov2alpha1.ComplianceGroup{
+		{Name: "disallow-latest", Summary: kyvernov2alpha1.ComplianceSummary{Fail: 1, Skip: 1}},
+		{Name: "require-labels", Summary: kyvernov2alpha1.ComplianceSummary{Pass: 1, Fail: 1, Warn: 1}},
+	}, summary.Policies)
+	assert.Equal(t, []kyvernov2alpha1.ComplianceGroup{
+		{Name: "Best Practices", Summary: kyvernov2alpha1.ComplianceSummary{Pass: 1, Fail: 1, Warn: 1}},
+	}, summary.Categories)
+	assert.Equal(t, []kyvernov2alpha1.ComplianceGroup{
+		{Name: "high", Summary: kyvernov2alpha1.ComplianceSummary{Warn: 1}},
+		{Name: "medium", Summary: kyvernov2alpha1.ComplianceSummary{Pass: 1, Fail: 1}},
+	}, summary.Severities)
+	assert.Equal(t, []kyvernov2alpha1.ComplianceGroup{
+		{Name: "default", Summary: kyvernov2alpha1.ComplianceSummary{Fail: 1, Warn: 1}},
+		{Name: "kube-system", Summary: kyvernov2alpha1.ComplianceSummary{Skip: 1}},
+	}, summary.Namespaces)
+	assert.False(t, summary.LastUpdateTime.IsZero())
+
+	// an unchanged summary is not written again
+	lastUpdateTime := summary.LastUpdateTime
+	assert.NoError(t, c.reconcile(ctx, logging.GlobalLogger(), SummaryName, "", SummaryName))
+	summary, err = kyvernoClient.KyvernoV2alpha1().PolicyComplianceSummaries().Get(ctx, SummaryName, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, lastUpdateTime, summary.LastUpdateTime)
+}
@@ -235,12 +235,16 @@ func (c *controller) updateDynamicWatchers(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	kinds := utils.BuildKindSet(logger, utils.RemoveNonValidationPolicies(append(clusterPolicies, policies...)...)...)
+	validationPolicies := utils.RemoveNonValidationPolicies(append(clusterPolicies, policies...)...)
+	kinds := utils.BuildKindSet(logger, validationPolicies...)
 	gvkToGvr := map[schema.GroupVersionKind]schema.GroupVersionResource{}
 	for _, policyKind := range sets.List(kinds) {
 		group, version, kind, subresource := kubeutils.ParseKindSelector(policyKind)
 		c.addGVKToGVRMapping(group, version, kind, subresource, gvkToGvr)
 	}
+	for _, resource := range sets.List(utils.BuildResourceSet(logger, validationPolicies...)) {
+		c.addResourceToGVRMapping(resource, gvkToGvr)
+	}
 	if c.vapLister != nil {
 		vapPolicies, err := utils.FetchValidatingAdmissionPolicies(c.vapLister)
 		if err != nil {
@@ -305,6 +309,27 @@ func (c *controller) addGVKToGVRMapping(group, version, kind, subresource string
 	}
 }
 
+func (c *controller) addResourceToGVRMapping(resource string, gvrMap map[schema.GroupVersionKind]schema.GroupVersionResource) {
+	gvrss, err := dclient.FindResourcesByName(c.client.Discovery(), resource)
+	if err != nil {
+		logger.Error(err, "failed to get gvr from resource", "resource", resource)
+		return
+	}
+	for gvrs, api := range gvrss {
+		if gvrs.SubResource != "" {
+			continue
+		}
+		gvk := schema.GroupVersionKind{Group: gvrs.Group, Version: gvrs.Version, Kind: gvrs.Kind}
+		if !reportutils.IsGvkSupported(gvk) {
+			logger.Info("kind is not supported", "gvk", gvk)
+		} else if slices.Contains(api.Verbs, "list") && slices.Contains(api.Verbs, "watch") {
+			gvrMap[gvk] = gvrs.GroupVersionResource()
+		} else {
+			logger.Info("list/watch not supported for resource", "resource", resource)
+		}
+	}
+}
+
 func (c *controller) stopDynamicWatchers() {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -61,7 +61,11 @@ func generateFailEventsPerEr(log logr.Logger, er engineapi.EngineResponse) []eve
 		if rule.Status() != engineapi.RuleStatusPass && rule.Status() != engineapi.RuleStatusSkip {
 			eventResource := event.NewResourceViolationEvent(event.PolicyController, event.PolicyViolation, er, rule)
 			eventInfos = append(eventInfos, eventResource)
-			eventPolicy := event.NewPolicyFailEvent(event.PolicyController, event.PolicyViolation, er, rule, false)
+			reason := event.PolicyViolation
+			if rule.Status() == engineapi.RuleStatusError {
+				reason = event.PolicyError
+			}
+			eventPolicy := event.NewPolicyFailEvent(event.PolicyController, reason, er, rule, false)
 			eventInfos = append(eventInfos, eventPolicy)
 		}
 	}
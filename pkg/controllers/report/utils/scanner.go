@@ -73,6 +73,18 @@ func (s *scanner) ScanResource(ctx context.Context, resource unstructured.Unstru
 					response.PolicyResponse.Rules = append(response.PolicyResponse.Rules, ivResponse.PolicyResponse.Rules...)
 				}
 			}
+			if spec.HasMutateAudit() && len(errors) == 0 {
+				mResponse, err := s.auditMutations(ctx, resource, nsLabels, pol)
+				if err != nil {
+					logger.Error(err, "failed to audit mutations")
+					errors = append(errors, err)
+				}
+				if response == nil {
+					response = mResponse
+				} else if mResponse != nil {
+					response.PolicyResponse.Rules = append(response.PolicyResponse.Rules, mResponse.PolicyResponse.Rules...)
+				}
+			}
 		} else {
 			pol := policy.GetPolicy().(admissionregistrationv1alpha1.ValidatingAdmissionPolicy)
 			res := validatingadmissionpolicy.Validate(pol, resource)
@@ -117,3 +129,34 @@ func (s *scanner) validateImages(ctx context.Context, resource unstructured.Unst
 	}
 	return &response, nil
 }
+
+// auditMutations evaluates the audit mutate rules of the policy in dry run mode, the rules that would change
+// the resource are reported as failed
+func (s *scanner) auditMutations(ctx context.Context, resource unstructured.Unstructured, nsLabels map[string]string, policy kyvernov1.PolicyInterface) (*engineapi.EngineResponse, error) {
+	audit := policy.CreateDeepCopy()
+	spec := audit.GetSpec()
+	var rules []kyvernov1.Rule
+	for _, rule := range spec.Rules {
+		if rule.HasMutateAudit() {
+			rule.Mutation.DryRun = true
+			rules = append(rules, rule)
+		}
+	}
+	spec.Rules = rules
+	policyCtx, err := engine.NewPolicyContext(s.jp, resource, kyvernov1.Create, nil, s.config)
+	if err != nil {
+		return nil, err
+	}
+	policyCtx = policyCtx.
+		WithNewResource(resource).
+		WithPolicy(audit).
+		WithNamespaceLabels(nsLabels)
+	response := s.engine.Mutate(ctx, policyCtx)
+	for i, rule := range response.PolicyResponse.Rules {
+		if rule.Status() == engineapi.RuleStatusWarn {
+			response.PolicyResponse.Rules[i] = *engineapi.NewRuleResponse(rule.Name(), rule.RuleType(), rule.Message(), engineapi.RuleStatusFail)
+		}
+	}
+	response = response.WithPolicy(engineapi.NewKyvernoPolicy(policy))
+	return &response, nil
+}
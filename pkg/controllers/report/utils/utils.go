@@ -32,7 +32,7 @@ func BuildKindSet(logger logr.Logger, policies ...kyvernov1.PolicyInterface) set
 	kinds := sets.New[string]()
 	for _, policy := range policies {
 		for _, rule := range autogen.ComputeRules(policy) {
-			if rule.HasValidate() || rule.HasVerifyImages() {
+			if rule.HasValidate() || rule.HasVerifyImages() || rule.HasMutateAudit() {
 				kinds.Insert(rule.MatchResources.GetKinds()...)
 			}
 		}
@@ -40,6 +40,18 @@ func BuildKindSet(logger logr.Logger, policies ...kyvernov1.PolicyInterface) set
 	return kinds
 }
 
+func BuildResourceSet(logger logr.Logger, policies ...kyvernov1.PolicyInterface) sets.Set[string] {
+	resources := sets.New[string]()
+	for _, policy := range policies {
+		for _, rule := range autogen.ComputeRules(policy) {
+			if rule.HasValidate() || rule.HasVerifyImages() || rule.HasMutateAudit() {
+				resources.Insert(rule.MatchResources.GetResources()...)
+			}
+		}
+	}
+	return resources
+}
+
 func RemoveNonBackgroundPolicies(policies ...kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
 	var backgroundPolicies []kyvernov1.PolicyInterface
 	for _, pol := range policies {
@@ -54,7 +66,7 @@ func RemoveNonValidationPolicies(policies ...kyvernov1.PolicyInterface) []kyvern
 	var validationPolicies []kyvernov1.PolicyInterface
 	for _, pol := range policies {
 		spec := pol.GetSpec()
-		if spec.HasVerifyImages() || spec.HasValidate() || spec.HasVerifyManifests() {
+		if spec.HasVerifyImages() || spec.HasValidate() || spec.HasVerifyManifests() || spec.HasMutateAudit() {
 			validationPolicies = append(validationPolicies, pol)
 		}
 	}
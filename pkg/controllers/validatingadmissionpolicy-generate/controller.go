@@ -273,9 +273,13 @@ func (c *controller) buildValidatingAdmissionPolicyBinding(vapbinding *admission
 		},
 	}
 
-	// set validation action for vap binding
+	// set validation action for vap binding, the failure action of the rule takes precedence
+	rule := cpol.GetSpec().Rules[0]
 	var validationActions []admissionregistrationv1alpha1.ValidationAction
 	action := cpol.GetSpec().ValidationFailureAction
+	if rule.Validation.FailureAction != nil {
+		action = *rule.Validation.FailureAction
+	}
 	if action.Enforce() {
 		validationActions = append(validationActions, admissionregistrationv1alpha1.Deny)
 	} else if action.Audit() {
@@ -284,7 +288,6 @@ func (c *controller) buildValidatingAdmissionPolicyBinding(vapbinding *admission
 	}
 
 	// set validating admission policy binding spec
-	rule := cpol.GetSpec().Rules[0]
 	vapbinding.Spec = admissionregistrationv1alpha1.ValidatingAdmissionPolicyBindingSpec{
 		PolicyName:        cpol.GetName(),
 		ParamRef:          rule.Validation.CEL.ParamRef,
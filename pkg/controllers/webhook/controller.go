@@ -19,7 +19,9 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/tls"
+	"github.com/kyverno/kyverno/pkg/toggle"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	runtimeutils "github.com/kyverno/kyverno/pkg/utils/runtime"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
@@ -100,10 +102,14 @@ type controller struct {
 	runtime            runtimeutils.Runtime
 	configuration      config.Configuration
 	caSecretName       string
+	excludedNamespaces []string
 
 	// state
 	lock        sync.Mutex
 	policyState map[string]sets.Set[string]
+
+	// metrics
+	metrics controllerMetrics
 }
 
 func NewController(
@@ -128,6 +134,7 @@ func NewController(
 	runtime runtimeutils.Runtime,
 	configuration config.Configuration,
 	caSecretName string,
+	excludedNamespaces []string,
 ) controllers.Controller {
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 	c := controller{
@@ -152,10 +159,12 @@ func NewController(
 		runtime:            runtime,
 		configuration:      configuration,
 		caSecretName:       caSecretName,
+		excludedNamespaces: excludedNamespaces,
 		policyState: map[string]sets.Set[string]{
 			config.MutatingWebhookConfigurationName:   sets.New[string](),
 			config.ValidatingWebhookConfigurationName: sets.New[string](),
 		},
+		metrics: newControllerMetrics(),
 	}
 	if _, _, err := controllerutils.AddDefaultEventHandlers(logger, mwcInformer.Informer(), queue); err != nil {
 		logger.Error(err, "failed to register event handlers")
@@ -325,6 +334,29 @@ func (c *controller) clientConfig(caBundle []byte, path string) admissionregistr
 	return clientConfig
 }
 
+// namespaceSelector extends the namespace selector of the resource webhooks to exclude the namespaces configured
+// with the flag and the configmap, the Kyverno namespace is excluded too so that Kyverno can't lock itself out
+// unless managed resources protection is enabled, in which case the Kyverno namespace is covered by the webhooks
+func (c *controller) namespaceSelector(ctx context.Context, cfg config.Configuration, selector *metav1.LabelSelector) *metav1.LabelSelector {
+	namespaces := sets.New(c.excludedNamespaces...).Insert(cfg.GetWebhookExcludedNamespaces()...)
+	if !toggle.FromContext(ctx).ProtectManagedResources() {
+		namespaces.Insert(config.KyvernoNamespace())
+	}
+	if namespaces.Len() == 0 {
+		return selector
+	}
+	out := &metav1.LabelSelector{}
+	if selector != nil {
+		out = selector.DeepCopy()
+	}
+	out.MatchExpressions = append(out.MatchExpressions, metav1.LabelSelectorRequirement{
+		Key:      corev1.LabelMetadataName,
+		Operator: metav1.LabelSelectorOpNotIn,
+		Values:   sets.List(namespaces),
+	})
+	return out
+}
+
 func (c *controller) reconcileResourceValidatingWebhookConfiguration(ctx context.Context) error {
 	if c.autoUpdateWebhooks {
 		return c.reconcileValidatingWebhookConfiguration(ctx, c.autoUpdateWebhooks, c.buildResourceValidatingWebhookConfiguration)
@@ -366,6 +398,9 @@ func (c *controller) reconcileValidatingWebhookConfiguration(ctx context.Context
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			_, err := c.vwcClient.Create(ctx, desired, metav1.CreateOptions{})
+			if err == nil {
+				c.metrics.recordWebhookReconcile(ctx, "validating", desired.Name, webhookOperationCreate)
+			}
 			return err
 		}
 		return err
@@ -373,14 +408,22 @@ func (c *controller) reconcileValidatingWebhookConfiguration(ctx context.Context
 	if !autoUpdateWebhooks {
 		return nil
 	}
-	_, err = controllerutils.Update(ctx, observed, c.vwcClient, func(w *admissionregistrationv1.ValidatingWebhookConfiguration) error {
+	updated, err := controllerutils.Update(ctx, observed, c.vwcClient, func(w *admissionregistrationv1.ValidatingWebhookConfiguration) error {
 		w.Labels = desired.Labels
 		w.Annotations = desired.Annotations
 		w.OwnerReferences = desired.OwnerReferences
 		w.Webhooks = desired.Webhooks
 		return nil
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	operation := webhookOperationNone
+	if updated.GetResourceVersion() != observed.GetResourceVersion() {
+		operation = webhookOperationUpdate
+	}
+	c.metrics.recordWebhookReconcile(ctx, "validating", desired.Name, operation)
+	return nil
 }
 
 func (c *controller) reconcileMutatingWebhookConfiguration(ctx context.Context, autoUpdateWebhooks bool, build func(context.Context, config.Configuration, []byte) (*admissionregistrationv1.MutatingWebhookConfiguration, error)) error {
@@ -396,6 +439,9 @@ func (c *controller) reconcileMutatingWebhookConfiguration(ctx context.Context,
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			_, err := c.mwcClient.Create(ctx, desired, metav1.CreateOptions{})
+			if err == nil {
+				c.metrics.recordWebhookReconcile(ctx, "mutating", desired.Name, webhookOperationCreate)
+			}
 			return err
 		}
 		return err
@@ -403,14 +449,22 @@ func (c *controller) reconcileMutatingWebhookConfiguration(ctx context.Context,
 	if !autoUpdateWebhooks {
 		return nil
 	}
-	_, err = controllerutils.Update(ctx, observed, c.mwcClient, func(w *admissionregistrationv1.MutatingWebhookConfiguration) error {
+	updated, err := controllerutils.Update(ctx, observed, c.mwcClient, func(w *admissionregistrationv1.MutatingWebhookConfiguration) error {
 		w.Labels = desired.Labels
 		w.Annotations = desired.Annotations
 		w.OwnerReferences = desired.OwnerReferences
 		w.Webhooks = desired.Webhooks
 		return nil
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	operation := webhookOperationNone
+	if updated.GetResourceVersion() != observed.GetResourceVersion() {
+		operation = webhookOperationUpdate
+	}
+	c.metrics.recordWebhookReconcile(ctx, "mutating", desired.Name, operation)
+	return nil
 }
 
 func (c *controller) updatePolicyStatuses(ctx context.Context) error {
@@ -436,6 +490,12 @@ func (c *controller) updatePolicyStatuses(ctx context.Context) error {
 		}
 		status := policy.GetStatus()
 		status.SetReady(ready, message)
+		if pending := c.getPendingRules(policy); len(pending) != 0 {
+			status.SetRulesPending(true, "Rules waiting for the CRDs of their kinds to be installed: "+strings.Join(pending, "; "))
+		} else if status.IsRulesPending() {
+			status.SetRulesPending(false, "All rules are active")
+		}
+		previous := status.Autogen.Rules
 		status.Autogen.Rules = nil
 		rules := autogen.ComputeRules(policy)
 		setRuleCount(rules, status)
@@ -444,6 +504,9 @@ func (c *controller) updatePolicyStatuses(ctx context.Context) error {
 				status.Autogen.Rules = append(status.Autogen.Rules, rule)
 			}
 		}
+		if !datautils.DeepEqual(previous, status.Autogen.Rules) {
+			c.metrics.recordAutogenChange(ctx, policy)
+		}
 		return nil
 	}
 	for _, policy := range policies {
@@ -582,7 +645,7 @@ func (c *controller) buildPolicyValidatingWebhookConfiguration(_ context.Context
 		nil
 }
 
-func (c *controller) buildDefaultResourceMutatingWebhookConfiguration(_ context.Context, cfg config.Configuration, caBundle []byte) (*admissionregistrationv1.MutatingWebhookConfiguration, error) {
+func (c *controller) buildDefaultResourceMutatingWebhookConfiguration(ctx context.Context, cfg config.Configuration, caBundle []byte) (*admissionregistrationv1.MutatingWebhookConfiguration, error) {
 	return &admissionregistrationv1.MutatingWebhookConfiguration{
 			ObjectMeta: objectMeta(config.MutatingWebhookConfigurationName, cfg.GetWebhookAnnotations(), cfg.GetWebhookLabels(), c.buildOwner()...),
 			Webhooks: []admissionregistrationv1.MutatingWebhook{{
@@ -602,6 +665,7 @@ func (c *controller) buildDefaultResourceMutatingWebhookConfiguration(_ context.
 				FailurePolicy:           &ignore,
 				SideEffects:             &noneOnDryRun,
 				AdmissionReviewVersions: []string{"v1"},
+				NamespaceSelector:       c.namespaceSelector(ctx, cfg, nil),
 				TimeoutSeconds:          &c.defaultTimeout,
 				ReinvocationPolicy:      &ifNeeded,
 			}, {
@@ -621,6 +685,7 @@ func (c *controller) buildDefaultResourceMutatingWebhookConfiguration(_ context.
 				FailurePolicy:           &fail,
 				SideEffects:             &noneOnDryRun,
 				AdmissionReviewVersions: []string{"v1"},
+				NamespaceSelector:       c.namespaceSelector(ctx, cfg, nil),
 				TimeoutSeconds:          &c.defaultTimeout,
 				ReinvocationPolicy:      &ifNeeded,
 			}},
@@ -634,75 +699,85 @@ func (c *controller) buildResourceMutatingWebhookConfiguration(ctx context.Conte
 		Webhooks:   []admissionregistrationv1.MutatingWebhook{},
 	}
 	if c.watchdogCheck() {
-		ignore := newWebhook(c.defaultTimeout, ignore)
-		fail := newWebhook(c.defaultTimeout, fail)
 		policies, err := c.getAllPolicies()
 		if err != nil {
 			return nil, err
 		}
 		c.recordPolicyState(config.MutatingWebhookConfigurationName, policies...)
-		for _, p := range policies {
-			if p.AdmissionProcessingEnabled() {
-				spec := p.GetSpec()
-				if spec.HasMutateStandard() || spec.HasVerifyImages() {
-					if spec.GetFailurePolicy(ctx) == kyvernov1.Ignore {
-						c.mergeWebhook(ignore, p, false)
-					} else {
-						c.mergeWebhook(fail, p, false)
-					}
-				}
-			}
-		}
-		webhookCfg := config.WebhookConfig{}
-		webhookCfgs := cfg.GetWebhooks()
-		if len(webhookCfgs) > 0 {
-			webhookCfg = webhookCfgs[0]
-		}
-		if !ignore.isEmpty() {
-			timeout := capTimeout(ignore.maxWebhookTimeout)
-			result.Webhooks = append(
-				result.Webhooks,
-				admissionregistrationv1.MutatingWebhook{
-					Name:                    config.MutatingWebhookName + "-ignore",
-					ClientConfig:            c.clientConfig(caBundle, config.MutatingWebhookServicePath+"/ignore"),
-					Rules:                   ignore.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update),
-					FailurePolicy:           &ignore.failurePolicy,
-					SideEffects:             &noneOnDryRun,
-					AdmissionReviewVersions: []string{"v1"},
-					NamespaceSelector:       webhookCfg.NamespaceSelector,
-					ObjectSelector:          webhookCfg.ObjectSelector,
-					TimeoutSeconds:          &timeout,
-					ReinvocationPolicy:      &ifNeeded,
-					MatchConditions:         cfg.GetMatchConditions(),
-				},
-			)
-		}
-		if !fail.isEmpty() {
-			timeout := capTimeout(fail.maxWebhookTimeout)
-			result.Webhooks = append(
-				result.Webhooks,
-				admissionregistrationv1.MutatingWebhook{
-					Name:                    config.MutatingWebhookName + "-fail",
-					ClientConfig:            c.clientConfig(caBundle, config.MutatingWebhookServicePath+"/fail"),
-					Rules:                   fail.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update),
-					FailurePolicy:           &fail.failurePolicy,
-					SideEffects:             &noneOnDryRun,
-					AdmissionReviewVersions: []string{"v1"},
-					NamespaceSelector:       webhookCfg.NamespaceSelector,
-					ObjectSelector:          webhookCfg.ObjectSelector,
-					TimeoutSeconds:          &timeout,
-					ReinvocationPolicy:      &ifNeeded,
-					MatchConditions:         cfg.GetMatchConditions(),
-				},
-			)
-		}
+		result.Webhooks = c.buildResourceMutatingWebhooks(ctx, cfg, caBundle, policies...)
 	} else {
 		c.recordPolicyState(config.MutatingWebhookConfigurationName)
 	}
 	return &result, nil
 }
 
-func (c *controller) buildDefaultResourceValidatingWebhookConfiguration(_ context.Context, cfg config.Configuration, caBundle []byte) (*admissionregistrationv1.ValidatingWebhookConfiguration, error) {
+// buildResourceMutatingWebhooks builds the resource mutating webhooks for the given policies
+func (c *controller) buildResourceMutatingWebhooks(ctx context.Context, cfg config.Configuration, caBundle []byte, policies ...kyvernov1.PolicyInterface) []admissionregistrationv1.MutatingWebhook {
+	webhooks := []admissionregistrationv1.MutatingWebhook{}
+	ignore := newWebhook(c.defaultTimeout, ignore)
+	fail := newWebhook(c.defaultTimeout, fail)
+	for _, p := range policies {
+		if p.AdmissionProcessingEnabled() {
+			spec := p.GetSpec()
+			if spec.HasMutateStandard() || spec.HasVerifyImages() {
+				dst := fail
+				if spec.GetFailurePolicy(ctx) == kyvernov1.Ignore {
+					dst = ignore
+				}
+				c.mergeWebhook(dst, p, false)
+				if spec.GetReinvocationPolicy() == admissionregistrationv1.IfNeededReinvocationPolicy {
+					dst.reinvocationPolicy = admissionregistrationv1.IfNeededReinvocationPolicy
+				}
+			}
+		}
+	}
+	webhookCfg := config.WebhookConfig{}
+	webhookCfgs := cfg.GetWebhooks()
+	if len(webhookCfgs) > 0 {
+		webhookCfg = webhookCfgs[0]
+	}
+	if !ignore.isEmpty() {
+		timeout := capTimeout(ignore.maxWebhookTimeout)
+		webhooks = append(
+			webhooks,
+			admissionregistrationv1.MutatingWebhook{
+				Name:                    config.MutatingWebhookName + "-ignore",
+				ClientConfig:            c.clientConfig(caBundle, config.MutatingWebhookServicePath+"/ignore"),
+				Rules:                   ignore.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update),
+				FailurePolicy:           &ignore.failurePolicy,
+				SideEffects:             &noneOnDryRun,
+				AdmissionReviewVersions: []string{"v1"},
+				NamespaceSelector:       c.namespaceSelector(ctx, cfg, webhookCfg.NamespaceSelector),
+				ObjectSelector:          webhookCfg.ObjectSelector,
+				TimeoutSeconds:          &timeout,
+				ReinvocationPolicy:      &ignore.reinvocationPolicy,
+				MatchConditions:         cfg.GetMatchConditions(),
+			},
+		)
+	}
+	if !fail.isEmpty() {
+		timeout := capTimeout(fail.maxWebhookTimeout)
+		webhooks = append(
+			webhooks,
+			admissionregistrationv1.MutatingWebhook{
+				Name:                    config.MutatingWebhookName + "-fail",
+				ClientConfig:            c.clientConfig(caBundle, config.MutatingWebhookServicePath+"/fail"),
+				Rules:                   fail.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update),
+				FailurePolicy:           &fail.failurePolicy,
+				SideEffects:             &noneOnDryRun,
+				AdmissionReviewVersions: []string{"v1"},
+				NamespaceSelector:       c.namespaceSelector(ctx, cfg, webhookCfg.NamespaceSelector),
+				ObjectSelector:          webhookCfg.ObjectSelector,
+				TimeoutSeconds:          &timeout,
+				ReinvocationPolicy:      &fail.reinvocationPolicy,
+				MatchConditions:         cfg.GetMatchConditions(),
+			},
+		)
+	}
+	return webhooks
+}
+
+func (c *controller) buildDefaultResourceValidatingWebhookConfiguration(ctx context.Context, cfg config.Configuration, caBundle []byte) (*admissionregistrationv1.ValidatingWebhookConfiguration, error) {
 	sideEffects := &none
 	if c.admissionReports {
 		sideEffects = &noneOnDryRun
@@ -728,6 +803,7 @@ func (c *controller) buildDefaultResourceValidatingWebhookConfiguration(_ contex
 				FailurePolicy:           &ignore,
 				SideEffects:             sideEffects,
 				AdmissionReviewVersions: []string{"v1"},
+				NamespaceSelector:       c.namespaceSelector(ctx, cfg, nil),
 				TimeoutSeconds:          &c.defaultTimeout,
 			}, {
 				Name:         config.ValidatingWebhookName + "-fail",
@@ -748,6 +824,7 @@ func (c *controller) buildDefaultResourceValidatingWebhookConfiguration(_ contex
 				FailurePolicy:           &fail,
 				SideEffects:             sideEffects,
 				AdmissionReviewVersions: []string{"v1"},
+				NamespaceSelector:       c.namespaceSelector(ctx, cfg, nil),
 				TimeoutSeconds:          &c.defaultTimeout,
 			}},
 		},
@@ -760,76 +837,83 @@ func (c *controller) buildResourceValidatingWebhookConfiguration(ctx context.Con
 		Webhooks:   []admissionregistrationv1.ValidatingWebhook{},
 	}
 	if c.watchdogCheck() {
-		ignore := newWebhook(c.defaultTimeout, ignore)
-		fail := newWebhook(c.defaultTimeout, fail)
 		policies, err := c.getAllPolicies()
 		if err != nil {
 			return nil, err
 		}
 		c.recordPolicyState(config.ValidatingWebhookConfigurationName, policies...)
-		for _, p := range policies {
-			if p.AdmissionProcessingEnabled() {
-				spec := p.GetSpec()
-				if spec.HasValidate() || spec.HasGenerate() || spec.HasMutateExisting() || spec.HasVerifyImageChecks() || spec.HasVerifyManifests() {
-					if spec.GetFailurePolicy(ctx) == kyvernov1.Ignore {
-						c.mergeWebhook(ignore, p, true)
-					} else {
-						c.mergeWebhook(fail, p, true)
-					}
-				}
-			}
-		}
-		webhookCfg := config.WebhookConfig{}
-		webhookCfgs := cfg.GetWebhooks()
-		if len(webhookCfgs) > 0 {
-			webhookCfg = webhookCfgs[0]
-		}
-		sideEffects := &none
-		if c.admissionReports {
-			sideEffects = &noneOnDryRun
-		}
-		if !ignore.isEmpty() {
-			timeout := capTimeout(ignore.maxWebhookTimeout)
-			result.Webhooks = append(
-				result.Webhooks,
-				admissionregistrationv1.ValidatingWebhook{
-					Name:                    config.ValidatingWebhookName + "-ignore",
-					ClientConfig:            c.clientConfig(caBundle, config.ValidatingWebhookServicePath+"/ignore"),
-					Rules:                   ignore.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete, admissionregistrationv1.Connect),
-					FailurePolicy:           &ignore.failurePolicy,
-					SideEffects:             sideEffects,
-					AdmissionReviewVersions: []string{"v1"},
-					NamespaceSelector:       webhookCfg.NamespaceSelector,
-					ObjectSelector:          webhookCfg.ObjectSelector,
-					TimeoutSeconds:          &timeout,
-					MatchConditions:         cfg.GetMatchConditions(),
-				},
-			)
-		}
-		if !fail.isEmpty() {
-			timeout := capTimeout(fail.maxWebhookTimeout)
-			result.Webhooks = append(
-				result.Webhooks,
-				admissionregistrationv1.ValidatingWebhook{
-					Name:                    config.ValidatingWebhookName + "-fail",
-					ClientConfig:            c.clientConfig(caBundle, config.ValidatingWebhookServicePath+"/fail"),
-					Rules:                   fail.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete, admissionregistrationv1.Connect),
-					FailurePolicy:           &fail.failurePolicy,
-					SideEffects:             sideEffects,
-					AdmissionReviewVersions: []string{"v1"},
-					NamespaceSelector:       webhookCfg.NamespaceSelector,
-					ObjectSelector:          webhookCfg.ObjectSelector,
-					TimeoutSeconds:          &timeout,
-					MatchConditions:         cfg.GetMatchConditions(),
-				},
-			)
-		}
+		result.Webhooks = c.buildResourceValidatingWebhooks(ctx, cfg, caBundle, policies...)
 	} else {
 		c.recordPolicyState(config.MutatingWebhookConfigurationName)
 	}
 	return &result, nil
 }
 
+// buildResourceValidatingWebhooks builds the resource validating webhooks for the given policies
+func (c *controller) buildResourceValidatingWebhooks(ctx context.Context, cfg config.Configuration, caBundle []byte, policies ...kyvernov1.PolicyInterface) []admissionregistrationv1.ValidatingWebhook {
+	webhooks := []admissionregistrationv1.ValidatingWebhook{}
+	ignore := newWebhook(c.defaultTimeout, ignore)
+	fail := newWebhook(c.defaultTimeout, fail)
+	for _, p := range policies {
+		if p.AdmissionProcessingEnabled() {
+			spec := p.GetSpec()
+			if spec.HasValidate() || spec.HasGenerate() || spec.HasMutateExisting() || spec.HasVerifyImageChecks() || spec.HasVerifyManifests() {
+				if spec.GetFailurePolicy(ctx) == kyvernov1.Ignore {
+					c.mergeWebhook(ignore, p, true)
+				} else {
+					c.mergeWebhook(fail, p, true)
+				}
+			}
+		}
+	}
+	webhookCfg := config.WebhookConfig{}
+	webhookCfgs := cfg.GetWebhooks()
+	if len(webhookCfgs) > 0 {
+		webhookCfg = webhookCfgs[0]
+	}
+	sideEffects := &none
+	if c.admissionReports {
+		sideEffects = &noneOnDryRun
+	}
+	if !ignore.isEmpty() {
+		timeout := capTimeout(ignore.maxWebhookTimeout)
+		webhooks = append(
+			webhooks,
+			admissionregistrationv1.ValidatingWebhook{
+				Name:                    config.ValidatingWebhookName + "-ignore",
+				ClientConfig:            c.clientConfig(caBundle, config.ValidatingWebhookServicePath+"/ignore"),
+				Rules:                   ignore.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete, admissionregistrationv1.Connect),
+				FailurePolicy:           &ignore.failurePolicy,
+				SideEffects:             sideEffects,
+				AdmissionReviewVersions: []string{"v1"},
+				NamespaceSelector:       c.namespaceSelector(ctx, cfg, webhookCfg.NamespaceSelector),
+				ObjectSelector:          webhookCfg.ObjectSelector,
+				TimeoutSeconds:          &timeout,
+				MatchConditions:         cfg.GetMatchConditions(),
+			},
+		)
+	}
+	if !fail.isEmpty() {
+		timeout := capTimeout(fail.maxWebhookTimeout)
+		webhooks = append(
+			webhooks,
+			admissionregistrationv1.ValidatingWebhook{
+				Name:                    config.ValidatingWebhookName + "-fail",
+				ClientConfig:            c.clientConfig(caBundle, config.ValidatingWebhookServicePath+"/fail"),
+				Rules:                   fail.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete, admissionregistrationv1.Connect),
+				FailurePolicy:           &fail.failurePolicy,
+				SideEffects:             sideEffects,
+				AdmissionReviewVersions: []string{"v1"},
+				NamespaceSelector:       c.namespaceSelector(ctx, cfg, webhookCfg.NamespaceSelector),
+				ObjectSelector:          webhookCfg.ObjectSelector,
+				TimeoutSeconds:          &timeout,
+				MatchConditions:         cfg.GetMatchConditions(),
+			},
+		)
+	}
+	return webhooks
+}
+
 func (c *controller) getAllPolicies() ([]kyvernov1.PolicyInterface, error) {
 	var policies []kyvernov1.PolicyInterface
 	if cpols, err := c.cpolLister.List(labels.Everything()); err != nil {
@@ -855,26 +939,29 @@ func (c *controller) getLease() (*coordinationv1.Lease, error) {
 
 // mergeWebhook merges the matching kinds of the policy to webhook.rule
 func (c *controller) mergeWebhook(dst *webhook, policy kyvernov1.PolicyInterface, updateValidate bool) {
-	var matchedGVK []string
+	matchedGVK := map[string]sets.Set[admissionregistrationv1.OperationType]{}
+	matchedResources := map[string]sets.Set[admissionregistrationv1.OperationType]{}
 	for _, rule := range autogen.ComputeRules(policy) {
 		// matching kinds in generate policies need to be added to both webhook
 		if rule.HasGenerate() {
-			matchedGVK = append(matchedGVK, rule.MatchResources.GetKinds()...)
+			mergeMatchedKinds(matchedGVK, rule.MatchResources)
+			mergeMatchedResources(matchedResources, rule.MatchResources)
 			if rule.Generation.ResourceSpec.Kind != "" {
-				matchedGVK = append(matchedGVK, rule.Generation.ResourceSpec.Kind)
+				mergeKinds(matchedGVK, nil, rule.Generation.ResourceSpec.Kind)
 			}
-			matchedGVK = append(matchedGVK, rule.Generation.CloneList.Kinds...)
+			mergeKinds(matchedGVK, nil, rule.Generation.CloneList.Kinds...)
 			continue
 		}
 		if (updateValidate && rule.HasValidate() || rule.HasVerifyImageChecks()) ||
 			(updateValidate && rule.HasMutateExisting()) ||
 			(!updateValidate && rule.HasMutateStandard()) ||
 			(!updateValidate && rule.HasVerifyImages()) || (!updateValidate && rule.HasVerifyManifests()) {
-			matchedGVK = append(matchedGVK, rule.MatchResources.GetKinds()...)
+			mergeMatchedKinds(matchedGVK, rule.MatchResources)
+			mergeMatchedResources(matchedResources, rule.MatchResources)
 		}
 	}
-	var gvrsList []schema.GroupVersionResource
-	for _, gvk := range matchedGVK {
+	for gvk, ops := range matchedGVK {
+		var gvrsList []schema.GroupVersionResource
 		// NOTE: webhook stores GVR in its rules while policy stores GVK in its rules definition
 		group, version, kind, subresource := kubeutils.ParseKindSelector(gvk)
 		// if kind is `*` no need to lookup resources
@@ -894,9 +981,19 @@ func (c *controller) mergeWebhook(dst *webhook, policy kyvernov1.PolicyInterface
 				gvrsList = append(gvrsList, gvrs.GroupVersion.WithResource(gvrs.ResourceSubresource()))
 			}
 		}
+		for _, gvr := range gvrsList {
+			dst.set(gvr, sets.List(ops)...)
+		}
 	}
-	for _, gvr := range gvrsList {
-		dst.set(gvr)
+	for resource, ops := range matchedResources {
+		gvrss, err := dclient.FindResourcesByName(c.discoveryClient, resource)
+		if err != nil {
+			logger.Error(err, "unable to find resource", "resource", resource)
+			continue
+		}
+		for gvrs := range gvrss {
+			dst.set(gvrs.GroupVersion.WithResource(gvrs.ResourceSubresource()), sets.List(ops)...)
+		}
 	}
 	spec := policy.GetSpec()
 	if spec.WebhookTimeoutSeconds != nil {
@@ -906,6 +1003,33 @@ func (c *controller) mergeWebhook(dst *webhook, policy kyvernov1.PolicyInterface
 	}
 }
 
+// getPendingRules returns the rules of the policy matching kinds unknown to discovery, e.g. because their CRDs
+// are not installed yet. They are activated by the periodic reconciliation once the kinds become available.
+func (c *controller) getPendingRules(policy kyvernov1.PolicyInterface) []string {
+	var pending []string
+	for _, rule := range autogen.ComputeRules(policy) {
+		var missing []string
+		for _, gvk := range rule.MatchResources.GetKinds() {
+			group, version, kind, subresource := kubeutils.ParseKindSelector(gvk)
+			if kind == "*" {
+				continue
+			}
+			if _, err := c.discoveryClient.FindResources(group, version, kind, subresource); dclient.IsResourceNotFound(err) {
+				missing = append(missing, gvk)
+			}
+		}
+		for _, resource := range rule.MatchResources.GetResources() {
+			if _, err := dclient.FindResourcesByName(c.discoveryClient, resource); dclient.IsResourceNotFound(err) {
+				missing = append(missing, resource)
+			}
+		}
+		if len(missing) != 0 {
+			pending = append(pending, fmt.Sprintf("%s (%s)", rule.Name, strings.Join(missing, ", ")))
+		}
+	}
+	return pending
+}
+
 func (c *controller) buildOwner() []metav1.OwnerReference {
 	selector := labels.SelectorFromSet(labels.Set(map[string]string{
 		kyverno.LabelAppComponent: "kyverno",
@@ -0,0 +1,68 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/toggle"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type protectManagedResources struct {
+	toggle.Toggles
+	enabled bool
+}
+
+func (t protectManagedResources) ProtectManagedResources() bool { return t.enabled }
+
+func withProtectManagedResources(enabled bool) context.Context {
+	return toggle.NewContext(context.TODO(), protectManagedResources{toggle.FromContext(context.TODO()), enabled})
+}
+
+func Test_controller_namespaceSelector(t *testing.T) {
+	cfg := config.NewDefaultConfiguration(false)
+	assert.NilError(t, cfg.Load(&corev1.ConfigMap{
+		Data: map[string]string{
+			"webhookExcludedNamespaces": "kube-node-lease",
+		},
+	}))
+	notIn := func(namespaces ...string) metav1.LabelSelectorRequirement {
+		return metav1.LabelSelectorRequirement{Key: corev1.LabelMetadataName, Operator: metav1.LabelSelectorOpNotIn, Values: namespaces}
+	}
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "foo"}}
+	tests := []struct {
+		name    string
+		protect bool
+		flag    []string
+		want    *metav1.LabelSelector
+	}{{
+		name: "default",
+		flag: []string{"kube-system"},
+		want: &metav1.LabelSelector{
+			MatchLabels:      map[string]string{"team": "foo"},
+			MatchExpressions: []metav1.LabelSelectorRequirement{notIn("kube-node-lease", "kube-system", config.KyvernoNamespace())},
+		},
+	}, {
+		name:    "protect managed resources",
+		protect: true,
+		flag:    []string{"kube-system"},
+		want: &metav1.LabelSelector{
+			MatchLabels:      map[string]string{"team": "foo"},
+			MatchExpressions: []metav1.LabelSelectorRequirement{notIn("kube-node-lease", "kube-system")},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := controller{excludedNamespaces: tt.flag}
+			assert.DeepEqual(t, c.namespaceSelector(withProtectManagedResources(tt.protect), cfg, selector), tt.want)
+			// the configured selector is not modified
+			assert.DeepEqual(t, selector, &metav1.LabelSelector{MatchLabels: map[string]string{"team": "foo"}})
+		})
+	}
+	// without excluded namespaces the configured selector is kept as is
+	c := controller{}
+	assert.Assert(t, c.namespaceSelector(withProtectManagedResources(true), config.NewDefaultConfiguration(false), nil) == nil)
+}
@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"context"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/metric"
+)
+
+const (
+	webhookOperationCreate = "create"
+	webhookOperationUpdate = "update"
+	webhookOperationNone   = "none"
+)
+
+type controllerMetrics struct {
+	webhookReconcileTotal sdkmetric.Int64Counter
+	autogenRuleTotal      sdkmetric.Int64Counter
+}
+
+func newControllerMetrics() controllerMetrics {
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	webhookReconcileTotal, err := meter.Int64Counter(
+		"kyverno_webhook_configuration_reconcile",
+		sdkmetric.WithDescription("can be used to track number of webhook configuration reconciliations and whether they created or updated the configuration"))
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_webhook_configuration_reconcile")
+	}
+	autogenRuleTotal, err := meter.Int64Counter(
+		"kyverno_policy_autogen_changes",
+		sdkmetric.WithDescription("can be used to track number of times the auto-generated rules of a policy changed"))
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_autogen_changes")
+	}
+	return controllerMetrics{
+		webhookReconcileTotal: webhookReconcileTotal,
+		autogenRuleTotal:      autogenRuleTotal,
+	}
+}
+
+func (m controllerMetrics) recordWebhookReconcile(ctx context.Context, webhookType, webhookName, operation string) {
+	if m.webhookReconcileTotal != nil {
+		m.webhookReconcileTotal.Add(
+			ctx,
+			1,
+			sdkmetric.WithAttributes(
+				attribute.String("webhook_type", webhookType),
+				attribute.String("webhook_name", webhookName),
+				attribute.String("operation", operation),
+			),
+		)
+	}
+}
+
+func (m controllerMetrics) recordAutogenChange(ctx context.Context, policy kyvernov1.PolicyInterface) {
+	if m.autogenRuleTotal != nil {
+		namespace := policy.GetNamespace()
+		if namespace == "" {
+			namespace = "-"
+		}
+		m.autogenRuleTotal.Add(
+			ctx,
+			1,
+			sdkmetric.WithAttributes(
+				attribute.String("policy_namespace", namespace),
+				attribute.String("policy_name", policy.GetName()),
+			),
+		)
+	}
+}
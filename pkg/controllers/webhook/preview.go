@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"context"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Preview builds the resource webhook configurations the controller would generate for the given policies.
+// Configurations are not owned and have no CA bundle, they are meant to be reviewed, not applied.
+func Preview(
+	ctx context.Context,
+	discoveryClient dclient.IDiscovery,
+	configuration config.Configuration,
+	defaultTimeout int32,
+	servicePort int32,
+	admissionReports bool,
+	policies ...kyvernov1.PolicyInterface,
+) (*admissionregistrationv1.MutatingWebhookConfiguration, *admissionregistrationv1.ValidatingWebhookConfiguration) {
+	c := controller{
+		discoveryClient:  discoveryClient,
+		defaultTimeout:   defaultTimeout,
+		servicePort:      servicePort,
+		admissionReports: admissionReports,
+		configuration:    configuration,
+	}
+	typeMeta := func(kind string) metav1.TypeMeta {
+		return metav1.TypeMeta{APIVersion: admissionregistrationv1.SchemeGroupVersion.String(), Kind: kind}
+	}
+	mwc := &admissionregistrationv1.MutatingWebhookConfiguration{
+		TypeMeta:   typeMeta("MutatingWebhookConfiguration"),
+		ObjectMeta: objectMeta(config.MutatingWebhookConfigurationName, configuration.GetWebhookAnnotations(), configuration.GetWebhookLabels()),
+		Webhooks:   c.buildResourceMutatingWebhooks(ctx, configuration, nil, policies...),
+	}
+	vwc := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		TypeMeta:   typeMeta("ValidatingWebhookConfiguration"),
+		ObjectMeta: objectMeta(config.ValidatingWebhookConfigurationName, configuration.GetWebhookAnnotations(), configuration.GetWebhookLabels()),
+		Webhooks:   c.buildResourceValidatingWebhooks(ctx, configuration, nil, policies...),
+	}
+	return mwc, vwc
+}
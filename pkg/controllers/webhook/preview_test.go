@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_Preview(t *testing.T) {
+	validate := `{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "require-labels"},
+		"spec": {
+			"failurePolicy": "Ignore",
+			"webhookTimeoutSeconds": 15,
+			"rules": [{
+				"name": "check-team",
+				"match": {"any": [{"resources": {"kinds": ["Pod"], "operations": ["CREATE"]}}]},
+				"validate": {"pattern": {"metadata": {"labels": {"team": "?*"}}}}
+			}]
+		}
+	}`
+	mutate := `{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "add-labels"},
+		"spec": {
+			"rules": [{
+				"name": "add-team",
+				"match": {"any": [{"resources": {"kinds": ["ConfigMap"]}}]},
+				"mutate": {"patchStrategicMerge": {"metadata": {"labels": {"team": "foo"}}}}
+			}]
+		}
+	}`
+	var policies []kyverno.PolicyInterface
+	for _, raw := range []string{validate, mutate} {
+		var policy kyverno.ClusterPolicy
+		assert.NilError(t, json.Unmarshal([]byte(raw), &policy))
+		policies = append(policies, &policy)
+	}
+	cfg := config.NewDefaultConfiguration(false)
+	assert.NilError(t, cfg.Load(&corev1.ConfigMap{
+		Data: map[string]string{
+			"webhooks": `[{"namespaceSelector":{"matchLabels":{"team":"foo"}}}]`,
+		},
+	}))
+	discoveryClient := dclient.NewFakeDiscoveryClient([]schema.GroupVersionResource{{Version: "v1", Resource: "pods"}})
+	mwc, vwc := Preview(context.TODO(), discoveryClient, cfg, DefaultWebhookTimeout, 443, false, policies...)
+	assert.Equal(t, mwc.Kind, "MutatingWebhookConfiguration")
+	assert.Equal(t, mwc.Name, config.MutatingWebhookConfigurationName)
+	assert.Equal(t, len(mwc.Webhooks), 1)
+	assert.Equal(t, mwc.Webhooks[0].Name, config.MutatingWebhookName+"-fail")
+	assert.Equal(t, *mwc.Webhooks[0].FailurePolicy, admissionregistrationv1.Fail)
+	assert.Equal(t, *mwc.Webhooks[0].TimeoutSeconds, int32(DefaultWebhookTimeout))
+	assert.DeepEqual(t, mwc.Webhooks[0].Rules, []admissionregistrationv1.RuleWithOperations{{
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{""},
+			APIVersions: []string{"v1"},
+			Resources:   []string{"configmaps"},
+		},
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+	}})
+	assert.Equal(t, vwc.Kind, "ValidatingWebhookConfiguration")
+	assert.Equal(t, len(vwc.Webhooks), 1)
+	assert.Equal(t, vwc.Webhooks[0].Name, config.ValidatingWebhookName+"-ignore")
+	assert.Equal(t, *vwc.Webhooks[0].FailurePolicy, admissionregistrationv1.Ignore)
+	assert.Equal(t, *vwc.Webhooks[0].TimeoutSeconds, int32(15))
+	assert.DeepEqual(t, vwc.Webhooks[0].NamespaceSelector, &metav1.LabelSelector{
+		MatchLabels: map[string]string{"team": "foo"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      corev1.LabelMetadataName,
+			Operator: metav1.LabelSelectorOpNotIn,
+			Values:   []string{config.KyvernoNamespace()},
+		}},
+	})
+	assert.DeepEqual(t, vwc.Webhooks[0].Rules, []admissionregistrationv1.RuleWithOperations{{
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{""},
+			APIVersions: []string{"v1"},
+			Resources:   []string{"pods", "pods/ephemeralcontainers"},
+		},
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+	}, {
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{"apps"},
+			APIVersions: []string{"v1"},
+			Resources:   []string{"daemonsets", "deployments", "statefulsets"},
+		},
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+	}})
+}
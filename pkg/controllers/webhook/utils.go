@@ -2,6 +2,7 @@ package webhook
 
 import (
 	"cmp"
+	"fmt"
 	"slices"
 	"strings"
 
@@ -17,34 +18,59 @@ import (
 // webhook is the instance that aggregates the GVK of existing policies
 // based on kind, failurePolicy and webhookTimeout
 type webhook struct {
-	maxWebhookTimeout int32
-	failurePolicy     admissionregistrationv1.FailurePolicyType
-	rules             map[schema.GroupVersion]sets.Set[string]
+	maxWebhookTimeout  int32
+	failurePolicy      admissionregistrationv1.FailurePolicyType
+	reinvocationPolicy admissionregistrationv1.ReinvocationPolicyType
+	// rules stores the operations matched for every resource, admissionregistrationv1.OperationAll
+	// means the resource is matched regardless of the operation
+	rules map[schema.GroupVersion]map[string]sets.Set[admissionregistrationv1.OperationType]
 }
 
 func newWebhook(timeout int32, failurePolicy admissionregistrationv1.FailurePolicyType) *webhook {
 	return &webhook{
-		maxWebhookTimeout: timeout,
-		failurePolicy:     failurePolicy,
-		rules:             map[schema.GroupVersion]sets.Set[string]{},
+		maxWebhookTimeout:  timeout,
+		failurePolicy:      failurePolicy,
+		reinvocationPolicy: admissionregistrationv1.NeverReinvocationPolicy,
+		rules:              map[schema.GroupVersion]map[string]sets.Set[admissionregistrationv1.OperationType]{},
 	}
 }
 
+// buildRulesWithOperations builds the webhook rules, ops are the operations supported by the webhook,
+// resources matched on specific operations are restricted to these operations
 func (wh *webhook) buildRulesWithOperations(ops ...admissionregistrationv1.OperationType) []admissionregistrationv1.RuleWithOperations {
 	var rules []admissionregistrationv1.RuleWithOperations
 	for gv, resources := range wh.rules {
 		// if we have pods, we add pods/ephemeralcontainers by default
-		if (gv.Group == "" || gv.Group == "*") && (gv.Version == "v1" || gv.Version == "*") && (resources.Has("pods") || resources.Has("*")) {
-			resources.Insert("pods/ephemeralcontainers")
+		if (gv.Group == "" || gv.Group == "*") && (gv.Version == "v1" || gv.Version == "*") {
+			for _, resource := range []string{"pods", "*"} {
+				if operations, ok := resources[resource]; ok {
+					wh.set(gv.WithResource("pods/ephemeralcontainers"), sets.List(operations)...)
+				}
+			}
+		}
+		// group resources sharing the same operations in a single rule
+		grouped := map[string][]string{}
+		operations := map[string][]admissionregistrationv1.OperationType{}
+		for resource, matched := range resources {
+			effective := effectiveOperations(matched, ops)
+			if len(effective) == 0 {
+				continue
+			}
+			key := fmt.Sprint(effective)
+			grouped[key] = append(grouped[key], resource)
+			operations[key] = effective
+		}
+		for key, resources := range grouped {
+			slices.Sort(resources)
+			rules = append(rules, admissionregistrationv1.RuleWithOperations{
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{gv.Group},
+					APIVersions: []string{gv.Version},
+					Resources:   resources,
+				},
+				Operations: operations[key],
+			})
 		}
-		rules = append(rules, admissionregistrationv1.RuleWithOperations{
-			Rule: admissionregistrationv1.Rule{
-				APIGroups:   []string{gv.Group},
-				APIVersions: []string{gv.Version},
-				Resources:   sets.List(resources),
-			},
-			Operations: ops,
-		})
 	}
 	less := func(a []string, b []string) (int, bool) {
 		if x := cmp.Compare(len(a), len(b)); x != 0 {
@@ -67,18 +93,82 @@ func (wh *webhook) buildRulesWithOperations(ops ...admissionregistrationv1.Opera
 		if x, match := less(a.Resources, b.Resources); match {
 			return x
 		}
-		return 0
+		return cmp.Compare(fmt.Sprint(a.Operations), fmt.Sprint(b.Operations))
 	})
 	return rules
 }
 
-func (wh *webhook) set(gvrs schema.GroupVersionResource) {
+// effectiveOperations restricts the matched operations to the operations supported by the webhook,
+// the order of the supported operations is preserved
+func effectiveOperations(matched sets.Set[admissionregistrationv1.OperationType], supported []admissionregistrationv1.OperationType) []admissionregistrationv1.OperationType {
+	if matched.Has(admissionregistrationv1.OperationAll) {
+		return supported
+	}
+	var effective []admissionregistrationv1.OperationType
+	for _, op := range supported {
+		if matched.Has(op) {
+			effective = append(effective, op)
+		}
+	}
+	return effective
+}
+
+// set records the resource in the webhook rules, the resource is matched on all operations if none is given
+func (wh *webhook) set(gvrs schema.GroupVersionResource, ops ...admissionregistrationv1.OperationType) {
+	if len(ops) == 0 {
+		ops = []admissionregistrationv1.OperationType{admissionregistrationv1.OperationAll}
+	}
 	gv := gvrs.GroupVersion()
 	resources := wh.rules[gv]
 	if resources == nil {
-		wh.rules[gv] = sets.New(gvrs.Resource)
+		resources = map[string]sets.Set[admissionregistrationv1.OperationType]{}
+		wh.rules[gv] = resources
+	}
+	if operations := resources[gvrs.Resource]; operations == nil {
+		resources[gvrs.Resource] = sets.New(ops...)
 	} else {
-		resources.Insert(gvrs.Resource)
+		operations.Insert(ops...)
+	}
+}
+
+// mergeMatchedKinds merges the kinds of the match block with the operations they are matched on,
+// every resource filter can specify its own operations and the union is computed per kind
+func mergeMatchedKinds(dst map[string]sets.Set[admissionregistrationv1.OperationType], match kyvernov1.MatchResources) {
+	for _, filter := range match.Any {
+		mergeKinds(dst, filter.Operations, filter.Kinds...)
+	}
+	for _, filter := range match.All {
+		mergeKinds(dst, filter.Operations, filter.Kinds...)
+	}
+	mergeKinds(dst, match.Operations, match.Kinds...)
+}
+
+// mergeMatchedResources merges the resources of the match block with the operations they are matched on
+func mergeMatchedResources(dst map[string]sets.Set[admissionregistrationv1.OperationType], match kyvernov1.MatchResources) {
+	for _, filter := range match.Any {
+		mergeKinds(dst, filter.Operations, filter.Resources...)
+	}
+	for _, filter := range match.All {
+		mergeKinds(dst, filter.Operations, filter.Resources...)
+	}
+	mergeKinds(dst, match.Operations, match.Resources...)
+}
+
+// mergeKinds merges the kinds with the given operations, kinds are matched on all operations if none is given
+func mergeKinds(dst map[string]sets.Set[admissionregistrationv1.OperationType], ops []kyvernov1.AdmissionOperation, kinds ...string) {
+	operations := []admissionregistrationv1.OperationType{admissionregistrationv1.OperationAll}
+	if len(ops) != 0 {
+		operations = nil
+		for _, op := range ops {
+			operations = append(operations, admissionregistrationv1.OperationType(op))
+		}
+	}
+	for _, kind := range kinds {
+		if existing := dst[kind]; existing == nil {
+			dst[kind] = sets.New(operations...)
+		} else {
+			existing.Insert(operations...)
+		}
 	}
 }
 
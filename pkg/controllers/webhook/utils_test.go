@@ -6,9 +6,11 @@ import (
 
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"gotest.tools/assert"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 func Test_webhook_isEmpty(t *testing.T) {
@@ -159,3 +161,86 @@ func Test_RuleCount(t *testing.T) {
 	assert.Equal(t, status.RuleCount.Mutate, 1)
 	assert.Equal(t, status.RuleCount.VerifyImages, 2)
 }
+
+func Test_mergeMatchedKinds(t *testing.T) {
+	match := kyverno.MatchResources{
+		Any: kyverno.ResourceFilters{{
+			ResourceDescription: kyverno.ResourceDescription{
+				Kinds:      []string{"Pod"},
+				Operations: []kyverno.AdmissionOperation{kyverno.Create},
+			},
+		}, {
+			ResourceDescription: kyverno.ResourceDescription{
+				Kinds:      []string{"Deployment", "Pod"},
+				Operations: []kyverno.AdmissionOperation{kyverno.Update},
+			},
+		}, {
+			ResourceDescription: kyverno.ResourceDescription{
+				Kinds: []string{"ConfigMap"},
+			},
+		}},
+	}
+	kinds := map[string]sets.Set[admissionregistrationv1.OperationType]{}
+	mergeMatchedKinds(kinds, match)
+	assert.DeepEqual(t, kinds, map[string]sets.Set[admissionregistrationv1.OperationType]{
+		"Pod":        sets.New(admissionregistrationv1.Create, admissionregistrationv1.Update),
+		"Deployment": sets.New(admissionregistrationv1.Update),
+		"ConfigMap":  sets.New(admissionregistrationv1.OperationAll),
+	})
+}
+
+func Test_webhook_buildRulesWithOperations(t *testing.T) {
+	wh := newWebhook(DefaultWebhookTimeout, admissionregistrationv1.Ignore)
+	wh.set(schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"}, admissionregistrationv1.Create)
+	wh.set(schema.GroupVersionResource{Group: "", Version: "v1", Resource: "configmaps"})
+	wh.set(schema.GroupVersionResource{Group: "", Version: "v1", Resource: "secrets"}, admissionregistrationv1.Delete)
+	wh.set(schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, admissionregistrationv1.Update)
+	wh.set(schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, admissionregistrationv1.Create)
+	rules := wh.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update)
+	assert.DeepEqual(t, rules, []admissionregistrationv1.RuleWithOperations{{
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{""},
+			APIVersions: []string{"v1"},
+			Resources:   []string{"configmaps"},
+		},
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+	}, {
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{""},
+			APIVersions: []string{"v1"},
+			Resources:   []string{"pods", "pods/ephemeralcontainers"},
+		},
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+	}, {
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{"apps"},
+			APIVersions: []string{"v1"},
+			Resources:   []string{"deployments"},
+		},
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+	}})
+}
+
+func Test_getPendingRules(t *testing.T) {
+	c := controller{discoveryClient: dclient.NewFakeDiscoveryClient(nil)}
+	cpol := &kyverno.ClusterPolicy{
+		Spec: kyverno.Spec{
+			Rules: []kyverno.Rule{{
+				Name:           "configmaps",
+				MatchResources: kyverno.MatchResources{Any: kyverno.ResourceFilters{{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"ConfigMap"}}}}},
+			}, {
+				Name:           "tenants",
+				MatchResources: kyverno.MatchResources{Any: kyverno.ResourceFilters{{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"ConfigMap", "example.com/v1/Tenant"}}}}},
+			}, {
+				Name:           "all",
+				MatchResources: kyverno.MatchResources{Any: kyverno.ResourceFilters{{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"*"}}}}},
+			}},
+		},
+	}
+	assert.DeepEqual(t, c.getPendingRules(cpol), []string{"tenants (example.com/v1/Tenant)"})
+	status := cpol.GetStatus()
+	status.SetRulesPending(true, "tenants (example.com/v1/Tenant)")
+	assert.Assert(t, status.IsRulesPending())
+	status.SetRulesPending(false, "All rules are active")
+	assert.Assert(t, !status.IsRulesPending())
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/in-toto/in-toto-golang/in_toto"
@@ -91,7 +92,7 @@ func (v *cosignVerifier) VerifySignature(ctx context.Context, opts images.Option
 		}
 	}
 
-	return &images.Response{Digest: digest}, nil
+	return &images.Response{Digest: digest, SignedAt: signingTime(signatures)}, nil
 }
 
 func buildCosignOptions(ctx context.Context, opts images.Options) (*cosign.CheckOpts, error) {
@@ -308,7 +309,23 @@ func (v *cosignVerifier) FetchAttestations(ctx context.Context, opts images.Opti
 		return nil, err
 	}
 
-	return &images.Response{Digest: digest, Statements: inTotoStatements}, nil
+	return &images.Response{Digest: digest, Statements: inTotoStatements, SignedAt: signingTime(signatures)}, nil
+}
+
+// signingTime returns the most recent time at which the signatures were integrated in the transparency log,
+// the time is unknown when the signatures don't come with a verified bundle
+func signingTime(signatures []oci.Signature) time.Time {
+	var signedAt time.Time
+	for _, sig := range signatures {
+		bundle, err := sig.Bundle()
+		if err != nil || bundle == nil {
+			continue
+		}
+		if t := time.Unix(bundle.Payload.IntegratedTime, 0); t.After(signedAt) {
+			signedAt = t
+		}
+	}
+	return signedAt
 }
 
 func matchType(sig oci.Signature, expectedType string) (bool, string, error) {
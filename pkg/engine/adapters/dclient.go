@@ -11,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 type dclientAdapter struct {
@@ -69,6 +70,10 @@ func (a *dclientAdapter) IsNamespaced(group, version, kind string) (bool, error)
 	return false, nil
 }
 
+func (a *dclientAdapter) GetGVRFromGVK(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+	return a.client.Discovery().GetGVRFromGVK(gvk)
+}
+
 func (a *dclientAdapter) CanI(ctx context.Context, kind, namespace, verb, subresource, user string) (bool, string, error) {
 	canI := auth.NewCanI(a.client.Discovery(), a.client.GetKubeClient().AuthorizationV1().SubjectAccessReviews(), kind, namespace, verb, subresource, user)
 	ok, reason, err := canI.RunAccessCheck(ctx)
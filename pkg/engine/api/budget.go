@@ -0,0 +1,26 @@
+package api
+
+import (
+	"context"
+	"time"
+)
+
+type budgetKey struct{}
+
+// WithLatencyBudget returns a context carrying the time after which audit rules are no longer evaluated
+func WithLatencyBudget(ctx context.Context, deadline time.Time) context.Context {
+	if ctx == nil {
+		return nil
+	}
+	return context.WithValue(ctx, budgetKey{}, deadline)
+}
+
+// LatencyBudgetExceeded returns true if the context carries a latency budget that is exhausted
+func LatencyBudgetExceeded(ctx context.Context, now time.Time) bool {
+	if ctx != nil {
+		if deadline, ok := ctx.Value(budgetKey{}).(time.Time); ok {
+			return now.After(deadline)
+		}
+	}
+	return false
+}
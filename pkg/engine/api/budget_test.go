@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLatencyBudgetExceeded(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{{
+		name: "nil",
+		ctx:  nil,
+		want: false,
+	}, {
+		name: "no budget",
+		ctx:  context.TODO(),
+		want: false,
+	}, {
+		name: "budget left",
+		ctx:  WithLatencyBudget(context.TODO(), now.Add(time.Second)),
+		want: false,
+	}, {
+		name: "budget exceeded",
+		ctx:  WithLatencyBudget(context.TODO(), now.Add(-time.Second)),
+		want: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LatencyBudgetExceeded(tt.ctx, now); got != tt.want {
+				t.Errorf("LatencyBudgetExceeded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
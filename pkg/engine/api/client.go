@@ -9,6 +9,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 type Resource struct {
@@ -33,6 +34,7 @@ type ResourceClient interface {
 	GetResources(ctx context.Context, group, version, kind, subresource, namespace, name string) ([]Resource, error)
 	GetNamespace(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.Namespace, error)
 	IsNamespaced(group, version, kind string) (bool, error)
+	GetGVRFromGVK(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error)
 }
 
 type Client interface {
@@ -223,3 +223,22 @@ func (er EngineResponse) GetValidationFailureAction() kyvernov1.ValidationFailur
 	}
 	return spec.ValidationFailureAction
 }
+
+// GetRuleValidationFailureAction returns the failure action applying to a rule response,
+// the failure action declared by the rule takes precedence over the policy one.
+func (er EngineResponse) GetRuleValidationFailureAction(rule RuleResponse) kyvernov1.ValidationFailureAction {
+	if action := rule.FailureAction(); action != "" {
+		return action
+	}
+	return er.GetValidationFailureAction()
+}
+
+// IsEnforceFailed checks if a rule failed with the Enforce failure action
+func (er EngineResponse) IsEnforceFailed() bool {
+	for _, rule := range er.PolicyResponse.Rules {
+		if rule.Status() == RuleStatusFail && er.GetRuleValidationFailureAction(rule).Enforce() {
+			return true
+		}
+	}
+	return false
+}
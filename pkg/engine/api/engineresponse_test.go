@@ -1012,6 +1012,60 @@ func TestEngineResponse_GetValidationFailureAction(t *testing.T) {
 // 	}
 // }
 
+func TestEngineResponse_IsEnforceFailed(t *testing.T) {
+	audit := NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+		Spec: kyvernov1.Spec{
+			ValidationFailureAction: kyvernov1.Audit,
+		},
+	})
+	enforce := NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+		Spec: kyvernov1.Spec{
+			ValidationFailureAction: kyvernov1.Enforce,
+		},
+	})
+	tests := []struct {
+		name   string
+		policy GenericPolicy
+		rules  []RuleResponse
+		want   bool
+	}{{
+		name:   "audit policy",
+		policy: audit,
+		rules:  []RuleResponse{*RuleFail("a", Validation, "")},
+		want:   false,
+	}, {
+		name:   "enforce policy",
+		policy: enforce,
+		rules:  []RuleResponse{*RulePass("a", Validation, ""), *RuleFail("b", Validation, "")},
+		want:   true,
+	}, {
+		name:   "enforce rule in audit policy",
+		policy: audit,
+		rules:  []RuleResponse{*RuleFail("a", Validation, "").WithFailureAction(kyvernov1.Enforce)},
+		want:   true,
+	}, {
+		name:   "audit rule in enforce policy",
+		policy: enforce,
+		rules:  []RuleResponse{*RuleFail("a", Validation, "").WithFailureAction(kyvernov1.Audit)},
+		want:   false,
+	}, {
+		name:   "passing enforce rule in audit policy",
+		policy: audit,
+		rules:  []RuleResponse{*RulePass("a", Validation, "").WithFailureAction(kyvernov1.Enforce), *RuleFail("b", Validation, "")},
+		want:   false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			er := EngineResponse{
+				PolicyResponse: PolicyResponse{Rules: tt.rules},
+			}.WithPolicy(tt.policy)
+			if got := er.IsEnforceFailed(); got != tt.want {
+				t.Errorf("EngineResponse.IsEnforceFailed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestEngineResponse_GetResourceSpec(t *testing.T) {
 	namespacedResource := unstructured.Unstructured{}
 	namespacedResource.SetKind("Something")
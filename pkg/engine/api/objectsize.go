@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+)
+
+type objectSizeKey struct{}
+
+// WithObjectSizeExceeded returns a context flagging the admission object as too large to be evaluated by audit rules
+func WithObjectSizeExceeded(ctx context.Context) context.Context {
+	if ctx == nil {
+		return nil
+	}
+	return context.WithValue(ctx, objectSizeKey{}, true)
+}
+
+// ObjectSizeExceeded returns true if the context flags the admission object as too large
+func ObjectSizeExceeded(ctx context.Context) bool {
+	if ctx != nil {
+		if exceeded, ok := ctx.Value(objectSizeKey{}).(bool); ok {
+			return exceeded
+		}
+	}
+	return false
+}
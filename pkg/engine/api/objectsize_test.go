@@ -0,0 +1,33 @@
+package api
+
+import (
+	"context"
+	"testing"
+)
+
+func TestObjectSizeExceeded(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{{
+		name: "nil",
+		ctx:  nil,
+		want: false,
+	}, {
+		name: "not flagged",
+		ctx:  context.TODO(),
+		want: false,
+	}, {
+		name: "flagged",
+		ctx:  WithObjectSizeExceeded(context.TODO()),
+		want: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ObjectSizeExceeded(tt.ctx); got != tt.want {
+				t.Errorf("ObjectSizeExceeded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
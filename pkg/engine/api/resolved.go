@@ -0,0 +1,30 @@
+package api
+
+import (
+	"context"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+)
+
+// ResolvedRuleRecorder receives the rules evaluated by the engine after variable substitution
+type ResolvedRuleRecorder func(policy kyvernov1.PolicyInterface, rule string, resolved map[string]interface{})
+
+type resolvedRuleRecorderKey struct{}
+
+// WithResolvedRuleRecorder returns a context carrying a recorder for the rules after variable substitution
+func WithResolvedRuleRecorder(ctx context.Context, recorder ResolvedRuleRecorder) context.Context {
+	if ctx == nil {
+		return nil
+	}
+	return context.WithValue(ctx, resolvedRuleRecorderKey{}, recorder)
+}
+
+// ResolvedRuleRecorderFrom returns the resolved rule recorder carried by the context, if any
+func ResolvedRuleRecorderFrom(ctx context.Context) ResolvedRuleRecorder {
+	if ctx != nil {
+		if recorder, ok := ctx.Value(resolvedRuleRecorderKey{}).(ResolvedRuleRecorder); ok {
+			return recorder
+		}
+	}
+	return nil
+}
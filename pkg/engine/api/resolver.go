@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -21,6 +23,33 @@ type NamespacedResourceResolver[T any] interface {
 // ConfigmapResolver is an abstract interface used to resolve configmaps
 type ConfigmapResolver = NamespacedResourceResolver[*corev1.ConfigMap]
 
+// SecretResolver is an abstract interface used to resolve secret references at runtime
+type SecretResolver interface {
+	// Resolve is used to resolve the value of a secret reference,
+	// namespace is the namespace of the referencing policy and is empty for cluster-wide policies
+	Resolve(
+		ctx context.Context,
+		namespace string,
+		ref string,
+	) (string, error)
+}
+
+// CloudMetadataResolver is an abstract interface used to resolve the metadata of the cloud provider Kyverno runs on
+type CloudMetadataResolver interface {
+	// Get is used to resolve the metadata document of a cloud provider
+	Get(
+		ctx context.Context,
+		provider kyvernov1.CloudProvider,
+	) (map[string]interface{}, error)
+}
+
+// ImageAllowListResolver is an abstract interface used to resolve image allow lists
+// Any implementation might exist, cache based, client based etc...
+type ImageAllowListResolver interface {
+	// Get is used to resolve an image allow list given its name
+	Get(name string) (*kyvernov2alpha1.ImageAllowList, error)
+}
+
 // namespacedResourceResolverChain represents a chain of NamespacedResourceResolver
 type namespacedResourceResolverChain[T any] []NamespacedResourceResolver[T]
 
@@ -0,0 +1,52 @@
+package api
+
+// ResultReason is a standardized enumeration of rule execution results, combining the rule status and,
+// for skipped rules, the category of the skip reason
+type ResultReason string
+
+const (
+	// ResultReasonPass indicates that the rule passed
+	ResultReasonPass ResultReason = "pass"
+	// ResultReasonFail indicates that the rule failed
+	ResultReasonFail ResultReason = "fail"
+	// ResultReasonWarn indicates that the rule failed but the policy is not scored
+	ResultReasonWarn ResultReason = "warn"
+	// ResultReasonError indicates that the rule could not be evaluated
+	ResultReasonError ResultReason = "error"
+	// ResultReasonSkipPrecondition indicates that the rule was skipped because its preconditions were not met
+	ResultReasonSkipPrecondition ResultReason = "skip-precondition"
+	// ResultReasonSkipException indicates that the rule was skipped because a policy exception applied
+	ResultReasonSkipException ResultReason = "skip-exception"
+	// ResultReasonSkipFilter indicates that the rule was skipped because the resource or the request was
+	// filtered out, for example when conditional anchors are not satisfied or the admission guards apply
+	ResultReasonSkipFilter ResultReason = "skip-filter"
+	// ResultReasonSkip indicates that the rule was skipped for an unknown reason
+	ResultReasonSkip ResultReason = "skip"
+)
+
+// ResultReasonOf returns the result reason for the given status and skip reason
+func ResultReasonOf(status RuleStatus, skipReason SkipReason) ResultReason {
+	switch status {
+	case RuleStatusPass:
+		return ResultReasonPass
+	case RuleStatusFail:
+		return ResultReasonFail
+	case RuleStatusWarn:
+		return ResultReasonWarn
+	case RuleStatusError:
+		return ResultReasonError
+	case RuleStatusSkip:
+		switch skipReason {
+		case SkipReasonPreconditionsNotMet:
+			return ResultReasonSkipPrecondition
+		case SkipReasonPolicyException:
+			return ResultReasonSkipException
+		case SkipReasonAnchorsNotMet, SkipReasonNoElements, SkipReasonResultUnchanged, SkipReasonBudgetExceeded, SkipReasonObjectTooLarge:
+			return ResultReasonSkipFilter
+		default:
+			return ResultReasonSkip
+		}
+	default:
+		return ResultReasonError
+	}
+}
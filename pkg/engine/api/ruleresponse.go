@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	pssutils "github.com/kyverno/kyverno/pkg/pss/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -10,6 +11,18 @@ import (
 	"k8s.io/pod-security-admission/api"
 )
 
+// RemediationURLProperty is the property holding the remediation url of failed validation rules
+const RemediationURLProperty = "remediationUrl"
+
+// PathProperty is the property holding the path of the resource at which a validation pattern failed
+const PathProperty = "path"
+
+// ImagesProperty is the property listing the images rejected by image allow list rules
+const ImagesProperty = "images"
+
+// BaselineValuesProperty is the property holding the values observed by baseline rules in record mode, as a JSON array
+const BaselineValuesProperty = "baselineValues"
+
 // PodSecurityChecks details about pod securty checks
 type PodSecurityChecks struct {
 	// Level is the pod security level
@@ -44,6 +57,14 @@ type RuleResponse struct {
 	podSecurityChecks *PodSecurityChecks
 	// exception is the exception applied (if any)
 	exception *kyvernov2beta1.PolicyException
+	// skipReason is the reason why the rule was skipped (only if the status is skip)
+	skipReason SkipReason
+	// properties are additional properties declared by the rule for policy reports
+	properties map[string]string
+	// failureAction is the failure action declared by the rule, it overrides the policy failure action (only for validation rules)
+	failureAction kyvernov1.ValidationFailureAction
+	// severity is the severity declared by the rule, it overrides the policy severity (only for validation rules)
+	severity string
 }
 
 func NewRuleResponse(name string, ruleType RuleType, msg string, status RuleStatus) *RuleResponse {
@@ -83,6 +104,31 @@ func (r RuleResponse) WithException(exception *kyvernov2beta1.PolicyException) *
 	return &r
 }
 
+func (r RuleResponse) WithMessage(message string) *RuleResponse {
+	r.message = message
+	return &r
+}
+
+func (r RuleResponse) WithSkipReason(reason SkipReason) *RuleResponse {
+	r.skipReason = reason
+	return &r
+}
+
+func (r RuleResponse) WithProperties(properties map[string]string) *RuleResponse {
+	r.properties = properties
+	return &r
+}
+
+func (r RuleResponse) WithFailureAction(failureAction kyvernov1.ValidationFailureAction) *RuleResponse {
+	r.failureAction = failureAction
+	return &r
+}
+
+func (r RuleResponse) WithSeverity(severity string) *RuleResponse {
+	r.severity = severity
+	return &r
+}
+
 func (r RuleResponse) WithPodSecurityChecks(checks PodSecurityChecks) *RuleResponse {
 	r.podSecurityChecks = &checks
 	return &r
@@ -117,6 +163,37 @@ func (r *RuleResponse) IsException() bool {
 	return r.exception != nil
 }
 
+// SkipReason returns the reason why the rule was skipped, empty if the rule was not skipped or the reason is unknown
+func (r *RuleResponse) SkipReason() SkipReason {
+	if r.status != RuleStatusSkip {
+		return ""
+	}
+	if r.skipReason == "" && r.exception != nil {
+		return SkipReasonPolicyException
+	}
+	return r.skipReason
+}
+
+// ResultReason returns the standardized result reason of the rule execution
+func (r *RuleResponse) ResultReason() ResultReason {
+	return ResultReasonOf(r.status, r.SkipReason())
+}
+
+// Properties returns the additional properties declared by the rule for policy reports
+func (r *RuleResponse) Properties() map[string]string {
+	return r.properties
+}
+
+// FailureAction returns the failure action declared by the rule, empty if the policy failure action applies
+func (r *RuleResponse) FailureAction() kyvernov1.ValidationFailureAction {
+	return r.failureAction
+}
+
+// Severity returns the severity declared by the rule, empty if the policy severity applies
+func (r *RuleResponse) Severity() string {
+	return r.severity
+}
+
 func (r *RuleResponse) PodSecurityChecks() *PodSecurityChecks {
 	return r.podSecurityChecks
 }
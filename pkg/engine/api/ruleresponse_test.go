@@ -2,6 +2,8 @@ package api
 
 import (
 	"testing"
+
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 )
 
 func TestRuleResponse_String(t *testing.T) {
@@ -126,3 +128,85 @@ func TestRuleResponse_HasStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleResponse_SkipReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		response *RuleResponse
+		want     SkipReason
+	}{{
+		name:     "pass",
+		response: RulePass("rule", Validation, "").WithSkipReason(SkipReasonPreconditionsNotMet),
+		want:     "",
+	}, {
+		name:     "skip without reason",
+		response: RuleSkip("rule", Validation, ""),
+		want:     "",
+	}, {
+		name:     "skip with reason",
+		response: RuleSkip("rule", Validation, "").WithSkipReason(SkipReasonPreconditionsNotMet),
+		want:     SkipReasonPreconditionsNotMet,
+	}, {
+		name:     "skip with exception",
+		response: RuleSkip("rule", Validation, "").WithException(&kyvernov2beta1.PolicyException{}),
+		want:     SkipReasonPolicyException,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.response.SkipReason(); got != tt.want {
+				t.Errorf("RuleResponse.SkipReason() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleResponse_ResultReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		response *RuleResponse
+		want     ResultReason
+	}{{
+		name:     "pass",
+		response: RulePass("rule", Validation, ""),
+		want:     ResultReasonPass,
+	}, {
+		name:     "fail",
+		response: RuleFail("rule", Validation, ""),
+		want:     ResultReasonFail,
+	}, {
+		name:     "warn",
+		response: RuleWarn("rule", Validation, ""),
+		want:     ResultReasonWarn,
+	}, {
+		name:     "error",
+		response: RuleError("rule", Validation, "", nil),
+		want:     ResultReasonError,
+	}, {
+		name:     "skip without reason",
+		response: RuleSkip("rule", Validation, ""),
+		want:     ResultReasonSkip,
+	}, {
+		name:     "skip with preconditions",
+		response: RuleSkip("rule", Validation, "").WithSkipReason(SkipReasonPreconditionsNotMet),
+		want:     ResultReasonSkipPrecondition,
+	}, {
+		name:     "skip with exception",
+		response: RuleSkip("rule", Validation, "").WithException(&kyvernov2beta1.PolicyException{}),
+		want:     ResultReasonSkipException,
+	}, {
+		name:     "skip with anchors",
+		response: RuleSkip("rule", Validation, "").WithSkipReason(SkipReasonAnchorsNotMet),
+		want:     ResultReasonSkipFilter,
+	}, {
+		name:     "skip with budget",
+		response: RuleSkip("rule", Validation, "").WithSkipReason(SkipReasonBudgetExceeded),
+		want:     ResultReasonSkipFilter,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.response.ResultReason(); got != tt.want {
+				t.Errorf("RuleResponse.ResultReason() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
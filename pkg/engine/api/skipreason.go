@@ -0,0 +1,21 @@
+package api
+
+// SkipReason represents the reason why a rule was skipped
+type SkipReason string
+
+const (
+	// SkipReasonPreconditionsNotMet indicates that the rule (or mutate target) preconditions were not satisfied
+	SkipReasonPreconditionsNotMet SkipReason = "PreconditionsNotMet"
+	// SkipReasonPolicyException indicates that a policy exception applied to the resource
+	SkipReasonPolicyException SkipReason = "PolicyException"
+	// SkipReasonAnchorsNotMet indicates that the conditional anchors of a pattern were not satisfied
+	SkipReasonAnchorsNotMet SkipReason = "AnchorsNotMet"
+	// SkipReasonNoElements indicates that a foreach declaration didn't produce any element to process
+	SkipReasonNoElements SkipReason = "NoElements"
+	// SkipReasonResultUnchanged indicates that an update didn't change the validation result of the old object
+	SkipReasonResultUnchanged SkipReason = "ResultUnchanged"
+	// SkipReasonBudgetExceeded indicates that an audit rule was not evaluated because the admission latency budget was exhausted
+	SkipReasonBudgetExceeded SkipReason = "BudgetExceeded"
+	// SkipReasonObjectTooLarge indicates that an audit rule was not evaluated because the admission object exceeds the max object size
+	SkipReasonObjectTooLarge SkipReason = "ObjectTooLarge"
+)
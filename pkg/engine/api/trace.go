@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+)
+
+const (
+	// TraceStepMatch records the match and exclude blocks of the rule
+	TraceStepMatch = "match"
+	// TraceStepSkip records a rule skipped before being evaluated
+	TraceStepSkip = "skip"
+	// TraceStepContext records the loading of the rule context entries
+	TraceStepContext = "context"
+	// TraceStepPreconditions records the evaluation of the rule preconditions
+	TraceStepPreconditions = "preconditions"
+	// TraceStepCondition records the evaluation of a single condition
+	TraceStepCondition = "condition"
+	// TraceStepAnchors records the anchors of the validation patterns
+	TraceStepAnchors = "anchors"
+	// TraceStepResult records a rule response
+	TraceStepResult = "result"
+	// TraceStepPatch records the patch applied to the resource
+	TraceStepPatch = "patch"
+)
+
+// TraceStep is a decision taken by the engine while evaluating a rule
+type TraceStep struct {
+	// Name is the name of the step (match, preconditions, result...)
+	Name string `json:"name"`
+	// Result is the outcome of the step
+	Result string `json:"result"`
+	// Message explains the outcome of the step
+	Message string `json:"message,omitempty"`
+	// Values contains the values the decision was taken on
+	Values map[string]interface{} `json:"values,omitempty"`
+	// Steps contains the nested decisions
+	Steps []TraceStep `json:"steps,omitempty"`
+}
+
+// RuleTrace is the decision trace of a rule evaluated by the engine
+type RuleTrace struct {
+	// Policy is the policy key
+	Policy string `json:"policy"`
+	// Rule is the rule name
+	Rule string `json:"rule"`
+	// Type is the rule type
+	Type RuleType `json:"type"`
+	// Resource is the resource path (namespace/kind/name)
+	Resource string `json:"resource"`
+	// Steps contains the decisions in evaluation order
+	Steps []TraceStep `json:"steps"`
+}
+
+// RuleTracer receives the decision trace of every rule evaluated by the engine
+type RuleTracer func(trace RuleTrace)
+
+type ruleTracerKey struct{}
+
+// WithRuleTracer returns a context carrying a tracer for the rules evaluated by the engine
+func WithRuleTracer(ctx context.Context, tracer RuleTracer) context.Context {
+	if ctx == nil {
+		return nil
+	}
+	return context.WithValue(ctx, ruleTracerKey{}, tracer)
+}
+
+// RuleTracerFrom returns the rule tracer carried by the context, if any
+func RuleTracerFrom(ctx context.Context) RuleTracer {
+	if ctx != nil {
+		if tracer, ok := ctx.Value(ruleTracerKey{}).(RuleTracer); ok {
+			return tracer
+		}
+	}
+	return nil
+}
@@ -9,17 +9,27 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/secrets"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
+// maxRedirects is the number of redirects followed by service calls, as done by the default HTTP client
+const maxRedirects = 10
+
 type apiCall struct {
 	logger  logr.Logger
 	jp      jmespath.Interface
@@ -31,6 +41,11 @@ type apiCall struct {
 
 type APICallConfiguration struct {
 	maxAPICallResponseLength int64
+	secretResolver           engineapi.SecretResolver
+	policyNamespace          string
+	allowedHosts             []string
+	rootCAs                  *x509.CertPool
+	timeout                  time.Duration
 }
 
 func NewAPICallConfiguration(maxLen int64) APICallConfiguration {
@@ -39,6 +54,63 @@ func NewAPICallConfiguration(maxLen int64) APICallConfiguration {
 	}
 }
 
+// WithSecretResolver returns a copy of the configuration using the given resolver for secret references in service call headers
+func (c APICallConfiguration) WithSecretResolver(resolver engineapi.SecretResolver) APICallConfiguration {
+	c.secretResolver = resolver
+	return c
+}
+
+// WithPolicyNamespace returns a copy of the configuration resolving secret references on behalf of a policy of the
+// given namespace, the namespace is empty for cluster-wide policies
+func (c APICallConfiguration) WithPolicyNamespace(namespace string) APICallConfiguration {
+	c.policyNamespace = namespace
+	return c
+}
+
+// WithAllowedHosts returns a copy of the configuration restricting service calls to the hosts matching one of the given
+// patterns, patterns support wildcards (e.g. "*.svc" or "cmdb.example.com"). Only in-cluster services can be called when no pattern is given.
+func (c APICallConfiguration) WithAllowedHosts(hosts ...string) APICallConfiguration {
+	c.allowedHosts = hosts
+	return c
+}
+
+// WithCABundle returns a copy of the configuration validating the server certificates of service calls declaring no CA bundle
+// with the system roots and the given PEM encoded CA bundle
+func (c APICallConfiguration) WithCABundle(caBundle []byte) (APICallConfiguration, error) {
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if ok := rootCAs.AppendCertsFromPEM(caBundle); !ok {
+		return c, fmt.Errorf("failed to parse PEM CA bundle")
+	}
+	c.rootCAs = rootCAs
+	return c, nil
+}
+
+// WithTimeout returns a copy of the configuration limiting the duration of service calls, zero means no limit
+func (c APICallConfiguration) WithTimeout(timeout time.Duration) APICallConfiguration {
+	c.timeout = timeout
+	return c
+}
+
+func (c APICallConfiguration) isHostAllowed(host string) bool {
+	if len(c.allowedHosts) == 0 {
+		return isInClusterHost(host)
+	}
+	for _, pattern := range c.allowedHosts {
+		if wildcard.Match(pattern, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// isInClusterHost returns true when the host is the DNS name of a Kubernetes service
+func isInClusterHost(host string) bool {
+	return strings.HasSuffix(host, ".svc") || strings.Contains(host, ".svc.")
+}
+
 type ClientInterface interface {
 	RawAbsPath(ctx context.Context, path string, method string, dataReader io.Reader) ([]byte, error)
 }
@@ -100,6 +172,9 @@ func (a *apiCall) Store(data []byte) ([]byte, error) {
 
 func (a *apiCall) execute(ctx context.Context, call *kyvernov1.APICall) ([]byte, error) {
 	if call.URLPath != "" {
+		if call.PageSize > 0 && call.Method != "POST" {
+			return a.executePaginatedK8sAPICall(ctx, call.URLPath, call.PageSize, call.MaxItems)
+		}
 		return a.executeK8sAPICall(ctx, call.URLPath, call.Method, call.Data)
 	}
 
@@ -114,18 +189,91 @@ func (a *apiCall) executeK8sAPICall(ctx context.Context, path string, method kyv
 
 	jsonData, err := a.client.RawAbsPath(ctx, path, string(method), requestData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to %v resource with raw url\n: %s: %v", method, path, err)
+		return nil, fmt.Errorf("failed to %v resource with raw url\n: %s: %w", method, path, err)
 	}
 
 	a.logger.V(4).Info("executed APICall", "name", a.entry.Name, "path", path, "method", method, "len", len(jsonData))
 	return jsonData, nil
 }
 
+// listPage is a page of a list returned by the Kubernetes API server, items are kept raw to limit allocations
+type listPage struct {
+	APIVersion string            `json:"apiVersion,omitempty"`
+	Kind       string            `json:"kind,omitempty"`
+	Metadata   listMeta          `json:"metadata"`
+	Items      []json.RawMessage `json:"items"`
+}
+
+type listMeta struct {
+	Continue        string `json:"continue,omitempty"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// executePaginatedK8sAPICall retrieves a list in chunks of pageSize items and merges the items of all pages
+func (a *apiCall) executePaginatedK8sAPICall(ctx context.Context, path string, pageSize int64, maxItems int64) ([]byte, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse url path %s: %w", path, err)
+	}
+	var list listPage
+	var size int64
+	for pages := 0; ; pages++ {
+		query := u.Query()
+		query.Set("limit", strconv.FormatInt(pageSize, 10))
+		if list.Metadata.Continue != "" {
+			query.Set("continue", list.Metadata.Continue)
+		}
+		u.RawQuery = query.Encode()
+		jsonData, err := a.client.RawAbsPath(ctx, u.String(), "GET", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to GET resource with raw url\n: %s: %w", u.String(), err)
+		}
+		var page listPage
+		if err := json.Unmarshal(jsonData, &page); err != nil {
+			return nil, fmt.Errorf("failed to decode list page for APICall %s: %w", a.entry.Name, err)
+		}
+		if pages == 0 && page.Items == nil && !strings.HasSuffix(page.Kind, "List") {
+			// not a list, pagination does not apply
+			return jsonData, nil
+		}
+		size += int64(len(jsonData))
+		if a.config.maxAPICallResponseLength != 0 && size > a.config.maxAPICallResponseLength {
+			return nil, fmt.Errorf("response length must be less than max allowed response length of %d.", a.config.maxAPICallResponseLength)
+		}
+		list.APIVersion, list.Kind = page.APIVersion, page.Kind
+		list.Metadata = page.Metadata
+		list.Items = append(list.Items, page.Items...)
+		if maxItems > 0 && int64(len(list.Items)) > maxItems {
+			return nil, fmt.Errorf("list %s for APICall %s contains more than %d items", path, a.entry.Name, maxItems)
+		}
+		if page.Metadata.Continue == "" {
+			break
+		}
+	}
+	if list.Items == nil {
+		list.Items = []json.RawMessage{}
+	}
+	jsonData, err := json.Marshal(list)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode list for APICall %s: %w", a.entry.Name, err)
+	}
+	a.logger.V(4).Info("executed paginated APICall", "name", a.entry.Name, "path", path, "items", len(list.Items), "len", len(jsonData))
+	return jsonData, nil
+}
+
 func (a *apiCall) executeServiceCall(ctx context.Context, apiCall *kyvernov1.APICall) ([]byte, error) {
 	if apiCall.Service == nil {
 		return nil, fmt.Errorf("missing service for APICall %s", a.entry.Name)
 	}
 
+	serviceURL, err := url.Parse(apiCall.Service.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse service URL for APICall %s: %w", a.entry.Name, err)
+	}
+	if !a.config.isHostAllowed(serviceURL.Hostname()) {
+		return nil, fmt.Errorf("host %s of APICall %s is not allowed", serviceURL.Hostname(), a.entry.Name)
+	}
+
 	client, err := a.buildHTTPClient(apiCall.Service)
 	if err != nil {
 		return nil, err
@@ -150,10 +298,10 @@ func (a *apiCall) executeServiceCall(ctx context.Context, apiCall *kyvernov1.API
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		b, err := io.ReadAll(resp.Body)
 		if err == nil {
-			return nil, fmt.Errorf("HTTP %s: %s", resp.Status, string(b))
+			return nil, &statusError{status: resp.Status, code: resp.StatusCode, body: b}
 		}
 
-		return nil, fmt.Errorf("HTTP %s", resp.Status)
+		return nil, &statusError{status: resp.Status, code: resp.StatusCode}
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -176,7 +324,15 @@ func (a *apiCall) buildHTTPRequest(ctx context.Context, apiCall *kyvernov1.APICa
 
 	token := a.getToken()
 	defer func() {
-		if token != "" && req != nil {
+		if err != nil || req == nil {
+			return
+		}
+		if err = a.addHeaders(ctx, req, apiCall.Service.Headers); err != nil {
+			req = nil
+			return
+		}
+		// the service account token is only sent to in-cluster services
+		if token != "" && req.Header.Get("Authorization") == "" && isInClusterHost(req.URL.Hostname()) {
 			req.Header.Add("Authorization", "Bearer "+token)
 		}
 	}()
@@ -199,6 +355,24 @@ func (a *apiCall) buildHTTPRequest(ctx context.Context, apiCall *kyvernov1.APICa
 	return nil, fmt.Errorf("invalid request type %s for APICall %s", apiCall.Method, a.entry.Name)
 }
 
+func (a *apiCall) addHeaders(ctx context.Context, req *http.Request, headers []kyvernov1.HTTPHeader) error {
+	for _, header := range headers {
+		value := header.Value
+		if secrets.IsReference(value) {
+			if a.config.secretResolver == nil {
+				return fmt.Errorf("failed to resolve header %s: secret references are not enabled", header.Key)
+			}
+			resolved, err := a.config.secretResolver.Resolve(ctx, a.config.policyNamespace, value)
+			if err != nil {
+				return fmt.Errorf("failed to resolve header %s: %w", header.Key, err)
+			}
+			value = resolved
+		}
+		req.Header.Add(header.Key, value)
+	}
+	return nil
+}
+
 func (a *apiCall) getToken() string {
 	fileName := "/var/run/secrets/kubernetes.io/serviceaccount/token"
 	b, err := os.ReadFile(fileName)
@@ -211,24 +385,40 @@ func (a *apiCall) getToken() string {
 }
 
 func (a *apiCall) buildHTTPClient(service *kyvernov1.ServiceCall) (*http.Client, error) {
-	if service == nil || service.CABundle == "" {
-		return http.DefaultClient, nil
-	}
-	caCertPool := x509.NewCertPool()
-	if ok := caCertPool.AppendCertsFromPEM([]byte(service.CABundle)); !ok {
-		return nil, fmt.Errorf("failed to parse PEM CA bundle for APICall %s", a.entry.Name)
+	caCertPool := a.config.rootCAs
+	if service != nil && service.CABundle != "" {
+		caCertPool = x509.NewCertPool()
+		if ok := caCertPool.AppendCertsFromPEM([]byte(service.CABundle)); !ok {
+			return nil, fmt.Errorf("failed to parse PEM CA bundle for APICall %s", a.entry.Name)
+		}
 	}
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			RootCAs:    caCertPool,
-			MinVersion: tls.VersionTLS12,
-		},
+	transport := http.DefaultTransport
+	if caCertPool != nil {
+		transport = &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    caCertPool,
+				MinVersion: tls.VersionTLS12,
+			},
+		}
 	}
 	return &http.Client{
-		Transport: tracing.Transport(transport, otelhttp.WithFilter(tracing.RequestFilterIsInSpan)),
+		Transport:     tracing.Transport(transport, otelhttp.WithFilter(tracing.RequestFilterIsInSpan)),
+		Timeout:       a.config.timeout,
+		CheckRedirect: a.checkRedirect,
 	}, nil
 }
 
+// checkRedirect applies the allowed hosts to the redirects followed by service calls
+func (a *apiCall) checkRedirect(req *http.Request, via []*http.Request) error {
+	if !a.config.isHostAllowed(req.URL.Hostname()) {
+		return fmt.Errorf("redirect to host %s of APICall %s is not allowed", req.URL.Hostname(), a.entry.Name)
+	}
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects for APICall %s", maxRedirects, a.entry.Name)
+	}
+	return nil
+}
+
 func (a *apiCall) buildRequestData(data []kyvernov1.RequestData) (io.Reader, error) {
 	dataMap := make(map[string]interface{})
 	for _, d := range data {
@@ -2,11 +2,16 @@ package apicall
 
 import (
 	"context"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -21,12 +26,15 @@ var (
 	jp        = jmespath.New(config.NewDefaultConfiguration(false))
 	apiConfig = APICallConfiguration{
 		maxAPICallResponseLength: 1 * 1000 * 1000,
+		allowedHosts:             []string{"127.0.0.1"},
 	}
 	apiConfigMaxSizeExceed = APICallConfiguration{
 		maxAPICallResponseLength: 10,
+		allowedHosts:             []string{"127.0.0.1"},
 	}
 	apiConfigWithoutSecurityCheck = APICallConfiguration{
 		maxAPICallResponseLength: 0,
+		allowedHosts:             []string{"127.0.0.1"},
 	}
 )
 
@@ -188,3 +196,191 @@ func Test_servicePostRequest(t *testing.T) {
 	expectedResults := `{"images":["https://ghcr.io/tomcat/tomcat:9","https://ghcr.io/vault/vault:v3","https://ghcr.io/busybox/busybox:latest"]}`
 	assert.Equal(t, string(expectedResults)+"\n", string(data))
 }
+
+type fakeSecretResolver map[string]string
+
+func (r fakeSecretResolver) Resolve(_ context.Context, _ string, ref string) (string, error) {
+	if value, ok := r[ref]; ok {
+		return value, nil
+	}
+	return "", fmt.Errorf("secret %s not found", ref)
+}
+
+func Test_serviceRequestHeaders(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/resource", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"authorization":%q,"tenant":%q}`, r.Header.Get("Authorization"), r.Header.Get("X-Tenant"))
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	entry := kyvernov1.ContextEntry{
+		Name: "test",
+		APICall: &kyvernov1.APICall{
+			Method: "GET",
+			Service: &kyvernov1.ServiceCall{
+				URL: s.URL + "/resource",
+				Headers: []kyvernov1.HTTPHeader{
+					{Key: "Authorization", Value: "vault://secret/data/service#token"},
+					{Key: "X-Tenant", Value: "team-a"},
+				},
+			},
+		},
+	}
+
+	ctx := enginecontext.NewContext(jp)
+	call, err := New(logr.Discard(), jp, entry, ctx, nil, apiConfig)
+	assert.NilError(t, err)
+	_, err = call.FetchAndLoad(context.TODO())
+	assert.ErrorContains(t, err, "secret references are not enabled")
+
+	config := apiConfig.WithSecretResolver(fakeSecretResolver{"vault://secret/data/service#token": "Bearer secret"})
+	call, err = New(logr.Discard(), jp, entry, ctx, nil, config)
+	assert.NilError(t, err)
+	data, err := call.FetchAndLoad(context.TODO())
+	assert.NilError(t, err)
+	assert.Equal(t, `{"authorization":"Bearer secret","tenant":"team-a"}`, string(data))
+
+	entry.APICall.Service.Headers[0].Value = "vault://secret/data/unknown#token"
+	call, err = New(logr.Discard(), jp, entry, ctx, nil, config)
+	assert.NilError(t, err)
+	_, err = call.FetchAndLoad(context.TODO())
+	assert.ErrorContains(t, err, "failed to resolve header Authorization")
+}
+
+func Test_serviceCallEgress(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/resource", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ticket":"OPS-1"}`))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, strings.Replace("https://"+r.Host+"/resource", "127.0.0.1", r.URL.Query().Get("host"), 1), http.StatusFound)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Second)
+		w.Write([]byte(`{}`))
+	})
+	s := httptest.NewTLSServer(mux)
+	defer s.Close()
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw})
+
+	fetch := func(path string, config APICallConfiguration) ([]byte, error) {
+		entry := kyvernov1.ContextEntry{
+			Name: "test",
+			APICall: &kyvernov1.APICall{
+				Method:  "GET",
+				Service: &kyvernov1.ServiceCall{URL: s.URL + path},
+			},
+		}
+		call, err := New(logr.Discard(), jp, entry, enginecontext.NewContext(jp), nil, config)
+		assert.NilError(t, err)
+		return call.FetchAndLoad(context.TODO())
+	}
+
+	// the server certificate is not trusted
+	_, err := fetch("/resource", apiConfig)
+	assert.ErrorContains(t, err, "certificate")
+
+	config, err := apiConfig.WithCABundle(caBundle)
+	assert.NilError(t, err)
+	data, err := fetch("/resource", config)
+	assert.NilError(t, err)
+	assert.Equal(t, `{"ticket":"OPS-1"}`, string(data))
+
+	_, err = fetch("/resource", config.WithAllowedHosts("*.example.com"))
+	assert.ErrorContains(t, err, "host 127.0.0.1 of APICall test is not allowed")
+
+	// only in-cluster services are allowed without allowed hosts
+	_, err = fetch("/resource", config.WithAllowedHosts())
+	assert.ErrorContains(t, err, "host 127.0.0.1 of APICall test is not allowed")
+	assert.Assert(t, config.WithAllowedHosts().isHostAllowed("cmdb.default.svc"))
+	assert.Assert(t, config.WithAllowedHosts().isHostAllowed("cmdb.default.svc.cluster.local"))
+	assert.Assert(t, !config.WithAllowedHosts().isHostAllowed("cmdb.example.com"))
+
+	data, err = fetch("/resource", config.WithAllowedHosts("*.example.com", "127.0.0.*"))
+	assert.NilError(t, err)
+	assert.Equal(t, `{"ticket":"OPS-1"}`, string(data))
+
+	// redirects are restricted to the allowed hosts
+	data, err = fetch("/redirect?host=127.0.0.1", config.WithAllowedHosts("127.0.0.*"))
+	assert.NilError(t, err)
+	assert.Equal(t, `{"ticket":"OPS-1"}`, string(data))
+
+	_, err = fetch("/redirect?host=localhost", config.WithAllowedHosts("127.0.0.*"))
+	assert.ErrorContains(t, err, "redirect to host localhost of APICall test is not allowed")
+
+	_, err = fetch("/slow", config.WithTimeout(100*time.Millisecond))
+	assert.ErrorContains(t, err, "Client.Timeout exceeded")
+
+	_, err = apiConfig.WithCABundle([]byte("invalid"))
+	assert.ErrorContains(t, err, "failed to parse PEM CA bundle")
+}
+
+type fakePaginatedClient struct {
+	items []string
+	paths []string
+}
+
+func (c *fakePaginatedClient) RawAbsPath(_ context.Context, path string, method string, _ io.Reader) ([]byte, error) {
+	c.paths = append(c.paths, path)
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := strconv.Atoi(u.Query().Get("limit"))
+	if err != nil {
+		return nil, err
+	}
+	start := 0
+	if token := u.Query().Get("continue"); token != "" {
+		if start, err = strconv.Atoi(token); err != nil {
+			return nil, err
+		}
+	}
+	end := start + limit
+	next := strconv.Itoa(end)
+	if end >= len(c.items) {
+		end, next = len(c.items), ""
+	}
+	var items []string
+	for _, name := range c.items[start:end] {
+		items = append(items, fmt.Sprintf(`{"metadata":{"name":%q}}`, name))
+	}
+	return []byte(fmt.Sprintf(`{"apiVersion":"v1","kind":"IngressList","metadata":{"continue":%q},"items":[%s]}`, next, strings.Join(items, ","))), nil
+}
+
+func Test_paginatedK8sAPICall(t *testing.T) {
+	client := &fakePaginatedClient{items: []string{"a", "b", "c", "d", "e"}}
+	entry := kyvernov1.ContextEntry{
+		Name: "ingresses",
+		APICall: &kyvernov1.APICall{
+			URLPath:  "/apis/networking.k8s.io/v1/ingresses?labelSelector=app%3Dweb",
+			Method:   "GET",
+			JMESPath: "items[].metadata.name",
+			PageSize: 2,
+		},
+	}
+	ctx := enginecontext.NewContext(jp)
+	call, err := New(logr.Discard(), jp, entry, ctx, client, apiConfig)
+	assert.NilError(t, err)
+	data, err := call.FetchAndLoad(context.TODO())
+	assert.NilError(t, err)
+	assert.Equal(t, `["a","b","c","d","e"]`, string(data))
+	assert.DeepEqual(t, []string{
+		"/apis/networking.k8s.io/v1/ingresses?labelSelector=app%3Dweb&limit=2",
+		"/apis/networking.k8s.io/v1/ingresses?continue=2&labelSelector=app%3Dweb&limit=2",
+		"/apis/networking.k8s.io/v1/ingresses?continue=4&labelSelector=app%3Dweb&limit=2",
+	}, client.paths)
+
+	entry.APICall.MaxItems = 4
+	call, err = New(logr.Discard(), jp, entry, ctx, client, apiConfig)
+	assert.NilError(t, err)
+	_, err = call.FetchAndLoad(context.TODO())
+	assert.ErrorContains(t, err, "contains more than 4 items")
+
+	entry.APICall.MaxItems = 0
+	call, err = New(logr.Discard(), jp, entry, ctx, client, apiConfigMaxSizeExceed)
+	assert.NilError(t, err)
+	_, err = call.FetchAndLoad(context.TODO())
+	assert.ErrorContains(t, err, "max allowed response length")
+}
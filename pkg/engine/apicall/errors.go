@@ -0,0 +1,32 @@
+package apicall
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// statusError is returned when a service call responds with a non successful status
+type statusError struct {
+	status string
+	code   int
+	body   []byte
+}
+
+func (e *statusError) Error() string {
+	if e.body != nil {
+		return fmt.Sprintf("HTTP %s: %s", e.status, string(e.body))
+	}
+	return fmt.Sprintf("HTTP %s", e.status)
+}
+
+// IsNotFound returns true if the error is a kubernetes not found error or a service call 404 response
+func IsNotFound(err error) bool {
+	if kerrors.IsNotFound(err) {
+		return true
+	}
+	var statusErr *statusError
+	return errors.As(err, &statusErr) && statusErr.code == http.StatusNotFound
+}
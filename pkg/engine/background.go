@@ -62,7 +62,7 @@ func (e *engine) filterRule(
 	}
 
 	// get policy exceptions that matches both policy and rule name
-	exceptions, err := e.GetPolicyExceptions(policyContext.Policy(), rule.Name)
+	exceptions, _, err := e.GetPolicyExceptions(policyContext.Policy(), rule.Name)
 	if err != nil {
 		logger.Error(err, "failed to get exceptions")
 		return nil
@@ -86,11 +86,12 @@ func (e *engine) filterRule(
 	namespaceLabels := policyContext.NamespaceLabels()
 	policy := policyContext.Policy()
 	gvk, subresource := policyContext.ResourceKind()
+	matchRule := e.resolveResources(rule, policyContext)
 
-	if err := engineutils.MatchesResourceDescription(newResource, rule, admissionInfo, namespaceLabels, policy.GetNamespace(), gvk, subresource, policyContext.Operation()); err != nil {
+	if err := engineutils.MatchesResourceDescription(newResource, matchRule, admissionInfo, namespaceLabels, policy.GetNamespace(), gvk, subresource, policyContext.Operation()); err != nil {
 		if ruleType == engineapi.Generation {
 			// if the oldResource matched, return "false" to delete GR for it
-			if err = engineutils.MatchesResourceDescription(oldResource, rule, admissionInfo, namespaceLabels, policy.GetNamespace(), gvk, subresource, policyContext.Operation()); err == nil {
+			if err = engineutils.MatchesResourceDescription(oldResource, matchRule, admissionInfo, namespaceLabels, policy.GetNamespace(), gvk, subresource, policyContext.Operation()); err == nil {
 				return engineapi.RuleFail(rule.Name, ruleType, "")
 			}
 		}
@@ -108,7 +109,7 @@ func (e *engine) filterRule(
 	}
 
 	// operate on the copy of the conditions, as we perform variable substitution
-	copyConditions, err := engineutils.TransformConditions(rule.GetAnyAllConditions())
+	copyConditions, err := rule.GetConditions()
 	if err != nil {
 		logger.V(4).Info("cannot copy AnyAllConditions", "reason", err.Error())
 		return engineapi.RuleError(rule.Name, ruleType, "failed to convert AnyAllConditions", err)
@@ -138,5 +139,5 @@ func (e *engine) filterRule(
 	}
 
 	logger.V(4).Info("skip rule as preconditions are not met", "rule", rule.Name, "message", msg)
-	return engineapi.RuleSkip(rule.Name, ruleType, "")
+	return engineapi.RuleSkip(rule.Name, ruleType, "").WithSkipReason(engineapi.SkipReasonPreconditionsNotMet)
 }
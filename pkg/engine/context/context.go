@@ -3,7 +3,7 @@ package context
 import (
 	"encoding/csv"
 	"fmt"
-	"regexp"
+	"maps"
 	"strings"
 
 	jsoniter "github.com/json-iterator/go"
@@ -16,19 +16,18 @@ import (
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime"
 )
 
 var (
-	logger       = logging.WithName("context")
-	json         = jsoniter.ConfigCompatibleWithStandardLibrary
-	ReservedKeys = regexp.MustCompile(`request|serviceAccountName|serviceAccountNamespace|element|elementIndex|@|images|image|([a-z_0-9]+\()[^{}]`)
+	logger = logging.WithName("context")
+	json   = jsoniter.ConfigCompatibleWithStandardLibrary
 )
 
 // EvalInterface is used to query and inspect context data
 // TODO: move to contextapi to prevent circular dependencies
 type EvalInterface interface {
 	// Query accepts a JMESPath expression and returns matching data
+	// The returned data is shared with the context and its checkpoints, it must not be modified
 	Query(query string) (interface{}, error)
 
 	// Operation returns the admission operation i.e. "request.operation"
@@ -74,9 +73,15 @@ type Interface interface {
 	// AddServiceAccount merges ServiceAccount types
 	AddServiceAccount(userName string) error
 
+	// AddServiceAccountNamespaceLabels merges the labels of the service account namespace under serviceAccountNamespaceLabels
+	AddServiceAccountNamespaceLabels(labels map[string]string) error
+
 	// AddNamespace merges resource json under request.namespace
 	AddNamespace(namespace string) error
 
+	// AddRuleInfo merges the policy name, rule name and rule type under policy, rule and ruleType
+	AddRuleInfo(policy, rule, ruleType string) error
+
 	// AddElement adds element info to the context
 	AddElement(data interface{}, index, nesting int) error
 
@@ -90,6 +95,12 @@ type Interface interface {
 	// If deferred loading is disabled the loader is immediately executed.
 	AddDeferredLoader(loader DeferredLoader) error
 
+	// AddFallback records that a context entry was set to its default value
+	AddFallback(name string)
+
+	// Fallbacks returns the names of the context entries that were set to their default value
+	Fallbacks() []string
+
 	// ImageInfo returns image infos present in the context
 	ImageInfo() map[string]map[string]apiutils.ImageInfo
 
@@ -120,6 +131,9 @@ type context struct {
 	images             map[string]map[string]apiutils.ImageInfo
 	operation          kyvernov1.AdmissionOperation
 	deferred           DeferredLoaders
+	// fallbacks contains the context entries set to their default value, with the count at each checkpoint
+	fallbacks           []string
+	fallbackCheckpoints []int
 }
 
 // NewContext returns a new context
@@ -273,11 +287,31 @@ func (ctx *context) AddServiceAccount(userName string) error {
 	return nil
 }
 
+// AddServiceAccountNamespaceLabels merges the labels of the service account namespace under serviceAccountNamespaceLabels,
+// an empty map is expected when the request does not come from a service account
+func (ctx *context) AddServiceAccountNamespaceLabels(labels map[string]string) error {
+	data := map[string]interface{}{}
+	for key, value := range labels {
+		data[key] = value
+	}
+	return addToContext(ctx, data, "serviceAccountNamespaceLabels")
+}
+
 // AddNamespace merges resource json under request.namespace
 func (ctx *context) AddNamespace(namespace string) error {
 	return addToContext(ctx, namespace, "request", "namespace")
 }
 
+// AddRuleInfo adds the policy name, rule name and rule type being evaluated at paths policy, rule and ruleType
+func (ctx *context) AddRuleInfo(policy, rule, ruleType string) error {
+	data := map[string]interface{}{
+		"policy":   policy,
+		"rule":     rule,
+		"ruleType": ruleType,
+	}
+	return addToContext(ctx, data)
+}
+
 func (ctx *context) AddElement(data interface{}, index, nesting int) error {
 	nestedElement := fmt.Sprintf("element%d", nesting)
 	nestedElementIndex := fmt.Sprintf("elementIndex%d", nesting)
@@ -366,24 +400,26 @@ func (ctx *context) ImageInfo() map[string]map[string]apiutils.ImageInfo {
 	return ctx.images
 }
 
+func (ctx *context) AddFallback(name string) {
+	ctx.fallbacks = append(ctx.fallbacks, name)
+}
+
+func (ctx *context) Fallbacks() []string {
+	return ctx.fallbacks
+}
+
 // Checkpoint creates a copy of the current internal state and
 // pushes it into a stack of stored states.
 func (ctx *context) Checkpoint() {
 	jsonRawCheckpoint := ctx.copyContext(ctx.jsonRaw)
 	ctx.jsonRawCheckpoints = append(ctx.jsonRawCheckpoints, jsonRawCheckpoint)
+	ctx.fallbackCheckpoints = append(ctx.fallbackCheckpoints, len(ctx.fallbacks))
 }
 
+// copyContext returns a shallow copy of the context data, nested values are shared
+// with the copy as writes to the context are copy-on-write (see mergeMaps)
 func (ctx *context) copyContext(in map[string]interface{}) map[string]interface{} {
-	out := make(map[string]interface{}, len(in))
-	for k, v := range in {
-		if ReservedKeys.MatchString(k) {
-			out[k] = v
-		} else {
-			out[k] = runtime.DeepCopyJSONValue(v)
-		}
-	}
-
-	return out
+	return maps.Clone(in)
 }
 
 // Restore sets the internal state to the last checkpoint, and removes the checkpoint.
@@ -409,9 +445,11 @@ func (ctx *context) resetCheckpoint(restore bool) bool {
 
 	n := len(ctx.jsonRawCheckpoints) - 1
 	jsonRawCheckpoint := ctx.jsonRawCheckpoints[n]
+	ctx.fallbacks = ctx.fallbacks[:ctx.fallbackCheckpoints[n]]
 
 	if restore {
 		ctx.jsonRawCheckpoints = ctx.jsonRawCheckpoints[:n]
+		ctx.fallbackCheckpoints = ctx.fallbackCheckpoints[:n]
 		ctx.jsonRaw = jsonRawCheckpoint
 	} else {
 		ctx.jsonRaw = ctx.copyContext(jsonRawCheckpoint)
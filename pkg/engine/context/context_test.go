@@ -125,6 +125,32 @@ func Test_addResourceAndUserContext(t *testing.T) {
 	}
 }
 
+func TestAddServiceAccountNamespaceLabels(t *testing.T) {
+	ctx := NewContext(jp)
+	assert.NoError(t, ctx.AddServiceAccountNamespaceLabels(nil))
+	result, err := ctx.Query("serviceAccountNamespaceLabels")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{}, result)
+	assert.NoError(t, ctx.AddServiceAccountNamespaceLabels(map[string]string{"platform": "true"}))
+	result, err = ctx.Query("serviceAccountNamespaceLabels.platform")
+	assert.NoError(t, err)
+	assert.Equal(t, "true", result)
+}
+
+func TestAddRuleInfo(t *testing.T) {
+	ctx := NewContext(jp)
+	assert.NoError(t, ctx.AddRuleInfo("require-labels", "check-team", "Validation"))
+	for variable, expected := range map[string]interface{}{
+		"policy":   "require-labels",
+		"rule":     "check-team",
+		"ruleType": "Validation",
+	} {
+		result, err := ctx.Query(variable)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	}
+}
+
 func TestAddVariable(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -227,3 +253,70 @@ func TestAddVariable(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckpointRestore(t *testing.T) {
+	ctx := NewContext(jp)
+	err := ctx.AddResource(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name": "before",
+		},
+	})
+	assert.Nil(t, err)
+	err = ctx.AddContextEntry("entry", []byte(`{"data":{"key":"before"}}`))
+	assert.Nil(t, err)
+
+	ctx.Checkpoint()
+	err = ctx.AddResource(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name": "after",
+		},
+	})
+	assert.Nil(t, err)
+	err = ctx.AddContextEntry("entry", []byte(`{"data":{"key":"after"}}`))
+	assert.Nil(t, err)
+	name, err := ctx.Query("request.object.metadata.name")
+	assert.Nil(t, err)
+	assert.Equal(t, "after", name)
+
+	ctx.Restore()
+	name, err = ctx.Query("request.object.metadata.name")
+	assert.Nil(t, err)
+	assert.Equal(t, "before", name)
+	key, err := ctx.Query("entry.data.key")
+	assert.Nil(t, err)
+	assert.Equal(t, "before", key)
+}
+
+func TestCheckpointRestoreSharedElement(t *testing.T) {
+	ctx := NewContext(jp)
+	err := ctx.AddResource(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "first", "image": "nginx"},
+				map[string]interface{}{"name": "second"},
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	containers, err := ctx.Query("request.object.spec.containers")
+	assert.Nil(t, err)
+	ctx.Checkpoint()
+	// elements are shared with the resource, adding them must not change the resource
+	for i, container := range containers.([]interface{}) {
+		err = ctx.AddElement(container, i, 0)
+		assert.Nil(t, err)
+	}
+	image, err := ctx.Query("element.image")
+	assert.Nil(t, err)
+	assert.Equal(t, "nginx", image)
+	name, err := ctx.Query("request.object.spec.containers[1].name")
+	assert.Nil(t, err)
+	assert.Equal(t, "second", name)
+	_, ok := containers.([]interface{})[1].(map[string]interface{})["image"]
+	assert.False(t, ok)
+
+	ctx.Restore()
+	_, err = ctx.Query("element")
+	assert.NotNil(t, err)
+}
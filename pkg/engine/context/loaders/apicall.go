@@ -20,7 +20,10 @@ type apiLoader struct {
 	jp        jmespath.Interface
 	client    engineapi.RawClient
 	config    apicall.APICallConfiguration
+	breaker   *CircuitBreaker
 	data      []byte
+	// fallback is true when data holds the default value of the context entry
+	fallback bool
 }
 
 func NewAPILoader(
@@ -31,6 +34,7 @@ func NewAPILoader(
 	jp jmespath.Interface,
 	client engineapi.RawClient,
 	apiCallConfig apicall.APICallConfiguration,
+	breaker *CircuitBreaker,
 ) enginecontext.Loader {
 	return &apiLoader{
 		ctx:       ctx,
@@ -40,6 +44,7 @@ func NewAPILoader(
 		jp:        jp,
 		client:    client,
 		config:    apiCallConfig,
+		breaker:   breaker,
 	}
 }
 
@@ -48,14 +53,21 @@ func (a *apiLoader) HasLoaded() bool {
 }
 
 func (a *apiLoader) LoadData() error {
+	if a.fallback {
+		return a.addFallback()
+	}
 	executor, err := apicall.New(a.logger, a.jp, a.entry, a.enginectx, a.client, a.config)
 	if err != nil {
 		return fmt.Errorf("failed to initiaize APICal: %w", err)
 	}
 	if a.data == nil {
-		var err error
-		if a.data, err = executor.Fetch(a.ctx); err != nil {
-			return fmt.Errorf("failed to fetch data for APICall: %w", err)
+		if err := a.fetch(executor.Fetch); err != nil {
+			data, err := fallback(a.logger, a.enginectx, a.entry, fmt.Errorf("failed to fetch data for APICall: %w", err))
+			if err != nil {
+				return err
+			}
+			a.data, a.fallback = data, true
+			return a.addFallback()
 		}
 	}
 	if _, err := executor.Store(a.data); err != nil {
@@ -63,3 +75,27 @@ func (a *apiLoader) LoadData() error {
 	}
 	return nil
 }
+
+func (a *apiLoader) fetch(fetchFn func(context.Context) ([]byte, error)) error {
+	ctx, cancel := withTimeout(a.ctx, a.entry)
+	defer cancel()
+	fetch := func() error {
+		data, err := fetchFn(ctx)
+		if err != nil {
+			return err
+		}
+		a.data = data
+		return nil
+	}
+	if a.entry.APICall.Service == nil {
+		return a.breaker.do(resourceDependency(a.entry.APICall.URLPath), fetch)
+	}
+	return a.breaker.do(serviceDependency(a.entry.APICall.Service), fetch)
+}
+
+func (a *apiLoader) addFallback() error {
+	if err := a.enginectx.AddContextEntry(a.entry.Name, a.data); err != nil {
+		return fmt.Errorf("failed to add default value for APICall: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package loaders
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/engine/apicall"
+)
+
+const (
+	// DefaultBreakerThreshold is the default number of consecutive failures after which calls to a dependency are skipped
+	DefaultBreakerThreshold = 5
+	// DefaultBreakerCooldown is the default duration during which calls to a failing dependency are skipped
+	DefaultBreakerCooldown = 30 * time.Second
+)
+
+var errBreakerOpen = errors.New("circuit breaker is open, the dependency failed too many times")
+
+type breakerState struct {
+	failures  int
+	openUntil time.Time
+}
+
+// CircuitBreaker skips calls to a dependency for a cooldown period once it failed a number of consecutive times,
+// after the cooldown a single call is allowed through and either closes or reopens the breaker.
+// Each dependency has its own state, a nil breaker always calls the dependencies.
+type CircuitBreaker struct {
+	lock      sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+	states    map[string]*breakerState
+}
+
+// NewCircuitBreaker returns a CircuitBreaker to be shared by the context loaders of an engine,
+// the breaker is disabled when the threshold is zero
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return newCircuitBreaker(threshold, cooldown, time.Now)
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration, now func() time.Time) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       now,
+		states:    map[string]*breakerState{},
+	}
+}
+
+func (b *CircuitBreaker) allow(key string) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.threshold <= 0 {
+		return true
+	}
+	state := b.states[key]
+	if state == nil || state.failures < b.threshold {
+		return true
+	}
+	if b.now().Before(state.openUntil) {
+		return false
+	}
+	// half open, let one call through and reopen until it reports back
+	state.openUntil = b.now().Add(b.cooldown)
+	return true
+}
+
+func (b *CircuitBreaker) report(key string, err error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if err == nil || b.threshold <= 0 {
+		delete(b.states, key)
+		return
+	}
+	state := b.states[key]
+	if state == nil {
+		state = &breakerState{}
+		b.states[key] = state
+	}
+	state.failures++
+	if state.failures >= b.threshold {
+		state.openUntil = b.now().Add(b.cooldown)
+	}
+}
+
+// do invokes fn unless the breaker is open for the given dependency
+func (b *CircuitBreaker) do(key string, fn func() error) error {
+	if b == nil {
+		return fn()
+	}
+	if !b.allow(key) {
+		return errBreakerOpen
+	}
+	err := fn()
+	// a not found response means the dependency is available
+	if apicall.IsNotFound(err) {
+		b.report(key, nil)
+	} else {
+		b.report(key, err)
+	}
+	return err
+}
@@ -0,0 +1,75 @@
+package loaders
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_circuitBreaker(t *testing.T) {
+	errFailed := errors.New("failed")
+	start := time.Now()
+	tests := []struct {
+		name    string
+		results []error
+		elapsed time.Duration
+		wantErr error
+		called  bool
+	}{{
+		name:    "no failure",
+		wantErr: nil,
+		called:  true,
+	}, {
+		name:    "below threshold",
+		results: []error{errFailed, errFailed},
+		wantErr: nil,
+		called:  true,
+	}, {
+		name:    "reset on success",
+		results: []error{errFailed, errFailed, nil, errFailed, errFailed},
+		wantErr: nil,
+		called:  true,
+	}, {
+		name:    "open",
+		results: []error{errFailed, errFailed, errFailed},
+		wantErr: errBreakerOpen,
+		called:  false,
+	}, {
+		name:    "half open after cooldown",
+		results: []error{errFailed, errFailed, errFailed},
+		elapsed: time.Minute,
+		wantErr: nil,
+		called:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start
+			breaker := newCircuitBreaker(3, time.Minute, func() time.Time { return now })
+			for _, result := range tt.results {
+				result := result
+				_ = breaker.do("dependency", func() error { return result })
+			}
+			now = now.Add(tt.elapsed)
+			called := false
+			err := breaker.do("dependency", func() error {
+				called = true
+				return nil
+			})
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.called, called)
+			// other dependencies are not affected
+			assert.NoError(t, breaker.do("other", func() error { return nil }))
+		})
+	}
+}
+
+func Test_circuitBreakerDisabled(t *testing.T) {
+	errFailed := errors.New("failed")
+	for _, breaker := range []*CircuitBreaker{NewCircuitBreaker(0, time.Minute), nil} {
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, errFailed, breaker.do("dependency", func() error { return errFailed }))
+		}
+	}
+}
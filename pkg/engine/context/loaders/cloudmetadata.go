@@ -0,0 +1,99 @@
+package loaders
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+)
+
+type cloudMetadataLoader struct {
+	ctx       context.Context //nolint:containedctx
+	logger    logr.Logger
+	entry     kyvernov1.ContextEntry
+	enginectx enginecontext.Interface
+	jp        jmespath.Interface
+	resolver  engineapi.CloudMetadataResolver
+	breaker   *CircuitBreaker
+	data      []byte
+}
+
+func NewCloudMetadataLoader(
+	ctx context.Context,
+	logger logr.Logger,
+	entry kyvernov1.ContextEntry,
+	enginectx enginecontext.Interface,
+	jp jmespath.Interface,
+	resolver engineapi.CloudMetadataResolver,
+	breaker *CircuitBreaker,
+) enginecontext.Loader {
+	return &cloudMetadataLoader{
+		ctx:       ctx,
+		logger:    logger,
+		entry:     entry,
+		enginectx: enginectx,
+		jp:        jp,
+		resolver:  resolver,
+		breaker:   breaker,
+	}
+}
+
+func (cml *cloudMetadataLoader) HasLoaded() bool {
+	return cml.data != nil
+}
+
+func (cml *cloudMetadataLoader) LoadData() error {
+	if cml.data == nil {
+		metadata, err := cml.fetchCloudMetadata()
+		if err != nil {
+			if cml.data, err = fallback(cml.logger, cml.enginectx, cml.entry, err); err != nil {
+				return err
+			}
+		} else if cml.data, err = json.Marshal(metadata); err != nil {
+			return err
+		}
+	}
+	if err := cml.enginectx.AddContextEntry(cml.entry.Name, cml.data); err != nil {
+		return fmt.Errorf("failed to add cloud metadata to context: contextEntry: %v, error: %v", cml.entry, err)
+	}
+	return nil
+}
+
+func (cml *cloudMetadataLoader) fetchCloudMetadata() (interface{}, error) {
+	entry := cml.entry
+	path, err := variables.SubstituteAll(cml.logger, cml.enginectx, entry.CloudMetadata.JMESPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, entry.CloudMetadata.JMESPath, err)
+	}
+	var metadata interface{}
+	err = cml.breaker.do(cloudDependency(entry.CloudMetadata.Provider), func() error {
+		ctx, cancel := withTimeout(cml.ctx, entry)
+		defer cancel()
+		document, err := cml.resolver.Get(ctx, entry.CloudMetadata.Provider)
+		if err != nil {
+			return err
+		}
+		// convert the document to untyped data so that jmespath functions work as expected
+		raw, err := json.Marshal(document)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(raw, &metadata)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s cloud metadata for context entry %s: %w", entry.CloudMetadata.Provider, entry.Name, err)
+	}
+	if path != "" {
+		metadata, err = applyJMESPath(cml.jp, path.(string), metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply JMESPath (%s) results to context entry %s, error: %v", entry.CloudMetadata.JMESPath, entry.Name, err)
+		}
+	}
+	return metadata, nil
+}
@@ -49,7 +49,9 @@ func (cml *configMapLoader) LoadData() error {
 	if cml.data == nil {
 		data, err := cml.fetchConfigMap()
 		if err != nil {
-			return fmt.Errorf("failed to retrieve config map for context entry %s: %v", cml.entry.Name, err)
+			if data, err = fallback(cml.logger, cml.enginectx, cml.entry, err); err != nil {
+				return fmt.Errorf("failed to retrieve config map for context entry %s: %v", cml.entry.Name, err)
+			}
 		}
 
 		cml.data = data
@@ -82,7 +84,7 @@ func (cml *configMapLoader) fetchConfigMap() ([]byte, error) {
 	}
 	obj, err := cml.resolver.Get(cml.ctx, namespace.(string), name.(string))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get configmap %s/%s : %v", namespace, name, err)
+		return nil, fmt.Errorf("failed to get configmap %s/%s : %w", namespace, name, err)
 	}
 	// extract configmap data
 	contextData["data"] = obj.Data
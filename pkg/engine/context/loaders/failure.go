@@ -0,0 +1,77 @@
+package loaders
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+)
+
+// withTimeout returns a context bounded by the context entry timeout, if any
+func withTimeout(ctx context.Context, entry kyvernov1.ContextEntry) (context.Context, context.CancelFunc) {
+	if entry.Timeout == nil {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, entry.Timeout.Duration)
+}
+
+// fallback returns the default value of a context entry that failed to load, the error is returned
+// unless the entry is not required and its data was not found, or the entry failure policy is Ignore
+func fallback(logger logr.Logger, enginectx enginecontext.Interface, entry kyvernov1.ContextEntry, err error) ([]byte, error) {
+	if entry.GetFailurePolicy() != kyvernov1.Ignore && (entry.IsRequired() || !apicall.IsNotFound(err)) {
+		return nil, err
+	}
+	logger.V(2).Info("failed to load context entry, using default value", "name", entry.Name, "reason", err.Error())
+	enginectx.AddFallback(entry.Name)
+	if entry.Default == nil {
+		return []byte("null"), nil
+	}
+	return entry.Default.Raw, nil
+}
+
+// serviceDependency returns the circuit breaker key for an external service
+func serviceDependency(service *kyvernov1.ServiceCall) string {
+	if u, err := url.Parse(service.URL); err == nil && u.Host != "" {
+		return "service:" + u.Host
+	}
+	return "service:" + service.URL
+}
+
+// resourceDependency returns the circuit breaker key for a kubernetes api call, calls are keyed by the
+// group, version and resource of the url path so that a failing api doesn't affect the others
+func resourceDependency(urlPath string) string {
+	path, _, _ := strings.Cut(urlPath, "?")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var gv string
+	switch {
+	case len(segments) >= 3 && segments[0] == "api":
+		gv, segments = segments[1], segments[2:]
+	case len(segments) >= 4 && segments[0] == "apis":
+		gv, segments = segments[1]+"/"+segments[2], segments[3:]
+	default:
+		return "api:" + path
+	}
+	resource := segments[0]
+	if resource == "namespaces" && len(segments) >= 3 {
+		resource = segments[2]
+	}
+	return "api:" + gv + "/" + resource
+}
+
+// registryDependency returns the circuit breaker key for an image registry
+func registryDependency(ref string) string {
+	if parsed, err := name.ParseReference(ref); err == nil {
+		return "registry:" + parsed.Context().RegistryStr()
+	}
+	return "registry:" + ref
+}
+
+// cloudDependency returns the circuit breaker key for a cloud provider metadata service
+func cloudDependency(provider kyvernov1.CloudProvider) string {
+	return "cloud:" + string(provider)
+}
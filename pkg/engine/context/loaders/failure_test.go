@@ -0,0 +1,101 @@
+package loaders
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/stretchr/testify/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_fallback(t *testing.T) {
+	ignore := kyvernov1.Ignore
+	notRequired := false
+	notFound := kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "foo")
+	failed := errors.New("failed")
+	tests := []struct {
+		name      string
+		entry     kyvernov1.ContextEntry
+		err       error
+		want      []byte
+		wantErr   bool
+		fallbacks []string
+	}{{
+		name:    "required",
+		entry:   kyvernov1.ContextEntry{Name: "foo"},
+		err:     notFound,
+		wantErr: true,
+	}, {
+		name:      "not required",
+		entry:     kyvernov1.ContextEntry{Name: "foo", Required: &notRequired},
+		err:       notFound,
+		want:      []byte("null"),
+		fallbacks: []string{"foo"},
+	}, {
+		name:      "not required with default",
+		entry:     kyvernov1.ContextEntry{Name: "foo", Required: &notRequired, Default: &apiextv1.JSON{Raw: []byte(`{"bar":"baz"}`)}},
+		err:       notFound,
+		want:      []byte(`{"bar":"baz"}`),
+		fallbacks: []string{"foo"},
+	}, {
+		name:    "not required with other error",
+		entry:   kyvernov1.ContextEntry{Name: "foo", Required: &notRequired},
+		err:     failed,
+		wantErr: true,
+	}, {
+		name:      "ignore",
+		entry:     kyvernov1.ContextEntry{Name: "foo", FailurePolicy: &ignore},
+		err:       failed,
+		want:      []byte("null"),
+		fallbacks: []string{"foo"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enginectx := enginecontext.NewContext(jmespath.New(nil))
+			got, err := fallback(logr.Discard(), enginectx, tt.entry, tt.err)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.fallbacks, enginectx.Fallbacks())
+		})
+	}
+}
+
+func Test_resourceDependency(t *testing.T) {
+	tests := []struct {
+		urlPath string
+		want    string
+	}{{
+		urlPath: "/api/v1/namespaces",
+		want:    "api:v1/namespaces",
+	}, {
+		urlPath: "/api/v1/namespaces/default",
+		want:    "api:v1/namespaces",
+	}, {
+		urlPath: "/api/v1/namespaces/default/pods?labelSelector=app=foo",
+		want:    "api:v1/pods",
+	}, {
+		urlPath: "/apis/apps/v1/namespaces/{{request.namespace}}/deployments/foo",
+		want:    "api:apps/v1/deployments",
+	}, {
+		urlPath: "/apis/kyverno.io/v1/clusterpolicies",
+		want:    "api:kyverno.io/v1/clusterpolicies",
+	}, {
+		urlPath: "/version",
+		want:    "api:/version",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.urlPath, func(t *testing.T) {
+			assert.Equal(t, tt.want, resourceDependency(tt.urlPath))
+		})
+	}
+}
@@ -20,6 +20,7 @@ type imageDataLoader struct {
 	enginectx      enginecontext.Interface
 	jp             jmespath.Interface
 	rclientFactory engineapi.RegistryClientFactory
+	breaker        *CircuitBreaker
 	data           []byte
 }
 
@@ -30,6 +31,7 @@ func NewImageDataLoader(
 	enginectx enginecontext.Interface,
 	jp jmespath.Interface,
 	rclientFactory engineapi.RegistryClientFactory,
+	breaker *CircuitBreaker,
 ) enginecontext.Loader {
 	return &imageDataLoader{
 		ctx:            ctx,
@@ -38,6 +40,7 @@ func NewImageDataLoader(
 		enginectx:      enginectx,
 		jp:             jp,
 		rclientFactory: rclientFactory,
+		breaker:        breaker,
 	}
 }
 
@@ -53,11 +56,10 @@ func (idl *imageDataLoader) loadImageData() error {
 	if idl.data == nil {
 		imageData, err := idl.fetchImageData()
 		if err != nil {
-			return err
-		}
-
-		idl.data, err = json.Marshal(imageData)
-		if err != nil {
+			if idl.data, err = fallback(idl.logger, idl.enginectx, idl.entry, err); err != nil {
+				return err
+			}
+		} else if idl.data, err = json.Marshal(imageData); err != nil {
 			return err
 		}
 	}
@@ -91,7 +93,13 @@ func (idl *imageDataLoader) fetchImageData() (interface{}, error) {
 		return nil, fmt.Errorf("failed to get registry client %s: %v", entry.Name, err)
 	}
 
-	imageData, err := idl.fetchImageDataMap(client, refString)
+	var imageData interface{}
+	err = idl.breaker.do(registryDependency(refString), func() error {
+		ctx, cancel := withTimeout(idl.ctx, entry)
+		defer cancel()
+		imageData, err = idl.fetchImageDataMap(ctx, client, refString)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -107,8 +115,8 @@ func (idl *imageDataLoader) fetchImageData() (interface{}, error) {
 }
 
 // FetchImageDataMap fetches image information from the remote registry.
-func (idl *imageDataLoader) fetchImageDataMap(client engineapi.ImageDataClient, ref string) (interface{}, error) {
-	desc, err := client.ForRef(context.Background(), ref)
+func (idl *imageDataLoader) fetchImageDataMap(ctx context.Context, client engineapi.ImageDataClient, ref string) (interface{}, error) {
+	desc, err := client.ForRef(ctx, ref)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch image descriptor: %s, error: %v", ref, err)
 	}
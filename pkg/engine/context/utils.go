@@ -1,6 +1,7 @@
 package context
 
 import (
+	"maps"
 	"reflect"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -42,21 +43,17 @@ func clearLeafValue(data map[string]interface{}, tags ...string) bool {
 	if len(tags) == 0 {
 		return false
 	}
-
-	for i := 0; i < len(tags); i++ {
-		k := tags[i]
-		if i == len(tags)-1 {
-			delete(data, k)
-			return true
-		}
-
-		if nextMap, ok := data[k].(map[string]interface{}); ok {
-			data = nextMap
-		} else {
-			return false
-		}
+	k := tags[0]
+	if len(tags) == 1 {
+		delete(data, k)
+		return true
+	}
+	// nested maps are copied before being written, see mergeMaps
+	if nextMap, ok := data[k].(map[string]interface{}); ok {
+		nextMap = maps.Clone(nextMap)
+		data[k] = nextMap
+		return clearLeafValue(nextMap, tags[1:]...)
 	}
-
 	return false
 }
 
@@ -90,11 +87,15 @@ func push(data interface{}, tags ...string) map[string]interface{} {
 }
 
 // mergeMaps merges srcMap entries into destMap
+// nested maps of destMap are copied before being written (copy-on-write) so that
+// they can be shared with the context checkpoints without being deep copied
 func mergeMaps(srcMap, destMap map[string]interface{}) {
 	for k, v := range srcMap {
 		if nextSrcMap, ok := v.(map[string]interface{}); ok {
 			if nextDestMap, ok := destMap[k].(map[string]interface{}); ok {
+				nextDestMap = maps.Clone(nextDestMap)
 				mergeMaps(nextSrcMap, nextDestMap)
+				destMap[k] = nextDestMap
 			} else {
 				destMap[k] = nextSrcMap
 			}
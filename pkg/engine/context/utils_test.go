@@ -139,6 +139,36 @@ func TestClearLeaf(t *testing.T) {
 	result = clearLeafValue(request, "request", "object")
 	assert.True(t, result)
 
+	// nested maps are copied on write, the previous map is left untouched
 	_, exists = r["object"]
+	assert.Equal(t, true, exists)
+
+	r = request["request"].(map[string]interface{})
+	_, exists = r["object"]
+	assert.Equal(t, false, exists)
+}
+
+func TestMergeMapsCopyOnWrite(t *testing.T) {
+	object := map[string]interface{}{
+		"key1": "val1",
+	}
+	dest := map[string]interface{}{
+		"request": map[string]interface{}{
+			"object": object,
+		},
+	}
+	mergeMaps(map[string]interface{}{
+		"request": map[string]interface{}{
+			"object": map[string]interface{}{
+				"key2": "val2",
+			},
+		},
+	}, dest)
+
+	merged := dest["request"].(map[string]interface{})["object"].(map[string]interface{})
+	assert.Equal(t, "val1", merged["key1"])
+	assert.Equal(t, "val2", merged["key2"])
+
+	_, exists := object["key2"]
 	assert.Equal(t, false, exists)
 }
@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/conversion"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// convertedPolicyContext overrides the resources of a policy context with their converted version
+type convertedPolicyContext struct {
+	engineapi.PolicyContext
+	newResource unstructured.Unstructured
+	oldResource unstructured.Unstructured
+	gvk         schema.GroupVersionKind
+}
+
+func (c *convertedPolicyContext) NewResource() unstructured.Unstructured {
+	return c.newResource
+}
+
+func (c *convertedPolicyContext) OldResource() unstructured.Unstructured {
+	return c.oldResource
+}
+
+func (c *convertedPolicyContext) ResourceKind() (schema.GroupVersionKind, string) {
+	return c.gvk, ""
+}
+
+func (c *convertedPolicyContext) Copy() engineapi.PolicyContext {
+	copy := *c
+	copy.PolicyContext = c.PolicyContext.Copy()
+	return &copy
+}
+
+// declaredVersion returns the version declared by the rule kinds for the resource group and kind,
+// empty if the rule accepts the resource version or doesn't declare one.
+func declaredVersion(rule kyvernov1.Rule, gvk schema.GroupVersionKind, subresource string) string {
+	if subresource != "" {
+		return ""
+	}
+	var declared string
+	for _, selector := range rule.MatchResources.GetKinds() {
+		group, version, kind, sub := kubeutils.ParseKindSelector(selector)
+		if sub != "" || !wildcard.Match(group, gvk.Group) || kind != gvk.Kind {
+			continue
+		}
+		if wildcard.Match(version, gvk.Version) {
+			return ""
+		}
+		if declared == "" {
+			declared = version
+		}
+	}
+	return declared
+}
+
+// convertPolicyContext returns a policy context holding the resources converted to the version declared by the rule,
+// nil if there's no need to convert or if the resources can't be converted.
+func (e *engine) convertPolicyContext(
+	ctx context.Context,
+	logger logr.Logger,
+	rule kyvernov1.Rule,
+	policyContext engineapi.PolicyContext,
+) (engineapi.PolicyContext, error) {
+	gvk, subresource := policyContext.ResourceKind()
+	version := declaredVersion(rule, gvk, subresource)
+	if version == "" {
+		return nil, nil
+	}
+	resources, err := e.converter.Convert(ctx, version, policyContext.NewResource(), policyContext.OldResource())
+	if err != nil {
+		if errors.Is(err, conversion.ErrNotSupported) {
+			logger.V(4).Info("resource not converted", "version", version, "reason", err.Error())
+			return nil, nil
+		}
+		return nil, err
+	}
+	gvk.Version = version
+	return &convertedPolicyContext{
+		PolicyContext: policyContext,
+		newResource:   resources[0],
+		oldResource:   resources[1],
+		gvk:           gvk,
+	}, nil
+}
+
+// loadResources replaces the resources in the JSON context with the ones held by the policy context
+func loadResources(jsonContext enginecontext.Interface, policyContext engineapi.PolicyContext) error {
+	if resource := policyContext.NewResource(); resource.Object != nil {
+		if err := jsonContext.AddResource(resource.Object); err != nil {
+			return err
+		}
+	}
+	if resource := policyContext.OldResource(); resource.Object != nil {
+		if err := jsonContext.AddOldResource(resource.Object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package conversion
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsv1listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+// webhookTimeout is the maximum duration of a call to a conversion webhook
+const webhookTimeout = 10 * time.Second
+
+// ErrNotSupported is returned when the resource can't be converted, either because it's not a custom resource
+// or because no custom resource definition lister is available.
+var ErrNotSupported = errors.New("conversion not supported")
+
+// Converter converts resources to another version of the same group and kind.
+type Converter interface {
+	// Convert converts the given resources to the given version, empty resources are returned as is.
+	Convert(ctx context.Context, version string, resources ...unstructured.Unstructured) ([]unstructured.Unstructured, error)
+}
+
+type converter struct {
+	crdLister apiextensionsv1listers.CustomResourceDefinitionLister
+}
+
+// NewConverter returns a converter resolving custom resources conversion the same way the API server does.
+// Resources with the None strategy only get their apiVersion changed, conversion webhooks are called directly
+// at their URL or service address, trusting the CA bundle declared in the custom resource definition.
+// Built-in resources can't be converted.
+func NewConverter(crdLister apiextensionsv1listers.CustomResourceDefinitionLister) Converter {
+	return &converter{
+		crdLister: crdLister,
+	}
+}
+
+func (c *converter) Convert(ctx context.Context, version string, resources ...unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	var gvk schema.GroupVersionKind
+	for _, resource := range resources {
+		if resource.Object != nil {
+			gvk = resource.GroupVersionKind()
+			break
+		}
+	}
+	if gvk.Empty() || gvk.Version == version {
+		return resources, nil
+	}
+	desiredGV := schema.GroupVersion{Group: gvk.Group, Version: version}
+	if c.crdLister == nil {
+		return nil, fmt.Errorf("%w: a custom resource definition lister is required", ErrNotSupported)
+	}
+	crd, err := c.crd(gvk.GroupKind())
+	if err != nil {
+		return nil, err
+	}
+	if crd == nil {
+		return nil, fmt.Errorf("%w: %s is not a custom resource", ErrNotSupported, gvk.GroupKind())
+	}
+	if !served(crd, version) {
+		return nil, fmt.Errorf("version %s of %s is not served", version, gvk.GroupKind())
+	}
+	conversion := crd.Spec.Conversion
+	if conversion == nil || conversion.Strategy == apiextensionsv1.NoneConverter {
+		converted := make([]unstructured.Unstructured, 0, len(resources))
+		for _, resource := range resources {
+			if resource.Object != nil {
+				resource = *resource.DeepCopy()
+				resource.SetAPIVersion(desiredGV.String())
+			}
+			converted = append(converted, resource)
+		}
+		return converted, nil
+	}
+	return c.review(ctx, conversion, desiredGV, resources)
+}
+
+// crd returns the custom resource definition of the given group kind, nil if the group kind is not a custom resource.
+func (c *converter) crd(gk schema.GroupKind) (*apiextensionsv1.CustomResourceDefinition, error) {
+	crds, err := c.crdLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom resource definitions (%w)", err)
+	}
+	for _, crd := range crds {
+		if crd.Spec.Group == gk.Group && crd.Spec.Names.Kind == gk.Kind {
+			return crd, nil
+		}
+	}
+	return nil, nil
+}
+
+// review sends a conversion review to the custom resource conversion webhook.
+func (c *converter) review(ctx context.Context, conversion *apiextensionsv1.CustomResourceConversion, desired schema.GroupVersion, resources []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	if conversion.Webhook == nil || conversion.Webhook.ClientConfig == nil {
+		return nil, errors.New("missing conversion webhook client config")
+	}
+	review := apiextensionsv1.ConversionReview{
+		Request: &apiextensionsv1.ConversionRequest{
+			UID:               uuid.NewUUID(),
+			DesiredAPIVersion: desired.String(),
+		},
+	}
+	review.SetGroupVersionKind(apiextensionsv1.SchemeGroupVersion.WithKind("ConversionReview"))
+	var indexes []int
+	for i, resource := range resources {
+		if resource.Object == nil {
+			continue
+		}
+		raw, err := resource.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		review.Request.Objects = append(review.Request.Objects, runtime.RawExtension{Raw: raw})
+		indexes = append(indexes, i)
+	}
+	body, err := json.Marshal(review)
+	if err != nil {
+		return nil, err
+	}
+	data, err := callWebhook(ctx, conversion.Webhook.ClientConfig, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call conversion webhook (%w)", err)
+	}
+	var response apiextensionsv1.ConversionReview
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+	if response.Response == nil {
+		return nil, errors.New("conversion webhook returned an empty response")
+	}
+	if response.Response.Result.Status != "Success" {
+		return nil, fmt.Errorf("conversion webhook failed: %s", response.Response.Result.Message)
+	}
+	if len(response.Response.ConvertedObjects) != len(indexes) {
+		return nil, fmt.Errorf("conversion webhook returned %d objects, expected %d", len(response.Response.ConvertedObjects), len(indexes))
+	}
+	converted := append([]unstructured.Unstructured(nil), resources...)
+	for i, object := range response.Response.ConvertedObjects {
+		resource, err := kubeutils.BytesToUnstructured(object.Raw)
+		if err != nil {
+			return nil, err
+		}
+		converted[indexes[i]] = *resource
+	}
+	return converted, nil
+}
+
+// callWebhook posts the given conversion review to the webhook, the same way the API server does
+func callWebhook(ctx context.Context, clientConfig *apiextensionsv1.WebhookClientConfig, body []byte) ([]byte, error) {
+	url, err := webhookURL(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(clientConfig.CABundle) != 0 {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(clientConfig.CABundle) {
+			return nil, errors.New("failed to parse PEM CA bundle")
+		}
+	}
+	client := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		Timeout:   webhookTimeout,
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return data, nil
+}
+
+// webhookURL returns the URL of a conversion webhook, services are reached through their cluster DNS name
+func webhookURL(clientConfig *apiextensionsv1.WebhookClientConfig) (string, error) {
+	if clientConfig.URL != nil {
+		return *clientConfig.URL, nil
+	}
+	service := clientConfig.Service
+	if service == nil {
+		return "", errors.New("conversion webhook has neither a URL nor a service")
+	}
+	port := int32(443)
+	if service.Port != nil {
+		port = *service.Port
+	}
+	path := ""
+	if service.Path != nil {
+		path = *service.Path
+	}
+	return fmt.Sprintf("https://%s.%s.svc:%d%s", service.Name, service.Namespace, port, path), nil
+}
+
+func served(crd *apiextensionsv1.CustomResourceDefinition, version string) bool {
+	for _, v := range crd.Spec.Versions {
+		if v.Name == version {
+			return v.Served
+		}
+	}
+	return false
+}
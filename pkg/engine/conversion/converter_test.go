@@ -0,0 +1,180 @@
+package conversion
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsv1listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+func lister(t *testing.T, crds ...apiextensionsv1.CustomResourceDefinition) apiextensionsv1listers.CustomResourceDefinitionLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for i := range crds {
+		assert.NoError(t, indexer.Add(&crds[i]))
+	}
+	return apiextensionsv1listers.NewCustomResourceDefinitionLister(indexer)
+}
+
+// webhookServer returns a TLS server answering conversion reviews with the given function
+func webhookServer(t *testing.T, webhook func(apiextensionsv1.ConversionReview) apiextensionsv1.ConversionReview) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var review apiextensionsv1.ConversionReview
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&review))
+		assert.NoError(t, json.NewEncoder(w).Encode(webhook(review)))
+	}))
+}
+
+func crd(conversion *apiextensionsv1.CustomResourceConversion) apiextensionsv1.CustomResourceDefinition {
+	return apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets.example.com"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "example.com",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "Widget"},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1", Served: true},
+				{Name: "v2", Served: true},
+				{Name: "v3", Served: false},
+			},
+			Conversion: conversion,
+		},
+	}
+}
+
+func widget(apiVersion string, size int64) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       "Widget",
+		"metadata":   map[string]interface{}{"name": "foo"},
+		"spec":       map[string]interface{}{"size": size},
+	}}
+}
+
+func Test_converter_Convert(t *testing.T) {
+	// the fake webhook sets the desired version and doubles the size
+	convert := func(review apiextensionsv1.ConversionReview) apiextensionsv1.ConversionReview {
+		response := &apiextensionsv1.ConversionResponse{UID: review.Request.UID, Result: metav1.Status{Status: metav1.StatusSuccess}}
+		for _, object := range review.Request.Objects {
+			var resource unstructured.Unstructured
+			assert.NoError(t, resource.UnmarshalJSON(object.Raw))
+			size, _, _ := unstructured.NestedInt64(resource.Object, "spec", "size")
+			converted := widget(review.Request.DesiredAPIVersion, size*2)
+			raw, err := converted.MarshalJSON()
+			assert.NoError(t, err)
+			response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: raw})
+		}
+		review.Response = response
+		return review
+	}
+	failure := func(review apiextensionsv1.ConversionReview) apiextensionsv1.ConversionReview {
+		review.Response = &apiextensionsv1.ConversionResponse{Result: metav1.Status{Status: metav1.StatusFailure, Message: "boom"}}
+		return review
+	}
+	tests := []struct {
+		name         string
+		noLister     bool
+		crds         []apiextensionsv1.CustomResourceDefinition
+		webhook      func(apiextensionsv1.ConversionReview) apiextensionsv1.ConversionReview
+		version      string
+		resources    []unstructured.Unstructured
+		want         []unstructured.Unstructured
+		wantErr      bool
+		notSupported bool
+	}{{
+		name:      "same version",
+		noLister:  true,
+		version:   "v1",
+		resources: []unstructured.Unstructured{widget("example.com/v1", 1)},
+		want:      []unstructured.Unstructured{widget("example.com/v1", 1)},
+	}, {
+		name:         "no lister",
+		noLister:     true,
+		version:      "v2",
+		resources:    []unstructured.Unstructured{widget("example.com/v1", 1)},
+		wantErr:      true,
+		notSupported: true,
+	}, {
+		name:         "not a custom resource",
+		version:      "v2",
+		resources:    []unstructured.Unstructured{widget("example.com/v1", 1)},
+		wantErr:      true,
+		notSupported: true,
+	}, {
+		name:      "version not served",
+		crds:      []apiextensionsv1.CustomResourceDefinition{crd(nil)},
+		version:   "v3",
+		resources: []unstructured.Unstructured{widget("example.com/v1", 1)},
+		wantErr:   true,
+	}, {
+		name:      "none strategy",
+		crds:      []apiextensionsv1.CustomResourceDefinition{crd(nil)},
+		version:   "v2",
+		resources: []unstructured.Unstructured{widget("example.com/v1", 1), {}},
+		want:      []unstructured.Unstructured{widget("example.com/v2", 1), {}},
+	}, {
+		name:      "webhook strategy",
+		webhook:   convert,
+		version:   "v2",
+		resources: []unstructured.Unstructured{{}, widget("example.com/v1", 1)},
+		want:      []unstructured.Unstructured{{}, widget("example.com/v2", 2)},
+	}, {
+		name:      "webhook failure",
+		webhook:   failure,
+		version:   "v2",
+		resources: []unstructured.Unstructured{widget("example.com/v1", 1)},
+		wantErr:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crds := tt.crds
+			if tt.webhook != nil {
+				server := webhookServer(t, tt.webhook)
+				defer server.Close()
+				url := server.URL + "/convert"
+				crds = append(crds, crd(&apiextensionsv1.CustomResourceConversion{
+					Strategy: apiextensionsv1.WebhookConverter,
+					Webhook: &apiextensionsv1.WebhookConversion{
+						ClientConfig: &apiextensionsv1.WebhookClientConfig{
+							URL:      &url,
+							CABundle: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+						},
+					},
+				}))
+			}
+			c := NewConverter(nil)
+			if !tt.noLister {
+				c = NewConverter(lister(t, crds...))
+			}
+			got, err := c.Convert(context.TODO(), tt.version, tt.resources...)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.notSupported, errors.Is(err, ErrNotSupported))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_webhookURL(t *testing.T) {
+	port := int32(8443)
+	path := "/convert"
+	url, err := webhookURL(&apiextensionsv1.WebhookClientConfig{
+		Service: &apiextensionsv1.ServiceReference{Namespace: "widgets", Name: "converter", Port: &port, Path: &path},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://converter.widgets.svc:8443/convert", url)
+	_, err = webhookURL(&apiextensionsv1.WebhookClientConfig{})
+	assert.Error(t, err)
+}
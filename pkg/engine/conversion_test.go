@@ -0,0 +1,41 @@
+package engine
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_declaredVersion(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	rule := func(kinds ...string) kyvernov1.Rule {
+		return kyvernov1.Rule{
+			MatchResources: kyvernov1.MatchResources{
+				Any: kyvernov1.ResourceFilters{{ResourceDescription: kyvernov1.ResourceDescription{Kinds: kinds}}},
+			},
+		}
+	}
+	tests := []struct {
+		name        string
+		rule        kyvernov1.Rule
+		subresource string
+		want        string
+	}{
+		{name: "kind only", rule: rule("Widget"), want: ""},
+		{name: "same version", rule: rule("example.com/v1/Widget"), want: ""},
+		{name: "wildcard version", rule: rule("example.com/*/Widget"), want: ""},
+		{name: "other version", rule: rule("example.com/v2/Widget"), want: "v2"},
+		{name: "other version without group", rule: rule("v2/Widget"), want: "v2"},
+		{name: "other version and same version", rule: rule("example.com/v2/Widget", "example.com/v1/Widget"), want: ""},
+		{name: "other group", rule: rule("other.com/v2/Widget"), want: ""},
+		{name: "other kind", rule: rule("example.com/v2/Gadget"), want: ""},
+		{name: "subresource", rule: rule("example.com/v2/Widget"), subresource: "status", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, declaredVersion(tt.rule, gvk, tt.subresource))
+		})
+	}
+}
@@ -2,7 +2,9 @@ package engine
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -11,10 +13,12 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/conversion"
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
 	"github.com/kyverno/kyverno/pkg/engine/internal"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
@@ -23,6 +27,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
+	apiextensionsv1listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -36,9 +41,13 @@ type engine struct {
 	contextLoader            engineapi.ContextLoaderFactory
 	exceptionSelector        engineapi.PolicyExceptionSelector
 	imageSignatureRepository string
+	secretResolver           engineapi.SecretResolver
+	imageAllowListResolver   engineapi.ImageAllowListResolver
+	converter                conversion.Converter
 	// metrics
 	resultCounter     metric.Int64Counter
 	durationHistogram metric.Float64Histogram
+	errorCounter      metric.Int64Counter
 }
 
 type handlerFactory = func() (handlers.Handler, error)
@@ -53,6 +62,9 @@ func NewEngine(
 	contextLoader engineapi.ContextLoaderFactory,
 	exceptionSelector engineapi.PolicyExceptionSelector,
 	imageSignatureRepository string,
+	secretResolver engineapi.SecretResolver,
+	imageAllowListResolver engineapi.ImageAllowListResolver,
+	crdLister apiextensionsv1listers.CustomResourceDefinitionLister,
 ) engineapi.Engine {
 	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
 	resultCounter, err := meter.Int64Counter(
@@ -69,6 +81,13 @@ func NewEngine(
 	if err != nil {
 		logging.Error(err, "failed to register metric kyverno_policy_execution_duration_seconds")
 	}
+	errorCounter, err := meter.Int64Counter(
+		"kyverno_policy_errors",
+		metric.WithDescription("can be used to track the rules of the policies that failed to execute (bad variable, missing resource, context loading failure)"),
+	)
+	if err != nil {
+		logging.Error(err, "failed to register metric kyverno_policy_errors")
+	}
 	return &engine{
 		configuration:            configuration,
 		metricsConfiguration:     metricsConfiguration,
@@ -79,8 +98,12 @@ func NewEngine(
 		contextLoader:            contextLoader,
 		exceptionSelector:        exceptionSelector,
 		imageSignatureRepository: imageSignatureRepository,
+		secretResolver:           secretResolver,
+		imageAllowListResolver:   imageAllowListResolver,
+		converter:                conversion.NewConverter(crdLister),
 		resultCounter:            resultCounter,
 		durationHistogram:        durationHistogram,
+		errorCounter:             errorCounter,
 	}
 }
 
@@ -92,7 +115,14 @@ func (e *engine) Validate(
 	response := engineapi.NewEngineResponseFromPolicyContext(policyContext)
 	logger := internal.LoggerWithPolicyContext(logging.WithName("engine.validate"), policyContext)
 	if internal.MatchPolicyContext(logger, policyContext, e.configuration) {
-		policyResponse := e.validate(ctx, logger, policyContext)
+		// in fail fast mode an admission request is denied as soon as a rule fails in enforce mode
+		var failFast func(engineapi.RuleResponse) bool
+		if policyContext.AdmissionOperation() && policyContext.Policy().GetSpec().GetFailFast(e.configuration.GetFailFast()) {
+			failFast = func(rule engineapi.RuleResponse) bool {
+				return rule.Status() == engineapi.RuleStatusFail && response.GetRuleValidationFailureAction(rule).Enforce()
+			}
+		}
+		policyResponse := e.validate(ctx, logger, policyContext, failFast)
 		response = response.WithPolicyResponse(policyResponse)
 	}
 	response = response.WithStats(engineapi.NewExecutionStats(startTime, time.Now()))
@@ -199,6 +229,7 @@ func (e *engine) matches(
 		}
 	}
 	gvk, subresource := policyContext.ResourceKind()
+	rule = e.resolveResources(rule, policyContext)
 	err := engineutils.MatchesResourceDescription(
 		resource,
 		rule,
@@ -231,6 +262,94 @@ func (e *engine) matches(
 	return err
 }
 
+// reportProperties substitutes variables in the rule report properties, properties that can't be resolved are dropped
+func reportProperties(logger logr.Logger, jsonContext enginecontext.EvalInterface, properties map[string]string) map[string]string {
+	resolved := make(map[string]string, len(properties))
+	for key, value := range properties {
+		substituted, err := variables.SubstituteAll(logger, jsonContext, value)
+		if err != nil {
+			logger.V(3).Info("failed to substitute variables in report property", "property", key, "reason", err.Error())
+			continue
+		}
+		if str, ok := substituted.(string); ok {
+			resolved[key] = str
+		} else if data, err := json.Marshal(substituted); err == nil {
+			resolved[key] = string(data)
+		}
+	}
+	return resolved
+}
+
+// failureMessage substitutes variables in the rule message template, the template is returned as is if variables can't be resolved
+func failureMessage(logger logr.Logger, jsonContext enginecontext.EvalInterface, template string) string {
+	substituted, err := variables.SubstituteAll(logger, jsonContext, template)
+	if err != nil {
+		logger.V(3).Info("failed to substitute variables in message template", "reason", err.Error())
+		return template
+	}
+	if str, ok := substituted.(string); ok {
+		return str
+	}
+	return fmt.Sprint(substituted)
+}
+
+// withRemediation applies the validation message template and remediation url to the failed rule responses
+func withRemediation(logger logr.Logger, jsonContext enginecontext.EvalInterface, validation kyvernov1.Validation, ruleResponses []engineapi.RuleResponse) {
+	var message string
+	for i := range ruleResponses {
+		if ruleResponses[i].Status() != engineapi.RuleStatusFail {
+			continue
+		}
+		if validation.MessageTemplate != "" {
+			if message == "" {
+				message = failureMessage(logger, jsonContext, validation.MessageTemplate)
+			}
+			ruleResponses[i] = *ruleResponses[i].WithMessage(message)
+		}
+		if validation.RemediationURL != "" {
+			properties := map[string]string{}
+			for k, v := range ruleResponses[i].Properties() {
+				properties[k] = v
+			}
+			properties[engineapi.RemediationURLProperty] = validation.RemediationURL
+			ruleResponses[i] = *ruleResponses[i].WithProperties(properties)
+		}
+	}
+}
+
+// withRuleOverrides records the failure action and severity declared by the validation rule in the rule responses
+func withRuleOverrides(validation kyvernov1.Validation, ruleResponses []engineapi.RuleResponse) {
+	for i := range ruleResponses {
+		if validation.FailureAction != nil {
+			ruleResponses[i] = *ruleResponses[i].WithFailureAction(*validation.FailureAction)
+		}
+		if validation.Severity != "" {
+			ruleResponses[i] = *ruleResponses[i].WithSeverity(validation.Severity)
+		}
+	}
+}
+
+// skipOnBudget checks if the rule belongs to an audit policy and the admission latency budget is exhausted
+func (e *engine) skipOnBudget(ctx context.Context, policyContext engineapi.PolicyContext, rule kyvernov1.Rule, ruleType engineapi.RuleType) bool {
+	return engineapi.LatencyBudgetExceeded(ctx, time.Now()) && isAuditRule(policyContext, rule, ruleType)
+}
+
+// skipOnObjectSize checks if the rule belongs to an audit policy and the admission object exceeds the max object size
+func (e *engine) skipOnObjectSize(ctx context.Context, policyContext engineapi.PolicyContext, rule kyvernov1.Rule, ruleType engineapi.RuleType) bool {
+	return engineapi.ObjectSizeExceeded(ctx) && isAuditRule(policyContext, rule, ruleType)
+}
+
+func isAuditRule(policyContext engineapi.PolicyContext, rule kyvernov1.Rule, ruleType engineapi.RuleType) bool {
+	if ruleType != engineapi.Validation && ruleType != engineapi.ImageVerify {
+		return false
+	}
+	if ruleType == engineapi.Validation && rule.Validation.FailureAction != nil {
+		return rule.Validation.FailureAction.Audit()
+	}
+	response := engineapi.NewEngineResponseFromPolicyContext(policyContext)
+	return response.GetValidationFailureAction().Audit()
+}
+
 func (e *engine) invokeRuleHandler(
 	ctx context.Context,
 	logger logr.Logger,
@@ -245,11 +364,59 @@ func (e *engine) invokeRuleHandler(
 		"pkg/engine",
 		fmt.Sprintf("RULE %s", rule.Name),
 		func(ctx context.Context, span trace.Span) (patchedResource unstructured.Unstructured, results []engineapi.RuleResponse) {
+			// record the decisions taken on the rule if a tracer is set
+			var ruleTrace *ruleTrace
+			if tracer := engineapi.RuleTracerFrom(ctx); tracer != nil {
+				ruleTrace = newRuleTrace(policyContext, rule, ruleType, resource)
+				defer func(original unstructured.Unstructured) {
+					ruleTrace.results(logger, results, original, patchedResource)
+					tracer(ruleTrace.RuleTrace)
+				}(resource)
+			}
+			// evaluate validation rules against the resource version declared in their kinds
+			if ruleType == engineapi.Validation {
+				converted, err := e.convertPolicyContext(ctx, logger, rule, policyContext)
+				if err != nil {
+					return resource, handlers.WithError(rule, ruleType, "failed to convert resource", err)
+				}
+				if converted != nil {
+					policyContext.JSONContext().Checkpoint()
+					defer policyContext.JSONContext().Restore()
+					if err := loadResources(policyContext.JSONContext(), converted); err != nil {
+						return resource, handlers.WithError(rule, ruleType, "failed to load converted resource", err)
+					}
+					// the validated resource is returned unchanged
+					defer func(original unstructured.Unstructured) {
+						patchedResource = original
+					}(resource)
+					policyContext, resource = converted, converted.NewResource()
+				}
+			}
 			// check if resource and rule match
-			if err := e.matches(rule, policyContext, resource); err != nil {
+			err := e.matches(rule, policyContext, resource)
+			ruleTrace.match(err)
+			if err != nil {
 				logger.V(4).Info("rule not matched", "reason", err.Error())
 				return resource, nil
 			}
+			// skip audit rules when the admission object is too large
+			if e.skipOnObjectSize(ctx, policyContext, rule, ruleType) {
+				logger.V(2).Info("rule skipped, admission object exceeds the max object size")
+				ruleTrace.skip("admission object exceeds the max object size")
+				return resource, handlers.WithResponses(engineapi.RuleSkip(rule.Name, ruleType, "admission object exceeds the max object size").WithSkipReason(engineapi.SkipReasonObjectTooLarge))
+			}
+			// skip audit rules once the admission latency budget is exhausted
+			if e.skipOnBudget(ctx, policyContext, rule, ruleType) {
+				logger.V(2).Info("rule skipped, admission latency budget exceeded")
+				ruleTrace.skip("admission latency budget exceeded")
+				return resource, handlers.WithResponses(engineapi.RuleSkip(rule.Name, ruleType, "admission latency budget exceeded").WithSkipReason(engineapi.SkipReasonBudgetExceeded))
+			}
+			// bound rule processing if the rule has a timeout
+			if rule.Timeout != nil {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, rule.Timeout.Duration)
+				defer cancel()
+			}
 			if handlerFactory == nil {
 				return resource, handlers.WithError(rule, ruleType, "failed to instantiate handler", nil)
 			} else if handler, err := handlerFactory(); err != nil {
@@ -264,9 +431,15 @@ func (e *engine) invokeRuleHandler(
 						}
 					}
 				}()
+				// expose the policy and rule being evaluated
+				if err := policyContext.JSONContext().AddRuleInfo(policyContext.Policy().GetName(), rule.Name, string(ruleType)); err != nil {
+					return resource, handlers.WithError(rule, ruleType, "failed to add rule info to the context", err)
+				}
 				// load rule context
 				contextLoader := e.ContextLoader(policyContext.Policy(), rule)
-				if err := contextLoader(ctx, rule.Context, policyContext.JSONContext()); err != nil {
+				err := contextLoader(ctx, rule.Context, policyContext.JSONContext())
+				ruleTrace.context(rule.Context, err)
+				if err != nil {
 					if _, ok := err.(gojmespath.NotFoundError); ok {
 						logger.V(3).Info("failed to load context", "reason", err.Error())
 					} else {
@@ -274,23 +447,73 @@ func (e *engine) invokeRuleHandler(
 					}
 					return resource, handlers.WithError(rule, ruleType, "failed to load context", err)
 				}
+				// record the rule after variable substitution for debugging
+				if recorder := engineapi.ResolvedRuleRecorderFrom(ctx); recorder != nil {
+					recorder(policyContext.Policy(), rule.Name, resolveRule(logger, policyContext.JSONContext(), rule))
+				}
 				// check preconditions
 				preconditionsPassed, msg, err := internal.CheckPreconditions(logger, policyContext.JSONContext(), rule.GetAnyAllConditions())
+				ruleTrace.preconditions(logger, policyContext.JSONContext(), rule.GetAnyAllConditions(), preconditionsPassed, msg, err)
 				if err != nil {
 					return resource, handlers.WithError(rule, ruleType, "failed to evaluate preconditions", err)
 				}
 				if !preconditionsPassed {
 					s := stringutils.JoinNonEmpty([]string{"preconditions not met", msg}, "; ")
-					return resource, handlers.WithSkip(rule, ruleType, s)
+					return resource, handlers.WithResponses(engineapi.RuleSkip(rule.Name, ruleType, s).WithSkipReason(engineapi.SkipReasonPreconditionsNotMet))
+				}
+				// check CEL preconditions, validate.cel rules evaluate them along with their expressions
+				if !rule.HasValidateCEL() {
+					preconditionsPassed, msg, err := internal.CheckCELPreconditions(ctx, policyContext, resource, rule.CELPreconditions)
+					if err != nil {
+						return resource, handlers.WithError(rule, ruleType, "failed to evaluate CEL preconditions", err)
+					}
+					if !preconditionsPassed {
+						s := stringutils.JoinNonEmpty([]string{"preconditions not met", msg}, "; ")
+						return resource, handlers.WithResponses(engineapi.RuleSkip(rule.Name, ruleType, s).WithSkipReason(engineapi.SkipReasonPreconditionsNotMet))
+					}
 				}
 				// get policy exceptions that matches both policy and rule name
-				exceptions, err := e.GetPolicyExceptions(policyContext.Policy(), rule.Name)
+				exceptions, expired, err := e.GetPolicyExceptions(policyContext.Policy(), rule.Name)
 				if err != nil {
 					logger.Error(err, "failed to get exceptions")
 					return resource, nil
 				}
 				// process handler
 				resource, ruleResponses := handler.Process(ctx, logger, policyContext, resource, rule, contextLoader, exceptions)
+				ruleTrace.anchors(rule, ruleResponses)
+				// reflect context entries set to their default value in the rule responses
+				if fallbacks := policyContext.JSONContext().Fallbacks(); len(fallbacks) != 0 {
+					msg := fmt.Sprintf("context entries set to default value: %s", strings.Join(fallbacks, ", "))
+					for i := range ruleResponses {
+						ruleResponses[i] = *ruleResponses[i].WithMessage(stringutils.JoinNonEmpty([]string{ruleResponses[i].Message(), msg}, "; "))
+					}
+				}
+				// resolve the report properties declared by the rule
+				if len(rule.ReportProperties) != 0 {
+					properties := reportProperties(logger, policyContext.JSONContext(), rule.ReportProperties)
+					for i := range ruleResponses {
+						merged := map[string]string{}
+						for k, v := range ruleResponses[i].Properties() {
+							merged[k] = v
+						}
+						for k, v := range properties {
+							merged[k] = v
+						}
+						ruleResponses[i] = *ruleResponses[i].WithProperties(merged)
+					}
+				}
+				// point failed validations to their remediation and apply the rule failure action and severity
+				if ruleType == engineapi.Validation {
+					withRemediation(logger, policyContext.JSONContext(), rule.Validation, ruleResponses)
+					withRuleOverrides(rule.Validation, ruleResponses)
+				}
+				// flag expired exceptions that would otherwise have applied to the resource
+				if exception := engineutils.MatchesException(expired, policyContext, logger); exception != nil {
+					logger.V(3).Info("policy exception expired", "namespace", exception.GetNamespace(), "name", exception.GetName())
+					if err := flagExpiredException(exception, ruleResponses); err != nil {
+						logger.Error(err, "failed to compute policy exception key", "namespace", exception.GetNamespace(), "name", exception.GetName())
+					}
+				}
 				return resource, ruleResponses
 			}
 			return resource, nil
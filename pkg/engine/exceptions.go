@@ -2,34 +2,62 @@ package engine
 
 import (
 	"fmt"
+	"time"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 )
 
+// expiredExceptionProperty is the report property flagging an expired exception matching the resource
+const expiredExceptionProperty = "expiredException"
+
 // GetPolicyExceptions get all exceptions that match both the policy and the rule.
+// Expired exceptions are returned separately, they must not be applied.
 func (e *engine) GetPolicyExceptions(
 	policy kyvernov1.PolicyInterface,
 	rule string,
-) ([]kyvernov2beta1.PolicyException, error) {
-	var exceptions []kyvernov2beta1.PolicyException
+) ([]kyvernov2beta1.PolicyException, []kyvernov2beta1.PolicyException, error) {
+	var exceptions, expired []kyvernov2beta1.PolicyException
 	if e.exceptionSelector == nil {
-		return exceptions, nil
+		return exceptions, expired, nil
 	}
 	polexs, err := e.exceptionSelector.List(labels.Everything())
 	if err != nil {
-		return exceptions, err
+		return exceptions, expired, err
 	}
 	policyName, err := cache.MetaNamespaceKeyFunc(policy)
 	if err != nil {
-		return exceptions, fmt.Errorf("failed to compute policy key: %w", err)
+		return exceptions, expired, fmt.Errorf("failed to compute policy key: %w", err)
 	}
+	now := time.Now()
 	for _, polex := range polexs {
 		if polex.Contains(policyName, rule) {
-			exceptions = append(exceptions, *polex)
+			if polex.Spec.HasExpired(now) {
+				expired = append(expired, *polex)
+			} else {
+				exceptions = append(exceptions, *polex)
+			}
+		}
+	}
+	return exceptions, expired, nil
+}
+
+// flagExpiredException adds the expired exception key to the rule responses properties.
+func flagExpiredException(exception *kyvernov2beta1.PolicyException, ruleResponses []engineapi.RuleResponse) error {
+	key, err := cache.MetaNamespaceKeyFunc(exception)
+	if err != nil {
+		return err
+	}
+	for i := range ruleResponses {
+		properties := map[string]string{}
+		for k, v := range ruleResponses[i].Properties() {
+			properties[k] = v
 		}
+		properties[expiredExceptionProperty] = key
+		ruleResponses[i] = *ruleResponses[i].WithProperties(properties)
 	}
-	return exceptions, nil
+	return nil
 }
@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/adapters"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type exceptionSelector []*kyvernov2beta1.PolicyException
+
+func (s exceptionSelector) List(labels.Selector) ([]*kyvernov2beta1.PolicyException, error) {
+	return s, nil
+}
+
+func newException(name string, expiresAt *metav1.Time) *kyvernov2beta1.PolicyException {
+	return &kyvernov2beta1.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kyverno", Name: name},
+		Spec: kyvernov2beta1.PolicyExceptionSpec{
+			Match: kyvernov2beta1.MatchResources{
+				Any: kyvernov1.ResourceFilters{{
+					ResourceDescription: kyvernov1.ResourceDescription{
+						Kinds: []string{"Pod"},
+					},
+				}},
+			},
+			Exceptions: []kyvernov2beta1.Exception{{
+				PolicyName: "validate-namespace",
+				RuleNames:  []string{"check-default-namespace"},
+			}},
+			ExpiresAt: expiresAt,
+		},
+	}
+}
+
+func TestValidate_ExpiredException(t *testing.T) {
+	rawResource := []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"myapp-pod","namespace":"default"},"spec":{"containers":[{"name":"nginx","image":"nginx"}]}}`)
+	rawPolicy := []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"validate-namespace"},"spec":{"rules":[{"name":"check-default-namespace","match":{"resources":{"kinds":["Pod"]}},"validate":{"message":"Using default namespace is not allowed","pattern":{"metadata":{"namespace":"!default"}}}}]}}`)
+	var policy kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+	resource, err := kubeutils.BytesToUnstructured(rawResource)
+	assert.NilError(t, err)
+	past := metav1.NewTime(time.Now().Add(-time.Hour))
+	future := metav1.NewTime(time.Now().Add(time.Hour))
+	tests := []struct {
+		name       string
+		exceptions exceptionSelector
+		status     engineapi.RuleStatus
+		properties map[string]string
+	}{{
+		name:       "active",
+		exceptions: exceptionSelector{newException("active", &future)},
+		status:     engineapi.RuleStatusSkip,
+	}, {
+		name:       "expired",
+		exceptions: exceptionSelector{newException("expired", &past)},
+		status:     engineapi.RuleStatusFail,
+		properties: map[string]string{expiredExceptionProperty: "kyverno/expired", engineapi.PathProperty: "/metadata/namespace/"},
+	}, {
+		name:       "no expiry",
+		exceptions: exceptionSelector{newException("forever", nil)},
+		status:     engineapi.RuleStatusSkip,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEngine(
+				cfg,
+				config.NewDefaultMetricsConfiguration(),
+				jp,
+				nil,
+				factories.DefaultRegistryClientFactory(adapters.RegistryClient(registryclient.NewOrDie()), nil),
+				imageverifycache.DisabledImageVerifyCache(),
+				factories.DefaultContextLoaderFactory(nil),
+				tt.exceptions,
+				"",
+				nil,
+				nil,
+				nil,
+			)
+			er := e.Validate(context.TODO(), newPolicyContext(t, *resource, kyvernov1.Create, nil).WithPolicy(&policy))
+			assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+			assert.Equal(t, er.PolicyResponse.Rules[0].Status(), tt.status)
+			assert.DeepEqual(t, er.PolicyResponse.Rules[0].Properties(), tt.properties)
+		})
+	}
+}
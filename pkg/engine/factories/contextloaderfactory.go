@@ -18,7 +18,7 @@ import (
 type ContextLoaderFactoryOptions func(*contextLoader)
 
 func DefaultContextLoaderFactory(cmResolver engineapi.ConfigmapResolver, opts ...ContextLoaderFactoryOptions) engineapi.ContextLoaderFactory {
-	return func(_ kyvernov1.PolicyInterface, _ kyvernov1.Rule) engineapi.ContextLoader {
+	return func(policy kyvernov1.PolicyInterface, _ kyvernov1.Rule) engineapi.ContextLoader {
 		cl := &contextLoader{
 			logger:     logging.WithName("DefaultContextLoaderFactory"),
 			cmResolver: cmResolver,
@@ -26,6 +26,10 @@ func DefaultContextLoaderFactory(cmResolver engineapi.ConfigmapResolver, opts ..
 		for _, o := range opts {
 			o(cl)
 		}
+		if policy != nil {
+			cl.apiCallConfig = cl.apiCallConfig.WithPolicyNamespace(policy.GetNamespace())
+			cl.namespaced = policy.IsNamespaced()
+		}
 		return cl
 	}
 }
@@ -42,11 +46,27 @@ func WithAPICallConfig(config apicall.APICallConfiguration) ContextLoaderFactory
 	}
 }
 
+func WithCloudMetadataResolver(resolver engineapi.CloudMetadataResolver) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.cloudResolver = resolver
+	}
+}
+
+func WithCircuitBreaker(breaker *loaders.CircuitBreaker) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.breaker = breaker
+	}
+}
+
 type contextLoader struct {
 	logger        logr.Logger
 	cmResolver    engineapi.ConfigmapResolver
+	cloudResolver engineapi.CloudMetadataResolver
 	initializers  []engineapi.Initializer
 	apiCallConfig apicall.APICallConfiguration
+	breaker       *loaders.CircuitBreaker
+	// namespaced is true when the loader runs the context entries of a namespaced policy
+	namespaced bool
 }
 
 func (l *contextLoader) Load(
@@ -100,7 +120,7 @@ func (l *contextLoader) newLoader(
 		}
 	} else if entry.APICall != nil {
 		if client != nil {
-			ldr := loaders.NewAPILoader(ctx, l.logger, entry, jsonContext, jp, client, l.apiCallConfig)
+			ldr := loaders.NewAPILoader(ctx, l.logger, entry, jsonContext, jp, client, l.apiCallConfig, l.breaker)
 			return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
 		} else {
 			l.logger.Info("disabled loading of APICall context entry", "name", entry.Name)
@@ -108,15 +128,26 @@ func (l *contextLoader) newLoader(
 		}
 	} else if entry.ImageRegistry != nil {
 		if rclientFactory != nil {
-			ldr := loaders.NewImageDataLoader(ctx, l.logger, entry, jsonContext, jp, rclientFactory)
+			ldr := loaders.NewImageDataLoader(ctx, l.logger, entry, jsonContext, jp, rclientFactory, l.breaker)
 			return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
 		} else {
 			l.logger.Info("disabled loading of ImageRegistry context entry", "name", entry.Name)
 			return nil, nil
 		}
+	} else if entry.CloudMetadata != nil {
+		if l.namespaced {
+			return nil, fmt.Errorf("cloudMetadata context entry %s can only be used in cluster policies", entry.Name)
+		}
+		if l.cloudResolver != nil {
+			ldr := loaders.NewCloudMetadataLoader(ctx, l.logger, entry, jsonContext, jp, l.cloudResolver, l.breaker)
+			return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
+		} else {
+			l.logger.Info("disabled loading of CloudMetadata context entry", "name", entry.Name)
+			return nil, nil
+		}
 	} else if entry.Variable != nil {
 		ldr := loaders.NewVariableLoader(l.logger, entry, jsonContext, jp)
 		return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
 	}
-	return nil, fmt.Errorf("missing ConfigMap|APICall|ImageRegistry|Variable in context entry %s", entry.Name)
+	return nil, fmt.Errorf("missing ConfigMap|APICall|ImageRegistry|CloudMetadata|Variable in context entry %s", entry.Name)
 }
@@ -2,6 +2,7 @@ package factories
 
 import (
 	"context"
+	"errors"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
@@ -48,3 +49,15 @@ func (f *registryClientFactory) GetClient(ctx context.Context, creds *kyvernov1.
 	}
 	return f.globalClient, nil
 }
+
+// DisabledRegistryClientFactory returns a factory failing to create registry clients, it is used by engines
+// evaluating untrusted policies that must not reach arbitrary registries
+func DisabledRegistryClientFactory() engineapi.RegistryClientFactory {
+	return disabledRegistryClientFactory{}
+}
+
+type disabledRegistryClientFactory struct{}
+
+func (disabledRegistryClientFactory) GetClient(context.Context, *kyvernov1.ImageRegistryCredentials) (engineapi.RegistryClient, error) {
+	return nil, errors.New("registry access is disabled")
+}
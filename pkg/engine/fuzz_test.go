@@ -44,6 +44,9 @@ var (
 		factories.DefaultContextLoaderFactory(nil),
 		nil,
 		"",
+		nil,
+		nil,
+		nil,
 	)
 	initter sync.Once
 )
@@ -128,6 +131,9 @@ func FuzzVerifyImageAndPatchTest(f *testing.F) {
 			factories.DefaultContextLoaderFactory(nil),
 			nil,
 			"",
+			nil,
+			nil,
+			nil,
 		)
 
 		_, _ = verifyImageAndPatchEngine.VerifyAndPatchImages(
@@ -275,6 +281,9 @@ func FuzzMutateTest(f *testing.F) {
 			factories.DefaultContextLoaderFactory(nil),
 			nil,
 			"",
+			nil,
+			nil,
+			nil,
 		)
 		e.Mutate(
 			context.Background(),
@@ -2,6 +2,7 @@ package mutation
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -13,6 +14,7 @@ import (
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/utils/api"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	"gomodules.xyz/jsonpatch/v2"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -147,6 +149,9 @@ func (f *forEachMutator) mutateElements(ctx context.Context, foreach kyvernov1.F
 }
 
 func buildRuleResponse(rule *kyvernov1.Rule, mutateResp *mutate.Response, info resourceInfo) *engineapi.RuleResponse {
+	if rule.Mutation.DryRun && mutateResp.Status == engineapi.RuleStatusPass {
+		return buildDryRunResponse(rule, mutateResp, info)
+	}
 	message := mutateResp.Message
 	if mutateResp.Status == engineapi.RuleStatusPass {
 		message = buildSuccessMessage(mutateResp.PatchedResource)
@@ -165,6 +170,31 @@ func buildRuleResponse(rule *kyvernov1.Rule, mutateResp *mutate.Response, info r
 	return resp
 }
 
+// buildDryRunResponse reports the patches a dry run rule would have applied, patched targets are not set
+// so that nothing gets applied, a warning is returned when the rule would have changed the resource
+func buildDryRunResponse(rule *kyvernov1.Rule, mutateResp *mutate.Response, info resourceInfo) *engineapi.RuleResponse {
+	original, err := info.unstructured.MarshalJSON()
+	if err != nil {
+		return engineapi.RuleError(rule.Name, engineapi.Mutation, "failed to marshal resource", err)
+	}
+	patched, err := mutateResp.PatchedResource.MarshalJSON()
+	if err != nil {
+		return engineapi.RuleError(rule.Name, engineapi.Mutation, "failed to marshal patched resource", err)
+	}
+	patches, err := jsonpatch.CreatePatch(original, patched)
+	if err != nil {
+		return engineapi.RuleError(rule.Name, engineapi.Mutation, "failed to create patch", err)
+	}
+	if len(patches) == 0 {
+		return engineapi.RulePass(rule.Name, engineapi.Mutation, "dry run, no changes")
+	}
+	raw, err := json.Marshal(patches)
+	if err != nil {
+		return engineapi.RuleError(rule.Name, engineapi.Mutation, "failed to marshal patches", err)
+	}
+	return engineapi.RuleWarn(rule.Name, engineapi.Mutation, fmt.Sprintf("dry run, mutation not applied: %s", raw))
+}
+
 func buildSuccessMessage(r unstructured.Unstructured) string {
 	if r.Object == nil {
 		return "mutated resource"
@@ -0,0 +1,203 @@
+package mutation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	json_patch "github.com/evanphx/json-patch/v5"
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/handlers"
+	"github.com/kyverno/kyverno/pkg/engine/mutate"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
+	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+type digestCacheEntry struct {
+	digest  string
+	expires time.Time
+}
+
+// digestCache holds the resolved digests by image, it is shared by all rules
+var digestCache sync.Map
+
+func getCachedDigest(image string, now time.Time) (string, bool) {
+	if entry, ok := digestCache.Load(image); ok {
+		if entry := entry.(digestCacheEntry); now.Before(entry.expires) {
+			return entry.digest, true
+		}
+		digestCache.Delete(image)
+	}
+	return "", false
+}
+
+func setCachedDigest(image string, digest string, ttl time.Duration, now time.Time) {
+	if ttl <= 0 {
+		return
+	}
+	digestCache.Store(image, digestCacheEntry{digest: digest, expires: now.Add(ttl)})
+}
+
+type resolveDigestHandler struct {
+	configuration  config.Configuration
+	rclientFactory engineapi.RegistryClientFactory
+}
+
+func NewResolveDigestHandler(
+	configuration config.Configuration,
+	rclientFactory engineapi.RegistryClientFactory,
+) (handlers.Handler, error) {
+	return resolveDigestHandler{
+		configuration:  configuration,
+		rclientFactory: rclientFactory,
+	}, nil
+}
+
+func (h resolveDigestHandler) Process(
+	ctx context.Context,
+	logger logr.Logger,
+	policyContext engineapi.PolicyContext,
+	resource unstructured.Unstructured,
+	rule kyvernov1.Rule,
+	contextLoader engineapi.EngineContextLoader,
+	exceptions []kyvernov2beta1.PolicyException,
+) (unstructured.Unstructured, []engineapi.RuleResponse) {
+	// check if there is a policy exception matches the incoming resource
+	exception := engineutils.MatchesException(exceptions, policyContext, logger)
+	if exception != nil {
+		key, err := cache.MetaNamespaceKeyFunc(exception)
+		if err != nil {
+			logger.Error(err, "failed to compute policy exception key", "namespace", exception.GetNamespace(), "name", exception.GetName())
+			return resource, handlers.WithError(rule, engineapi.Mutation, "failed to compute exception key", err)
+		} else {
+			logger.V(3).Info("policy rule skipped due to policy exception", "exception", key)
+			return resource, handlers.WithResponses(
+				engineapi.RuleSkip(rule.Name, engineapi.Mutation, "rule skipped due to policy exception "+key).WithException(exception),
+			)
+		}
+	}
+
+	resolveDigest := rule.Mutation.ResolveDigest
+	images, err := h.matchingImages(resource, rule, resolveDigest.Registries)
+	if err != nil {
+		return resource, handlers.WithError(rule, engineapi.Mutation, "failed to extract images", err)
+	}
+	if len(images) == 0 {
+		return resource, handlers.WithResponses(engineapi.RuleSkip(rule.Name, engineapi.Mutation, "no image tags to resolve"))
+	}
+	rclient, err := h.rclientFactory.GetClient(ctx, resolveDigest.ImageRegistryCredentials)
+	if err != nil {
+		return resource, handlers.WithError(rule, engineapi.Mutation, "failed to fetch secrets", err)
+	}
+	var patches []jsonpatch.JsonPatchOperation
+	for _, image := range images {
+		digest, err := resolveImageDigest(ctx, rclient, image, resolveDigest.GetCacheTTL())
+		if err != nil {
+			if resolveDigest.GetFailurePolicy() == kyvernov1.Ignore {
+				logger.Error(err, "failed to resolve image digest, failure policy is Ignore", "image", image.String())
+				continue
+			}
+			return resource, handlers.WithError(rule, engineapi.Mutation, fmt.Sprintf("failed to resolve digest of image %s", image.String()), err)
+		}
+		logger.V(4).Info("resolved image digest", "image", image.String(), "digest", digest)
+		patches = append(patches, jsonpatch.JsonPatchOperation{
+			Operation: "replace",
+			Path:      image.Pointer,
+			Value:     image.String() + "@" + digest,
+		})
+	}
+	if len(patches) == 0 {
+		return resource, handlers.WithResponses(engineapi.RuleSkip(rule.Name, engineapi.Mutation, "no image digest resolved"))
+	}
+	patchedResource, err := applyPatches(resource, patches)
+	if err != nil {
+		return resource, handlers.WithError(rule, engineapi.Mutation, "failed to apply patch", err)
+	}
+	mutateResp := mutate.NewResponse(engineapi.RuleStatusPass, patchedResource, "")
+	info := resourceInfo{unstructured: resource}
+	if rule.Mutation.DryRun {
+		return resource, handlers.WithResponses(buildRuleResponse(&rule, mutateResp, info))
+	}
+	return patchedResource, handlers.WithResponses(buildRuleResponse(&rule, mutateResp, info))
+}
+
+// matchingImages returns the images of the resource that are referenced by tag and come from one of the registries,
+// sorted by pointer so that digests are resolved in a stable order
+func (h resolveDigestHandler) matchingImages(resource unstructured.Unstructured, rule kyvernov1.Rule, registries []string) ([]apiutils.ImageInfo, error) {
+	infos, err := apiutils.ExtractImagesFromResource(resource, rule.ImageExtractors, h.configuration)
+	if err != nil {
+		return nil, err
+	}
+	var images []apiutils.ImageInfo
+	for _, byName := range infos {
+		for _, image := range byName {
+			if image.Digest != "" || !matchesRegistry(registries, image.Registry) {
+				continue
+			}
+			images = append(images, image)
+		}
+	}
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].Pointer < images[j].Pointer
+	})
+	return images, nil
+}
+
+func matchesRegistry(registries []string, registry string) bool {
+	if len(registries) == 0 {
+		return true
+	}
+	for _, pattern := range registries {
+		if wildcard.Match(pattern, registry) {
+			return true
+		}
+	}
+	return false
+}
+
+func resolveImageDigest(ctx context.Context, rclient engineapi.RegistryClient, image apiutils.ImageInfo, ttl time.Duration) (string, error) {
+	ref := image.String()
+	now := time.Now()
+	if digest, ok := getCachedDigest(ref, now); ok {
+		return digest, nil
+	}
+	desc, err := rclient.FetchImageDescriptor(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	digest := desc.Digest.String()
+	setCachedDigest(ref, digest, ttl, now)
+	return digest, nil
+}
+
+func applyPatches(resource unstructured.Unstructured, patches []jsonpatch.JsonPatchOperation) (unstructured.Unstructured, error) {
+	decoded, err := json_patch.DecodePatch(jsonutils.JoinPatches(patch.ConvertPatches(patches...)...))
+	if err != nil {
+		return resource, err
+	}
+	resourceBytes, err := resource.MarshalJSON()
+	if err != nil {
+		return resource, err
+	}
+	patchedBytes, err := decoded.Apply(resourceBytes)
+	if err != nil {
+		return resource, err
+	}
+	var patched unstructured.Unstructured
+	if err := patched.UnmarshalJSON(patchedBytes); err != nil {
+		return resource, err
+	}
+	return patched, nil
+}
@@ -0,0 +1,161 @@
+package mutation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	gcrv1 "github.com/google/go-containerregistry/pkg/v1"
+	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/policycontext"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const testDigest = "sha256:0000000000000000000000000000000000000000000000000000000000000001"
+
+type fakeRegistryClient struct {
+	engineapi.RegistryClient
+	digests map[string]string
+	calls   []string
+}
+
+func (c *fakeRegistryClient) FetchImageDescriptor(_ context.Context, ref string) (*gcrremote.Descriptor, error) {
+	c.calls = append(c.calls, ref)
+	digest, ok := c.digests[ref]
+	if !ok {
+		return nil, errors.New("manifest unknown")
+	}
+	hash, err := gcrv1.NewHash(digest)
+	if err != nil {
+		return nil, err
+	}
+	return &gcrremote.Descriptor{Descriptor: gcrv1.Descriptor{Digest: hash}}, nil
+}
+
+type fakeRegistryClientFactory struct {
+	client *fakeRegistryClient
+}
+
+func (f fakeRegistryClientFactory) GetClient(context.Context, *kyvernov1.ImageRegistryCredentials) (engineapi.RegistryClient, error) {
+	return f.client, nil
+}
+
+func Test_ResolveDigest(t *testing.T) {
+	pod := []byte(`{
+    "apiVersion": "v1",
+    "kind": "Pod",
+    "metadata": {
+      "name": "test"
+    },
+    "spec": {
+      "initContainers": [{"name": "init", "image": "docker.io/busybox:1.36"}],
+      "containers": [
+        {"name": "app", "image": "ghcr.io/kyverno/app:v1"},
+        {"name": "pinned", "image": "ghcr.io/kyverno/app@` + testDigest + `"},
+        {"name": "missing", "image": "ghcr.io/kyverno/missing:v1"}
+      ]
+    }
+  }`)
+	ignore := kyvernov1.Ignore
+	tests := []struct {
+		name          string
+		resolveDigest kyvernov1.ResolveDigest
+		status        engineapi.RuleStatus
+		images        map[string]string
+		calls         []string
+	}{{
+		name:          "fail on unresolved digest",
+		resolveDigest: kyvernov1.ResolveDigest{CacheTTL: &metav1.Duration{}},
+		status:        engineapi.RuleStatusError,
+		calls:         []string{"ghcr.io/kyverno/app:v1", "ghcr.io/kyverno/missing:v1"},
+	}, {
+		name:          "ignore unresolved digest",
+		resolveDigest: kyvernov1.ResolveDigest{FailurePolicy: &ignore, CacheTTL: &metav1.Duration{}},
+		status:        engineapi.RuleStatusPass,
+		images: map[string]string{
+			"init":    "docker.io/busybox:1.36@" + testDigest,
+			"app":     "ghcr.io/kyverno/app:v1@" + testDigest,
+			"missing": "ghcr.io/kyverno/missing:v1",
+		},
+		calls: []string{"ghcr.io/kyverno/app:v1", "ghcr.io/kyverno/missing:v1", "docker.io/busybox:1.36"},
+	}, {
+		name:          "registries",
+		resolveDigest: kyvernov1.ResolveDigest{Registries: []string{"docker.io"}, CacheTTL: &metav1.Duration{}},
+		status:        engineapi.RuleStatusPass,
+		images: map[string]string{
+			"init":    "docker.io/busybox:1.36@" + testDigest,
+			"app":     "ghcr.io/kyverno/app:v1",
+			"missing": "ghcr.io/kyverno/missing:v1",
+		},
+		calls: []string{"docker.io/busybox:1.36"},
+	}, {
+		name:          "no matching registry",
+		resolveDigest: kyvernov1.ResolveDigest{Registries: []string{"quay.io"}},
+		status:        engineapi.RuleStatusSkip,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, err := kubeutils.BytesToUnstructured(pod)
+			require.NoError(t, err)
+			cfg := config.NewDefaultConfiguration(false)
+			policyContext, err := policycontext.NewPolicyContext(jmespath.New(cfg), *resource, kyvernov1.Create, nil, cfg)
+			require.NoError(t, err)
+			rclient := &fakeRegistryClient{digests: map[string]string{
+				"docker.io/busybox:1.36": testDigest,
+				"ghcr.io/kyverno/app:v1": testDigest,
+			}}
+			rule := kyvernov1.Rule{Name: "resolve", Mutation: kyvernov1.Mutation{ResolveDigest: &tt.resolveDigest}}
+			handler, err := NewResolveDigestHandler(cfg, fakeRegistryClientFactory{client: rclient})
+			require.NoError(t, err)
+			patched, responses := handler.Process(context.TODO(), logr.Discard(), policyContext, *resource, rule, nil, nil)
+			require.Len(t, responses, 1)
+			assert.Equal(t, tt.status, responses[0].Status())
+			assert.Equal(t, tt.calls, rclient.calls)
+			if tt.images == nil {
+				assert.Equal(t, *resource, patched)
+				return
+			}
+			for _, field := range []string{"initContainers", "containers"} {
+				containers, _, err := unstructured.NestedSlice(patched.Object, "spec", field)
+				require.NoError(t, err)
+				for _, container := range containers {
+					container := container.(map[string]interface{})
+					name, image := container["name"].(string), container["image"].(string)
+					if expected, ok := tt.images[name]; ok {
+						assert.Equal(t, expected, image)
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_ResolveDigestCache(t *testing.T) {
+	rclient := &fakeRegistryClient{digests: map[string]string{"ghcr.io/kyverno/cached:v1": testDigest}}
+	cfg := config.NewDefaultConfiguration(false)
+	resource, err := kubeutils.BytesToUnstructured([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test"},"spec":{"containers":[{"name":"app","image":"ghcr.io/kyverno/cached:v1"}]}}`))
+	require.NoError(t, err)
+	handler := resolveDigestHandler{configuration: cfg}
+	images, err := handler.matchingImages(*resource, kyvernov1.Rule{}, nil)
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	for i := 0; i < 2; i++ {
+		digest, err := resolveImageDigest(context.TODO(), rclient, images[0], time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, testDigest, digest)
+	}
+	assert.Equal(t, []string{"ghcr.io/kyverno/cached:v1"}, rclient.calls)
+	setCachedDigest("ghcr.io/kyverno/cached:v1", testDigest, time.Minute, time.Now().Add(-time.Hour))
+	_, ok := getCachedDigest("ghcr.io/kyverno/cached:v1", time.Now())
+	assert.False(t, ok)
+}
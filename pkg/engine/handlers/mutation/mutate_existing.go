@@ -84,7 +84,7 @@ func (h mutateExistingHandler) Process(
 		}
 		if !preconditionsPassed {
 			s := stringutils.JoinNonEmpty([]string{"preconditions not met", msg}, "; ")
-			rr := engineapi.RuleSkip(rule.Name, engineapi.Mutation, s)
+			rr := engineapi.RuleSkip(rule.Name, engineapi.Mutation, s).WithSkipReason(engineapi.SkipReasonPreconditionsNotMet)
 			responses = append(responses, *rr)
 			continue
 		}
@@ -30,6 +30,7 @@ type mutateImageHandler struct {
 	ivm                      *engineapi.ImageVerificationMetadata
 	images                   []apiutils.ImageInfo
 	imageSignatureRepository string
+	secretResolver           engineapi.SecretResolver
 }
 
 func NewMutateImageHandler(
@@ -41,6 +42,7 @@ func NewMutateImageHandler(
 	ivCache imageverifycache.Client,
 	ivm *engineapi.ImageVerificationMetadata,
 	imageSignatureRepository string,
+	secretResolver engineapi.SecretResolver,
 ) (handlers.Handler, error) {
 	if len(rule.VerifyImages) == 0 {
 		return nil, nil
@@ -59,6 +61,7 @@ func NewMutateImageHandler(
 		ivCache:                  ivCache,
 		images:                   ruleImages,
 		imageSignatureRepository: imageSignatureRepository,
+		secretResolver:           secretResolver,
 	}, nil
 }
 
@@ -102,7 +105,7 @@ func (h mutateImageHandler) Process(
 				engineapi.RuleError(rule.Name, engineapi.ImageVerify, "failed to fetch secrets", err),
 			)
 		}
-		iv := internal.NewImageVerifier(logger, rclient, h.ivCache, policyContext, *ruleCopy, h.ivm, h.imageSignatureRepository)
+		iv := internal.NewImageVerifier(logger, rclient, h.ivCache, policyContext, *ruleCopy, h.ivm, h.imageSignatureRepository, h.secretResolver)
 		patch, ruleResponse := iv.Verify(ctx, imageVerify, h.images, h.configuration)
 		patches = append(patches, patch...)
 		engineResponses = append(engineResponses, ruleResponse...)
@@ -75,5 +75,8 @@ func (h mutateResourceHandler) Process(
 	if mutateResp == nil {
 		return resource, nil
 	}
+	if rule.Mutation.DryRun {
+		return resource, handlers.WithResponses(buildRuleResponse(&rule, mutateResp, resourceInfo))
+	}
 	return mutateResp.PatchedResource, handlers.WithResponses(buildRuleResponse(&rule, mutateResp, resourceInfo))
 }
@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// baselineContextEntry is the context entry the baseline ConfigMap is loaded into
+const baselineContextEntry = "baseline"
+
+func (v *validator) validateBaseline(ctx context.Context) *engineapi.RuleResponse {
+	if engineutils.IsDeleteRequest(v.policyContext) {
+		return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, "baseline values are not checked on deletes")
+	}
+	value, err := queryField(v.baseline.JMESPath, v.policyContext.NewResource())
+	if err != nil {
+		return engineapi.RuleError(v.rule.Name, engineapi.Validation, fmt.Sprintf("failed to query %s", v.baseline.JMESPath), err)
+	}
+	values, err := baselineValues(value)
+	if err != nil {
+		return engineapi.RuleError(v.rule.Name, engineapi.Validation, "failed to convert baseline values", err)
+	}
+	if v.baseline.Mode == kyvernov1.BaselineRecord {
+		data, err := json.Marshal(values)
+		if err != nil {
+			return engineapi.RuleError(v.rule.Name, engineapi.Validation, "failed to marshal baseline values", err)
+		}
+		msg := fmt.Sprintf("%d values observed, recording baseline", len(values))
+		return engineapi.RulePass(v.rule.Name, engineapi.Validation, msg).WithProperties(map[string]string{engineapi.BaselineValuesProperty: string(data)})
+	}
+	if len(values) == 0 {
+		return engineapi.RulePass(v.rule.Name, engineapi.Validation, v.getDenyMessage(false, ""))
+	}
+	recorded, err := v.loadBaseline(ctx)
+	if err != nil {
+		return engineapi.RuleError(v.rule.Name, engineapi.Validation, "failed to load baseline", err)
+	}
+	var unknown []string
+	for _, value := range values {
+		if !recorded.Has(value) {
+			unknown = append(unknown, value)
+		}
+	}
+	if len(unknown) != 0 {
+		msg := fmt.Sprintf("values are not part of the recorded baseline: %s", strings.Join(unknown, ", "))
+		return engineapi.RuleFail(v.rule.Name, engineapi.Validation, v.getDenyMessage(true, msg))
+	}
+	return engineapi.RulePass(v.rule.Name, engineapi.Validation, v.getDenyMessage(false, ""))
+}
+
+// loadBaseline returns the values recorded in the baseline ConfigMap, a ConfigMap without values is an empty baseline
+func (v *validator) loadBaseline(ctx context.Context) (sets.Set[string], error) {
+	configMap := v.baseline.ConfigMap
+	entries := []kyvernov1.ContextEntry{{Name: baselineContextEntry, ConfigMap: &configMap}}
+	if err := v.contextLoader(ctx, entries, v.policyContext.JSONContext()); err != nil {
+		return nil, err
+	}
+	data, err := v.policyContext.JSONContext().Query(baselineContextEntry + ".data.values")
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return sets.New[string](), nil
+	}
+	raw, ok := data.(string)
+	if !ok {
+		return nil, fmt.Errorf("baseline values must be a string")
+	}
+	var values []string
+	if err := json.Unmarshal([]byte(raw), &values); err != nil {
+		return nil, fmt.Errorf("baseline values must be a JSON array of strings: %w", err)
+	}
+	return sets.New(values...), nil
+}
+
+// baselineValues converts the result of a baseline query to a sorted list of unique strings,
+// values that are not strings are stored as JSON
+func baselineValues(value interface{}) ([]string, error) {
+	var items []interface{}
+	switch typed := value.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		items = typed
+	default:
+		items = []interface{}{typed}
+	}
+	values := sets.New[string]()
+	for _, item := range items {
+		switch typed := item.(type) {
+		case nil:
+		case string:
+			values.Insert(typed)
+		default:
+			data, err := json.Marshal(typed)
+			if err != nil {
+				return nil, err
+			}
+			values.Insert(string(data))
+		}
+	}
+	return sets.List(values), nil
+}
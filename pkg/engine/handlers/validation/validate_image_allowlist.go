@@ -0,0 +1,167 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/handlers"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+type validateImageAllowListHandler struct {
+	resolver engineapi.ImageAllowListResolver
+}
+
+func NewValidateImageAllowListHandler(resolver engineapi.ImageAllowListResolver) (handlers.Handler, error) {
+	return validateImageAllowListHandler{
+		resolver: resolver,
+	}, nil
+}
+
+func (h validateImageAllowListHandler) Process(
+	ctx context.Context,
+	logger logr.Logger,
+	policyContext engineapi.PolicyContext,
+	resource unstructured.Unstructured,
+	rule kyvernov1.Rule,
+	_ engineapi.EngineContextLoader,
+	exceptions []kyvernov2beta1.PolicyException,
+) (unstructured.Unstructured, []engineapi.RuleResponse) {
+	if engineutils.IsDeleteRequest(policyContext) {
+		logger.V(3).Info("skipping image allow list validation on deleted resource")
+		return resource, nil
+	}
+
+	// check if there is a policy exception matches the incoming resource
+	exception := engineutils.MatchesException(exceptions, policyContext, logger)
+	if exception != nil {
+		key, err := cache.MetaNamespaceKeyFunc(exception)
+		if err != nil {
+			logger.Error(err, "failed to compute policy exception key", "namespace", exception.GetNamespace(), "name", exception.GetName())
+			return resource, handlers.WithError(rule, engineapi.Validation, "failed to compute exception key", err)
+		} else {
+			logger.V(3).Info("policy rule skipped due to policy exception", "exception", key)
+			return resource, handlers.WithResponses(
+				engineapi.RuleSkip(rule.Name, engineapi.Validation, "rule skipped due to policy exception "+key).WithException(exception),
+			)
+		}
+	}
+
+	if h.resolver == nil {
+		return resource, handlers.WithError(rule, engineapi.Validation, "failed to resolve image allow lists", errors.New("image allow lists are not enabled"))
+	}
+	var lists []*kyvernov2alpha1.ImageAllowList
+	for _, name := range rule.Validation.ImageAllowList.Names {
+		list, err := h.resolver.Get(name)
+		if err != nil {
+			return resource, handlers.WithError(rule, engineapi.Validation, fmt.Sprintf("failed to get image allow list %s", name), err)
+		}
+		lists = append(lists, list)
+	}
+
+	var violations, images []string
+	for _, infoMap := range sortedImageInfos(policyContext.JSONContext().ImageInfo()) {
+		for _, entry := range infoMap {
+			image := entry.info.String()
+			if reason := checkAllowedImage(lists, entry.info); reason != "" {
+				logger.V(4).Info("image not allowed", "image", image, "container", entry.name, "reason", reason)
+				violations = append(violations, fmt.Sprintf("image %s of container %s %s", image, entry.name, reason))
+				images = append(images, image)
+			}
+		}
+	}
+	if len(violations) != 0 {
+		msg := fmt.Sprintf("images are not allowed by image allow lists %s: %s", strings.Join(rule.Validation.ImageAllowList.Names, ", "), strings.Join(violations, "; "))
+		return resource, handlers.WithResponses(
+			engineapi.RuleFail(rule.Name, engineapi.Validation, msg).WithProperties(map[string]string{engineapi.ImagesProperty: strings.Join(images, ",")}),
+		)
+	}
+	return resource, handlers.WithPass(rule, engineapi.Validation, "all images are allowed")
+}
+
+type namedImageInfo struct {
+	name string
+	info apiutils.ImageInfo
+}
+
+// sortedImageInfos orders image infos by container type and name so that messages are stable
+func sortedImageInfos(infos map[string]map[string]apiutils.ImageInfo) [][]namedImageInfo {
+	keys := make([]string, 0, len(infos))
+	for key := range infos {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var result [][]namedImageInfo
+	for _, key := range keys {
+		var entries []namedImageInfo
+		for name, info := range infos[key] {
+			entries = append(entries, namedImageInfo{name: name, info: info})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+		result = append(result, entries)
+	}
+	return result
+}
+
+// checkAllowedImage returns an empty string if one of the lists allows the image,
+// otherwise the reason the image is not allowed
+func checkAllowedImage(lists []*kyvernov2alpha1.ImageAllowList, info apiutils.ImageInfo) string {
+	reason := "is not allowed"
+	for _, list := range lists {
+		allowed, listReason := isAllowedImage(list.Spec, info)
+		if allowed {
+			return ""
+		}
+		if listReason != "" {
+			reason = listReason
+		}
+	}
+	return reason
+}
+
+func isAllowedImage(spec kyvernov2alpha1.ImageAllowListSpec, info apiutils.ImageInfo) (bool, string) {
+	if spec.RequireDigest && info.Digest == "" {
+		return false, "must be referenced by digest"
+	}
+	repository := info.Path
+	if info.Registry != "" {
+		repository = info.Registry + "/" + info.Path
+	}
+	for _, registry := range spec.Registries {
+		if wildcard.Match(registry, info.Registry) {
+			return true, ""
+		}
+	}
+	for _, allowed := range spec.Repositories {
+		if wildcard.Match(allowed, repository) {
+			return true, ""
+		}
+	}
+	var reason string
+	for _, image := range spec.Images {
+		if image.Repository != repository {
+			continue
+		}
+		if info.Digest == "" {
+			reason = "must be pinned to one of the allowed digests"
+		} else if slices.Contains(image.Digests, info.Digest) {
+			return true, ""
+		} else {
+			reason = fmt.Sprintf("uses digest %s which is not allowed", info.Digest)
+		}
+	}
+	return false, reason
+}
@@ -0,0 +1,84 @@
+package validation
+
+import (
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
+	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
+	imageutils "github.com/kyverno/kyverno/pkg/utils/image"
+	"gotest.tools/assert"
+)
+
+func Test_checkAllowedImage(t *testing.T) {
+	digest := "sha256:128c6e3534b842a2eec139999b8ce8aa9a2af9907e2b9269550809d18cd832a3"
+	other := "sha256:00000000000000000000000000000000000000000000000000000000000000aa"
+	registries := &kyvernov2alpha1.ImageAllowList{Spec: kyvernov2alpha1.ImageAllowListSpec{
+		Registries:   []string{"ghcr.io"},
+		Repositories: []string{"docker.io/library/*"},
+	}}
+	pinned := &kyvernov2alpha1.ImageAllowList{Spec: kyvernov2alpha1.ImageAllowListSpec{
+		Images: []kyvernov2alpha1.AllowedImage{{
+			Repository: "quay.io/acme/app",
+			Digests:    []string{digest},
+		}},
+	}}
+	requireDigest := &kyvernov2alpha1.ImageAllowList{Spec: kyvernov2alpha1.ImageAllowListSpec{
+		Registries:    []string{"*"},
+		RequireDigest: true,
+	}}
+	tests := []struct {
+		name  string
+		lists []*kyvernov2alpha1.ImageAllowList
+		image string
+		want  string
+	}{{
+		name:  "allowed registry",
+		lists: []*kyvernov2alpha1.ImageAllowList{registries},
+		image: "ghcr.io/kyverno/kyverno:latest",
+	}, {
+		name:  "allowed repository",
+		lists: []*kyvernov2alpha1.ImageAllowList{registries},
+		image: "docker.io/library/nginx:1.25",
+	}, {
+		name:  "not allowed",
+		lists: []*kyvernov2alpha1.ImageAllowList{registries},
+		image: "quay.io/acme/app:v1",
+		want:  "is not allowed",
+	}, {
+		name:  "allowed digest",
+		lists: []*kyvernov2alpha1.ImageAllowList{pinned},
+		image: "quay.io/acme/app@" + digest,
+	}, {
+		name:  "missing digest",
+		lists: []*kyvernov2alpha1.ImageAllowList{pinned},
+		image: "quay.io/acme/app:v1",
+		want:  "must be pinned to one of the allowed digests",
+	}, {
+		name:  "digest not allowed",
+		lists: []*kyvernov2alpha1.ImageAllowList{pinned},
+		image: "quay.io/acme/app@" + other,
+		want:  "uses digest " + other + " which is not allowed",
+	}, {
+		name:  "any list allows",
+		lists: []*kyvernov2alpha1.ImageAllowList{pinned, registries},
+		image: "ghcr.io/kyverno/kyverno:latest",
+	}, {
+		name:  "digest required",
+		lists: []*kyvernov2alpha1.ImageAllowList{requireDigest},
+		image: "ghcr.io/kyverno/kyverno:latest",
+		want:  "must be referenced by digest",
+	}, {
+		name:  "digest provided",
+		lists: []*kyvernov2alpha1.ImageAllowList{requireDigest},
+		image: "ghcr.io/kyverno/kyverno@" + digest,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := imageutils.GetImageInfo(tt.image, config.NewDefaultConfiguration(false))
+			assert.NilError(t, err)
+			got := checkAllowedImage(tt.lists, apiutils.ImageInfo{ImageInfo: *info})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
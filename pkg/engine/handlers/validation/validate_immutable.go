@@ -0,0 +1,118 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	gojmespath "github.com/kyverno/go-jmespath"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func (v *validator) validateImmutable() *engineapi.RuleResponse {
+	if !engineutils.IsUpdateRequest(v.policyContext) {
+		return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, "immutable fields are only checked on updates")
+	}
+	immutable, err := v.isImmutable(time.Now())
+	if err != nil {
+		return engineapi.RuleError(v.rule.Name, engineapi.Validation, "failed to evaluate immutable conditions", err)
+	}
+	if !immutable {
+		return engineapi.RulePass(v.rule.Name, engineapi.Validation, v.getDenyMessage(false, ""))
+	}
+	var changed []string
+	for _, field := range v.immutable.Fields {
+		fieldChanged, err := v.hasFieldChanged(field)
+		if err != nil {
+			return engineapi.RuleError(v.rule.Name, engineapi.Validation, fmt.Sprintf("failed to compare field %s", field), err)
+		}
+		if fieldChanged {
+			changed = append(changed, field)
+		}
+	}
+	if len(changed) != 0 {
+		msg := fmt.Sprintf("immutable fields cannot be changed: %s", strings.Join(changed, ", "))
+		return engineapi.RuleFail(v.rule.Name, engineapi.Validation, v.getDenyMessage(true, msg))
+	}
+	return engineapi.RulePass(v.rule.Name, engineapi.Validation, v.getDenyMessage(false, ""))
+}
+
+// isImmutable returns true if fields are always immutable, if the time falls in one
+// of the configured windows or if the existing resource matches the selector
+func (v *validator) isImmutable(now time.Time) (bool, error) {
+	if len(v.immutable.Windows) == 0 && v.immutable.Selector == nil {
+		return true, nil
+	}
+	if v.immutable.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(v.immutable.Selector)
+		if err != nil {
+			return false, fmt.Errorf("invalid selector: %w", err)
+		}
+		oldResource := v.policyContext.OldResource()
+		if selector.Matches(labels.Set(oldResource.GetLabels())) {
+			return true, nil
+		}
+	}
+	for _, window := range v.immutable.Windows {
+		inWindow, err := v.isInWindow(window, now)
+		if err != nil {
+			return false, err
+		}
+		if inWindow {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (v *validator) isInWindow(window kyvernov1.TimeWindow, now time.Time) (bool, error) {
+	start, err := v.parseTime(window.Start)
+	if err != nil {
+		return false, fmt.Errorf("invalid window start: %w", err)
+	}
+	end, err := v.parseTime(window.End)
+	if err != nil {
+		return false, fmt.Errorf("invalid window end: %w", err)
+	}
+	return !now.Before(start) && now.Before(end), nil
+}
+
+func (v *validator) parseTime(value string) (time.Time, error) {
+	raw, err := variables.SubstituteAll(v.log, v.policyContext.JSONContext(), value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	str, ok := raw.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("time %v must be a string", raw)
+	}
+	return time.Parse(time.RFC3339, str)
+}
+
+// hasFieldChanged compares a field of the new and old resources, adding or removing the field is a change
+func (v *validator) hasFieldChanged(field string) (bool, error) {
+	newValue, err := queryField(field, v.policyContext.NewResource())
+	if err != nil {
+		return false, err
+	}
+	oldValue, err := queryField(field, v.policyContext.OldResource())
+	if err != nil {
+		return false, err
+	}
+	return !datautils.DeepEqual(newValue, oldValue), nil
+}
+
+func queryField(field string, resource unstructured.Unstructured) (interface{}, error) {
+	value, err := gojmespath.Search(field, resource.Object)
+	if _, ok := err.(gojmespath.NotFoundError); ok {
+		return nil, nil
+	}
+	return value, err
+}
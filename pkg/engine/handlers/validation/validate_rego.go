@@ -0,0 +1,28 @@
+package validation
+
+import (
+	"context"
+	"strings"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/rego"
+	"github.com/kyverno/kyverno/pkg/toggle"
+)
+
+func (v *validator) validateRego(ctx context.Context) *engineapi.RuleResponse {
+	if !toggle.FromContext(ctx).EnableRegoRules() {
+		return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, "rego rules are disabled")
+	}
+	request, err := v.policyContext.JSONContext().Query("request")
+	if err != nil {
+		return engineapi.RuleError(v.rule.Name, engineapi.Validation, "failed to query admission request", err)
+	}
+	violations, err := rego.Evaluate(ctx, *v.rego, map[string]interface{}{"request": request})
+	if err != nil {
+		return engineapi.RuleError(v.rule.Name, engineapi.Validation, "failed to evaluate rego query", err)
+	}
+	if len(violations) != 0 {
+		return engineapi.RuleFail(v.rule.Name, engineapi.Validation, v.getDenyMessage(true, strings.Join(violations, "; ")))
+	}
+	return engineapi.RulePass(v.rule.Name, engineapi.Validation, v.getDenyMessage(false, ""))
+}
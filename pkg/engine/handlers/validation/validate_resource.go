@@ -67,6 +67,9 @@ type validator struct {
 	pattern          apiextensions.JSON
 	anyPattern       apiextensions.JSON
 	deny             *kyvernov1.Deny
+	immutable        *kyvernov1.Immutable
+	baseline         *kyvernov1.Baseline
+	rego             *kyvernov1.Rego
 	forEach          []kyvernov1.ForEachValidation
 	contextLoader    engineapi.EngineContextLoader
 	nesting          int
@@ -82,6 +85,9 @@ func newValidator(log logr.Logger, contextLoader engineapi.EngineContextLoader,
 		pattern:          rule.Validation.GetPattern(),
 		anyPattern:       rule.Validation.GetAnyPattern(),
 		deny:             rule.Validation.Deny,
+		immutable:        rule.Validation.Immutable,
+		baseline:         rule.Validation.Baseline,
+		rego:             rule.Validation.Rego,
 		anyAllConditions: anyAllConditions,
 		forEach:          rule.Validation.ForEachValidation,
 	}
@@ -128,13 +134,25 @@ func (v *validator) validate(ctx context.Context) *engineapi.RuleResponse {
 	}
 	if !preconditionsPassed {
 		s := stringutils.JoinNonEmpty([]string{"preconditions not met", msg}, "; ")
-		return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, s)
+		return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, s).WithSkipReason(engineapi.SkipReasonPreconditionsNotMet)
 	}
 
 	if v.deny != nil {
 		return v.validateDeny()
 	}
 
+	if v.immutable != nil {
+		return v.validateImmutable()
+	}
+
+	if v.baseline != nil {
+		return v.validateBaseline(ctx)
+	}
+
+	if v.rego != nil {
+		return v.validateRego(ctx)
+	}
+
 	if v.pattern != nil || v.anyPattern != nil {
 		if err = v.substitutePatterns(); err != nil {
 			return engineapi.RuleError(v.rule.Name, engineapi.Validation, "variable substitution failed", err)
@@ -153,7 +171,7 @@ func (v *validator) validate(ctx context.Context) *engineapi.RuleResponse {
 				if ruleResponse.Status() == engineapi.RuleStatusPass {
 					return ruleResponse
 				}
-				return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, "skipping modified resource as validation results have not changed")
+				return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, "skipping modified resource as validation results have not changed").WithSkipReason(engineapi.SkipReasonResultUnchanged)
 			}
 		}
 
@@ -165,7 +183,7 @@ func (v *validator) validate(ctx context.Context) *engineapi.RuleResponse {
 		return ruleResponse
 	}
 
-	v.log.V(2).Info("invalid validation rule: podSecurity, cel, patterns, or deny expected")
+	v.log.V(2).Info("invalid validation rule: podSecurity, cel, patterns, deny, immutable, baseline or rego expected")
 	return nil
 }
 
@@ -201,7 +219,7 @@ func (v *validator) validateForEach(ctx context.Context) *engineapi.RuleResponse
 		if v.forEach == nil {
 			return nil
 		}
-		return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, "rule skipped")
+		return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, "rule skipped").WithSkipReason(engineapi.SkipReasonNoElements)
 	}
 	return engineapi.RulePass(v.rule.Name, engineapi.Validation, "rule passed")
 }
@@ -311,11 +329,16 @@ func (v *validator) validateResourceWithRule() *engineapi.RuleResponse {
 		v.log.V(3).Info("skipping validation on deleted resource")
 		return nil
 	}
-	resp := v.validatePatterns(v.policyContext.NewResource())
+	resource := v.policyContext.NewResource()
+	if v.rule.Validation.StripServerMetadata() {
+		resource = engineutils.StripServerMetadata(resource)
+	}
+	resp := v.validatePatterns(resource)
 	return resp
 }
 
-// validatePatterns validate pattern and anyPattern
+// validatePatterns validate pattern and anyPattern, when both are set the resource must match the pattern
+// and at least one of the anyPattern entries
 func (v *validator) validatePatterns(resource unstructured.Unstructured) *engineapi.RuleResponse {
 	if v.pattern != nil {
 		if err := validate.MatchPattern(v.log, resource.Object, v.pattern); err != nil {
@@ -324,22 +347,27 @@ func (v *validator) validatePatterns(resource unstructured.Unstructured) *engine
 				v.log.V(3).Info("validation error", "path", pe.Path, "error", err.Error())
 
 				if pe.Skip {
-					return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, pe.Error())
+					return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, pe.Error()).WithSkipReason(engineapi.SkipReasonAnchorsNotMet)
 				}
 
 				if pe.Path == "" {
 					return engineapi.RuleError(v.rule.Name, engineapi.Validation, v.buildErrorMessage(err, ""), nil)
 				}
 
-				return engineapi.RuleFail(v.rule.Name, engineapi.Validation, v.buildErrorMessage(err, pe.Path))
+				return engineapi.RuleFail(v.rule.Name, engineapi.Validation, v.buildErrorMessage(err, pe.Path)).WithProperties(map[string]string{
+					engineapi.PathProperty: pe.Path,
+				})
 			}
 
 			return engineapi.RuleError(v.rule.Name, engineapi.Validation, v.buildErrorMessage(err, pe.Path), nil)
 		}
 
-		v.log.V(4).Info("successfully processed rule")
-		msg := fmt.Sprintf("validation rule '%s' passed.", v.rule.Name)
-		return engineapi.RulePass(v.rule.Name, engineapi.Validation, msg)
+		// when combined with anyPattern, the resource must also match one of the anyPattern entries
+		if v.anyPattern == nil {
+			v.log.V(4).Info("successfully processed rule")
+			msg := fmt.Sprintf("validation rule '%s' passed.", v.rule.Name)
+			return engineapi.RulePass(v.rule.Name, engineapi.Validation, msg)
+		}
 	}
 
 	if v.anyPattern != nil {
@@ -384,7 +412,7 @@ func (v *validator) validatePatterns(resource unstructured.Unstructured) *engine
 				errorStr = append(errorStr, err.Error())
 			}
 			v.log.V(4).Info(fmt.Sprintf("Validation rule '%s' skipped. %s", v.rule.Name, errorStr))
-			return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, strings.Join(errorStr, " "))
+			return engineapi.RuleSkip(v.rule.Name, engineapi.Validation, strings.Join(errorStr, " ")).WithSkipReason(engineapi.SkipReasonAnchorsNotMet)
 		} else if len(failedAnyPatternsErrors) > 0 {
 			var errorStr []string
 			for _, err := range failedAnyPatternsErrors {
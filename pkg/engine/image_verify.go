@@ -44,6 +44,7 @@ func (e *engine) verifyAndPatchImages(
 				e.ivCache,
 				&ivm,
 				e.imageSignatureRepository,
+				e.secretResolver,
 			)
 		}
 		resource, ruleResp := e.invokeRuleHandler(
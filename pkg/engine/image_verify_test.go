@@ -323,6 +323,9 @@ func testVerifyAndPatchImages(
 		factories.DefaultContextLoaderFactory(cmResolver),
 		nil,
 		"",
+		nil,
+		nil,
+		nil,
 	)
 	return e.VerifyAndPatchImages(
 		ctx,
@@ -709,6 +712,15 @@ func Test_SignaturesMultiKeyZeroGoodKey(t *testing.T) {
 	assert.Equal(t, resp.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusFail, resp.PolicyResponse.Rules[0].Message())
 }
 
+func Test_SignaturesAttestorsImageReferencesNoMatch(t *testing.T) {
+	policy := strings.Replace(testSampleMultipleKeyPolicy, "KEY1", testOtherKey, -1)
+	policy = strings.Replace(policy, "KEY2", testOtherKey, -1)
+	policy = strings.Replace(policy, `"count": COUNT,`, `"count": 1, "imageReferences": ["docker.io/*"],`, -1)
+	policyContext := buildContext(t, policy, testSampleResource, "")
+	resp, _ := testVerifyAndPatchImages(context.TODO(), registryclient.NewOrDie(), nil, policyContext, cfg)
+	assert.Equal(t, len(resp.PolicyResponse.Rules), 0)
+}
+
 func Test_RuleSelectorImageVerify(t *testing.T) {
 
 	policyContext := buildContext(t, testSampleSingleKeyPolicy, testSampleResource, "")
@@ -1076,6 +1088,9 @@ func testImageVerifyCache(
 		factories.DefaultContextLoaderFactory(cmResolver),
 		nil,
 		"",
+		nil,
+		nil,
+		nil,
 	)
 	return e.VerifyAndPatchImages(
 		ctx,
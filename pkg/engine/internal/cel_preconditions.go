@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	celutils "github.com/kyverno/kyverno/pkg/utils/cel"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/admission/plugin/cel"
+	"k8s.io/apiserver/pkg/admission/plugin/webhook/matchconditions"
+)
+
+// CheckCELPreconditions evaluates the CEL preconditions of a rule against the admission request,
+// it returns false and the name of the first condition evaluating to false when the preconditions are not met
+func CheckCELPreconditions(
+	ctx context.Context,
+	policyContext engineapi.PolicyContext,
+	resource unstructured.Unstructured,
+	conditions []admissionregistrationv1alpha1.MatchCondition,
+) (bool, string, error) {
+	if len(conditions) == 0 {
+		return true, "", nil
+	}
+	compiler, err := celutils.NewCompiler(nil, nil, conditions, nil)
+	if err != nil {
+		return false, "", err
+	}
+	filter := compiler.CompileMatchExpressions(cel.OptionalVariableDeclarations{})
+	policy := policyContext.Policy()
+	matcher := matchconditions.NewMatcher(filter, nil, policy.GetKind(), "", policy.GetName())
+
+	gvk := resource.GroupVersionKind()
+	namespace := resource.GetNamespace()
+	// the namespace object has the namespace of itself, unset it
+	if gvk.Kind == "Namespace" && gvk.Version == "v1" && gvk.Group == "" {
+		namespace = ""
+	}
+	var object, oldObject runtime.Object
+	if resource.Object != nil {
+		object = resource.DeepCopyObject()
+	}
+	if oldResource := policyContext.OldResource(); oldResource.Object != nil {
+		oldObject = oldResource.DeepCopyObject()
+	}
+	requestInfo := policyContext.AdmissionInfo()
+	userInfo := NewUser(requestInfo.AdmissionUserInfo.Username, requestInfo.AdmissionUserInfo.UID, requestInfo.AdmissionUserInfo.Groups)
+	attributes := admission.NewAttributesRecord(
+		object,
+		oldObject,
+		gvk,
+		namespace,
+		resource.GetName(),
+		schema.GroupVersionResource(policyContext.RequestResource()),
+		"",
+		admission.Operation(policyContext.Operation()),
+		nil,
+		false,
+		&userInfo,
+	)
+	versionedAttributes, err := admission.NewVersionedAttributes(attributes, attributes.GetKind(), nil)
+	if err != nil {
+		return false, "", err
+	}
+	result := matcher.Match(ctx, versionedAttributes, nil, nil)
+	if result.Error != nil {
+		return false, "", fmt.Errorf("failed to evaluate CEL preconditions: %w", result.Error)
+	}
+	if !result.Matches {
+		return false, fmt.Sprintf("CEL precondition %s not met", result.FailedConditionName), nil
+	}
+	return true, "", nil
+}
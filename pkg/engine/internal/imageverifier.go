@@ -21,10 +21,12 @@ import (
 	"github.com/kyverno/kyverno/pkg/images"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/notary"
+	"github.com/kyverno/kyverno/pkg/secrets"
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
 	"github.com/kyverno/kyverno/pkg/utils/jsonpointer"
 	"go.uber.org/multierr"
 	"gomodules.xyz/jsonpatch/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -36,6 +38,7 @@ type ImageVerifier struct {
 	rule                     kyvernov1.Rule
 	ivm                      *engineapi.ImageVerificationMetadata
 	imageSignatureRepository string
+	secretResolver           engineapi.SecretResolver
 }
 
 func NewImageVerifier(
@@ -46,6 +49,7 @@ func NewImageVerifier(
 	rule kyvernov1.Rule,
 	ivm *engineapi.ImageVerificationMetadata,
 	imageSignatureRepository string,
+	secretResolver engineapi.SecretResolver,
 ) *ImageVerifier {
 	return &ImageVerifier{
 		logger:                   logger,
@@ -55,6 +59,7 @@ func NewImageVerifier(
 		rule:                     rule,
 		ivm:                      ivm,
 		imageSignatureRepository: imageSignatureRepository,
+		secretResolver:           secretResolver,
 	}
 }
 
@@ -102,6 +107,21 @@ func matchImageReferences(imageReferences []string, image string) bool {
 	return false
 }
 
+// attestorSetMatchesImage returns true if the attestor set applies to the image, an attestor set
+// without image references applies to all the images of the rule
+func attestorSetMatchesImage(attestorSet kyvernov1.AttestorSet, image string) bool {
+	return len(attestorSet.ImageReferences) == 0 || matchImageReferences(attestorSet.ImageReferences, image)
+}
+
+func hasAttestorSetsForImage(attestors []kyvernov1.AttestorSet, image string) bool {
+	for _, attestorSet := range attestors {
+		if attestorSetMatchesImage(attestorSet, image) {
+			return true
+		}
+	}
+	return false
+}
+
 func isImageVerified(resource unstructured.Unstructured, image string, log logr.Logger) (bool, error) {
 	if resource.Object == nil {
 		return false, fmt.Errorf("nil resource")
@@ -246,7 +266,9 @@ func (iv *ImageVerifier) Verify(
 		}
 		start := time.Now()
 		isInCache := false
-		if iv.ivCache != nil {
+		// the tag resolution and the signatures age change over time, responses can't be cached
+		useCache := iv.ivCache != nil && !imageVerify.VerifyTagDigest && imageVerify.MaxSignatureAge == nil
+		if useCache {
 			found, err := iv.ivCache.Get(ctx, iv.policyContext.Policy(), iv.rule.Name, image)
 			if err != nil {
 				iv.logger.Error(err, "error occurred during cache get")
@@ -265,7 +287,7 @@ func (iv *ImageVerifier) Verify(
 			iv.logger.V(2).Info("cache entry not found", "namespace", iv.policyContext.Policy().GetNamespace(), "policy", iv.policyContext.Policy().GetName(), "ruleName", iv.rule.Name, "imageRef", image)
 			ruleResp, digest = iv.verifyImage(ctx, imageVerify, imageInfo, cfg)
 			if ruleResp != nil && ruleResp.Status() == engineapi.RuleStatusPass {
-				if iv.ivCache != nil {
+				if useCache {
 					setted, err := iv.ivCache.Set(ctx, iv.policyContext.Policy(), iv.rule.Name, image)
 					if err != nil {
 						iv.logger.Error(err, "error occurred during cache set")
@@ -309,10 +331,11 @@ func (iv *ImageVerifier) verifyImage(
 	imageInfo apiutils.ImageInfo,
 	cfg config.Configuration,
 ) (*engineapi.RuleResponse, string) {
-	if len(imageVerify.Attestors) <= 0 && len(imageVerify.Attestations) <= 0 {
+	image := imageInfo.String()
+	hasAttestors := hasAttestorSetsForImage(imageVerify.Attestors, image)
+	if !hasAttestors && len(imageVerify.Attestations) <= 0 {
 		return nil, ""
 	}
-	image := imageInfo.String()
 	for _, att := range imageVerify.Attestations {
 		if att.Type == "" && att.PredicateType != "" {
 			att.Type = att.PredicateType
@@ -323,7 +346,7 @@ func (iv *ImageVerifier) verifyImage(
 		iv.logger.Error(err, "failed to add image to context")
 		return engineapi.RuleError(iv.rule.Name, engineapi.ImageVerify, fmt.Sprintf("failed to add image to context %s", image), err), ""
 	}
-	if len(imageVerify.Attestors) > 0 {
+	if hasAttestors {
 		if !matchImageReferences(imageVerify.ImageReferences, image) {
 			return nil, ""
 		}
@@ -331,6 +354,11 @@ func (iv *ImageVerifier) verifyImage(
 		if ruleResp.Status() != engineapi.RuleStatusPass {
 			return ruleResp, ""
 		}
+		if imageVerify.VerifyTagDigest {
+			if ruleResp := iv.verifyTagDigest(ctx, imageInfo, cosignResp.Digest); ruleResp != nil {
+				return ruleResp, ""
+			}
+		}
 		if imageInfo.Digest == "" {
 			imageInfo.Digest = cosignResp.Digest
 		}
@@ -339,7 +367,50 @@ func (iv *ImageVerifier) verifyImage(
 		}
 	}
 
-	return iv.verifyAttestations(ctx, imageVerify, imageInfo)
+	ruleResp, digest := iv.verifyAttestations(ctx, imageVerify, imageInfo)
+	if ruleResp.Status() == engineapi.RuleStatusPass && imageVerify.VerifyTagDigest && !hasAttestors {
+		if ruleResp := iv.verifyTagDigest(ctx, imageInfo, digest); ruleResp != nil {
+			return ruleResp, ""
+		}
+	}
+	return ruleResp, digest
+}
+
+// verifyTagDigest checks that the image tag still resolves to the signed digest, a mismatch means the tag
+// was moved after the image was signed
+func (iv *ImageVerifier) verifyTagDigest(ctx context.Context, imageInfo apiutils.ImageInfo, digest string) *engineapi.RuleResponse {
+	if imageInfo.Tag == "" {
+		return nil
+	}
+	image := imageInfo.String()
+	if digest == "" {
+		return engineapi.RuleFail(iv.rule.Name, engineapi.ImageVerify, fmt.Sprintf("failed to verify tag of image %s: the signed digest is unknown", image))
+	}
+	tagged := imageInfo
+	tagged.Digest = ""
+	desc, err := iv.rclient.FetchImageDescriptor(ctx, tagged.String())
+	if err != nil {
+		return engineapi.RuleError(iv.rule.Name, engineapi.ImageVerify, fmt.Sprintf("failed to resolve tag of image %s", image), err)
+	}
+	if resolved := desc.Digest.String(); resolved != digest {
+		msg := fmt.Sprintf("image tag %s resolves to %s but the signed digest is %s, the tag was moved after signing", tagged.String(), resolved, digest)
+		return engineapi.RuleFail(iv.rule.Name, engineapi.ImageVerify, msg)
+	}
+	return nil
+}
+
+// checkSignatureAge returns an error when the signing time is unknown or older than the maximum signature age
+func checkSignatureAge(maxAge *metav1.Duration, resp *images.Response) error {
+	if maxAge == nil {
+		return nil
+	}
+	if resp.SignedAt.IsZero() {
+		return fmt.Errorf("signing time is unknown, the maximum signature age can't be verified")
+	}
+	if age := time.Since(resp.SignedAt); age > maxAge.Duration {
+		return fmt.Errorf("signature is older than %s, signed at %s", maxAge.Duration, resp.SignedAt.UTC().Format(time.RFC3339))
+	}
+	return nil
 }
 
 func (iv *ImageVerifier) verifyAttestors(
@@ -354,6 +425,10 @@ func (iv *ImageVerifier) verifyAttestors(
 	for i, attestorSet := range attestors {
 		var err error
 		path := fmt.Sprintf(".attestors[%d]", i)
+		if !attestorSetMatchesImage(attestorSet, image) {
+			iv.logger.V(4).Info("skipping attestors not applying to the image", "path", path, "image", image)
+			continue
+		}
 		iv.logger.V(4).Info("verifying attestors", "path", path)
 		cosignResponse, err = iv.verifyAttestorSet(ctx, attestorSet, imageVerify, imageInfo, path)
 		if err != nil {
@@ -404,6 +479,10 @@ func (iv *ImageVerifier) verifyAttestations(
 
 		for j, attestor := range attestation.Attestors {
 			attestorPath := fmt.Sprintf("%s.attestors[%d]", path, j)
+			attestor, err := iv.resolveStaticKeys(ctx, attestor)
+			if err != nil {
+				return engineapi.RuleError(iv.rule.Name, engineapi.ImageVerify, attestorPath, err), ""
+			}
 			requiredCount := attestor.RequiredCount()
 			verifiedCount := 0
 
@@ -416,6 +495,10 @@ func (iv *ImageVerifier) verifyAttestations(
 					return iv.handleRegistryErrors(image, err), ""
 				}
 
+				if err := checkSignatureAge(imageVerify.MaxSignatureAge, cosignResp); err != nil {
+					return engineapi.RuleFail(iv.rule.Name, engineapi.ImageVerify, fmt.Sprintf("%s: %s", entryPath+subPath, err)), ""
+				}
+
 				if imageInfo.Digest == "" {
 					imageInfo.Digest = cosignResp.Digest
 					image = imageInfo.String()
@@ -457,6 +540,10 @@ func (iv *ImageVerifier) verifyAttestorSet(
 ) (*images.Response, error) {
 	var errorList []error
 	verifiedCount := 0
+	attestorSet, err := iv.resolveStaticKeys(ctx, attestorSet)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
 	attestorSet = ExpandStaticKeys(attestorSet)
 	requiredCount := attestorSet.RequiredCount()
 	image := imageInfo.String()
@@ -478,6 +565,9 @@ func (iv *ImageVerifier) verifyAttestorSet(
 		} else {
 			v, opts, subPath := iv.buildVerifier(a, imageVerify, image, nil)
 			cosignResp, entryError = v.VerifySignature(ctx, *opts)
+			if entryError == nil {
+				entryError = checkSignatureAge(imageVerify.MaxSignatureAge, cosignResp)
+			}
 			if entryError != nil {
 				entryError = fmt.Errorf("%s: %w", attestorPath+subPath, entryError)
 			}
@@ -494,11 +584,34 @@ func (iv *ImageVerifier) verifyAttestorSet(
 		}
 	}
 
-	err := multierr.Combine(errorList...)
+	err = multierr.Combine(errorList...)
 	iv.logger.Info("image attestors verification failed", "verifiedCount", verifiedCount, "requiredCount", requiredCount, "errors", err.Error())
 	return nil, err
 }
 
+// resolveStaticKeys resolves the public keys referencing secrets stored in HashiCorp Vault
+func (iv *ImageVerifier) resolveStaticKeys(ctx context.Context, attestorSet kyvernov1.AttestorSet) (kyvernov1.AttestorSet, error) {
+	entries := make([]kyvernov1.Attestor, 0, len(attestorSet.Entries))
+	for _, e := range attestorSet.Entries {
+		if e.Keys != nil && secrets.IsVaultReference(strings.TrimSpace(e.Keys.PublicKeys)) {
+			if iv.secretResolver == nil {
+				return attestorSet, fmt.Errorf("failed to resolve public keys %s: secret references are not enabled", e.Keys.PublicKeys)
+			}
+			keys, err := iv.secretResolver.Resolve(ctx, iv.policyContext.Policy().GetNamespace(), strings.TrimSpace(e.Keys.PublicKeys))
+			if err != nil {
+				return attestorSet, err
+			}
+			e.Keys = e.Keys.DeepCopy()
+			e.Keys.PublicKeys = keys
+		}
+		entries = append(entries, e)
+	}
+	return kyvernov1.AttestorSet{
+		Count:   attestorSet.Count,
+		Entries: entries,
+	}, nil
+}
+
 func (iv *ImageVerifier) buildVerifier(
 	attestor kyvernov1.Attestor,
 	imageVerify kyvernov1.ImageVerification,
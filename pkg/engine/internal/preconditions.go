@@ -4,25 +4,26 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
-	"github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 func CheckPreconditions(logger logr.Logger, jsonContext enginecontext.Interface, anyAllConditions apiextensions.JSON) (bool, string, error) {
-	typeConditions, err := utils.TransformConditions(anyAllConditions)
-	if err != nil {
-		return false, "", fmt.Errorf("failed to parse preconditions: %w", err)
+	typeConditions, errs := kyvernov1.ParseConditions(field.NewPath("preconditions"), anyAllConditions)
+	if len(errs) != 0 {
+		return false, "", fmt.Errorf("failed to parse preconditions: %w", errs.ToAggregate())
 	}
 
 	return variables.EvaluateConditions(logger, jsonContext, typeConditions)
 }
 
 func CheckDenyPreconditions(logger logr.Logger, jsonContext enginecontext.Interface, anyAllConditions apiextensions.JSON) (bool, string, error) {
-	typeConditions, err := utils.TransformConditions(anyAllConditions)
-	if err != nil {
-		return false, "", fmt.Errorf("failed to parse deny conditions: %w", err)
+	typeConditions, errs := kyvernov1.ParseConditions(field.NewPath("conditions"), anyAllConditions)
+	if len(errs) != 0 {
+		return false, "", fmt.Errorf("failed to parse deny conditions: %w", errs.ToAggregate())
 	}
 
 	return variables.EvaluateConditions(logger, jsonContext, typeConditions)
@@ -1,7 +1,6 @@
 package jmespath
 
 import (
-	gojmespath "github.com/kyverno/go-jmespath"
 	"github.com/kyverno/kyverno/pkg/config"
 )
 
@@ -15,17 +14,24 @@ type Interface interface {
 }
 
 type implementation struct {
-	functionCaller *gojmespath.FunctionCaller
+	registry *Registry
 }
 
 func New(configuration config.Configuration) Interface {
-	return newImplementation(configuration)
+	return NewWithRegistry(DefaultRegistry(configuration))
+}
+
+// NewWithRegistry creates an interface evaluating queries with the functions of the given registry
+func NewWithRegistry(registry *Registry) Interface {
+	return implementation{
+		registry: registry,
+	}
 }
 
 func (i implementation) Query(query string) (Query, error) {
-	return newJMESPath(query, i.functionCaller)
+	return newJMESPath(query, i.registry.caller)
 }
 
 func (i implementation) Search(query string, data interface{}) (interface{}, error) {
-	return newExecution(i.functionCaller, query, data)
+	return newExecution(i.registry.caller, query, data)
 }
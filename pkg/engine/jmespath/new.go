@@ -2,7 +2,6 @@ package jmespath
 
 import (
 	gojmespath "github.com/kyverno/go-jmespath"
-	"github.com/kyverno/kyverno/pkg/config"
 )
 
 type QueryProxy struct {
@@ -25,18 +24,6 @@ func newJMESPath(query string, functionCaller *gojmespath.FunctionCaller) (*Quer
 	}, nil
 }
 
-func newImplementation(configuration config.Configuration) Interface {
-	functionCaller := gojmespath.NewFunctionCaller()
-	functions := GetFunctions(configuration)
-	for _, f := range functions {
-		functionCaller.Register(f.FunctionEntry)
-	}
-
-	return implementation{
-		functionCaller,
-	}
-}
-
 func newExecution(fCall *gojmespath.FunctionCaller, query string, data interface{}) (interface{}, error) {
 	return gojmespath.Search(query, data, gojmespath.WithFunctionCaller(fCall))
 }
@@ -0,0 +1,61 @@
+package jmespath
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+
+	gojmespath "github.com/kyverno/go-jmespath"
+	"github.com/kyverno/kyverno/pkg/config"
+)
+
+// Registry is an immutable set of JMESPath custom functions, it is safe to share a registry across goroutines
+type Registry struct {
+	functions []FunctionEntry
+	caller    *gojmespath.FunctionCaller
+}
+
+// NewRegistry creates a registry with the given functions, when several functions have the same name the last one wins
+func NewRegistry(functions ...FunctionEntry) *Registry {
+	caller := gojmespath.NewFunctionCaller()
+	var entries []FunctionEntry
+	indexes := map[string]int{}
+	for _, function := range functions {
+		caller.Register(function.FunctionEntry)
+		if index, ok := indexes[function.Name]; ok {
+			entries[index] = function
+		} else {
+			indexes[function.Name] = len(entries)
+			entries = append(entries, function)
+		}
+	}
+	return &Registry{
+		functions: entries,
+		caller:    caller,
+	}
+}
+
+// With returns a new registry with the functions of the registry and the given functions
+func (r *Registry) With(functions ...FunctionEntry) *Registry {
+	return NewRegistry(append(r.Functions(), functions...)...)
+}
+
+// Functions returns the custom functions of the registry
+func (r *Registry) Functions() []FunctionEntry {
+	return slices.Clone(r.functions)
+}
+
+var registries sync.Map
+
+// DefaultRegistry returns the registry of the Kyverno custom functions for the given configuration,
+// the registry is created once per configuration and shared by all the callers
+func DefaultRegistry(configuration config.Configuration) *Registry {
+	if configuration == nil || !reflect.TypeOf(configuration).Comparable() {
+		return NewRegistry(GetFunctions(configuration)...)
+	}
+	if registry, ok := registries.Load(configuration); ok {
+		return registry.(*Registry)
+	}
+	registry, _ := registries.LoadOrStore(configuration, NewRegistry(GetFunctions(configuration)...))
+	return registry.(*Registry)
+}
@@ -0,0 +1,89 @@
+package jmespath
+
+import (
+	"sync"
+	"testing"
+
+	gojmespath "github.com/kyverno/go-jmespath"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+)
+
+func TestDefaultRegistry(t *testing.T) {
+	configuration := config.NewDefaultConfiguration(false)
+	registry := DefaultRegistry(configuration)
+	assert.Assert(t, registry == DefaultRegistry(configuration))
+	assert.Assert(t, registry != DefaultRegistry(config.NewDefaultConfiguration(false)))
+	assert.Equal(t, len(registry.Functions()), len(GetFunctions(configuration)))
+}
+
+func TestRegistry_With(t *testing.T) {
+	hello := FunctionEntry{
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: "hello",
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: func(arguments []interface{}) (interface{}, error) {
+				return "hello " + arguments[0].(string), nil
+			},
+		},
+		ReturnType: []jpType{jpString},
+	}
+	upper := FunctionEntry{
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: toUpper,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: func(arguments []interface{}) (interface{}, error) {
+				return "overridden", nil
+			},
+		},
+		ReturnType: []jpType{jpString},
+	}
+	base := DefaultRegistry(config.NewDefaultConfiguration(false))
+	registry := base.With(hello, upper)
+	assert.Equal(t, len(registry.Functions()), len(base.Functions())+1)
+	jp := NewWithRegistry(registry)
+	result, err := jp.Search("hello('world')", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, result, "hello world")
+	result, err = jp.Search("to_upper('world')", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, result, "overridden")
+	// the base registry is left unchanged
+	_, err = NewWithRegistry(base).Search("hello('world')", nil)
+	assert.ErrorContains(t, err, "hello")
+	result, err = NewWithRegistry(base).Search("to_upper('world')", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, result, "WORLD")
+}
+
+func TestRegistry_Concurrent(t *testing.T) {
+	jp := New(config.NewDefaultConfiguration(false))
+	query, err := jp.Query("to_upper(name)")
+	assert.NilError(t, err)
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := query.Search(map[string]interface{}{"name": "kyverno"}); err != nil {
+				errs <- err
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := jp.Search("sum(`[1, 2]`)", nil); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NilError(t, err)
+	}
+}
@@ -19,7 +19,7 @@ func (e *engine) reportMetrics(
 	admissionOperation bool,
 	response engineapi.EngineResponse,
 ) {
-	if e.resultCounter == nil && e.durationHistogram == nil {
+	if e.resultCounter == nil && e.durationHistogram == nil && e.errorCounter == nil {
 		return
 	}
 	policy := response.Policy().GetPolicy().(kyvernov1.PolicyInterface)
@@ -53,6 +53,7 @@ func (e *engine) reportMetrics(
 			default:
 				ruleResult = metrics.Fail
 			}
+			ruleResultReason := rule.ResultReason()
 			executionCause := metrics.AdmissionRequest
 			if !admissionOperation {
 				executionCause = metrics.BackgroundScan
@@ -69,6 +70,7 @@ func (e *engine) reportMetrics(
 					attribute.String("resource_request_operation", strings.ToLower(string(operation))),
 					attribute.String("rule_name", ruleName),
 					attribute.String("rule_result", string(ruleResult)),
+					attribute.String("rule_result_reason", string(ruleResultReason)),
 					attribute.String("rule_type", string(ruleType)),
 					attribute.String("rule_execution_cause", string(executionCause)),
 				}
@@ -86,11 +88,24 @@ func (e *engine) reportMetrics(
 					attribute.String("resource_request_operation", strings.ToLower(string(operation))),
 					attribute.String("rule_name", ruleName),
 					attribute.String("rule_result", string(ruleResult)),
+					attribute.String("rule_result_reason", string(ruleResultReason)),
 					attribute.String("rule_type", string(ruleType)),
 					attribute.String("rule_execution_cause", string(executionCause)),
 				}
 				e.durationHistogram.Record(ctx, rule.Stats().ProcessingTime().Seconds(), metric.WithAttributes(commonLabels...))
 			}
+			if e.errorCounter != nil && ruleResult == metrics.Error {
+				commonLabels := []attribute.KeyValue{
+					attribute.String("policy_type", string(policyType)),
+					attribute.String("policy_namespace", namespace),
+					attribute.String("policy_name", name),
+					attribute.String("resource_kind", resourceKind),
+					attribute.String("rule_name", ruleName),
+					attribute.String("rule_type", string(ruleType)),
+					attribute.String("rule_execution_cause", string(executionCause)),
+				}
+				e.errorCounter.Add(ctx, 1, metric.WithAttributes(commonLabels...))
+			}
 		}
 	}
 }
@@ -48,8 +48,8 @@ func Mutate(rule *kyvernov1.Rule, ctx context.Interface, resource unstructured.U
 		return NewErrorResponse("empty mutate rule", nil)
 	}
 
-	patchedResource := resource.DeepCopy()
-	resourceBytes, err := patchedResource.MarshalJSON()
+	// the resource is serialized as is, patching works on bytes so no deep copy is needed
+	resourceBytes, err := resource.MarshalJSON()
 	if err != nil {
 		return NewErrorResponse("failed to marshal resource", err)
 	}
@@ -60,6 +60,7 @@ func Mutate(rule *kyvernov1.Rule, ctx context.Interface, resource unstructured.U
 	if strings.TrimSpace(string(resourceBytes)) == strings.TrimSpace(string(patchedBytes)) {
 		return NewResponse(engineapi.RuleStatusSkip, resource, "no patches applied")
 	}
+	var patchedResource unstructured.Unstructured
 	if err := patchedResource.UnmarshalJSON(patchedBytes); err != nil {
 		return NewErrorResponse("failed to unmarshal patched resource", err)
 	}
@@ -68,7 +69,7 @@ func Mutate(rule *kyvernov1.Rule, ctx context.Interface, resource unstructured.U
 			return NewErrorResponse("failed to update patched target resource in the JSON context", err)
 		}
 	}
-	return NewResponse(engineapi.RuleStatusPass, *patchedResource, "resource patched")
+	return NewResponse(engineapi.RuleStatusPass, patchedResource, "resource patched")
 }
 
 func ForEach(name string, foreach kyvernov1.ForEachMutation, policyContext engineapi.PolicyContext, resource unstructured.Unstructured, element interface{}, logger logr.Logger) *Response {
@@ -82,8 +83,7 @@ func ForEach(name string, foreach kyvernov1.ForEachMutation, policyContext engin
 		return NewErrorResponse("empty mutate rule", nil)
 	}
 
-	patchedResource := resource.DeepCopy()
-	resourceBytes, err := patchedResource.MarshalJSON()
+	resourceBytes, err := resource.MarshalJSON()
 	if err != nil {
 		return NewErrorResponse("failed to marshal resource", err)
 	}
@@ -94,11 +94,12 @@ func ForEach(name string, foreach kyvernov1.ForEachMutation, policyContext engin
 	if strings.TrimSpace(string(resourceBytes)) == strings.TrimSpace(string(patchedBytes)) {
 		return NewResponse(engineapi.RuleStatusSkip, resource, "no patches applied")
 	}
+	var patchedResource unstructured.Unstructured
 	if err := patchedResource.UnmarshalJSON(patchedBytes); err != nil {
 		return NewErrorResponse("failed to unmarshal patched resource", err)
 	}
 
-	return NewResponse(engineapi.RuleStatusPass, *patchedResource, "resource patched")
+	return NewResponse(engineapi.RuleStatusPass, patchedResource, "resource patched")
 }
 
 func substituteAllInForEach(fe kyvernov1.ForEachMutation, ctx context.Interface, logger logr.Logger) (*kyvernov1.ForEachMutation, error) {
@@ -0,0 +1,103 @@
+package patch
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"gomodules.xyz/jsonpatch/v2"
+)
+
+const annotationsPath = "/metadata/annotations"
+
+// SortPatches sorts patches by path in a stable way, patches are expected to come from a single diff
+// (operations in a diff don't depend on each other except for operations on the same array).
+// Array indices are not taken into account when comparing paths, operations on the same array keep
+// their relative order because changing it could change the result of the patch.
+func SortPatches(patches ...jsonpatch.JsonPatchOperation) []jsonpatch.JsonPatchOperation {
+	if len(patches) == 0 {
+		return patches
+	}
+	keys := make(map[string]string, len(patches))
+	for _, patch := range patches {
+		keys[patch.Path] = sortKey(patch.Path)
+	}
+	sorted := make([]jsonpatch.JsonPatchOperation, len(patches))
+	copy(sorted, patches)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return keys[sorted[i].Path] < keys[sorted[j].Path]
+	})
+	return sorted
+}
+
+// MergeAnnotationPatches folds operations on individual annotations into a preceding operation adding
+// or replacing the whole annotations map, the resulting patch contains a single operation for those annotations.
+func MergeAnnotationPatches(patches ...jsonpatch.JsonPatchOperation) []jsonpatch.JsonPatchOperation {
+	var merged []jsonpatch.JsonPatchOperation
+	target := -1
+	for _, patch := range patches {
+		if patch.Path == annotationsPath {
+			target = -1
+			if patch.Operation == "add" || patch.Operation == "replace" {
+				if annotations, ok := toAnnotations(patch.Value); ok {
+					patch.Value = annotations
+					target = len(merged)
+				}
+			}
+			merged = append(merged, patch)
+			continue
+		}
+		if target != -1 && strings.HasPrefix(patch.Path, annotationsPath+"/") {
+			segment := strings.TrimPrefix(patch.Path, annotationsPath+"/")
+			if !strings.Contains(segment, "/") {
+				key := unescapePathSegment(segment)
+				annotations := merged[target].Value.(map[string]interface{})
+				switch patch.Operation {
+				case "add", "replace":
+					annotations[key] = patch.Value
+					continue
+				case "remove":
+					delete(annotations, key)
+					continue
+				}
+			}
+		}
+		merged = append(merged, patch)
+	}
+	return merged
+}
+
+// toAnnotations returns a copy of the annotations map held by a patch value
+func toAnnotations(value interface{}) (map[string]interface{}, bool) {
+	annotations := map[string]interface{}{}
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for k, v := range typed {
+			annotations[k] = v
+		}
+	case map[string]string:
+		for k, v := range typed {
+			annotations[k] = v
+		}
+	default:
+		return nil, false
+	}
+	return annotations, true
+}
+
+func sortKey(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment == "-" {
+			segments[i] = ""
+		} else if _, err := strconv.Atoi(segment); err == nil {
+			segments[i] = ""
+		}
+	}
+	// use a separator lower than any printable character so that parents sort before their children
+	return strings.Join(segments, "\x00")
+}
+
+func unescapePathSegment(segment string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+}
@@ -0,0 +1,107 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gomodules.xyz/jsonpatch/v2"
+)
+
+func TestSortPatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		patches []jsonpatch.JsonPatchOperation
+		want    []string
+	}{{
+		name: "nil",
+	}, {
+		name: "object keys",
+		patches: []jsonpatch.JsonPatchOperation{
+			jsonpatch.NewOperation("add", "/metadata/labels/foo", "bar"),
+			jsonpatch.NewOperation("replace", "/metadata/annotations/foo", "bar"),
+			jsonpatch.NewOperation("add", "/metadata/labels/bar", "foo"),
+		},
+		want: []string{
+			`{"op":"replace","path":"/metadata/annotations/foo","value":"bar"}`,
+			`{"op":"add","path":"/metadata/labels/bar","value":"foo"}`,
+			`{"op":"add","path":"/metadata/labels/foo","value":"bar"}`,
+		},
+	}, {
+		name: "array order is preserved",
+		patches: []jsonpatch.JsonPatchOperation{
+			jsonpatch.NewOperation("remove", "/spec/containers/2", nil),
+			jsonpatch.NewOperation("remove", "/spec/containers/1", nil),
+			jsonpatch.NewOperation("replace", "/spec/containers/0/name", "foo"),
+			jsonpatch.NewOperation("replace", "/spec/containers/0/image", "nginx"),
+		},
+		want: []string{
+			`{"op":"remove","path":"/spec/containers/2"}`,
+			`{"op":"remove","path":"/spec/containers/1"}`,
+			`{"op":"replace","path":"/spec/containers/0/image","value":"nginx"}`,
+			`{"op":"replace","path":"/spec/containers/0/name","value":"foo"}`,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, patch := range SortPatches(tt.patches...) {
+				got = append(got, patch.Json())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMergeAnnotationPatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		patches []jsonpatch.JsonPatchOperation
+		want    []string
+	}{{
+		name: "nil",
+	}, {
+		name: "no annotations map",
+		patches: []jsonpatch.JsonPatchOperation{
+			jsonpatch.NewOperation("add", "/metadata/annotations/foo", "bar"),
+			jsonpatch.NewOperation("add", "/metadata/annotations/bar", "foo"),
+		},
+		want: []string{
+			`{"op":"add","path":"/metadata/annotations/foo","value":"bar"}`,
+			`{"op":"add","path":"/metadata/annotations/bar","value":"foo"}`,
+		},
+	}, {
+		name: "merge into annotations map",
+		patches: []jsonpatch.JsonPatchOperation{
+			jsonpatch.NewOperation("add", "/metadata/annotations", map[string]string{}),
+			jsonpatch.NewOperation("add", "/spec/replicas", 1),
+			jsonpatch.NewOperation("add", "/metadata/annotations/kyverno.io~1verify-images", "{}"),
+			jsonpatch.NewOperation("add", "/metadata/annotations/foo", "bar"),
+			jsonpatch.NewOperation("remove", "/metadata/annotations/foo", nil),
+		},
+		want: []string{
+			`{"op":"add","path":"/metadata/annotations","value":{"kyverno.io/verify-images":"{}"}}`,
+			`{"op":"add","path":"/spec/replicas","value":1}`,
+		},
+	}, {
+		name: "annotations map removed",
+		patches: []jsonpatch.JsonPatchOperation{
+			jsonpatch.NewOperation("add", "/metadata/annotations", map[string]interface{}{"foo": "bar"}),
+			jsonpatch.NewOperation("remove", "/metadata/annotations", nil),
+			jsonpatch.NewOperation("add", "/metadata/annotations/bar", "foo"),
+		},
+		want: []string{
+			`{"op":"add","path":"/metadata/annotations","value":{"foo":"bar"}}`,
+			`{"op":"remove","path":"/metadata/annotations"}`,
+			`{"op":"add","path":"/metadata/annotations/bar","value":"foo"}`,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, patch := range MergeAnnotationPatches(tt.patches...) {
+				got = append(got, patch.Json())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
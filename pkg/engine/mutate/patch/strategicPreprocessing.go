@@ -116,6 +116,7 @@ func walkList(logger logr.Logger, pattern, resource *yaml.RNode) error {
 // in many cases like containers, volumes kustomize uses name field to match resource for processing
 // If any conditional anchor match resource field and if the pattern doesn't contain "name" field and
 // resource contains "name" field, then copy the name field from resource to pattern.
+// Elements with a $select directive are replaced with one element per selected resource element.
 func processListOfMaps(logger logr.Logger, pattern, resource *yaml.RNode) error {
 	patternElements, err := pattern.Elements()
 	if err != nil {
@@ -128,6 +129,12 @@ func processListOfMaps(logger logr.Logger, pattern, resource *yaml.RNode) error
 	}
 
 	for _, patternElement := range patternElements {
+		if hasSelectDirective(patternElement) {
+			if err := handleSelectDirective(logger, pattern, patternElement, resourceElements); err != nil {
+				return err
+			}
+			continue
+		}
 		// If pattern has conditions, look for matching elements and process them
 		hasAnyAnchor := hasAnchors(patternElement, hasAnchor)
 		hasGlobalConditions := hasAnchors(patternElement, anchor.IsGlobal)
@@ -180,6 +187,7 @@ func processListOfMaps(logger logr.Logger, pattern, resource *yaml.RNode) error
 			}
 		}
 	}
+	deleteSelectElements(pattern)
 
 	return nil
 }
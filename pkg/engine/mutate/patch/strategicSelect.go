@@ -0,0 +1,129 @@
+package patch
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const (
+	// selectDirective selects the list elements a pattern element is merged with, the selector is either a map
+	// matched against every element the same way conditional anchors are (e.g. `$select: {name: app}`)
+	// or a JMESPath expression evaluated against every element (e.g. `$select: "name == 'app' || name == 'sidecar'"`)
+	selectDirective = "$select"
+	// mergeKeyDirective is the field, or the list of fields, copied from the selected elements so that the patch
+	// is merged with them (name by default), e.g. `$mergeKey: [containerPort, protocol]` for container ports
+	mergeKeyDirective = "$mergeKey"
+)
+
+var selectJP = jmespath.New(config.NewDefaultConfiguration(false))
+
+func hasSelectDirective(patternElement *yaml.RNode) bool {
+	return isMappingNode(patternElement) && patternElement.Field(selectDirective) != nil
+}
+
+// handleSelectDirective appends a copy of the pattern element to the pattern for every resource element
+// matching the selector, the copy carries the merge key of the resource element it targets
+func handleSelectDirective(logger logr.Logger, pattern, patternElement *yaml.RNode, resourceElements []*yaml.RNode) error {
+	selector := patternElement.Field(selectDirective).Value
+	mergeKeys := []string{"name"}
+	if field := patternElement.Field(mergeKeyDirective); field != nil {
+		keys, err := parseMergeKeys(field.Value)
+		if err != nil {
+			return err
+		}
+		mergeKeys = keys
+	}
+	template := patternElement.Copy()
+	for _, directive := range []string{selectDirective, mergeKeyDirective} {
+		if err := template.PipeE(yaml.Clear(directive)); err != nil {
+			return err
+		}
+	}
+	for _, resourceElement := range resourceElements {
+		selected, err := isSelected(logger, selector, resourceElement)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate %s: %w", selectDirective, err)
+		}
+		if !selected {
+			continue
+		}
+		newNode := template.Copy()
+		for _, mergeKey := range mergeKeys {
+			key := resourceElement.Field(mergeKey)
+			if key.IsNilOrEmpty() {
+				return fmt.Errorf("element selected by %s has no %s field, use %s to set the fields it is merged by", selectDirective, mergeKey, mergeKeyDirective)
+			}
+			if err := newNode.PipeE(yaml.SetField(mergeKey, key.Value.Copy())); err != nil {
+				return err
+			}
+		}
+		if err := preProcessRecursive(logger, newNode, resourceElement); err != nil {
+			if isConditionError(err) {
+				logger.V(3).Info("anchor mismatch", "reason", err.Error())
+				continue
+			}
+			return err
+		}
+		if err := pattern.PipeE(yaml.Append(newNode.YNode())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseMergeKeys(node *yaml.RNode) ([]string, error) {
+	var keys []string
+	switch node.YNode().Kind {
+	case yaml.ScalarNode:
+		keys = append(keys, node.YNode().Value)
+	case yaml.SequenceNode:
+		for _, key := range node.YNode().Content {
+			if key.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("%s must be a field name or a list of field names", mergeKeyDirective)
+			}
+			keys = append(keys, key.Value)
+		}
+	}
+	if len(keys) == 0 || slices.Contains(keys, "") {
+		return nil, fmt.Errorf("%s must be a field name or a list of field names", mergeKeyDirective)
+	}
+	return keys, nil
+}
+
+func isSelected(logger logr.Logger, selector, resourceElement *yaml.RNode) (bool, error) {
+	switch selector.YNode().Kind {
+	case yaml.MappingNode:
+		return checkCondition(logger, selector, resourceElement) == nil, nil
+	case yaml.ScalarNode:
+		element, err := convertRNodeToInterface(resourceElement)
+		if err != nil {
+			return false, err
+		}
+		result, err := selectJP.Search(selector.YNode().Value, element)
+		if err != nil {
+			return false, err
+		}
+		selected, ok := result.(bool)
+		if !ok {
+			return false, fmt.Errorf("expression %s must evaluate to a boolean", selector.YNode().Value)
+		}
+		return selected, nil
+	}
+	return false, fmt.Errorf("selector must be a map or a JMESPath expression")
+}
+
+// deleteSelectElements removes the pattern elements holding a select directive, they were replaced by one element per selected resource element
+func deleteSelectElements(pattern *yaml.RNode) {
+	var content []*yaml.Node
+	for _, node := range pattern.YNode().Content {
+		if !hasSelectDirective(yaml.NewRNode(node)) {
+			content = append(content, node)
+		}
+	}
+	pattern.YNode().Content = content
+}
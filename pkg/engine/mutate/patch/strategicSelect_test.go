@@ -0,0 +1,85 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+)
+
+func Test_SelectDirective(t *testing.T) {
+	resource := []byte(`{
+    "apiVersion": "v1",
+    "kind": "Pod",
+    "metadata": {"name": "hello"},
+    "spec": {
+      "containers": [
+        {"name": "app", "image": "app:v1", "ports": [{"containerPort": 80, "protocol": "TCP"}, {"containerPort": 443, "protocol": "TCP"}]},
+        {"name": "app-sidecar", "image": "sidecar:v1"},
+        {"name": "proxy", "image": "proxy:v1"}
+      ]
+    }
+  }`)
+	testCases := []struct {
+		name     string
+		patch    string
+		expected string
+		wantErr  bool
+	}{{
+		name:  "map selector",
+		patch: `{"spec": {"containers": [{"$select": {"name": "app"}, "image": "app:v2"}]}}`,
+		expected: `{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "hello"}, "spec": {"containers": [
+        {"name": "app", "image": "app:v2", "ports": [{"containerPort": 80, "protocol": "TCP"}, {"containerPort": 443, "protocol": "TCP"}]},
+        {"name": "app-sidecar", "image": "sidecar:v1"},
+        {"name": "proxy", "image": "proxy:v1"}]}}`,
+	}, {
+		name:  "map selector with wildcard",
+		patch: `{"spec": {"containers": [{"$select": {"name": "app*"}, "imagePullPolicy": "Always"}]}}`,
+		expected: `{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "hello"}, "spec": {"containers": [
+        {"name": "app", "image": "app:v1", "imagePullPolicy": "Always", "ports": [{"containerPort": 80, "protocol": "TCP"}, {"containerPort": 443, "protocol": "TCP"}]},
+        {"name": "app-sidecar", "image": "sidecar:v1", "imagePullPolicy": "Always"},
+        {"name": "proxy", "image": "proxy:v1"}]}}`,
+	}, {
+		name:  "jmespath selector",
+		patch: `{"spec": {"containers": [{"$select": "starts_with(image, 'proxy:')", "image": "proxy:v2"}]}}`,
+		// strategic merge moves the patched elements first
+		expected: `{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "hello"}, "spec": {"containers": [
+        {"name": "proxy", "image": "proxy:v2"},
+        {"name": "app", "image": "app:v1", "ports": [{"containerPort": 80, "protocol": "TCP"}, {"containerPort": 443, "protocol": "TCP"}]},
+        {"name": "app-sidecar", "image": "sidecar:v1"}]}}`,
+	}, {
+		name:  "nested selectors with merge key",
+		patch: `{"spec": {"containers": [{"$select": {"name": "app"}, "ports": [{"$select": "containerPort == ` + "`443`" + `", "$mergeKey": ["containerPort", "protocol"], "name": "https"}]}]}}`,
+		expected: `{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "hello"}, "spec": {"containers": [
+        {"name": "app", "image": "app:v1", "ports": [{"containerPort": 80, "protocol": "TCP"}, {"containerPort": 443, "protocol": "TCP", "name": "https"}]},
+        {"name": "app-sidecar", "image": "sidecar:v1"},
+        {"name": "proxy", "image": "proxy:v1"}]}}`,
+	}, {
+		name:     "no selected element",
+		patch:    `{"spec": {"containers": [{"$select": {"name": "missing"}, "image": "missing:v1"}]}}`,
+		expected: string(resource),
+	}, {
+		name:    "non boolean expression",
+		patch:   `{"spec": {"containers": [{"$select": "name", "image": "app:v2"}]}}`,
+		wantErr: true,
+	}, {
+		name:    "invalid merge key",
+		patch:   `{"spec": {"containers": [{"$select": {"name": "app"}, "$mergeKey": {"name": "app"}, "image": "app:v2"}]}}`,
+		wantErr: true,
+	}, {
+		name:    "missing merge key",
+		patch:   `{"spec": {"containers": [{"$select": {"name": "app"}, "$mergeKey": "command", "image": "app:v2"}]}}`,
+		wantErr: true,
+	}}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := strategicMergePatch(logr.Discard(), string(resource), test.patch)
+			if test.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, toJSON(t, []byte(test.expected)), toJSON(t, out))
+		})
+	}
+}
@@ -28,7 +28,7 @@ func (e *engine) mutate(
 	policyContext.JSONContext().Checkpoint()
 	defer policyContext.JSONContext().Restore()
 
-	for _, rule := range autogen.ComputeRules(policy) {
+	for _, rule := range orderRules(autogen.ComputeRules(policy)) {
 		startTime := time.Now()
 		logger := internal.LoggerWithRule(logger, rule)
 		handlerFactory := func() (handlers.Handler, error) {
@@ -38,6 +38,9 @@ func (e *engine) mutate(
 			if !policyContext.AdmissionOperation() && rule.HasMutateExisting() {
 				return mutation.NewMutateExistingHandler(e.client)
 			}
+			if rule.Mutation.ResolveDigest != nil {
+				return mutation.NewResolveDigestHandler(e.configuration, e.rclientFactory)
+			}
 			return mutation.NewMutateResourceHandler()
 		}
 		resource, ruleResp := e.invokeRuleHandler(
@@ -57,3 +60,24 @@ func (e *engine) mutate(
 	}
 	return resp, matchedResource
 }
+
+// orderRules orders the rules so that every rule is executed after the rules it depends on
+func orderRules(rules []kyvernov1.Rule) []kyvernov1.Rule {
+	names := make([]string, 0, len(rules))
+	dependsOn := make([][]string, 0, len(rules))
+	hasDependencies := false
+	for _, rule := range rules {
+		names = append(names, rule.Name)
+		dependsOn = append(dependsOn, rule.DependsOn)
+		hasDependencies = hasDependencies || len(rule.DependsOn) != 0
+	}
+	if !hasDependencies {
+		return rules
+	}
+	ordered, _ := kyvernov1.OrderRules(names, dependsOn)
+	out := make([]kyvernov1.Rule, 0, len(rules))
+	for _, i := range ordered {
+		out = append(out, rules[i])
+	}
+	return out
+}
@@ -43,6 +43,9 @@ func testMutate(
 		contextLoader,
 		nil,
 		"",
+		nil,
+		nil,
+		nil,
 	)
 	return e.Mutate(
 		ctx,
@@ -2398,3 +2401,128 @@ func Test_SpecialCharacters(t *testing.T) {
 		})
 	}
 }
+
+func Test_MutateRuleDependencies(t *testing.T) {
+	policyRaw := []byte(`{
+    "apiVersion": "kyverno.io/v1",
+    "kind": "ClusterPolicy",
+    "metadata": {
+      "name": "set-owner"
+    },
+    "spec": {
+      "rules": [
+        {
+          "name": "set-team-owner",
+          "dependsOn": ["set-default-owner"],
+          "match": {
+            "resources": {
+              "kinds": ["ConfigMap"]
+            }
+          },
+          "mutate": {
+            "patchStrategicMerge": {
+              "metadata": {
+                "labels": {
+                  "owner": "team"
+                }
+              }
+            }
+          }
+        },
+        {
+          "name": "set-default-owner",
+          "match": {
+            "resources": {
+              "kinds": ["ConfigMap"]
+            }
+          },
+          "mutate": {
+            "patchStrategicMerge": {
+              "metadata": {
+                "labels": {
+                  "owner": "default"
+                }
+              }
+            }
+          }
+        }
+      ]
+    }
+  }`)
+	resourceRaw := []byte(`{
+    "apiVersion": "v1",
+    "kind": "ConfigMap",
+    "metadata": {
+      "name": "config"
+    }
+  }`)
+
+	policy := loadResource[kyverno.ClusterPolicy](t, policyRaw)
+	resource := loadUnstructured(t, resourceRaw)
+
+	er := testMutate(context.TODO(), nil, nil, createContext(t, &policy, resource, kyverno.Create), nil)
+	require.Len(t, er.PolicyResponse.Rules, 2)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Name(), "set-default-owner")
+	assert.Equal(t, er.PolicyResponse.Rules[1].Name(), "set-team-owner")
+	assert.Equal(t, er.PatchedResource.GetLabels()["owner"], "team")
+
+	policy.Spec.Rules[0].DependsOn = nil
+	er = testMutate(context.TODO(), nil, nil, createContext(t, &policy, resource, kyverno.Create), nil)
+	require.Len(t, er.PolicyResponse.Rules, 2)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Name(), "set-team-owner")
+	assert.Equal(t, er.PatchedResource.GetLabels()["owner"], "default")
+}
+
+func Test_MutateDryRun(t *testing.T) {
+	policyRaw := []byte(`{
+    "apiVersion": "kyverno.io/v1",
+    "kind": "ClusterPolicy",
+    "metadata": {
+      "name": "add-owner"
+    },
+    "spec": {
+      "rules": [
+        {
+          "name": "add-owner",
+          "match": {
+            "resources": {
+              "kinds": ["ConfigMap"]
+            }
+          },
+          "mutate": {
+            "dryRun": true,
+            "patchStrategicMerge": {
+              "metadata": {
+                "labels": {
+                  "owner": "team"
+                }
+              }
+            }
+          }
+        }
+      ]
+    }
+  }`)
+	resourceRaw := []byte(`{
+    "apiVersion": "v1",
+    "kind": "ConfigMap",
+    "metadata": {
+      "name": "config"
+    }
+  }`)
+
+	policy := loadResource[kyverno.ClusterPolicy](t, policyRaw)
+	resource := loadUnstructured(t, resourceRaw)
+
+	er := testMutate(context.TODO(), nil, nil, createContext(t, &policy, resource, kyverno.Create), nil)
+	require.Len(t, er.PolicyResponse.Rules, 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusWarn)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Message(), `dry run, mutation not applied: [{"op":"add","path":"/metadata/labels","value":{"owner":"team"}}]`)
+	require.Equal(t, resource, er.PatchedResource)
+	assert.Equal(t, len(er.GetPatches()), 0)
+
+	resource.SetLabels(map[string]string{"owner": "team"})
+	er = testMutate(context.TODO(), nil, nil, createContext(t, &policy, resource, kyverno.Create), nil)
+	require.Len(t, er.PolicyResponse.Rules, 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusSkip)
+}
@@ -26,9 +26,13 @@ const (
 	NotInRange Operator = "!-"
 )
 
+// rangeEndpoint matches a signed number, optionally followed by an exponent (1e3), a quantity suffix (128Mi)
+// or duration units (1h30m)
+const rangeEndpoint = `[-+]?\d+(?:\.\d+)?(?:[eE][-+]?\d+|[A-Za-z]+(?:\d+(?:\.\d+)?[A-Za-z]+)*)?`
+
 var (
-	InRangeRegex    = regexp.MustCompile(`^([-|\+]?\d+(?:\.\d+)?[A-Za-z]*)-([-|\+]?\d+(?:\.\d+)?[A-Za-z]*)$`)
-	NotInRangeRegex = regexp.MustCompile(`^([-|\+]?\d+(?:\.\d+)?[A-Za-z]*)!-([-|\+]?\d+(?:\.\d+)?[A-Za-z]*)$`)
+	InRangeRegex    = regexp.MustCompile(`^(` + rangeEndpoint + `)\s*-\s*(` + rangeEndpoint + `)$`)
+	NotInRangeRegex = regexp.MustCompile(`^(` + rangeEndpoint + `)\s*!-\s*(` + rangeEndpoint + `)$`)
 )
 
 // GetOperatorFromStringPattern parses opeartor from pattern
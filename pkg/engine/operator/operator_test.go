@@ -51,4 +51,20 @@ func TestGetOperatorFromStringPattern_RangeOperator(t *testing.T) {
 	assert.Equal(t, GetOperatorFromStringPattern("+0!-+1"), NotInRange)
 	assert.Equal(t, GetOperatorFromStringPattern("+0Mi!-+1024Mi"), NotInRange)
 
+	assert.Equal(t, GetOperatorFromStringPattern("1 - 10"), InRange)
+	assert.Equal(t, GetOperatorFromStringPattern("1 !- 10"), NotInRange)
+	assert.Equal(t, GetOperatorFromStringPattern("1e3-1e6"), InRange)
+	assert.Equal(t, GetOperatorFromStringPattern("1h30m-2h"), InRange)
+	assert.Equal(t, GetOperatorFromStringPattern("1.5h!-2h45m30s"), NotInRange)
+
+	assert.Equal(t, GetOperatorFromStringPattern("|1-10"), Equal)
+	assert.Equal(t, GetOperatorFromStringPattern("1h30-2h"), Equal)
+}
+
+func TestGetOperatorFromStringPattern_ComparisonOperators(t *testing.T) {
+	assert.Equal(t, GetOperatorFromStringPattern(">=200Mi"), MoreEqual)
+	assert.Equal(t, GetOperatorFromStringPattern("<=200Mi"), LessEqual)
+	assert.Equal(t, GetOperatorFromStringPattern(">1h"), More)
+	assert.Equal(t, GetOperatorFromStringPattern("<1h"), Less)
+	assert.Equal(t, GetOperatorFromStringPattern("!1h"), NotEqual)
 }
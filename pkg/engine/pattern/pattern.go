@@ -52,6 +52,13 @@ func validateBoolPattern(log logr.Logger, value interface{}, pattern bool) bool
 	switch typedValue := value.(type) {
 	case bool:
 		return pattern == typedValue
+	case string:
+		value, err := strconv.ParseBool(typedValue)
+		if err != nil {
+			log.V(4).Info("Expected type bool", "type", fmt.Sprintf("%T", typedValue), "value", typedValue)
+			return false
+		}
+		return pattern == value
 	default:
 		log.V(4).Info("Expected type bool", "type", fmt.Sprintf("%T", value), "value", value)
 		return false
@@ -39,6 +39,13 @@ func TestValidateValueWithIntPattern_NotPass(t *testing.T) {
 	assert.Assert(t, !validateFloatPattern(logr.Discard(), 8, 7))
 }
 
+func TestValidateValueWithBoolPattern_StringValue(t *testing.T) {
+	assert.Assert(t, validateBoolPattern(logr.Discard(), "false", false))
+	assert.Assert(t, !validateBoolPattern(logr.Discard(), "true", false))
+	assert.Assert(t, !validateBoolPattern(logr.Discard(), "no", false))
+	assert.Assert(t, Validate(logr.Discard(), false, "false"))
+}
+
 func TestValidateValueWithStringPattern_WithSpace(t *testing.T) {
 	assert.Assert(t, validateStringPattern(logr.Discard(), 4, ">= 3"))
 }
@@ -101,6 +108,31 @@ func TestValidateValueWithStringPattern_Ranges(t *testing.T) {
 	assert.Assert(t, validateStringPattern(logr.Discard(), 10, "+0!-+1"))
 	assert.Assert(t, validateStringPattern(logr.Discard(), "1025Mi", "+0Mi!-+1024Mi"))
 
+	assert.Assert(t, validateStringPattern(logr.Discard(), 5, "1 - 10"))
+	assert.Assert(t, !validateStringPattern(logr.Discard(), 11, "1 - 10"))
+	assert.Assert(t, validateStringPattern(logr.Discard(), 11, "1 !- 10"))
+
+	assert.Assert(t, validateStringPattern(logr.Discard(), 500, "1e2-1e3"))
+	assert.Assert(t, !validateStringPattern(logr.Discard(), "2e3", "1e2-1e3"))
+
+	assert.Assert(t, validateStringPattern(logr.Discard(), "1.5Gi", "1Gi-2Gi"))
+	assert.Assert(t, validateStringPattern(logr.Discard(), "1500m", "1-2"))
+}
+
+func TestValidateValueWithStringPattern_DurationRanges(t *testing.T) {
+	assert.Assert(t, validateStringPattern(logr.Discard(), "90m", "1h-2h"))
+	assert.Assert(t, validateStringPattern(logr.Discard(), "1h", "1h-2h"))
+	assert.Assert(t, validateStringPattern(logr.Discard(), "2h", "1h-2h"))
+	assert.Assert(t, !validateStringPattern(logr.Discard(), "3h", "1h-2h"))
+
+	assert.Assert(t, validateStringPattern(logr.Discard(), "1h45m", "1h30m-2h"))
+	assert.Assert(t, !validateStringPattern(logr.Discard(), "1h15m", "1h30m-2h"))
+	assert.Assert(t, validateStringPattern(logr.Discard(), "1h15m", "1h30m!-2h"))
+	assert.Assert(t, !validateStringPattern(logr.Discard(), "1h45m", "1h30m !- 2h"))
+
+	assert.Assert(t, validateStringPattern(logr.Discard(), "30s", "0.5m-1.5m"))
+	assert.Assert(t, validateStringPattern(logr.Discard(), "90s", "0.5m-1.5m"))
+	assert.Assert(t, !validateStringPattern(logr.Discard(), "91s", "0.5m-1.5m"))
 }
 
 func TestValidateNumberWithStr_LessFloatAndInt(t *testing.T) {
@@ -131,6 +163,21 @@ func TestValidateDuration(t *testing.T) {
 	assert.Assert(t, !validateString(logr.Discard(), "12s", "15s", operator.MoreEqual))
 }
 
+func TestValidateValueWithStringPattern_Comparisons(t *testing.T) {
+	assert.Assert(t, validateStringPattern(logr.Discard(), "100Mi", "<=200Mi"))
+	assert.Assert(t, validateStringPattern(logr.Discard(), "200Mi", "<=200Mi"))
+	assert.Assert(t, !validateStringPattern(logr.Discard(), "201Mi", "<=200Mi"))
+	assert.Assert(t, validateStringPattern(logr.Discard(), "0.5Gi", ">=512Mi"))
+	assert.Assert(t, !validateStringPattern(logr.Discard(), "511Mi", ">= 512Mi"))
+
+	assert.Assert(t, validateStringPattern(logr.Discard(), "30m", "<1h"))
+	assert.Assert(t, !validateStringPattern(logr.Discard(), "60m", "<1h"))
+	assert.Assert(t, validateStringPattern(logr.Discard(), "60m", "<=1h"))
+	assert.Assert(t, validateStringPattern(logr.Discard(), "1h0m1s", ">1h"))
+	assert.Assert(t, !validateStringPattern(logr.Discard(), "3600", "<1h"))
+	assert.Assert(t, !validateStringPattern(logr.Discard(), "abc", "<1h"))
+}
+
 func TestValidateQuantity_Equal(t *testing.T) {
 	assert.Assert(t, validateString(logr.Discard(), "1024Gi", "1024Gi", operator.Equal))
 	assert.Assert(t, validateString(logr.Discard(), "1024Mi", "1Gi", operator.Equal))
@@ -759,6 +806,22 @@ func Test_split(t *testing.T) {
 		want:  "",
 		want1: "",
 		want2: false,
+	}, {
+		args: args{
+			pattern: "-1h30m - 2h",
+			r:       operator.InRangeRegex,
+		},
+		want:  "-1h30m",
+		want1: "2h",
+		want2: true,
+	}, {
+		args: args{
+			pattern: "1e3!-+2Gi",
+			r:       operator.NotInRangeRegex,
+		},
+		want:  "1e3",
+		want1: "+2Gi",
+		want2: true,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,64 @@
+package payload
+
+import (
+	"context"
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/handlers/validation"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/logging"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var logger = logging.WithName("payload")
+
+// Response contains the rule responses of a payload validating policy
+type Response struct {
+	// Policy is the evaluated policy
+	Policy ValidatingPolicy
+	// Rules contains the responses of the rules applied to the payload
+	Rules []engineapi.RuleResponse
+}
+
+// Evaluate evaluates the rules of the policy against a JSON object payload.
+// Rules are evaluated with the same handlers as Kyverno validate rules, skipping resource matching.
+func Evaluate(ctx context.Context, jp jmespath.Interface, configuration config.Configuration, policy ValidatingPolicy, payload map[string]interface{}) (Response, error) {
+	response := Response{Policy: policy}
+	handler, err := validation.NewValidateResourceHandler()
+	if err != nil {
+		return response, err
+	}
+	resource := unstructured.Unstructured{Object: payload}
+	for _, payloadRule := range policy.Spec.Rules {
+		rule := payloadRule.rule()
+		kyvernoPolicy := &kyvernov1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: policy.Name},
+			Spec:       kyvernov1.Spec{Rules: []kyvernov1.Rule{rule}},
+		}
+		policyContext, err := engine.NewPolicyContext(jp, resource, kyvernov1.Create, nil, configuration)
+		if err != nil {
+			return response, fmt.Errorf("failed to create policy context: %w", err)
+		}
+		policyContext = policyContext.WithPolicy(kyvernoPolicy)
+		loader := factories.DefaultContextLoaderFactory(nil)(kyvernoPolicy, rule)
+		contextLoader := func(ctx context.Context, entries []kyvernov1.ContextEntry, jsonContext enginecontext.Interface) error {
+			return loader.Load(ctx, jp, nil, nil, entries, jsonContext)
+		}
+		logger := logger.WithValues("policy", policy.Name, "rule", rule.Name)
+		// load rule context, preconditions are checked by the validate handler
+		if err := contextLoader(ctx, rule.Context, policyContext.JSONContext()); err != nil {
+			response.Rules = append(response.Rules, *engineapi.RuleError(rule.Name, engineapi.Validation, "failed to load context", err))
+			continue
+		}
+		_, responses := handler.Process(ctx, logger, policyContext, resource, rule, contextLoader, nil)
+		response.Rules = append(response.Rules, responses...)
+	}
+	return response, nil
+}
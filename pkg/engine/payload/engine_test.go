@@ -0,0 +1,116 @@
+package payload
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/stretchr/testify/assert"
+)
+
+var policyYAML = []byte(`
+apiVersion: json.kyverno.io/v1alpha1
+kind: ValidatingPolicy
+metadata:
+  name: terraform-s3
+spec:
+  rules:
+  - name: require-encryption
+    context:
+    - name: bucketType
+      variable:
+        value: aws_s3_bucket
+    preconditions:
+      all:
+      - key: "{{ request.object.format_version }}"
+        operator: Equals
+        value: "1.2"
+    validate:
+      message: S3 buckets must be encrypted
+      foreach:
+      - list: request.object.planned_values.root_module.resources
+        deny:
+          conditions:
+            all:
+            - key: "{{ element.type }}"
+              operator: Equals
+              value: "{{ bucketType }}"
+            - key: "{{ element.values.server_side_encryption_configuration || '' }}"
+              operator: Equals
+              value: ""
+  - name: require-version
+    validate:
+      pattern:
+        terraform_version: "1.*"
+`)
+
+func Test_Evaluate(t *testing.T) {
+	policies, err := Load(policyYAML)
+	assert.NoError(t, err)
+	assert.Len(t, policies, 1)
+	tests := []struct {
+		name    string
+		payload string
+		want    []engineapi.RuleStatus
+	}{{
+		name:    "encrypted",
+		payload: `{"format_version":"1.2","terraform_version":"1.5.7","planned_values":{"root_module":{"resources":[{"type":"aws_s3_bucket","values":{"server_side_encryption_configuration":[{"rule":{}}]}}]}}}`,
+		want:    []engineapi.RuleStatus{engineapi.RuleStatusPass, engineapi.RuleStatusPass},
+	}, {
+		name:    "not encrypted",
+		payload: `{"format_version":"1.2","terraform_version":"0.14.0","planned_values":{"root_module":{"resources":[{"type":"aws_s3_bucket","values":{}}]}}}`,
+		want:    []engineapi.RuleStatus{engineapi.RuleStatusFail, engineapi.RuleStatusFail},
+	}, {
+		name:    "preconditions not met",
+		payload: `{"format_version":"1.0","terraform_version":"1.5.7"}`,
+		want:    []engineapi.RuleStatus{engineapi.RuleStatusSkip, engineapi.RuleStatusPass},
+	}}
+	cfg := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(cfg)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payload map[string]interface{}
+			assert.NoError(t, json.Unmarshal([]byte(tt.payload), &payload))
+			response, err := Evaluate(context.TODO(), jp, cfg, policies[0], payload)
+			assert.NoError(t, err)
+			var statuses []engineapi.RuleStatus
+			for _, rule := range response.Rules {
+				statuses = append(statuses, rule.Status())
+			}
+			assert.Equal(t, tt.want, statuses)
+		})
+	}
+}
+
+func Test_Load(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr string
+	}{{
+		name:    "wrong kind",
+		policy:  "apiVersion: kyverno.io/v1\nkind: ClusterPolicy\nmetadata:\n  name: test\nspec:\n  rules: []\n",
+		wantErr: "policy test is a kyverno.io/v1 ClusterPolicy, expected a json.kyverno.io/v1alpha1 ValidatingPolicy",
+	}, {
+		name:    "unsupported context",
+		policy:  "apiVersion: json.kyverno.io/v1alpha1\nkind: ValidatingPolicy\nmetadata:\n  name: test\nspec:\n  rules:\n  - name: rule\n    context:\n    - name: cm\n      configMap:\n        name: test\n    validate:\n      pattern:\n        a: b\n",
+		wantErr: "invalid policy test: spec.rules[0].context[0]: only variable entries are supported",
+	}, {
+		name:    "unsupported validation",
+		policy:  "apiVersion: json.kyverno.io/v1alpha1\nkind: ValidatingPolicy\nmetadata:\n  name: test\nspec:\n  rules:\n  - name: rule\n    validate:\n      podSecurity:\n        level: baseline\n",
+		wantErr: "invalid policy test: spec.rules[0].validate: only pattern, anyPattern, deny and foreach are supported",
+	}, {
+		name:    "duplicate rule",
+		policy:  "apiVersion: json.kyverno.io/v1alpha1\nkind: ValidatingPolicy\nmetadata:\n  name: test\nspec:\n  rules:\n  - name: rule\n    validate:\n      pattern:\n        a: b\n  - name: rule\n    validate:\n      pattern:\n        a: b\n",
+		wantErr: "invalid policy test: spec.rules[1].name: duplicate rule name rule",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load([]byte(tt.policy))
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
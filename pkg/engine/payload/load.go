@@ -0,0 +1,68 @@
+package payload
+
+import (
+	"context"
+	"fmt"
+
+	extyaml "github.com/kyverno/kyverno/ext/yaml"
+	"github.com/kyverno/kyverno/pkg/policy/validate"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+)
+
+// Load extracts the payload validating policies from YAML or JSON documents
+func Load(data []byte) ([]ValidatingPolicy, error) {
+	documents, err := extyaml.SplitDocuments(data)
+	if err != nil {
+		return nil, err
+	}
+	var policies []ValidatingPolicy
+	for _, document := range documents {
+		var policy ValidatingPolicy
+		if err := yaml.UnmarshalStrict(document, &policy); err != nil {
+			return nil, fmt.Errorf("failed to decode policy: %w", err)
+		}
+		if policy.APIVersion != APIVersion || policy.Kind != ValidatingPolicyKind {
+			return nil, fmt.Errorf("policy %s is a %s %s, expected a %s %s", policy.Name, policy.APIVersion, policy.Kind, APIVersion, ValidatingPolicyKind)
+		}
+		if err := Validate(policy); err != nil {
+			return nil, fmt.Errorf("invalid policy %s: %w", policy.Name, err)
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// Validate checks the policy only uses validations supported on payloads
+func Validate(policy ValidatingPolicy) error {
+	if policy.Name == "" {
+		return fmt.Errorf("metadata.name is required")
+	}
+	if len(policy.Spec.Rules) == 0 {
+		return fmt.Errorf("spec.rules: at least one rule is required")
+	}
+	names := sets.New[string]()
+	for i, rule := range policy.Spec.Rules {
+		path := fmt.Sprintf("spec.rules[%d]", i)
+		if rule.Name == "" {
+			return fmt.Errorf("%s.name: name is required", path)
+		}
+		if names.Has(rule.Name) {
+			return fmt.Errorf("%s.name: duplicate rule name %s", path, rule.Name)
+		}
+		names.Insert(rule.Name)
+		for j, entry := range rule.Context {
+			if entry.Variable == nil {
+				return fmt.Errorf("%s.context[%d]: only variable entries are supported", path, j)
+			}
+		}
+		validation := rule.Validation
+		if validation.PodSecurity != nil || validation.CEL != nil || validation.Manifests != nil || validation.Immutable != nil || validation.ImageAllowList != nil || validation.Baseline != nil || validation.Rego != nil {
+			return fmt.Errorf("%s.validate: only pattern, anyPattern, deny and foreach are supported", path)
+		}
+		if field, err := validate.NewValidateFactory(&validation).Validate(context.TODO()); err != nil {
+			return fmt.Errorf("%s.validate.%s: %w", path, field, err)
+		}
+	}
+	return nil
+}
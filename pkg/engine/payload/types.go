@@ -0,0 +1,57 @@
+package payload
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// APIVersion is the api version of payload policies
+	APIVersion = "json.kyverno.io/v1alpha1"
+	// ValidatingPolicyKind is the kind of payload validating policies
+	ValidatingPolicyKind = "ValidatingPolicy"
+)
+
+// ValidatingPolicy validates arbitrary JSON payloads (Terraform plans, Dockerfiles converted to JSON, service requests...).
+// Unlike Kyverno policies, its rules are not bound to resource kinds and apply to every payload meeting their preconditions.
+type ValidatingPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the policy rules.
+	Spec ValidatingPolicySpec `json:"spec"`
+}
+
+// ValidatingPolicySpec declares the rules of a payload validating policy.
+type ValidatingPolicySpec struct {
+	// Rules are evaluated against the payload, in order.
+	Rules []ValidatingRule `json:"rules"`
+}
+
+// ValidatingRule validates a payload, the payload is available in variables as `request.object`.
+type ValidatingRule struct {
+	// Name is the rule name, unique in the policy.
+	Name string `json:"name"`
+
+	// Context defines variables available in the rule, only variable entries are supported.
+	// +optional
+	Context []kyvernov1.ContextEntry `json:"context,omitempty"`
+
+	// Preconditions select the payloads the rule applies to.
+	// +optional
+	RawAnyAllConditions *apiextv1.JSON `json:"preconditions,omitempty"`
+
+	// Validation checks the payload with pattern, anyPattern, deny or foreach declarations.
+	Validation kyvernov1.Validation `json:"validate"`
+}
+
+// rule converts the payload rule to a Kyverno rule so that it can be evaluated by the engine handlers
+func (r ValidatingRule) rule() kyvernov1.Rule {
+	return kyvernov1.Rule{
+		Name:                r.Name,
+		Context:             r.Context,
+		RawAnyAllConditions: r.RawAnyAllConditions,
+		Validation:          r.Validation,
+	}
+}
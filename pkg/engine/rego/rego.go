@@ -0,0 +1,142 @@
+package rego
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"k8s.io/utils/lru"
+)
+
+const (
+	// defaultRule is the rule of the module package queried when no query is set
+	defaultRule = "deny"
+	// cacheSize is the maximum number of prepared queries kept in the cache
+	cacheSize = 1000
+)
+
+// deniedBuiltins are the builtins reaching the network or exposing the runtime of Kyverno, modules using them fail to compile
+var deniedBuiltins = map[string]bool{
+	ast.HTTPSend.Name:        true,
+	ast.NetLookupIPAddr.Name: true,
+	ast.OPARuntime.Name:      true,
+}
+
+type cacheKey struct {
+	module string
+	query  string
+}
+
+// cache holds the most recently used prepared queries by module and query, rules are compiled once
+var cache = lru.New(cacheSize)
+
+var capabilities = newCapabilities()
+
+// newCapabilities returns the capabilities of the OPA version without the denied builtins
+func newCapabilities() *ast.Capabilities {
+	c := ast.CapabilitiesForThisVersion()
+	builtins := make([]*ast.Builtin, 0, len(c.Builtins))
+	for _, builtin := range c.Builtins {
+		if !deniedBuiltins[builtin.Name] {
+			builtins = append(builtins, builtin)
+		}
+	}
+	c.Builtins = builtins
+	return c
+}
+
+// Prepare compiles the module and prepares the query for evaluation
+func Prepare(ctx context.Context, r kyvernov1.Rego) (rego.PreparedEvalQuery, error) {
+	key := cacheKey{module: r.Module, query: r.Query}
+	if prepared, ok := cache.Get(key); ok {
+		return prepared.(rego.PreparedEvalQuery), nil
+	}
+	module, err := ast.ParseModule("rule.rego", r.Module)
+	if err != nil {
+		return rego.PreparedEvalQuery{}, err
+	}
+	if module == nil {
+		return rego.PreparedEvalQuery{}, fmt.Errorf("module is empty")
+	}
+	query := r.Query
+	if query == "" {
+		query = module.Package.Path.String() + "." + defaultRule
+	}
+	prepared, err := rego.New(
+		rego.Query(query),
+		rego.ParsedModule(module),
+		rego.Capabilities(capabilities),
+		rego.StrictBuiltinErrors(true),
+	).PrepareForEval(ctx)
+	if err != nil {
+		return rego.PreparedEvalQuery{}, err
+	}
+	cache.Add(key, prepared)
+	return prepared, nil
+}
+
+// Evaluate evaluates the query against the input and returns the violation messages
+func Evaluate(ctx context.Context, r kyvernov1.Rego, input interface{}) ([]string, error) {
+	prepared, err := Prepare(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	results, err := prepared.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return nil, err
+	}
+	var violations []string
+	for _, result := range results {
+		for _, expression := range result.Expressions {
+			messages, err := messages(expression.Value)
+			if err != nil {
+				return nil, err
+			}
+			violations = append(violations, messages...)
+		}
+	}
+	sort.Strings(violations)
+	return violations, nil
+}
+
+// messages converts the value produced by the query, a set is returned as an array by OPA
+func messages(value interface{}) ([]string, error) {
+	switch typed := value.(type) {
+	case []interface{}:
+		var out []string
+		for _, item := range typed {
+			message, err := message(item)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, message)
+		}
+		return out, nil
+	case bool:
+		if typed {
+			return []string{"rego query returned true"}, nil
+		}
+		return nil, nil
+	default:
+		message, err := message(typed)
+		if err != nil {
+			return nil, err
+		}
+		return []string{message}, nil
+	}
+}
+
+func message(value interface{}) (string, error) {
+	switch typed := value.(type) {
+	case string:
+		return typed, nil
+	case map[string]interface{}:
+		if msg, ok := typed["msg"].(string); ok {
+			return msg, nil
+		}
+	}
+	return "", fmt.Errorf("violations must be strings or objects with a msg field, got %T", value)
+}
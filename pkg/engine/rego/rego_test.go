@@ -0,0 +1,70 @@
+package rego
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	input := map[string]interface{}{
+		"request": map[string]interface{}{
+			"object": map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "test"},
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		rego    kyvernov1.Rego
+		want    []string
+		wantErr bool
+	}{{
+		name: "default query",
+		rego: kyvernov1.Rego{Module: "package kubernetes\n\ndeny[msg] {\n  not input.request.object.metadata.labels.app\n  msg := \"label app is required\"\n}\n"},
+		want: []string{"label app is required"},
+	}, {
+		name: "no violation",
+		rego: kyvernov1.Rego{Module: "package kubernetes\n\ndeny[msg] {\n  input.request.object.metadata.name == \"other\"\n  msg := \"denied\"\n}\n"},
+	}, {
+		name: "gatekeeper violations",
+		rego: kyvernov1.Rego{
+			Module: "package kubernetes\n\nviolation[{\"msg\": msg}] {\n  msg := sprintf(\"%s is denied\", [input.request.object.metadata.name])\n}\n",
+			Query:  "data.kubernetes.violation",
+		},
+		want: []string{"test is denied"},
+	}, {
+		name:    "invalid violations",
+		rego:    kyvernov1.Rego{Module: "package kubernetes\n\ndeny[msg] {\n  msg := 1\n}\n"},
+		wantErr: true,
+	}, {
+		name:    "invalid module",
+		rego:    kyvernov1.Rego{Module: "package kubernetes\n\ndeny[msg] {"},
+		wantErr: true,
+	}, {
+		name:    "network builtin",
+		rego:    kyvernov1.Rego{Module: "package kubernetes\n\ndeny[msg] {\n  resp := http.send({\"method\": \"get\", \"url\": \"http://example.com\"})\n  msg := resp.body\n}\n"},
+		wantErr: true,
+	}, {
+		name:    "runtime builtin",
+		rego:    kyvernov1.Rego{Module: "package kubernetes\n\ndeny[msg] {\n  msg := opa.runtime().env.HOME\n}\n"},
+		wantErr: true,
+	}, {
+		name:    "builtin error",
+		rego:    kyvernov1.Rego{Module: "package kubernetes\n\ndeny[msg] {\n  x := to_number(input.request.object.metadata.name)\n  msg := sprintf(\"%v\", [x])\n}\n"},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Evaluate(context.TODO(), tt.rego, input)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
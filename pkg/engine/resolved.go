@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jsonutils"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+)
+
+// resolveRule substitutes the variables of a rule for debugging purposes, values that can't be resolved
+// in the rule context (e.g. foreach element variables) are kept unchanged
+func resolveRule(logger logr.Logger, ctx enginecontext.EvalInterface, rule kyvernov1.Rule) map[string]interface{} {
+	untyped, err := jsonutils.DocumentToUntyped(rule)
+	if err != nil {
+		logger.Error(err, "failed to convert rule")
+		return nil
+	}
+	resolved, _ := resolveValue(logger, ctx, untyped).(map[string]interface{})
+	return resolved
+}
+
+func resolveValue(logger logr.Logger, ctx enginecontext.EvalInterface, value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for k, v := range typed {
+			resolved := resolveValue(logger, ctx, v)
+			// drop the empty blocks of the rule to keep the output readable
+			if m, ok := resolved.(map[string]interface{}); ok && len(m) == 0 {
+				delete(typed, k)
+			} else {
+				typed[k] = resolved
+			}
+		}
+	case []interface{}:
+		for i, v := range typed {
+			typed[i] = resolveValue(logger, ctx, v)
+		}
+	case string:
+		if resolved, err := variables.SubstituteAll(logger, ctx, typed); err == nil {
+			return resolved
+		}
+	}
+	return value
+}
@@ -0,0 +1,48 @@
+package engine
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// resolveResources returns the rule with the resources of its match and exclude statements resolved to kinds,
+// the statements whose resources match the resource being processed are extended to match its kind.
+func (e *engine) resolveResources(rule kyvernov1.Rule, policyContext engineapi.PolicyContext) kyvernov1.Rule {
+	if len(rule.MatchResources.GetResources()) == 0 && len(rule.ExcludeResources.GetResources()) == 0 {
+		return rule
+	}
+	gvk, subresource := policyContext.ResourceKind()
+	gvr := schema.GroupVersionResource(policyContext.RequestResource())
+	if gvr.Resource == "" {
+		if e.client == nil {
+			return rule
+		}
+		resolved, err := e.client.GetGVRFromGVK(gvk)
+		if err != nil {
+			return rule
+		}
+		gvr = resolved
+	}
+	kind := "*"
+	if subresource != "" {
+		kind = "*/" + subresource
+	}
+	resolve := func(resources *kyvernov1.ResourceDescription) {
+		if matchutils.CheckResources(resources.Resources, gvr, subresource) {
+			resources.Kinds = append(resources.Kinds, kind)
+		}
+	}
+	resolved := rule.DeepCopy()
+	for _, match := range []*kyvernov1.MatchResources{&resolved.MatchResources, &resolved.ExcludeResources} {
+		resolve(&match.ResourceDescription)
+		for i := range match.Any {
+			resolve(&match.Any[i].ResourceDescription)
+		}
+		for i := range match.All {
+			resolve(&match.All[i].ResourceDescription)
+		}
+	}
+	return *resolved
+}
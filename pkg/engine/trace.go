@@ -0,0 +1,223 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/anchor"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ruleTrace collects the decisions taken by the engine while evaluating a rule,
+// all methods are no-ops on a nil trace so that tracing costs nothing when disabled
+type ruleTrace struct {
+	engineapi.RuleTrace
+}
+
+func newRuleTrace(policyContext engineapi.PolicyContext, rule kyvernov1.Rule, ruleType engineapi.RuleType, resource unstructured.Unstructured) *ruleTrace {
+	policy := policyContext.Policy()
+	key := policy.GetName()
+	if policy.GetNamespace() != "" {
+		key = policy.GetNamespace() + "/" + key
+	}
+	if resource.Object == nil {
+		resource = policyContext.OldResource()
+	}
+	return &ruleTrace{
+		RuleTrace: engineapi.RuleTrace{
+			Policy:   key,
+			Rule:     rule.Name,
+			Type:     ruleType,
+			Resource: fmt.Sprintf("%s/%s/%s", resource.GetNamespace(), resource.GetKind(), resource.GetName()),
+		},
+	}
+}
+
+func (t *ruleTrace) add(step engineapi.TraceStep) {
+	if t != nil {
+		t.Steps = append(t.Steps, step)
+	}
+}
+
+// match records the result of the match and exclude blocks, the error lists the filters that didn't match or the exclusions hit
+func (t *ruleTrace) match(err error) {
+	if err != nil {
+		t.add(engineapi.TraceStep{Name: engineapi.TraceStepMatch, Result: "not matched", Message: err.Error()})
+	} else {
+		t.add(engineapi.TraceStep{Name: engineapi.TraceStepMatch, Result: "matched"})
+	}
+}
+
+func (t *ruleTrace) skip(message string) {
+	t.add(engineapi.TraceStep{Name: engineapi.TraceStepSkip, Result: "skipped", Message: message})
+}
+
+func (t *ruleTrace) context(entries []kyvernov1.ContextEntry, err error) {
+	if t == nil || len(entries) == 0 {
+		return
+	}
+	var names []interface{}
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+	step := engineapi.TraceStep{Name: engineapi.TraceStepContext, Result: "loaded", Values: map[string]interface{}{"entries": names}}
+	if err != nil {
+		step.Result, step.Message = "error", err.Error()
+	}
+	t.add(step)
+}
+
+// preconditions records the result of the preconditions with the values of every condition after variable substitution
+func (t *ruleTrace) preconditions(logger logr.Logger, jsonContext enginecontext.EvalInterface, conditions apiextensions.JSON, passed bool, message string, err error) {
+	if t == nil || conditions == nil {
+		return
+	}
+	step := engineapi.TraceStep{Name: engineapi.TraceStepPreconditions, Result: "passed", Message: message}
+	if err != nil {
+		step.Result, step.Message = "error", err.Error()
+	} else if !passed {
+		step.Result = "not passed"
+	}
+	if typed, errs := kyvernov1.ParseConditions(field.NewPath("preconditions"), conditions); len(errs) == 0 {
+		if len(typed.AnyConditions) != 0 {
+			step.Steps = append(step.Steps, engineapi.TraceStep{Name: "any", Result: "evaluated", Steps: traceConditions(logger, jsonContext, typed.AnyConditions)})
+		}
+		if len(typed.AllConditions) != 0 {
+			step.Steps = append(step.Steps, engineapi.TraceStep{Name: "all", Result: "evaluated", Steps: traceConditions(logger, jsonContext, typed.AllConditions)})
+		}
+	}
+	t.add(step)
+}
+
+func traceConditions(logger logr.Logger, jsonContext enginecontext.EvalInterface, conditions []kyvernov1.Condition) []engineapi.TraceStep {
+	var steps []engineapi.TraceStep
+	for _, condition := range conditions {
+		step := engineapi.TraceStep{
+			Name:    engineapi.TraceStepCondition,
+			Message: condition.Message,
+			Values: map[string]interface{}{
+				"operator": string(condition.Operator),
+			},
+		}
+		if key, err := variables.SubstituteAllInPreconditions(logger, jsonContext, condition.GetKey()); err == nil {
+			step.Values["key"] = key
+		}
+		if value, err := variables.SubstituteAllInPreconditions(logger, jsonContext, condition.GetValue()); err == nil {
+			step.Values["value"] = value
+		}
+		if result, _, err := variables.Evaluate(logger, jsonContext, condition); err != nil {
+			step.Result, step.Message = "error", err.Error()
+		} else {
+			step.Result = fmt.Sprint(result)
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// anchors records the anchors declared in the validation patterns and whether they were met
+func (t *ruleTrace) anchors(rule kyvernov1.Rule, responses []engineapi.RuleResponse) {
+	if t == nil || !rule.HasValidate() {
+		return
+	}
+	var anchors []string
+	collectAnchors("pattern", rule.Validation.GetPattern(), &anchors)
+	if anyPattern, ok := rule.Validation.GetAnyPattern().([]interface{}); ok {
+		for i, pattern := range anyPattern {
+			collectAnchors(fmt.Sprintf("anyPattern[%d]", i), pattern, &anchors)
+		}
+	}
+	if len(anchors) == 0 {
+		return
+	}
+	result := "met"
+	for _, response := range responses {
+		if response.SkipReason() == engineapi.SkipReasonAnchorsNotMet {
+			result = "not met"
+		}
+	}
+	values := make([]interface{}, 0, len(anchors))
+	for _, a := range anchors {
+		values = append(values, a)
+	}
+	t.add(engineapi.TraceStep{Name: engineapi.TraceStepAnchors, Result: result, Values: map[string]interface{}{"anchors": values}})
+}
+
+func collectAnchors(path string, pattern interface{}, anchors *[]string) {
+	switch typed := pattern.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if anchor.Parse(key) != nil {
+				*anchors = append(*anchors, path+"."+key)
+			}
+			collectAnchors(path+"."+key, typed[key], anchors)
+		}
+	case []interface{}:
+		for i, element := range typed {
+			collectAnchors(fmt.Sprintf("%s[%d]", path, i), element, anchors)
+		}
+	}
+}
+
+// results records the rule responses and the patch applied to the resource
+func (t *ruleTrace) results(logger logr.Logger, responses []engineapi.RuleResponse, resource, patchedResource unstructured.Unstructured) {
+	if t == nil {
+		return
+	}
+	for _, response := range responses {
+		step := engineapi.TraceStep{Name: engineapi.TraceStepResult, Result: string(response.Status()), Message: response.Message()}
+		if reason := response.SkipReason(); reason != "" {
+			step.Values = map[string]interface{}{"skipReason": string(reason)}
+		}
+		if exception := response.Exception(); exception != nil {
+			if step.Values == nil {
+				step.Values = map[string]interface{}{}
+			}
+			step.Values["exception"] = exception.GetNamespace() + "/" + exception.GetName()
+		}
+		t.add(step)
+	}
+	if t.Type != engineapi.Mutation || resource.Object == nil || patchedResource.Object == nil {
+		return
+	}
+	original, err := resource.MarshalJSON()
+	if err != nil {
+		logger.Error(err, "failed to marshal resource")
+		return
+	}
+	patched, err := patchedResource.MarshalJSON()
+	if err != nil {
+		logger.Error(err, "failed to marshal patched resource")
+		return
+	}
+	patches, err := jsonpatch.CreatePatch(original, patched)
+	if err != nil {
+		logger.Error(err, "failed to create patch")
+		return
+	}
+	if len(patches) == 0 {
+		return
+	}
+	var operations []interface{}
+	for _, patch := range patches {
+		operation := map[string]interface{}{"op": patch.Operation, "path": patch.Path}
+		if patch.Operation != "remove" {
+			operation["value"] = patch.Value
+		}
+		operations = append(operations, operation)
+	}
+	t.add(engineapi.TraceStep{Name: engineapi.TraceStepPatch, Result: "applied", Values: map[string]interface{}{"operations": operations}})
+}
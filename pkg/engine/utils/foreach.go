@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"slices"
 
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
@@ -19,8 +20,8 @@ func EvaluateList(jmesPath string, ctx enginecontext.EvalInterface) ([]interface
 	if !ok {
 		return []interface{}{i}, nil
 	}
-
-	return l, nil
+	// the list is shared with the context, only the list itself is copied so that callers can reorder it
+	return slices.Clone(l), nil
 }
 
 // InvertedElement inverted the order of element for patchStrategicMerge  policies as kustomize patch revering the order of patch resources.
@@ -31,7 +32,7 @@ func InvertedElement(elements []interface{}) {
 }
 
 func AddElementToContext(ctx engineapi.PolicyContext, element interface{}, index, nesting int, elementScope *bool) error {
-	data, err := jsonutils.DocumentToUntyped(element)
+	data, err := elementToUntyped(element)
 	if err != nil {
 		return err
 	}
@@ -61,3 +62,14 @@ func AddElementToContext(ctx engineapi.PolicyContext, element interface{}, index
 	}
 	return nil
 }
+
+// elementToUntyped converts an element to untyped JSON data, elements evaluated from the context are already
+// untyped and are referenced instead of being copied as writes to the context are copy-on-write
+func elementToUntyped(element interface{}) (interface{}, error) {
+	switch element.(type) {
+	case map[string]interface{}, []interface{}, string, bool, float64, int64, nil:
+		return element, nil
+	default:
+		return jsonutils.DocumentToUntyped(element)
+	}
+}
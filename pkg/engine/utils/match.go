@@ -20,14 +20,7 @@ func checkNameSpace(namespaces []string, resource unstructured.Unstructured) boo
 	if resource.GetKind() == "Namespace" {
 		resourceNameSpace = resource.GetName()
 	}
-
-	for _, namespace := range namespaces {
-		if wildcard.Match(namespace, resourceNameSpace) {
-			return true
-		}
-	}
-
-	return false
+	return matchutils.CheckNamespaces(namespaces, resourceNameSpace)
 }
 
 // doesResourceMatchConditionBlock filters the resource with defined conditions
@@ -38,6 +31,7 @@ func checkNameSpace(namespaces []string, resource unstructured.Unstructured) boo
 //	Name       string
 //	Namespaces []string
 //	Selector
+//	OwnerReferences
 //
 // UserInfo:
 //
@@ -68,7 +62,8 @@ func doesResourceMatchConditionBlock(
 	}
 
 	var errs []error
-	if len(conditionBlock.Kinds) > 0 {
+	// resources are resolved to kinds before matching, a block with resources only matches the resolved kinds
+	if len(conditionBlock.Kinds) > 0 || len(conditionBlock.Resources) > 0 {
 		// Matching on ephemeralcontainers even when they are not explicitly specified for backward compatibility.
 		if !matchutils.CheckKind(conditionBlock.Kinds, gvk, subresource, true) {
 			errs = append(errs, fmt.Errorf("kind does not match %v", conditionBlock.Kinds))
@@ -122,6 +117,12 @@ func doesResourceMatchConditionBlock(
 		}
 	}
 
+	if len(conditionBlock.OwnerReferences) > 0 {
+		if !matchutils.CheckOwnerReferences(conditionBlock.OwnerReferences, resource.GetOwnerReferences()) {
+			errs = append(errs, fmt.Errorf("owner references does not match"))
+		}
+	}
+
 	if conditionBlock.NamespaceSelector != nil {
 		if resource.GetKind() == "Namespace" {
 			errs = append(errs, fmt.Errorf("namespace selector is not applicable for namespace resource"))
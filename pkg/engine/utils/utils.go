@@ -1,15 +1,12 @@
 package utils
 
 import (
-	"fmt"
 
 	jsonpatch "github.com/evanphx/json-patch/v5"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/logging"
-	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
-	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -37,6 +34,31 @@ func IsEmptyUnstructured(u *unstructured.Unstructured) bool {
 	return false
 }
 
+// serverMetadataFields are the metadata fields populated by the API server
+var serverMetadataFields = []string{"managedFields", "resourceVersion", "generation", "uid", "creationTimestamp", "selfLink"}
+
+// StripServerMetadata returns a copy of the resource without the metadata populated by the API server,
+// only the top level and metadata maps are copied
+func StripServerMetadata(resource unstructured.Unstructured) unstructured.Unstructured {
+	metadata, ok := resource.Object["metadata"].(map[string]interface{})
+	if !ok {
+		return resource
+	}
+	object := make(map[string]interface{}, len(resource.Object))
+	for key, value := range resource.Object {
+		object[key] = value
+	}
+	stripped := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		stripped[key] = value
+	}
+	for _, field := range serverMetadataFields {
+		delete(stripped, field)
+	}
+	object["metadata"] = stripped
+	return unstructured.Unstructured{Object: object}
+}
+
 // ApplyPatches patches given resource with given patches and returns patched document
 // return original resource if any error occurs
 func ApplyPatches(resource []byte, patches [][]byte) ([]byte, error) {
@@ -75,25 +97,6 @@ func ApplyPatchNew(resource, patch []byte) ([]byte, error) {
 	return patchedResource, err
 }
 
-func TransformConditions(original apiextensions.JSON) (interface{}, error) {
-	// conditions are currently in the form of []interface{}
-	oldConditions, err := apiutils.ApiextensionsJsonToKyvernoConditions(original)
-	if err != nil {
-		return nil, err
-	}
-	switch typedValue := oldConditions.(type) {
-	case kyvernov1.AnyAllConditions:
-		return *typedValue.DeepCopy(), nil
-	case []kyvernov1.Condition: // backwards compatibility
-		var copies []kyvernov1.Condition
-		for _, condition := range typedValue {
-			copies = append(copies, *condition.DeepCopy())
-		}
-		return copies, nil
-	}
-	return nil, fmt.Errorf("invalid preconditions")
-}
-
 func IsSameRuleResponse(r1 *engineapi.RuleResponse, r2 *engineapi.RuleResponse) bool {
 	if r1.Name() != r2.Name() ||
 		r1.RuleType() != r2.RuleType() ||
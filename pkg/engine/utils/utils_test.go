@@ -2,6 +2,7 @@ package utils
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 
 	authenticationv1 "k8s.io/api/authentication/v1"
@@ -2468,3 +2469,108 @@ func TestResourceDescriptionExclude_Label_Expression_Match(t *testing.T) {
 		t.Errorf("Testcase has failed due to the following:\n Function has returned no error, even though it was supposed to fail")
 	}
 }
+
+func TestResourceDescriptionExclude_AnyAll(t *testing.T) {
+	rawResource := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+		   "name": "nginx",
+		   "namespace": "prod",
+		   "annotations": {
+			  "owner": "team-a"
+		   }
+		},
+		"spec": {
+		   "containers": [{"name": "nginx", "image": "nginx"}]
+		}
+	 }`)
+	resource, err := kubeutils.BytesToUnstructured(rawResource)
+	if err != nil {
+		t.Errorf("unable to convert raw resource to unstructured: %v", err)
+	}
+	admissionInfo := v1beta1.RequestInfo{
+		AdmissionUserInfo: authenticationv1.UserInfo{Username: "system:serviceaccount:prod:deployer"},
+	}
+	namespaceLabels := map[string]string{"env": "prod"}
+	match := v1.MatchResources{Any: v1.ResourceFilters{{ResourceDescription: v1.ResourceDescription{Kinds: []string{"Pod"}}}}}
+	testCases := []struct {
+		name     string
+		exclude  v1.MatchResources
+		excluded bool
+	}{{
+		name: "any namespace selector",
+		exclude: v1.MatchResources{Any: v1.ResourceFilters{{ResourceDescription: v1.ResourceDescription{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+		}}}},
+		excluded: true,
+	}, {
+		name: "any namespace selector not matching",
+		exclude: v1.MatchResources{Any: v1.ResourceFilters{{ResourceDescription: v1.ResourceDescription{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+		}}}},
+	}, {
+		name:     "any annotations",
+		exclude:  v1.MatchResources{Any: v1.ResourceFilters{{ResourceDescription: v1.ResourceDescription{Annotations: map[string]string{"owner": "team-*"}}}}},
+		excluded: true,
+	}, {
+		name:     "any operations",
+		exclude:  v1.MatchResources{Any: v1.ResourceFilters{{ResourceDescription: v1.ResourceDescription{Operations: []v1.AdmissionOperation{v1.Create}}}}},
+		excluded: true,
+	}, {
+		name:    "any operations not matching",
+		exclude: v1.MatchResources{Any: v1.ResourceFilters{{ResourceDescription: v1.ResourceDescription{Operations: []v1.AdmissionOperation{v1.Delete}}}}},
+	}, {
+		name: "any subjects",
+		exclude: v1.MatchResources{Any: v1.ResourceFilters{{UserInfo: v1.UserInfo{Subjects: []rbacv1.Subject{{
+			Kind: "ServiceAccount", Namespace: "prod", Name: "deployer",
+		}}}}}},
+		excluded: true,
+	}, {
+		name: "all criteria",
+		exclude: v1.MatchResources{All: v1.ResourceFilters{
+			{ResourceDescription: v1.ResourceDescription{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}}},
+			{ResourceDescription: v1.ResourceDescription{Annotations: map[string]string{"owner": "team-a"}, Operations: []v1.AdmissionOperation{v1.Create}}},
+			{UserInfo: v1.UserInfo{Subjects: []rbacv1.Subject{{Kind: "ServiceAccount", Namespace: "prod", Name: "deployer"}}}},
+		}},
+		excluded: true,
+	}, {
+		name: "all criteria with one not matching",
+		exclude: v1.MatchResources{All: v1.ResourceFilters{
+			{ResourceDescription: v1.ResourceDescription{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}}},
+			{ResourceDescription: v1.ResourceDescription{Operations: []v1.AdmissionOperation{v1.Update}}},
+		}},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := v1.Rule{MatchResources: match, ExcludeResources: tc.exclude}
+			err := MatchesResourceDescription(*resource, rule, admissionInfo, namespaceLabels, "", resource.GroupVersionKind(), "", "CREATE")
+			if tc.excluded && err == nil {
+				t.Errorf("resource was expected to be excluded")
+			}
+			if !tc.excluded && err != nil {
+				t.Errorf("resource was not expected to be excluded: %v", err)
+			}
+		})
+	}
+}
+
+func TestStripServerMetadata(t *testing.T) {
+	rawResource := []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test","namespace":"default","labels":{"app":"nginx"},"uid":"1234","resourceVersion":"42","generation":2,"creationTimestamp":"2023-01-01T00:00:00Z","managedFields":[{"manager":"kubectl"}]},"spec":{}}`)
+	resource, err := kubeutils.BytesToUnstructured(rawResource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stripped := StripServerMetadata(*resource)
+	expected := map[string]interface{}{
+		"name":      "test",
+		"namespace": "default",
+		"labels":    map[string]interface{}{"app": "nginx"},
+	}
+	if !reflect.DeepEqual(stripped.Object["metadata"], expected) {
+		t.Errorf("unexpected metadata %v", stripped.Object["metadata"])
+	}
+	if resource.GetUID() != "1234" || resource.GetResourceVersion() != "42" {
+		t.Errorf("the original resource must not be modified")
+	}
+}
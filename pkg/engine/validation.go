@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -17,6 +18,7 @@ func (e *engine) validate(
 	ctx context.Context,
 	logger logr.Logger,
 	policyContext engineapi.PolicyContext,
+	failFast func(engineapi.RuleResponse) bool,
 ) engineapi.PolicyResponse {
 	resp := engineapi.NewPolicyResponse()
 	policy := policyContext.Policy()
@@ -39,6 +41,7 @@ func (e *engine) validate(
 				hasVerifyManifest := rule.HasVerifyManifests()
 				hasValidatePss := rule.HasValidatePodSecurity()
 				hasValidateCEL := rule.HasValidateCEL()
+				hasValidateImageAllowList := rule.HasValidateImageAllowList()
 				if hasVerifyManifest {
 					return validation.NewValidateManifestHandler(
 						policyContext,
@@ -48,6 +51,8 @@ func (e *engine) validate(
 					return validation.NewValidatePssHandler()
 				} else if hasValidateCEL {
 					return validation.NewValidateCELHandler(e.client)
+				} else if hasValidateImageAllowList {
+					return validation.NewValidateImageAllowListHandler(e.imageAllowListResolver)
 				} else {
 					return validation.NewValidateResourceHandler()
 				}
@@ -75,6 +80,10 @@ func (e *engine) validate(
 		if applyRules == kyvernov1.ApplyOne && resp.RulesAppliedCount() > 0 {
 			break
 		}
+		if failFast != nil && slices.ContainsFunc(ruleResp, failFast) {
+			logger.V(3).Info("rule failed in enforce mode, skipping the remaining rules")
+			break
+		}
 	}
 	return resp
 }
@@ -3,8 +3,10 @@ package engine
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
@@ -12,14 +14,21 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/kyverno/kyverno/pkg/toggle"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"gotest.tools/assert"
 	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
 )
 
 func testValidate(
@@ -42,6 +51,9 @@ func testValidate(
 		contextLoader,
 		nil,
 		"",
+		nil,
+		nil,
+		nil,
 	)
 	return e.Validate(
 		ctx,
@@ -337,6 +349,98 @@ func TestValidate_Fail_anyPattern(t *testing.T) {
 	}
 }
 
+func TestValidate_patternAndAnyPattern(t *testing.T) {
+	rawPolicy := []byte(`
+	{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+		   "name": "require-team-and-non-root"
+		},
+		"spec": {
+		   "rules": [
+			  {
+				 "name": "check-team-and-non-root",
+				 "match": {
+					"resources": {
+					   "kinds": [
+						  "Pod"
+					   ]
+					}
+				 },
+				 "validate": {
+					"message": "A team label and runAsNonRoot are required",
+					"pattern": {
+					   "metadata": {
+						  "labels": {
+							 "team": "?*"
+						  }
+					   }
+					},
+					"anyPattern": [
+					   {
+						  "spec": {
+							 "securityContext": {
+								"runAsNonRoot": true
+							 }
+						  }
+					   },
+					   {
+						  "spec": {
+							 "containers": [
+								{
+								   "securityContext": {
+									  "runAsNonRoot": true
+								   }
+								}
+							 ]
+						  }
+					   }
+					]
+				 }
+			  }
+		   ]
+		}
+	 }
+	`)
+
+	testcases := []struct {
+		description string
+		rawResource []byte
+		status      engineapi.RuleStatus
+	}{{
+		description: "pattern and anyPattern match",
+		rawResource: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"myapp-pod","labels":{"team":"foo"}},"spec":{"securityContext":{"runAsNonRoot":true},"containers":[{"name":"nginx","image":"nginx"}]}}`),
+		status:      engineapi.RuleStatusPass,
+	}, {
+		description: "pattern and second anyPattern match",
+		rawResource: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"myapp-pod","labels":{"team":"foo"}},"spec":{"containers":[{"name":"nginx","image":"nginx","securityContext":{"runAsNonRoot":true}}]}}`),
+		status:      engineapi.RuleStatusPass,
+	}, {
+		description: "pattern does not match",
+		rawResource: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"myapp-pod"},"spec":{"securityContext":{"runAsNonRoot":true},"containers":[{"name":"nginx","image":"nginx"}]}}`),
+		status:      engineapi.RuleStatusFail,
+	}, {
+		description: "anyPattern does not match",
+		rawResource: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"myapp-pod","labels":{"team":"foo"}},"spec":{"containers":[{"name":"nginx","image":"nginx"}]}}`),
+		status:      engineapi.RuleStatusFail,
+	}}
+
+	var policy kyvernov1.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			resourceUnstructured, err := kubeutils.BytesToUnstructured(testcase.rawResource)
+			assert.NilError(t, err)
+			er := testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+			assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+			assert.Equal(t, er.PolicyResponse.Rules[0].Status(), testcase.status, er.PolicyResponse.Rules[0].Message())
+		})
+	}
+}
+
 func TestValidate_host_network_port(t *testing.T) {
 	rawPolicy := []byte(`
 	{
@@ -3224,3 +3328,1052 @@ func Test_ValidatePattern_anyPattern(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidateImmutable(t *testing.T) {
+	deployment := func(replicas int, labels string) []byte {
+		return []byte(fmt.Sprintf(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"test","namespace":"default","labels":{%s}},"spec":{"replicas":%d}}`, labels, replicas))
+	}
+	now := time.Now().UTC()
+	inWindow := fmt.Sprintf(`"windows":[{"start":"%s","end":"%s"}]`, now.Add(-time.Hour).Format(time.RFC3339), now.Add(time.Hour).Format(time.RFC3339))
+	outOfWindow := fmt.Sprintf(`"windows":[{"start":"%s","end":"%s"}]`, now.Add(time.Hour).Format(time.RFC3339), now.Add(2*time.Hour).Format(time.RFC3339))
+	selector := `"selector":{"matchLabels":{"incident-mode":"true"}}`
+	tests := []struct {
+		name        string
+		immutable   string
+		oldResource []byte
+		newResource []byte
+		want        engineapi.RuleStatus
+	}{{
+		name:        "always immutable",
+		oldResource: deployment(1, ``),
+		newResource: deployment(2, ``),
+		want:        engineapi.RuleStatusFail,
+	}, {
+		name:        "unchanged field",
+		oldResource: deployment(1, ``),
+		newResource: deployment(1, `"foo":"bar"`),
+		want:        engineapi.RuleStatusPass,
+	}, {
+		name:        "in window",
+		immutable:   inWindow,
+		oldResource: deployment(1, ``),
+		newResource: deployment(2, ``),
+		want:        engineapi.RuleStatusFail,
+	}, {
+		name:        "out of window",
+		immutable:   outOfWindow,
+		oldResource: deployment(1, ``),
+		newResource: deployment(2, ``),
+		want:        engineapi.RuleStatusPass,
+	}, {
+		name:        "label set",
+		immutable:   selector,
+		oldResource: deployment(1, `"incident-mode":"true"`),
+		newResource: deployment(2, `"incident-mode":"true"`),
+		want:        engineapi.RuleStatusFail,
+	}, {
+		name:        "label not set",
+		immutable:   selector,
+		oldResource: deployment(1, ``),
+		newResource: deployment(2, `"incident-mode":"true"`),
+		want:        engineapi.RuleStatusPass,
+	}, {
+		name:        "create",
+		newResource: deployment(2, ``),
+		want:        engineapi.RuleStatusSkip,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			immutable := `"fields":["spec.replicas"]`
+			if tt.immutable != "" {
+				immutable += "," + tt.immutable
+			}
+			rawPolicy := []byte(fmt.Sprintf(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"freeze-replicas"},"spec":{"rules":[{"name":"freeze-replicas","match":{"any":[{"resources":{"kinds":["Deployment"]}}]},"validate":{"immutable":{%s}}}]}}`, immutable))
+			var policy kyvernov1.ClusterPolicy
+			assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+			newResource, err := kubeutils.BytesToUnstructured(tt.newResource)
+			assert.NilError(t, err)
+			operation := kyvernov1.Create
+			pc := newPolicyContext(t, *newResource, operation, nil).WithPolicy(&policy)
+			if tt.oldResource != nil {
+				oldResource, err := kubeutils.BytesToUnstructured(tt.oldResource)
+				assert.NilError(t, err)
+				operation = kyvernov1.Update
+				pc = newPolicyContext(t, *newResource, operation, nil).WithPolicy(&policy).WithOldResource(*oldResource)
+			}
+			resp := testValidate(context.TODO(), registryclient.NewOrDie(), pc, cfg, nil)
+			assert.Equal(t, len(resp.PolicyResponse.Rules), 1)
+			assert.Equal(t, resp.PolicyResponse.Rules[0].Status(), tt.want, resp.PolicyResponse.Rules[0].Message())
+		})
+	}
+}
+
+func Test_ValidateBaseline(t *testing.T) {
+	pod := func(images ...string) []byte {
+		var containers []string
+		for i, image := range images {
+			containers = append(containers, fmt.Sprintf(`{"name":"c%d","image":"%s"}`, i, image))
+		}
+		return []byte(fmt.Sprintf(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test","namespace":"default"},"spec":{"containers":[%s]}}`, strings.Join(containers, ",")))
+	}
+	baseline := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "registries", Namespace: "kyverno"},
+		Data:       map[string]string{"values": `["ghcr.io/kyverno/app:v1","nginx:1.25"]`},
+	}
+	tests := []struct {
+		name       string
+		mode       kyvernov1.BaselineMode
+		resource   []byte
+		configMaps []runtime.Object
+		want       engineapi.RuleStatus
+		wantValues string
+	}{{
+		name:       "record",
+		mode:       kyvernov1.BaselineRecord,
+		resource:   pod("nginx:1.25", "busybox", "nginx:1.25"),
+		want:       engineapi.RuleStatusPass,
+		wantValues: `["busybox","nginx:1.25"]`,
+	}, {
+		name:       "enforce recorded values",
+		mode:       kyvernov1.BaselineEnforce,
+		resource:   pod("nginx:1.25", "ghcr.io/kyverno/app:v1"),
+		configMaps: []runtime.Object{baseline},
+		want:       engineapi.RuleStatusPass,
+	}, {
+		name:       "enforce unknown value",
+		mode:       kyvernov1.BaselineEnforce,
+		resource:   pod("nginx:1.25", "busybox"),
+		configMaps: []runtime.Object{baseline},
+		want:       engineapi.RuleStatusFail,
+	}, {
+		name:     "enforce without baseline",
+		mode:     kyvernov1.BaselineEnforce,
+		resource: pod("nginx:1.25"),
+		want:     engineapi.RuleStatusError,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawPolicy := []byte(fmt.Sprintf(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"baseline-images"},"spec":{"rules":[{"name":"baseline-images","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"baseline":{"mode":"%s","jmesPath":"spec.containers[].image","configMap":{"name":"registries","namespace":"kyverno"}}}}]}}`, tt.mode))
+			var policy kyvernov1.ClusterPolicy
+			assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+			resource, err := kubeutils.BytesToUnstructured(tt.resource)
+			assert.NilError(t, err)
+			resolver, err := resolvers.NewClientBasedResolver(kubefake.NewSimpleClientset(tt.configMaps...))
+			assert.NilError(t, err)
+			pc := newPolicyContext(t, *resource, kyvernov1.Create, nil).WithPolicy(&policy)
+			resp := testValidate(context.TODO(), registryclient.NewOrDie(), pc, cfg, factories.DefaultContextLoaderFactory(resolver))
+			assert.Equal(t, len(resp.PolicyResponse.Rules), 1)
+			rule := resp.PolicyResponse.Rules[0]
+			assert.Equal(t, rule.Status(), tt.want, rule.Message())
+			assert.Equal(t, rule.Properties()[engineapi.BaselineValuesProperty], tt.wantValues)
+		})
+	}
+}
+
+type enableRegoRules struct {
+	toggle.Toggles
+	enabled bool
+}
+
+func (t enableRegoRules) EnableRegoRules() bool { return t.enabled }
+
+func Test_ValidateRego(t *testing.T) {
+	module := `package kubernetes
+
+deny[msg] {
+  not input.request.object.metadata.labels.team
+  msg := sprintf("pod %s must have a team label", [input.request.object.metadata.name])
+}
+`
+	tests := []struct {
+		name     string
+		disabled bool
+		resource []byte
+		want     engineapi.RuleStatus
+		wantMsg  string
+	}{{
+		name:     "labelled",
+		resource: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test","namespace":"default","labels":{"team":"kyverno"}},"spec":{"containers":[{"name":"nginx","image":"nginx"}]}}`),
+		want:     engineapi.RuleStatusPass,
+		wantMsg:  "validation rule 'require-team' passed.",
+	}, {
+		name:     "not labelled",
+		resource: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test","namespace":"default"},"spec":{"containers":[{"name":"nginx","image":"nginx"}]}}`),
+		want:     engineapi.RuleStatusFail,
+		wantMsg:  "pod test must have a team label",
+	}, {
+		name:     "disabled",
+		disabled: true,
+		resource: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test","namespace":"default"},"spec":{"containers":[{"name":"nginx","image":"nginx"}]}}`),
+		want:     engineapi.RuleStatusSkip,
+		wantMsg:  "rego rules are disabled",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawModule, err := json.Marshal(module)
+			assert.NilError(t, err)
+			rawPolicy := []byte(fmt.Sprintf(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"require-team"},"spec":{"rules":[{"name":"require-team","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"rego":{"module":%s}}}]}}`, rawModule))
+			var policy kyvernov1.ClusterPolicy
+			assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+			resource, err := kubeutils.BytesToUnstructured(tt.resource)
+			assert.NilError(t, err)
+			pc := newPolicyContext(t, *resource, kyvernov1.Create, nil).WithPolicy(&policy)
+			ctx := toggle.NewContext(context.TODO(), enableRegoRules{toggle.FromContext(context.TODO()), !tt.disabled})
+			resp := testValidate(ctx, registryclient.NewOrDie(), pc, cfg, nil)
+			assert.Equal(t, len(resp.PolicyResponse.Rules), 1)
+			rule := resp.PolicyResponse.Rules[0]
+			assert.Equal(t, rule.Status(), tt.want, rule.Message())
+			assert.Equal(t, rule.Message(), tt.wantMsg)
+		})
+	}
+}
+
+func Test_ValidateServerMetadata(t *testing.T) {
+	pod := func(generation int) []byte {
+		return []byte(fmt.Sprintf(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test","namespace":"default","generation":%d,"resourceVersion":"%d"},"spec":{"containers":[{"name":"nginx","image":"nginx"}]}}`, generation, generation))
+	}
+	tests := []struct {
+		name           string
+		serverMetadata kyvernov1.ServerMetadataMode
+		want           engineapi.RuleStatus
+	}{{
+		name: "default",
+		want: engineapi.RuleStatusPass,
+	}, {
+		name:           "strip",
+		serverMetadata: kyvernov1.ServerMetadataStrip,
+		want:           engineapi.RuleStatusPass,
+	}, {
+		name:           "preserve",
+		serverMetadata: kyvernov1.ServerMetadataPreserve,
+		want:           engineapi.RuleStatusFail,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawPolicy := []byte(fmt.Sprintf(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"first-generation"},"spec":{"rules":[{"name":"first-generation","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"serverMetadata":"%s","pattern":{"metadata":{"=(generation)":1}}}}]}}`, tt.serverMetadata))
+			var policy kyvernov1.ClusterPolicy
+			assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+			oldResource, err := kubeutils.BytesToUnstructured(pod(1))
+			assert.NilError(t, err)
+			newResource, err := kubeutils.BytesToUnstructured(pod(2))
+			assert.NilError(t, err)
+			pc := newPolicyContext(t, *newResource, kyvernov1.Update, nil).WithPolicy(&policy).WithOldResource(*oldResource)
+			resp := testValidate(context.TODO(), registryclient.NewOrDie(), pc, cfg, nil)
+			assert.Equal(t, len(resp.PolicyResponse.Rules), 1)
+			rule := resp.PolicyResponse.Rules[0]
+			assert.Equal(t, rule.Status(), tt.want, rule.Message())
+		})
+	}
+}
+
+func TestValidate_LatencyBudget(t *testing.T) {
+	rawResource := []byte(`
+	{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+		   "name": "myapp-pod",
+		   "namespace": "default"
+		},
+		"spec": {
+		   "containers": [
+			  {
+				 "name": "nginx",
+				 "image": "nginx"
+			  }
+		   ]
+		}
+	 }
+	`)
+	tests := []struct {
+		name   string
+		action kyvernov1.ValidationFailureAction
+		status engineapi.RuleStatus
+	}{{
+		name:   "audit",
+		action: kyvernov1.Audit,
+		status: engineapi.RuleStatusSkip,
+	}, {
+		name:   "enforce",
+		action: kyvernov1.Enforce,
+		status: engineapi.RuleStatusFail,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawPolicy := []byte(`
+			{
+				"apiVersion": "kyverno.io/v1",
+				"kind": "ClusterPolicy",
+				"metadata": {
+				   "name": "validate-namespace"
+				},
+				"spec": {
+				   "validationFailureAction": "` + string(tt.action) + `",
+				   "rules": [
+					  {
+						 "name": "check-default-namespace",
+						 "match": {
+							"resources": {
+							   "kinds": [
+								  "Pod"
+							   ]
+							}
+						 },
+						 "validate": {
+							"message": "Using default namespace is not allowed",
+							"pattern": {
+							   "metadata": {
+								  "namespace": "!default"
+							   }
+							}
+						 }
+					  }
+				   ]
+				}
+			}
+			`)
+			var policy kyvernov1.ClusterPolicy
+			err := json.Unmarshal(rawPolicy, &policy)
+			assert.NilError(t, err)
+			resourceUnstructured, err := kubeutils.BytesToUnstructured(rawResource)
+			assert.NilError(t, err)
+			ctx := engineapi.WithLatencyBudget(context.TODO(), time.Now().Add(-time.Second))
+			er := testValidate(ctx, registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+			assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+			assert.Equal(t, er.PolicyResponse.Rules[0].Status(), tt.status)
+			if tt.status == engineapi.RuleStatusSkip {
+				assert.Equal(t, er.PolicyResponse.Rules[0].SkipReason(), engineapi.SkipReasonBudgetExceeded)
+			}
+		})
+	}
+}
+
+func TestValidate_ReportProperties(t *testing.T) {
+	rawResource := []byte(`
+	{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+		   "name": "myapp-pod",
+		   "namespace": "default",
+		   "labels": {
+			  "team": "payments"
+		   }
+		},
+		"spec": {
+		   "containers": [
+			  {
+				 "name": "nginx",
+				 "image": "nginx"
+			  }
+		   ]
+		}
+	 }
+	`)
+	rawPolicy := []byte(`
+	{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+		   "name": "validate-namespace"
+		},
+		"spec": {
+		   "rules": [
+			  {
+				 "name": "check-default-namespace",
+				 "match": {
+					"resources": {
+					   "kinds": [
+						  "Pod"
+					   ]
+					}
+				 },
+				 "reportProperties": {
+					"team": "{{ request.object.metadata.labels.team }}",
+					"containers": "{{ length(request.object.spec.containers) }}",
+					"owner": "{{ request.object.metadata.labels.owner }}",
+					"channel": "#alerts"
+				 },
+				 "validate": {
+					"message": "Using default namespace is not allowed",
+					"pattern": {
+					   "metadata": {
+						  "namespace": "!default"
+					   }
+					}
+				 }
+			  }
+		   ]
+		}
+	}
+	`)
+	var policy kyvernov1.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+	resourceUnstructured, err := kubeutils.BytesToUnstructured(rawResource)
+	assert.NilError(t, err)
+	er := testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusFail)
+	assert.DeepEqual(t, er.PolicyResponse.Rules[0].Properties(), map[string]string{
+		"team":       "payments",
+		"containers": "1",
+		"path":       "/metadata/namespace/",
+		"channel":    "#alerts",
+	})
+}
+
+func TestValidate_RuleInfoVariables(t *testing.T) {
+	rawResource := []byte(`
+	{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+		   "name": "myapp-pod",
+		   "namespace": "default"
+		},
+		"spec": {
+		   "containers": [
+			  {
+				 "name": "nginx",
+				 "image": "nginx"
+			  }
+		   ]
+		}
+	 }
+	`)
+	rawPolicy := []byte(`
+	{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+		   "name": "validate-namespace"
+		},
+		"spec": {
+		   "rules": [
+			  {
+				 "name": "check-default-namespace",
+				 "match": {
+					"resources": {
+					   "kinds": [
+						  "Pod"
+					   ]
+					}
+				 },
+				 "reportProperties": {
+					"source": "{{ policy }}/{{ rule }}"
+				 },
+				 "validate": {
+					"message": "{{ ruleType }} rule {{ rule }} of policy {{ policy }} failed",
+					"pattern": {
+					   "metadata": {
+						  "namespace": "!default"
+					   }
+					}
+				 }
+			  }
+		   ]
+		}
+	}
+	`)
+	var policy kyvernov1.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+	resourceUnstructured, err := kubeutils.BytesToUnstructured(rawResource)
+	assert.NilError(t, err)
+	er := testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusFail)
+	assert.Assert(t, strings.Contains(er.PolicyResponse.Rules[0].Message(), "Validation rule check-default-namespace of policy validate-namespace failed"), er.PolicyResponse.Rules[0].Message())
+	assert.DeepEqual(t, er.PolicyResponse.Rules[0].Properties(), map[string]string{
+		"source": "validate-namespace/check-default-namespace",
+		"path":   "/metadata/namespace/",
+	})
+}
+
+func TestValidate_MessageTemplate(t *testing.T) {
+	rawResource := []byte(`
+	{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+		   "name": "myapp-pod",
+		   "namespace": "default"
+		},
+		"spec": {
+		   "containers": [
+			  {
+				 "name": "nginx",
+				 "image": "nginx"
+			  }
+		   ]
+		}
+	 }
+	`)
+	rawPolicy := []byte(`
+	{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+		   "name": "validate-namespace"
+		},
+		"spec": {
+		   "rules": [
+			  {
+				 "name": "check-default-namespace",
+				 "match": {
+					"resources": {
+					   "kinds": [
+						  "Pod"
+					   ]
+					}
+				 },
+				 "validate": {
+					"message": "Using default namespace is not allowed",
+					"messageTemplate": "Pod {{ request.object.metadata.name }} is in the default namespace.\nMove it to a team namespace.",
+					"remediationUrl": "https://example.com/policies/namespaces",
+					"pattern": {
+					   "metadata": {
+						  "namespace": "!default"
+					   }
+					}
+				 }
+			  }
+		   ]
+		}
+	}
+	`)
+	var policy kyvernov1.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+	resourceUnstructured, err := kubeutils.BytesToUnstructured(rawResource)
+	assert.NilError(t, err)
+	er := testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusFail)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Message(), "Pod myapp-pod is in the default namespace.\nMove it to a team namespace.")
+	assert.DeepEqual(t, er.PolicyResponse.Rules[0].Properties(), map[string]string{
+		engineapi.RemediationURLProperty: "https://example.com/policies/namespaces",
+		engineapi.PathProperty:           "/metadata/namespace/",
+	})
+	// passing resources are left untouched
+	unstructured.SetNestedField(resourceUnstructured.Object, "team", "metadata", "namespace")
+	er = testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusPass)
+	assert.Equal(t, len(er.PolicyResponse.Rules[0].Properties()), 0)
+}
+
+func TestValidate_ObjectSizeExceeded(t *testing.T) {
+	rawResource := []byte(`
+	{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+		   "name": "myapp-pod",
+		   "namespace": "default"
+		},
+		"spec": {
+		   "containers": [
+			  {
+				 "name": "nginx",
+				 "image": "nginx"
+			  }
+		   ]
+		}
+	 }
+	`)
+	tests := []struct {
+		name   string
+		action kyvernov1.ValidationFailureAction
+		status engineapi.RuleStatus
+	}{{
+		name:   "audit",
+		action: kyvernov1.Audit,
+		status: engineapi.RuleStatusSkip,
+	}, {
+		name:   "enforce",
+		action: kyvernov1.Enforce,
+		status: engineapi.RuleStatusFail,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawPolicy := []byte(`
+			{
+				"apiVersion": "kyverno.io/v1",
+				"kind": "ClusterPolicy",
+				"metadata": {
+				   "name": "validate-namespace"
+				},
+				"spec": {
+				   "validationFailureAction": "` + string(tt.action) + `",
+				   "rules": [
+					  {
+						 "name": "check-default-namespace",
+						 "match": {
+							"resources": {
+							   "kinds": [
+								  "Pod"
+							   ]
+							}
+						 },
+						 "validate": {
+							"message": "Using default namespace is not allowed",
+							"pattern": {
+							   "metadata": {
+								  "namespace": "!default"
+							   }
+							}
+						 }
+					  }
+				   ]
+				}
+			}
+			`)
+			var policy kyvernov1.ClusterPolicy
+			err := json.Unmarshal(rawPolicy, &policy)
+			assert.NilError(t, err)
+			resourceUnstructured, err := kubeutils.BytesToUnstructured(rawResource)
+			assert.NilError(t, err)
+			ctx := engineapi.WithObjectSizeExceeded(context.TODO())
+			er := testValidate(ctx, registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+			assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+			assert.Equal(t, er.PolicyResponse.Rules[0].Status(), tt.status)
+			if tt.status == engineapi.RuleStatusSkip {
+				assert.Equal(t, er.PolicyResponse.Rules[0].SkipReason(), engineapi.SkipReasonObjectTooLarge)
+			}
+		})
+	}
+}
+
+func TestValidate_ResolvedRuleRecorder(t *testing.T) {
+	rawResource := []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"myapp-pod","namespace":"default","labels":{"team":"platform"}},"spec":{"containers":[{"name":"nginx","image":"nginx"}]}}`)
+	rawPolicy := []byte(`
+	{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+		   "name": "check-team"
+		},
+		"spec": {
+		   "rules": [
+			  {
+				 "name": "check-team",
+				 "match": {
+					"resources": {
+					   "kinds": [
+						  "Pod"
+					   ]
+					}
+				 },
+				 "context": [
+					{
+					   "name": "team",
+					   "variable": {
+						  "jmesPath": "request.object.metadata.labels.team"
+					   }
+					}
+				 ],
+				 "validate": {
+					"message": "team {{ team }} is not allowed",
+					"foreach": [
+					   {
+						  "list": "request.object.spec.containers",
+						  "pattern": {
+							 "name": "{{ element.name }}"
+						  }
+					   }
+					]
+				 }
+			  }
+		   ]
+		}
+	}
+	`)
+	var policy kyvernov1.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+	resourceUnstructured, err := kubeutils.BytesToUnstructured(rawResource)
+	assert.NilError(t, err)
+	resolved := map[string]map[string]interface{}{}
+	ctx := engineapi.WithResolvedRuleRecorder(context.TODO(), func(policy kyvernov1.PolicyInterface, rule string, rawRule map[string]interface{}) {
+		resolved[policy.GetName()+"/"+rule] = rawRule
+	})
+	er := testValidate(ctx, registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	rule, ok := resolved["check-team/check-team"]
+	assert.Assert(t, ok)
+	validate := rule["validate"].(map[string]interface{})
+	assert.Equal(t, validate["message"], "team platform is not allowed")
+	foreach := validate["foreach"].([]interface{})[0].(map[string]interface{})
+	assert.DeepEqual(t, foreach["pattern"], map[string]interface{}{"name": "{{ element.name }}"})
+}
+
+func TestValidate_RuleTracer(t *testing.T) {
+	rawResource := []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"myapp-pod","namespace":"default","labels":{"team":"platform"}},"spec":{"containers":[{"name":"nginx","image":"nginx"}]}}`)
+	rawPolicy := []byte(`
+	{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+		   "name": "check-team"
+		},
+		"spec": {
+		   "rules": [
+			  {
+				 "name": "check-team",
+				 "match": {
+					"any": [
+					   {
+						  "resources": {
+							 "kinds": [
+								"Pod"
+							 ]
+						  }
+					   }
+					]
+				 },
+				 "preconditions": {
+					"all": [
+					   {
+						  "key": "{{ request.object.metadata.labels.team }}",
+						  "operator": "Equals",
+						  "value": "platform"
+					   }
+					]
+				 },
+				 "validate": {
+					"pattern": {
+					   "spec": {
+						  "containers": [
+							 {
+								"(name)": "nginx",
+								"image": "nginx:*"
+							 }
+						  ]
+					   }
+					}
+				 }
+			  },
+			  {
+				 "name": "check-deployment",
+				 "match": {
+					"any": [
+					   {
+						  "resources": {
+							 "kinds": [
+								"Deployment"
+							 ]
+						  }
+					   }
+					]
+				 },
+				 "validate": {
+					"pattern": {
+					   "spec": {
+						  "replicas": ">1"
+					   }
+					}
+				 }
+			  }
+		   ]
+		}
+	}
+	`)
+	var policy kyvernov1.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+	resourceUnstructured, err := kubeutils.BytesToUnstructured(rawResource)
+	assert.NilError(t, err)
+	traces := map[string]engineapi.RuleTrace{}
+	ctx := engineapi.WithRuleTracer(context.TODO(), func(trace engineapi.RuleTrace) {
+		traces[trace.Rule] = trace
+	})
+	er := testValidate(ctx, registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	trace := traces["check-team"]
+	assert.Equal(t, trace.Policy, "check-team")
+	assert.Equal(t, trace.Resource, "default/Pod/myapp-pod")
+	var names []string
+	for _, step := range trace.Steps {
+		names = append(names, step.Name)
+	}
+	assert.DeepEqual(t, names, []string{"match", "preconditions", "anchors", "result"})
+	preconditions := trace.Steps[1]
+	assert.Equal(t, preconditions.Result, "passed")
+	condition := preconditions.Steps[0].Steps[0]
+	assert.Equal(t, condition.Result, "true")
+	assert.Equal(t, condition.Values["key"], "platform")
+	assert.DeepEqual(t, trace.Steps[2].Values["anchors"], []interface{}{"pattern.spec.containers[0].(name)"})
+	assert.Equal(t, trace.Steps[3].Result, "fail")
+	trace = traces["check-deployment"]
+	assert.Equal(t, len(trace.Steps), 1)
+	assert.Equal(t, trace.Steps[0].Result, "not matched")
+}
+
+func Test_ValidateFailFast(t *testing.T) {
+	rawPolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+			"name": "pod-checks"
+		},
+		"spec": {
+			"validationFailureAction": "Enforce",
+			"rules": [{
+				"name": "check-label",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"validate": {"message": "label app is required", "pattern": {"metadata": {"labels": {"app": "?*"}}}}
+			}, {
+				"name": "check-host-network",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"validate": {"message": "host network is not allowed", "pattern": {"spec": {"hostNetwork": false}}}
+			}]
+		}
+	}`)
+	rawResource := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+			"name": "test",
+			"namespace": "default"
+		},
+		"spec": {
+			"hostNetwork": true,
+			"containers": [{"name": "nginx", "image": "nginx"}]
+		}
+	}`)
+	enabled, disabled := true, false
+	testCases := []struct {
+		name      string
+		failFast  *bool
+		action    kyvernov1.ValidationFailureAction
+		global    bool
+		admission bool
+		rules     int
+	}{{
+		name:      "disabled",
+		action:    kyvernov1.Enforce,
+		admission: true,
+		rules:     2,
+	}, {
+		name:      "enabled by the policy",
+		failFast:  &enabled,
+		action:    kyvernov1.Enforce,
+		admission: true,
+		rules:     1,
+	}, {
+		name:      "enabled globally",
+		action:    kyvernov1.Enforce,
+		global:    true,
+		admission: true,
+		rules:     1,
+	}, {
+		name:      "disabled by the policy",
+		failFast:  &disabled,
+		action:    kyvernov1.Enforce,
+		global:    true,
+		admission: true,
+		rules:     2,
+	}, {
+		name:      "audit",
+		failFast:  &enabled,
+		action:    kyvernov1.Audit,
+		admission: true,
+		rules:     2,
+	}, {
+		name:     "background",
+		failFast: &enabled,
+		action:   kyvernov1.Enforce,
+		rules:    2,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var policy kyvernov1.ClusterPolicy
+			assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+			policy.Spec.FailFast = tc.failFast
+			policy.Spec.ValidationFailureAction = tc.action
+			resource, err := kubeutils.BytesToUnstructured(rawResource)
+			assert.NilError(t, err)
+			configuration := config.NewDefaultConfiguration(false)
+			configuration.Load(&corev1.ConfigMap{Data: map[string]string{"failFast": fmt.Sprint(tc.global)}})
+			policyContext := newPolicyContext(t, *resource, kyvernov1.Create, nil).WithPolicy(&policy).WithAdmissionOperation(tc.admission)
+			er := testValidate(context.TODO(), registryclient.NewOrDie(), policyContext, configuration, nil)
+			assert.Equal(t, len(er.PolicyResponse.Rules), tc.rules)
+			assert.Equal(t, er.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusFail)
+		})
+	}
+}
+
+func TestValidate_CELPreconditions(t *testing.T) {
+	rawResource := []byte(`
+	{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+		   "name": "myapp-pod",
+		   "namespace": "default"
+		},
+		"spec": {
+		   "containers": [
+			  {
+				 "name": "nginx",
+				 "image": "nginx"
+			  }
+		   ]
+		}
+	 }
+	`)
+	rawPolicy := []byte(`
+	{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+		   "name": "validate-namespace"
+		},
+		"spec": {
+		   "rules": [
+			  {
+				 "name": "check-default-namespace",
+				 "match": {
+					"resources": {
+					   "kinds": [
+						  "Pod"
+					   ]
+					}
+				 },
+				 "validate": {
+					"message": "Using default namespace is not allowed",
+					"pattern": {
+					   "metadata": {
+						  "namespace": "!default"
+					   }
+					}
+				 }
+			  }
+		   ]
+		}
+	}
+	`)
+	testCases := []struct {
+		name       string
+		expression string
+		status     engineapi.RuleStatus
+	}{{
+		name:       "met",
+		expression: "request.operation == 'CREATE' && object.metadata.name == 'myapp-pod'",
+		status:     engineapi.RuleStatusFail,
+	}, {
+		name:       "not met",
+		expression: "object.metadata.name.startsWith('system-')",
+		status:     engineapi.RuleStatusSkip,
+	}, {
+		name:       "error",
+		expression: "object.metadata.labels.team == 'payments'",
+		status:     engineapi.RuleStatusError,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var policy kyvernov1.ClusterPolicy
+			assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+			policy.Spec.Rules[0].CELPreconditions = []admissionregistrationv1alpha1.MatchCondition{{
+				Name:       "condition",
+				Expression: tc.expression,
+			}}
+			resource, err := kubeutils.BytesToUnstructured(rawResource)
+			assert.NilError(t, err)
+			er := testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, *resource, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+			assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+			assert.Equal(t, er.PolicyResponse.Rules[0].Status(), tc.status)
+		})
+	}
+}
+
+func TestValidate_MatchResources(t *testing.T) {
+	rawResource := []byte(`
+	{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+		   "name": "myapp-pod",
+		   "namespace": "default"
+		},
+		"spec": {
+		   "containers": [
+			  {
+				 "name": "nginx",
+				 "image": "nginx"
+			  }
+		   ]
+		}
+	 }
+	`)
+	rawPolicy := []byte(`
+	{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+		   "name": "validate-namespace"
+		},
+		"spec": {
+		   "rules": [
+			  {
+				 "name": "check-default-namespace",
+				 "match": {
+					"any": [
+					   {
+						  "resources": {
+							 "resources": []
+						  }
+					   }
+					]
+				 },
+				 "validate": {
+					"message": "Using default namespace is not allowed",
+					"pattern": {
+					   "metadata": {
+						  "namespace": "!default"
+					   }
+					}
+				 }
+			  }
+		   ]
+		}
+	}
+	`)
+	testCases := []struct {
+		name      string
+		resources []string
+		results   int
+	}{{
+		name:      "plural name",
+		resources: []string{"pods"},
+		results:   1,
+	}, {
+		name:      "core group",
+		resources: []string{"pods."},
+		results:   1,
+	}, {
+		name:      "group wildcard",
+		resources: []string{"*.apps"},
+		results:   0,
+	}, {
+		name:      "other resource",
+		resources: []string{"deployments"},
+		results:   0,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var policy kyvernov1.ClusterPolicy
+			assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+			policy.Spec.Rules[0].MatchResources.Any[0].Resources = tc.resources
+			resource, err := kubeutils.BytesToUnstructured(rawResource)
+			assert.NilError(t, err)
+			policyContext := newPolicyContext(t, *resource, kyvernov1.Create, nil).
+				WithPolicy(&policy).
+				WithRequestResource(metav1.GroupVersionResource{Version: "v1", Resource: "pods"})
+			er := testValidate(context.TODO(), registryclient.NewOrDie(), policyContext, cfg, nil)
+			assert.Equal(t, len(er.PolicyResponse.Rules), tc.results)
+		})
+	}
+}
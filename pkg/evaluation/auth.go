@@ -0,0 +1,101 @@
+package evaluation
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+const (
+	// AuthorizationGroup is the api group of the evaluation permissions
+	AuthorizationGroup = "kyverno.io"
+	// AuthorizationResource is the resource of the evaluation permissions
+	AuthorizationResource = "evaluations"
+	// EvaluateVerb is the verb required to evaluate policies
+	EvaluateVerb = "evaluate"
+	// ReplayVerb is the verb required to replay the recorded admission requests
+	ReplayVerb = "replay"
+)
+
+// Authorizer checks whether the owner of a bearer token is allowed to call a method of the evaluation service
+type Authorizer interface {
+	Authorize(ctx context.Context, token string, method string) (bool, error)
+}
+
+type authorizer struct {
+	reviewer auth.TokenReviewer
+}
+
+// NewAuthorizer returns an Authorizer authenticating bearer tokens with the reviewer and granting access
+// to the users allowed to perform the method verb on evaluations.kyverno.io
+func NewAuthorizer(reviewer auth.TokenReviewer) Authorizer {
+	return authorizer{
+		reviewer: reviewer,
+	}
+}
+
+func (a authorizer) Authorize(ctx context.Context, token string, method string) (bool, error) {
+	user, err := a.reviewer.Authenticate(ctx, token)
+	if err != nil {
+		return false, err
+	}
+	if user == nil {
+		return false, nil
+	}
+	return a.reviewer.Authorize(ctx, token, *user, authorizationv1.ResourceAttributes{
+		Verb:     methodVerb(method),
+		Group:    AuthorizationGroup,
+		Resource: AuthorizationResource,
+	})
+}
+
+// methodVerb returns the verb a caller must be granted to call a method, replaying recorded admission
+// requests requires its own permission as it reveals the workloads of the cluster
+func methodVerb(method string) string {
+	if method == replayMethod {
+		return ReplayVerb
+	}
+	return EvaluateVerb
+}
+
+// WithAuthorizer returns a server option rejecting the calls without a bearer token allowed by the authorizer
+func WithAuthorizer(authorizer Authorizer) grpc.ServerOption {
+	return grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var token string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			for _, value := range md.Get("authorization") {
+				if t, ok := strings.CutPrefix(value, "Bearer "); ok {
+					token = t
+				}
+			}
+		}
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "a bearer token is required")
+		}
+		allowed, err := authorizer.Authorize(ctx, token, info.FullMethod)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to authorize the call: %v", err)
+		}
+		if !allowed {
+			return nil, status.Errorf(codes.PermissionDenied, "%s is forbidden", info.FullMethod)
+		}
+		return handler(ctx, req)
+	})
+}
+
+// tokenCredentials sends a bearer token with every call
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
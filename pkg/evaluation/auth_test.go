@@ -0,0 +1,100 @@
+package evaluation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/auth"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+type fakeAuthorizer map[string]string
+
+func (a fakeAuthorizer) Authorize(_ context.Context, token string, method string) (bool, error) {
+	if token == "error" {
+		return false, errors.New("failed")
+	}
+	return a[token] == methodVerb(method), nil
+}
+
+func TestWithAuthorizer(t *testing.T) {
+	client := newClient(t, nil, WithAuthorizer(fakeAuthorizer{"allowed": EvaluateVerb}))
+	tests := []struct {
+		name  string
+		token string
+		want  codes.Code
+	}{{
+		name: "no token",
+		want: codes.Unauthenticated,
+	}, {
+		name:  "forbidden",
+		token: "forbidden",
+		want:  codes.PermissionDenied,
+	}, {
+		name:  "error",
+		token: "error",
+		want:  codes.Internal,
+	}, {
+		name:  "allowed",
+		token: "allowed",
+		want:  codes.OK,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			if tt.token != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+tt.token)
+			}
+			_, err := client.EvaluatePayload(ctx, &EvaluatePayloadRequest{Payload: map[string]interface{}{"foo": "bar"}})
+			assert.Equal(t, tt.want, status.Code(err))
+		})
+	}
+}
+
+func TestWithAuthorizerReplay(t *testing.T) {
+	client := newClient(t, NewRecorder(logging.GlobalLogger(), 10, nil), WithAuthorizer(fakeAuthorizer{
+		"evaluator": EvaluateVerb,
+		"replayer":  ReplayVerb,
+	}))
+	ctx := metadata.AppendToOutgoingContext(context.TODO(), "authorization", "Bearer evaluator")
+	_, err := client.Replay(ctx, &ReplayRequest{Policies: []string{policy}})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	ctx = metadata.AppendToOutgoingContext(context.TODO(), "authorization", "Bearer replayer")
+	_, err = client.Replay(ctx, &ReplayRequest{Policies: []string{policy}})
+	assert.NoError(t, err)
+}
+
+func TestAuthorizer(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "tokenreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		review := action.(clienttesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		review.Status.Authenticated = review.Spec.Token != "invalid"
+		review.Status.User = authenticationv1.UserInfo{Username: review.Spec.Token}
+		return true, review, nil
+	})
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		sar := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attributes := sar.Spec.ResourceAttributes
+		sar.Status.Allowed = sar.Spec.User == "evaluator" &&
+			attributes.Group == AuthorizationGroup &&
+			attributes.Resource == AuthorizationResource &&
+			attributes.Verb == EvaluateVerb
+		return true, sar, nil
+	})
+	authorizer := NewAuthorizer(auth.NewTokenReviewer(kubeClient, auth.DefaultTokenReviewTTL))
+	for token, want := range map[string]bool{"evaluator": true, "other": false, "invalid": false} {
+		allowed, err := authorizer.Authorize(context.TODO(), token, evaluateMethod)
+		assert.NoError(t, err)
+		assert.Equal(t, want, allowed, token)
+	}
+}
@@ -0,0 +1,85 @@
+package evaluation
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// EvaluationClient is the client API of the evaluation service
+type EvaluationClient interface {
+	// Evaluate evaluates a set of policies against a resource
+	Evaluate(context.Context, *EvaluateRequest, ...grpc.CallOption) (*EvaluateResponse, error)
+	// Replay evaluates a set of policies against the recorded admission requests
+	Replay(context.Context, *ReplayRequest, ...grpc.CallOption) (*ReplayResponse, error)
+	// EvaluatePayload evaluates a set of payload validating policies against a JSON payload
+	EvaluatePayload(context.Context, *EvaluatePayloadRequest, ...grpc.CallOption) (*EvaluatePayloadResponse, error)
+}
+
+type client struct {
+	conn grpc.ClientConnInterface
+}
+
+// NewEvaluationClient returns an evaluation client using the given connection
+func NewEvaluationClient(conn grpc.ClientConnInterface) EvaluationClient {
+	return &client{
+		conn: conn,
+	}
+}
+
+func (c *client) Evaluate(ctx context.Context, request *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error) {
+	var response EvaluateResponse
+	opts = append([]grpc.CallOption{grpc.CallContentSubtype(CodecName)}, opts...)
+	if err := c.conn.Invoke(ctx, evaluateMethod, request, &response, opts...); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *client) Replay(ctx context.Context, request *ReplayRequest, opts ...grpc.CallOption) (*ReplayResponse, error) {
+	var response ReplayResponse
+	opts = append([]grpc.CallOption{grpc.CallContentSubtype(CodecName)}, opts...)
+	if err := c.conn.Invoke(ctx, replayMethod, request, &response, opts...); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *client) EvaluatePayload(ctx context.Context, request *EvaluatePayloadRequest, opts ...grpc.CallOption) (*EvaluatePayloadResponse, error) {
+	var response EvaluatePayloadResponse
+	opts = append([]grpc.CallOption{grpc.CallContentSubtype(CodecName)}, opts...)
+	if err := c.conn.Invoke(ctx, payloadMethod, request, &response, opts...); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Dial connects to an evaluation server over TLS, the server certificate is verified
+// with the CA certificate stored in caFile or with the system pool when empty.
+// The token is sent as a bearer token with every call when not empty.
+func Dial(address string, caFile string, token string) (*grpc.ClientConn, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if caFile != "" {
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("failed to load CA certificate from %s", caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	options := []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}
+	if token != "" {
+		options = append(options, grpc.WithPerRPCCredentials(tokenCredentials(token)))
+	}
+	return grpc.Dial(address, options...)
+}
@@ -0,0 +1,30 @@
+package evaluation
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// CodecName is the content subtype used by the evaluation service
+const CodecName = "json"
+
+func init() {
+	encoding.RegisterCodec(codec{})
+}
+
+// codec marshals the evaluation messages as JSON, this avoids the need for generated protobuf types
+// as the policies and resources exchanged are already JSON documents
+type codec struct{}
+
+func (codec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (codec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (codec) Name() string {
+	return CodecName
+}
@@ -0,0 +1,158 @@
+package evaluation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+// RecordedRequest is an anonymized admission request kept for replay
+type RecordedRequest struct {
+	// Request is the admission request, user identity is hashed and secret data is redacted
+	Request admissionv1.AdmissionRequest
+	// Roles is the list of roles of the requester
+	Roles []string
+	// ClusterRoles is the list of cluster roles of the requester
+	ClusterRoles []string
+	// GroupVersionKind is the top level GVK
+	GroupVersionKind schema.GroupVersionKind
+	// NamespaceLabels are the labels of the resource namespace at admission time
+	NamespaceLabels map[string]string
+	// Allowed is the original admission decision
+	Allowed bool
+	// Time is the time the request was recorded
+	Time time.Time
+}
+
+// Recorder keeps the most recent admission requests in a ring buffer
+type Recorder interface {
+	// Record records an admission request and its response
+	Record(handlers.AdmissionRequest, handlers.AdmissionResponse)
+	// Requests returns the recorded requests, oldest first
+	Requests() []RecordedRequest
+}
+
+type recorder struct {
+	logger   logr.Logger
+	nsLister corev1listers.NamespaceLister
+	lock     sync.Mutex
+	buffer   []RecordedRequest
+	next     int
+	full     bool
+}
+
+// NewRecorder returns a recorder keeping up to size admission requests
+func NewRecorder(logger logr.Logger, size int, nsLister corev1listers.NamespaceLister) Recorder {
+	return &recorder{
+		logger:   logger,
+		nsLister: nsLister,
+		buffer:   make([]RecordedRequest, size),
+	}
+}
+
+func (r *recorder) Record(request handlers.AdmissionRequest, response handlers.AdmissionResponse) {
+	if len(r.buffer) == 0 {
+		return
+	}
+	recorded, err := anonymize(request.AdmissionRequest)
+	if err != nil {
+		r.logger.Error(err, "failed to record admission request", "uid", request.UID)
+		return
+	}
+	entry := RecordedRequest{
+		Request:          recorded,
+		Roles:            request.Roles,
+		ClusterRoles:     request.ClusterRoles,
+		GroupVersionKind: request.GroupVersionKind,
+		Allowed:          response.Allowed,
+		Time:             time.Now(),
+	}
+	if r.nsLister != nil {
+		entry.NamespaceLabels = engineutils.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, r.nsLister, r.logger)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.buffer[r.next] = entry
+	r.next = (r.next + 1) % len(r.buffer)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+func (r *recorder) Requests() []RecordedRequest {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.full {
+		return append([]RecordedRequest(nil), r.buffer[:r.next]...)
+	}
+	requests := make([]RecordedRequest, 0, len(r.buffer))
+	requests = append(requests, r.buffer[r.next:]...)
+	return append(requests, r.buffer[:r.next]...)
+}
+
+// anonymize hashes the requester identity and redacts secrets content
+func anonymize(request admissionv1.AdmissionRequest) (admissionv1.AdmissionRequest, error) {
+	request = *request.DeepCopy()
+	if request.UserInfo.Username != "" {
+		// keep service accounts namespaces so that policies matching them can still be replayed
+		prefix := ""
+		if strings.HasPrefix(request.UserInfo.Username, "system:serviceaccount:") {
+			parts := strings.Split(request.UserInfo.Username, ":")
+			if len(parts) == 4 {
+				prefix = strings.Join(parts[:3], ":") + ":"
+			}
+		}
+		request.UserInfo.Username = prefix + hash(request.UserInfo.Username)
+	}
+	request.UserInfo.UID = ""
+	request.UserInfo.Extra = nil
+	if strings.EqualFold(request.Kind.Kind, "Secret") {
+		object, err := redact(request.Object)
+		if err != nil {
+			return request, err
+		}
+		request.Object = object
+		oldObject, err := redact(request.OldObject)
+		if err != nil {
+			return request, err
+		}
+		request.OldObject = oldObject
+	}
+	return request, nil
+}
+
+func redact(object runtime.RawExtension) (runtime.RawExtension, error) {
+	if object.Raw == nil {
+		return object, nil
+	}
+	resource, err := kubeutils.BytesToUnstructured(object.Raw)
+	if err != nil {
+		return object, err
+	}
+	redacted, err := kubeutils.RedactSecret(resource)
+	if err != nil {
+		return object, err
+	}
+	raw, err := json.Marshal(redacted.Object)
+	if err != nil {
+		return object, err
+	}
+	return runtime.RawExtension{Raw: raw}, nil
+}
+
+func hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return "anonymous-" + hex.EncodeToString(sum[:8])
+}
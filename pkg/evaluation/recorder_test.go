@@ -0,0 +1,114 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func admissionRequest(name string) handlers.AdmissionRequest {
+	return handlers.AdmissionRequest{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+			Name:      name,
+			Namespace: "default",
+		},
+	}
+}
+
+func TestRecorder_Requests(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		records int
+		want    []string
+	}{{
+		name:    "disabled",
+		size:    0,
+		records: 2,
+	}, {
+		name:    "not full",
+		size:    3,
+		records: 2,
+		want:    []string{"pod-0", "pod-1"},
+	}, {
+		name:    "full",
+		size:    3,
+		records: 3,
+		want:    []string{"pod-0", "pod-1", "pod-2"},
+	}, {
+		name:    "wrapped",
+		size:    3,
+		records: 5,
+		want:    []string{"pod-2", "pod-3", "pod-4"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRecorder(logging.GlobalLogger(), tt.size, nil)
+			for i := 0; i < tt.records; i++ {
+				r.Record(admissionRequest(fmt.Sprintf("pod-%d", i)), handlers.AdmissionResponse{Allowed: true})
+			}
+			var got []string
+			for _, request := range r.Requests() {
+				got = append(got, request.Request.Name)
+				assert.True(t, request.Allowed)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_anonymize(t *testing.T) {
+	secret, err := json.Marshal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]interface{}{"name": "token", "namespace": "default"},
+		"data":       map[string]interface{}{"token": "c2VjcmV0"},
+	})
+	assert.NoError(t, err)
+	tests := []struct {
+		name     string
+		username string
+		want     string
+	}{{
+		name:     "user",
+		username: "jane@example.com",
+		want:     hash("jane@example.com"),
+	}, {
+		name:     "service account",
+		username: "system:serviceaccount:kube-system:replicaset-controller",
+		want:     "system:serviceaccount:kube-system:" + hash("system:serviceaccount:kube-system:replicaset-controller"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{Version: "v1", Kind: "Secret"},
+				UserInfo: authenticationv1.UserInfo{
+					Username: tt.username,
+					UID:      "1234",
+					Groups:   []string{"system:authenticated"},
+					Extra:    map[string]authenticationv1.ExtraValue{"scopes": {"admin"}},
+				},
+				Object: runtime.RawExtension{Raw: secret},
+			}
+			got, err := anonymize(request)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.UserInfo.Username)
+			assert.Empty(t, got.UserInfo.UID)
+			assert.Nil(t, got.UserInfo.Extra)
+			assert.Equal(t, []string{"system:authenticated"}, got.UserInfo.Groups)
+			assert.NotContains(t, string(got.Object.Raw), "c2VjcmV0")
+			assert.Nil(t, got.OldObject.Raw)
+			// the original request is left untouched
+			assert.Equal(t, tt.username, request.UserInfo.Username)
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package evaluation
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/payload"
+	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	serviceName    = "kyverno.evaluation.v1.Evaluation"
+	evaluateMethod = "/" + serviceName + "/Evaluate"
+	replayMethod   = "/" + serviceName + "/Replay"
+	payloadMethod  = "/" + serviceName + "/EvaluatePayload"
+)
+
+// EvaluationServer is the server API of the evaluation service
+type EvaluationServer interface {
+	// Evaluate evaluates a set of policies against a resource
+	Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error)
+	// Replay evaluates a set of policies against the recorded admission requests
+	Replay(context.Context, *ReplayRequest) (*ReplayResponse, error)
+	// EvaluatePayload evaluates a set of payload validating policies against a JSON payload
+	EvaluatePayload(context.Context, *EvaluatePayloadRequest) (*EvaluatePayloadResponse, error)
+}
+
+// RegisterEvaluationServer registers the evaluation service in the grpc server
+func RegisterEvaluationServer(s grpc.ServiceRegistrar, srv EvaluationServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*EvaluationServer)(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "Evaluate",
+		Handler:    evaluateHandler,
+	}, {
+		MethodName: "Replay",
+		Handler:    replayHandler,
+	}, {
+		MethodName: "EvaluatePayload",
+		Handler:    payloadHandler,
+	}},
+	Streams: []grpc.StreamDesc{},
+}
+
+func evaluateHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EvaluationServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: evaluateMethod,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EvaluationServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func replayHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EvaluationServer).Replay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: replayMethod,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EvaluationServer).Replay(ctx, req.(*ReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func payloadHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluatePayloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EvaluationServer).EvaluatePayload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: payloadMethod,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EvaluationServer).EvaluatePayload(ctx, req.(*EvaluatePayloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+type server struct {
+	engine        engineapi.Engine
+	jp            jmespath.Interface
+	configuration config.Configuration
+	recorder      Recorder
+}
+
+// NewEvaluationServer returns an evaluation server backed by the engine,
+// replaying admission requests requires a recorder
+func NewEvaluationServer(engine engineapi.Engine, jp jmespath.Interface, configuration config.Configuration, recorder Recorder) EvaluationServer {
+	return &server{
+		engine:        engine,
+		jp:            jp,
+		configuration: configuration,
+		recorder:      recorder,
+	}
+}
+
+func (s *server) Evaluate(ctx context.Context, request *EvaluateRequest) (*EvaluateResponse, error) {
+	if len(request.Resource) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "resource is required")
+	}
+	policies, err := loadPolicies(request.Policies)
+	if err != nil {
+		return nil, err
+	}
+	operation := request.Operation
+	if operation == "" {
+		operation = kyvernov1.Create
+	}
+	resource := unstructured.Unstructured{Object: request.Resource}
+	var response EvaluateResponse
+	// resolved rules by policy and rule names
+	resolved := map[string]map[string]map[string]interface{}{}
+	if request.ShowResolved {
+		ctx = engineapi.WithResolvedRuleRecorder(ctx, func(policy kyvernov1.PolicyInterface, rule string, rawRule map[string]interface{}) {
+			key := policyKey(policy)
+			if resolved[key] == nil {
+				resolved[key] = map[string]map[string]interface{}{}
+			}
+			resolved[key][rule] = rawRule
+		})
+	}
+	// mutate
+	for _, policy := range policies {
+		if !policy.GetSpec().HasMutate() {
+			continue
+		}
+		policyContext, err := s.policyContext(resource, operation, request, policy)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		mutateResponse := s.engine.Mutate(ctx, policyContext)
+		response.Results = append(response.Results, newPolicyResult(mutateResponse, resolved[policyKey(policy)]))
+		resource = mutateResponse.PatchedResource
+	}
+	// validate
+	for _, policy := range policies {
+		spec := policy.GetSpec()
+		if !spec.HasValidate() && !spec.HasVerifyImageChecks() {
+			continue
+		}
+		policyContext, err := s.policyContext(resource, operation, request, policy)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		validateResponse := s.engine.Validate(ctx, policyContext)
+		response.Results = append(response.Results, newPolicyResult(validateResponse, resolved[policyKey(policy)]))
+	}
+	response.PatchedResource = resource.Object
+	return &response, nil
+}
+
+func (s *server) policyContext(resource unstructured.Unstructured, operation kyvernov1.AdmissionOperation, request *EvaluateRequest, policy kyvernov1.PolicyInterface) (*engine.PolicyContext, error) {
+	policyContext, err := engine.NewPolicyContext(s.jp, resource, operation, request.UserInfo, s.configuration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create policy context: %w", err)
+	}
+	return policyContext.WithPolicy(policy).WithNamespaceLabels(request.NamespaceLabels), nil
+}
+
+func (s *server) Replay(ctx context.Context, request *ReplayRequest) (*ReplayResponse, error) {
+	if s.recorder == nil {
+		return nil, status.Error(codes.FailedPrecondition, "admission requests recording is disabled")
+	}
+	policies, err := loadPolicies(request.Policies)
+	if err != nil {
+		return nil, err
+	}
+	var response ReplayResponse
+	for _, recorded := range s.recorder.Requests() {
+		result, err := s.replay(ctx, recorded, policies)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		response.Total++
+		if result.Denied {
+			response.Denied++
+			if result.Allowed {
+				response.NewlyDenied++
+			}
+		}
+		if result.Mutated {
+			response.Mutated++
+		}
+		if result.Denied || result.Mutated {
+			response.Results = append(response.Results, result)
+		}
+	}
+	return &response, nil
+}
+
+func (s *server) replay(ctx context.Context, recorded RecordedRequest, policies []kyvernov1.PolicyInterface) (ReplayResult, error) {
+	request := recorded.Request
+	result := ReplayResult{
+		Kind:      request.Kind.Kind,
+		Namespace: request.Namespace,
+		Name:      request.Name,
+		Operation: kyvernov1.AdmissionOperation(request.Operation),
+		Allowed:   recorded.Allowed,
+	}
+	policyContext, err := webhookutils.NewPolicyContextBuilder(s.configuration, s.jp, nil).Build(request, recorded.Roles, recorded.ClusterRoles, recorded.GroupVersionKind)
+	if err != nil {
+		return result, fmt.Errorf("failed to create policy context: %w", err)
+	}
+	policyContext = policyContext.WithNamespaceLabels(recorded.NamespaceLabels)
+	matched := sets.New[string]()
+	// mutate
+	for _, policy := range policies {
+		if !policy.GetSpec().HasMutateStandard() {
+			continue
+		}
+		currentContext := policyContext.WithPolicy(policy)
+		mutateResponse := s.engine.Mutate(ctx, currentContext)
+		if len(mutateResponse.GetPatches()) > 0 {
+			result.Mutated = true
+			matched.Insert(policyKey(policy))
+		}
+		policyContext = currentContext.WithNewResource(mutateResponse.PatchedResource)
+	}
+	// validate
+	for _, policy := range policies {
+		spec := policy.GetSpec()
+		if !spec.HasValidate() && !spec.HasVerifyImageChecks() {
+			continue
+		}
+		validateResponse := s.engine.Validate(ctx, policyContext.WithPolicy(policy))
+		if engineutils.BlockRequest(validateResponse, spec.GetFailurePolicy(ctx)) {
+			result.Denied = true
+			matched.Insert(policyKey(policy))
+		}
+	}
+	result.Policies = sets.List(matched)
+	return result, nil
+}
+
+func (s *server) EvaluatePayload(ctx context.Context, request *EvaluatePayloadRequest) (*EvaluatePayloadResponse, error) {
+	if len(request.Payload) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "payload is required")
+	}
+	var policies []payload.ValidatingPolicy
+	for _, document := range request.Policies {
+		loaded, err := payload.Load([]byte(document))
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to load policies: %v", err)
+		}
+		policies = append(policies, loaded...)
+	}
+	var response EvaluatePayloadResponse
+	for _, policy := range policies {
+		payloadResponse, err := payload.Evaluate(ctx, s.jp, s.configuration, policy, request.Payload)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		response.Results = append(response.Results, newPayloadPolicyResult(payloadResponse))
+	}
+	return &response, nil
+}
+
+func loadPolicies(documents []string) ([]kyvernov1.PolicyInterface, error) {
+	var policies []kyvernov1.PolicyInterface
+	for _, document := range documents {
+		loaded, _, err := yamlutils.GetPolicy([]byte(document))
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to load policies: %v", err)
+		}
+		policies = append(policies, loaded...)
+	}
+	return policies, nil
+}
+
+func policyKey(policy kyvernov1.PolicyInterface) string {
+	if policy.GetNamespace() != "" {
+		return policy.GetNamespace() + "/" + policy.GetName()
+	}
+	return policy.GetName()
+}
+
+// Serve starts a grpc server exposing the evaluation service on the given address,
+// it stops when the context is cancelled
+func Serve(ctx context.Context, logger logr.Logger, address string, srv EvaluationServer, opts ...grpc.ServerOption) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	s := grpc.NewServer(opts...)
+	RegisterEvaluationServer(s, srv)
+	go func() {
+		<-ctx.Done()
+		s.GracefulStop()
+	}()
+	logger.Info("starting evaluation server", "address", address)
+	return s.Serve(listener)
+}
+
+// TLSCredentials returns a server option serving the certificate returned by the provider
+func TLSCredentials(provider func() ([]byte, []byte, error)) grpc.ServerOption {
+	return grpc.Creds(credentials.NewTLS(&tls.Config{
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			certPem, keyPem, err := provider()
+			if err != nil {
+				return nil, err
+			}
+			pair, err := tls.X509KeyPair(certPem, keyPem)
+			if err != nil {
+				return nil, err
+			}
+			return &pair, nil
+		},
+		MinVersion: tls.VersionTLS12,
+	}))
+}
@@ -0,0 +1,325 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const policy = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  validationFailureAction: Enforce
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: label team is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+`
+
+func newClient(t *testing.T, recorder Recorder, opts ...grpc.ServerOption) EvaluationClient {
+	cfg := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(cfg)
+	eng := engine.NewEngine(
+		cfg,
+		config.NewDefaultMetricsConfiguration(),
+		jp,
+		nil,
+		nil,
+		imageverifycache.DisabledImageVerifyCache(),
+		factories.DefaultContextLoaderFactory(nil),
+		nil,
+		"",
+		nil,
+		nil,
+		nil,
+	)
+	listener := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer(opts...)
+	RegisterEvaluationServer(s, NewEvaluationServer(eng, jp, cfg, recorder))
+	go func() {
+		_ = s.Serve(listener)
+	}()
+	t.Cleanup(s.Stop)
+	conn, err := grpc.DialContext(
+		context.TODO(),
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return NewEvaluationClient(conn)
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  map[string]interface{}
+		want    engineapi.RuleStatus
+		wantErr bool
+	}{{
+		name:   "pass",
+		labels: map[string]interface{}{"team": "kyverno"},
+		want:   engineapi.RuleStatusPass,
+	}, {
+		name:   "fail",
+		labels: map[string]interface{}{"app": "nginx"},
+		want:   engineapi.RuleStatusFail,
+	}}
+	client := newClient(t, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := client.Evaluate(context.TODO(), &EvaluateRequest{
+				Policies: []string{policy},
+				Resource: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "Pod",
+					"metadata": map[string]interface{}{
+						"name":      "nginx",
+						"namespace": "default",
+						"labels":    tt.labels,
+					},
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": "nginx", "image": "nginx"},
+						},
+					},
+				},
+			})
+			assert.NoError(t, err)
+			assert.Len(t, response.Results, 1)
+			assert.Equal(t, "require-labels", response.Results[0].Name)
+			assert.Len(t, response.Results[0].Rules, 1)
+			assert.Equal(t, tt.want, response.Results[0].Rules[0].Status)
+		})
+	}
+}
+
+func TestEvaluateShowResolved(t *testing.T) {
+	client := newClient(t, nil)
+	response, err := client.Evaluate(context.TODO(), &EvaluateRequest{
+		Policies: []string{mutatePolicy},
+		Resource: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name":      "nginx",
+				"namespace": "default",
+			},
+		},
+		ShowResolved: true,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, response.Results, 1)
+	assert.Len(t, response.Results[0].Rules, 1)
+	resolved := response.Results[0].Rules[0].Resolved
+	assert.NotNil(t, resolved)
+	assert.Equal(t, "add-app", resolved["name"])
+	assert.Equal(t, map[string]interface{}{"+(app)": "nginx"}, resolved["mutate"].(map[string]interface{})["patchStrategicMerge"].(map[string]interface{})["metadata"].(map[string]interface{})["labels"])
+}
+
+func TestEvaluateInvalidRequest(t *testing.T) {
+	client := newClient(t, nil)
+	_, err := client.Evaluate(context.TODO(), &EvaluateRequest{
+		Policies: []string{policy},
+	})
+	assert.Error(t, err)
+}
+
+const mutatePolicy = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: add-labels
+spec:
+  rules:
+  - name: add-app
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    mutate:
+      patchStrategicMerge:
+        metadata:
+          labels:
+            +(app): "{{ request.object.metadata.name }}"
+`
+
+func recordPod(t *testing.T, recorder Recorder, name string, labels map[string]interface{}) {
+	raw, err := json.Marshal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "default",
+			"labels":    labels,
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "nginx", "image": "nginx"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	recorder.Record(handlers.AdmissionRequest{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:       types.UID(name),
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+			Resource:  metav1.GroupVersionResource{Version: "v1", Resource: "pods"},
+			Name:      name,
+			Namespace: "default",
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+		GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+	}, handlers.AdmissionResponse{Allowed: true})
+}
+
+func TestReplay(t *testing.T) {
+	recorder := NewRecorder(logging.GlobalLogger(), 10, nil)
+	recordPod(t, recorder, "labelled", map[string]interface{}{"team": "kyverno", "app": "nginx"})
+	recordPod(t, recorder, "unlabelled", map[string]interface{}{"app": "nginx"})
+	recordPod(t, recorder, "no-app", map[string]interface{}{"team": "kyverno"})
+	client := newClient(t, recorder)
+	tests := []struct {
+		name     string
+		policies []string
+		want     ReplayResponse
+	}{{
+		name:     "validate",
+		policies: []string{policy},
+		want: ReplayResponse{
+			Total:       3,
+			Denied:      1,
+			NewlyDenied: 1,
+			Results: []ReplayResult{{
+				Kind:      "Pod",
+				Namespace: "default",
+				Name:      "unlabelled",
+				Operation: kyvernov1.Create,
+				Allowed:   true,
+				Denied:    true,
+				Policies:  []string{"require-labels"},
+			}},
+		},
+	}, {
+		name:     "mutate",
+		policies: []string{mutatePolicy},
+		want: ReplayResponse{
+			Total:   3,
+			Mutated: 1,
+			Results: []ReplayResult{{
+				Kind:      "Pod",
+				Namespace: "default",
+				Name:      "no-app",
+				Operation: kyvernov1.Create,
+				Allowed:   true,
+				Mutated:   true,
+				Policies:  []string{"add-labels"},
+			}},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := client.Replay(context.TODO(), &ReplayRequest{Policies: tt.policies})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, *response)
+		})
+	}
+}
+
+func TestReplayDisabled(t *testing.T) {
+	client := newClient(t, nil)
+	_, err := client.Replay(context.TODO(), &ReplayRequest{Policies: []string{policy}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+const payloadPolicy = `
+apiVersion: json.kyverno.io/v1alpha1
+kind: ValidatingPolicy
+metadata:
+  name: require-version
+spec:
+  rules:
+  - name: check-version
+    validate:
+      message: terraform 1.x is required
+      pattern:
+        terraform_version: "1.*"
+`
+
+func TestEvaluatePayload(t *testing.T) {
+	client := newClient(t, nil)
+	tests := []struct {
+		name    string
+		payload map[string]interface{}
+		want    engineapi.RuleStatus
+	}{{
+		name:    "pass",
+		payload: map[string]interface{}{"terraform_version": "1.5.7"},
+		want:    engineapi.RuleStatusPass,
+	}, {
+		name:    "fail",
+		payload: map[string]interface{}{"terraform_version": "0.14.0"},
+		want:    engineapi.RuleStatusFail,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := client.EvaluatePayload(context.TODO(), &EvaluatePayloadRequest{
+				Policies: []string{payloadPolicy},
+				Payload:  tt.payload,
+			})
+			assert.NoError(t, err)
+			assert.Len(t, response.Results, 1)
+			assert.Equal(t, "require-version", response.Results[0].Name)
+			assert.Len(t, response.Results[0].Rules, 1)
+			assert.Equal(t, tt.want, response.Results[0].Rules[0].Status)
+		})
+	}
+}
+
+func TestEvaluatePayloadInvalidRequest(t *testing.T) {
+	client := newClient(t, nil)
+	_, err := client.EvaluatePayload(context.TODO(), &EvaluatePayloadRequest{Policies: []string{payloadPolicy}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.EvaluatePayload(context.TODO(), &EvaluatePayloadRequest{
+		Policies: []string{policy},
+		Payload:  map[string]interface{}{"a": "b"},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,128 @@
+package evaluation
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/payload"
+)
+
+// EvaluateRequest contains the policy set and the resource to evaluate
+type EvaluateRequest struct {
+	// Policies contains the JSON or YAML documents of the policies to evaluate
+	Policies []string `json:"policies"`
+	// Resource is the resource to evaluate the policies against
+	Resource map[string]interface{} `json:"resource"`
+	// Operation is the admission operation, defaults to CREATE
+	Operation kyvernov1.AdmissionOperation `json:"operation,omitempty"`
+	// NamespaceLabels are the labels of the resource namespace
+	NamespaceLabels map[string]string `json:"namespaceLabels,omitempty"`
+	// UserInfo is the admission request user information
+	UserInfo *kyvernov1beta1.RequestInfo `json:"userInfo,omitempty"`
+	// ShowResolved returns the rules after variable substitution in the results
+	ShowResolved bool `json:"showResolved,omitempty"`
+}
+
+// EvaluateResponse contains the evaluation results
+type EvaluateResponse struct {
+	// Results contains the results of every policy evaluated
+	Results []PolicyResult `json:"results,omitempty"`
+	// PatchedResource is the resource after mutate policies were applied
+	PatchedResource map[string]interface{} `json:"patchedResource,omitempty"`
+}
+
+// PolicyResult contains the rule results of a policy
+type PolicyResult struct {
+	Namespace string       `json:"namespace,omitempty"`
+	Name      string       `json:"name"`
+	Rules     []RuleResult `json:"rules,omitempty"`
+}
+
+// RuleResult contains the result of a rule
+type RuleResult struct {
+	Name    string               `json:"name"`
+	Type    engineapi.RuleType   `json:"type"`
+	Status  engineapi.RuleStatus `json:"status"`
+	Message string               `json:"message,omitempty"`
+	// Resolved is the rule after variable substitution, only set when requested
+	Resolved map[string]interface{} `json:"resolved,omitempty"`
+}
+
+func newPolicyResult(response engineapi.EngineResponse, resolved map[string]map[string]interface{}) PolicyResult {
+	policy := response.Policy()
+	result := PolicyResult{
+		Namespace: policy.GetNamespace(),
+		Name:      policy.GetName(),
+	}
+	for _, rule := range response.PolicyResponse.Rules {
+		result.Rules = append(result.Rules, RuleResult{
+			Name:     rule.Name(),
+			Type:     rule.RuleType(),
+			Status:   rule.Status(),
+			Message:  rule.Message(),
+			Resolved: resolved[rule.Name()],
+		})
+	}
+	return result
+}
+
+// ReplayRequest contains the policy set to evaluate against the recorded admission requests
+type ReplayRequest struct {
+	// Policies contains the JSON or YAML documents of the policies to evaluate
+	Policies []string `json:"policies"`
+}
+
+// ReplayResponse summarizes how the recorded admission requests would be handled by the policy set
+type ReplayResponse struct {
+	// Total is the number of replayed requests
+	Total int `json:"total"`
+	// Denied is the number of requests the policy set would deny
+	Denied int `json:"denied"`
+	// NewlyDenied is the number of requests the policy set would deny that were originally allowed
+	NewlyDenied int `json:"newlyDenied"`
+	// Mutated is the number of requests the policy set would mutate
+	Mutated int `json:"mutated"`
+	// Results contains the requests that would be denied or mutated
+	Results []ReplayResult `json:"results,omitempty"`
+}
+
+// ReplayResult contains the outcome of a replayed request
+type ReplayResult struct {
+	Kind      string                       `json:"kind"`
+	Namespace string                       `json:"namespace,omitempty"`
+	Name      string                       `json:"name,omitempty"`
+	Operation kyvernov1.AdmissionOperation `json:"operation"`
+	Allowed   bool                         `json:"allowed"`
+	Denied    bool                         `json:"denied"`
+	Mutated   bool                         `json:"mutated"`
+	Policies  []string                     `json:"policies,omitempty"`
+}
+
+// EvaluatePayloadRequest contains the payload validating policies and the JSON payload to evaluate
+type EvaluatePayloadRequest struct {
+	// Policies contains the JSON or YAML documents of the payload validating policies to evaluate
+	Policies []string `json:"policies"`
+	// Payload is the JSON object to evaluate the policies against
+	Payload map[string]interface{} `json:"payload"`
+}
+
+// EvaluatePayloadResponse contains the payload evaluation results
+type EvaluatePayloadResponse struct {
+	// Results contains the results of every policy evaluated
+	Results []PolicyResult `json:"results,omitempty"`
+}
+
+func newPayloadPolicyResult(response payload.Response) PolicyResult {
+	result := PolicyResult{
+		Name: response.Policy.Name,
+	}
+	for _, rule := range response.Rules {
+		result.Rules = append(result.Rules, RuleResult{
+			Name:    rule.Name(),
+			Type:    rule.RuleType(),
+			Status:  rule.Status(),
+			Message: rule.Message(),
+		})
+	}
+	return result
+}
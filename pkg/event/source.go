@@ -14,4 +14,6 @@ const (
 	MutateExistingController Source = "kyverno-mutate"
 	// CleanupController : event generated for cleanup policies
 	CleanupController Source = "kyverno-cleanup"
+	// ConfigController : event generated when a configmap is rejected
+	ConfigController Source = "kyverno-config"
 )
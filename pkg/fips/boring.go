@@ -0,0 +1,15 @@
+//go:build boringcrypto
+
+package fips
+
+import (
+	"crypto/boring"
+	// restrict TLS configurations to FIPS approved settings
+	_ "crypto/tls/fipsonly"
+)
+
+const built = true
+
+func enabled() bool {
+	return boring.Enabled()
+}
@@ -0,0 +1,31 @@
+// Package fips reports whether kyverno runs with a FIPS validated crypto backend.
+//
+// Binaries built with GOEXPERIMENT=boringcrypto (see the FIPS=true make variable) use BoringCrypto for
+// TLS and signature verification and restrict TLS to FIPS approved settings.
+package fips
+
+import (
+	"errors"
+)
+
+// Built returns true if the binary was built with the BoringCrypto backend
+func Built() bool {
+	return built
+}
+
+// Enabled returns true if the FIPS validated crypto backend is in use
+func Enabled() bool {
+	return enabled()
+}
+
+// Check verifies the crypto backend at runtime, it fails when the binary was built with BoringCrypto but the
+// backend is not in use, or when FIPS mode is required and the binary was not built with BoringCrypto
+func Check(required bool) error {
+	if built && !enabled() {
+		return errors.New("built with BoringCrypto but the FIPS crypto backend is not in use")
+	}
+	if required && !enabled() {
+		return errors.New("FIPS mode is required but the binary was not built with a FIPS validated crypto backend")
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package fips
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	assert.Equal(t, built, Built())
+	assert.Equal(t, built, Enabled())
+	assert.NoError(t, Check(false))
+	if built {
+		assert.NoError(t, Check(true))
+	} else {
+		assert.Error(t, Check(true))
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !boringcrypto
+
+package fips
+
+const built = false
+
+func enabled() bool {
+	return false
+}
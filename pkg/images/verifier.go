@@ -2,6 +2,7 @@ package images
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
@@ -47,4 +48,6 @@ type Options struct {
 type Response struct {
 	Digest     string
 	Statements []map[string]interface{}
+	// SignedAt is the signing time of the most recent verified signature, zero when unknown
+	SignedAt time.Time
 }
@@ -5,6 +5,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	corev1informers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
@@ -22,10 +23,32 @@ func NewSecretInformer(
 	name string,
 	resyncPeriod time.Duration,
 ) corev1informers.SecretInformer {
-	indexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
 	options := func(lo *metav1.ListOptions) {
 		lo.FieldSelector = fields.OneTermEqualSelector(metav1.ObjectNameField, name).String()
 	}
+	return newFilteredSecretInformer(client, namespace, resyncPeriod, options)
+}
+
+// NewLabelledSecretInformer returns an informer watching the secrets of a namespace matching the label selector
+func NewLabelledSecretInformer(
+	client kubernetes.Interface,
+	namespace string,
+	selector labels.Selector,
+	resyncPeriod time.Duration,
+) corev1informers.SecretInformer {
+	options := func(lo *metav1.ListOptions) {
+		lo.LabelSelector = selector.String()
+	}
+	return newFilteredSecretInformer(client, namespace, resyncPeriod, options)
+}
+
+func newFilteredSecretInformer(
+	client kubernetes.Interface,
+	namespace string,
+	resyncPeriod time.Duration,
+	options func(*metav1.ListOptions),
+) corev1informers.SecretInformer {
+	indexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
 	informer := corev1informers.NewFilteredSecretInformer(
 		client,
 		namespace,
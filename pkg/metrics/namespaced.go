@@ -0,0 +1,134 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/auth"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+// NamespaceLabel is the metric label used to filter the metrics served to namespace admins
+const NamespaceLabel = "resource_namespace"
+
+// NamespaceAuthorizer checks whether the owner of a bearer token has access to the metrics of namespaces
+type NamespaceAuthorizer interface {
+	// Authorize returns the first namespace the owner of the token has no access to, or an empty string
+	// when all the namespaces are allowed
+	Authorize(ctx context.Context, token string, namespaces ...string) (string, error)
+}
+
+type namespaceAuthorizer struct {
+	reviewer auth.TokenReviewer
+}
+
+// NewNamespaceAuthorizer returns a NamespaceAuthorizer granting access to the metrics of a namespace
+// to the users allowed to list the policy reports of this namespace
+func NewNamespaceAuthorizer(reviewer auth.TokenReviewer) NamespaceAuthorizer {
+	return namespaceAuthorizer{
+		reviewer: reviewer,
+	}
+}
+
+func (a namespaceAuthorizer) Authorize(ctx context.Context, token string, namespaces ...string) (string, error) {
+	if len(namespaces) == 0 {
+		return "", nil
+	}
+	// the token is authenticated once for all the namespaces
+	user, err := a.reviewer.Authenticate(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	if user == nil {
+		return namespaces[0], nil
+	}
+	for _, namespace := range namespaces {
+		allowed, err := a.reviewer.Authorize(ctx, token, *user, authorizationv1.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      "list",
+			Group:     "wgpolicyk8s.io",
+			Resource:  "policyreports",
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to authorize access to namespace %s: %w", namespace, err)
+		}
+		if !allowed {
+			return namespace, nil
+		}
+	}
+	return "", nil
+}
+
+// NamespacedHandler serves the metrics labelled with the namespaces given in the namespace query parameter,
+// the caller must send a bearer token granting access to all these namespaces. Metrics without a namespace
+// label are never served.
+func NamespacedHandler(gatherer prometheus.Gatherer, authorizer NamespaceAuthorizer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		namespaces := r.URL.Query()["namespace"]
+		if len(namespaces) == 0 {
+			http.Error(w, "at least one namespace query parameter is required", http.StatusBadRequest)
+			return
+		}
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			http.Error(w, "a bearer token is required", http.StatusUnauthorized)
+			return
+		}
+		forbidden, err := authorizer.Authorize(r.Context(), token, namespaces...)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if forbidden != "" {
+			http.Error(w, fmt.Sprintf("access to the metrics of namespace %s is forbidden", forbidden), http.StatusForbidden)
+			return
+		}
+		families, err := gatherer.Gather()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to gather metrics: %v", err), http.StatusInternalServerError)
+			return
+		}
+		format := expfmt.Negotiate(r.Header)
+		w.Header().Set("Content-Type", string(format))
+		encoder := expfmt.NewEncoder(w, format)
+		for _, family := range filterNamespaces(families, namespaces) {
+			if err := encoder.Encode(family); err != nil {
+				return
+			}
+		}
+	})
+}
+
+// filterNamespaces keeps the metrics labelled with one of the namespaces and drops the empty families
+func filterNamespaces(families []*dto.MetricFamily, namespaces []string) []*dto.MetricFamily {
+	allowed := map[string]bool{}
+	for _, namespace := range namespaces {
+		allowed[namespace] = true
+	}
+	var filtered []*dto.MetricFamily
+	for _, family := range families {
+		var metrics []*dto.Metric
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == NamespaceLabel && allowed[label.GetValue()] {
+					metrics = append(metrics, metric)
+					break
+				}
+			}
+		}
+		if len(metrics) != 0 {
+			filtered = append(filtered, &dto.MetricFamily{
+				Name:   family.Name,
+				Help:   family.Help,
+				Type:   family.Type,
+				Metric: metrics,
+			})
+		}
+	}
+	return filtered
+}
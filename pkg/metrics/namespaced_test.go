@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gotest.tools/assert"
+)
+
+type fakeAuthorizer map[string]bool
+
+func (a fakeAuthorizer) Authorize(_ context.Context, token string, namespaces ...string) (string, error) {
+	for _, namespace := range namespaces {
+		if token != "token" || !a[namespace] {
+			return namespace, nil
+		}
+	}
+	return "", nil
+}
+
+func TestNamespacedHandler(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	results := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "kyverno_policy_results"}, []string{"policy_name", NamespaceLabel})
+	results.WithLabelValues("require-labels", "tenant-a").Inc()
+	results.WithLabelValues("require-labels", "tenant-b").Inc()
+	changes := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "kyverno_policy_changes"}, []string{"policy_name"})
+	changes.WithLabelValues("require-labels").Inc()
+	registry.MustRegister(results, changes)
+	handler := NamespacedHandler(registry, fakeAuthorizer{"tenant-a": true})
+	tests := []struct {
+		name   string
+		query  string
+		token  string
+		status int
+	}{{
+		name:   "no namespace",
+		token:  "token",
+		status: http.StatusBadRequest,
+	}, {
+		name:   "no token",
+		query:  "?namespace=tenant-a",
+		status: http.StatusUnauthorized,
+	}, {
+		name:   "forbidden namespace",
+		query:  "?namespace=tenant-a&namespace=tenant-b",
+		token:  "token",
+		status: http.StatusForbidden,
+	}, {
+		name:   "allowed namespace",
+		query:  "?namespace=tenant-a",
+		token:  "token",
+		status: http.StatusOK,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/namespaced-metrics"+tt.query, nil)
+			if tt.token != "" {
+				request.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			assert.Equal(t, tt.status, recorder.Code)
+			if tt.status == http.StatusOK {
+				body := recorder.Body.String()
+				assert.Assert(t, strings.Contains(body, `resource_namespace="tenant-a"`), body)
+				assert.Assert(t, !strings.Contains(body, "tenant-b"), body)
+				assert.Assert(t, !strings.Contains(body, "kyverno_policy_changes"), body)
+			}
+		})
+	}
+}
@@ -55,13 +55,13 @@ func ParseResourceRequestOperation(requestOperationStr string) (ResourceRequestO
 
 func ParseRuleTypeFromEngineRuleResponse(rule engineapi.RuleResponse) RuleType {
 	switch rule.RuleType() {
-	case "Validation":
+	case engineapi.Validation:
 		return Validate
-	case "Mutation":
+	case engineapi.Mutation:
 		return Mutate
-	case "Generation":
+	case engineapi.Generation:
 		return Generate
-	case "ImageVerify":
+	case engineapi.ImageVerify:
 		return ImageVerify
 	default:
 		return EmptyRuleType
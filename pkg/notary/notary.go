@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-containerregistry/pkg/name"
@@ -83,6 +84,7 @@ func (v *notaryVerifier) VerifySignature(ctx context.Context, opts images.Option
 	resp := &images.Response{
 		Digest:     targetDesc.Digest.String(),
 		Statements: nil,
+		SignedAt:   signingTime(outcomes),
 	}
 
 	return resp, nil
@@ -133,6 +135,20 @@ func (v *notaryVerifier) verifyOutcomes(outcomes []*notation.VerificationOutcome
 	return multierr.Combine(errs...)
 }
 
+// signingTime returns the most recent signing time of the successfully verified signatures
+func signingTime(outcomes []*notation.VerificationOutcome) time.Time {
+	var signedAt time.Time
+	for _, outcome := range outcomes {
+		if outcome.Error != nil || outcome.EnvelopeContent == nil {
+			continue
+		}
+		if t := outcome.EnvelopeContent.SignerInfo.SignedAttributes.SigningTime; t.After(signedAt) {
+			signedAt = t
+		}
+	}
+	return signedAt
+}
+
 func (v *notaryVerifier) FetchAttestations(ctx context.Context, opts images.Options) (*images.Response, error) {
 	v.log.V(2).Info("fetching attestations", "reference", opts.ImageRef, "opts", opts)
 
@@ -0,0 +1,103 @@
+package oci
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/kyverno/kyverno/pkg/cosign"
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+)
+
+const (
+	// Scheme is the scheme of OCI artifact references
+	Scheme = "oci://"
+	// PolicyConfigMediaType is the config media type of policy artifacts
+	PolicyConfigMediaType = "application/vnd.cncf.kyverno.config.v1+json"
+	// PolicyLayerMediaType is the media type of the layers holding a policy
+	PolicyLayerMediaType = "application/vnd.cncf.kyverno.policy.layer.v1+yaml"
+	// ResourceLayerMediaType is the media type of the layers holding resources
+	ResourceLayerMediaType = "application/vnd.cncf.kyverno.resource.layer.v1+yaml"
+)
+
+// Reference returns the image reference without the oci:// scheme
+func Reference(in string) string {
+	return strings.TrimPrefix(in, Scheme)
+}
+
+// PullLayers downloads an OCI image and returns the content of its layers with the given media type
+func PullLayers(ctx context.Context, imageRef string, mediaType string, options ...remote.Option) ([][]byte, error) {
+	ref, err := name.ParseReference(Reference(imageRef))
+	if err != nil {
+		return nil, fmt.Errorf("parsing image reference: %v", err)
+	}
+	options = append(options, remote.WithContext(ctx))
+	rmt, err := remote.Get(ref, options...)
+	if err != nil {
+		return nil, fmt.Errorf("getting image: %v", err)
+	}
+	img, err := rmt.Image()
+	if err != nil {
+		return nil, fmt.Errorf("getting image: %v", err)
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("getting image layers: %v", err)
+	}
+	var results [][]byte
+	for _, layer := range layers {
+		lmt, err := layer.MediaType()
+		if err != nil {
+			return nil, fmt.Errorf("getting layer media type: %v", err)
+		}
+		if string(lmt) == mediaType {
+			layerBytes, err := readLayer(layer.Compressed)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, layerBytes)
+		}
+	}
+	return results, nil
+}
+
+// Verify checks the cosign signature of an OCI image against the given key (file path, KMS or k8s:// reference)
+// and returns the image reference pinned to the verified digest.
+// Verification fails if the digest of the image can't be resolved, the returned reference can't be moved by
+// pushing to the tag after the signature was checked.
+func Verify(ctx context.Context, rclient registryclient.Client, imageRef string, key string) (string, error) {
+	ref, err := name.ParseReference(Reference(imageRef))
+	if err != nil {
+		return "", fmt.Errorf("parsing image reference: %v", err)
+	}
+	response, err := cosign.NewVerifier().VerifySignature(ctx, images.Options{
+		ImageRef:           ref.Name(),
+		Client:             rclient,
+		Key:                key,
+		SignatureAlgorithm: "sha256",
+	})
+	if err != nil {
+		return "", fmt.Errorf("verifying image %s: %w", ref.Name(), err)
+	}
+	if response.Digest == "" {
+		return "", fmt.Errorf("verifying image %s: failed to resolve the digest of the verified image", ref.Name())
+	}
+	return Scheme + ref.Context().Digest(response.Digest).Name(), nil
+}
+
+func readLayer(open func() (io.ReadCloser, error)) ([]byte, error) {
+	blob, err := open()
+	if err != nil {
+		return nil, fmt.Errorf("getting layer blob: %v", err)
+	}
+	defer blob.Close()
+	layerBytes, err := io.ReadAll(blob)
+	if err != nil {
+		return nil, fmt.Errorf("reading layer blob: %v", err)
+	}
+	return layerBytes, nil
+}
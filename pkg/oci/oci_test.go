@@ -0,0 +1,46 @@
+package oci
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReference(t *testing.T) {
+	assert.Equal(t, "ghcr.io/org/policies:v1", Reference("oci://ghcr.io/org/policies:v1"))
+	assert.Equal(t, "ghcr.io/org/policies:v1", Reference("ghcr.io/org/policies:v1"))
+}
+
+func TestPullLayers(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	imageRef := strings.TrimPrefix(server.URL, "http://") + "/org/bundle:v1"
+	ref, err := name.ParseReference(imageRef)
+	assert.NoError(t, err)
+	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, PolicyConfigMediaType)
+	img, err = mutate.Append(img,
+		mutate.Addendum{Layer: static.NewLayer([]byte("policy"), PolicyLayerMediaType)},
+		mutate.Addendum{Layer: static.NewLayer([]byte("resources"), ResourceLayerMediaType)},
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, remote.Write(ref, img))
+	policies, err := PullLayers(context.Background(), Scheme+imageRef, PolicyLayerMediaType)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("policy")}, policies)
+	resources, err := PullLayers(context.Background(), imageRef, ResourceLayerMediaType)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("resources")}, resources)
+	_, err = PullLayers(context.Background(), imageRef+"-missing", PolicyLayerMediaType)
+	assert.Error(t, err)
+}
@@ -69,6 +69,10 @@ func (g *Generate) Validate(ctx context.Context) (string, error) {
 		}
 	}
 
+	if rule.GetNamespace() != "" && rule.HasMultipleNamespaces() || len(rule.Namespaces) != 0 && rule.NamespaceSelector != nil {
+		return "namespace", fmt.Errorf("only one of namespace, namespaces or namespaceSelector can be specified")
+	}
+
 	if rule.CloneList.Selector != nil {
 		if wildcard.ContainsWildcard(rule.CloneList.Selector.String()) {
 			return "selector", fmt.Errorf("wildcard characters `*/?` not supported")
@@ -97,8 +101,14 @@ func (g *Generate) Validate(ctx context.Context) (string, error) {
 		}
 	} else {
 		k, sub := kubeutils.SplitSubresource(kind)
-		if err := g.canIGenerate(ctx, strings.Join([]string{apiVersion, k}, "/"), namespace, sub); err != nil {
-			return "", err
+		namespaces := []string{namespace}
+		if len(rule.Namespaces) != 0 {
+			namespaces = rule.Namespaces
+		}
+		for _, namespace := range namespaces {
+			if err := g.canIGenerate(ctx, strings.Join([]string{apiVersion, k}, "/"), namespace, sub); err != nil {
+				return "", err
+			}
 		}
 	}
 	return "", nil
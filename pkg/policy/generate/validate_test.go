@@ -88,3 +88,61 @@ func Test_Validate_Generate_HasAnchors(t *testing.T) {
 		assert.Assert(t, err != nil)
 	}
 }
+
+func Test_Validate_Generate_MultipleNamespaces(t *testing.T) {
+	testcases := []struct {
+		name    string
+		raw     []byte
+		wantErr bool
+	}{{
+		name: "namespaces",
+		raw: []byte(`{
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"name": "tenant-config",
+			"namespaces": ["tenant-a", "tenant-b"],
+			"data": {"data": {"foo": "bar"}}
+		}`),
+	}, {
+		name: "namespace selector",
+		raw: []byte(`{
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"name": "tenant-config",
+			"namespaceSelector": {"matchLabels": {"tenant": "foo"}},
+			"data": {"data": {"foo": "bar"}}
+		}`),
+	}, {
+		name: "namespace and namespaces",
+		raw: []byte(`{
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"name": "tenant-config",
+			"namespace": "default",
+			"namespaces": ["tenant-a", "tenant-b"],
+			"data": {"data": {"foo": "bar"}}
+		}`),
+		wantErr: true,
+	}, {
+		name: "namespaces and namespace selector",
+		raw: []byte(`{
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"name": "tenant-config",
+			"namespaces": ["tenant-a", "tenant-b"],
+			"namespaceSelector": {"matchLabels": {"tenant": "foo"}},
+			"data": {"data": {"foo": "bar"}}
+		}`),
+		wantErr: true,
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var genRule kyverno.Generation
+			err := json.Unmarshal(tc.raw, &genRule)
+			assert.NilError(t, err)
+			checker := NewFakeGenerate(genRule)
+			_, err = checker.Validate(context.TODO())
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
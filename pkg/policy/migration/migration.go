@@ -0,0 +1,135 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// AnnotationMigrations lists the migrations applied to a policy
+	AnnotationMigrations = "kyverno.io/migrations"
+	// AnnotationMigrationBackup stores the spec of a policy before it was migrated
+	AnnotationMigrationBackup = "kyverno.io/migration-backup"
+)
+
+// Migration rewrites a deprecated shape of a policy to the current one
+type Migration struct {
+	// Name identifies the migration in the migrations annotation and the report
+	Name string
+	// Migrate rewrites the policy in place, it returns true when the policy was changed
+	Migrate func(kyvernov1.PolicyInterface) bool
+}
+
+// Migrations are the migrations applied to the stored policies, in order
+var Migrations = []Migration{
+	{Name: "validation-failure-action", Migrate: migrateValidationFailureAction},
+	{Name: "generate-existing", Migrate: migrateGenerateExisting},
+	{Name: "resource-names", Migrate: migrateResourceNames},
+	{Name: "match-any", Migrate: migrateMatchAny},
+}
+
+// Result is the outcome of the migration of a policy
+type Result struct {
+	Kind       string
+	Namespace  string
+	Name       string
+	Migrations []string
+	Err        error
+}
+
+// Apply runs the migrations on a copy of the policy, it returns the migrated policy
+// and the names of the migrations that changed it
+func Apply(policy kyvernov1.PolicyInterface, migrations ...Migration) (kyvernov1.PolicyInterface, []string) {
+	migrated := policy.CreateDeepCopy()
+	var applied []string
+	for _, migration := range migrations {
+		if migration.Migrate(migrated) {
+			applied = append(applied, migration.Name)
+		}
+	}
+	if len(applied) == 0 {
+		return policy, nil
+	}
+	return migrated, applied
+}
+
+// Run migrates the stored policies and cluster policies, in dry run mode the policies are only reported
+func Run(ctx context.Context, logger logr.Logger, client versioned.Interface, dryRun bool, migrations ...Migration) ([]Result, error) {
+	var results []Result
+	cpols, err := client.KyvernoV1().ClusterPolicies().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range cpols.Items {
+		results = append(results, migrate(ctx, logger, client, &cpols.Items[i], dryRun, migrations...)...)
+	}
+	pols, err := client.KyvernoV1().Policies(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return results, err
+	}
+	for i := range pols.Items {
+		results = append(results, migrate(ctx, logger, client, &pols.Items[i], dryRun, migrations...)...)
+	}
+	return results, nil
+}
+
+func migrate(ctx context.Context, logger logr.Logger, client versioned.Interface, policy kyvernov1.PolicyInterface, dryRun bool, migrations ...Migration) []Result {
+	migrated, applied := Apply(policy, migrations...)
+	if len(applied) == 0 {
+		return nil
+	}
+	result := Result{
+		Kind:       policy.GetKind(),
+		Namespace:  policy.GetNamespace(),
+		Name:       policy.GetName(),
+		Migrations: applied,
+	}
+	logger = logger.WithValues("kind", result.Kind, "namespace", result.Namespace, "name", result.Name, "migrations", applied)
+	if dryRun {
+		logger.Info("policy needs to be migrated (dry run)")
+		return []Result{result}
+	}
+	if err := setAnnotations(policy, migrated, applied); err != nil {
+		result.Err = err
+	} else if migrated.IsNamespaced() {
+		_, result.Err = client.KyvernoV1().Policies(migrated.GetNamespace()).Update(ctx, migrated.(*kyvernov1.Policy), metav1.UpdateOptions{})
+	} else {
+		_, result.Err = client.KyvernoV1().ClusterPolicies().Update(ctx, migrated.(*kyvernov1.ClusterPolicy), metav1.UpdateOptions{})
+	}
+	if result.Err != nil {
+		logger.Error(result.Err, "failed to migrate policy")
+	} else {
+		logger.Info("policy migrated")
+	}
+	return []Result{result}
+}
+
+// setAnnotations records the applied migrations on the migrated policy, the spec of the original policy
+// is kept as a backup unless a previous migration already stored one
+func setAnnotations(policy, migrated kyvernov1.PolicyInterface, applied []string) error {
+	annotations := migrated.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if _, ok := annotations[AnnotationMigrationBackup]; !ok {
+		backup, err := json.Marshal(policy.GetSpec())
+		if err != nil {
+			return err
+		}
+		annotations[AnnotationMigrationBackup] = string(backup)
+	}
+	names := sets.New(applied...)
+	if previous := annotations[AnnotationMigrations]; previous != "" {
+		names.Insert(strings.Split(previous, ",")...)
+	}
+	annotations[AnnotationMigrations] = strings.Join(sets.List(names), ",")
+	migrated.SetAnnotations(annotations)
+	return nil
+}
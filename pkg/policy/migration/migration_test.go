@@ -0,0 +1,92 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newPolicy() *kyvernov1.ClusterPolicy {
+	generateExisting := true
+	return &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+		Spec: kyvernov1.Spec{
+			ValidationFailureAction: "enforce",
+			ValidationFailureActionOverrides: []kyvernov1.ValidationFailureActionOverride{
+				{Action: "audit", Namespaces: []string{"dev"}},
+			},
+			GenerateExistingOnPolicyUpdate: &generateExisting,
+			Rules: []kyvernov1.Rule{{
+				Name: "rule",
+				MatchResources: kyvernov1.MatchResources{
+					ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}, Name: "app"},
+					UserInfo:            kyvernov1.UserInfo{Roles: []string{"dev"}},
+				},
+				ExcludeResources: kyvernov1.MatchResources{
+					All: kyvernov1.ResourceFilters{{ResourceDescription: kyvernov1.ResourceDescription{Name: "system", Names: []string{"system"}}}},
+				},
+			}},
+		},
+	}
+}
+
+func TestApply(t *testing.T) {
+	policy := newPolicy()
+	migrated, applied := Apply(policy, Migrations...)
+	assert.Equal(t, []string{"validation-failure-action", "generate-existing", "resource-names", "match-any"}, applied)
+	spec := migrated.GetSpec()
+	assert.Equal(t, kyvernov1.Enforce, spec.ValidationFailureAction)
+	assert.Equal(t, kyvernov1.Audit, spec.ValidationFailureActionOverrides[0].Action)
+	assert.Nil(t, spec.GenerateExistingOnPolicyUpdate)
+	assert.True(t, spec.GenerateExisting)
+	assert.Equal(t, kyvernov1.MatchResources{
+		Any: kyvernov1.ResourceFilters{{
+			ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}, Names: []string{"app"}},
+			UserInfo:            kyvernov1.UserInfo{Roles: []string{"dev"}},
+		}},
+	}, spec.Rules[0].MatchResources)
+	assert.Equal(t, kyvernov1.MatchResources{
+		All: kyvernov1.ResourceFilters{{ResourceDescription: kyvernov1.ResourceDescription{Names: []string{"system"}}}},
+	}, spec.Rules[0].ExcludeResources)
+	// the original policy is not modified
+	assert.Equal(t, newPolicy(), policy)
+	// migrations are idempotent
+	same, applied := Apply(migrated, Migrations...)
+	assert.Empty(t, applied)
+	assert.Same(t, migrated, same)
+}
+
+func TestRun(t *testing.T) {
+	policy := newPolicy()
+	client := fake.NewSimpleClientset(policy)
+	// dry run only reports the policies
+	results, err := Run(context.TODO(), logr.Discard(), client, true, Migrations...)
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "policy", results[0].Name)
+	stored, err := client.KyvernoV1().ClusterPolicies().Get(context.TODO(), "policy", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, policy.Spec, stored.Spec)
+	// migrate the policy
+	results, err = Run(context.TODO(), logr.Discard(), client, false, Migrations...)
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+	stored, err = client.KyvernoV1().ClusterPolicies().Get(context.TODO(), "policy", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, kyvernov1.Enforce, stored.Spec.ValidationFailureAction)
+	assert.Equal(t, "generate-existing,match-any,resource-names,validation-failure-action", stored.GetAnnotations()[AnnotationMigrations])
+	var backup kyvernov1.Spec
+	assert.NoError(t, json.Unmarshal([]byte(stored.GetAnnotations()[AnnotationMigrationBackup]), &backup))
+	assert.Equal(t, policy.Spec, backup)
+	// nothing left to migrate
+	results, err = Run(context.TODO(), logr.Discard(), client, false, Migrations...)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
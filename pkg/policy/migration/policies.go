@@ -0,0 +1,94 @@
+package migration
+
+import (
+	"slices"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+)
+
+// migrateValidationFailureAction replaces the lower case audit and enforce actions with Audit and Enforce
+func migrateValidationFailureAction(policy kyvernov1.PolicyInterface) bool {
+	spec := policy.GetSpec()
+	changed := migrateFailureAction(&spec.ValidationFailureAction)
+	for i := range spec.ValidationFailureActionOverrides {
+		if migrateFailureAction(&spec.ValidationFailureActionOverrides[i].Action) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+func migrateFailureAction(action *kyvernov1.ValidationFailureAction) bool {
+	switch *action {
+	case "audit":
+		*action = kyvernov1.Audit
+	case "enforce":
+		*action = kyvernov1.Enforce
+	default:
+		return false
+	}
+	return true
+}
+
+// migrateGenerateExisting replaces generateExistingOnPolicyUpdate with generateExisting
+func migrateGenerateExisting(policy kyvernov1.PolicyInterface) bool {
+	spec := policy.GetSpec()
+	if spec.GenerateExistingOnPolicyUpdate == nil {
+		return false
+	}
+	spec.GenerateExisting = spec.IsGenerateExisting()
+	spec.GenerateExistingOnPolicyUpdate = nil
+	return true
+}
+
+// migrateResourceNames moves the resource name of match and exclude statements to the resource names
+func migrateResourceNames(policy kyvernov1.PolicyInterface) bool {
+	changed := false
+	migrate := func(resources *kyvernov1.ResourceDescription) {
+		if resources.Name == "" {
+			return
+		}
+		if !slices.Contains(resources.Names, resources.Name) {
+			resources.Names = append(resources.Names, resources.Name)
+		}
+		resources.Name = ""
+		changed = true
+	}
+	forEachMatchResources(policy, func(match *kyvernov1.MatchResources) {
+		migrate(&match.ResourceDescription)
+		for i := range match.Any {
+			migrate(&match.Any[i].ResourceDescription)
+		}
+		for i := range match.All {
+			migrate(&match.All[i].ResourceDescription)
+		}
+	})
+	return changed
+}
+
+// migrateMatchAny moves the resources and user info set directly under match and exclude statements to an any block
+func migrateMatchAny(policy kyvernov1.PolicyInterface) bool {
+	changed := false
+	forEachMatchResources(policy, func(match *kyvernov1.MatchResources) {
+		filter := kyvernov1.ResourceFilter{
+			UserInfo:            match.UserInfo,
+			ResourceDescription: match.ResourceDescription,
+		}
+		if filter.IsEmpty() || len(match.Any) != 0 || len(match.All) != 0 {
+			return
+		}
+		match.Any = kyvernov1.ResourceFilters{filter}
+		match.UserInfo = kyvernov1.UserInfo{}
+		match.ResourceDescription = kyvernov1.ResourceDescription{}
+		changed = true
+	})
+	return changed
+}
+
+func forEachMatchResources(policy kyvernov1.PolicyInterface, fn func(*kyvernov1.MatchResources)) {
+	spec := policy.GetSpec()
+	for i := range spec.Rules {
+		fn(&spec.Rules[i].MatchResources)
+		fn(&spec.Rules[i].ExcludeResources)
+	}
+}
@@ -32,6 +32,20 @@ func NewMutateFactory(m kyvernov1.Mutation, client dclient.Interface, user strin
 
 // Validate validates the 'mutate' rule
 func (m *Mutate) Validate(ctx context.Context) (string, error) {
+	if m.mutation.Audit && m.mutation.Targets != nil {
+		return "audit", fmt.Errorf("`audit` can't be combined with `targets`")
+	}
+
+	if m.hasResolveDigest() {
+		if m.hasForEach() || m.hasPatchStrategicMerge() || m.hasPatchesJSON6902() || m.mutation.Targets != nil {
+			return "resolveDigest", fmt.Errorf("`resolveDigest` can't be combined with `foreach`, `patchStrategicMerge`, `patchesJson6902` or `targets`")
+		}
+		if ttl := m.mutation.ResolveDigest.CacheTTL; ttl != nil && ttl.Duration < 0 {
+			return "resolveDigest.cacheTTL", fmt.Errorf("cache TTL can't be negative")
+		}
+		return "", nil
+	}
+
 	if m.hasForEach() {
 		if m.hasPatchStrategicMerge() || m.hasPatchesJSON6902() {
 			return "foreach", fmt.Errorf("only one of `foreach`, `patchStrategicMerge`, or `patchesJson6902` is allowed")
@@ -93,6 +107,10 @@ func (m *Mutate) hasPatchesJSON6902() bool {
 	return m.mutation.PatchesJSON6902 != ""
 }
 
+func (m *Mutate) hasResolveDigest() bool {
+	return m.mutation.ResolveDigest != nil
+}
+
 func (m *Mutate) validateAuth(ctx context.Context, targets []kyvernov1.TargetResourceSpec) error {
 	var errs []error
 	for _, target := range targets {
@@ -0,0 +1,236 @@
+package scope
+
+import (
+	"errors"
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// WellKnownClusterResources are the cluster-scoped kinds of a standard Kubernetes cluster, they can be used
+// to validate namespace-scoped restrictions when the resources of the cluster can't be discovered
+var WellKnownClusterResources = sets.New(
+	"APIService",
+	"CertificateSigningRequest",
+	"ClusterCleanupPolicy",
+	"ClusterPolicy",
+	"ClusterPolicyReport",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"ComponentStatus",
+	"CSIDriver",
+	"CSINode",
+	"CustomResourceDefinition",
+	"FlowSchema",
+	"IngressClass",
+	"MutatingWebhookConfiguration",
+	"Namespace",
+	"Node",
+	"PersistentVolume",
+	"PriorityClass",
+	"PriorityLevelConfiguration",
+	"RuntimeClass",
+	"StorageClass",
+	"ValidatingAdmissionPolicy",
+	"ValidatingAdmissionPolicyBinding",
+	"ValidatingWebhookConfiguration",
+	"VolumeAttachment",
+)
+
+// ToPolicy converts a cluster policy to a policy in the given namespace.
+// Namespace filters of match and exclude statements are resolved against the namespace, rules that can't match
+// a resource in the namespace are removed and validation failure action overrides are folded into the
+// validation failure action. The returned warnings describe the changes made to the policy.
+// An error is returned if the converted policy violates the restrictions of namespaced policies.
+func ToPolicy(cpol *kyvernov1.ClusterPolicy, namespace string, clusterResources sets.Set[string]) (*kyvernov1.Policy, []string, error) {
+	if namespace == "" {
+		return nil, nil, errors.New("namespace is required to convert a cluster policy")
+	}
+	pol := &kyvernov1.Policy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kyvernov1.SchemeGroupVersion.String(),
+			Kind:       "Policy",
+		},
+		ObjectMeta: convertMeta(cpol.ObjectMeta),
+		Spec:       *cpol.Spec.DeepCopy(),
+	}
+	pol.Namespace = namespace
+	var warnings []string
+	for _, override := range pol.Spec.ValidationFailureActionOverrides {
+		if override.NamespaceSelector != nil {
+			warnings = append(warnings, "validationFailureActionOverrides with a namespaceSelector are not supported in namespaced policies and were removed")
+		}
+		if wildcard.CheckPatterns(override.Namespaces, namespace) {
+			pol.Spec.ValidationFailureAction = override.Action
+			warnings = append(warnings, fmt.Sprintf("validationFailureAction set to %s from validationFailureActionOverrides", override.Action))
+			break
+		}
+	}
+	pol.Spec.ValidationFailureActionOverrides = nil
+	rules := make([]kyvernov1.Rule, 0, len(pol.Spec.Rules))
+	for _, rule := range pol.Spec.Rules {
+		if !restrictMatch(&rule.MatchResources, namespace) {
+			warnings = append(warnings, fmt.Sprintf("rule %s was removed, it doesn't match resources in namespace %s", rule.Name, namespace))
+			continue
+		}
+		if !restrictExclude(&rule.ExcludeResources, namespace) {
+			warnings = append(warnings, fmt.Sprintf("rule %s was removed, it excludes all resources in namespace %s", rule.Name, namespace))
+			continue
+		}
+		for i := range rule.Mutation.Targets {
+			if rule.Mutation.Targets[i].Namespace == "" {
+				rule.Mutation.Targets[i].Namespace = namespace
+			}
+		}
+		rules = append(rules, rule)
+	}
+	if len(rules) == 0 {
+		return nil, warnings, fmt.Errorf("no rule of cluster policy %s applies to namespace %s", cpol.GetName(), namespace)
+	}
+	pol.Spec.Rules = rules
+	if errs := pol.Validate(clusterResources); len(errs) != 0 {
+		return nil, warnings, errs.ToAggregate()
+	}
+	return pol, warnings, nil
+}
+
+// ToClusterPolicy converts a policy to a cluster policy.
+// The match statements of the rules are restricted to the namespace of the policy so that the cluster policy
+// applies to the same resources as the original policy.
+// An error is returned if the converted cluster policy is not valid.
+func ToClusterPolicy(pol *kyvernov1.Policy, clusterResources sets.Set[string]) (*kyvernov1.ClusterPolicy, error) {
+	namespace := pol.GetNamespace()
+	if namespace == "" {
+		return nil, errors.New("namespace is required to convert a policy")
+	}
+	cpol := &kyvernov1.ClusterPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kyvernov1.SchemeGroupVersion.String(),
+			Kind:       "ClusterPolicy",
+		},
+		ObjectMeta: convertMeta(pol.ObjectMeta),
+		Spec:       *pol.Spec.DeepCopy(),
+	}
+	for i := range cpol.Spec.Rules {
+		rule := &cpol.Spec.Rules[i]
+		match := &rule.MatchResources
+		switch {
+		case len(match.Any) != 0:
+			for j := range match.Any {
+				match.Any[j].Namespaces = []string{namespace}
+			}
+		case len(match.All) != 0:
+			for j := range match.All {
+				match.All[j].Namespaces = []string{namespace}
+			}
+		default:
+			match.Namespaces = []string{namespace}
+		}
+		for j := range rule.Mutation.Targets {
+			if rule.Mutation.Targets[j].Namespace == "" {
+				rule.Mutation.Targets[j].Namespace = namespace
+			}
+		}
+	}
+	if errs := cpol.Validate(clusterResources); len(errs) != 0 {
+		return nil, errs.ToAggregate()
+	}
+	return cpol, nil
+}
+
+func convertMeta(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	out := metav1.ObjectMeta{
+		Name: meta.Name,
+	}
+	if len(meta.Labels) != 0 {
+		out.Labels = make(map[string]string, len(meta.Labels))
+		for key, value := range meta.Labels {
+			out.Labels[key] = value
+		}
+	}
+	if len(meta.Annotations) != 0 {
+		out.Annotations = make(map[string]string, len(meta.Annotations))
+		for key, value := range meta.Annotations {
+			out.Annotations[key] = value
+		}
+	}
+	return out
+}
+
+// restrictNamespaces returns false if the resource description can't match a resource in the namespace,
+// otherwise the namespace filter, which always matches, is removed
+func restrictNamespaces(description *kyvernov1.ResourceDescription, namespace string) bool {
+	if len(description.Namespaces) == 0 {
+		return true
+	}
+	if !wildcard.CheckPatterns(description.Namespaces, namespace) {
+		return false
+	}
+	description.Namespaces = nil
+	return true
+}
+
+// restrictMatch returns false if the match statement can't match a resource in the namespace
+func restrictMatch(match *kyvernov1.MatchResources, namespace string) bool {
+	switch {
+	case len(match.Any) != 0:
+		var filters kyvernov1.ResourceFilters
+		for _, filter := range match.Any {
+			if restrictNamespaces(&filter.ResourceDescription, namespace) {
+				filters = append(filters, filter)
+			}
+		}
+		match.Any = filters
+		return len(filters) != 0
+	case len(match.All) != 0:
+		for i := range match.All {
+			if !restrictNamespaces(&match.All[i].ResourceDescription, namespace) {
+				return false
+			}
+		}
+		return true
+	default:
+		return restrictNamespaces(&match.ResourceDescription, namespace)
+	}
+}
+
+// restrictExclude returns false if the exclude statement excludes all resources in the namespace
+func restrictExclude(exclude *kyvernov1.MatchResources, namespace string) bool {
+	switch {
+	case len(exclude.Any) != 0:
+		var filters kyvernov1.ResourceFilters
+		for _, filter := range exclude.Any {
+			if !restrictNamespaces(&filter.ResourceDescription, namespace) {
+				continue
+			}
+			if filter.IsEmpty() {
+				return false
+			}
+			filters = append(filters, filter)
+		}
+		exclude.Any = filters
+		return true
+	case len(exclude.All) != 0:
+		empty := true
+		for i := range exclude.All {
+			if !restrictNamespaces(&exclude.All[i].ResourceDescription, namespace) {
+				exclude.All = nil
+				return true
+			}
+			empty = empty && exclude.All[i].IsEmpty()
+		}
+		return !empty
+	default:
+		if exclude.ResourceDescription.IsEmpty() && exclude.UserInfo.IsEmpty() {
+			return true
+		}
+		if !restrictNamespaces(&exclude.ResourceDescription, namespace) {
+			*exclude = kyvernov1.MatchResources{}
+			return true
+		}
+		return !exclude.ResourceDescription.IsEmpty() || !exclude.UserInfo.IsEmpty()
+	}
+}
@@ -0,0 +1,143 @@
+package scope
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newClusterPolicy(rules ...kyvernov1.Rule) *kyvernov1.ClusterPolicy {
+	return &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "policy",
+			Labels:          map[string]string{"team": "platform"},
+			ResourceVersion: "42",
+		},
+		Spec: kyvernov1.Spec{
+			ValidationFailureAction: kyvernov1.Audit,
+			ValidationFailureActionOverrides: []kyvernov1.ValidationFailureActionOverride{
+				{Action: kyvernov1.Enforce, Namespaces: []string{"prod-*"}},
+			},
+			Rules: rules,
+		},
+	}
+}
+
+func newRule(name string, match, exclude kyvernov1.MatchResources) kyvernov1.Rule {
+	return kyvernov1.Rule{
+		Name:             name,
+		MatchResources:   match,
+		ExcludeResources: exclude,
+		Validation: kyvernov1.Validation{
+			Message: "label app is required",
+			RawPattern: kyvernov1.ToJSON(map[string]interface{}{
+				"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "?*"}},
+			}),
+		},
+	}
+}
+
+func TestToPolicy(t *testing.T) {
+	cpol := newClusterPolicy(
+		newRule("all",
+			kyvernov1.MatchResources{Any: kyvernov1.ResourceFilters{
+				{ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}, Namespaces: []string{"prod-*"}}},
+				{ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Deployment"}, Namespaces: []string{"dev"}}},
+			}},
+			kyvernov1.MatchResources{Any: kyvernov1.ResourceFilters{
+				{ResourceDescription: kyvernov1.ResourceDescription{Names: []string{"debug-*"}, Namespaces: []string{"prod-*"}}},
+				{ResourceDescription: kyvernov1.ResourceDescription{Namespaces: []string{"kube-system"}}},
+			}},
+		),
+		newRule("other",
+			kyvernov1.MatchResources{ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}, Namespaces: []string{"dev"}}},
+			kyvernov1.MatchResources{},
+		),
+		newRule("excluded",
+			kyvernov1.MatchResources{ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}}},
+			kyvernov1.MatchResources{ResourceDescription: kyvernov1.ResourceDescription{Namespaces: []string{"prod-*"}}},
+		),
+	)
+	pol, warnings, err := ToPolicy(cpol, "prod-eu", WellKnownClusterResources)
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 3)
+	assert.Equal(t, "Policy", pol.Kind)
+	assert.Equal(t, "kyverno.io/v1", pol.APIVersion)
+	assert.Equal(t, "policy", pol.Name)
+	assert.Equal(t, "prod-eu", pol.Namespace)
+	assert.Equal(t, "", pol.ResourceVersion)
+	assert.Equal(t, map[string]string{"team": "platform"}, pol.Labels)
+	assert.Equal(t, kyvernov1.Enforce, pol.Spec.ValidationFailureAction)
+	assert.Nil(t, pol.Spec.ValidationFailureActionOverrides)
+	assert.Len(t, pol.Spec.Rules, 1)
+	rule := pol.Spec.Rules[0]
+	assert.Equal(t, "all", rule.Name)
+	assert.Equal(t, kyvernov1.ResourceFilters{
+		{ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}}},
+	}, rule.MatchResources.Any)
+	assert.Equal(t, kyvernov1.ResourceFilters{
+		{ResourceDescription: kyvernov1.ResourceDescription{Names: []string{"debug-*"}}},
+	}, rule.ExcludeResources.Any)
+	// the cluster policy is left untouched
+	assert.Equal(t, []string{"prod-*"}, cpol.Spec.Rules[0].MatchResources.Any[0].Namespaces)
+	assert.Len(t, cpol.Spec.ValidationFailureActionOverrides, 1)
+}
+
+func TestToPolicyNoRule(t *testing.T) {
+	cpol := newClusterPolicy(newRule("rule",
+		kyvernov1.MatchResources{All: kyvernov1.ResourceFilters{
+			{ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}, Namespaces: []string{"dev"}}},
+		}},
+		kyvernov1.MatchResources{},
+	))
+	_, _, err := ToPolicy(cpol, "prod", WellKnownClusterResources)
+	assert.Error(t, err)
+	_, _, err = ToPolicy(cpol, "", WellKnownClusterResources)
+	assert.Error(t, err)
+}
+
+func TestToPolicyClusterResources(t *testing.T) {
+	cpol := newClusterPolicy(newRule("rule",
+		kyvernov1.MatchResources{ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Namespace"}}},
+		kyvernov1.MatchResources{},
+	))
+	_, _, err := ToPolicy(cpol, "dev", WellKnownClusterResources)
+	assert.Error(t, err)
+}
+
+func TestToClusterPolicy(t *testing.T) {
+	pol := &kyvernov1.Policy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "dev"},
+		Spec: kyvernov1.Spec{
+			ValidationFailureAction: kyvernov1.Enforce,
+			Rules: []kyvernov1.Rule{
+				newRule("any",
+					kyvernov1.MatchResources{Any: kyvernov1.ResourceFilters{
+						{ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}}},
+						{ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Deployment"}}},
+					}},
+					kyvernov1.MatchResources{},
+				),
+				newRule("resources",
+					kyvernov1.MatchResources{ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}}},
+					kyvernov1.MatchResources{},
+				),
+			},
+		},
+	}
+	cpol, err := ToClusterPolicy(pol, WellKnownClusterResources)
+	assert.NoError(t, err)
+	assert.Equal(t, "ClusterPolicy", cpol.Kind)
+	assert.Equal(t, "policy", cpol.Name)
+	assert.Equal(t, "", cpol.Namespace)
+	assert.Equal(t, []string{"dev"}, cpol.Spec.Rules[0].MatchResources.Any[0].Namespaces)
+	assert.Equal(t, []string{"dev"}, cpol.Spec.Rules[0].MatchResources.Any[1].Namespaces)
+	assert.Equal(t, []string{"dev"}, cpol.Spec.Rules[1].MatchResources.Namespaces)
+	assert.Nil(t, pol.Spec.Rules[1].MatchResources.Namespaces)
+	// converting back gives the original rules
+	back, _, err := ToPolicy(cpol, "dev", WellKnownClusterResources)
+	assert.NoError(t, err)
+	assert.Equal(t, pol.Spec, back.Spec)
+}
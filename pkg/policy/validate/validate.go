@@ -3,16 +3,29 @@ package validate
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
 
+	gojmespath "github.com/kyverno/go-jmespath"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/engine/anchor"
+	"github.com/kyverno/kyverno/pkg/engine/rego"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
 	"github.com/kyverno/kyverno/pkg/policy/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// severities are the allowed values of the rule severity
+var severities = []string{"critical", "high", "medium", "low", "info"}
+
 // Validate validates a 'validate' rule
 type Validate struct {
 	// rule to hold 'validate' rule specifications
 	rule *kyvernov1.Validation
+	// policyNamespace is the namespace of the policy, empty for cluster policies
+	policyNamespace string
 }
 
 // NewValidateFactory returns a new instance of Mutate validation checker
@@ -24,6 +37,13 @@ func NewValidateFactory(rule *kyvernov1.Validation) *Validate {
 	return &m
 }
 
+// WithPolicyNamespace sets the namespace of the policy holding the rule, namespaced policies can only
+// reference resources of their own namespace
+func (v *Validate) WithPolicyNamespace(namespace string) *Validate {
+	v.policyNamespace = namespace
+	return v
+}
+
 // Validate validates the 'validate' rule
 func (v *Validate) Validate(ctx context.Context) (string, error) {
 	if err := v.validateElements(); err != nil {
@@ -120,17 +140,146 @@ func (v *Validate) Validate(ctx context.Context) (string, error) {
 		}
 	}
 
+	if v.rule.Immutable != nil {
+		if path, err := validateImmutable(v.rule.Immutable); err != nil {
+			return path, err
+		}
+	}
+
+	if v.rule.ImageAllowList != nil {
+		if path, err := validateImageAllowList(v.rule.ImageAllowList); err != nil {
+			return path, err
+		}
+	}
+
+	if v.rule.Baseline != nil {
+		if path, err := validateBaseline(v.rule.Baseline, v.policyNamespace); err != nil {
+			return path, err
+		}
+	}
+
+	if v.rule.Rego != nil {
+		if err := validateRego(ctx, v.rule.Rego); err != nil {
+			return "rego", err
+		}
+	}
+
+	if v.rule.ServerMetadata != "" && v.rule.ServerMetadata != kyvernov1.ServerMetadataStrip && v.rule.ServerMetadata != kyvernov1.ServerMetadataPreserve {
+		return "serverMetadata", fmt.Errorf("serverMetadata must be one of %s, %s", kyvernov1.ServerMetadataStrip, kyvernov1.ServerMetadataPreserve)
+	}
+
+	if v.rule.FailureAction != nil && *v.rule.FailureAction != kyvernov1.Audit && *v.rule.FailureAction != kyvernov1.Enforce {
+		return "failureAction", fmt.Errorf("failureAction must be one of %s, %s", kyvernov1.Audit, kyvernov1.Enforce)
+	}
+
+	if v.rule.Severity != "" && !slices.Contains(severities, v.rule.Severity) {
+		return "severity", fmt.Errorf("severity must be one of %s", strings.Join(severities, ", "))
+	}
+
+	if v.rule.RemediationURL != "" {
+		if u, err := url.ParseRequestURI(v.rule.RemediationURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return "remediationUrl", fmt.Errorf("remediationUrl must be an absolute URL")
+		}
+	}
+
+	return "", nil
+}
+
+func validateImmutable(immutable *kyvernov1.Immutable) (string, error) {
+	if len(immutable.Fields) == 0 {
+		return "immutable.fields", fmt.Errorf("at least one field is required")
+	}
+	for i, field := range immutable.Fields {
+		if field == "" {
+			return fmt.Sprintf("immutable.fields[%d]", i), fmt.Errorf("field cannot be empty")
+		}
+	}
+	for i, window := range immutable.Windows {
+		if err := validateWindowTime(window.Start); err != nil {
+			return fmt.Sprintf("immutable.windows[%d].start", i), err
+		}
+		if err := validateWindowTime(window.End); err != nil {
+			return fmt.Sprintf("immutable.windows[%d].end", i), err
+		}
+	}
+	if immutable.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(immutable.Selector); err != nil {
+			return "immutable.selector", err
+		}
+	}
 	return "", nil
 }
 
+func validateImageAllowList(imageAllowList *kyvernov1.ImageAllowListValidation) (string, error) {
+	if len(imageAllowList.Names) == 0 {
+		return "imageAllowList.names", fmt.Errorf("at least one name is required")
+	}
+	for i, name := range imageAllowList.Names {
+		if name == "" {
+			return fmt.Sprintf("imageAllowList.names[%d]", i), fmt.Errorf("name cannot be empty")
+		}
+	}
+	return "", nil
+}
+
+func validateBaseline(baseline *kyvernov1.Baseline, policyNamespace string) (string, error) {
+	if baseline.Mode != kyvernov1.BaselineRecord && baseline.Mode != kyvernov1.BaselineEnforce {
+		return "baseline.mode", fmt.Errorf("mode must be one of %s, %s", kyvernov1.BaselineRecord, kyvernov1.BaselineEnforce)
+	}
+	if baseline.JMESPath == "" {
+		return "baseline.jmesPath", fmt.Errorf("jmesPath is required")
+	}
+	if _, err := gojmespath.Compile(baseline.JMESPath); err != nil {
+		return "baseline.jmesPath", err
+	}
+	if baseline.ConfigMap.Name == "" {
+		return "baseline.configMap.name", fmt.Errorf("name is required")
+	}
+	if regex.IsVariable(baseline.ConfigMap.Name) {
+		return "baseline.configMap.name", fmt.Errorf("variables are not allowed")
+	}
+	if baseline.ConfigMap.Namespace == "" {
+		return "baseline.configMap.namespace", fmt.Errorf("namespace is required")
+	}
+	if regex.IsVariable(baseline.ConfigMap.Namespace) {
+		return "baseline.configMap.namespace", fmt.Errorf("variables are not allowed")
+	}
+	if policyNamespace != "" && baseline.ConfigMap.Namespace != policyNamespace {
+		return "baseline.configMap.namespace", fmt.Errorf("namespace must be the policy namespace %s", policyNamespace)
+	}
+	return "", nil
+}
+
+func validateRego(ctx context.Context, r *kyvernov1.Rego) error {
+	if r.Module == "" {
+		return fmt.Errorf("module is required")
+	}
+	if _, err := rego.Prepare(ctx, *r); err != nil {
+		return fmt.Errorf("invalid rego module or query: %w", err)
+	}
+	return nil
+}
+
+// validateWindowTime checks a time window bound is a RFC 3339 timestamp, unless it contains variables
+func validateWindowTime(value string) error {
+	if value == "" {
+		return fmt.Errorf("time is required")
+	}
+	if regex.IsVariable(value) {
+		return nil
+	}
+	_, err := time.Parse(time.RFC3339, value)
+	return err
+}
+
 func (v *Validate) validateElements() error {
 	count := validationElemCount(v.rule)
 	if count == 0 {
-		return fmt.Errorf("one of pattern, anyPattern, deny, foreach, cel must be specified")
+		return fmt.Errorf("one of pattern, anyPattern, deny, foreach, cel, immutable, imageAllowList, baseline, rego must be specified")
 	}
 
 	if count > 1 {
-		return fmt.Errorf("only one of pattern, anyPattern, deny, foreach, cel can be specified")
+		return fmt.Errorf("only one of pattern and anyPattern, deny, foreach, cel, immutable, imageAllowList, baseline, rego can be specified")
 	}
 
 	return nil
@@ -142,11 +291,8 @@ func validationElemCount(v *kyvernov1.Validation) int {
 	}
 
 	count := 0
-	if v.GetPattern() != nil {
-		count++
-	}
-
-	if v.GetAnyPattern() != nil {
+	// pattern and anyPattern can be combined, the resource must match the pattern and one of the anyPattern entries
+	if v.GetPattern() != nil || v.GetAnyPattern() != nil {
 		count++
 	}
 
@@ -166,6 +312,22 @@ func validationElemCount(v *kyvernov1.Validation) int {
 		count++
 	}
 
+	if v.Immutable != nil {
+		count++
+	}
+
+	if v.ImageAllowList != nil {
+		count++
+	}
+
+	if v.Baseline != nil {
+		count++
+	}
+
+	if v.Rego != nil {
+		count++
+	}
+
 	if v.Manifests != nil && len(v.Manifests.Attestors) != 0 {
 		count++
 	}
@@ -184,7 +346,7 @@ func (v *Validate) validateForEach(foreach kyvernov1.ForEachValidation) error {
 	}
 
 	if count > 1 {
-		return fmt.Errorf("only one of pattern, anyPattern, deny, or a nested foreach can be specified")
+		return fmt.Errorf("only one of pattern and anyPattern, deny, or a nested foreach can be specified")
 	}
 
 	return nil
@@ -192,11 +354,7 @@ func (v *Validate) validateForEach(foreach kyvernov1.ForEachValidation) error {
 
 func foreachElemCount(foreach kyvernov1.ForEachValidation) int {
 	count := 0
-	if foreach.GetPattern() != nil {
-		count++
-	}
-
-	if foreach.GetAnyPattern() != nil {
+	if foreach.GetPattern() != nil || foreach.GetAnyPattern() != nil {
 		count++
 	}
 
@@ -380,3 +380,270 @@ func Test_Validate_Validate_Unsupported(t *testing.T) {
 	}
 
 }
+
+func Test_Validate_Immutable(t *testing.T) {
+	testcases := []struct {
+		description string
+		rawValidate []byte
+		wantPath    string
+		wantErr     bool
+	}{{
+		description: "valid",
+		rawValidate: []byte(`{"immutable":{"fields":["spec.replicas"],"windows":[{"start":"2023-01-01T00:00:00Z","end":"2023-01-02T00:00:00Z"}],"selector":{"matchLabels":{"incident-mode":"true"}}}}`),
+	}, {
+		description: "window with variables",
+		rawValidate: []byte(`{"immutable":{"fields":["spec.replicas"],"windows":[{"start":"{{ freeze.data.start }}","end":"{{ freeze.data.end }}"}]}}`),
+	}, {
+		description: "no fields",
+		rawValidate: []byte(`{"immutable":{}}`),
+		wantPath:    "immutable.fields",
+		wantErr:     true,
+	}, {
+		description: "invalid window",
+		rawValidate: []byte(`{"immutable":{"fields":["spec.replicas"],"windows":[{"start":"2023-01-01T00:00:00Z","end":"tomorrow"}]}}`),
+		wantPath:    "immutable.windows[0].end",
+		wantErr:     true,
+	}, {
+		description: "invalid selector",
+		rawValidate: []byte(`{"immutable":{"fields":["spec.replicas"],"selector":{"matchExpressions":[{"key":"foo","operator":"Bad"}]}}}`),
+		wantPath:    "immutable.selector",
+		wantErr:     true,
+	}, {
+		description: "with deny",
+		rawValidate: []byte(`{"immutable":{"fields":["spec.replicas"]},"deny":{}}`),
+		wantErr:     true,
+	}}
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			var validate kyverno.Validation
+			err := json.Unmarshal(testcase.rawValidate, &validate)
+			assert.NilError(t, err)
+			path, err := NewValidateFactory(&validate).Validate(context.TODO())
+			assert.Equal(t, testcase.wantErr, err != nil)
+			assert.Equal(t, testcase.wantPath, path)
+		})
+	}
+}
+
+func Test_Validate_ImageAllowList(t *testing.T) {
+	testcases := []struct {
+		description string
+		rawValidate []byte
+		wantPath    string
+		wantErr     bool
+	}{{
+		description: "valid",
+		rawValidate: []byte(`{"imageAllowList":{"names":["trusted-images"]}}`),
+	}, {
+		description: "no names",
+		rawValidate: []byte(`{"imageAllowList":{}}`),
+		wantPath:    "imageAllowList.names",
+		wantErr:     true,
+	}, {
+		description: "empty name",
+		rawValidate: []byte(`{"imageAllowList":{"names":["trusted-images",""]}}`),
+		wantPath:    "imageAllowList.names[1]",
+		wantErr:     true,
+	}, {
+		description: "with pattern",
+		rawValidate: []byte(`{"imageAllowList":{"names":["trusted-images"]},"pattern":{"spec":{}}}`),
+		wantErr:     true,
+	}}
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			var validate kyverno.Validation
+			err := json.Unmarshal(testcase.rawValidate, &validate)
+			assert.NilError(t, err)
+			path, err := NewValidateFactory(&validate).Validate(context.TODO())
+			assert.Equal(t, testcase.wantErr, err != nil)
+			assert.Equal(t, testcase.wantPath, path)
+		})
+	}
+}
+
+func Test_Validate_Baseline(t *testing.T) {
+	testcases := []struct {
+		description     string
+		policyNamespace string
+		rawValidate     []byte
+		wantPath        string
+		wantErr         bool
+	}{{
+		description: "valid",
+		rawValidate: []byte(`{"baseline":{"mode":"Record","jmesPath":"spec.containers[].image","configMap":{"name":"images","namespace":"kyverno"}}}`),
+	}, {
+		description:     "policy namespace",
+		policyNamespace: "kyverno",
+		rawValidate:     []byte(`{"baseline":{"mode":"Record","jmesPath":"spec.containers[].image","configMap":{"name":"images","namespace":"kyverno"}}}`),
+	}, {
+		description:     "other namespace",
+		policyNamespace: "team",
+		rawValidate:     []byte(`{"baseline":{"mode":"Record","jmesPath":"spec.containers[].image","configMap":{"name":"images","namespace":"kyverno"}}}`),
+		wantPath:        "baseline.configMap.namespace",
+		wantErr:         true,
+	}, {
+		description: "invalid mode",
+		rawValidate: []byte(`{"baseline":{"mode":"Learn","jmesPath":"spec.containers[].image","configMap":{"name":"images","namespace":"kyverno"}}}`),
+		wantPath:    "baseline.mode",
+		wantErr:     true,
+	}, {
+		description: "invalid jmesPath",
+		rawValidate: []byte(`{"baseline":{"mode":"Enforce","jmesPath":"spec.containers[","configMap":{"name":"images","namespace":"kyverno"}}}`),
+		wantPath:    "baseline.jmesPath",
+		wantErr:     true,
+	}, {
+		description: "no configmap namespace",
+		rawValidate: []byte(`{"baseline":{"mode":"Enforce","jmesPath":"spec.containers[].image","configMap":{"name":"images"}}}`),
+		wantPath:    "baseline.configMap.namespace",
+		wantErr:     true,
+	}, {
+		description: "with deny",
+		rawValidate: []byte(`{"baseline":{"mode":"Record","jmesPath":"spec.containers[].image","configMap":{"name":"images","namespace":"kyverno"}},"deny":{}}`),
+		wantErr:     true,
+	}}
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			var validate kyverno.Validation
+			err := json.Unmarshal(testcase.rawValidate, &validate)
+			assert.NilError(t, err)
+			path, err := NewValidateFactory(&validate).WithPolicyNamespace(testcase.policyNamespace).Validate(context.TODO())
+			assert.Equal(t, testcase.wantErr, err != nil)
+			assert.Equal(t, testcase.wantPath, path)
+		})
+	}
+}
+
+func Test_Validate_Rego(t *testing.T) {
+	testcases := []struct {
+		description string
+		rawValidate []byte
+		wantPath    string
+		wantErr     bool
+	}{{
+		description: "valid",
+		rawValidate: []byte(`{"rego":{"module":"package kubernetes\n\ndeny[msg] {\n  not input.request.object.metadata.labels.app\n  msg := \"label app is required\"\n}\n"}}`),
+	}, {
+		description: "valid with query",
+		rawValidate: []byte(`{"rego":{"module":"package kubernetes\n\nviolation[{\"msg\": msg}] {\n  msg := \"denied\"\n}\n","query":"data.kubernetes.violation"}}`),
+	}, {
+		description: "no module",
+		rawValidate: []byte(`{"rego":{"query":"data.kubernetes.deny"}}`),
+		wantPath:    "rego",
+		wantErr:     true,
+	}, {
+		description: "invalid module",
+		rawValidate: []byte(`{"rego":{"module":"package kubernetes\n\ndeny[msg] {"}}`),
+		wantPath:    "rego",
+		wantErr:     true,
+	}, {
+		description: "with pattern",
+		rawValidate: []byte(`{"rego":{"module":"package kubernetes\n"},"pattern":{"a":"b"}}`),
+		wantErr:     true,
+	}}
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			var validate kyverno.Validation
+			err := json.Unmarshal(testcase.rawValidate, &validate)
+			assert.NilError(t, err)
+			path, err := NewValidateFactory(&validate).Validate(context.TODO())
+			assert.Equal(t, testcase.wantErr, err != nil)
+			assert.Equal(t, testcase.wantPath, path)
+		})
+	}
+}
+
+func Test_Validate_ServerMetadata(t *testing.T) {
+	testcases := []struct {
+		description string
+		rawValidate []byte
+		wantPath    string
+		wantErr     bool
+	}{{
+		description: "default",
+		rawValidate: []byte(`{"pattern":{"metadata":{"labels":{"app":"?*"}}}}`),
+	}, {
+		description: "preserve",
+		rawValidate: []byte(`{"serverMetadata":"Preserve","pattern":{"metadata":{"labels":{"app":"?*"}}}}`),
+	}, {
+		description: "invalid",
+		rawValidate: []byte(`{"serverMetadata":"Drop","pattern":{"metadata":{"labels":{"app":"?*"}}}}`),
+		wantPath:    "serverMetadata",
+		wantErr:     true,
+	}}
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			var validate kyverno.Validation
+			err := json.Unmarshal(testcase.rawValidate, &validate)
+			assert.NilError(t, err)
+			path, err := NewValidateFactory(&validate).Validate(context.TODO())
+			assert.Equal(t, testcase.wantErr, err != nil)
+			assert.Equal(t, testcase.wantPath, path)
+		})
+	}
+}
+
+func Test_Validate_Remediation(t *testing.T) {
+	testcases := []struct {
+		description string
+		rawValidate []byte
+		wantPath    string
+		wantErr     bool
+	}{{
+		description: "valid",
+		rawValidate: []byte(`{"messageTemplate":"image {{ request.object.spec.containers[0].image }} is not allowed\nuse an image from the internal registry","remediationUrl":"https://example.com/policies/images","pattern":{"spec":{"containers":[{"image":"registry.example.com/*"}]}}}`),
+	}, {
+		description: "relative url",
+		rawValidate: []byte(`{"remediationUrl":"/policies/images","pattern":{"spec":{"containers":[{"image":"registry.example.com/*"}]}}}`),
+		wantPath:    "remediationUrl",
+		wantErr:     true,
+	}, {
+		description: "invalid url",
+		rawValidate: []byte(`{"remediationUrl":"not a url","pattern":{"spec":{"containers":[{"image":"registry.example.com/*"}]}}}`),
+		wantPath:    "remediationUrl",
+		wantErr:     true,
+	}}
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			var validate kyverno.Validation
+			err := json.Unmarshal(testcase.rawValidate, &validate)
+			assert.NilError(t, err)
+			path, err := NewValidateFactory(&validate).Validate(context.TODO())
+			assert.Equal(t, testcase.wantErr, err != nil)
+			assert.Equal(t, testcase.wantPath, path)
+		})
+	}
+}
+
+func Test_Validate_PatternAndAnyPattern(t *testing.T) {
+	testcases := []struct {
+		description string
+		rawValidate []byte
+		wantPath    string
+		wantErr     bool
+	}{{
+		description: "pattern and anyPattern",
+		rawValidate: []byte(`{"pattern":{"metadata":{"labels":{"team":"?*"}}},"anyPattern":[{"spec":{"runAsNonRoot":true}},{"spec":{"securityContext":{"runAsNonRoot":true}}}]}`),
+	}, {
+		description: "invalid anyPattern",
+		rawValidate: []byte(`{"pattern":{"metadata":{"labels":{"team":"?*"}}},"anyPattern":[{"spec":{"+(runAsNonRoot)":true}}]}`),
+		wantPath:    "anyPattern[0].//spec/+(runAsNonRoot)",
+		wantErr:     true,
+	}, {
+		description: "pattern and deny",
+		rawValidate: []byte(`{"pattern":{"metadata":{"labels":{"team":"?*"}}},"deny":{}}`),
+		wantErr:     true,
+	}, {
+		description: "foreach pattern and anyPattern",
+		rawValidate: []byte(`{"foreach":[{"list":"request.object.spec.containers","pattern":{"name":"?*"},"anyPattern":[{"image":"registry.example.com/*"},{"image":"ghcr.io/*"}]}]}`),
+	}}
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			var validate kyverno.Validation
+			err := json.Unmarshal(testcase.rawValidate, &validate)
+			assert.NilError(t, err)
+			path, err := NewValidateFactory(&validate).Validate(context.TODO())
+			assert.Equal(t, testcase.wantErr, err != nil)
+			assert.Equal(t, testcase.wantPath, path)
+		})
+	}
+}
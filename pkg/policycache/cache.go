@@ -44,13 +44,15 @@ func (c *cache) Unset(key string) {
 
 func (c *cache) GetPolicies(pkey PolicyType, gvr schema.GroupVersionResource, subresource string, nspace string) []kyvernov1.PolicyInterface {
 	var result []kyvernov1.PolicyInterface
-	result = append(result, c.store.get(pkey, gvr, subresource, "")...)
+	// lookups are made against the same snapshot to stay consistent with concurrent updates
+	store := c.store.snapshot()
+	result = append(result, store.get(pkey, gvr, subresource, "")...)
 	if nspace != "" {
-		result = append(result, c.store.get(pkey, gvr, subresource, nspace)...)
+		result = append(result, store.get(pkey, gvr, subresource, nspace)...)
 	}
 	// also get policies with ValidateEnforce
 	if pkey == ValidateAudit {
-		result = append(result, c.store.get(ValidateEnforce, gvr, subresource, "")...)
+		result = append(result, store.get(ValidateEnforce, gvr, subresource, "")...)
 	}
 	if pkey == ValidateAudit || pkey == ValidateEnforce {
 		result = filterPolicies(pkey, result, nspace)
@@ -78,6 +80,10 @@ func filterPolicies(pkey PolicyType, result []kyvernov1.PolicyInterface, nspace
 }
 
 func checkValidationFailureActionOverrides(enforce bool, ns string, policy kyvernov1.PolicyInterface) bool {
+	// policies with rules in enforce mode are always evaluated synchronously
+	if policy.GetSpec().HasValidateEnforceRules() {
+		return enforce
+	}
 	validationFailureAction := policy.GetSpec().ValidationFailureAction
 	validationFailureActionOverrides := policy.GetSpec().ValidationFailureActionOverrides
 	if validationFailureAction.Enforce() != enforce && (ns == "" || len(validationFailureActionOverrides) == 0) {
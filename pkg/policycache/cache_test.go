@@ -2,10 +2,12 @@ package policycache
 
 import (
 	"encoding/json"
+	"sync"
 	"testing"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"gotest.tools/assert"
 	kubecache "k8s.io/client-go/tools/cache"
@@ -1260,3 +1262,114 @@ func Test_Get_Policies_Validate_Failure_Action_Overrides(t *testing.T) {
 		t.Errorf("expected 2 validate enforce policy, found %v", len(validateEnforce))
 	}
 }
+
+func Test_Get_Policies_Validate_Rule_Failure_Action(t *testing.T) {
+	cache := NewCache()
+	policy := newValidateAuditPolicy(t)
+	enforce := kyvernov1.Enforce
+	policy.Spec.Rules[0].Validation.FailureAction = &enforce
+	finder := TestResourceFinder{}
+	key, _ := kubecache.MetaNamespaceKeyFunc(policy)
+	cache.Set(key, policy, finder)
+	for _, nspace := range []string{"", "test", "default"} {
+		validateAudit := cache.GetPolicies(ValidateAudit, podsGVRS.GroupVersionResource(), "", nspace)
+		if len(validateAudit) != 0 {
+			t.Errorf("expected 0 validate audit policy in namespace %q, found %v", nspace, len(validateAudit))
+		}
+		validateEnforce := cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", nspace)
+		if len(validateEnforce) != 1 {
+			t.Errorf("expected 1 validate enforce policy in namespace %q, found %v", nspace, len(validateEnforce))
+		}
+	}
+}
+
+func Test_Update_Policy_Kinds(t *testing.T) {
+	pCache := newPolicyCache()
+	finder := TestResourceFinder{}
+	policy := newMutatePolicy(t)
+	setPolicy(t, pCache, policy, finder)
+	mutate := pCache.get(Mutate, statefulsetsGVRS.GroupVersionResource(), "", "")
+	if len(mutate) != 1 {
+		t.Errorf("expected 1 mutate policy, found %v", len(mutate))
+	}
+	updated := policy.DeepCopy()
+	updated.Spec.Rules[0].MatchResources.Kinds = []string{"DaemonSet"}
+	setPolicy(t, pCache, updated, finder)
+	mutate = pCache.get(Mutate, statefulsetsGVRS.GroupVersionResource(), "", "")
+	if len(mutate) != 0 {
+		t.Errorf("expected 0 mutate policy, found %v", len(mutate))
+	}
+	mutate = pCache.get(Mutate, daemonsetsGVRS.GroupVersionResource(), "", "")
+	if len(mutate) != 1 {
+		t.Errorf("expected 1 mutate policy, found %v", len(mutate))
+	}
+}
+
+func Test_Policy_Resources(t *testing.T) {
+	pCache := newPolicyCache()
+	finder := TestResourceFinder{}
+	policy := newMutatePolicy(t)
+	policy.Spec.Rules[0].MatchResources.Kinds = nil
+	policy.Spec.Rules[0].MatchResources.Resources = []string{"*.apps", "jobs"}
+	setPolicy(t, pCache, policy, finder)
+	for _, gvrs := range []dclient.TopLevelApiDescription{deploymentsGVRS, statefulsetsGVRS, daemonsetsGVRS, replicasetsGVRS, jobsGVRS} {
+		mutate := pCache.get(Mutate, gvrs.GroupVersionResource(), "", "")
+		if len(mutate) != 1 {
+			t.Errorf("expected 1 mutate policy for %s, found %v", gvrs.Resource, len(mutate))
+		}
+	}
+	mutate := pCache.get(Mutate, podsGVRS.GroupVersionResource(), "", "")
+	if len(mutate) != 0 {
+		t.Errorf("expected 0 mutate policy, found %v", len(mutate))
+	}
+}
+
+func Test_Snapshot_Not_Affected_By_Updates(t *testing.T) {
+	pCache := newPolicyCache()
+	finder := TestResourceFinder{}
+	policy := newMutatePolicy(t)
+	setPolicy(t, pCache, policy, finder)
+	snapshot := pCache.snapshot()
+	unsetPolicy(pCache, policy)
+	setPolicy(t, pCache, newGVKPolicy(t), finder)
+	mutate := snapshot.get(Mutate, statefulsetsGVRS.GroupVersionResource(), "", "")
+	if len(mutate) != 1 {
+		t.Errorf("expected 1 mutate policy, found %v", len(mutate))
+	}
+	generate := snapshot.get(Generate, clusterrolesGVRS.GroupVersionResource(), "", "")
+	if len(generate) != 0 {
+		t.Errorf("expected 0 generate policy, found %v", len(generate))
+	}
+	mutate = pCache.get(Mutate, statefulsetsGVRS.GroupVersionResource(), "", "")
+	if len(mutate) != 0 {
+		t.Errorf("expected 0 mutate policy, found %v", len(mutate))
+	}
+}
+
+func Test_Concurrent_Updates(t *testing.T) {
+	cache := NewCache()
+	finder := TestResourceFinder{}
+	policy := newValidateEnforcePolicy(t)
+	key, _ := kubecache.MetaNamespaceKeyFunc(policy)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			if err := cache.Set(key, policy, finder); err != nil {
+				t.Error(err)
+			}
+			cache.Unset(key)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			policies := cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "")
+			if len(policies) > 1 {
+				t.Errorf("expected at most 1 validate enforce policy, found %v", len(policies))
+			}
+		}
+	}()
+	wg.Wait()
+}
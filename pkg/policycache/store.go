@@ -2,9 +2,11 @@ package policycache
 
 import (
 	"sync"
+	"sync/atomic"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"go.uber.org/multierr"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -19,40 +21,53 @@ type store interface {
 	unset(string)
 	// get finds policies that match a given type, gvr, subresource and namespace
 	get(PolicyType, schema.GroupVersionResource, string, string) []kyvernov1.PolicyInterface
+	// snapshot returns a read only view of the cache that is not affected by subsequent updates
+	snapshot() store
 }
 
+// policyCache never modifies the policy map readers are looking at, updates are applied
+// to a copy of the current map that is swapped atomically once complete so that concurrent
+// readers either see the policy set before or after an update but never a partial one
 type policyCache struct {
-	store store
-	lock  sync.RWMutex
+	current atomic.Pointer[policyMap]
+	// lock serializes writers
+	lock sync.Mutex
 }
 
 func newPolicyCache() store {
-	return &policyCache{
-		store: newPolicyMap(),
-	}
+	var pc policyCache
+	pc.current.Store(newPolicyMap())
+	return &pc
 }
 
 func (pc *policyCache) set(key string, policy kyvernov1.PolicyInterface, client ResourceFinder) error {
+	// resolving the kinds may hit discovery, do it before blocking other writers
+	states, err := computeKindStates(policy, client)
 	pc.lock.Lock()
 	defer pc.lock.Unlock()
-	if err := pc.store.set(key, policy, client); err != nil {
-		return err
-	}
+	next := pc.current.Load().clone()
+	next.unset(key)
+	next.apply(key, policy, states)
+	pc.current.Store(next)
 	logger.V(4).Info("policy is added to cache", "key", key)
-	return nil
+	return err
 }
 
 func (pc *policyCache) unset(key string) {
 	pc.lock.Lock()
 	defer pc.lock.Unlock()
-	pc.store.unset(key)
+	next := pc.current.Load().clone()
+	next.unset(key)
+	pc.current.Store(next)
 	logger.V(4).Info("policy is removed from cache", "key", key)
 }
 
 func (pc *policyCache) get(pkey PolicyType, gvr schema.GroupVersionResource, subresource string, nspace string) []kyvernov1.PolicyInterface {
-	pc.lock.RLock()
-	defer pc.lock.RUnlock()
-	return pc.store.get(pkey, gvr, subresource, nspace)
+	return pc.current.Load().get(pkey, gvr, subresource, nspace)
+}
+
+func (pc *policyCache) snapshot() store {
+	return pc.current.Load()
 }
 
 type policyKey struct {
@@ -80,7 +95,7 @@ func newPolicyMap() *policyMap {
 }
 
 func computeEnforcePolicy(spec *kyvernov1.Spec) bool {
-	if spec.ValidationFailureAction.Enforce() {
+	if spec.ValidationFailureAction.Enforce() || spec.HasValidateEnforceRules() {
 		return true
 	}
 	for _, k := range spec.ValidationFailureActionOverrides {
@@ -91,22 +106,28 @@ func computeEnforcePolicy(spec *kyvernov1.Spec) bool {
 	return false
 }
 
+// set returns the set with the item inserted or removed, the set is copied before being modified
+// because it can be shared with the previous versions of the policy map
 func set(set sets.Set[string], item string, value bool) sets.Set[string] {
+	if set.Has(item) == value {
+		return set
+	}
 	if value {
-		return set.Insert(item)
+		return set.Clone().Insert(item)
 	} else {
-		return set.Delete(item)
+		return set.Clone().Delete(item)
 	}
 }
 
-func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface, client ResourceFinder) error {
+type kindState struct {
+	hasMutate, hasValidate, hasGenerate, hasVerifyImages, hasImagesValidationChecks bool
+}
+
+// computeKindStates resolves the resources matched by the policy rules and the kind of rules applying to them,
+// resources that failed to be resolved are skipped and reported in the returned error
+func computeKindStates(policy kyvernov1.PolicyInterface, client ResourceFinder) (map[policyKey]kindState, error) {
 	var errs []error
-	enforcePolicy := computeEnforcePolicy(policy.GetSpec())
-	m.policies[key] = policy
-	type state struct {
-		hasMutate, hasValidate, hasGenerate, hasVerifyImages, hasImagesValidationChecks bool
-	}
-	kindStates := map[policyKey]state{}
+	kindStates := map[policyKey]kindState{}
 	for _, rule := range autogen.ComputeRules(policy) {
 		entries := sets.New[policyKey]()
 		for _, gvk := range rule.MatchResources.GetKinds() {
@@ -126,6 +147,22 @@ func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface, client Res
 				}
 			}
 		}
+		for _, resource := range rule.MatchResources.GetResources() {
+			gvrss, err := dclient.FindResourcesByName(client, resource)
+			if err != nil {
+				logger.Error(err, "failed to fetch resource group versions", "resource", resource)
+				errs = append(errs, err)
+			} else {
+				for gvrs := range gvrss {
+					entries.Insert(policyKey{
+						Group:       gvrs.Group,
+						Version:     gvrs.Version,
+						Resource:    gvrs.Resource,
+						SubResource: gvrs.SubResource,
+					})
+				}
+			}
+		}
 		if entries.Len() > 0 {
 			// account for pods/ephemeralcontainers special case
 			if entries.Has(podsKey) {
@@ -152,6 +189,38 @@ func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface, client Res
 			}
 		}
 	}
+	return kindStates, multierr.Combine(errs...)
+}
+
+// clone returns a copy of the policy map that can be modified without affecting the original,
+// the sets are shared and copied on write
+func (m *policyMap) clone() *policyMap {
+	policies := make(map[string]kyvernov1.PolicyInterface, len(m.policies))
+	for key, policy := range m.policies {
+		policies[key] = policy
+	}
+	kindType := make(map[policyKey]map[PolicyType]sets.Set[string], len(m.kindType))
+	for gvrs, types := range m.kindType {
+		kindType[gvrs] = make(map[PolicyType]sets.Set[string], len(types))
+		for policyType, names := range types {
+			kindType[gvrs][policyType] = names
+		}
+	}
+	return &policyMap{
+		policies: policies,
+		kindType: kindType,
+	}
+}
+
+func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface, client ResourceFinder) error {
+	states, err := computeKindStates(policy, client)
+	m.apply(key, policy, states)
+	return err
+}
+
+func (m *policyMap) apply(key string, policy kyvernov1.PolicyInterface, kindStates map[policyKey]kindState) {
+	enforcePolicy := computeEnforcePolicy(policy.GetSpec())
+	m.policies[key] = policy
 	for gvrs, state := range kindStates {
 		if m.kindType[gvrs] == nil {
 			m.kindType[gvrs] = map[PolicyType]sets.Set[string]{
@@ -170,14 +239,13 @@ func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface, client Res
 		m.kindType[gvrs][VerifyImagesMutate] = set(m.kindType[gvrs][VerifyImagesMutate], key, state.hasVerifyImages)
 		m.kindType[gvrs][VerifyImagesValidate] = set(m.kindType[gvrs][VerifyImagesValidate], key, state.hasVerifyImages && state.hasImagesValidationChecks)
 	}
-	return multierr.Combine(errs...)
 }
 
 func (m *policyMap) unset(key string) {
 	delete(m.policies, key)
 	for gvrs := range m.kindType {
 		for policyType := range m.kindType[gvrs] {
-			m.kindType[gvrs][policyType] = m.kindType[gvrs][policyType].Delete(key)
+			m.kindType[gvrs][policyType] = set(m.kindType[gvrs][policyType], key, false)
 		}
 	}
 }
@@ -205,3 +273,7 @@ func (m *policyMap) get(key PolicyType, gvr schema.GroupVersionResource, subreso
 	}
 	return result
 }
+
+func (m *policyMap) snapshot() store {
+	return m
+}
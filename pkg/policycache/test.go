@@ -3,6 +3,7 @@ package policycache
 import (
 	"fmt"
 
+	"github.com/kyverno/kyverno/ext/wildcard"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -65,6 +66,17 @@ func (TestResourceFinder) FindResources(group, version, kind, subresource string
 		return map[dclient.TopLevelApiDescription]metav1.APIResource{replicationcontrollersGVRS: dummy}, nil
 	case "CronJob":
 		return map[dclient.TopLevelApiDescription]metav1.APIResource{cronjobsGVRS: dummy}, nil
+	case "*":
+		all := map[dclient.TopLevelApiDescription]metav1.APIResource{}
+		for _, gvrs := range []dclient.TopLevelApiDescription{
+			podsGVRS, namespacesGVRS, clusterrolesGVRS, deploymentsGVRS, statefulsetsGVRS, daemonsetsGVRS,
+			replicasetsGVRS, jobsGVRS, replicationcontrollersGVRS, cronjobsGVRS,
+		} {
+			if wildcard.Match(group, gvrs.Group) {
+				all[gvrs] = dummy
+			}
+		}
+		return all, nil
 	}
 	return nil, fmt.Errorf("not found: %s", kind)
 }
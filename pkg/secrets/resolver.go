@@ -0,0 +1,173 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// VaultPrefix is the prefix of references to secrets stored in HashiCorp Vault,
+	// the format is `vault://<path>#<field>`
+	VaultPrefix = "vault://"
+	// KubernetesPrefix is the prefix of references to keys of Kubernetes secrets,
+	// the format is `k8s://<namespace>/<name>#<key>`
+	KubernetesPrefix = "k8s://"
+	// DefaultTTL is the default duration resolved secrets are cached for
+	DefaultTTL = 5 * time.Minute
+)
+
+// IsReference returns true if the value is a secret reference that needs to be resolved
+func IsReference(value string) bool {
+	return IsVaultReference(value) || strings.HasPrefix(value, KubernetesPrefix)
+}
+
+// IsVaultReference returns true if the value references a secret stored in HashiCorp Vault
+func IsVaultReference(value string) bool {
+	return strings.HasPrefix(value, VaultPrefix)
+}
+
+// vaultReader reads secrets from HashiCorp Vault
+type vaultReader interface {
+	read(ctx context.Context, path string) (map[string]interface{}, time.Duration, error)
+}
+
+// cacheKey identifies a resolved reference, values are cached per policy namespace so that
+// values resolved for cluster policies are never handed to namespaced policies
+type cacheKey struct {
+	namespace string
+	ref       string
+}
+
+type cacheEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+type resolver struct {
+	lock       sync.Mutex
+	ttl        time.Duration
+	now        func() time.Time
+	kubeClient kubernetes.Interface
+	vault      vaultReader
+	cache      map[cacheKey]cacheEntry
+}
+
+// NewResolver returns a resolver for secret references, Vault references can only be resolved when a Vault
+// configuration is provided. Resolved values are cached for the given duration (or the lease duration of the
+// Vault secret if shorter) so that rotated secrets are picked up without restarting Kyverno.
+func NewResolver(kubeClient kubernetes.Interface, vaultConfig *VaultConfig, ttl time.Duration) (engineapi.SecretResolver, error) {
+	r := &resolver{
+		ttl:        ttl,
+		now:        time.Now,
+		kubeClient: kubeClient,
+		cache:      map[cacheKey]cacheEntry{},
+	}
+	if vaultConfig != nil {
+		vault, err := newVaultClient(*vaultConfig)
+		if err != nil {
+			return nil, err
+		}
+		r.vault = vault
+	}
+	return r, nil
+}
+
+// Resolve resolves a secret reference, namespaced policies can only reference Kubernetes secrets of their own namespace
+// and can't reference Vault secrets as they would be read with the Vault identity of Kyverno
+func (r *resolver) Resolve(ctx context.Context, namespace string, ref string) (string, error) {
+	if err := checkNamespace(namespace, ref); err != nil {
+		return "", fmt.Errorf("failed to resolve secret reference %s: %w", ref, err)
+	}
+	key := cacheKey{namespace: namespace, ref: ref}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if entry, ok := r.cache[key]; ok && r.now().Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+	value, ttl, err := r.fetch(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve secret reference %s: %w", ref, err)
+	}
+	if ttl <= 0 || ttl > r.ttl {
+		ttl = r.ttl
+	}
+	r.cache[key] = cacheEntry{value: value, expiresAt: r.now().Add(ttl)}
+	return value, nil
+}
+
+func (r *resolver) fetch(ctx context.Context, ref string) (string, time.Duration, error) {
+	switch {
+	case IsVaultReference(ref):
+		if r.vault == nil {
+			return "", 0, errors.New("vault is not configured")
+		}
+		path, field, err := split(strings.TrimPrefix(ref, VaultPrefix))
+		if err != nil {
+			return "", 0, err
+		}
+		data, ttl, err := r.vault.read(ctx, path)
+		if err != nil {
+			return "", 0, err
+		}
+		value, ok := data[field].(string)
+		if !ok {
+			return "", 0, fmt.Errorf("field %s not found or not a string", field)
+		}
+		return value, ttl, nil
+	case strings.HasPrefix(ref, KubernetesPrefix):
+		path, key, err := split(strings.TrimPrefix(ref, KubernetesPrefix))
+		if err != nil {
+			return "", 0, err
+		}
+		namespace, name, ok := strings.Cut(path, "/")
+		if !ok || namespace == "" || name == "" {
+			return "", 0, errors.New("invalid reference, the format is k8s://<namespace>/<name>#<key>")
+		}
+		secret, err := r.kubeClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", 0, err
+		}
+		value, ok := secret.Data[key]
+		if !ok {
+			return "", 0, fmt.Errorf("key %s not found", key)
+		}
+		return string(value), 0, nil
+	default:
+		return "", 0, errors.New("unsupported secret reference")
+	}
+}
+
+// checkNamespace returns an error if a policy of the given namespace is not allowed to reference the secret,
+// cluster-wide policies (empty namespace) can reference any secret
+func checkNamespace(namespace string, ref string) error {
+	if namespace == "" {
+		return nil
+	}
+	if IsVaultReference(ref) {
+		return fmt.Errorf("policies of namespace %s can not reference Vault secrets", namespace)
+	}
+	if !strings.HasPrefix(ref, KubernetesPrefix) {
+		return nil
+	}
+	if secretNamespace, _, _ := strings.Cut(strings.TrimPrefix(ref, KubernetesPrefix), "/"); secretNamespace != namespace {
+		return fmt.Errorf("policies of namespace %s can not reference secrets of namespace %s", namespace, secretNamespace)
+	}
+	return nil
+}
+
+// split splits a reference into its path and the field selected after `#`
+func split(ref string) (string, string, error) {
+	i := strings.LastIndex(ref, "#")
+	if i <= 0 || i == len(ref)-1 {
+		return "", "", errors.New("invalid reference, a path and a field separated by # are required")
+	}
+	return ref[:i], ref[i+1:], nil
+}
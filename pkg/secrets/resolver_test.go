@@ -0,0 +1,146 @@
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestIsReference(t *testing.T) {
+	assert.True(t, IsReference("vault://secret/data/cosign#key"))
+	assert.True(t, IsReference("k8s://default/cosign#cosign.pub"))
+	assert.False(t, IsReference("-----BEGIN PUBLIC KEY-----"))
+	assert.True(t, IsVaultReference("vault://secret/data/cosign#key"))
+	assert.False(t, IsVaultReference("k8s://default/cosign#cosign.pub"))
+}
+
+func Test_split(t *testing.T) {
+	path, field, err := split("secret/data/cosign#key")
+	assert.NoError(t, err)
+	assert.Equal(t, "secret/data/cosign", path)
+	assert.Equal(t, "key", field)
+	_, _, err = split("secret/data/cosign")
+	assert.Error(t, err)
+	_, _, err = split("secret/data/cosign#")
+	assert.Error(t, err)
+	_, _, err = split("#key")
+	assert.Error(t, err)
+}
+
+func TestVaultClient(t *testing.T) {
+	logins := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/kubernetes/login":
+			logins++
+			_, _ = w.Write([]byte(`{"auth":{"client_token":"token","lease_duration":3600}}`))
+		case "/v1/secret/data/cosign":
+			if r.Header.Get("X-Vault-Token") != "token" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":{"data":{"key":"-----BEGIN PUBLIC KEY-----"},"metadata":{"version":2}}}`))
+		case "/v1/database/creds/readonly":
+			_, _ = w.Write([]byte(`{"lease_duration":60,"data":{"password":"secret"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenPath, []byte("jwt"), 0o600))
+	client, err := newVaultClient(VaultConfig{Address: server.URL, KubernetesRole: "kyverno"})
+	assert.NoError(t, err)
+	client.tokenPath = tokenPath
+	client.client.ClearToken()
+	data, ttl, err := client.read(context.TODO(), "secret/data/cosign")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"key": "-----BEGIN PUBLIC KEY-----"}, data)
+	assert.Equal(t, time.Duration(0), ttl)
+	assert.Equal(t, 1, logins)
+	// an expired token triggers a new login
+	client.client.SetToken("expired")
+	_, _, err = client.read(context.TODO(), "secret/data/cosign")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, logins)
+	data, ttl, err = client.read(context.TODO(), "database/creds/readonly")
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", data["password"])
+	assert.Equal(t, time.Minute, ttl)
+	_, _, err = client.read(context.TODO(), "secret/data/unknown")
+	assert.Error(t, err)
+}
+
+type fakeVault struct {
+	calls int
+	ttl   time.Duration
+}
+
+func (v *fakeVault) read(context.Context, string) (map[string]interface{}, time.Duration, error) {
+	v.calls++
+	if v.calls == 1 {
+		return map[string]interface{}{"key": "first"}, v.ttl, nil
+	}
+	return map[string]interface{}{"key": "rotated"}, v.ttl, nil
+}
+
+func TestResolver(t *testing.T) {
+	now := time.Now()
+	vault := &fakeVault{ttl: 30 * time.Second}
+	kubeClient := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cosign", Namespace: "default"},
+		Data:       map[string][]byte{"cosign.pub": []byte("public key")},
+	})
+	r := &resolver{
+		ttl:        time.Minute,
+		now:        func() time.Time { return now },
+		kubeClient: kubeClient,
+		vault:      vault,
+		cache:      map[cacheKey]cacheEntry{},
+	}
+	value, err := r.Resolve(context.TODO(), "", "vault://secret/data/cosign#key")
+	assert.NoError(t, err)
+	assert.Equal(t, "first", value)
+	value, err = r.Resolve(context.TODO(), "", "vault://secret/data/cosign#key")
+	assert.NoError(t, err)
+	assert.Equal(t, "first", value)
+	assert.Equal(t, 1, vault.calls)
+	// the lease duration is shorter than the ttl
+	now = now.Add(45 * time.Second)
+	value, err = r.Resolve(context.TODO(), "", "vault://secret/data/cosign#key")
+	assert.NoError(t, err)
+	assert.Equal(t, "rotated", value)
+	_, err = r.Resolve(context.TODO(), "", "vault://secret/data/cosign#unknown")
+	assert.Error(t, err)
+	value, err = r.Resolve(context.TODO(), "", "k8s://default/cosign#cosign.pub")
+	assert.NoError(t, err)
+	assert.Equal(t, "public key", value)
+	_, err = r.Resolve(context.TODO(), "", "k8s://default/cosign#unknown")
+	assert.Error(t, err)
+	_, err = r.Resolve(context.TODO(), "", "k8s://cosign#cosign.pub")
+	assert.Error(t, err)
+	// namespaced policies can only reference secrets of their own namespace
+	value, err = r.Resolve(context.TODO(), "default", "k8s://default/cosign#cosign.pub")
+	assert.NoError(t, err)
+	assert.Equal(t, "public key", value)
+	_, err = r.Resolve(context.TODO(), "team", "k8s://default/cosign#cosign.pub")
+	assert.Error(t, err)
+	// namespaced policies can't reference Vault secrets, even when resolved for a cluster policy
+	calls := vault.calls
+	_, err = r.Resolve(context.TODO(), "default", "vault://secret/data/cosign#key")
+	assert.Error(t, err)
+	assert.Equal(t, calls, vault.calls)
+	r.vault = nil
+	_, err = r.Resolve(context.TODO(), "", "vault://secret/data/other#key")
+	assert.Error(t, err)
+}
@@ -0,0 +1,121 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+const serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// VaultConfig configures the access to HashiCorp Vault. When no Kubernetes auth role is configured, the
+// token is read from the VAULT_TOKEN environment variable.
+type VaultConfig struct {
+	// Address is the address of the Vault server
+	Address string
+	// KubernetesRole is the role used to login with the Kubernetes auth method
+	KubernetesRole string
+	// KubernetesMountPath is the mount path of the Kubernetes auth method
+	KubernetesMountPath string
+}
+
+type vaultClient struct {
+	lock      sync.Mutex
+	client    *vaultapi.Client
+	role      string
+	mountPath string
+	tokenPath string
+	loggedIn  bool
+}
+
+func newVaultClient(config VaultConfig) (*vaultClient, error) {
+	vaultConfig := vaultapi.DefaultConfig()
+	if vaultConfig.Error != nil {
+		return nil, vaultConfig.Error
+	}
+	if config.Address != "" {
+		vaultConfig.Address = config.Address
+	}
+	client, err := vaultapi.NewClient(vaultConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vault client: %w", err)
+	}
+	mountPath := config.KubernetesMountPath
+	if mountPath == "" {
+		mountPath = "kubernetes"
+	}
+	return &vaultClient{
+		client:    client,
+		role:      config.KubernetesRole,
+		mountPath: mountPath,
+		tokenPath: serviceAccountTokenPath,
+	}, nil
+}
+
+func (c *vaultClient) read(ctx context.Context, path string) (map[string]interface{}, time.Duration, error) {
+	if err := c.login(ctx, false); err != nil {
+		return nil, 0, err
+	}
+	secret, err := c.client.Logical().ReadWithContext(ctx, path)
+	// the token may have expired, login again and retry once
+	if isPermissionDenied(err) && c.role != "" {
+		if err := c.login(ctx, true); err != nil {
+			return nil, 0, err
+		}
+		secret, err = c.client.Logical().ReadWithContext(ctx, path)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, 0, fmt.Errorf("secret %s not found", path)
+	}
+	data := secret.Data
+	// KV version 2 nests the secret data next to its metadata
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+	return data, time.Duration(secret.LeaseDuration) * time.Second, nil
+}
+
+// login authenticates with the Kubernetes auth method if a role is configured
+func (c *vaultClient) login(ctx context.Context, force bool) error {
+	if c.role == "" {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.loggedIn && !force {
+		return nil
+	}
+	jwt, err := os.ReadFile(c.tokenPath)
+	if err != nil {
+		return fmt.Errorf("failed to read service account token: %w", err)
+	}
+	secret, err := c.client.Logical().WriteWithContext(ctx, "auth/"+c.mountPath+"/login", map[string]interface{}{
+		"role": c.role,
+		"jwt":  string(jwt),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to login to vault: %w", err)
+	}
+	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+		return errors.New("failed to login to vault: no token returned")
+	}
+	c.client.SetToken(secret.Auth.ClientToken)
+	c.loggedIn = true
+	return nil
+}
+
+func isPermissionDenied(err error) bool {
+	var responseError *vaultapi.ResponseError
+	return errors.As(err, &responseError) && responseError.StatusCode == http.StatusForbidden
+}
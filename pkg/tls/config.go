@@ -0,0 +1,56 @@
+package tls
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+)
+
+// DefaultCipherSuites are the cipher suites used by the webhook server when none are configured (AEADs w/ ECDHE)
+var DefaultCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+	tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+}
+
+var versions = map[string]uint16{
+	"VersionTLS12": tls.VersionTLS12,
+	"VersionTLS13": tls.VersionTLS13,
+}
+
+// ParseVersion converts a TLS version name (VersionTLS12 or VersionTLS13) to its value,
+// older versions are not supported
+func ParseVersion(name string) (uint16, error) {
+	if version, ok := versions[strings.TrimSpace(name)]; ok {
+		return version, nil
+	}
+	return 0, fmt.Errorf("unsupported TLS version %q, must be one of VersionTLS12 or VersionTLS13", name)
+}
+
+// ParseCipherSuites converts a comma separated list of cipher suite names to their values,
+// insecure cipher suites are rejected and an empty list returns the default cipher suites
+func ParseCipherSuites(names string) ([]uint16, error) {
+	known := map[string]uint16{}
+	for _, suite := range tls.CipherSuites() {
+		known[suite.Name] = suite.ID
+	}
+	var suites []uint16
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		id, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported or insecure cipher suite %q", name)
+		}
+		suites = append(suites, id)
+	}
+	if len(suites) == 0 {
+		return DefaultCipherSuites, nil
+	}
+	return suites, nil
+}
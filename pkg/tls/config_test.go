@@ -0,0 +1,32 @@
+package tls
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersion(t *testing.T) {
+	version, err := ParseVersion("VersionTLS12")
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS12), version)
+	version, err = ParseVersion("VersionTLS13")
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), version)
+	_, err = ParseVersion("VersionTLS10")
+	assert.Error(t, err)
+}
+
+func TestParseCipherSuites(t *testing.T) {
+	suites, err := ParseCipherSuites("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultCipherSuites, suites)
+	suites, err = ParseCipherSuites("TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384, TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint16{tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384, tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384}, suites)
+	_, err = ParseCipherSuites("TLS_RSA_WITH_RC4_128_SHA")
+	assert.Error(t, err)
+	_, err = ParseCipherSuites("foo")
+	assert.Error(t, err)
+}
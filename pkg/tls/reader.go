@@ -2,6 +2,7 @@ package tls
 
 import (
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
@@ -26,3 +27,22 @@ func ReadRootCASecret(name, namespace string, client corev1listers.SecretNamespa
 	}
 	return result, nil
 }
+
+// ReadCertificateExpiry returns the expiry time of the certificate stored in the secret,
+// when the secret contains a bundle the latest expiry time is returned
+func ReadCertificateExpiry(secret *corev1.Secret) (time.Time, error) {
+	raw := secret.Data[corev1.TLSCertKey]
+	if len(raw) == 0 {
+		raw = secret.Data[rootCAKey]
+	}
+	var expiry time.Time
+	for _, cert := range pemToCertificates(raw) {
+		if cert.NotAfter.After(expiry) {
+			expiry = cert.NotAfter
+		}
+	}
+	if expiry.IsZero() {
+		return expiry, fmt.Errorf("certificate not found in secret %s/%s", secret.Namespace, secret.Name)
+	}
+	return expiry, nil
+}
@@ -0,0 +1,53 @@
+package tls
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReadCertificateExpiry(t *testing.T) {
+	key, short, err := generateCA(nil, time.Hour)
+	assert.NoError(t, err)
+	_, long, err := generateCA(key, 24*time.Hour)
+	assert.NoError(t, err)
+	tests := []struct {
+		name    string
+		data    map[string][]byte
+		want    time.Time
+		wantErr bool
+	}{{
+		name:    "empty",
+		wantErr: true,
+	}, {
+		name: "tls.crt",
+		data: map[string][]byte{corev1.TLSCertKey: certificateToPem(short)},
+		want: short.NotAfter,
+	}, {
+		name: "rootCA.crt",
+		data: map[string][]byte{rootCAKey: certificateToPem(short)},
+		want: short.NotAfter,
+	}, {
+		name: "bundle",
+		data: map[string][]byte{corev1.TLSCertKey: certificateToPem(short, long)},
+		want: long.NotAfter,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "kyverno", Name: "secret"},
+				Data:       tt.data,
+			}
+			got, err := ReadCertificateExpiry(secret)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, tt.want.Equal(got))
+			}
+		})
+	}
+}
@@ -26,6 +26,11 @@ const (
 	GenerateValidatingAdmissionPolicyDescription = "Set the flag to 'true', to generate validating admission policies."
 	generateValidatingAdmissionPolicyEnvVar      = "FLAG_GENERATE_VALIDATING_ADMISSION_POLICY"
 	defaultGenerateValidatingAdmissionPolicy     = false
+	// enable rego validation rules
+	EnableRegoRulesFlagName    = "enableRegoRules"
+	EnableRegoRulesDescription = "Set the flag to 'true', to evaluate rego validation rules."
+	enableRegoRulesEnvVar      = "FLAG_ENABLE_REGO_RULES"
+	defaultEnableRegoRules     = false
 )
 
 var (
@@ -33,6 +38,7 @@ var (
 	ForceFailurePolicyIgnore          = newToggle(defaultForceFailurePolicyIgnore, forceFailurePolicyIgnoreEnvVar)
 	EnableDeferredLoading             = newToggle(defaultEnableDeferredLoading, enableDeferredLoadingEnvVar)
 	GenerateValidatingAdmissionPolicy = newToggle(defaultGenerateValidatingAdmissionPolicy, generateValidatingAdmissionPolicyEnvVar)
+	EnableRegoRules                   = newToggle(defaultEnableRegoRules, enableRegoRulesEnvVar)
 )
 
 type ToggleFlag interface {
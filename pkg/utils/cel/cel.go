@@ -76,6 +76,18 @@ func (c Compiler) CompileMatchExpressions(optionalVars cel.OptionalVariableDecla
 	)
 }
 
+// ValidateMatchExpressions compiles the match expressions and returns the compilation errors
+func (c Compiler) ValidateMatchExpressions(optionalVars cel.OptionalVariableDeclarations) []error {
+	compiler := cel.NewCompiler(environment.MustBaseEnvSet(environment.DefaultCompatibilityVersion()))
+	var errs []error
+	for _, accessor := range c.convertMatchExpressions() {
+		if result := compiler.CompileCELExpression(accessor, optionalVars, environment.StoredExpressions); result.Error != nil {
+			errs = append(errs, result.Error)
+		}
+	}
+	return errs
+}
+
 func (c Compiler) convertValidations() []cel.ExpressionAccessor {
 	celExpressionAccessor := make([]cel.ExpressionAccessor, len(c.validateExpressions))
 	for i, validation := range c.validateExpressions {
@@ -9,12 +9,19 @@ import (
 	"github.com/go-git/go-billy/v5"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/storage/memory"
 )
 
 func Clone(path string, fs billy.Filesystem, branch string) (*git.Repository, error) {
+	return CloneWithAuth(path, fs, branch, nil)
+}
+
+// CloneWithAuth clones the given branch of a repository using the provided auth method (can be nil).
+func CloneWithAuth(path string, fs billy.Filesystem, branch string, auth transport.AuthMethod) (*git.Repository, error) {
 	return git.Clone(memory.NewStorage(), fs, &git.CloneOptions{
 		URL:           path,
+		Auth:          auth,
 		ReferenceName: plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", branch)),
 		Progress:      os.Stdout,
 		SingleBranch:  true,
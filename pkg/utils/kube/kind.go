@@ -121,3 +121,14 @@ func GroupVersionMatches(groupVersion, serverResourceGroupVersion string) bool {
 func IsSubresource(resourceName string) bool {
 	return strings.Contains(resourceName, "/")
 }
+
+// ParseResourceSelector parses a resource selector in the form resource[/subresource][.group],
+// the group defaults to `*` when it is omitted
+func ParseResourceSelector(input string) (string, string, string) {
+	resource, group, found := strings.Cut(input, ".")
+	if !found {
+		group = "*"
+	}
+	resource, subresource, _ := strings.Cut(resource, "/")
+	return group, resource, subresource
+}
@@ -211,3 +211,45 @@ func TestParseKindSelector(t *testing.T) {
 		})
 	}
 }
+
+func TestParseResourceSelector(t *testing.T) {
+	tests := []struct {
+		input       string
+		group       string
+		resource    string
+		subresource string
+	}{{
+		input:    "pods",
+		group:    "*",
+		resource: "pods",
+	}, {
+		input:    "pods.",
+		group:    "",
+		resource: "pods",
+	}, {
+		input:    "deployments.apps",
+		group:    "apps",
+		resource: "deployments",
+	}, {
+		input:       "deployments/scale.apps",
+		group:       "apps",
+		resource:    "deployments",
+		subresource: "scale",
+	}, {
+		input:    "*.example.com",
+		group:    "example.com",
+		resource: "*",
+	}, {
+		input:    "*.*.example.com",
+		group:    "*.example.com",
+		resource: "*",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			group, resource, subresource := ParseResourceSelector(tt.input)
+			assert.Equal(t, tt.group, group)
+			assert.Equal(t, tt.resource, resource)
+			assert.Equal(t, tt.subresource, subresource)
+		})
+	}
+}
@@ -6,7 +6,6 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
-	"github.com/kyverno/kyverno/ext/wildcard"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	"go.uber.org/multierr"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -133,6 +132,9 @@ func checkResourceDescription(
 	subresource string,
 ) []error {
 	var errs []error
+	if len(conditionBlock.Resources) > 0 {
+		errs = append(errs, fmt.Errorf("resources are not supported in this statement, use kinds instead"))
+	}
 	if len(conditionBlock.Kinds) > 0 {
 		// Matching on ephemeralcontainers even when they are not explicitly specified is only applicable to policies.
 		if !CheckKind(conditionBlock.Kinds, gvk, subresource, false) {
@@ -180,6 +182,11 @@ func checkResourceDescription(
 			}
 		}
 	}
+	if len(conditionBlock.OwnerReferences) > 0 {
+		if !CheckOwnerReferences(conditionBlock.OwnerReferences, resource.GetOwnerReferences()) {
+			errs = append(errs, fmt.Errorf("owner references does not match"))
+		}
+	}
 	if conditionBlock.NamespaceSelector != nil && resource.GetKind() != "Namespace" && resource.GetKind() != "" {
 		hasPassed, err := CheckSelector(conditionBlock.NamespaceSelector, namespaceLabels)
 		if err != nil {
@@ -198,10 +205,5 @@ func checkNameSpace(namespaces []string, resource unstructured.Unstructured) boo
 	if resource.GetKind() == "Namespace" {
 		resourceNameSpace = resource.GetName()
 	}
-	for _, namespace := range namespaces {
-		if wildcard.Match(namespace, resourceNameSpace) {
-			return true
-		}
-	}
-	return false
+	return CheckNamespaces(namespaces, resourceNameSpace)
 }
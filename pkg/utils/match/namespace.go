@@ -0,0 +1,31 @@
+package match
+
+import (
+	"strings"
+
+	"github.com/kyverno/kyverno/ext/wildcard"
+)
+
+// CheckNamespaces checks a namespace against a list of namespace patterns.
+// Patterns prefixed with `!` exclude namespaces and take precedence over the other patterns.
+// A list made of negated patterns only matches every namespace that is not excluded, it never
+// matches cluster wide resources.
+func CheckNamespaces(patterns []string, namespace string) bool {
+	included, hasIncludes := false, false
+	for _, pattern := range patterns {
+		if negated, ok := strings.CutPrefix(pattern, "!"); ok {
+			if wildcard.Match(negated, namespace) {
+				return false
+			}
+			continue
+		}
+		hasIncludes = true
+		if wildcard.Match(pattern, namespace) {
+			included = true
+		}
+	}
+	if !hasIncludes {
+		return namespace != ""
+	}
+	return included
+}
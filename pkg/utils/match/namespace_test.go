@@ -0,0 +1,69 @@
+package match
+
+import "testing"
+
+func TestCheckNamespaces(t *testing.T) {
+	tests := []struct {
+		name      string
+		patterns  []string
+		namespace string
+		want      bool
+	}{{
+		name:      "exact",
+		patterns:  []string{"prod"},
+		namespace: "prod",
+		want:      true,
+	}, {
+		name:      "wildcard",
+		patterns:  []string{"prod-*"},
+		namespace: "prod-payments",
+		want:      true,
+	}, {
+		name:      "no match",
+		patterns:  []string{"prod-*"},
+		namespace: "dev",
+		want:      false,
+	}, {
+		name:      "negation takes precedence",
+		patterns:  []string{"prod-*", "!prod-sandbox"},
+		namespace: "prod-sandbox",
+		want:      false,
+	}, {
+		name:      "negation order does not matter",
+		patterns:  []string{"!prod-sandbox", "prod-*"},
+		namespace: "prod-sandbox",
+		want:      false,
+	}, {
+		name:      "not negated",
+		patterns:  []string{"prod-*", "!prod-sandbox"},
+		namespace: "prod-payments",
+		want:      true,
+	}, {
+		name:      "negated wildcard",
+		patterns:  []string{"prod-*", "!prod-sandbox-*"},
+		namespace: "prod-sandbox-1",
+		want:      false,
+	}, {
+		name:      "negations only",
+		patterns:  []string{"!kube-*"},
+		namespace: "default",
+		want:      true,
+	}, {
+		name:      "negations only excluded",
+		patterns:  []string{"!kube-*"},
+		namespace: "kube-system",
+		want:      false,
+	}, {
+		name:      "negations only cluster wide",
+		patterns:  []string{"!kube-*"},
+		namespace: "",
+		want:      false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckNamespaces(tt.patterns, tt.namespace); got != tt.want {
+				t.Errorf("CheckNamespaces() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
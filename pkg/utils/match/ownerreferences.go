@@ -0,0 +1,38 @@
+package match
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CheckOwnerReferences returns true if at least one of the actual owner references matches one of the expected filters
+func CheckOwnerReferences(expected []kyvernov1.OwnerReferenceFilter, actual []metav1.OwnerReference) bool {
+	if len(expected) == 0 {
+		return true
+	}
+	for _, filter := range expected {
+		for _, owner := range actual {
+			if checkOwnerReference(filter, owner) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func checkOwnerReference(filter kyvernov1.OwnerReferenceFilter, owner metav1.OwnerReference) bool {
+	if filter.Kind != "" && !wildcard.Match(filter.Kind, owner.Kind) {
+		return false
+	}
+	if filter.Name != "" && !wildcard.Match(filter.Name, owner.Name) {
+		return false
+	}
+	if filter.Controller != nil {
+		controller := owner.Controller != nil && *owner.Controller
+		if *filter.Controller != controller {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package match
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckOwnerReferences(t *testing.T) {
+	isController, notController := true, false
+	job := metav1.OwnerReference{Kind: "Job", Name: "backup-28160", Controller: &isController}
+	replicaSet := metav1.OwnerReference{Kind: "ReplicaSet", Name: "nginx-7d9c6f", Controller: &isController}
+	configMap := metav1.OwnerReference{Kind: "ConfigMap", Name: "settings"}
+	tests := []struct {
+		name     string
+		expected []kyvernov1.OwnerReferenceFilter
+		actual   []metav1.OwnerReference
+		want     bool
+	}{{
+		name: "no filter",
+		want: true,
+	}, {
+		name:     "no owner",
+		expected: []kyvernov1.OwnerReferenceFilter{{Kind: "Job"}},
+		want:     false,
+	}, {
+		name:     "kind matches",
+		expected: []kyvernov1.OwnerReferenceFilter{{Kind: "Job"}},
+		actual:   []metav1.OwnerReference{job},
+		want:     true,
+	}, {
+		name:     "kind does not match",
+		expected: []kyvernov1.OwnerReferenceFilter{{Kind: "Job"}},
+		actual:   []metav1.OwnerReference{replicaSet},
+		want:     false,
+	}, {
+		name:     "name with wildcard",
+		expected: []kyvernov1.OwnerReferenceFilter{{Kind: "Job", Name: "backup-*"}},
+		actual:   []metav1.OwnerReference{job},
+		want:     true,
+	}, {
+		name:     "name does not match",
+		expected: []kyvernov1.OwnerReferenceFilter{{Kind: "Job", Name: "cleanup-*"}},
+		actual:   []metav1.OwnerReference{job},
+		want:     false,
+	}, {
+		name:     "controller matches",
+		expected: []kyvernov1.OwnerReferenceFilter{{Kind: "*", Controller: &isController}},
+		actual:   []metav1.OwnerReference{configMap, replicaSet},
+		want:     true,
+	}, {
+		name:     "controller does not match",
+		expected: []kyvernov1.OwnerReferenceFilter{{Controller: &isController}},
+		actual:   []metav1.OwnerReference{configMap},
+		want:     false,
+	}, {
+		name:     "non controller owner",
+		expected: []kyvernov1.OwnerReferenceFilter{{Controller: &notController}},
+		actual:   []metav1.OwnerReference{configMap},
+		want:     true,
+	}, {
+		name:     "any filter matches",
+		expected: []kyvernov1.OwnerReferenceFilter{{Kind: "Job"}, {Kind: "ReplicaSet"}},
+		actual:   []metav1.OwnerReference{replicaSet},
+		want:     true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckOwnerReferences(tt.expected, tt.actual); got != tt.want {
+				t.Errorf("CheckOwnerReferences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package match
+
+import (
+	"github.com/kyverno/kyverno/ext/wildcard"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// CheckResources checks if the resource and subresource match one of the resource selectors in the policy.
+func CheckResources(resources []string, gvr schema.GroupVersionResource, subresource string) bool {
+	for _, r := range resources {
+		group, resource, sub := kubeutils.ParseResourceSelector(r)
+		if wildcard.Match(group, gvr.Group) && wildcard.Match(resource, gvr.Resource) && wildcard.Match(sub, subresource) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package match
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_CheckResources(t *testing.T) {
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	pods := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"}
+	widgets := schema.GroupVersionResource{Group: "widgets.example.com", Version: "v1alpha1", Resource: "widgets"}
+
+	assert.Equal(t, CheckResources([]string{"deployments.apps"}, deployments, ""), true)
+	assert.Equal(t, CheckResources([]string{"deployments"}, deployments, ""), true)
+	assert.Equal(t, CheckResources([]string{"deployments.batch"}, deployments, ""), false)
+	assert.Equal(t, CheckResources([]string{"deployments.apps"}, deployments, "scale"), false)
+	assert.Equal(t, CheckResources([]string{"deployments/scale.apps"}, deployments, "scale"), true)
+	assert.Equal(t, CheckResources([]string{"deployments/*.apps"}, deployments, "scale"), true)
+	assert.Equal(t, CheckResources([]string{"pods"}, pods, ""), true)
+	assert.Equal(t, CheckResources([]string{"pods."}, pods, ""), true)
+	assert.Equal(t, CheckResources([]string{"pods.apps"}, pods, ""), false)
+	assert.Equal(t, CheckResources([]string{"*.example.com"}, widgets, ""), false)
+	assert.Equal(t, CheckResources([]string{"*.*.example.com"}, widgets, ""), true)
+	assert.Equal(t, CheckResources([]string{"*.widgets.example.com"}, widgets, ""), true)
+	assert.Equal(t, CheckResources([]string{"pods", "*.*.example.com"}, widgets, ""), true)
+}
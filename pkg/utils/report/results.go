@@ -17,6 +17,12 @@ import (
 	"k8s.io/client-go/tools/cache"
 )
 
+// SkipReasonProperty is the report result property holding the reason why a rule was skipped
+const SkipReasonProperty = "skipReason"
+
+// ResultReasonProperty is the report result property holding the standardized result reason of a skipped rule
+const ResultReasonProperty = "resultReason"
+
 func SortReportResults(results []policyreportv1alpha2.PolicyReportResult) {
 	slices.SortFunc(results, func(a policyreportv1alpha2.PolicyReportResult, b policyreportv1alpha2.PolicyReportResult) int {
 		if x := cmp.Compare(a.Policy, b.Policy); x != 0 {
@@ -107,6 +113,15 @@ func EngineResponseToReportResults(response engineapi.EngineResponse) []policyre
 				Category: annotations[kyverno.AnnotationPolicyCategory],
 				Severity: SeverityFromString(annotations[kyverno.AnnotationPolicySeverity]),
 			}
+			if severity := ruleResult.Severity(); severity != "" {
+				result.Severity = SeverityFromString(severity)
+			}
+			if properties := ruleResult.Properties(); len(properties) != 0 {
+				result.Properties = make(map[string]string, len(properties))
+				for key, value := range properties {
+					result.Properties[key] = value
+				}
+			}
 			pss := ruleResult.PodSecurityChecks()
 			if pss != nil {
 				var controls []string
@@ -117,12 +132,25 @@ func EngineResponseToReportResults(response engineapi.EngineResponse) []policyre
 				}
 				if len(controls) > 0 {
 					sort.Strings(controls)
-					result.Properties = map[string]string{
-						"standard": string(pss.Level),
-						"version":  pss.Version,
-						"controls": strings.Join(controls, ","),
+					if result.Properties == nil {
+						result.Properties = map[string]string{}
 					}
+					result.Properties["standard"] = string(pss.Level)
+					result.Properties["version"] = pss.Version
+					result.Properties["controls"] = strings.Join(controls, ",")
+				}
+			}
+			if reason := ruleResult.SkipReason(); reason != "" {
+				if result.Properties == nil {
+					result.Properties = map[string]string{}
+				}
+				result.Properties[SkipReasonProperty] = string(reason)
+			}
+			if ruleResult.Status() == engineapi.RuleStatusSkip {
+				if result.Properties == nil {
+					result.Properties = map[string]string{}
 				}
+				result.Properties[ResultReasonProperty] = string(ruleResult.ResultReason())
 			}
 			if result.Result == "fail" && !result.Scored {
 				result.Result = "warn"
@@ -2,19 +2,32 @@ package exception
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 const (
 	namespacesDontMatch = "PolicyException resource namespace must match the defined namespace."
 	disabledPolex       = "PolicyException resources would not be processed until it is enabled."
+	expiredPolex        = "PolicyException has already expired and will be ignored."
 )
 
 type ValidationOptions struct {
 	Enabled   bool
 	Namespace string
+	// RequireApproval rejects exceptions without the approved-by annotation
+	RequireApproval bool
+	// MaxDuration, if set, rejects exceptions that don't expire within the given duration
+	MaxDuration time.Duration
+	// RestrictScope rejects exceptions using wildcard rule names or matching resources in any namespace
+	RestrictScope bool
 }
 
 // Validate checks policy exception is valid
@@ -25,6 +38,73 @@ func Validate(ctx context.Context, logger logr.Logger, polex *kyvernov2beta1.Pol
 	} else if opts.Namespace != "" && opts.Namespace != polex.Namespace {
 		warnings = append(warnings, namespacesDontMatch)
 	}
+	now := time.Now()
+	if polex.Spec.HasExpired(now) {
+		warnings = append(warnings, expiredPolex)
+	}
 	errs := polex.Validate()
+	errs = append(errs, validateApproval(polex, opts)...)
+	errs = append(errs, validateExpiry(polex, opts, now)...)
+	errs = append(errs, validateScope(polex, opts)...)
 	return warnings, errs.ToAggregate()
 }
+
+func validateApproval(polex *kyvernov2beta1.PolicyException, opts ValidationOptions) (errs field.ErrorList) {
+	if !opts.RequireApproval {
+		return nil
+	}
+	if strings.TrimSpace(polex.GetAnnotations()[kyverno.AnnotationExceptionApprovedBy]) == "" {
+		path := field.NewPath("metadata", "annotations").Key(kyverno.AnnotationExceptionApprovedBy)
+		errs = append(errs, field.Required(path, "An exception requires an approver"))
+	}
+	return errs
+}
+
+func validateExpiry(polex *kyvernov2beta1.PolicyException, opts ValidationOptions, now time.Time) (errs field.ErrorList) {
+	if opts.MaxDuration <= 0 {
+		return nil
+	}
+	path := field.NewPath("spec", "expiresAt")
+	if polex.Spec.ExpiresAt == nil {
+		errs = append(errs, field.Required(path, fmt.Sprintf("An exception must expire within %s", opts.MaxDuration)))
+	} else if polex.Spec.ExpiresAt.Time.After(now.Add(opts.MaxDuration)) {
+		errs = append(errs, field.Invalid(path, polex.Spec.ExpiresAt.String(), fmt.Sprintf("An exception must expire within %s", opts.MaxDuration)))
+	}
+	return errs
+}
+
+func validateScope(polex *kyvernov2beta1.PolicyException, opts ValidationOptions) (errs field.ErrorList) {
+	if !opts.RestrictScope {
+		return nil
+	}
+	exceptionsPath := field.NewPath("spec", "exceptions")
+	for i, exception := range polex.Spec.Exceptions {
+		for j, rule := range exception.RuleNames {
+			if strings.ContainsAny(rule, "*?") {
+				errs = append(errs, field.Invalid(exceptionsPath.Index(i).Child("ruleNames").Index(j), rule, "Wildcard rule names are not allowed"))
+			}
+		}
+	}
+	// any filter can match on its own so every filter must be scoped, all filters are combined so one is enough
+	matchPath := field.NewPath("spec", "match")
+	for i, filter := range polex.Spec.Match.Any {
+		if !scoped(filter) {
+			errs = append(errs, field.Forbidden(matchPath.Child("any").Index(i), "An exception must be scoped to namespaces or resource names"))
+		}
+	}
+	if len(polex.Spec.Match.All) != 0 {
+		var ok bool
+		for _, filter := range polex.Spec.Match.All {
+			ok = ok || scoped(filter)
+		}
+		if !ok {
+			errs = append(errs, field.Forbidden(matchPath.Child("all"), "An exception must be scoped to namespaces or resource names"))
+		}
+	}
+	return errs
+}
+
+func scoped(filter kyvernov1.ResourceFilter) bool {
+	resources := filter.ResourceDescription
+	return len(resources.Namespaces) != 0 || resources.NamespaceSelector != nil || resources.Name != "" || len(resources.Names) != 0
+}
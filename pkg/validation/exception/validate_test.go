@@ -3,6 +3,7 @@ package exception
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/kyverno/kyverno/pkg/logging"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
@@ -74,3 +75,73 @@ func Test_Validate(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidateLimits(t *testing.T) {
+	tc := []struct {
+		name     string
+		opts     ValidationOptions
+		resource string
+		warnings int
+		wantErr  bool
+	}{
+		{
+			name:     "Approval required and missing.",
+			opts:     ValidationOptions{Enabled: true, RequireApproval: true},
+			resource: `{"apiVersion":"kyverno.io/v2beta1","kind":"PolicyException","metadata":{"name":"polex","namespace":"kyverno"},"spec":{"exceptions":[{"policyName":"enforce-label","ruleNames":["enforce-label"]}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]}}}`,
+			wantErr:  true,
+		},
+		{
+			name:     "Approval required and present.",
+			opts:     ValidationOptions{Enabled: true, RequireApproval: true},
+			resource: `{"apiVersion":"kyverno.io/v2beta1","kind":"PolicyException","metadata":{"name":"polex","namespace":"kyverno","annotations":{"exceptions.kyverno.io/approved-by":"alice"}},"spec":{"exceptions":[{"policyName":"enforce-label","ruleNames":["enforce-label"]}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]}}}`,
+		},
+		{
+			name:     "Max duration set and no expiry.",
+			opts:     ValidationOptions{Enabled: true, MaxDuration: time.Hour},
+			resource: `{"apiVersion":"kyverno.io/v2beta1","kind":"PolicyException","metadata":{"name":"polex","namespace":"kyverno"},"spec":{"exceptions":[{"policyName":"enforce-label","ruleNames":["enforce-label"]}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]}}}`,
+			wantErr:  true,
+		},
+		{
+			name:     "Max duration set and expiry too far.",
+			opts:     ValidationOptions{Enabled: true, MaxDuration: time.Hour},
+			resource: `{"apiVersion":"kyverno.io/v2beta1","kind":"PolicyException","metadata":{"name":"polex","namespace":"kyverno"},"spec":{"expiresAt":"2999-01-01T00:00:00Z","exceptions":[{"policyName":"enforce-label","ruleNames":["enforce-label"]}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]}}}`,
+			wantErr:  true,
+		},
+		{
+			name:     "Expired exception.",
+			opts:     ValidationOptions{Enabled: true, MaxDuration: time.Hour},
+			resource: `{"apiVersion":"kyverno.io/v2beta1","kind":"PolicyException","metadata":{"name":"polex","namespace":"kyverno"},"spec":{"expiresAt":"2000-01-01T00:00:00Z","exceptions":[{"policyName":"enforce-label","ruleNames":["enforce-label"]}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]}}}`,
+			warnings: 1,
+		},
+		{
+			name:     "Restricted scope and wildcard rule.",
+			opts:     ValidationOptions{Enabled: true, RestrictScope: true},
+			resource: `{"apiVersion":"kyverno.io/v2beta1","kind":"PolicyException","metadata":{"name":"polex","namespace":"kyverno"},"spec":{"exceptions":[{"policyName":"enforce-label","ruleNames":["*"]}],"match":{"any":[{"resources":{"kinds":["Pod"],"namespaces":["delta"]}}]}}}`,
+			wantErr:  true,
+		},
+		{
+			name:     "Restricted scope and any namespace.",
+			opts:     ValidationOptions{Enabled: true, RestrictScope: true},
+			resource: `{"apiVersion":"kyverno.io/v2beta1","kind":"PolicyException","metadata":{"name":"polex","namespace":"kyverno"},"spec":{"exceptions":[{"policyName":"enforce-label","ruleNames":["enforce-label"]}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]}}}`,
+			wantErr:  true,
+		},
+		{
+			name:     "Restricted scope and scoped exception.",
+			opts:     ValidationOptions{Enabled: true, RestrictScope: true},
+			resource: `{"apiVersion":"kyverno.io/v2beta1","kind":"PolicyException","metadata":{"name":"polex","namespace":"kyverno"},"spec":{"exceptions":[{"policyName":"enforce-label","ruleNames":["enforce-label"]}],"match":{"all":[{"resources":{"kinds":["Pod"]}},{"resources":{"namespaces":["delta"]}}]}}}`,
+		},
+	}
+	for _, c := range tc {
+		t.Run(c.name, func(t *testing.T) {
+			polex, err := admissionutils.UnmarshalPolicyException([]byte(c.resource))
+			assert.NilError(t, err)
+			warnings, err := Validate(context.Background(), logging.GlobalLogger(), polex, c.opts)
+			if c.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+			}
+			assert.Assert(t, len(warnings) == c.warnings)
+		})
+	}
+}
@@ -25,7 +25,7 @@ type Validation interface {
 // - Mutate
 // - Validation
 // - Generate
-func validateActions(idx int, rule *kyvernov1.Rule, client dclient.Interface, mock bool, username string) (string, error) {
+func validateActions(idx int, rule *kyvernov1.Rule, policyNamespace string, client dclient.Interface, mock bool, username string) (string, error) {
 	if rule == nil {
 		return "", nil
 	}
@@ -41,7 +41,7 @@ func validateActions(idx int, rule *kyvernov1.Rule, client dclient.Interface, mo
 
 	// Validate
 	if rule.HasValidate() {
-		checker = validate.NewValidateFactory(&rule.Validation)
+		checker = validate.NewValidateFactory(&rule.Validation).WithPolicyNamespace(policyNamespace)
 		if path, err := checker.Validate(context.TODO()); err != nil {
 			return "", fmt.Errorf("path: spec.rules[%d].validate.%s.: %v", idx, path, err)
 		}
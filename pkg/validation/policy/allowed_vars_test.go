@@ -364,6 +364,7 @@ func TestNotAllowedVars_VariableFormats(t *testing.T) {
 		{"request_object", "request.object.meta", true},
 		{"service_account_name", "serviceAccountName", true},
 		{"service_account_namespace", "serviceAccountNamespace", true},
+		{"service_account_namespace_labels", "serviceAccountNamespaceLabels.platform", true},
 		{"self", "@", true},
 		{"custom_func_compare", "compare(string, string)", true},
 		{"custom_func_contains", "contains(string, string)", true},
@@ -406,6 +407,16 @@ func TestNotAllowedVars_VariableFormats(t *testing.T) {
 		{"type", "type(foo, bar)", true},
 		{"values", "values(foo, bar)", true},
 		{"self_path_test", "@", true},
+		{"typo_request", "reqest.object.spec.image", false},
+		{"unknown_root", "foo.request.object", false},
+		{"nested_element", "element0.name", true},
+		{"nested_element_index", "elementIndex1", true},
+		{"policy", "policy", true},
+		{"rule", "rule", true},
+		{"rule_type", "ruleType", true},
+		{"rule_prefix", "rules", false},
+		{"multi_select_list", "[request.object.metadata.name, request.object.metadata.namespace]", true},
+		{"literal", "'foo'", true},
 	}
 
 	for _, tc := range tcs {
@@ -487,3 +498,39 @@ spec:
 	err = hasInvalidVariables(policy[0], false)
 	assert.NilError(t, err)
 }
+
+func TestNotAllowedVars_AllowedVariablePrefixes(t *testing.T) {
+	var policyYAML = []byte(`
+    apiVersion: kyverno.io/v1
+    kind: ClusterPolicy
+    metadata:
+      name: custom-prefix
+    spec:
+      rules:
+      - name: custom-prefix
+        match:
+          resources:
+            kinds:
+            - Pod
+        preconditions:
+          any:
+            - key: "{{ custom.value }}"
+              operator: NotEquals
+              value: ""
+        validate:
+          pattern:
+            metadata:
+              name: "?*"
+    `)
+
+	policy, _, err := yamlutils.GetPolicy(policyYAML)
+	assert.NilError(t, err)
+
+	err = hasInvalidVariables(policy[0], false)
+	assert.Assert(t, err != nil, "was expecting an error")
+
+	SetAllowedVariablePrefixes("custom.", " ")
+	defer SetAllowedVariablePrefixes()
+	err = hasInvalidVariables(policy[0], false)
+	assert.NilError(t, err)
+}
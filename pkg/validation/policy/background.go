@@ -11,6 +11,7 @@ import (
 var ForbiddenUserVariables = []*regexp.Regexp{
 	regexp.MustCompile(`[^\.](serviceAccountName)\b`),
 	regexp.MustCompile(`[^\.](serviceAccountNamespace)\b`),
+	regexp.MustCompile(`[^\.](serviceAccountNamespaceLabels)\b`),
 	regexp.MustCompile(`[^\.](request.userInfo)\b`),
 	regexp.MustCompile(`[^\.](request.roles)\b`),
 	regexp.MustCompile(`[^\.](request.clusterRoles)\b`),
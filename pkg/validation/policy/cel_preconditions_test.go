@@ -0,0 +1,54 @@
+package policy
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func Test_validateResources_CELPreconditions(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expression string
+		wantErr    bool
+	}{{
+		name:       "valid",
+		expression: "request.operation == 'CREATE' && has(object.metadata.labels)",
+	}, {
+		name:       "syntax error",
+		expression: "object.metadata.name ==",
+		wantErr:    true,
+	}, {
+		name:       "not a boolean",
+		expression: "object.metadata.name",
+		wantErr:    true,
+	}, {
+		name:       "undeclared variable",
+		expression: "params.enabled",
+		wantErr:    true,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := kyvernov1.Rule{
+				Name: "test",
+				MatchResources: kyvernov1.MatchResources{
+					Any: kyvernov1.ResourceFilters{{ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}}}},
+				},
+				CELPreconditions: []admissionregistrationv1alpha1.MatchCondition{{
+					Name:       "condition",
+					Expression: tc.expression,
+				}},
+			}
+			path, err := validateResources(field.NewPath("spec").Child("rules").Index(0), rule)
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+				assert.Equal(t, path, "celPreconditions")
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package policy
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+)
+
+func Test_validateRuleContext_CloudMetadata(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   kyvernov1.ContextEntry
+		wantErr bool
+	}{{
+		name: "valid",
+		entry: kyvernov1.ContextEntry{
+			Name:          "aws",
+			CloudMetadata: &kyvernov1.CloudMetadata{Provider: kyvernov1.CloudProviderAWS, JMESPath: "region"},
+		},
+	}, {
+		name: "invalid provider",
+		entry: kyvernov1.ContextEntry{
+			Name:          "cloud",
+			CloudMetadata: &kyvernov1.CloudMetadata{Provider: "OpenStack"},
+		},
+		wantErr: true,
+	}, {
+		name: "invalid jmespath",
+		entry: kyvernov1.ContextEntry{
+			Name:          "gcp",
+			CloudMetadata: &kyvernov1.CloudMetadata{Provider: kyvernov1.CloudProviderGCP, JMESPath: "region | "},
+		},
+		wantErr: true,
+	}, {
+		name: "multiple sources",
+		entry: kyvernov1.ContextEntry{
+			Name:          "azure",
+			CloudMetadata: &kyvernov1.CloudMetadata{Provider: kyvernov1.CloudProviderAzure},
+			ConfigMap:     &kyvernov1.ConfigMapReference{Name: "foo", Namespace: "bar"},
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRuleContext(kyvernov1.Rule{Context: []kyvernov1.ContextEntry{tt.entry}})
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
+func Test_validateNamespacedSecretReferences(t *testing.T) {
+	service := func(value string) kyvernov1.Rule {
+		return kyvernov1.Rule{Context: []kyvernov1.ContextEntry{{
+			Name: "cmdb",
+			APICall: &kyvernov1.APICall{
+				Service: &kyvernov1.ServiceCall{
+					URL:     "https://cmdb.example.com",
+					Headers: []kyvernov1.HTTPHeader{{Key: "Authorization", Value: value}},
+				},
+			},
+		}}}
+	}
+	assert.NilError(t, validateNamespacedSecretReferences(service("Bearer token")))
+	assert.Assert(t, validateNamespacedSecretReferences(service("vault://secret/data/cmdb#token")) != nil)
+	assert.Assert(t, validateNamespacedSecretReferences(service("k8s://default/cmdb#token")) != nil)
+	keys := func(publicKeys string) kyvernov1.Rule {
+		return kyvernov1.Rule{VerifyImages: []kyvernov1.ImageVerification{{
+			Attestors: []kyvernov1.AttestorSet{{
+				Entries: []kyvernov1.Attestor{{Keys: &kyvernov1.StaticKeyAttestor{PublicKeys: publicKeys}}},
+			}},
+		}}}
+	}
+	assert.NilError(t, validateNamespacedSecretReferences(keys("-----BEGIN PUBLIC KEY-----")))
+	assert.Assert(t, validateNamespacedSecretReferences(keys("vault://secret/data/cosign#key")) != nil)
+}
+
+func Test_validateNamespacedCloudMetadata(t *testing.T) {
+	assert.NilError(t, validateNamespacedCloudMetadata(kyvernov1.Rule{Context: []kyvernov1.ContextEntry{{
+		Name:      "config",
+		ConfigMap: &kyvernov1.ConfigMapReference{Name: "foo", Namespace: "bar"},
+	}}}))
+	assert.Assert(t, validateNamespacedCloudMetadata(kyvernov1.Rule{Context: []kyvernov1.ContextEntry{{
+		Name:          "aws",
+		CloudMetadata: &kyvernov1.CloudMetadata{Provider: kyvernov1.CloudProviderAWS},
+	}}}) != nil)
+}
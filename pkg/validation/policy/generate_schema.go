@@ -0,0 +1,149 @@
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/logging"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/openapi"
+	"sigs.k8s.io/kubectl-validate/pkg/openapiclient"
+	"sigs.k8s.io/kubectl-validate/pkg/validator"
+)
+
+// builtinsVersion is the Kubernetes version of the builtin schemas used when no cluster is available
+const builtinsVersion = "1.28"
+
+var fieldPathKey = regexp.MustCompile(`\[([^\]]*[^\]0-9][^\]]*)\]`)
+
+// useBuiltinSchemas returns true when the builtin schemas are used instead of the cluster OpenAPI schemas
+func useBuiltinSchemas(client dclient.Interface, mock bool) bool {
+	return mock || client == nil || client.GetKubeClient() == nil
+}
+
+// newOpenAPIClient returns a client for the cluster OpenAPI schemas, or the builtin schemas when running in mock mode
+func newOpenAPIClient(client dclient.Interface, mock bool) openapi.Client {
+	if useBuiltinSchemas(client, mock) {
+		return openapiclient.NewHardcodedBuiltins(builtinsVersion)
+	}
+	return client.GetKubeClient().Discovery().OpenAPIV3()
+}
+
+// newSchemaValidator returns a validator using the cluster OpenAPI schemas, or the builtin schemas when running in mock mode
+func newSchemaValidator(client dclient.Interface, mock bool) *validator.Validator {
+	v, err := validator.New(newOpenAPIClient(client, mock))
+	if err != nil {
+		logging.Error(err, "failed to create schema validator, generate data will not be validated")
+		return nil
+	}
+	return v
+}
+
+// validateGenerateSchema validates the data of a generate rule against the schema of the generated kind.
+// Fields whose value contains variables are not checked as their value is only known at runtime, kinds
+// without a known schema are ignored.
+func validateGenerateSchema(v *validator.Validator, generation kyvernov1.Generation) error {
+	if v == nil || generation.RawData == nil || generation.APIVersion == "" || generation.Kind == "" {
+		return nil
+	}
+	if regexVariables.MatchString(generation.APIVersion + generation.Kind) {
+		return nil
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(generation.RawData.Raw, &data); err != nil {
+		return nil
+	}
+	object := runtime.DeepCopyJSON(data)
+	object["apiVersion"] = generation.APIVersion
+	object["kind"] = generation.Kind
+	metadata, _ := object["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+		object["metadata"] = metadata
+	}
+	if _, ok := metadata["name"]; !ok {
+		metadata["name"] = generation.Name
+		if generation.Name == "" {
+			metadata["name"] = "generated"
+		}
+	}
+	if _, ok := metadata["namespace"]; !ok && generation.Namespace != "" {
+		metadata["namespace"] = generation.Namespace
+	}
+	variablePaths := sets.New[string]()
+	collectVariablePaths(object, "", variablePaths)
+	document, err := json.Marshal(object)
+	if err != nil {
+		return nil
+	}
+	_, parsed, err := v.Parse(document)
+	if err != nil {
+		// the kind is unknown to the schema source or a key contains a variable
+		if strings.Contains(err.Error(), "failed to retrieve validator") || strings.Contains(err.Error(), "{{") {
+			return nil
+		}
+		return err
+	}
+	if err := v.Validate(parsed); err != nil {
+		var statusErr *apierrors.StatusError
+		if !errors.As(err, &statusErr) || statusErr.ErrStatus.Details == nil {
+			return err
+		}
+		var messages []string
+		for _, cause := range statusErr.ErrStatus.Details.Causes {
+			// label and annotation errors are reported on the parent field with the invalid value in the message
+			if isVariablePath(normalizeFieldPath(cause.Field), variablePaths) || regexVariables.MatchString(cause.Message) {
+				continue
+			}
+			messages = append(messages, cause.Message)
+		}
+		if len(messages) != 0 {
+			return fmt.Errorf("invalid %s: %s", generation.Kind, strings.Join(messages, ", "))
+		}
+	}
+	return nil
+}
+
+// collectVariablePaths records the field paths of the string values containing variables
+func collectVariablePaths(value interface{}, path string, paths sets.Set[string]) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for k, v := range typed {
+			child := k
+			if path != "" {
+				child = path + "." + k
+			}
+			collectVariablePaths(v, child, paths)
+		}
+	case []interface{}:
+		for i, v := range typed {
+			collectVariablePaths(v, path+"["+strconv.Itoa(i)+"]", paths)
+		}
+	case string:
+		if regexVariables.MatchString(typed) {
+			paths.Insert(path)
+		}
+	}
+}
+
+// normalizeFieldPath converts map keys in a field path from the `[key]` to the `.key` notation
+func normalizeFieldPath(path string) string {
+	return fieldPathKey.ReplaceAllString(path, ".$1")
+}
+
+func isVariablePath(path string, paths sets.Set[string]) bool {
+	for p := range paths {
+		if path == p || strings.HasPrefix(path, p+".") || strings.HasPrefix(path, p+"[") {
+			return true
+		}
+	}
+	return false
+}
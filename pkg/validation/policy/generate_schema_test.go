@@ -0,0 +1,67 @@
+package policy
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func Test_validateGenerateSchema(t *testing.T) {
+	v := newSchemaValidator(nil, true)
+	assert.Assert(t, v != nil)
+	generation := func(apiVersion, kind, data string) kyvernov1.Generation {
+		return kyvernov1.Generation{
+			ResourceSpec: kyvernov1.ResourceSpec{
+				APIVersion: apiVersion,
+				Kind:       kind,
+				Name:       "{{request.object.metadata.name}}-default",
+				Namespace:  "{{request.object.metadata.name}}",
+			},
+			RawData: &apiextv1.JSON{Raw: []byte(data)},
+		}
+	}
+	tests := []struct {
+		name       string
+		generation kyvernov1.Generation
+		wantErr    bool
+	}{{
+		name:       "valid network policy",
+		generation: generation("networking.k8s.io/v1", "NetworkPolicy", `{"spec":{"podSelector":{},"policyTypes":["Ingress","Egress"]}}`),
+	}, {
+		name:       "unknown field",
+		generation: generation("networking.k8s.io/v1", "NetworkPolicy", `{"spec":{"podSelectr":{},"policyTypes":["Ingress"]}}`),
+		wantErr:    true,
+	}, {
+		name:       "invalid type",
+		generation: generation("v1", "ResourceQuota", `{"spec":{"hard":{"pods":"ten"},"scopes":"BestEffort"}}`),
+		wantErr:    true,
+	}, {
+		name:       "variable value",
+		generation: generation("v1", "Service", `{"spec":{"ports":[{"port":"{{request.object.spec.port}}"}],"selector":{"app":"{{request.object.metadata.name}}"}}}`),
+	}, {
+		name:       "variable labels",
+		generation: generation("v1", "ConfigMap", `{"metadata":{"labels":{"policy":"{{ policy }}","rule":"{{ rule }}"}},"data":{"a":"b"}}`),
+	}, {
+		name:       "invalid labels",
+		generation: generation("v1", "ConfigMap", `{"metadata":{"labels":{"policy":"not a label"}},"data":{"a":"b"}}`),
+		wantErr:    true,
+	}, {
+		name:       "unknown kind",
+		generation: generation("example.com/v1", "Unknown", `{"spec":{"foo":"bar"}}`),
+	}, {
+		name:       "variable kind",
+		generation: generation("v1", "{{request.object.kind}}", `{"spec":{"foo":"bar"}}`),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateGenerateSchema(v, tt.generation)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_validKinds(t *testing.T) {
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{})
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+	tests := []struct {
+		name    string
+		kinds   []string
+		missing []string
+	}{{
+		name:  "installed kinds",
+		kinds: []string{"ConfigMap", "apps/v1/Deployment"},
+	}, {
+		name:    "kind not installed yet",
+		kinds:   []string{"ConfigMap", "example.com/v1/Tenant"},
+		missing: []string{"example.com/v1/Tenant"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			missing, err := validKinds(tt.kinds, false, false, true, client)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.missing, missing)
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine/anchor"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"k8s.io/client-go/openapi"
+	"k8s.io/kube-openapi/pkg/spec3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// builtinDocuments holds the parsed builtin schemas, they never change and are shared by all policy validations
+var builtinDocuments sync.Map
+
+// patternSchemas resolves the OpenAPI schemas of the kinds matched by validate patterns,
+// group versions are parsed on demand
+type patternSchemas struct {
+	paths     []string
+	groups    map[string]openapi.GroupVersion
+	documents *sync.Map
+}
+
+func newPatternSchemas(client dclient.Interface, mock bool) *patternSchemas {
+	p := &patternSchemas{
+		documents: &sync.Map{},
+	}
+	if useBuiltinSchemas(client, mock) {
+		p.documents = &builtinDocuments
+	}
+	if groups, err := newOpenAPIClient(client, mock).Paths(); err == nil {
+		p.groups = groups
+		for path := range groups {
+			p.paths = append(p.paths, path)
+		}
+		// sorting puts the core group (api/v1) first
+		sort.Strings(p.paths)
+	}
+	return p
+}
+
+// schemaForKind returns the schema of a kind selector and the components used to resolve its references,
+// nil is returned for wildcards, subresources and kinds without a known schema
+func (p *patternSchemas) schemaForKind(kindSelector string) (*spec.Schema, map[string]*spec.Schema) {
+	group, version, kind, subresource := kubeutils.ParseKindSelector(kindSelector)
+	if subresource != "" || strings.ContainsAny(kind, "*?") || regexVariables.MatchString(kindSelector) {
+		return nil, nil
+	}
+	for _, path := range p.paths {
+		g, v := groupVersionForPath(path)
+		if !wildcard.Match(group, g) || !wildcard.Match(version, v) {
+			continue
+		}
+		document := p.document(path)
+		if document == nil || document.Components == nil {
+			continue
+		}
+		for _, schema := range document.Components.Schemas {
+			if hasGroupVersionKind(schema, g, v, kind) {
+				return schema, document.Components.Schemas
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (p *patternSchemas) document(path string) *spec3.OpenAPI {
+	if document, ok := p.documents.Load(path); ok {
+		return document.(*spec3.OpenAPI)
+	}
+	var document *spec3.OpenAPI
+	if data, err := p.groups[path].Schema("application/json"); err == nil {
+		var parsed spec3.OpenAPI
+		if err := json.Unmarshal(data, &parsed); err == nil {
+			document = &parsed
+		}
+	}
+	p.documents.Store(path, document)
+	return document
+}
+
+func groupVersionForPath(path string) (string, string) {
+	parts := strings.Split(path, "/")
+	if len(parts) == 2 && parts[0] == "api" {
+		return "", parts[1]
+	}
+	if len(parts) == 3 && parts[0] == "apis" {
+		return parts[1], parts[2]
+	}
+	return path, ""
+}
+
+func hasGroupVersionKind(schema *spec.Schema, group, version, kind string) bool {
+	gvks, _ := schema.Extensions["x-kubernetes-group-version-kind"].([]interface{})
+	for _, gvk := range gvks {
+		if gvk, ok := gvk.(map[string]interface{}); ok && gvk["group"] == group && gvk["version"] == version && gvk["kind"] == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveSchema follows the references of a schema
+func resolveSchema(schema *spec.Schema, components map[string]*spec.Schema) *spec.Schema {
+	for i := 0; schema != nil && i < 10; i++ {
+		ref := schema.Ref.String()
+		if ref == "" && len(schema.AllOf) == 1 && len(schema.Type) == 0 {
+			ref = schema.AllOf[0].Ref.String()
+		}
+		if ref == "" {
+			return schema
+		}
+		schema = components[strings.TrimPrefix(ref, "#/components/schemas/")]
+	}
+	return schema
+}
+
+// validatePatternSchema checks that the scalar values of a pattern have the type of the boolean and
+// numeric fields they are compared to, it returns the path of the first mismatching value
+func validatePatternSchema(path string, pattern interface{}, schema *spec.Schema, components map[string]*spec.Schema) (string, error) {
+	schema = resolveSchema(schema, components)
+	if schema == nil {
+		return "", nil
+	}
+	switch typed := pattern.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			name := key
+			if a := anchor.Parse(key); a != nil {
+				name = a.Key()
+			}
+			if strings.ContainsAny(name, "*?") || regexVariables.MatchString(name) {
+				continue
+			}
+			var property *spec.Schema
+			if p, ok := schema.Properties[name]; ok {
+				property = &p
+			} else if schema.AdditionalProperties != nil {
+				property = schema.AdditionalProperties.Schema
+			}
+			if property == nil {
+				continue
+			}
+			if path, err := validatePatternSchema(path+"."+key, typed[key], property, components); err != nil {
+				return path, err
+			}
+		}
+	case []interface{}:
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return "", nil
+		}
+		for i, item := range typed {
+			if path, err := validatePatternSchema(fmt.Sprintf("%s[%d]", path, i), item, schema.Items.Schema, components); err != nil {
+				return path, err
+			}
+		}
+	default:
+		if err := validatePatternValueType(typed, schema); err != nil {
+			return path, err
+		}
+	}
+	return "", nil
+}
+
+func validatePatternValueType(value interface{}, schema *spec.Schema) error {
+	if len(schema.Type) != 1 {
+		return nil
+	}
+	if intOrString, _ := schema.Extensions["x-kubernetes-int-or-string"].(bool); intOrString {
+		return nil
+	}
+	switch schema.Type[0] {
+	case "boolean":
+		switch typed := value.(type) {
+		case string:
+			if regexVariables.MatchString(typed) {
+				return nil
+			}
+			if b, err := strconv.ParseBool(typed); err == nil {
+				return fmt.Errorf("field is a boolean, use %t instead of the string %q", b, typed)
+			}
+		case float64, int64, int:
+			return fmt.Errorf("field is a boolean, %v is a number", typed)
+		}
+	case "integer", "number":
+		if typed, ok := value.(bool); ok {
+			return fmt.Errorf("field is a number, %t is a boolean", typed)
+		}
+	}
+	return nil
+}
+
+// validatePatternTypes checks the patterns of a validate rule against the schemas of the kinds it matches
+func validatePatternTypes(schemas *patternSchemas, rule kyvernov1.Rule) (string, error) {
+	var paths []string
+	var patterns []interface{}
+	if pattern := rule.Validation.GetPattern(); pattern != nil {
+		paths = append(paths, "pattern")
+		patterns = append(patterns, pattern)
+	}
+	if anyPattern, ok := rule.Validation.GetAnyPattern().([]interface{}); ok {
+		for i, pattern := range anyPattern {
+			paths = append(paths, fmt.Sprintf("anyPattern[%d]", i))
+			patterns = append(patterns, pattern)
+		}
+	}
+	for _, kind := range rule.MatchResources.GetKinds() {
+		schema, components := schemas.schemaForKind(kind)
+		if schema == nil {
+			continue
+		}
+		for i := range patterns {
+			if path, err := validatePatternSchema(paths[i], patterns[i], schema, components); err != nil {
+				return path, err
+			}
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,80 @@
+package policy
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func Test_validatePatternTypes(t *testing.T) {
+	schemas := newPatternSchemas(nil, true)
+	rule := func(kind, pattern, anyPattern string) kyvernov1.Rule {
+		rule := kyvernov1.Rule{
+			Name: "check",
+			MatchResources: kyvernov1.MatchResources{
+				Any: kyvernov1.ResourceFilters{{
+					ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{kind}},
+				}},
+			},
+		}
+		if pattern != "" {
+			rule.Validation.RawPattern = &apiextv1.JSON{Raw: []byte(pattern)}
+		}
+		if anyPattern != "" {
+			rule.Validation.RawAnyPattern = &apiextv1.JSON{Raw: []byte(anyPattern)}
+		}
+		return rule
+	}
+	tests := []struct {
+		name     string
+		rule     kyvernov1.Rule
+		wantPath string
+	}{{
+		name: "boolean",
+		rule: rule("Pod", `{"spec":{"=(hostNetwork)":false,"containers":[{"securityContext":{"allowPrivilegeEscalation":false}}]}}`, ""),
+	}, {
+		name:     "string boolean",
+		rule:     rule("Pod", `{"spec":{"containers":[{"securityContext":{"allowPrivilegeEscalation":"false"}}]}}`, ""),
+		wantPath: "pattern.spec.containers[0].securityContext.allowPrivilegeEscalation",
+	}, {
+		name:     "string boolean in anchor",
+		rule:     rule("v1/Pod", `{"spec":{"=(hostPID)":"true"}}`, ""),
+		wantPath: "pattern.spec.=(hostPID)",
+	}, {
+		name:     "number boolean",
+		rule:     rule("Pod", "", `[{"spec":{"hostIPC":false}},{"spec":{"hostIPC":0}}]`),
+		wantPath: "anyPattern[1].spec.hostIPC",
+	}, {
+		name:     "boolean integer",
+		rule:     rule("apps/v1/Deployment", `{"spec":{"replicas":true}}`, ""),
+		wantPath: "pattern.spec.replicas",
+	}, {
+		name: "integer operators",
+		rule: rule("Deployment", `{"spec":{"replicas":">1","template":{"spec":{"containers":[{"ports":[{"containerPort":"1024-65535"}]}]}}}}`, ""),
+	}, {
+		name: "wildcards and variables",
+		rule: rule("Pod", `{"spec":{"hostNetwork":"{{ allowed }}","containers":[{"securityContext":{"privileged":"?*"}}]},"metadata":{"labels":{"app*":"true"}}}`, ""),
+	}, {
+		name: "int or string",
+		rule: rule("Service", `{"spec":{"ports":[{"targetPort":true}]}}`, ""),
+	}, {
+		name: "unknown kind",
+		rule: rule("example.com/v1/Unknown", `{"spec":{"enabled":"false"}}`, ""),
+	}, {
+		name: "wildcard kind",
+		rule: rule("*", `{"spec":{"hostNetwork":"false"}}`, ""),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := validatePatternTypes(schemas, tt.rule)
+			if tt.wantPath != "" {
+				assert.Assert(t, err != nil)
+				assert.Equal(t, path, tt.wantPath)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	"github.com/kyverno/kyverno/pkg/engine/anchor"
+)
+
+// checkAmbiguousPatterns returns warnings for the combinations of anchors and wildcards in validation
+// patterns that are valid but rarely behave as intended
+func checkAmbiguousPatterns(idx int, validation *kyvernov1.Validation) []string {
+	var warnings []string
+	path := fmt.Sprintf("spec.rules[%d].validate", idx)
+	warnings = append(warnings, checkPatternAnchors(path+".pattern", validation.GetPattern())...)
+	if anyPattern, ok := validation.GetAnyPattern().([]interface{}); ok {
+		for i, pattern := range anyPattern {
+			warnings = append(warnings, checkPatternAnchors(fmt.Sprintf("%s.anyPattern[%d]", path, i), pattern)...)
+		}
+	}
+	for i := range validation.ForEachValidation {
+		foreach := &validation.ForEachValidation[i]
+		foreachPath := fmt.Sprintf("%s.foreach[%d]", path, i)
+		warnings = append(warnings, checkPatternAnchors(foreachPath+".pattern", foreach.GetPattern())...)
+		if anyPattern, ok := foreach.GetAnyPattern().([]interface{}); ok {
+			for j, pattern := range anyPattern {
+				warnings = append(warnings, checkPatternAnchors(fmt.Sprintf("%s.anyPattern[%d]", foreachPath, j), pattern)...)
+			}
+		}
+	}
+	return warnings
+}
+
+func checkPatternAnchors(path string, pattern interface{}) []string {
+	var warnings []string
+	switch typed := pattern.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var anchors, wildcards []string
+		for _, key := range keys {
+			a := anchor.Parse(key)
+			if a == nil {
+				if wildcard.ContainsWildcard(key) {
+					wildcards = append(wildcards, key)
+				}
+			} else {
+				anchors = append(anchors, key)
+				if wildcard.ContainsWildcard(a.Key()) {
+					warnings = append(warnings, fmt.Sprintf("%s: anchor %q uses a wildcard key and only applies to one of "+
+						"the matching keys of the resource. Use one anchor per key instead, e.g. %q.", path, key, anchor.String(a.Type(), "app.kubernetes.io/name")))
+				}
+				if anchor.IsCondition(a) && isListOfMaps(typed[key]) {
+					warnings = append(warnings, fmt.Sprintf("%s.%s: conditional anchor %q is applied to a list of maps, the condition "+
+						"is only met when every element matches. To check the elements matching a condition, put the conditional "+
+						"anchor inside the elements instead, e.g. \"%s: [{(name): nginx, image: nginx:1.*}]\", or use %q if the "+
+						"list is optional.", path, key, key, a.Key(), anchor.String(anchor.Equality, a.Key())))
+				}
+			}
+			warnings = append(warnings, checkPatternAnchors(path+"."+key, typed[key])...)
+		}
+		if len(wildcards) != 0 && len(anchors) != 0 {
+			warnings = append(warnings, fmt.Sprintf("%s: wildcard keys [%s] are used beside anchors [%s], a wildcard key is replaced "+
+				"by one of the matching keys of the resource so the anchors may be evaluated against unexpected values. Move the "+
+				"condition to the rule preconditions, e.g. \"{{ request.object.metadata.labels.app || '' }}\", or split the checks "+
+				"with anyPattern.", path, strings.Join(wildcards, ", "), strings.Join(anchors, ", ")))
+		}
+	case []interface{}:
+		for i, element := range typed {
+			warnings = append(warnings, checkPatternAnchors(fmt.Sprintf("%s[%d]", path, i), element)...)
+		}
+	}
+	return warnings
+}
+
+func isListOfMaps(value interface{}) bool {
+	list, ok := value.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, element := range list {
+		if _, ok := element.(map[string]interface{}); ok {
+			return true
+		}
+	}
+	return false
+}
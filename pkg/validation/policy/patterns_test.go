@@ -0,0 +1,73 @@
+package policy
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func Test_checkAmbiguousPatterns(t *testing.T) {
+	tests := []struct {
+		name       string
+		pattern    string
+		anyPattern string
+		foreach    string
+		want       []string
+	}{{
+		name:    "no anchors",
+		pattern: `{"spec":{"containers":[{"image":"!*:latest"}]}}`,
+	}, {
+		name:    "conditional anchor inside list elements",
+		pattern: `{"spec":{"containers":[{"(name)":"nginx","image":"nginx:1.*"}]}}`,
+	}, {
+		name:    "equality anchor on a list of maps",
+		pattern: `{"spec":{"=(volumes)":[{"X(hostPath)":"null"}]}}`,
+	}, {
+		name:    "conditional anchor on a list of maps",
+		pattern: `{"spec":{"(containers)":[{"name":"nginx"}],"hostNetwork":false}}`,
+		want:    []string{`spec.rules[0].validate.pattern.spec.(containers): conditional anchor "(containers)" is applied to a list of maps`},
+	}, {
+		name:    "conditional anchor on a list of strings",
+		pattern: `{"spec":{"(finalizers)":["kubernetes"],"hostNetwork":false}}`,
+	}, {
+		name:    "wildcard keys beside anchors",
+		pattern: `{"metadata":{"labels":{"(app)":"nginx","team-*":"?*"}}}`,
+		want:    []string{`spec.rules[0].validate.pattern.metadata.labels: wildcard keys [team-*] are used beside anchors [(app)]`},
+	}, {
+		name:    "wildcard keys without anchors",
+		pattern: `{"metadata":{"labels":{"app.kubernetes.io/*":"?*"}}}`,
+	}, {
+		name:       "anchor with a wildcard key",
+		anyPattern: `[{"metadata":{"labels":{"=(app.kubernetes.io/*)":"?*"}}},{"spec":{"hostNetwork":false}}]`,
+		want:       []string{`spec.rules[0].validate.anyPattern[0].metadata.labels: anchor "=(app.kubernetes.io/*)" uses a wildcard key`},
+	}, {
+		name:    "foreach pattern",
+		foreach: `{"list":"request.object.spec.containers","pattern":{"(securityContext)":[{"privileged":false}]}}`,
+		want:    []string{`spec.rules[0].validate.foreach[0].pattern.(securityContext): conditional anchor "(securityContext)"`},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validation kyvernov1.Validation
+			if tt.pattern != "" {
+				validation.RawPattern = &apiextv1.JSON{Raw: []byte(tt.pattern)}
+			}
+			if tt.anyPattern != "" {
+				validation.RawAnyPattern = &apiextv1.JSON{Raw: []byte(tt.anyPattern)}
+			}
+			if tt.foreach != "" {
+				var foreach kyvernov1.ForEachValidation
+				assert.NilError(t, json.Unmarshal([]byte(tt.foreach), &foreach))
+				validation.ForEachValidation = []kyvernov1.ForEachValidation{foreach}
+			}
+			warnings := checkAmbiguousPatterns(0, &validation)
+			assert.Equal(t, len(tt.want), len(warnings), strings.Join(warnings, "\n"))
+			for i := range tt.want {
+				assert.Assert(t, strings.HasPrefix(warnings[i], tt.want[i]), warnings[i])
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+)
+
+type HighChurnKindsAction string
+
+const (
+	// HighChurnKindsWarn returns a warning when a policy matches high churn kinds without explicit intent
+	HighChurnKindsWarn HighChurnKindsAction = "warn"
+	// HighChurnKindsDeny rejects policies matching high churn kinds without explicit intent
+	HighChurnKindsDeny HighChurnKindsAction = "deny"
+	// HighChurnKindsIgnore disables the high churn kinds check
+	HighChurnKindsIgnore HighChurnKindsAction = "ignore"
+)
+
+// highChurnKinds are kinds created and updated at a high rate by the control plane,
+// sending them to the webhooks can degrade the cluster stability
+var highChurnKinds = []string{"Event", "Lease", "EndpointSlice"}
+
+var highChurnKindsAction = HighChurnKindsWarn
+
+// SetHighChurnKindsAction configures how policies matching high churn kinds through wildcards are handled.
+func SetHighChurnKindsAction(action string) error {
+	switch a := HighChurnKindsAction(strings.ToLower(strings.TrimSpace(action))); a {
+	case HighChurnKindsWarn, HighChurnKindsDeny, HighChurnKindsIgnore:
+		highChurnKindsAction = a
+		return nil
+	default:
+		return fmt.Errorf("invalid high churn kinds action %q, must be one of warn, deny or ignore", action)
+	}
+}
+
+// checkHighChurnKinds returns a message for every rule matching high churn kinds through wildcards.
+// Listing a high churn kind explicitly in the match or exclude block of the rule is considered as an
+// explicit intent and silences the check for this kind.
+func checkHighChurnKinds(rules []kyvernov1.Rule) []string {
+	if highChurnKindsAction == HighChurnKindsIgnore {
+		return nil
+	}
+	var msgs []string
+	for i, rule := range rules {
+		explicit := map[string]bool{}
+		for _, kind := range append(rule.MatchResources.GetKinds(), rule.ExcludeResources.GetKinds()...) {
+			if k := kindName(kind); !strings.ContainsAny(k, "*?") {
+				explicit[k] = true
+			}
+		}
+		var matched []string
+		for _, churnKind := range highChurnKinds {
+			if explicit[churnKind] {
+				continue
+			}
+			for _, kind := range rule.MatchResources.GetKinds() {
+				_, k := kubeutils.GetKindFromGVK(kind)
+				// subresources of high churn kinds are not a concern
+				if k, subresource := kubeutils.SplitSubresource(k); subresource != "" && subresource != "*" {
+					continue
+				} else if strings.ContainsAny(k, "*?") && wildcard.Match(k, churnKind) {
+					matched = append(matched, churnKind)
+					break
+				}
+			}
+		}
+		if len(matched) != 0 {
+			msgs = append(msgs, fmt.Sprintf(
+				"spec.rules[%d]: rule %s matches high churn kinds %s through wildcards, list them explicitly in match or exclude to confirm the webhook scope",
+				i, rule.Name, strings.Join(matched, ", "),
+			))
+		}
+	}
+	return msgs
+}
+
+func kindName(kind string) string {
+	_, k := kubeutils.GetKindFromGVK(kind)
+	k, _ = kubeutils.SplitSubresource(k)
+	return k
+}
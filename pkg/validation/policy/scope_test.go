@@ -0,0 +1,75 @@
+package policy
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+)
+
+func Test_checkHighChurnKinds(t *testing.T) {
+	rule := func(match []string, exclude []string) kyvernov1.Rule {
+		return kyvernov1.Rule{
+			Name: "test",
+			MatchResources: kyvernov1.MatchResources{
+				Any: kyvernov1.ResourceFilters{{ResourceDescription: kyvernov1.ResourceDescription{Kinds: match}}},
+			},
+			ExcludeResources: kyvernov1.MatchResources{
+				ResourceDescription: kyvernov1.ResourceDescription{Kinds: exclude},
+			},
+		}
+	}
+	testCases := []struct {
+		name   string
+		action HighChurnKindsAction
+		rule   kyvernov1.Rule
+		want   int
+	}{{
+		name: "explicit kinds",
+		rule: rule([]string{"Pod", "Event"}, nil),
+	}, {
+		name: "wildcard",
+		rule: rule([]string{"*"}, nil),
+		want: 1,
+	}, {
+		name: "group version wildcard",
+		rule: rule([]string{"coordination.k8s.io/v1/*"}, nil),
+		want: 1,
+	}, {
+		name: "wildcard not matching",
+		rule: rule([]string{"Pod*"}, nil),
+	}, {
+		name: "partial wildcard",
+		rule: rule([]string{"Endpoint*"}, nil),
+		want: 1,
+	}, {
+		name: "explicitly listed",
+		rule: rule([]string{"*", "Event", "Lease"}, []string{"EndpointSlice"}),
+	}, {
+		name: "subresource",
+		rule: rule([]string{"*/status"}, nil),
+	}, {
+		name:   "ignored",
+		action: HighChurnKindsIgnore,
+		rule:   rule([]string{"*"}, nil),
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			action := tc.action
+			if action == "" {
+				action = HighChurnKindsWarn
+			}
+			assert.NilError(t, SetHighChurnKindsAction(string(action)))
+			defer SetHighChurnKindsAction(string(HighChurnKindsWarn))
+			msgs := checkHighChurnKinds([]kyvernov1.Rule{tc.rule})
+			assert.Equal(t, len(msgs), tc.want)
+		})
+	}
+}
+
+func Test_SetHighChurnKindsAction(t *testing.T) {
+	assert.NilError(t, SetHighChurnKindsAction("Deny"))
+	assert.Equal(t, highChurnKindsAction, HighChurnKindsDeny)
+	assert.ErrorContains(t, SetHighChurnKindsAction("block"), "invalid high churn kinds action")
+	assert.NilError(t, SetHighChurnKindsAction(string(HighChurnKindsWarn)))
+}
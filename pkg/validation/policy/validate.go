@@ -1,6 +1,7 @@
 package policy
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -23,29 +24,53 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
 	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/secrets"
+	"github.com/kyverno/kyverno/pkg/toggle"
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
+	celutils "github.com/kyverno/kyverno/pkg/utils/cel"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apiserver/pkg/admission/plugin/cel"
 	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/kubectl-validate/pkg/validator"
 )
 
 var (
-	allowedVariables                   = enginecontext.ReservedKeys
-	allowedVariablesBackground         = regexp.MustCompile(`request\.|element|elementIndex|@|images|images\.|image\.|([a-z_0-9]+\()[^{}]`)
-	allowedVariablesInTarget           = regexp.MustCompile(`request\.|serviceAccountName|serviceAccountNamespace|element|elementIndex|@|images|images\.|image\.|target\.|([a-z_0-9]+\()[^{}]`)
-	allowedVariablesBackgroundInTarget = regexp.MustCompile(`request\.|element|elementIndex|@|images|images\.|image\.|target\.|([a-z_0-9]+\()[^{}]`)
+	allowedVariables                   = variableRoots(`request\b|serviceAccountName\b|serviceAccountNamespace\b|serviceAccountNamespaceLabels\b|element[0-9]*\b|elementIndex[0-9]*\b|@|images\b|image\b|policy\b|rule\b|ruleType\b`)
+	allowedVariablesBackground         = variableRoots(`request\.|element[0-9]*\b|elementIndex[0-9]*\b|@|images\b|image\.|policy\b|rule\b|ruleType\b`)
+	allowedVariablesInTarget           = variableRoots(`request\.|serviceAccountName\b|serviceAccountNamespace\b|serviceAccountNamespaceLabels\b|element[0-9]*\b|elementIndex[0-9]*\b|@|images\b|image\.|target\.|policy\b|rule\b|ruleType\b`)
+	allowedVariablesBackgroundInTarget = variableRoots(`request\.|element[0-9]*\b|elementIndex[0-9]*\b|@|images\b|image\.|target\.|policy\b|rule\b|ruleType\b`)
 	regexVariables                     = regexp.MustCompile(`\{\{[^{}]*\}\}`)
 	// wildCardAllowedVariables represents regex for the allowed fields in wildcards
 	wildCardAllowedVariables = regexp.MustCompile(`\{\{\s*(request\.|serviceAccountName|serviceAccountNamespace)[^{}]*\}\}`)
 	errOperationForbidden    = errors.New("variables are forbidden in the path of a JSONPatch")
+	// allowedVariablePrefixes holds additional variable prefixes accepted by the validator
+	allowedVariablePrefixes []string
 )
 
 var allowedJsonPatch = regexp.MustCompile("^/")
 
+// variableRoots returns a regex matching variables whose root is one of the given alternatives,
+// a JMESPath function call or a literal. The root is anchored at the start of the expression so
+// that typos like {{reqest.object}} are not accepted because they happen to contain a known key.
+func variableRoots(roots string) *regexp.Regexp {
+	return regexp.MustCompile(`^[\s\[(!]*(?:\{\s*\w+:\s*)?(?:` + roots + "|[a-z_0-9]+\\(|['`])")
+}
+
+// SetAllowedVariablePrefixes configures additional variable prefixes accepted when validating policies.
+func SetAllowedVariablePrefixes(prefixes ...string) {
+	allowedVariablePrefixes = nil
+	for _, prefix := range prefixes {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			allowedVariablePrefixes = append(allowedVariablePrefixes, prefix)
+		}
+	}
+}
+
 // validateJSONPatchPathForForwardSlash checks for forward slash
 func validateJSONPatchPathForForwardSlash(patch string) error {
 	// Replace all variables in PatchesJSON6902, all variable checks should have happened already.
@@ -211,39 +236,69 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 	rules := autogen.ComputeRules(policy)
 	rulesPath := specPath.Child("rules")
 
+	if msgs := checkHighChurnKinds(rules); len(msgs) != 0 {
+		if highChurnKindsAction == HighChurnKindsDeny {
+			return warnings, errors.New(strings.Join(msgs, "; "))
+		}
+		warnings = append(warnings, msgs...)
+	}
+
 	for i, rule := range rules {
+		// kinds unknown to discovery don't fail the validation, the rule stays pending until their CRDs are installed
+		checkKinds := func(path string, kinds []string) error {
+			missing, err := validateKinds(kinds, rule, mock, background, client)
+			if err != nil {
+				return fmt.Errorf("path: %s: %v", path, err)
+			}
+			for _, kind := range missing {
+				warnings = append(warnings, fmt.Sprintf("%s: kind %s is not installed, the rule is pending until its CRD is available", path, kind))
+			}
+			return nil
+		}
 		match := rule.MatchResources
 		exclude := rule.ExcludeResources
 		for j, value := range match.Any {
-			if err := validateKinds(value.ResourceDescription.Kinds, rule, mock, background, client); err != nil {
-				return warnings, fmt.Errorf("path: spec.rules[%d].match.any[%d].kinds: %v", i, j, err)
+			if err := checkKinds(fmt.Sprintf("spec.rules[%d].match.any[%d].kinds", i, j), value.ResourceDescription.Kinds); err != nil {
+				return warnings, err
 			}
 		}
 		for j, value := range match.All {
-			if err := validateKinds(value.ResourceDescription.Kinds, rule, mock, background, client); err != nil {
-				return warnings, fmt.Errorf("path: spec.rules[%d].match.all[%d].kinds: %v", i, j, err)
+			if err := checkKinds(fmt.Sprintf("spec.rules[%d].match.all[%d].kinds", i, j), value.ResourceDescription.Kinds); err != nil {
+				return warnings, err
 			}
 		}
 		for j, value := range exclude.Any {
-			if err := validateKinds(value.ResourceDescription.Kinds, rule, mock, background, client); err != nil {
-				return warnings, fmt.Errorf("path: spec.rules[%d].exclude.any[%d].kinds: %v", i, j, err)
+			if err := checkKinds(fmt.Sprintf("spec.rules[%d].exclude.any[%d].kinds", i, j), value.ResourceDescription.Kinds); err != nil {
+				return warnings, err
 			}
 		}
 		for j, value := range exclude.All {
-			if err := validateKinds(value.ResourceDescription.Kinds, rule, mock, background, client); err != nil {
-				return warnings, fmt.Errorf("path: spec.rules[%d].exclude.all[%d].kinds: %v", i, j, err)
+			if err := checkKinds(fmt.Sprintf("spec.rules[%d].exclude.all[%d].kinds", i, j), value.ResourceDescription.Kinds); err != nil {
+				return warnings, err
 			}
 		}
 
-		if err := validateKinds(rule.MatchResources.Kinds, rule, mock, background, client); err != nil {
-			return warnings, fmt.Errorf("path: spec.rules[%d].match.kinds: %v", i, err)
+		if err := checkKinds(fmt.Sprintf("spec.rules[%d].match.kinds", i), rule.MatchResources.Kinds); err != nil {
+			return warnings, err
 		}
 
-		if err := validateKinds(rule.ExcludeResources.Kinds, rule, mock, background, client); err != nil {
-			return warnings, fmt.Errorf("path: spec.rules[%d].exclude.kinds: %v", i, err)
+		if err := checkKinds(fmt.Sprintf("spec.rules[%d].exclude.kinds", i), rule.ExcludeResources.Kinds); err != nil {
+			return warnings, err
+		}
+
+		if !mock {
+			for _, resource := range rule.MatchResources.GetResources() {
+				if _, err := dclient.FindResourcesByName(client.Discovery(), resource); dclient.IsResourceNotFound(err) {
+					warnings = append(warnings, fmt.Sprintf("spec.rules[%d].match: resource %s is not installed, the rule is pending until its CRD is available", i, resource))
+				} else if err != nil {
+					return warnings, fmt.Errorf("path: spec.rules[%d].match: unable to find resource %s: %v", i, resource, err)
+				}
+			}
 		}
 	}
 
+	var schemaValidator *validator.Validator
+	var schemas *patternSchemas
 	for i, rule := range rules {
 		rulePath := rulesPath.Index(i)
 		// check for forward slash
@@ -275,31 +330,62 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 			return warnings, fmt.Errorf("path: spec.rules[%d]: %v", i, err)
 		}
 
+		if rule.Validation.Rego != nil && !toggle.FromContext(context.TODO()).EnableRegoRules() {
+			warnings = append(warnings, fmt.Sprintf("spec.rules[%d].validate.rego: rego rules are disabled, the rule is skipped until the %s flag is set", i, toggle.EnableRegoRulesFlagName))
+		}
+
+		if policy.IsNamespaced() {
+			if err := validateNamespacedSecretReferences(rule); err != nil {
+				return warnings, fmt.Errorf("path: spec.rules[%d]: %v", i, err)
+			}
+			if err := validateNamespacedCloudMetadata(rule); err != nil {
+				return warnings, fmt.Errorf("path: spec.rules[%d]: %v", i, err)
+			}
+		}
+
 		if err := validateRuleImageExtractorsJMESPath(rule); err != nil {
 			return warnings, fmt.Errorf("path: spec.rules[%d]: %v", i, err)
 		}
 
+		if rule.HasGenerate() && rule.Generation.RawData != nil {
+			if schemaValidator == nil {
+				schemaValidator = newSchemaValidator(client, mock)
+			}
+			if err := validateGenerateSchema(schemaValidator, rule.Generation); err != nil {
+				return warnings, fmt.Errorf("path: spec.rules[%d].generate.data: %v", i, err)
+			}
+		}
+
+		if rule.HasValidate() && (rule.Validation.GetPattern() != nil || rule.Validation.GetAnyPattern() != nil) {
+			if schemas == nil {
+				schemas = newPatternSchemas(client, mock)
+			}
+			if path, err := validatePatternTypes(schemas, rule); err != nil {
+				return warnings, fmt.Errorf("path: spec.rules[%d].validate.%s: %v", i, path, err)
+			}
+		}
+
 		// If a rule's match block does not match any kind,
 		// we should only allow it to have metadata in its overlay
 		if len(rule.MatchResources.Any) > 0 {
 			for _, rmr := range rule.MatchResources.Any {
-				if len(rmr.Kinds) == 0 {
+				if len(rmr.Kinds) == 0 && len(rmr.Resources) == 0 {
 					return warnings, validateMatchKindHelper(rule)
 				}
 			}
 		} else if len(rule.MatchResources.All) > 0 {
 			for _, rmr := range rule.MatchResources.All {
-				if len(rmr.Kinds) == 0 {
+				if len(rmr.Kinds) == 0 && len(rmr.Resources) == 0 {
 					return warnings, validateMatchKindHelper(rule)
 				}
 			}
 		} else {
-			if len(rule.MatchResources.Kinds) == 0 {
+			if len(rule.MatchResources.Kinds) == 0 && len(rule.MatchResources.Resources) == 0 {
 				return warnings, validateMatchKindHelper(rule)
 			}
 		}
 
-		msg, err := validateActions(i, &rules[i], client, mock, username)
+		msg, err := validateActions(i, &rules[i], policy.GetNamespace(), client, mock, username)
 		if err != nil {
 			return warnings, err
 		} else {
@@ -365,6 +451,7 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 			}
 			checkForScaleSubresource(validationJson, allKinds, &warnings)
 			checkForStatusSubresource(validationJson, allKinds, &warnings)
+			warnings = append(warnings, checkAmbiguousPatterns(i, &rule.Validation)...)
 		}
 
 		if rule.HasMutate() {
@@ -588,6 +675,9 @@ func ruleWithoutPattern(ruleCopy *kyvernov1.Rule) *kyvernov1.Rule {
 func buildContext(rule *kyvernov1.Rule, background bool, target bool) *enginecontext.MockContext {
 	re := getAllowedVariables(background, target)
 	ctx := enginecontext.NewMockContext(re)
+	for _, prefix := range allowedVariablePrefixes {
+		ctx.AddVariable(prefix + "*")
+	}
 	addContextVariables(rule.Context, ctx)
 	for _, fe := range rule.Validation.ForEachValidation {
 		addContextVariables(fe.Context, ctx)
@@ -617,7 +707,7 @@ func getAllowedVariables(background bool, target bool) *regexp.Regexp {
 
 func addContextVariables(entries []kyvernov1.ContextEntry, ctx *enginecontext.MockContext) {
 	for _, contextEntry := range entries {
-		if contextEntry.APICall != nil || contextEntry.ImageRegistry != nil || contextEntry.Variable != nil {
+		if contextEntry.APICall != nil || contextEntry.ImageRegistry != nil || contextEntry.CloudMetadata != nil || contextEntry.Variable != nil {
 			ctx.AddVariable(contextEntry.Name + "*")
 		}
 
@@ -716,9 +806,10 @@ func isLabelAndAnnotationsString(rule kyvernov1.Rule) bool {
 			}
 		} else {
 			patternMap, ok := rule.Validation.GetPattern().(map[string]interface{})
-			if ok {
-				return checkMetadata(patternMap)
-			} else if rule.Validation.GetAnyPattern() != nil {
+			if ok && !checkMetadata(patternMap) {
+				return false
+			}
+			if rule.Validation.GetAnyPattern() != nil {
 				anyPatterns, err := rule.Validation.DeserializeAnyPattern()
 				if err != nil {
 					logging.Error(err, "failed to deserialize anyPattern, expect type array")
@@ -816,7 +907,18 @@ func validateResources(path *field.Path, rule kyvernov1.Rule) (string, error) {
 	}
 
 	if len(rule.ExcludeResources.Any) > 0 && len(rule.ExcludeResources.All) > 0 {
-		return "match.", fmt.Errorf("can't specify any and all together")
+		return "exclude.", fmt.Errorf("can't specify any and all together")
+	}
+
+	for i, rer := range rule.ExcludeResources.Any {
+		if path, err := validateExcludedResourceFilter(rer); err != nil {
+			return fmt.Sprintf("exclude.any[%d]%s", i, path), err
+		}
+	}
+	for i, rer := range rule.ExcludeResources.All {
+		if path, err := validateExcludedResourceFilter(rer); err != nil {
+			return fmt.Sprintf("exclude.all[%d]%s", i, path), err
+		}
 	}
 
 	if len(rule.MatchResources.Any) > 0 {
@@ -846,6 +948,16 @@ func validateResources(path *field.Path, rule kyvernov1.Rule) (string, error) {
 			return fmt.Sprintf("validate.%s", path), err
 		}
 	}
+	// validating the CEL preconditions compile, if they exist
+	if len(rule.CELPreconditions) != 0 {
+		compiler, err := celutils.NewCompiler(nil, nil, rule.CELPreconditions, nil)
+		if err != nil {
+			return "celPreconditions", err
+		}
+		if errs := compiler.ValidateMatchExpressions(cel.OptionalVariableDeclarations{HasParams: rule.HasValidateCEL() && rule.Validation.CEL.HasParam(), HasAuthorizer: rule.HasValidateCEL()}); len(errs) != 0 {
+			return "celPreconditions", errors.Join(errs...)
+		}
+	}
 	// validating the values present under validate.conditions, if they exist
 	if rule.Validation.Deny != nil {
 		if target := rule.Validation.Deny.GetAnyAllConditions(); target != nil {
@@ -975,23 +1087,33 @@ func validateRuleContext(rule kyvernov1.Rule) error {
 		if entry.Name == "" {
 			return fmt.Errorf("a name is required for context entries")
 		}
-		for _, v := range []string{"images", "request", "serviceAccountName", "serviceAccountNamespace", "element", "elementIndex"} {
+		for _, v := range []string{"images", "request", "serviceAccountName", "serviceAccountNamespace", "serviceAccountNamespaceLabels", "element", "elementIndex", "policy", "rule", "ruleType"} {
 			if entry.Name == v || strings.HasPrefix(entry.Name, v+".") {
 				return fmt.Errorf("entry name %s is invalid as it conflicts with a pre-defined variable %s", entry.Name, v)
 			}
 		}
 
+		sources := 0
+		for _, set := range []bool{entry.ConfigMap != nil, entry.APICall != nil, entry.ImageRegistry != nil, entry.CloudMetadata != nil, entry.Variable != nil} {
+			if set {
+				sources++
+			}
+		}
+		if sources != 1 {
+			return fmt.Errorf("exactly one of configMap or apiCall or imageRegistry or cloudMetadata or variable is required for context entries")
+		}
+
 		var err error
-		if entry.ConfigMap != nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable == nil {
+		if entry.ConfigMap != nil {
 			err = validateConfigMap(entry)
-		} else if entry.ConfigMap == nil && entry.APICall != nil && entry.ImageRegistry == nil && entry.Variable == nil {
+		} else if entry.APICall != nil {
 			err = validateAPICall(entry)
-		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry != nil && entry.Variable == nil {
+		} else if entry.ImageRegistry != nil {
 			err = validateImageRegistry(entry)
-		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable != nil {
-			err = validateVariable(entry)
+		} else if entry.CloudMetadata != nil {
+			err = validateCloudMetadata(entry)
 		} else {
-			return fmt.Errorf("exactly one of configMap or apiCall or imageRegistry or variable is required for context entries")
+			err = validateVariable(entry)
 		}
 
 		if err != nil {
@@ -1081,6 +1203,18 @@ func validateAPICall(entry kyvernov1.ContextEntry) error {
 		}
 	}
 
+	if entry.APICall.PageSize < 0 || entry.APICall.MaxItems < 0 {
+		return fmt.Errorf("pageSize and maxItems must not be negative")
+	}
+
+	if entry.APICall.PageSize > 0 && (entry.APICall.URLPath == "" || entry.APICall.Method == "POST") {
+		return fmt.Errorf("pageSize can only be used for GET requests with a URLPath")
+	}
+
+	if entry.APICall.MaxItems > 0 && entry.APICall.PageSize == 0 {
+		return fmt.Errorf("maxItems can only be used with pageSize")
+	}
+
 	// If JMESPath contains variables, the validation will fail because it's not
 	// possible to infer which value will be inserted by the variable
 	// Skip validation if a variable is detected
@@ -1096,6 +1230,42 @@ func validateAPICall(entry kyvernov1.ContextEntry) error {
 	return nil
 }
 
+// validateNamespacedSecretReferences ensures the service calls and image verifications of a namespaced policy don't
+// reference secrets, these references are resolved with the identity of Kyverno and can only be used in cluster-wide policies
+func validateNamespacedSecretReferences(rule kyvernov1.Rule) error {
+	for _, entry := range rule.Context {
+		if entry.APICall == nil || entry.APICall.Service == nil {
+			continue
+		}
+		for _, header := range entry.APICall.Service.Headers {
+			if secrets.IsReference(header.Value) {
+				return fmt.Errorf("context entry %s: header %s references a secret, only cluster policies can reference secrets", entry.Name, header.Key)
+			}
+		}
+	}
+	for i, imageVerify := range rule.VerifyImages {
+		for _, attestorSet := range imageVerify.Attestors {
+			for _, attestor := range attestorSet.Entries {
+				if attestor.Keys != nil && secrets.IsVaultReference(strings.TrimSpace(attestor.Keys.PublicKeys)) {
+					return fmt.Errorf("verifyImages[%d]: public keys reference a Vault secret, only cluster policies can reference Vault secrets", i)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateNamespacedCloudMetadata ensures a namespaced policy has no cloudMetadata context entry, the cloud metadata
+// exposes cluster-wide information (like the IAM mappings of EKS clusters) and is restricted to cluster policies
+func validateNamespacedCloudMetadata(rule kyvernov1.Rule) error {
+	for _, entry := range rule.Context {
+		if entry.CloudMetadata != nil {
+			return fmt.Errorf("context entry %s: only cluster policies can use cloudMetadata context entries", entry.Name)
+		}
+	}
+	return nil
+}
+
 func validateImageRegistry(entry kyvernov1.ContextEntry) error {
 	if entry.ImageRegistry.Reference == "" {
 		return fmt.Errorf("a ref is required for imageRegistry context entry")
@@ -1125,6 +1295,27 @@ func validateImageRegistry(entry kyvernov1.ContextEntry) error {
 	return nil
 }
 
+func validateCloudMetadata(entry kyvernov1.ContextEntry) error {
+	switch entry.CloudMetadata.Provider {
+	case kyvernov1.CloudProviderAWS, kyvernov1.CloudProviderGCP, kyvernov1.CloudProviderAzure:
+	default:
+		return fmt.Errorf("invalid cloud provider %s for cloudMetadata context entry, must be one of AWS, GCP or Azure", entry.CloudMetadata.Provider)
+	}
+
+	// If JMESPath contains variables, the validation will fail because it's not possible to infer which value
+	// will be inserted by the variable
+	// Skip validation if a variable is detected
+	jmesPath := variables.ReplaceAllVars(entry.CloudMetadata.JMESPath, func(s string) string { return "kyvernojmespathvariable" })
+
+	if !strings.Contains(jmesPath, "kyvernojmespathvariable") && entry.CloudMetadata.JMESPath != "" {
+		if _, err := jmespath.NewParser().Parse(entry.CloudMetadata.JMESPath); err != nil {
+			return fmt.Errorf("failed to parse JMESPath %s: %v", entry.CloudMetadata.JMESPath, err)
+		}
+	}
+
+	return nil
+}
+
 // validateResourceDescription checks if all necessary fields are present and have values. Also checks a Selector.
 // field type is checked through openapi
 // Returns error if
@@ -1138,6 +1329,15 @@ func validateMatchedResourceDescription(rd kyvernov1.ResourceDescription) (strin
 	return "", nil
 }
 
+// validateExcludedResourceFilter checks an exclude any/all entry, an empty entry never excludes anything
+// and would silently disable the exclude all block it belongs to
+func validateExcludedResourceFilter(rer kyvernov1.ResourceFilter) (string, error) {
+	if rer.IsEmpty() {
+		return "", fmt.Errorf("exclude resources not specified")
+	}
+	return "", nil
+}
+
 // jsonPatchOnPod checks if a rule applies JSON patches to Pod
 func jsonPatchOnPod(rule kyvernov1.Rule) bool {
 	if !rule.HasMutate() {
@@ -1166,19 +1366,21 @@ func podControllerAutoGenExclusion(policy kyvernov1.PolicyInterface) bool {
 	return false
 }
 
-func validateKinds(kinds []string, rule kyvernov1.Rule, mock, background bool, client dclient.Interface) error {
+// validateKinds checks the kinds of a match or exclude block and returns the kinds unknown to discovery
+func validateKinds(kinds []string, rule kyvernov1.Rule, mock, background bool, client dclient.Interface) ([]string, error) {
 	if err := validateWildcard(kinds, background, rule); err != nil {
-		return err
+		return nil, err
 	}
 
 	if slices.Contains(kinds, "*") {
-		return nil
+		return nil, nil
 	}
 
-	if err := validKinds(kinds, mock, background, rule.HasValidate(), client); err != nil {
-		return fmt.Errorf("the kind defined in the all match resource is invalid: %w", err)
+	missing, err := validKinds(kinds, mock, background, rule.HasValidate(), client)
+	if err != nil {
+		return nil, fmt.Errorf("the kind defined in the all match resource is invalid: %w", err)
 	}
-	return nil
+	return missing, nil
 }
 
 // validateWildcard check for an Match/Exclude block contains "*"
@@ -1228,29 +1430,34 @@ func validateWildcard(kinds []string, background bool, rule kyvernov1.Rule) erro
 }
 
 // validKinds verifies if an API resource that matches 'kind' is valid kind
-// and found in the cache, returns error if not found. It also returns an error if background scanning
-// is enabled for a subresource.
-func validKinds(kinds []string, mock, backgroundScanningEnabled, isValidationPolicy bool, client dclient.Interface) error {
+// and found in the cache, kinds not found are returned as they may be installed later.
+// It returns an error if discovery fails or if background scanning is enabled for a subresource.
+func validKinds(kinds []string, mock, backgroundScanningEnabled, isValidationPolicy bool, client dclient.Interface) ([]string, error) {
+	var missing []string
 	if !mock {
 		for _, k := range kinds {
 			group, version, kind, subresource := kubeutils.ParseKindSelector(k)
 			gvrss, err := client.Discovery().FindResources(group, version, kind, subresource)
+			if dclient.IsResourceNotFound(err) {
+				missing = append(missing, k)
+				continue
+			}
 			if err != nil {
-				return fmt.Errorf("unable to convert GVK to GVR for kinds %s, err: %s", k, err)
+				return nil, fmt.Errorf("unable to convert GVK to GVR for kinds %s, err: %s", k, err)
 			}
 			if len(gvrss) == 0 {
-				return fmt.Errorf("unable to convert GVK to GVR for kinds %s", k)
+				return nil, fmt.Errorf("unable to convert GVK to GVR for kinds %s", k)
 			}
 			if isValidationPolicy && backgroundScanningEnabled {
 				for gvrs := range gvrss {
 					if gvrs.SubResource != "" {
-						return fmt.Errorf("background scan enabled with subresource %s", k)
+						return nil, fmt.Errorf("background scan enabled with subresource %s", k)
 					}
 				}
 			}
 		}
 	}
-	return nil
+	return missing, nil
 }
 
 func validateWildcardsWithNamespaces(enforce, audit, enforceW, auditW []string) error {
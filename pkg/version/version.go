@@ -4,6 +4,7 @@ import (
 	"runtime/debug"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/fips"
 )
 
 // BuildVersion is provided by govvv at compile-time
@@ -44,7 +45,25 @@ func Hash() string {
 	return "---"
 }
 
+// Info holds the version information of the running binary
+type Info struct {
+	Version string `json:"version"`
+	Hash    string `json:"hash"`
+	Time    string `json:"time"`
+	FIPS    bool   `json:"fips"`
+}
+
+// GetInfo returns the version information of the running binary
+func GetInfo() Info {
+	return Info{
+		Version: Version(),
+		Hash:    Hash(),
+		Time:    Time(),
+		FIPS:    fips.Enabled(),
+	}
+}
+
 // PrintVersionInfo displays the kyverno version - git version
 func PrintVersionInfo(log logr.Logger) {
-	log.Info("version", "version", Version(), "hash", Hash(), "time", Time())
+	log.Info("version", "version", Version(), "hash", Hash(), "time", Time(), "fips", fips.Enabled())
 }
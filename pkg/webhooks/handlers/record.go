@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Recorder records admission requests and the response sent back to the API server
+type Recorder interface {
+	Record(AdmissionRequest, AdmissionResponse)
+}
+
+func (inner AdmissionHandler) WithRecorder(recorder Recorder) AdmissionHandler {
+	if recorder == nil {
+		return inner
+	}
+	return inner.withRecorder(recorder).WithTrace("RECORD")
+}
+
+func (inner AdmissionHandler) withRecorder(recorder Recorder) AdmissionHandler {
+	return func(ctx context.Context, logger logr.Logger, request AdmissionRequest, startTime time.Time) AdmissionResponse {
+		response := inner(ctx, logger, request, startTime)
+		recorder.Record(request, response)
+		return response
+	}
+}
@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kyverno/kyverno/pkg/version"
+)
+
+func Version() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(version.GetInfo()); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
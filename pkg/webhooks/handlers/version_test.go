@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/fips"
+	"github.com/kyverno/kyverno/pkg/version"
+	"gotest.tools/assert"
+)
+
+func TestVersion(t *testing.T) {
+	version.BuildVersion = "test"
+	recorder := httptest.NewRecorder()
+	Version()(recorder, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var info version.Info
+	assert.NilError(t, json.Unmarshal(recorder.Body.Bytes(), &info))
+	assert.Equal(t, "test", info.Version)
+	assert.Equal(t, fips.Enabled(), info.FIPS)
+}
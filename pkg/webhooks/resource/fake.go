@@ -52,7 +52,7 @@ func NewFakeHandlers(ctx context.Context, policyCache policycache.Cache) webhook
 		urLister:      urLister,
 		urGenerator:   updaterequest.NewFake(),
 		eventGen:      event.NewFake(),
-		pcBuilder:     webhookutils.NewPolicyContextBuilder(configuration, jp),
+		pcBuilder:     webhookutils.NewPolicyContextBuilder(configuration, jp, informers.Core().V1().Namespaces().Lister()),
 		engine: engine.NewEngine(
 			configuration,
 			config.NewDefaultMetricsConfiguration(),
@@ -63,6 +63,9 @@ func NewFakeHandlers(ctx context.Context, policyCache policycache.Cache) webhook
 			factories.DefaultContextLoaderFactory(configMapResolver),
 			peLister,
 			"",
+			nil,
+			nil,
+			nil,
 		),
 	}
 }
@@ -114,6 +114,10 @@ func (h *generationHandler) handleTrigger(
 	policyContext *engine.PolicyContext,
 ) {
 	h.log.V(4).Info("handle trigger resource operation for generate", "policies", len(policies))
+	// the trigger is being deleted, downstream resources were handled on the delete request
+	if trigger := policyContext.NewResource(); request.Operation == admissionv1.Update && trigger.GetDeletionTimestamp() != nil {
+		return
+	}
 	for _, policy := range policies {
 		var appliedRules, failedRules []engineapi.RuleResponse
 		policyContext := policyContext.WithPolicy(policy)
@@ -57,6 +57,8 @@ type resourceHandlers struct {
 
 	admissionReports             bool
 	backgroundServiceAccountName string
+	latencyBudget                time.Duration
+	maxObjectSize                int64
 }
 
 func NewHandlers(
@@ -75,6 +77,8 @@ func NewHandlers(
 	admissionReports bool,
 	backgroundServiceAccountName string,
 	jp jmespath.Interface,
+	latencyBudget time.Duration,
+	maxObjectSize int64,
 ) webhooks.ResourceHandlers {
 	return &resourceHandlers{
 		engine:                       engine,
@@ -89,9 +93,11 @@ func NewHandlers(
 		polLister:                    polInformer.Lister(),
 		urGenerator:                  urGenerator,
 		eventGen:                     eventGen,
-		pcBuilder:                    webhookutils.NewPolicyContextBuilder(configuration, jp),
+		pcBuilder:                    webhookutils.NewPolicyContextBuilder(configuration, jp, nsLister),
 		admissionReports:             admissionReports,
 		backgroundServiceAccountName: backgroundServiceAccountName,
+		latencyBudget:                latencyBudget,
+		maxObjectSize:                maxObjectSize,
 	}
 }
 
@@ -107,6 +113,7 @@ func (h *resourceHandlers) Validate(ctx context.Context, logger logr.Logger, req
 	generatePolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.Generate, gvr, request.SubResource, request.Namespace)...)
 	imageVerifyValidatePolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.VerifyImagesValidate, gvr, request.SubResource, request.Namespace)...)
 	policies = append(policies, imageVerifyValidatePolicies...)
+	policies = webhookutils.SkipSameServiceAccountRequests(logger, request.UserInfo.Username, policies...)
 
 	if len(policies) == 0 && len(mutatePolicies) == 0 && len(generatePolicies) == 0 {
 		logger.V(4).Info("no policies matched admission request")
@@ -126,7 +133,7 @@ func (h *resourceHandlers) Validate(ctx context.Context, logger logr.Logger, req
 	policyContext = policyContext.WithNamespaceLabels(namespaceLabels)
 	vh := validation.NewValidationHandler(logger, h.kyvernoClient, h.engine, h.pCache, h.pcBuilder, h.eventGen, h.admissionReports, h.metricsConfig, h.configuration)
 
-	ok, msg, warnings := vh.HandleValidation(ctx, request, policies, policyContext, startTime)
+	ok, msg, warnings := vh.HandleValidation(withRuleTracer(h.withObjectSizeGuard(h.withLatencyBudget(ctx, startTime), logger, request), logger), request, policies, policyContext, startTime)
 	if !ok {
 		logger.Info("admission request denied")
 		return admissionutils.Response(request.UID, errors.New(msg), warnings...)
@@ -144,6 +151,8 @@ func (h *resourceHandlers) Mutate(ctx context.Context, logger logr.Logger, reque
 	gvr := schema.GroupVersionResource(request.Resource)
 	mutatePolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.Mutate, gvr, request.SubResource, request.Namespace)...)
 	verifyImagesPolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.VerifyImagesMutate, gvr, request.SubResource, request.Namespace)...)
+	mutatePolicies = webhookutils.SkipSameServiceAccountRequests(logger, request.UserInfo.Username, mutatePolicies...)
+	verifyImagesPolicies = webhookutils.SkipSameServiceAccountRequests(logger, request.UserInfo.Username, verifyImagesPolicies...)
 	if len(mutatePolicies) == 0 && len(verifyImagesPolicies) == 0 {
 		logger.V(4).Info("no policies matched mutate admission request")
 		return admissionutils.ResponseSuccess(request.UID)
@@ -155,7 +164,7 @@ func (h *resourceHandlers) Mutate(ctx context.Context, logger logr.Logger, reque
 		return admissionutils.Response(request.UID, err)
 	}
 	mh := mutation.NewMutationHandler(logger, h.engine, h.eventGen, h.nsLister, h.metricsConfig)
-	mutatePatches, mutateWarnings, err := mh.HandleMutation(ctx, request.AdmissionRequest, mutatePolicies, policyContext, startTime)
+	mutatePatches, mutateWarnings, err := mh.HandleMutation(withRuleTracer(ctx, logger), request.AdmissionRequest, mutatePolicies, policyContext, startTime)
 	if err != nil {
 		logger.Error(err, "mutation failed")
 		return admissionutils.Response(request.UID, err)
@@ -168,7 +177,7 @@ func (h *resourceHandlers) Mutate(ctx context.Context, logger logr.Logger, reque
 		return admissionutils.Response(request.UID, err)
 	}
 	ivh := imageverification.NewImageVerificationHandler(logger, h.kyvernoClient, h.engine, h.eventGen, h.admissionReports, h.configuration, h.nsLister)
-	imagePatches, imageVerifyWarnings, err := ivh.Handle(ctx, newRequest, verifyImagesPolicies, policyContext)
+	imagePatches, imageVerifyWarnings, err := ivh.Handle(withRuleTracer(h.withObjectSizeGuard(h.withLatencyBudget(ctx, startTime), logger, request), logger), newRequest, verifyImagesPolicies, policyContext)
 	if err != nil {
 		logger.Error(err, "image verification failed")
 		return admissionutils.Response(request.UID, err)
@@ -180,6 +189,37 @@ func (h *resourceHandlers) Mutate(ctx context.Context, logger logr.Logger, reque
 	return admissionutils.MutationResponse(request.UID, patch, warnings...)
 }
 
+// withLatencyBudget bounds the time spent evaluating audit rules, counted from the admission request start time
+func (h *resourceHandlers) withLatencyBudget(ctx context.Context, startTime time.Time) context.Context {
+	if h.latencyBudget <= 0 {
+		return ctx
+	}
+	return engineapi.WithLatencyBudget(ctx, startTime.Add(h.latencyBudget))
+}
+
+// withObjectSizeGuard flags admission objects larger than the max object size so that audit rules are skipped
+func (h *resourceHandlers) withObjectSizeGuard(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest) context.Context {
+	if h.maxObjectSize <= 0 {
+		return ctx
+	}
+	size := max(len(request.Object.Raw), len(request.OldObject.Raw))
+	if int64(size) <= h.maxObjectSize {
+		return ctx
+	}
+	logger.V(2).Info("admission object exceeds the max object size, audit rules will be skipped", "size", size, "maxObjectSize", h.maxObjectSize)
+	return engineapi.WithObjectSizeExceeded(ctx)
+}
+
+// withRuleTracer logs the decision trace of every evaluated rule when the debug log level is enabled
+func withRuleTracer(ctx context.Context, logger logr.Logger) context.Context {
+	if !logger.V(5).Enabled() {
+		return ctx
+	}
+	return engineapi.WithRuleTracer(ctx, func(trace engineapi.RuleTrace) {
+		logger.V(5).Info("rule trace", "policy", trace.Policy, "rule", trace.Rule, "type", trace.Type, "resource", trace.Resource, "steps", trace.Steps)
+	})
+}
+
 func filterPolicies(ctx context.Context, failurePolicy string, policies ...kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
 	var results []kyvernov1.PolicyInterface
 	for _, policy := range policies {
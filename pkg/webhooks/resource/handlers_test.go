@@ -7,6 +7,7 @@ import (
 	"time"
 
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	log "github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/policycache"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
@@ -430,3 +431,36 @@ func makeKey(policy kyverno.PolicyInterface) string {
 
 	return namespace + "/" + name
 }
+
+func Test_withObjectSizeGuard(t *testing.T) {
+	request := handlers.AdmissionRequest{
+		AdmissionRequest: v1.AdmissionRequest{
+			Object:    runtime.RawExtension{Raw: []byte(`{"metadata":{"name":"small"}}`)},
+			OldObject: runtime.RawExtension{Raw: []byte(`{"metadata":{"name":"large","annotations":{"data":"0123456789"}}}`)},
+		},
+	}
+	tests := []struct {
+		name          string
+		maxObjectSize int64
+		want          bool
+	}{{
+		name:          "disabled",
+		maxObjectSize: 0,
+		want:          false,
+	}, {
+		name:          "below limit",
+		maxObjectSize: 1024,
+		want:          false,
+	}, {
+		name:          "old object above limit",
+		maxObjectSize: 40,
+		want:          true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &resourceHandlers{maxObjectSize: tt.maxObjectSize}
+			ctx := h.withObjectSizeGuard(context.TODO(), log.GlobalLogger(), request)
+			assert.Equal(t, engineapi.ObjectSizeExceeded(ctx), tt.want)
+		})
+	}
+}
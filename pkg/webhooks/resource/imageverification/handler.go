@@ -110,7 +110,7 @@ func (h *imageVerificationHandler) handleVerifyImages(
 					engineResponses = append(engineResponses, resp)
 				}
 
-				patches = append(patches, resp.GetPatches()...)
+				patches = append(patches, patch.SortPatches(resp.GetPatches()...)...)
 				verifiedImageData.Merge(ivm)
 			},
 		)
@@ -122,7 +122,7 @@ func (h *imageVerificationHandler) handleVerifyImages(
 
 	if blocked {
 		logger.V(4).Info("admission request blocked")
-		return false, webhookutils.GetBlockedMessages(engineResponses), nil, nil
+		return false, webhookutils.GetBlockedMessages(engineResponses, h.cfg.GetDenialMessageFormat()), nil, nil
 	}
 
 	if !verifiedImageData.IsEmpty() {
@@ -139,7 +139,7 @@ func (h *imageVerificationHandler) handleVerifyImages(
 	go h.handleAudit(ctx, policyContext.NewResource(), request, nil, engineResponses...)
 
 	warnings := webhookutils.GetWarningMessages(engineResponses)
-	return true, "", jsonutils.JoinPatches(patch.ConvertPatches(patches...)...), warnings
+	return true, "", jsonutils.JoinPatches(patch.ConvertPatches(patch.MergeAnnotationPatches(patches...)...)...), warnings
 }
 
 func hasAnnotations(context *engine.PolicyContext) bool {
@@ -107,7 +107,7 @@ func (v *mutationHandler) applyMutations(
 				}
 
 				if len(policyPatches) > 0 {
-					patches = append(patches, policyPatches...)
+					patches = append(patches, patch.SortPatches(policyPatches...)...)
 					rules := engineResponse.GetSuccessRules()
 					if len(rules) != 0 {
 						v.log.Info("mutation rules from policy applied successfully", "policy", policy.GetName(), "rules", rules)
@@ -133,7 +133,7 @@ func (v *mutationHandler) applyMutations(
 	logMutationResponse(patches, engineResponses, v.log)
 
 	// patches holds all the successful patches, if no patch is created, it returns nil
-	return jsonutils.JoinPatches(patch.ConvertPatches(patches...)...), engineResponses, nil
+	return jsonutils.JoinPatches(patch.ConvertPatches(patch.MergeAnnotationPatches(patches...)...)...), engineResponses, nil
 }
 
 func (h *mutationHandler) applyMutation(ctx context.Context, request admissionv1.AdmissionRequest, policyContext *engine.PolicyContext, failurePolicy kyvernov1.FailurePolicyType) (*engineapi.EngineResponse, []jsonpatch.JsonPatchOperation, error) {
@@ -80,7 +80,12 @@ func (v *validationHandler) HandleValidation(
 
 	var engineResponses []engineapi.EngineResponse
 	failurePolicy := kyvernov1.Ignore
+	failFast := false
 	for _, policy := range policies {
+		if failFast {
+			logger.V(2).Info("validation failed in fail fast mode, skipping the remaining policies")
+			break
+		}
 		tracing.ChildSpan(
 			ctx,
 			"pkg/webhooks/resource/validate",
@@ -101,6 +106,7 @@ func (v *validationHandler) HandleValidation(
 				engineResponses = append(engineResponses, engineResponse)
 				if !engineResponse.IsSuccessful() {
 					logger.V(2).Info("validation failed", "action", policy.GetSpec().ValidationFailureAction, "policy", policy.GetName(), "failed rules", engineResponse.GetFailedRules())
+					failFast = engineResponse.IsEnforceFailed() && policy.GetSpec().GetFailFast(v.cfg.GetFailFast())
 					return
 				}
 
@@ -117,7 +123,7 @@ func (v *validationHandler) HandleValidation(
 
 	if blocked {
 		logger.V(4).Info("admission request blocked")
-		return false, webhookutils.GetBlockedMessages(engineResponses), nil
+		return false, webhookutils.GetBlockedMessages(engineResponses, v.cfg.GetDenialMessageFormat()), nil
 	}
 
 	go v.handleAudit(ctx, policyContext.NewResource(), request, policyContext.NamespaceLabels(), engineResponses...)
@@ -134,6 +140,7 @@ func (v *validationHandler) buildAuditResponses(
 ) ([]engineapi.EngineResponse, error) {
 	gvr := schema.GroupVersionResource(request.Resource)
 	policies := v.pCache.GetPolicies(policycache.ValidateAudit, gvr, request.SubResource, request.Namespace)
+	policies = webhookutils.SkipSameServiceAccountRequests(v.log, request.UserInfo.Username, policies...)
 	policyContext, err := v.pcBuilder.Build(request.AdmissionRequest, request.Roles, request.ClusterRoles, request.GroupVersionKind)
 	if err != nil {
 		return nil, err
@@ -177,11 +184,16 @@ func (v *validationHandler) handleAudit(
 	if resource.GetUID() == "" {
 		createReport = false
 	}
+	// audit rules run detached from the admission request, keep the object size guard
+	objectSizeExceeded := engineapi.ObjectSizeExceeded(ctx)
 	tracing.Span(
 		context.Background(),
 		"",
 		fmt.Sprintf("AUDIT %s %s", request.Operation, request.Kind),
 		func(ctx context.Context, span trace.Span) {
+			if objectSizeExceeded {
+				ctx = engineapi.WithObjectSizeExceeded(ctx)
+			}
 			responses, err := v.buildAuditResponses(ctx, resource, request, namespaceLabels)
 			if err != nil {
 				v.log.Error(err, "failed to build audit responses")
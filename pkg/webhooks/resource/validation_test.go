@@ -1065,6 +1065,9 @@ func TestValidate_failure_action_overrides(t *testing.T) {
 		factories.DefaultContextLoaderFactory(nil),
 		nil,
 		"",
+		nil,
+		nil,
+		nil,
 	)
 	for i, tc := range testcases {
 		t.Run(fmt.Sprintf("case %d", i), func(t *testing.T) {
@@ -1168,6 +1171,9 @@ func Test_RuleSelector(t *testing.T) {
 		factories.DefaultContextLoaderFactory(nil),
 		nil,
 		"",
+		nil,
+		nil,
+		nil,
 	)
 	resp := eng.Validate(
 		context.TODO(),
@@ -18,6 +18,7 @@ import (
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	runtimeutils "github.com/kyverno/kyverno/pkg/utils/runtime"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"golang.org/x/net/http2"
 	admissionv1 "k8s.io/api/admission/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +29,28 @@ import (
 type DebugModeOptions struct {
 	// DumpPayload is used to activate/deactivate debug mode.
 	DumpPayload bool
+	// Recorder records resource admission requests for replay, recording is disabled when nil.
+	Recorder handlers.Recorder
+}
+
+// ServerOptions holds the options to tune the webhook HTTP server
+type ServerOptions struct {
+	// ReadTimeout is the maximum duration for reading an entire request.
+	ReadTimeout time.Duration
+	// WriteTimeout is the maximum duration before timing out writes of a response.
+	WriteTimeout time.Duration
+	// ReadHeaderTimeout is the maximum duration for reading request headers.
+	ReadHeaderTimeout time.Duration
+	// IdleTimeout is the maximum duration to wait for the next request when keep-alives are enabled.
+	IdleTimeout time.Duration
+	// MaxConcurrentStreams is the maximum number of concurrent HTTP/2 streams per connection, the Go default is used when 0.
+	MaxConcurrentStreams uint32
+	// DisableHTTP2 restricts the server to HTTP/1.1.
+	DisableHTTP2 bool
+	// TLSMinVersion is the minimum TLS version accepted by the server.
+	TLSMinVersion uint16
+	// TLSCipherSuites are the cipher suites enabled for TLS 1.2, TLS 1.3 cipher suites are not configurable.
+	TLSCipherSuites []uint16
 }
 
 type Server interface {
@@ -75,6 +98,7 @@ func NewServer(
 	configuration config.Configuration,
 	metricsConfig metrics.MetricsConfigManager,
 	debugModeOpts DebugModeOptions,
+	serverOpts ServerOptions,
 	tlsProvider TlsProvider,
 	mwcClient controllerutils.DeleteCollectionClient,
 	vwcClient controllerutils.DeleteCollectionClient,
@@ -117,6 +141,7 @@ func NewServer(
 				WithFilter(configuration).
 				WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
 				WithDump(debugModeOpts.DumpPayload).
+				WithRecorder(debugModeOpts.Recorder).
 				WithTopLevelGVK(discovery).
 				WithRoles(rbLister, crbLister).
 				WithMetrics(resourceLogger, metricsConfig.Config(), metrics.WebhookValidating).
@@ -161,39 +186,34 @@ func NewServer(
 	)
 	mux.HandlerFunc("GET", config.LivenessServicePath, handlers.Probe(runtime.IsLive))
 	mux.HandlerFunc("GET", config.ReadinessServicePath, handlers.Probe(runtime.IsReady))
-	return &server{
-		server: &http.Server{
-			Addr: fmt.Sprintf(":%d", webhookServerPort),
-			TLSConfig: &tls.Config{
-				GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
-					certPem, keyPem, err := tlsProvider()
-					if err != nil {
-						return nil, err
-					}
-					pair, err := tls.X509KeyPair(certPem, keyPem)
-					if err != nil {
-						return nil, err
-					}
-					return &pair, nil
-				},
-				MinVersion: tls.VersionTLS12,
-				CipherSuites: []uint16{
-					// AEADs w/ ECDHE
-					tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
-					tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
-					tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
-					tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
-					tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
-					tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
-				},
+	mux.HandlerFunc("GET", config.VersionServicePath, handlers.Version())
+	srv := &http.Server{
+		Addr: fmt.Sprintf(":%d", webhookServerPort),
+		TLSConfig: &tls.Config{
+			GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+				certPem, keyPem, err := tlsProvider()
+				if err != nil {
+					return nil, err
+				}
+				pair, err := tls.X509KeyPair(certPem, keyPem)
+				if err != nil {
+					return nil, err
+				}
+				return &pair, nil
 			},
-			Handler:           mux,
-			ReadTimeout:       30 * time.Second,
-			WriteTimeout:      30 * time.Second,
-			ReadHeaderTimeout: 30 * time.Second,
-			IdleTimeout:       5 * time.Minute,
-			ErrorLog:          logging.StdLogger(logger.WithName("server"), ""),
+			MinVersion:   serverOpts.TLSMinVersion,
+			CipherSuites: serverOpts.TLSCipherSuites,
 		},
+		Handler:           mux,
+		ReadTimeout:       serverOpts.ReadTimeout,
+		WriteTimeout:      serverOpts.WriteTimeout,
+		ReadHeaderTimeout: serverOpts.ReadHeaderTimeout,
+		IdleTimeout:       serverOpts.IdleTimeout,
+		ErrorLog:          logging.StdLogger(logger.WithName("server"), ""),
+	}
+	configureHTTP2(srv, serverOpts)
+	return &server{
+		server:      srv,
 		mwcClient:   mwcClient,
 		vwcClient:   vwcClient,
 		leaseClient: leaseClient,
@@ -201,6 +221,22 @@ func NewServer(
 	}
 }
 
+// configureHTTP2 applies the HTTP/2 options to the server, the server falls back to HTTP/1.1
+// when HTTP/2 is disabled or can't be configured with the given TLS settings
+func configureHTTP2(srv *http.Server, serverOpts ServerOptions) {
+	if !serverOpts.DisableHTTP2 {
+		err := http2.ConfigureServer(srv, &http2.Server{
+			MaxConcurrentStreams: serverOpts.MaxConcurrentStreams,
+		})
+		if err == nil {
+			return
+		}
+		logger.Error(err, "failed to configure HTTP/2, falling back to HTTP/1.1")
+	}
+	srv.TLSConfig.NextProtos = nil
+	srv.TLSNextProto = map[string]func(*http.Server, *tls.Conn, http.Handler){}
+}
+
 func (s *server) Run(stopCh <-chan struct{}) {
 	go func() {
 		logger.V(3).Info("started serving requests", "addr", s.server.Addr)
@@ -1,10 +1,12 @@
 package utils
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
 	"gopkg.in/yaml.v2"
@@ -34,28 +36,71 @@ func BlockRequest(engineResponses []engineapi.EngineResponse, failurePolicy kyve
 	return false
 }
 
-// GetBlockedMessages gets the error messages for rules with error or fail status
-func GetBlockedMessages(engineResponses []engineapi.EngineResponse) string {
-	if len(engineResponses) == 0 {
-		return ""
+// withRemediation appends the remediation url of the rule (if any) to the rule message
+func withRemediation(message, url string, separator string) string {
+	if url == "" {
+		return message
 	}
-	failures := make(map[string]interface{})
+	return message + separator + "remediation: " + url
+}
+
+// BlockedRule is a rule failing an admission request
+type BlockedRule struct {
+	Policy         string `json:"policy"`
+	Rule           string `json:"rule"`
+	Message        string `json:"message"`
+	Path           string `json:"path,omitempty"`
+	RemediationURL string `json:"remediationUrl,omitempty"`
+}
+
+// blockedMessage is the JSON encoded denial message
+type blockedMessage struct {
+	Resource string        `json:"resource"`
+	Failures []BlockedRule `json:"failures"`
+}
+
+// GetBlockedRules returns the rules with error or fail status, in the order the policies and rules were evaluated
+func GetBlockedRules(engineResponses []engineapi.EngineResponse) []BlockedRule {
+	var blocked []BlockedRule
 	for _, er := range engineResponses {
-		ruleToReason := make(map[string]string)
 		for _, rule := range er.PolicyResponse.Rules {
 			if rule.Status() != engineapi.RuleStatusPass {
-				ruleToReason[rule.Name()] = rule.Message()
+				blocked = append(blocked, BlockedRule{
+					Policy:         er.Policy().GetName(),
+					Rule:           rule.Name(),
+					Message:        rule.Message(),
+					Path:           rule.Properties()[engineapi.PathProperty],
+					RemediationURL: rule.Properties()[engineapi.RemediationURLProperty],
+				})
 			}
 		}
-		if len(ruleToReason) != 0 {
-			failures[er.Policy().GetName()] = ruleToReason
-		}
 	}
-	if len(failures) == 0 {
+	return blocked
+}
+
+// GetBlockedMessages gets the error messages for rules with error or fail status, formatted
+// according to the denial message format (text by default)
+func GetBlockedMessages(engineResponses []engineapi.EngineResponse, format string) string {
+	if len(engineResponses) == 0 {
+		return ""
+	}
+	blocked := GetBlockedRules(engineResponses)
+	if len(blocked) == 0 {
 		return ""
 	}
 	r := engineResponses[0].Resource
 	resourceName := fmt.Sprintf("%s/%s/%s", r.GetKind(), r.GetNamespace(), r.GetName())
+	if format == config.DenialMessageFormatJSON {
+		results, _ := json.Marshal(blockedMessage{Resource: resourceName, Failures: blocked})
+		return string(results)
+	}
+	failures := make(map[string]map[string]string)
+	for _, rule := range blocked {
+		if failures[rule.Policy] == nil {
+			failures[rule.Policy] = map[string]string{}
+		}
+		failures[rule.Policy][rule.Rule] = withRemediation(rule.Message, rule.RemediationURL, "\n")
+	}
 	results, _ := yaml.Marshal(failures)
 	msg := fmt.Sprintf("\n\nresource %s was blocked due to the following policies \n\n%s", resourceName, results)
 	return msg
@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -193,6 +194,7 @@ func TestGetBlockedMessages(t *testing.T) {
 	}
 	type args struct {
 		engineResponses []engineapi.EngineResponse
+		format          string
 	}
 	tests := []struct {
 		name string
@@ -235,10 +237,54 @@ func TestGetBlockedMessages(t *testing.T) {
 			},
 		},
 		want: "\n\nresource foo/bar/baz was blocked due to the following policies \n\ntest:\n  rule-error: message error\n  rule-fail: message fail\n",
+	}, {
+		name: "failure with remediation - enforce",
+		args: args{
+			engineResponses: []engineapi.EngineResponse{
+				engineapi.NewEngineResponse(resource, enforcePolicy, nil).WithPolicyResponse(engineapi.PolicyResponse{
+					Rules: []engineapi.RuleResponse{
+						*engineapi.RuleFail("rule-fail", engineapi.Validation, "message fail").WithProperties(map[string]string{
+							engineapi.RemediationURLProperty: "https://example.com/remediation",
+						}),
+					},
+				}),
+			},
+		},
+		want: "\n\nresource foo/bar/baz was blocked due to the following policies \n\ntest:\n  rule-fail: |-\n    message fail\n    remediation: https://example.com/remediation\n",
+	}, {
+		name: "failures of several policies - json",
+		args: args{
+			engineResponses: []engineapi.EngineResponse{
+				engineapi.NewEngineResponse(resource, enforcePolicy, nil).WithPolicyResponse(engineapi.PolicyResponse{
+					Rules: []engineapi.RuleResponse{
+						*engineapi.RuleFail("rule-fail", engineapi.Validation, "message fail").WithProperties(map[string]string{
+							engineapi.PathProperty:           "/spec/containers/0/image/",
+							engineapi.RemediationURLProperty: "https://example.com/remediation",
+						}),
+						*engineapi.RulePass("rule-pass", engineapi.Validation, "message pass"),
+						*engineapi.RuleError("rule-error", engineapi.Validation, "message error", nil),
+					},
+				}),
+				engineapi.NewEngineResponse(resource, engineapi.NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+					ObjectMeta: v1.ObjectMeta{
+						Name: "another",
+					},
+				}), nil).WithPolicyResponse(engineapi.PolicyResponse{
+					Rules: []engineapi.RuleResponse{
+						*engineapi.RuleFail("rule", engineapi.Validation, "another message"),
+					},
+				}),
+			},
+			format: config.DenialMessageFormatJSON,
+		},
+		want: `{"resource":"foo/bar/baz","failures":[` +
+			`{"policy":"test","rule":"rule-fail","message":"message fail","path":"/spec/containers/0/image/","remediationUrl":"https://example.com/remediation"},` +
+			`{"policy":"test","rule":"rule-error","message":"message error"},` +
+			`{"policy":"another","rule":"rule","message":"another message"}]}`,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := GetBlockedMessages(tt.args.engineResponses)
+			got := GetBlockedMessages(tt.args.engineResponses, tt.args.format)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -21,9 +21,12 @@ func GenerateEvents(engineResponses []engineapi.EngineResponse, blocked bool) []
 		}
 		if !er.IsSuccessful() {
 			for _, ruleResp := range er.PolicyResponse.Rules {
-				if ruleResp.Status() == engineapi.RuleStatusFail || ruleResp.Status() == engineapi.RuleStatusError {
+				if ruleResp.Status() == engineapi.RuleStatusFail {
 					e := event.NewPolicyFailEvent(event.AdmissionController, event.PolicyViolation, er, ruleResp, blocked)
 					events = append(events, e)
+				} else if ruleResp.Status() == engineapi.RuleStatusError {
+					e := event.NewPolicyFailEvent(event.AdmissionController, event.PolicyError, er, ruleResp, blocked)
+					events = append(events, e)
 				}
 				if !blocked {
 					e := event.NewResourceViolationEvent(event.AdmissionController, event.PolicyViolation, er, ruleResp)
@@ -1,5 +1,11 @@
 package utils
 
+import (
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+)
+
 func ExcludeKyvernoResources(kind string) bool {
 	switch kind {
 	case "AdmissionReport":
@@ -16,3 +22,27 @@ func ExcludeKyvernoResources(kind string) bool {
 		return false
 	}
 }
+
+// SkipSameServiceAccountRequests removes the rules that skip requests sent by the Kyverno admission controller
+// service account when the request user is that service account, policies left without rules are dropped.
+func SkipSameServiceAccountRequests(logger logr.Logger, username string, policies ...kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
+	if username != config.KyvernoUserName(config.KyvernoServiceAccountName()) {
+		return policies
+	}
+	var results []kyvernov1.PolicyInterface
+	for _, policy := range policies {
+		policyNew := policy.CreateDeepCopy()
+		policyNew.GetSpec().Rules = nil
+		for _, rule := range policy.GetSpec().Rules {
+			if rule.GetSkipRequestsFromSameServiceAccount() {
+				logger.V(4).Info("skipping rule for request from the same service account", "policy", policy.GetName(), "rule", rule.Name, "username", username)
+				continue
+			}
+			policyNew.GetSpec().Rules = append(policyNew.GetSpec().Rules, *rule.DeepCopy())
+		}
+		if len(policyNew.GetSpec().Rules) != 0 {
+			results = append(results, policyNew)
+		}
+	}
+	return results
+}
@@ -3,6 +3,9 @@ package utils
 import (
 	"testing"
 
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -70,3 +73,60 @@ func TestExcludeKyvernoResources(t *testing.T) {
 		})
 	}
 }
+
+func TestSkipSameServiceAccountRequests(t *testing.T) {
+	skip, apply := true, false
+	policy := &kyvernov1.ClusterPolicy{
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{
+				Name:                               "skip",
+				SkipRequestsFromSameServiceAccount: &skip,
+			}, {
+				Name: "apply",
+			}, {
+				Name:                               "disabled",
+				SkipRequestsFromSameServiceAccount: &apply,
+			}},
+		},
+	}
+	skipOnly := &kyvernov1.ClusterPolicy{
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{
+				Name:                               "skip",
+				SkipRequestsFromSameServiceAccount: &skip,
+			}},
+		},
+	}
+	tests := []struct {
+		name     string
+		username string
+		want     [][]string
+	}{{
+		name:     "other user",
+		username: "system:serviceaccount:default:default",
+		want:     [][]string{{"skip", "apply", "disabled"}, {"skip"}},
+	}, {
+		name:     "background controller",
+		username: config.KyvernoUserName("kyverno-background-controller"),
+		want:     [][]string{{"skip", "apply", "disabled"}, {"skip"}},
+	}, {
+		name:     "admission controller",
+		username: config.KyvernoUserName(config.KyvernoServiceAccountName()),
+		want:     [][]string{{"apply", "disabled"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SkipSameServiceAccountRequests(logr.Discard(), tt.username, policy, skipOnly)
+			var names [][]string
+			for _, policy := range got {
+				var rules []string
+				for _, rule := range policy.GetSpec().Rules {
+					rules = append(rules, rule.Name)
+				}
+				names = append(names, rules)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+	assert.Len(t, policy.Spec.Rules, 3)
+}
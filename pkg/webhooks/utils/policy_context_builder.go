@@ -6,7 +6,10 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
 type PolicyContextBuilder interface {
@@ -16,15 +19,20 @@ type PolicyContextBuilder interface {
 type policyContextBuilder struct {
 	configuration config.Configuration
 	jp            jmespath.Interface
+	nsLister      corev1listers.NamespaceLister
 }
 
+// NewPolicyContextBuilder returns a builder for admission policy contexts, the namespace lister is used to
+// load the labels of the requesting service account namespace and can be nil
 func NewPolicyContextBuilder(
 	configuration config.Configuration,
 	jp jmespath.Interface,
+	nsLister corev1listers.NamespaceLister,
 ) PolicyContextBuilder {
 	return &policyContextBuilder{
 		configuration: configuration,
 		jp:            jp,
+		nsLister:      nsLister,
 	}
 }
 
@@ -34,5 +42,35 @@ func (b *policyContextBuilder) Build(request admissionv1.AdmissionRequest, roles
 		Roles:             roles,
 		ClusterRoles:      clusterRoles,
 	}
-	return engine.NewPolicyContextFromAdmissionRequest(b.jp, request, userRequestInfo, gvk, b.configuration)
+	policyContext, err := engine.NewPolicyContextFromAdmissionRequest(b.jp, request, userRequestInfo, gvk, b.configuration)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := b.serviceAccountNamespaceLabels(request.UserInfo.Username)
+	if err != nil {
+		return nil, err
+	}
+	if err := policyContext.JSONContext().AddServiceAccountNamespaceLabels(labels); err != nil {
+		return nil, err
+	}
+	return policyContext, nil
+}
+
+// serviceAccountNamespaceLabels returns the labels of the namespace of the requesting service account from the lister cache
+func (b *policyContextBuilder) serviceAccountNamespaceLabels(username string) (map[string]string, error) {
+	if b.nsLister == nil {
+		return nil, nil
+	}
+	namespace, _, err := serviceaccount.SplitUsername(username)
+	if err != nil {
+		return nil, nil
+	}
+	ns, err := b.nsLister.Get(namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ns.GetLabels(), nil
 }
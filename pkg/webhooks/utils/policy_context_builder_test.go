@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestPolicyContextBuilderServiceAccountNamespaceLabels(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, indexer.Add(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "platform",
+			Labels: map[string]string{"owner": "platform"},
+		},
+	}))
+	nsLister := corev1listers.NewNamespaceLister(indexer)
+	configuration := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(configuration)
+	request := func(username string) admissionv1.AdmissionRequest {
+		return admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+			Namespace: "tenant",
+			UserInfo:  authenticationv1.UserInfo{Username: username},
+			Object:    runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"foo","namespace":"tenant"}}`)},
+		}
+	}
+	gvk := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	tests := []struct {
+		name     string
+		nsLister corev1listers.NamespaceLister
+		username string
+		want     interface{}
+	}{{
+		name:     "service account",
+		nsLister: nsLister,
+		username: "system:serviceaccount:platform:deployer",
+		want:     map[string]interface{}{"owner": "platform"},
+	}, {
+		name:     "unknown namespace",
+		nsLister: nsLister,
+		username: "system:serviceaccount:tenant:deployer",
+		want:     map[string]interface{}{},
+	}, {
+		name:     "user",
+		nsLister: nsLister,
+		username: "alice",
+		want:     map[string]interface{}{},
+	}, {
+		name:     "no lister",
+		username: "system:serviceaccount:platform:deployer",
+		want:     map[string]interface{}{},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policyContext, err := NewPolicyContextBuilder(configuration, jp, tt.nsLister).Build(request(tt.username), nil, nil, gvk)
+			assert.NoError(t, err)
+			labels, err := policyContext.JSONContext().Query("serviceAccountNamespaceLabels")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, labels)
+		})
+	}
+}
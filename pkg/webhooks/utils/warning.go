@@ -11,7 +11,7 @@ func GetWarningMessages(engineResponses []engineapi.EngineResponse) []string {
 	for _, er := range engineResponses {
 		for _, rule := range er.PolicyResponse.Rules {
 			if rule.Status() != engineapi.RuleStatusPass && rule.Status() != engineapi.RuleStatusSkip {
-				msg := fmt.Sprintf("policy %s.%s: %s", er.Policy().GetName(), rule.Name(), rule.Message())
+				msg := fmt.Sprintf("policy %s.%s: %s", er.Policy().GetName(), rule.Name(), withRemediation(rule.Message(), rule.Properties()[engineapi.RemediationURLProperty], "; "))
 				warnings = append(warnings, msg)
 			}
 		}
@@ -67,6 +67,26 @@ func TestGetWarningMessages(t *testing.T) {
 			"policy test.rule-fail: message fail",
 			"policy test.rule-error: message error",
 		},
+	}, {
+		name: "remediation",
+		args: args{[]engineapi.EngineResponse{
+			engineapi.EngineResponse{
+				PolicyResponse: engineapi.PolicyResponse{
+					Rules: []engineapi.RuleResponse{
+						*engineapi.RuleFail("rule", engineapi.Validation, "message fail").WithProperties(map[string]string{
+							engineapi.RemediationURLProperty: "https://example.com/remediation",
+						}),
+					},
+				},
+			}.WithPolicy(engineapi.NewKyvernoPolicy(&v1.ClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+			})),
+		}},
+		want: []string{
+			"policy test.rule: message fail; remediation: https://example.com/remediation",
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {